@@ -0,0 +1,275 @@
+package goshopify
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const appSubscriptionFields = `
+      id
+      name
+      status
+      test
+      trialDays
+      createdAt
+      currentPeriodEnd
+      lineItems {
+        id
+        plan {
+          pricingDetails {
+            __typename
+          }
+        }
+      }`
+
+const currentAppInstallationQuery = `query currentAppInstallation {
+  currentAppInstallation {
+    id
+    launchUrl
+    uninstallUrl
+    accessScopes {
+      handle
+    }
+    app {
+      id
+      title
+      handle
+    }
+    activeSubscriptions {` + appSubscriptionFields + `
+    }
+    metafields(first: 250) {
+      nodes {
+        id
+        namespace
+        key
+        type
+        value
+      }
+    }
+  }
+}`
+
+const activeAppSubscriptionsQuery = `query activeAppSubscriptions {
+  currentAppInstallation {
+    activeSubscriptions {` + appSubscriptionFields + `
+    }
+  }
+}`
+
+const currentAppQuery = `query currentApp {
+  app {
+    id
+    title
+    handle
+  }
+}`
+
+// AppInstallationService is an interface for the installation of the app on
+// the shop and its billing status through the GraphQL API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/queries/currentAppInstallation
+type AppInstallationService interface {
+	Current(context.Context) (*AppInstallation, error)
+	ActiveSubscriptions(context.Context) ([]AppSubscription, error)
+	HasActiveSubscription(context.Context) (bool, error)
+	CurrentApp(context.Context) (*AppInfo, error)
+}
+
+// AppInstallationServiceOp handles communication with the app installation
+// through the GraphQL API
+type AppInstallationServiceOp struct {
+	client *Client
+}
+
+// AppInstallation is the installation of the app on the shop. Metafields are
+// its app-data metafields, see AppMetafieldService.
+type AppInstallation struct {
+	Id                  string
+	LaunchURL           string
+	UninstallURL        string
+	AccessScopes        []string
+	App                 *AppInfo
+	ActiveSubscriptions []AppSubscription
+	Metafields          []Metafield
+}
+
+// AppSubscriptionStatus is the status of an app subscription
+type AppSubscriptionStatus string
+
+const (
+	AppSubscriptionStatusActive    AppSubscriptionStatus = "active"
+	AppSubscriptionStatusPending   AppSubscriptionStatus = "pending"
+	AppSubscriptionStatusAccepted  AppSubscriptionStatus = "accepted"
+	AppSubscriptionStatusFrozen    AppSubscriptionStatus = "frozen"
+	AppSubscriptionStatusCancelled AppSubscriptionStatus = "cancelled"
+	AppSubscriptionStatusDeclined  AppSubscriptionStatus = "declined"
+	AppSubscriptionStatusExpired   AppSubscriptionStatus = "expired"
+)
+
+// AppSubscription is a recurring charge of the app, managed through the
+// GraphQL billing API
+type AppSubscription struct {
+	Id               string
+	Name             string
+	Status           AppSubscriptionStatus
+	Test             bool
+	TrialDays        int
+	CreatedAt        *time.Time
+	CurrentPeriodEnd *time.Time
+	LineItems        []AppSubscriptionLineItem
+}
+
+// AppSubscriptionLineItem is a line item of an app subscription. Usage is set
+// for usage pricing, whose line item id is used by
+// NewAppSubscriptionUsageMeter.
+type AppSubscriptionLineItem struct {
+	Id    string
+	Usage bool
+}
+
+// UsageLineItemId returns the id of the usage pricing line item of the
+// subscription, or "" if it has none
+func (s AppSubscription) UsageLineItemId() string {
+	for _, li := range s.LineItems {
+		if li.Usage {
+			return li.Id
+		}
+	}
+	return ""
+}
+
+type graphQLAppSubscription struct {
+	Id               string     `json:"id"`
+	Name             string     `json:"name"`
+	Status           string     `json:"status"`
+	Test             bool       `json:"test"`
+	TrialDays        int        `json:"trialDays"`
+	CreatedAt        *time.Time `json:"createdAt"`
+	CurrentPeriodEnd *time.Time `json:"currentPeriodEnd"`
+	LineItems        []struct {
+		Id   string `json:"id"`
+		Plan struct {
+			PricingDetails struct {
+				Typename string `json:"__typename"`
+			} `json:"pricingDetails"`
+		} `json:"plan"`
+	} `json:"lineItems"`
+}
+
+func (g graphQLAppSubscription) subscription() AppSubscription {
+	s := AppSubscription{
+		Id:               g.Id,
+		Name:             g.Name,
+		Status:           AppSubscriptionStatus(strings.ToLower(g.Status)),
+		Test:             g.Test,
+		TrialDays:        g.TrialDays,
+		CreatedAt:        g.CreatedAt,
+		CurrentPeriodEnd: g.CurrentPeriodEnd,
+	}
+	for _, li := range g.LineItems {
+		s.LineItems = append(s.LineItems, AppSubscriptionLineItem{
+			Id:    li.Id,
+			Usage: li.Plan.PricingDetails.Typename == "AppUsagePricing",
+		})
+	}
+	return s
+}
+
+func appSubscriptions(g []graphQLAppSubscription) []AppSubscription {
+	subscriptions := make([]AppSubscription, 0, len(g))
+	for _, s := range g {
+		subscriptions = append(subscriptions, s.subscription())
+	}
+	return subscriptions
+}
+
+// Current gets the installation of the app on the shop with its granted
+// scopes, active subscriptions and up to 250 app-data metafields
+func (s *AppInstallationServiceOp) Current(ctx context.Context) (*AppInstallation, error) {
+	resp := struct {
+		CurrentAppInstallation *struct {
+			Id           string `json:"id"`
+			LaunchUrl    string `json:"launchUrl"`
+			UninstallUrl string `json:"uninstallUrl"`
+			AccessScopes []struct {
+				Handle string `json:"handle"`
+			} `json:"accessScopes"`
+			App                 *graphQLAppInfo          `json:"app"`
+			ActiveSubscriptions []graphQLAppSubscription `json:"activeSubscriptions"`
+			Metafields          struct {
+				Nodes []graphQLMetafield `json:"nodes"`
+			} `json:"metafields"`
+		} `json:"currentAppInstallation"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, currentAppInstallationQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	g := resp.CurrentAppInstallation
+	if g == nil {
+		return nil, graphQLNotFound()
+	}
+
+	installation := &AppInstallation{
+		Id:                  g.Id,
+		LaunchURL:           g.LaunchUrl,
+		UninstallURL:        g.UninstallUrl,
+		ActiveSubscriptions: appSubscriptions(g.ActiveSubscriptions),
+	}
+	for _, scope := range g.AccessScopes {
+		installation.AccessScopes = append(installation.AccessScopes, scope.Handle)
+	}
+	if g.App != nil {
+		if installation.App, err = g.App.app(); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range g.Metafields.Nodes {
+		installation.Metafields = append(installation.Metafields, m.metafield())
+	}
+	return installation, nil
+}
+
+// ActiveSubscriptions lists the active subscriptions of the app on the shop
+func (s *AppInstallationServiceOp) ActiveSubscriptions(ctx context.Context) ([]AppSubscription, error) {
+	resp := struct {
+		CurrentAppInstallation struct {
+			ActiveSubscriptions []graphQLAppSubscription `json:"activeSubscriptions"`
+		} `json:"currentAppInstallation"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, activeAppSubscriptionsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return appSubscriptions(resp.CurrentAppInstallation.ActiveSubscriptions), nil
+}
+
+// HasActiveSubscription tells whether the shop pays for the app, i.e. has an
+// active subscription
+func (s *AppInstallationServiceOp) HasActiveSubscription(ctx context.Context) (bool, error) {
+	subscriptions, err := s.ActiveSubscriptions(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, sub := range subscriptions {
+		if sub.Status == AppSubscriptionStatusActive {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CurrentApp gets the app the client's token belongs to
+func (s *AppInstallationServiceOp) CurrentApp(ctx context.Context) (*AppInfo, error) {
+	resp := struct {
+		App *graphQLAppInfo `json:"app"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, currentAppQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.App == nil {
+		return nil, graphQLNotFound()
+	}
+	return resp.App.app()
+}
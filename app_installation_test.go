@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+const appSubscriptionsJSON = `[
+	{"id":"gid://shopify/AppSubscription/1","name":"Pro","status":"ACTIVE","test":false,"trialDays":7,
+		"createdAt":"2024-01-01T00:00:00Z","currentPeriodEnd":"2024-02-01T00:00:00Z",
+		"lineItems":[
+			{"id":"gid://shopify/AppSubscriptionLineItem/1?v=1&index=0","plan":{"pricingDetails":{"__typename":"AppRecurringPricing"}}},
+			{"id":"gid://shopify/AppSubscriptionLineItem/1?v=1&index=1","plan":{"pricingDetails":{"__typename":"AppUsagePricing"}}}
+		]}
+]`
+
+func TestAppInstallationCurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		currentAppInstallationQuery: `{"data":{"currentAppInstallation":{
+			"id":"gid://shopify/AppInstallation/3",
+			"launchUrl":"https://fooshop.myshopify.com/admin/apps/my-app",
+			"uninstallUrl":"https://fooshop.myshopify.com/admin/settings/apps",
+			"accessScopes":[{"handle":"read_orders"},{"handle":"write_products"}],
+			"app":{"id":"gid://shopify/App/42","title":"My App","handle":"my-app"},
+			"activeSubscriptions":` + appSubscriptionsJSON + `,
+			"metafields":{"nodes":[{"id":"gid://shopify/Metafield/9","namespace":"settings","key":"plan","type":"single_line_text_field","value":"pro"}]}
+		}}}`,
+	}))
+
+	installation, err := client.AppInstallation.Current(context.Background())
+	if err != nil {
+		t.Fatalf("AppInstallation.Current returned error: %v", err)
+	}
+	if installation.Id != "gid://shopify/AppInstallation/3" || !reflect.DeepEqual(installation.AccessScopes, []string{"read_orders", "write_products"}) {
+		t.Errorf("AppInstallation.Current returned %+v", installation)
+	}
+	if !reflect.DeepEqual(installation.App, &AppInfo{Id: 42, Title: "My App", Handle: "my-app"}) {
+		t.Errorf("AppInstallation.Current returned app %+v", installation.App)
+	}
+	if len(installation.Metafields) != 1 || installation.Metafields[0].Key != "plan" || installation.Metafields[0].Value != "pro" {
+		t.Errorf("AppInstallation.Current returned metafields %+v", installation.Metafields)
+	}
+
+	if len(installation.ActiveSubscriptions) != 1 {
+		t.Fatalf("AppInstallation.Current returned %d subscriptions, expected 1", len(installation.ActiveSubscriptions))
+	}
+	sub := installation.ActiveSubscriptions[0]
+	if sub.Name != "Pro" || sub.Status != AppSubscriptionStatusActive || sub.TrialDays != 7 || sub.CurrentPeriodEnd == nil {
+		t.Errorf("AppInstallation.Current returned subscription %+v", sub)
+	}
+	if id := sub.UsageLineItemId(); id != "gid://shopify/AppSubscriptionLineItem/1?v=1&index=1" {
+		t.Errorf("AppSubscription.UsageLineItemId returned %s", id)
+	}
+}
+
+func TestAppInstallationHasActiveSubscription(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		activeAppSubscriptionsQuery: `{"data":{"currentAppInstallation":{"activeSubscriptions":` + appSubscriptionsJSON + `}}}`,
+	}))
+
+	active, err := client.AppInstallation.HasActiveSubscription(context.Background())
+	if err != nil || !active {
+		t.Errorf("AppInstallation.HasActiveSubscription returned %v, %v, expected true", active, err)
+	}
+}
+
+func TestAppInstallationNoActiveSubscription(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		activeAppSubscriptionsQuery: `{"data":{"currentAppInstallation":{"activeSubscriptions":[]}}}`,
+	}))
+
+	active, err := client.AppInstallation.HasActiveSubscription(context.Background())
+	if err != nil || active {
+		t.Errorf("AppInstallation.HasActiveSubscription returned %v, %v, expected false", active, err)
+	}
+}
+
+func TestAppInstallationCurrentApp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		currentAppQuery: `{"data":{"app":{"id":"gid://shopify/App/42","title":"My App","handle":"my-app"}}}`,
+	}))
+
+	app, err := client.AppInstallation.CurrentApp(context.Background())
+	if err != nil {
+		t.Fatalf("AppInstallation.CurrentApp returned error: %v", err)
+	}
+	if !reflect.DeepEqual(app, &AppInfo{Id: 42, Title: "My App", Handle: "my-app"}) {
+		t.Errorf("AppInstallation.CurrentApp returned %+v", app)
+	}
+}
@@ -0,0 +1,257 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const currentAppInstallationIdQuery = `query currentAppInstallationId {
+  currentAppInstallation {
+    id
+  }
+}`
+
+const shopIdQuery = `query shopId {
+  shop {
+    id
+  }
+}`
+
+const appMetafieldsQuery = `query appMetafields($namespace: String, $after: String) {
+  currentAppInstallation {
+    metafields(first: 250, namespace: $namespace, after: $after) {
+      nodes {
+        id
+        namespace
+        key
+        type
+        value
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+const appMetafieldQuery = `query appMetafield($namespace: String!, $key: String!) {
+  currentAppInstallation {
+    metafield(namespace: $namespace, key: $key) {
+      id
+      namespace
+      key
+      type
+      value
+    }
+  }
+}`
+
+const metafieldsSetMutation = `mutation metafieldsSet($metafields: [MetafieldsSetInput!]!) {
+  metafieldsSet(metafields: $metafields) {
+    metafields {
+      id
+      namespace
+      key
+      type
+      value
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const metafieldsDeleteMutation = `mutation metafieldsDelete($metafields: [MetafieldIdentifierInput!]!) {
+  metafieldsDelete(metafields: $metafields) {
+    deletedMetafields {
+      key
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// AppMetafieldService is an interface for the app-data metafields of the
+// current app installation. They are owned by the installation, so only the
+// app itself can read them, which makes them a private per-shop settings
+// store.
+// See: https://shopify.dev/docs/apps/build/custom-data/ownership
+type AppMetafieldService interface {
+	InstallationId(context.Context) (string, error)
+	List(context.Context, string) ([]Metafield, error)
+	Get(context.Context, string, string) (*Metafield, error)
+	Set(context.Context, ...Metafield) ([]Metafield, error)
+	Delete(context.Context, string, string) error
+}
+
+// AppMetafieldServiceOp handles communication with the app-data metafields
+// through the GraphQL API
+type AppMetafieldServiceOp struct {
+	client *Client
+
+	mu             sync.Mutex
+	installationId string
+}
+
+// InstallationId returns the GraphQL id of the current app installation, the
+// owner of app-data metafields
+func (s *AppMetafieldServiceOp) InstallationId(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.installationId != "" {
+		return s.installationId, nil
+	}
+
+	resp := struct {
+		CurrentAppInstallation struct {
+			Id string `json:"id"`
+		} `json:"currentAppInstallation"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, currentAppInstallationIdQuery, nil, &resp)
+	if err != nil {
+		return "", err
+	}
+	s.installationId = resp.CurrentAppInstallation.Id
+	return s.installationId, nil
+}
+
+// List lists the app-data metafields, of a namespace if given
+func (s *AppMetafieldServiceOp) List(ctx context.Context, namespace string) ([]Metafield, error) {
+	vars := map[string]interface{}{}
+	if namespace != "" {
+		vars["namespace"] = namespace
+	}
+
+	var metafields []Metafield
+	err := s.client.GraphQLEachNode(ctx, appMetafieldsQuery, vars, "currentAppInstallation.metafields", func(node json.RawMessage) error {
+		var m graphQLMetafield
+		if err := json.Unmarshal(node, &m); err != nil {
+			return err
+		}
+		metafields = append(metafields, m.metafield())
+		return nil
+	})
+	return metafields, err
+}
+
+// Get gets an app-data metafield by namespace and key
+func (s *AppMetafieldServiceOp) Get(ctx context.Context, namespace string, key string) (*Metafield, error) {
+	resp := struct {
+		CurrentAppInstallation struct {
+			Metafield *graphQLMetafield `json:"metafield"`
+		} `json:"currentAppInstallation"`
+	}{}
+	vars := map[string]interface{}{"namespace": namespace, "key": key}
+	err := s.client.GraphQL.Query(ctx, appMetafieldQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.CurrentAppInstallation.Metafield == nil {
+		return nil, graphQLNotFound()
+	}
+	metafield := resp.CurrentAppInstallation.Metafield.metafield()
+	return &metafield, nil
+}
+
+// Set creates or updates app-data metafields by namespace and key
+func (s *AppMetafieldServiceOp) Set(ctx context.Context, metafields ...Metafield) ([]Metafield, error) {
+	ownerId, err := s.InstallationId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return setMetafields(ctx, s.client, ownerId, metafields)
+}
+
+// Delete deletes an app-data metafield by namespace and key, deleting a
+// metafield that doesn't exist is not an error
+func (s *AppMetafieldServiceOp) Delete(ctx context.Context, namespace string, key string) error {
+	ownerId, err := s.InstallationId(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp := struct {
+		MetafieldsDelete struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"metafieldsDelete"`
+	}{}
+	vars := map[string]interface{}{
+		"metafields": []interface{}{
+			map[string]interface{}{"ownerId": ownerId, "namespace": namespace, "key": key},
+		},
+	}
+	err = s.client.GraphQL.Query(ctx, metafieldsDeleteMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.MetafieldsDelete.UserErrors) > 0 {
+		return resp.MetafieldsDelete.UserErrors
+	}
+	return nil
+}
+
+// SetMetafields creates or updates metafields of the shop by namespace and
+// key through the GraphQL metafieldsSet mutation
+func (s *ShopServiceOp) SetMetafields(ctx context.Context, metafields ...Metafield) ([]Metafield, error) {
+	resp := struct {
+		Shop struct {
+			Id string `json:"id"`
+		} `json:"shop"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, shopIdQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return setMetafields(ctx, s.client, resp.Shop.Id, metafields)
+}
+
+// setMetafields sets metafields of an owner, at most 25 per request as
+// metafieldsSet allows
+func setMetafields(ctx context.Context, client *Client, ownerId string, metafields []Metafield) ([]Metafield, error) {
+	var set []Metafield
+	for start := 0; start < len(metafields); start += 25 {
+		end := start + 25
+		if end > len(metafields) {
+			end = len(metafields)
+		}
+
+		inputs := make([]map[string]interface{}, 0, end-start)
+		for _, metafield := range metafields[start:end] {
+			if metafield.Namespace == "" || metafield.Key == "" || metafield.Type == "" {
+				return set, fmt.Errorf("metafield %s.%s: namespace, key and type are required", metafield.Namespace, metafield.Key)
+			}
+			input, err := metafieldInput(metafield)
+			if err != nil {
+				return set, err
+			}
+			delete(input, "id")
+			input["ownerId"] = ownerId
+			inputs = append(inputs, input)
+		}
+
+		resp := struct {
+			MetafieldsSet struct {
+				Metafields []graphQLMetafield `json:"metafields"`
+				UserErrors GraphQLUserErrors  `json:"userErrors"`
+			} `json:"metafieldsSet"`
+		}{}
+		err := client.GraphQL.Query(ctx, metafieldsSetMutation, map[string]interface{}{"metafields": inputs}, &resp)
+		if err != nil {
+			return set, err
+		}
+		if len(resp.MetafieldsSet.UserErrors) > 0 {
+			return set, resp.MetafieldsSet.UserErrors
+		}
+		for _, m := range resp.MetafieldsSet.Metafields {
+			set = append(set, m.metafield())
+		}
+	}
+	return set, nil
+}
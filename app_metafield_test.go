@@ -0,0 +1,116 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAppMetafieldSet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		currentAppInstallationIdQuery: `{"data":{"currentAppInstallation":{"id":"gid://shopify/AppInstallation/3"}}}`,
+		metafieldsSetMutation: `{"data":{"metafieldsSet":{"metafields":[
+			{"id":"gid://shopify/Metafield/9","namespace":"settings","key":"config","type":"json","value":"{\"theme\":\"dark\"}"}
+		],"userErrors":[]}}}`,
+	}))
+
+	metafields, err := client.AppMetafield.Set(context.Background(), Metafield{
+		Namespace: "settings",
+		Key:       "config",
+		Type:      MetafieldTypeJSON,
+		Value:     map[string]string{"theme": "dark"},
+	})
+	if err != nil {
+		t.Fatalf("AppMetafield.Set returned error: %v", err)
+	}
+	if len(metafields) != 1 || metafields[0].Id != 9 || metafields[0].Value != `{"theme":"dark"}` {
+		t.Errorf("AppMetafield.Set returned %+v", metafields)
+	}
+
+	expected := map[string]interface{}{"metafields": []interface{}{map[string]interface{}{
+		"ownerId":   "gid://shopify/AppInstallation/3",
+		"namespace": "settings",
+		"key":       "config",
+		"type":      "json",
+		"value":     `{"theme":"dark"}`,
+	}}}
+	if len(sent) != 2 || !reflect.DeepEqual(sent[1].Variables, expected) {
+		t.Fatalf("AppMetafield.Set sent %+v, expected variables %v", sent, expected)
+	}
+
+	// the installation id is cached
+	if _, err := client.AppMetafield.Set(context.Background(), Metafield{Namespace: "settings", Key: "plan", Type: MetafieldTypeSingleLineTextField, Value: "pro"}); err != nil {
+		t.Fatalf("AppMetafield.Set returned error: %v", err)
+	}
+	if len(sent) != 3 {
+		t.Errorf("AppMetafield.Set sent %d requests, expected the installation id to be cached", len(sent))
+	}
+
+	if _, err := client.AppMetafield.Set(context.Background(), Metafield{Namespace: "settings", Key: "plan"}); err == nil {
+		t.Error("AppMetafield.Set accepted a metafield without type")
+	}
+}
+
+func TestAppMetafieldListGetDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		appMetafieldsQuery: `{"data":{"currentAppInstallation":{"metafields":{
+			"nodes":[{"id":"gid://shopify/Metafield/9","namespace":"settings","key":"plan","type":"single_line_text_field","value":"pro"}],
+			"pageInfo":{"hasNextPage":false}}}}}`,
+		appMetafieldQuery:             `{"data":{"currentAppInstallation":{"metafield":null}}}`,
+		currentAppInstallationIdQuery: `{"data":{"currentAppInstallation":{"id":"gid://shopify/AppInstallation/3"}}}`,
+		metafieldsDeleteMutation:      `{"data":{"metafieldsDelete":{"deletedMetafields":[null],"userErrors":[]}}}`,
+	}))
+
+	metafields, err := client.AppMetafield.List(context.Background(), "settings")
+	if err != nil {
+		t.Fatalf("AppMetafield.List returned error: %v", err)
+	}
+	expected := []Metafield{{Id: 9, Namespace: "settings", Key: "plan", Type: MetafieldTypeSingleLineTextField, Value: "pro", AdminGraphqlApiId: "gid://shopify/Metafield/9"}}
+	if !reflect.DeepEqual(metafields, expected) {
+		t.Errorf("AppMetafield.List returned %+v, expected %+v", metafields, expected)
+	}
+	if sent[0].Variables["namespace"] != "settings" {
+		t.Errorf("AppMetafield.List sent variables %v", sent[0].Variables)
+	}
+
+	if _, err := client.AppMetafield.Get(context.Background(), "settings", "missing"); !isNotFound(err) {
+		t.Errorf("AppMetafield.Get returned %v, expected not found", err)
+	}
+
+	if err := client.AppMetafield.Delete(context.Background(), "settings", "plan"); err != nil {
+		t.Fatalf("AppMetafield.Delete returned error: %v", err)
+	}
+	expectedVars := map[string]interface{}{"metafields": []interface{}{map[string]interface{}{
+		"ownerId": "gid://shopify/AppInstallation/3", "namespace": "settings", "key": "plan",
+	}}}
+	if last := sent[len(sent)-1]; !reflect.DeepEqual(last.Variables, expectedVars) {
+		t.Errorf("AppMetafield.Delete sent variables %v, expected %v", last.Variables, expectedVars)
+	}
+}
+
+func TestShopSetMetafields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		shopIdQuery:           `{"data":{"shop":{"id":"gid://shopify/Shop/1"}}}`,
+		metafieldsSetMutation: `{"data":{"metafieldsSet":{"metafields":[],"userErrors":[{"field":["metafields","0","value"],"message":"Value is invalid"}]}}}`,
+	}))
+
+	_, err := client.Shop.SetMetafields(context.Background(), Metafield{Namespace: "store", Key: "opened", Type: MetafieldTypeDate, Value: "yesterday"})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("Shop.SetMetafields returned %v, expected user errors", err)
+	}
+	if len(sent) != 2 || sent[1].Variables["metafields"].([]interface{})[0].(map[string]interface{})["ownerId"] != "gid://shopify/Shop/1" {
+		t.Errorf("Shop.SetMetafields sent %+v", sent)
+	}
+}
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -18,6 +19,8 @@ type ApplicationChargeService interface {
 	Get(context.Context, uint64, interface{}) (*ApplicationCharge, error)
 	List(context.Context, interface{}) ([]ApplicationCharge, error)
 	Activate(context.Context, ApplicationCharge) (*ApplicationCharge, error)
+	Confirm(context.Context, uint64) (*ApplicationCharge, error)
+	ConfirmReturn(context.Context, *url.URL) (*ApplicationCharge, error)
 }
 
 type ApplicationChargeServiceOp struct {
@@ -53,6 +56,9 @@ type ApplicationChargesResource struct {
 
 // Create creates new application charge.
 func (a ApplicationChargeServiceOp) Create(ctx context.Context, charge ApplicationCharge) (*ApplicationCharge, error) {
+	if err := ValidateApplicationChargeReturnURL(charge.ReturnURL); err != nil {
+		return nil, err
+	}
 	path := fmt.Sprintf("%s.json", applicationChargesBasePath)
 	resource := &ApplicationChargeResource{}
 	return resource.Charge, a.client.Post(ctx, path, ApplicationChargeResource{Charge: &charge}, resource)
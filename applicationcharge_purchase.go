@@ -0,0 +1,103 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Statuses of application charges
+const (
+	ApplicationChargeStatusPending  = "pending"
+	ApplicationChargeStatusAccepted = "accepted"
+	ApplicationChargeStatusActive   = "active"
+	ApplicationChargeStatusDeclined = "declined"
+	ApplicationChargeStatusExpired  = "expired"
+)
+
+// ApplicationChargeStatusError is returned when confirming a charge the
+// merchant didn't approve, e.g. declined it or let it expire
+type ApplicationChargeStatusError struct {
+	ChargeId uint64
+	Status   string
+}
+
+func (e ApplicationChargeStatusError) Error() string {
+	return fmt.Sprintf("application charge %d is %s", e.ChargeId, e.Status)
+}
+
+// IsActive reports whether the merchant approved and paid for the charge
+func (c ApplicationCharge) IsActive() bool {
+	return c.Status == ApplicationChargeStatusActive
+}
+
+// ValidateApplicationChargeReturnURL checks that a return URL is an absolute
+// http or https URL, Shopify redirects the merchant to it with the charge_id
+// after approving or declining a charge
+func ValidateApplicationChargeReturnURL(returnURL string) error {
+	if returnURL == "" {
+		return errors.New("return url is required")
+	}
+	u, err := url.Parse(returnURL)
+	if err != nil {
+		return fmt.Errorf("invalid return url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("return url %q must be an http or https url", returnURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("return url %q has no host", returnURL)
+	}
+	return nil
+}
+
+// ApplicationChargeIdFromURL returns the charge_id Shopify adds to the return
+// URL of a charge, e.g. the URL of the request to the return URL handler
+func ApplicationChargeIdFromURL(u *url.URL) (uint64, error) {
+	if u == nil {
+		return 0, errors.New("charge_id is missing")
+	}
+	value := u.Query().Get("charge_id")
+	if value == "" {
+		return 0, errors.New("charge_id is missing")
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid charge_id %q", value)
+	}
+	return id, nil
+}
+
+// Confirm fetches a charge the merchant returned from and makes sure it is
+// active before features are granted. Accepted charges are activated, which
+// older API versions require. Charges in other states return an
+// ApplicationChargeStatusError.
+func (a ApplicationChargeServiceOp) Confirm(ctx context.Context, chargeId uint64) (*ApplicationCharge, error) {
+	charge, err := a.Get(ctx, chargeId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if charge.Status == ApplicationChargeStatusAccepted {
+		charge, err = a.Activate(ctx, *charge)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !charge.IsActive() {
+		return charge, ApplicationChargeStatusError{ChargeId: chargeId, Status: charge.Status}
+	}
+	return charge, nil
+}
+
+// ConfirmReturn confirms the charge of a request to the return URL, see
+// ApplicationChargeIdFromURL and Confirm
+func (a ApplicationChargeServiceOp) ConfirmReturn(ctx context.Context, returnURL *url.URL) (*ApplicationCharge, error) {
+	chargeId, err := ApplicationChargeIdFromURL(returnURL)
+	if err != nil {
+		return nil, err
+	}
+	return a.Confirm(ctx, chargeId)
+}
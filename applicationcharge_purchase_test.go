@@ -0,0 +1,127 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestValidateApplicationChargeReturnURL(t *testing.T) {
+	cases := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://app.example.com/charges/return", true},
+		{"http://localhost:8080/return?shop=fooshop", true},
+		{"", false},
+		{"/charges/return", false},
+		{"ftp://app.example.com/return", false},
+		{"https://", false},
+		{"https://app.example.com/%zz", false},
+	}
+
+	for _, c := range cases {
+		err := ValidateApplicationChargeReturnURL(c.url)
+		if c.valid && err != nil {
+			t.Errorf("ValidateApplicationChargeReturnURL(%q) returned error: %v", c.url, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("ValidateApplicationChargeReturnURL(%q) accepted an invalid url", c.url)
+		}
+	}
+}
+
+func TestApplicationChargeCreateInvalidReturnURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.ApplicationCharge.Create(context.Background(), ApplicationCharge{Name: "Upsell", ReturnURL: "/return"})
+	if err == nil {
+		t.Error("ApplicationCharge.Create accepted a relative return url")
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 0 {
+		t.Errorf("ApplicationCharge.Create made %d requests with an invalid return url", calls)
+	}
+}
+
+func TestApplicationChargeIdFromURL(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected uint64
+	}{
+		{"https://app.example.com/return?charge_id=1017262355&shop=fooshop", 1017262355},
+		{"https://app.example.com/return", 0},
+		{"https://app.example.com/return?charge_id=abc", 0},
+		{"https://app.example.com/return?charge_id=0", 0},
+	}
+
+	for _, c := range cases {
+		u, _ := url.Parse(c.url)
+		id, err := ApplicationChargeIdFromURL(u)
+		if id != c.expected || (c.expected == 0) != (err != nil) {
+			t.Errorf("ApplicationChargeIdFromURL(%q) returned %d, %v, expected %d", c.url, id, err, c.expected)
+		}
+	}
+	if _, err := ApplicationChargeIdFromURL(nil); err == nil {
+		t.Error("ApplicationChargeIdFromURL(nil) returned no error")
+	}
+}
+
+func TestApplicationChargeConfirm(t *testing.T) {
+	setup()
+	defer teardown()
+
+	chargeURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/application_charges", client.pathPrefix)
+	httpmock.RegisterResponder("GET", chargeURL+"/1.json",
+		httpmock.NewStringResponder(200, `{"application_charge":{"id":1,"status":"active"}}`))
+	httpmock.RegisterResponder("GET", chargeURL+"/2.json",
+		httpmock.NewStringResponder(200, `{"application_charge":{"id":2,"status":"accepted"}}`))
+	httpmock.RegisterResponder("POST", chargeURL+"/2/activate.json",
+		httpmock.NewStringResponder(200, `{"application_charge":{"id":2,"status":"active"}}`))
+	httpmock.RegisterResponder("GET", chargeURL+"/3.json",
+		httpmock.NewStringResponder(200, `{"application_charge":{"id":3,"status":"declined"}}`))
+
+	for _, id := range []uint64{1, 2} {
+		charge, err := client.ApplicationCharge.Confirm(context.Background(), id)
+		if err != nil {
+			t.Fatalf("ApplicationCharge.Confirm(%d) returned error: %v", id, err)
+		}
+		if !charge.IsActive() {
+			t.Errorf("ApplicationCharge.Confirm(%d) returned %+v", id, charge)
+		}
+	}
+	if calls := httpmock.GetCallCountInfo()["POST "+chargeURL+"/2/activate.json"]; calls != 1 {
+		t.Errorf("ApplicationCharge.Confirm activated the accepted charge %d times", calls)
+	}
+
+	charge, err := client.ApplicationCharge.Confirm(context.Background(), 3)
+	statusErr, ok := err.(ApplicationChargeStatusError)
+	if !ok || statusErr.Status != ApplicationChargeStatusDeclined || statusErr.ChargeId != 3 {
+		t.Errorf("ApplicationCharge.Confirm returned %v, expected a declined status error", err)
+	}
+	if charge == nil || charge.Id != 3 {
+		t.Errorf("ApplicationCharge.Confirm returned %+v, expected the declined charge", charge)
+	}
+}
+
+func TestApplicationChargeConfirmReturn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/application_charges/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"application_charge":{"id":1,"status":"active"}}`))
+
+	u, _ := url.Parse("https://app.example.com/return?charge_id=1")
+	charge, err := client.ApplicationCharge.ConfirmReturn(context.Background(), u)
+	if err != nil || charge.Id != 1 {
+		t.Errorf("ApplicationCharge.ConfirmReturn returned %+v, %v", charge, err)
+	}
+
+	u, _ = url.Parse("https://app.example.com/return")
+	if _, err := client.ApplicationCharge.ConfirmReturn(context.Background(), u); err == nil {
+		t.Error("ApplicationCharge.ConfirmReturn accepted a url without charge_id")
+	}
+}
@@ -16,6 +16,21 @@ type AssignedFulfillmentOrderService interface {
 	Get(context.Context, interface{}) ([]AssignedFulfillmentOrder, error)
 }
 
+// AssignmentStatus filters assigned fulfillment orders by the state of the
+// request made to the fulfillment service.
+type AssignmentStatus string
+
+const (
+	// Fulfillment orders the merchant requested to be fulfilled
+	AssignmentStatusFulfillmentRequested AssignmentStatus = "fulfillment_requested"
+
+	// Fulfillment orders accepted by the fulfillment service
+	AssignmentStatusFulfillmentAccepted AssignmentStatus = "fulfillment_accepted"
+
+	// Fulfillment orders the merchant requested to be cancelled
+	AssignmentStatusCancellationRequested AssignmentStatus = "cancellation_requested"
+)
+
 type AssignedFulfillmentOrder struct {
 	Id                 uint64                              `json:"id,omitempty"`
 	AssignedLocationId uint64                              `json:"assigned_location_id,omitempty"`
@@ -60,9 +75,11 @@ type AssignedFulfillmentOrdersResource struct {
 	AssignedFulfillmentOrders []AssignedFulfillmentOrder `json:"fulfillment_orders,omitempty"`
 }
 
+// AssignedFulfillmentOrderOptions filters the assigned fulfillment orders,
+// LocationIds is a comma separated list of location ids.
 type AssignedFulfillmentOrderOptions struct {
-	AssignmentStatus string `url:"assignment_status,omitempty"`
-	LocationIds      string `url:"location_ids,omitempty"`
+	AssignmentStatus AssignmentStatus `url:"assignment_status,omitempty"`
+	LocationIds      string           `url:"location_ids,omitempty"`
 }
 
 // AssignedFulfillmentOrderServiceOp handles communication with the AssignedFulfillmentOrderService
@@ -71,7 +88,9 @@ type AssignedFulfillmentOrderServiceOp struct {
 	client *Client
 }
 
-// Gets a list of all the fulfillment orders that are assigned to an app at the shop level
+// Gets a list of all the fulfillment orders that are assigned to an app at the shop level.
+// Filter them with AssignedFulfillmentOrderOptions, e.g. by AssignmentStatus to poll the
+// fulfillment service's work queue.
 func (s *AssignedFulfillmentOrderServiceOp) Get(ctx context.Context, options interface{}) ([]AssignedFulfillmentOrder, error) {
 	path := fmt.Sprintf("%s.json", assignedFulfillmentOrderBasePath)
 	resource := new(AssignedFulfillmentOrdersResource)
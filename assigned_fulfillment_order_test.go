@@ -37,6 +37,30 @@ func TestAssignedFulfillmentOrderGet(t *testing.T) {
 	}
 }
 
+func TestAssignedFulfillmentOrderGetFiltered(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"assignment_status": "cancellation_requested", "location_ids": "1,2"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/assigned_fulfillment_orders.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"fulfillment_orders": [{"id":1,"request_status":"cancellation_requested"}]}`))
+
+	options := AssignedFulfillmentOrderOptions{
+		AssignmentStatus: AssignmentStatusCancellationRequested,
+		LocationIds:      "1,2",
+	}
+	assignedFulfillmentOrders, err := client.AssignedFulfillmentOrder.Get(context.Background(), options)
+	if err != nil {
+		t.Errorf("AssignedFulfillmentOrder.Get returned error: %v", err)
+	}
+
+	expected := []AssignedFulfillmentOrder{{Id: 1, RequestStatus: "cancellation_requested"}}
+	if !reflect.DeepEqual(assignedFulfillmentOrders, expected) {
+		t.Errorf("AssignedFulfillmentOrder.Get returned %+v, expected %+v", assignedFulfillmentOrders, expected)
+	}
+}
+
 // func TestFulfillmentOrderGet(t *testing.T) {
 // 	setup()
 // 	defer teardown()
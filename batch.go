@@ -0,0 +1,112 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// number of concurrent requests made by GetMany
+	batchConcurrency = 4
+
+	// number of times GetMany waits out a rate limit for a single id
+	batchRateLimitRetries = 5
+)
+
+// BatchGetFunc fetches a single resource by id
+type BatchGetFunc func(ctx context.Context, id uint64) (interface{}, error)
+
+// BatchErrors holds the errors of a batch, keyed by resource id. See
+// NewBatchResult to tell the retryable ones apart.
+type BatchErrors map[uint64]error
+
+func (e BatchErrors) Error() string {
+	ids := make([]uint64, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%d: %s", id, e[id]))
+	}
+	return fmt.Sprintf("%d of the batch failed: %s", len(e), strings.Join(msgs, ", "))
+}
+
+// GetMany calls get for every id concurrently and returns the fetched
+// resources keyed by id. Requests are tagged PriorityBatch and rate limit
+// errors are waited out before retrying the id, so a batch does not starve
+// other requests when used together with WithPriorityQueue.
+// If any id failed the returned error is a BatchErrors, the resources of the
+// other ids are returned regardless.
+func (c *Client) GetMany(ctx context.Context, ids []uint64, get BatchGetFunc) (map[uint64]interface{}, error) {
+	ctx = WithRequestPriority(ctx, PriorityBatch)
+
+	var mu sync.Mutex
+	results := make(map[uint64]interface{}, len(ids))
+	errs := BatchErrors{}
+
+	work := make(chan uint64)
+	var wg sync.WaitGroup
+	for i := 0; i < batchConcurrency && i < len(ids); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				v, err := c.batchGet(ctx, id, get)
+
+				mu.Lock()
+				if err != nil {
+					errs[id] = err
+				} else {
+					results[id] = v
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, id := range ids {
+		work <- id
+	}
+	close(work)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+	return results, nil
+}
+
+func (c *Client) batchGet(ctx context.Context, id uint64, get BatchGetFunc) (interface{}, error) {
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		v, err := get(ctx, id)
+
+		var rateLimitErr RateLimitError
+		if err == nil || attempt >= batchRateLimitRetries || !errors.As(err, &rateLimitErr) {
+			return v, err
+		}
+
+		wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
+		if wait <= 0 {
+			wait = time.Second
+		}
+		c.log.Debugf("batch rate limited, waiting %s", wait)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
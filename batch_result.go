@@ -0,0 +1,145 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// BatchFailure is an item of a batch that failed
+type BatchFailure struct {
+	// Id of the resource, or index of the input for bulk imports
+	Id  uint64
+	Err error
+
+	// Retryable is set when the item may succeed if tried again, see
+	// IsRetryableError
+	Retryable bool
+}
+
+// BatchResult is the outcome of a batch or bulk helper, e.g. GetMany,
+// DraftOrder.DeleteMany, Order.AddTags or BulkOperation.Import, telling the
+// items which succeeded from those which failed so failures can be retried
+// the same way whichever helper ran the batch. See NewBatchResult and
+// BulkMutationBatchResult.
+type BatchResult struct {
+	// Succeeded are the ids or indexes of the items which succeeded, in
+	// input order
+	Succeeded []uint64
+
+	// Failed are the items which failed, in input order
+	Failed []BatchFailure
+}
+
+// NewBatchResult returns the result of a batch helper run for ids from the
+// error it returned. A BatchErrors or TagsErrors fails the items it holds,
+// any other error fails the whole batch.
+func NewBatchResult(ids []uint64, err error) *BatchResult {
+	result := &BatchResult{}
+
+	var failed func(id uint64) error
+	switch errs := err.(type) {
+	case nil:
+		failed = func(uint64) error { return nil }
+	case BatchErrors:
+		failed = func(id uint64) error { return errs[id] }
+	case TagsErrors:
+		byId := make(map[uint64]error, len(errs))
+		for gid, err := range errs {
+			if id, parseErr := ParseGraphQLId(gid); parseErr == nil {
+				byId[id] = err
+			}
+		}
+		failed = func(id uint64) error { return byId[id] }
+	default:
+		failed = func(uint64) error { return err }
+	}
+
+	for _, id := range ids {
+		if err := failed(id); err != nil {
+			result.Failed = append(result.Failed, BatchFailure{Id: id, Err: err, Retryable: IsRetryableError(err)})
+		} else {
+			result.Succeeded = append(result.Succeeded, id)
+		}
+	}
+	return result
+}
+
+// BulkMutationBatchResult returns the result of a bulk import by the index
+// of the imported variables. GraphQL and user errors aren't retryable.
+func BulkMutationBatchResult(results []BulkMutationResult) *BatchResult {
+	sorted := append([]BulkMutationResult{}, results...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	result := &BatchResult{}
+	for _, r := range sorted {
+		if err := r.Err(); err != nil {
+			result.Failed = append(result.Failed, BatchFailure{Id: uint64(r.Index), Err: err})
+		} else {
+			result.Succeeded = append(result.Succeeded, uint64(r.Index))
+		}
+	}
+	return result
+}
+
+// Err returns the failures as a BatchErrors, or nil if every item succeeded
+func (r *BatchResult) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	errs := make(BatchErrors, len(r.Failed))
+	for _, f := range r.Failed {
+		errs[f.Id] = f.Err
+	}
+	return errs
+}
+
+// RetryableIds returns the ids of the failed items worth retrying, e.g. to
+// pass them to the helper again
+func (r *BatchResult) RetryableIds() []uint64 {
+	var ids []uint64
+	for _, f := range r.Failed {
+		if f.Retryable {
+			ids = append(ids, f.Id)
+		}
+	}
+	return ids
+}
+
+// String summarizes the result, e.g. "3 succeeded, 1 failed (1 retryable)"
+func (r *BatchResult) String() string {
+	return fmt.Sprintf("%d succeeded, %d failed (%d retryable)", len(r.Succeeded), len(r.Failed), len(r.RetryableIds()))
+}
+
+// IsRetryableError reports whether an operation failing with err may succeed
+// if tried again: rate limits including GraphQL throttling, server errors,
+// timeouts, transport errors such as connection resets and an open circuit.
+// Validation errors, missing resources and canceled contexts aren't
+// retryable.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) || isTimeoutError(err) {
+		return true
+	}
+
+	// the http client failed to send the request or read the response
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var rateLimitErr RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return true
+	}
+	var respErr ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.Status >= http.StatusInternalServerError || respErr.Status == http.StatusTooManyRequests
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestNewBatchResult(t *testing.T) {
+	rateLimited := RateLimitError{ResponseError: ResponseError{Status: http.StatusTooManyRequests}, RetryAfter: 2}
+	invalid := ResponseError{Status: http.StatusUnprocessableEntity, Message: "invalid"}
+
+	result := NewBatchResult([]uint64{1, 2, 3, 4}, BatchErrors{2: rateLimited, 4: invalid})
+
+	expected := &BatchResult{
+		Succeeded: []uint64{1, 3},
+		Failed: []BatchFailure{
+			{Id: 2, Err: rateLimited, Retryable: true},
+			{Id: 4, Err: invalid},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("NewBatchResult returned %+v, expected %+v", result, expected)
+	}
+	if ids := result.RetryableIds(); !reflect.DeepEqual(ids, []uint64{2}) {
+		t.Errorf("BatchResult.RetryableIds returned %v, expected [2]", ids)
+	}
+	if !reflect.DeepEqual(result.Err(), BatchErrors{2: rateLimited, 4: invalid}) {
+		t.Errorf("BatchResult.Err returned %v", result.Err())
+	}
+	if s := result.String(); s != "2 succeeded, 2 failed (1 retryable)" {
+		t.Errorf("BatchResult.String returned %q", s)
+	}
+}
+
+func TestNewBatchResultWholeBatch(t *testing.T) {
+	result := NewBatchResult([]uint64{1, 2}, nil)
+	if !reflect.DeepEqual(result.Succeeded, []uint64{1, 2}) || result.Failed != nil || result.Err() != nil {
+		t.Errorf("NewBatchResult without error returned %+v", result)
+	}
+
+	unavailable := ResponseError{Status: http.StatusServiceUnavailable}
+	result = NewBatchResult([]uint64{1, 2}, unavailable)
+	if result.Succeeded != nil || !reflect.DeepEqual(result.RetryableIds(), []uint64{1, 2}) {
+		t.Errorf("NewBatchResult with a batch error returned %+v", result)
+	}
+}
+
+func TestNewBatchResultTags(t *testing.T) {
+	userErr := GraphQLUserErrors{{Message: "Order not found"}}
+	err := TagsErrors{GraphQLId("Order", 2): userErr}
+
+	result := NewBatchResult([]uint64{1, 2}, err)
+	expected := &BatchResult{
+		Succeeded: []uint64{1},
+		Failed:    []BatchFailure{{Id: 2, Err: userErr}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("NewBatchResult of TagsErrors returned %+v, expected %+v", result, expected)
+	}
+}
+
+func TestBulkMutationBatchResult(t *testing.T) {
+	results := []BulkMutationResult{
+		{Index: 2, Data: []byte(`{}`)},
+		{Index: 0, Data: []byte(`{}`)},
+		{Index: 1, UserErrors: GraphQLUserErrors{{Message: "Title can't be blank"}}},
+	}
+
+	result := BulkMutationBatchResult(results)
+	if !reflect.DeepEqual(result.Succeeded, []uint64{0, 2}) || len(result.Failed) != 1 || result.Failed[0].Id != 1 || result.Failed[0].Retryable {
+		t.Errorf("BulkMutationBatchResult returned %+v", result)
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	cases := []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{RateLimitError{ResponseError: ResponseError{Status: http.StatusTooManyRequests}}, true},
+		{RateLimitError{ResponseError: ResponseError{Status: http.StatusOK, Message: "Throttled"}}, true},
+		{ResponseError{Status: http.StatusServiceUnavailable}, true},
+		{ResponseError{Status: http.StatusInternalServerError}, true},
+		{ResponseError{Status: http.StatusNotFound}, false},
+		{ResponseError{Status: http.StatusUnprocessableEntity}, false},
+		{GraphQLUserErrors{{Message: "invalid"}}, false},
+		{ErrCircuitOpen, true},
+		{context.DeadlineExceeded, true},
+		{context.Canceled, false},
+		{timeoutError{}, true},
+		{&url.Error{Op: "Get", URL: "https://fooshop.myshopify.com", Err: errors.New("connection reset by peer")}, true},
+		{fmt.Errorf("get order: %w", ResponseError{Status: http.StatusBadGateway}), true},
+		{errors.New("unknown"), false},
+	}
+	for _, c := range cases {
+		if retryable := IsRetryableError(c.err); retryable != c.retryable {
+			t.Errorf("IsRetryableError(%v) returned %t, expected %t", c.err, retryable, c.retryable)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestGetMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	failure := errors.New("not found")
+	var mu sync.Mutex
+	priorities := []RequestPriority{}
+
+	results, err := client.GetMany(context.Background(), []uint64{1, 2, 3, 4, 5}, func(ctx context.Context, id uint64) (interface{}, error) {
+		mu.Lock()
+		priorities = append(priorities, RequestPriorityFromContext(ctx))
+		mu.Unlock()
+
+		if id%2 == 0 {
+			return nil, failure
+		}
+		return id * 10, nil
+	})
+
+	expectedResults := map[uint64]interface{}{1: uint64(10), 3: uint64(30), 5: uint64(50)}
+	if !reflect.DeepEqual(results, expectedResults) {
+		t.Errorf("GetMany returned %v, expected %v", results, expectedResults)
+	}
+
+	expectedErr := BatchErrors{2: failure, 4: failure}
+	if !reflect.DeepEqual(err, expectedErr) {
+		t.Errorf("GetMany err returned %v, expected %v", err, expectedErr)
+	}
+
+	for _, p := range priorities {
+		if p != PriorityBatch {
+			t.Errorf("GetMany request priority = %d, expected %d", p, PriorityBatch)
+		}
+	}
+}
+
+func TestGetManyRateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	results, err := client.GetMany(context.Background(), []uint64{1}, func(ctx context.Context, id uint64) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, RateLimitError{ResponseError: ResponseError{Status: 429}}
+		}
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("GetMany returned error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("GetMany called get %d times, expected 2", calls)
+	}
+
+	if results[1] != "ok" {
+		t.Errorf("GetMany returned %v, expected ok", results[1])
+	}
+}
+
+func TestGetManyCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetMany(ctx, []uint64{1, 2}, func(ctx context.Context, id uint64) (interface{}, error) {
+		return id, nil
+	})
+
+	expected := BatchErrors{1: context.Canceled, 2: context.Canceled}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("GetMany err returned %v, expected %v", err, expected)
+	}
+}
+
+func TestBatchErrorsError(t *testing.T) {
+	err := BatchErrors{2: errors.New("b"), 1: errors.New("a")}
+
+	expected := "2 of the batch failed: 1: a, 2: b"
+	if err.Error() != expected {
+		t.Errorf("BatchErrors.Error returned %q, expected %q", err.Error(), expected)
+	}
+}
@@ -0,0 +1,345 @@
+package goshopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// BulkOperationService is an interface for running bulk mutations through
+// the GraphQL API, which import large numbers of resources without being
+// rate limited, see
+// https://shopify.dev/docs/api/usage/bulk-operations/imports
+type BulkOperationService interface {
+	StageUpload(context.Context, BulkVariablesIterator) (string, error)
+	RunMutation(context.Context, string, string) (*BulkOperation, error)
+	Get(context.Context, string) (*BulkOperation, error)
+	WaitForCompletion(context.Context, string, Backoff) (*BulkOperation, error)
+	MutationResults(context.Context, *BulkOperation) ([]BulkMutationResult, error)
+	Import(context.Context, string, BulkVariablesIterator, Backoff) ([]BulkMutationResult, error)
+}
+
+// BulkOperationServiceOp handles communication with the bulk operation
+// related methods of the Shopify API.
+type BulkOperationServiceOp struct {
+	client *Client
+}
+
+// BulkOperationStatus is the status of a bulk operation
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusCreated   BulkOperationStatus = "CREATED"
+	BulkOperationStatusRunning   BulkOperationStatus = "RUNNING"
+	BulkOperationStatusCompleted BulkOperationStatus = "COMPLETED"
+	BulkOperationStatusCanceling BulkOperationStatus = "CANCELING"
+	BulkOperationStatusCanceled  BulkOperationStatus = "CANCELED"
+	BulkOperationStatusFailed    BulkOperationStatus = "FAILED"
+	BulkOperationStatusExpired   BulkOperationStatus = "EXPIRED"
+)
+
+// BulkOperation represents a Shopify bulk operation
+type BulkOperation struct {
+	Id             string              `json:"id"`
+	Type           string              `json:"type"`
+	Status         BulkOperationStatus `json:"status"`
+	ErrorCode      string              `json:"errorCode"`
+	ObjectCount    string              `json:"objectCount"`
+	FileSize       string              `json:"fileSize"`
+	Url            string              `json:"url"`
+	PartialDataUrl string              `json:"partialDataUrl"`
+	CreatedAt      *time.Time          `json:"createdAt"`
+	CompletedAt    *time.Time          `json:"completedAt"`
+}
+
+// Done reports whether the operation has finished, successfully or not
+func (o *BulkOperation) Done() bool {
+	switch o.Status {
+	case BulkOperationStatusCreated, BulkOperationStatusRunning, BulkOperationStatusCanceling:
+		return false
+	}
+	return true
+}
+
+// BulkOperationError is returned when a bulk operation did not complete
+type BulkOperationError struct {
+	Operation *BulkOperation
+}
+
+func (e BulkOperationError) Error() string {
+	if e.Operation.ErrorCode != "" {
+		return fmt.Sprintf("bulk operation %s %s: %s", e.Operation.Id, e.Operation.Status, e.Operation.ErrorCode)
+	}
+	return fmt.Sprintf("bulk operation %s %s", e.Operation.Id, e.Operation.Status)
+}
+
+// BulkVariablesIterator returns the variables of the next mutation of a bulk
+// import, or io.EOF once there are none left.
+type BulkVariablesIterator func() (interface{}, error)
+
+// BulkVariables returns a BulkVariablesIterator over the given variables
+func BulkVariables(vars ...interface{}) BulkVariablesIterator {
+	return func() (interface{}, error) {
+		if len(vars) == 0 {
+			return nil, io.EOF
+		}
+		next := vars[0]
+		vars = vars[1:]
+		return next, nil
+	}
+}
+
+// BulkMutationResult is the result of a single mutation of a bulk import
+type BulkMutationResult struct {
+	// Index of the variables in the imported input
+	Index int
+
+	// Data is the data of the mutation, e.g. {"productCreate": {...}}
+	Data json.RawMessage
+
+	// Errors are the GraphQL errors of the mutation
+	Errors []string
+
+	// UserErrors are the userErrors reported by the mutation
+	UserErrors GraphQLUserErrors
+}
+
+// Err returns the errors of the mutation, if any
+func (r BulkMutationResult) Err() error {
+	if len(r.Errors) > 0 {
+		return ResponseError{Status: http.StatusOK, Errors: r.Errors}
+	}
+	if len(r.UserErrors) > 0 {
+		return r.UserErrors
+	}
+	return nil
+}
+
+const bulkOperationRunMutation = `mutation bulkOperationRunMutation($mutation: String!, $stagedUploadPath: String!) {
+	bulkOperationRunMutation(mutation: $mutation, stagedUploadPath: $stagedUploadPath) {
+		bulkOperation { ` + bulkOperationFields + ` }
+		userErrors { field message code }
+	}
+}`
+
+const bulkOperationQuery = `query bulkOperation($id: ID!) {
+	node(id: $id) {
+		... on BulkOperation { ` + bulkOperationFields + ` }
+	}
+}`
+
+const bulkOperationFields = `id type status errorCode objectCount fileSize url partialDataUrl createdAt completedAt`
+
+// StageUpload streams the variables returned by vars as a JSONL file to a
+// staged upload and returns its path for RunMutation. The file is limited to
+// 100MB by Shopify.
+func (s *BulkOperationServiceOp) StageUpload(ctx context.Context, vars BulkVariablesIterator) (string, error) {
+	target, err := s.client.createStagedUpload(ctx, map[string]interface{}{
+		"resource":   "BULK_MUTATION_VARIABLES",
+		"filename":   "bulk_op_vars.jsonl",
+		"mimeType":   "text/jsonl",
+		"httpMethod": "POST",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	path := target.parameter("key")
+	if path == "" {
+		return "", fmt.Errorf("staged upload target has no key parameter")
+	}
+
+	err = s.client.postStagedUpload(ctx, target, "bulk_op_vars.jsonl", func(file io.Writer) error {
+		return writeBulkVariables(file, vars)
+	})
+	return path, err
+}
+
+func writeBulkVariables(file io.Writer, vars BulkVariablesIterator) error {
+	enc := json.NewEncoder(file)
+	for index := 0; ; index++ {
+		v, err := vars()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("encoding variables %d: %w", index, err)
+		}
+	}
+}
+
+// RunMutation starts a bulk operation running the mutation once for each
+// line of variables of the staged upload, see StageUpload.
+func (s *BulkOperationServiceOp) RunMutation(ctx context.Context, mutation string, stagedUploadPath string) (*BulkOperation, error) {
+	resp := struct {
+		BulkOperationRunMutation struct {
+			BulkOperation *BulkOperation    `json:"bulkOperation"`
+			UserErrors    GraphQLUserErrors `json:"userErrors"`
+		} `json:"bulkOperationRunMutation"`
+	}{}
+	vars := map[string]interface{}{
+		"mutation":         mutation,
+		"stagedUploadPath": stagedUploadPath,
+	}
+	err := s.client.GraphQL.Query(ctx, bulkOperationRunMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.BulkOperationRunMutation.UserErrors) > 0 {
+		return nil, resp.BulkOperationRunMutation.UserErrors
+	}
+	if resp.BulkOperationRunMutation.BulkOperation == nil {
+		return nil, fmt.Errorf("no bulk operation returned")
+	}
+	return resp.BulkOperationRunMutation.BulkOperation, nil
+}
+
+// Get a bulk operation by its GraphQL id
+func (s *BulkOperationServiceOp) Get(ctx context.Context, id string) (*BulkOperation, error) {
+	resp := struct {
+		Node *BulkOperation `json:"node"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, bulkOperationQuery, map[string]interface{}{"id": id}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Node == nil {
+		return nil, ResponseError{Status: http.StatusNotFound, Message: "Not Found"}
+	}
+	return resp.Node, nil
+}
+
+// WaitForCompletion polls the bulk operation until it is done, see WaitFor.
+// A BulkOperationError is returned along with the operation if it did not
+// complete successfully.
+func (s *BulkOperationServiceOp) WaitForCompletion(ctx context.Context, id string, backoff Backoff) (*BulkOperation, error) {
+	resource, err := WaitFor(ctx, func(ctx context.Context) (interface{}, error) {
+		return s.Get(ctx, id)
+	}, func(resource interface{}) bool {
+		return resource.(*BulkOperation).Done()
+	}, backoff)
+
+	op, _ := resource.(*BulkOperation)
+	if err == nil && op.Status != BulkOperationStatusCompleted {
+		err = BulkOperationError{Operation: op}
+	}
+	return op, err
+}
+
+// MutationResults downloads and parses the result file of a finished bulk
+// mutation, ordered by the index of the variables. For failed operations the
+// partial results are returned, if any.
+func (s *BulkOperationServiceOp) MutationResults(ctx context.Context, op *BulkOperation) ([]BulkMutationResult, error) {
+	url := op.Url
+	if url == "" {
+		url = op.PartialDataUrl
+	}
+	if url == "" {
+		return nil, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := s.client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ResponseError{Status: resp.StatusCode}
+	}
+
+	return parseBulkMutationResults(resp.Body)
+}
+
+func parseBulkMutationResults(r io.Reader) ([]BulkMutationResult, error) {
+	var results []BulkMutationResult
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		row := struct {
+			Data       json.RawMessage `json:"data"`
+			Errors     []graphQLError  `json:"errors"`
+			LineNumber int             `json:"__lineNumber"`
+		}{}
+		if err := json.Unmarshal(line, &row); err != nil {
+			return results, fmt.Errorf("invalid bulk operation result line: %w", err)
+		}
+
+		result := BulkMutationResult{Index: row.LineNumber}
+		if len(row.Data) > 0 && string(row.Data) != "null" {
+			result.Data = row.Data
+
+			fields := map[string]*struct {
+				UserErrors GraphQLUserErrors `json:"userErrors"`
+			}{}
+			if err := json.Unmarshal(row.Data, &fields); err == nil {
+				for _, field := range fields {
+					if field != nil {
+						result.UserErrors = append(result.UserErrors, field.UserErrors...)
+					}
+				}
+			}
+		}
+		for _, err := range row.Errors {
+			result.Errors = append(result.Errors, err.Message)
+		}
+
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return results, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Index < results[j].Index
+	})
+	return results, nil
+}
+
+// Import runs the mutation for each of the variables returned by vars as a
+// bulk operation: it stages the upload, runs the mutation, waits for the
+// operation to finish and returns the parsed results, see StageUpload,
+// RunMutation, WaitForCompletion and MutationResults.
+func (s *BulkOperationServiceOp) Import(ctx context.Context, mutation string, vars BulkVariablesIterator, backoff Backoff) ([]BulkMutationResult, error) {
+	path, err := s.StageUpload(ctx, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.RunMutation(ctx, mutation, path)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err = s.WaitForCompletion(ctx, op.Id, backoff)
+	if op == nil || !op.Done() {
+		return nil, err
+	}
+
+	results, resultsErr := s.MutationResults(ctx, op)
+	if err == nil {
+		err = resultsErr
+	}
+	return results, err
+}
@@ -0,0 +1,212 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const (
+	testStagedUploadUrl  = "https://shopify-staged-uploads.storage.googleapis.com/"
+	testBulkResultUrl    = "https://storage.googleapis.com/shopify/bulk-result.jsonl"
+	testStagedUploadPath = "tmp/21759409/bulk/2d278b12-d153-4667-a05c-a5d8181623de/bulk_op_vars.jsonl"
+	testBulkOperationId  = "gid://shopify/BulkOperation/206005076024"
+)
+
+// registerBulkOperationResponders mocks a bulk import ending with the given
+// status, returning the uploaded form fields and file
+func registerBulkOperationResponders(t *testing.T, status string, fields map[string]string, file *string) {
+	polls := 0
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		switch {
+		case strings.Contains(req.Query, "stagedUploadsCreate("):
+			return `{"data":{"stagedUploadsCreate":{"stagedTargets":[{
+				"url":"` + testStagedUploadUrl + `",
+				"resourceUrl":null,
+				"parameters":[{"name":"key","value":"` + testStagedUploadPath + `"},{"name":"policy","value":"abc"}]
+			}],"userErrors":[]}}}`
+		case strings.Contains(req.Query, "bulkOperationRunMutation("):
+			if req.Variables["stagedUploadPath"] != testStagedUploadPath {
+				t.Errorf("bulkOperationRunMutation sent %+v", req.Variables)
+			}
+			return `{"data":{"bulkOperationRunMutation":{"bulkOperation":{
+				"id":"` + testBulkOperationId + `","status":"CREATED"
+			},"userErrors":[]}}}`
+		case strings.Contains(req.Query, "node(id: $id)"):
+			polls++
+			if polls < 2 {
+				return `{"data":{"node":{"id":"` + testBulkOperationId + `","status":"RUNNING"}}}`
+			}
+			return `{"data":{"node":{"id":"` + testBulkOperationId + `","status":"` + status + `",
+				"objectCount":"3","url":"` + testBulkResultUrl + `"}}}`
+		}
+
+		t.Errorf("unexpected graphql query %s", req.Query)
+		return ""
+	})
+
+	httpmock.RegisterResponder("POST", testStagedUploadUrl,
+		func(req *http.Request) (*http.Response, error) {
+			reader, err := req.MultipartReader()
+			if err != nil {
+				t.Fatalf("staged upload is not multipart: %v", err)
+			}
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				data, _ := ioutil.ReadAll(part)
+				if part.FormName() == "file" {
+					*file = string(data)
+				} else {
+					fields[part.FormName()] = string(data)
+				}
+			}
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+
+	httpmock.RegisterResponder("GET", testBulkResultUrl,
+		httpmock.NewStringResponder(200, `{"data":{"productCreate":{"product":{"id":"gid://shopify/Product/2"},"userErrors":[]}},"__lineNumber":1}
+{"data":{"productCreate":{"product":{"id":"gid://shopify/Product/1"},"userErrors":[]}},"__lineNumber":0}
+{"data":{"productCreate":{"product":null,"userErrors":[{"field":["input","title"],"message":"Title can't be blank"}]}},"__lineNumber":2}
+`))
+}
+
+const testBulkProductMutation = `mutation call($input: ProductInput!) { productCreate(input: $input) { product { id } userErrors { field message } } }`
+
+func TestBulkOperationImport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fields := map[string]string{}
+	var file string
+	registerBulkOperationResponders(t, "COMPLETED", fields, &file)
+
+	vars := BulkVariables(
+		map[string]interface{}{"input": map[string]interface{}{"title": "Sweet new snowboard"}},
+		map[string]interface{}{"input": map[string]interface{}{"title": "Winter hat"}},
+		map[string]interface{}{"input": map[string]interface{}{"title": ""}},
+	)
+	results, err := client.BulkOperation.Import(context.Background(), testBulkProductMutation, vars, ConstantBackoff(time.Millisecond))
+	if err != nil {
+		t.Fatalf("BulkOperation.Import returned error: %v", err)
+	}
+
+	expectedFields := map[string]string{"key": testStagedUploadPath, "policy": "abc"}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("BulkOperation.Import uploaded fields %+v, expected %+v", fields, expectedFields)
+	}
+	expectedFile := `{"input":{"title":"Sweet new snowboard"}}
+{"input":{"title":"Winter hat"}}
+{"input":{"title":""}}
+`
+	if file != expectedFile {
+		t.Errorf("BulkOperation.Import uploaded %q, expected %q", file, expectedFile)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("BulkOperation.Import returned %d results, expected 3", len(results))
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("BulkOperation.Import returned result %d with index %d", i, result.Index)
+		}
+	}
+	if results[0].Err() != nil || !strings.Contains(string(results[0].Data), "gid://shopify/Product/1") {
+		t.Errorf("BulkOperation.Import returned first result %+v", results[0])
+	}
+
+	expectedUserErrors := GraphQLUserErrors{{Field: []string{"input", "title"}, Message: "Title can't be blank"}}
+	if !reflect.DeepEqual(results[2].UserErrors, expectedUserErrors) || results[2].Err() == nil {
+		t.Errorf("BulkOperation.Import returned user errors %+v, expected %+v", results[2].UserErrors, expectedUserErrors)
+	}
+}
+
+func TestBulkOperationImportFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var file string
+	registerBulkOperationResponders(t, "FAILED", map[string]string{}, &file)
+
+	results, err := client.BulkOperation.Import(context.Background(), testBulkProductMutation, BulkVariables(1, 2, 3), ConstantBackoff(time.Millisecond))
+	opErr, ok := err.(BulkOperationError)
+	if !ok || opErr.Operation.Status != BulkOperationStatusFailed {
+		t.Errorf("BulkOperation.Import returned error %v, expected a BulkOperationError", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("BulkOperation.Import returned %d partial results, expected 3", len(results))
+	}
+}
+
+func TestBulkOperationRunMutationUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"bulkOperationRunMutation":{"bulkOperation":null,
+			"userErrors":[{"field":null,"message":"A bulk mutation operation for this app and shop is already in progress.","code":"OPERATION_IN_PROGRESS"}]}}}`
+	})
+
+	_, err := client.BulkOperation.RunMutation(context.Background(), testBulkProductMutation, testStagedUploadPath)
+	if userErrs, ok := err.(GraphQLUserErrors); !ok || userErrs[0].Code != "OPERATION_IN_PROGRESS" {
+		t.Errorf("BulkOperation.RunMutation returned error %v, expected user errors", err)
+	}
+}
+
+func TestBulkOperationRunMutationNoOperation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"bulkOperationRunMutation":{"bulkOperation":null,"userErrors":[]}}}`
+	})
+
+	op, err := client.BulkOperation.RunMutation(context.Background(), testBulkProductMutation, testStagedUploadPath)
+	if op != nil || err == nil {
+		t.Errorf("BulkOperation.RunMutation returned %+v, %v, expected an error", op, err)
+	}
+}
+
+func TestBulkOperationStageUploadIteratorError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var file string
+	registerBulkOperationResponders(t, "COMPLETED", map[string]string{}, &file)
+
+	iterErr := fmt.Errorf("database gone")
+	vars := func() (interface{}, error) {
+		return nil, iterErr
+	}
+	_, err := client.BulkOperation.StageUpload(context.Background(), vars)
+	if err != iterErr {
+		t.Errorf("BulkOperation.StageUpload returned error %v, expected %v", err, iterErr)
+	}
+}
+
+func TestBulkOperationGetNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"node":null}}`
+	})
+
+	_, err := client.BulkOperation.Get(context.Background(), testBulkOperationId)
+	if !isNotFound(err) {
+		t.Errorf("BulkOperation.Get returned error %v, expected not found", err)
+	}
+}
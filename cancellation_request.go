@@ -0,0 +1,57 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	cancellationRequestBasePath = "fulfillment_orders"
+)
+
+// CancellationRequestService is an interface for interfacing with the cancellation request endpoints of the Shopify API.
+// https://shopify.dev/docs/api/admin-rest/2023-10/resources/cancellationrequest
+type CancellationRequestService interface {
+	Send(context.Context, uint64, CancellationRequest) (*FulfillmentOrder, error)
+	Accept(context.Context, uint64, CancellationRequest) (*FulfillmentOrder, error)
+	Reject(context.Context, uint64, CancellationRequest) (*FulfillmentOrder, error)
+}
+
+type CancellationRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+type CancellationRequestResource struct {
+	CancellationRequest *CancellationRequest `json:"cancellation_request,omitempty"`
+	FulfillmentOrder    *FulfillmentOrder    `json:"fulfillment_order,omitempty"`
+}
+
+// CancellationRequestServiceOp handles communication with the cancellation request related methods of the Shopify API.
+type CancellationRequestServiceOp struct {
+	client *Client
+}
+
+// Send sends a cancellation request to the fulfillment service of a fulfillment order.
+func (s *CancellationRequestServiceOp) Send(ctx context.Context, fulfillmentOrderId uint64, request CancellationRequest) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/cancellation_request.json", cancellationRequestBasePath, fulfillmentOrderId)
+	return s.post(ctx, path, request)
+}
+
+// Accept accepts a cancellation request sent to a fulfillment service for a fulfillment order.
+func (s *CancellationRequestServiceOp) Accept(ctx context.Context, fulfillmentOrderId uint64, request CancellationRequest) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/cancellation_request/accept.json", cancellationRequestBasePath, fulfillmentOrderId)
+	return s.post(ctx, path, request)
+}
+
+// Reject rejects a cancellation request sent to a fulfillment service for a fulfillment order.
+func (s *CancellationRequestServiceOp) Reject(ctx context.Context, fulfillmentOrderId uint64, request CancellationRequest) (*FulfillmentOrder, error) {
+	path := fmt.Sprintf("%s/%d/cancellation_request/reject.json", cancellationRequestBasePath, fulfillmentOrderId)
+	return s.post(ctx, path, request)
+}
+
+func (s *CancellationRequestServiceOp) post(ctx context.Context, path string, request CancellationRequest) (*FulfillmentOrder, error) {
+	wrappedData := CancellationRequestResource{CancellationRequest: &request}
+	resource := new(CancellationRequestResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.FulfillmentOrder, err
+}
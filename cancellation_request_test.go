@@ -0,0 +1,78 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCancellationRequestServiceOp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	fulfillmentOrderId := uint64(1046000823)
+	message := "The customer changed their mind."
+
+	cases := []struct {
+		path   string
+		status string
+		call   func() (*FulfillmentOrder, error)
+	}{
+		{
+			"cancellation_request.json",
+			"cancellation_requested",
+			func() (*FulfillmentOrder, error) {
+				return client.CancellationRequest.Send(context.Background(), fulfillmentOrderId, CancellationRequest{Message: message})
+			},
+		},
+		{
+			"cancellation_request/accept.json",
+			"cancellation_accepted",
+			func() (*FulfillmentOrder, error) {
+				return client.CancellationRequest.Accept(context.Background(), fulfillmentOrderId, CancellationRequest{Message: message})
+			},
+		},
+		{
+			"cancellation_request/reject.json",
+			"cancellation_rejected",
+			func() (*FulfillmentOrder, error) {
+				return client.CancellationRequest.Reject(context.Background(), fulfillmentOrderId, CancellationRequest{Message: message})
+			},
+		},
+	}
+
+	for _, c := range cases {
+		response := fmt.Sprintf(`{"fulfillment_order":{"id":%d,"status":"in_progress","request_status":"%s"}}`, fulfillmentOrderId, c.status)
+		httpmock.RegisterResponder(
+			http.MethodPost,
+			fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/%d/%s", client.pathPrefix, fulfillmentOrderId, c.path),
+			func(req *http.Request) (*http.Response, error) {
+				body, _ := ioutil.ReadAll(req.Body)
+				expected := `{"cancellation_request":{"message":"The customer changed their mind."}}`
+				if string(body) != expected {
+					t.Errorf("POST %s sent %s, expected %s", c.path, body, expected)
+				}
+				return httpmock.NewStringResponse(200, response), nil
+			},
+		)
+
+		result, err := c.call()
+		if err != nil {
+			t.Errorf("POST %s returned error: %v", c.path, err)
+		}
+
+		expected := &FulfillmentOrder{
+			Id:            fulfillmentOrderId,
+			Status:        FulfillmentOrderStatusInProgress,
+			RequestStatus: c.status,
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("POST %s returned %+v, expected %+v", c.path, result, expected)
+		}
+	}
+}
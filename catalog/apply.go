@@ -0,0 +1,300 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+const productSetMutation = `mutation call($input: ProductSetInput!) {
+  productSet(input: $input) {
+    product {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// weight units of the REST API by their GraphQL WeightUnit
+var weightUnits = map[string]string{
+	"g":  "GRAMS",
+	"kg": "KILOGRAMS",
+	"oz": "OUNCES",
+	"lb": "POUNDS",
+}
+
+// Errors holds the errors of the changes which failed, by handle
+type Errors map[string]error
+
+func (e Errors) Error() string {
+	handles := make([]string, 0, len(e))
+	for handle := range e {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+
+	msgs := make([]string, 0, len(handles))
+	for _, handle := range handles {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", handle, e[handle]))
+	}
+	return fmt.Sprintf("%d changes failed: %s", len(e), strings.Join(msgs, ", "))
+}
+
+// Sync lists the products of the client's shop, diffs them with the desired
+// products and applies the changes. It returns the plan it applied.
+func Sync(ctx context.Context, client *goshopify.Client, desired []goshopify.Product, opts *Options) (*Plan, error) {
+	existing, err := client.Product.ListAll(ctx, goshopify.ListOptions{Limit: 250})
+	if err != nil {
+		return nil, err
+	}
+	plan := Diff(desired, existing, opts)
+	return plan, Apply(ctx, client, plan, opts)
+}
+
+// Apply applies the changes of a plan. Products are created and updated by a
+// single bulk productSet mutation, which isn't rate limited, then images are
+// added and removed and products deleted through the REST API. The ids of
+// created products are set on their changes. If any change failed the
+// returned error is an Errors.
+func Apply(ctx context.Context, client *goshopify.Client, plan *Plan, opts *Options) error {
+	errs := Errors{}
+
+	var sets []int
+	for i, c := range plan.Changes {
+		if c.Action == ActionCreate || (c.Action == ActionUpdate && productFieldsDiffer(c)) {
+			sets = append(sets, i)
+		}
+	}
+	if len(sets) > 0 {
+		if err := applyProductSets(ctx, client, plan, sets, opts, errs); err != nil {
+			return err
+		}
+	}
+
+	var ids []uint64
+	for i, c := range plan.Changes {
+		if errs[c.Handle] == nil && (c.Action == ActionDelete || len(c.AddImages) > 0 || len(c.RemoveImages) > 0) {
+			ids = append(ids, uint64(i))
+		}
+	}
+	_, err := client.GetMany(ctx, ids, func(ctx context.Context, i uint64) (interface{}, error) {
+		c := plan.Changes[i]
+		if c.Action == ActionDelete {
+			return nil, client.Product.Delete(ctx, c.Product.Id)
+		}
+		return nil, applyImages(ctx, client, c)
+	})
+	if batchErrs, ok := err.(goshopify.BatchErrors); ok {
+		for i, err := range batchErrs {
+			errs[plan.Changes[i].Handle] = err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// productFieldsDiffer tells whether an update changes more than images
+func productFieldsDiffer(c Change) bool {
+	for _, f := range c.Fields {
+		if !strings.HasPrefix(f, "images[") {
+			return true
+		}
+	}
+	return false
+}
+
+func applyProductSets(ctx context.Context, client *goshopify.Client, plan *Plan, sets []int, opts *Options, errs Errors) error {
+	vars := make([]interface{}, 0, len(sets))
+	for _, i := range sets {
+		vars = append(vars, productSetVariables(plan.Changes[i].Product))
+	}
+
+	var backoff goshopify.Backoff
+	if opts != nil {
+		backoff = opts.Backoff
+	}
+	results, err := client.BulkOperation.Import(ctx, productSetMutation, goshopify.BulkVariables(vars...), backoff)
+	if err != nil && len(results) == 0 {
+		return err
+	}
+
+	done := make(map[int]bool, len(results))
+	for _, r := range results {
+		if r.Index < 0 || r.Index >= len(sets) {
+			continue
+		}
+		done[r.Index] = true
+		c := &plan.Changes[sets[r.Index]]
+		if err := r.Err(); err != nil {
+			errs[c.Handle] = err
+			continue
+		}
+
+		data := struct {
+			ProductSet struct {
+				Product struct {
+					Id string `json:"id"`
+				} `json:"product"`
+			} `json:"productSet"`
+		}{}
+		if err := json.Unmarshal(r.Data, &data); err != nil {
+			errs[c.Handle] = err
+			continue
+		}
+		if id, err := goshopify.ParseGraphQLId(data.ProductSet.Product.Id); err == nil {
+			c.Product.Id = id
+		}
+	}
+	// products missing from the results, e.g. of a failed operation
+	if err == nil {
+		err = fmt.Errorf("productSet returned no result")
+	}
+	for index, i := range sets {
+		if !done[index] {
+			errs[plan.Changes[i].Handle] = err
+		}
+	}
+	return nil
+}
+
+func applyImages(ctx context.Context, client *goshopify.Client, c Change) error {
+	for _, img := range c.RemoveImages {
+		if err := client.Image.Delete(ctx, c.Product.Id, img.Id); err != nil {
+			return err
+		}
+	}
+	for _, img := range c.AddImages {
+		if _, err := client.Image.Create(ctx, c.Product.Id, img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// productSetVariables returns the productSet input of a product, with its
+// variants and options when it has variants
+func productSetVariables(p goshopify.Product) map[string]interface{} {
+	input := map[string]interface{}{"handle": p.Handle}
+	if p.Id != 0 {
+		input["id"] = goshopify.GraphQLId("Product", p.Id)
+	}
+	for field, value := range map[string]string{
+		"title":           p.Title,
+		"descriptionHtml": p.BodyHTML,
+		"vendor":          p.Vendor,
+		"productType":     p.ProductType,
+		"templateSuffix":  p.TemplateSuffix,
+		"status":          strings.ToUpper(string(p.Status)),
+	} {
+		if value != "" {
+			input[field] = value
+		}
+	}
+	if p.Tags != "" {
+		var tags []string
+		for _, tag := range strings.Split(p.Tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		input["tags"] = tags
+	}
+	if p.Variants != nil {
+		input["productOptions"], input["variants"] = productSetVariants(p)
+	}
+	return map[string]interface{}{"input": input}
+}
+
+func productSetVariants(p goshopify.Product) ([]interface{}, []interface{}) {
+	var names []string
+	for _, o := range p.Options {
+		if len(names) < 3 {
+			names = append(names, o.Name)
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"Title"}
+	}
+
+	values := make([][]string, len(names))
+	variants := make([]interface{}, 0, len(p.Variants))
+	for _, v := range p.Variants {
+		optionValues := make([]interface{}, 0, len(names))
+		for i, value := range []string{v.Option1, v.Option2, v.Option3}[:len(names)] {
+			if value == "" && i == 0 && len(p.Options) == 0 {
+				value = "Default Title"
+			}
+			if indexOf(values[i], value) < 0 {
+				values[i] = append(values[i], value)
+			}
+			optionValues = append(optionValues, map[string]interface{}{"optionName": names[i], "name": value})
+		}
+
+		variant := map[string]interface{}{"optionValues": optionValues}
+		if v.Id != 0 {
+			variant["id"] = goshopify.GraphQLId("ProductVariant", v.Id)
+		}
+		inventoryItem := map[string]interface{}{}
+		if v.Sku != "" {
+			inventoryItem["sku"] = v.Sku
+		}
+		if v.Weight != nil {
+			weight, _ := v.Weight.Float64()
+			unit := weightUnits[v.WeightUnit]
+			if unit == "" {
+				unit = "KILOGRAMS"
+			}
+			inventoryItem["measurement"] = map[string]interface{}{
+				"weight": map[string]interface{}{"value": weight, "unit": unit},
+			}
+		}
+		if len(inventoryItem) > 0 {
+			variant["inventoryItem"] = inventoryItem
+		}
+		if v.Price != nil {
+			variant["price"] = v.Price.String()
+		}
+		if v.CompareAtPrice != nil {
+			variant["compareAtPrice"] = v.CompareAtPrice.String()
+		}
+		if v.Barcode != "" {
+			variant["barcode"] = v.Barcode
+		}
+		if v.InventoryPolicy != "" {
+			variant["inventoryPolicy"] = strings.ToUpper(string(v.InventoryPolicy))
+		}
+		variants = append(variants, variant)
+	}
+
+	options := make([]interface{}, 0, len(names))
+	for i, name := range names {
+		optionValues := make([]interface{}, 0, len(values[i]))
+		for _, value := range values[i] {
+			optionValues = append(optionValues, map[string]interface{}{"name": value})
+		}
+		options = append(options, map[string]interface{}{"name": name, "position": i + 1, "values": optionValues})
+	}
+	return options, variants
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,107 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/jarcoal/httpmock"
+)
+
+const testApiVersion = "9999-99"
+
+var client *goshopify.Client
+
+func setup() {
+	client = goshopify.MustNewClient(goshopify.App{}, "fooshop", "abcd",
+		goshopify.WithVersion(testApiVersion))
+	httpmock.ActivateNonDefault(client.Client)
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+// fakeBulkOperations records the variables of bulk imports and returns a
+// result per variables from results
+type fakeBulkOperations struct {
+	goshopify.BulkOperationService
+	vars    []interface{}
+	results func(index int) goshopify.BulkMutationResult
+}
+
+func (f *fakeBulkOperations) Import(ctx context.Context, mutation string, vars goshopify.BulkVariablesIterator, backoff goshopify.Backoff) ([]goshopify.BulkMutationResult, error) {
+	var results []goshopify.BulkMutationResult
+	for {
+		v, err := vars()
+		if err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return results, err
+		}
+		results = append(results, f.results(len(f.vars)))
+		f.vars = append(f.vars, v)
+	}
+}
+
+func TestApply(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bulk := &fakeBulkOperations{results: func(index int) goshopify.BulkMutationResult {
+		if index == 2 {
+			return goshopify.BulkMutationResult{Index: index, UserErrors: goshopify.GraphQLUserErrors{{Message: "Handle has already been taken"}}}
+		}
+		return goshopify.BulkMutationResult{
+			Index: index,
+			Data:  json.RawMessage(fmt.Sprintf(`{"productSet":{"product":{"id":"gid://shopify/Product/%d"}}}`, 100+index)),
+		}
+	}}
+	client.BulkOperation = bulk
+
+	base := fmt.Sprintf("https://fooshop.myshopify.com/admin/api/%s/products", testApiVersion)
+	httpmock.RegisterResponder("POST", base+"/100/images.json", httpmock.NewStringResponder(200, `{"image":{"id":1}}`))
+	httpmock.RegisterResponder("DELETE", base+"/1/images/22.json", httpmock.NewStringResponder(200, `{}`))
+	httpmock.RegisterResponder("DELETE", base+"/3.json", httpmock.NewStringResponder(200, `{}`))
+
+	plan := &Plan{Changes: []Change{
+		{Handle: "gloves", Action: ActionCreate, Product: goshopify.Product{Handle: "gloves", Title: "Gloves"},
+			AddImages: []goshopify.Image{{Src: "https://erp.example.com/images/gloves.jpg"}}},
+		{Handle: "snowboard", Action: ActionUpdate, Fields: []string{"images[back.jpg]"},
+			Product: goshopify.Product{Id: 1, Handle: "snowboard"}, RemoveImages: []goshopify.Image{{Id: 22}}},
+		{Handle: "hat", Action: ActionUpdate, Fields: []string{"title"},
+			Product: goshopify.Product{Id: 2, Handle: "hat", Title: "Beanie", Variants: []goshopify.Variant{{Id: 5, Sku: "HAT", Price: price("10")}}}},
+		{Handle: "scarf", Action: ActionCreate, Product: goshopify.Product{Handle: "scarf"}},
+		{Handle: "discontinued", Action: ActionDelete, Product: goshopify.Product{Id: 3}},
+	}}
+
+	err := Apply(context.Background(), client, plan, nil)
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 || errs["scarf"] == nil {
+		t.Fatalf("Apply returned %v, expected the scarf to fail", err)
+	}
+
+	if len(bulk.vars) != 3 {
+		t.Fatalf("Apply set %d products, expected gloves, hat and scarf", len(bulk.vars))
+	}
+	hat, _ := json.Marshal(bulk.vars[1])
+	expected := `{"input":{"handle":"hat","id":"gid://shopify/Product/2","productOptions":[{"name":"Title","position":1,"values":[{"name":"Default Title"}]}],` +
+		`"title":"Beanie","variants":[{"id":"gid://shopify/ProductVariant/5","inventoryItem":{"sku":"HAT"},"optionValues":[{"name":"Default Title","optionName":"Title"}],"price":"10"}]}}`
+	if string(hat) != expected {
+		t.Errorf("Apply set hat to %s, expected %s", hat, expected)
+	}
+
+	if plan.Changes[0].Product.Id != 100 {
+		t.Errorf("Apply set created product id %d, expected 100", plan.Changes[0].Product.Id)
+	}
+
+	calls := httpmock.GetCallCountInfo()
+	for _, call := range []string{"POST " + base + "/100/images.json", "DELETE " + base + "/1/images/22.json", "DELETE " + base + "/3.json"} {
+		if calls[call] != 1 {
+			t.Errorf("Apply called %s %d times, expected once", call, calls[call])
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Package catalog syncs a product catalog, e.g. exported from an ERP, to a
+// shop. The desired products are diffed against the store, matching
+// products by handle, variants by SKU and images by alt text or file name,
+// and only the products which differ are written.
+package catalog
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// Action is what a Change does to a product
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a change of the catalog to a single product
+type Change struct {
+	Handle string
+	Action Action
+
+	// Fields lists what differs for updates, e.g. "title",
+	// "variants[SKU-1].price" or "images[front.jpg]"
+	Fields []string
+
+	// Product is the desired product, with the ids of the existing product,
+	// variants and images set for updates, or the existing product for
+	// deletes
+	Product goshopify.Product
+
+	// AddImages and RemoveImages are the images to add to and remove from
+	// the product, images aren't part of Product
+	AddImages    []goshopify.Image
+	RemoveImages []goshopify.Image
+}
+
+// Plan is the changes bringing the store to the desired catalog
+type Plan struct {
+	Changes []Change
+}
+
+// Empty tells whether the store already matches the desired catalog
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String summarizes the plan, e.g. "2 to create, 1 to update, 0 to delete"
+func (p *Plan) String() string {
+	count := map[Action]int{}
+	for _, c := range p.Changes {
+		count[c.Action]++
+	}
+	return fmt.Sprintf("%d to create, %d to update, %d to delete",
+		count[ActionCreate], count[ActionUpdate], count[ActionDelete])
+}
+
+// Options configure Diff and Sync
+type Options struct {
+	// DeleteMissing deletes the products of the store which aren't in the
+	// desired catalog. Off by default, products are only created and
+	// updated.
+	DeleteMissing bool
+
+	// Backoff between polls of the bulk operation, see
+	// goshopify.BulkOperationService
+	Backoff goshopify.Backoff
+}
+
+// Diff returns the changes bringing the existing products to the desired
+// ones. Only the fields set on the desired products are compared, an empty
+// field, e.g. a variant without a barcode, leaves the store's value alone.
+// Variants and images are managed when the desired product has some: the
+// existing ones which aren't desired are removed.
+func Diff(desired []goshopify.Product, existing []goshopify.Product, opts *Options) *Plan {
+	byHandle := make(map[string]goshopify.Product, len(existing))
+	for _, p := range existing {
+		byHandle[p.Handle] = p
+	}
+
+	plan := &Plan{}
+	wanted := make(map[string]bool, len(desired))
+	for _, p := range desired {
+		wanted[p.Handle] = true
+
+		current, ok := byHandle[p.Handle]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{
+				Handle:    p.Handle,
+				Action:    ActionCreate,
+				Product:   withoutImages(p),
+				AddImages: p.Images,
+			})
+			continue
+		}
+
+		change := diffProduct(p, current)
+		if len(change.Fields) > 0 {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	if opts != nil && opts.DeleteMissing {
+		for _, p := range existing {
+			if !wanted[p.Handle] {
+				plan.Changes = append(plan.Changes, Change{Handle: p.Handle, Action: ActionDelete, Product: p})
+			}
+		}
+	}
+	return plan
+}
+
+func withoutImages(p goshopify.Product) goshopify.Product {
+	p.Image = goshopify.Image{}
+	p.Images = nil
+	return p
+}
+
+func diffProduct(p goshopify.Product, current goshopify.Product) Change {
+	change := Change{Handle: p.Handle, Action: ActionUpdate}
+	differs := func(field string) {
+		change.Fields = append(change.Fields, field)
+	}
+
+	for _, f := range []struct {
+		name    string
+		desired string
+		current string
+	}{
+		{"title", p.Title, current.Title},
+		{"body_html", p.BodyHTML, current.BodyHTML},
+		{"vendor", p.Vendor, current.Vendor},
+		{"product_type", p.ProductType, current.ProductType},
+		{"status", string(p.Status), string(current.Status)},
+		{"template_suffix", p.TemplateSuffix, current.TemplateSuffix},
+	} {
+		if f.desired != "" && f.desired != f.current {
+			differs(f.name)
+		}
+	}
+	if p.Tags != "" && !sameTags(p.Tags, current.Tags) {
+		differs("tags")
+	}
+
+	p.Id = current.Id
+	if p.Variants != nil {
+		p.Variants = diffVariants(p.Variants, current.Variants, differs)
+	}
+	if p.Images != nil {
+		change.AddImages, change.RemoveImages = diffImages(p.Images, current.Images, differs)
+	}
+	change.Product = withoutImages(p)
+	return change
+}
+
+// diffVariants returns the desired variants with the ids of the existing
+// variants of the same SKU
+func diffVariants(desired []goshopify.Variant, existing []goshopify.Variant, differs func(string)) []goshopify.Variant {
+	bySku := make(map[string]goshopify.Variant, len(existing))
+	for _, v := range existing {
+		bySku[strings.TrimSpace(v.Sku)] = v
+	}
+	// a product without options has a single variant, which may have no SKU
+	if len(desired) == 1 && len(existing) == 1 && desired[0].Sku == "" {
+		bySku[""] = existing[0]
+	}
+
+	variants := make([]goshopify.Variant, 0, len(desired))
+	wanted := make(map[string]bool, len(desired))
+	for _, v := range desired {
+		sku := strings.TrimSpace(v.Sku)
+		wanted[sku] = true
+
+		current, ok := bySku[sku]
+		if !ok {
+			differs(fmt.Sprintf("variants[%s]", sku))
+			variants = append(variants, v)
+			continue
+		}
+
+		v.Id = current.Id
+		for _, f := range []struct {
+			name    string
+			desired string
+			current string
+		}{
+			{"option1", v.Option1, current.Option1},
+			{"option2", v.Option2, current.Option2},
+			{"option3", v.Option3, current.Option3},
+			{"barcode", v.Barcode, current.Barcode},
+			{"inventory_policy", string(v.InventoryPolicy), string(current.InventoryPolicy)},
+			{"weight_unit", v.WeightUnit, current.WeightUnit},
+		} {
+			if f.desired != "" && f.desired != f.current {
+				differs(fmt.Sprintf("variants[%s].%s", sku, f.name))
+			}
+		}
+		for _, f := range []struct {
+			name    string
+			desired *decimal.Decimal
+			current *decimal.Decimal
+		}{
+			{"price", v.Price, current.Price},
+			{"compare_at_price", v.CompareAtPrice, current.CompareAtPrice},
+			{"weight", v.Weight, current.Weight},
+		} {
+			if f.desired != nil && (f.current == nil || !f.desired.Equal(*f.current)) {
+				differs(fmt.Sprintf("variants[%s].%s", sku, f.name))
+			}
+		}
+		variants = append(variants, v)
+	}
+
+	for _, v := range existing {
+		if sku := strings.TrimSpace(v.Sku); !wanted[sku] && !(sku == "" && len(desired) == 1 && desired[0].Sku == "") {
+			differs(fmt.Sprintf("variants[%s]", sku))
+		}
+	}
+	return variants
+}
+
+// diffImages returns the desired images missing from the product and the
+// existing images which aren't desired
+func diffImages(desired []goshopify.Image, existing []goshopify.Image, differs func(string)) (add []goshopify.Image, remove []goshopify.Image) {
+	byKey := make(map[string]bool, len(existing))
+	for _, img := range existing {
+		byKey[imageKey(img)] = true
+	}
+	wanted := make(map[string]bool, len(desired))
+	for _, img := range desired {
+		key := imageKey(img)
+		wanted[key] = true
+		if !byKey[key] {
+			differs(fmt.Sprintf("images[%s]", key))
+			add = append(add, img)
+		}
+	}
+	for _, img := range existing {
+		if key := imageKey(img); !wanted[key] {
+			differs(fmt.Sprintf("images[%s]", key))
+			remove = append(remove, img)
+		}
+	}
+	return add, remove
+}
+
+// imageKey identifies an image by its alt text, or else the name of its file
+// without the version Shopify's CDN adds, e.g. front.jpg
+func imageKey(img goshopify.Image) string {
+	if img.Alt != "" {
+		return img.Alt
+	}
+	if img.Filename != "" {
+		return img.Filename
+	}
+	if u, err := url.Parse(img.Src); err == nil {
+		return path.Base(u.Path)
+	}
+	return img.Src
+}
+
+func sameTags(a string, b string) bool {
+	set := func(tags string) map[string]bool {
+		s := map[string]bool{}
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+				s[tag] = true
+			}
+		}
+		return s
+	}
+	as, bs := set(a), set(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	for tag := range as {
+		if !bs[tag] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+func price(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+var existingProducts = []goshopify.Product{
+	{
+		Id:     1,
+		Handle: "snowboard",
+		Title:  "Snowboard",
+		Vendor: "Burton",
+		Tags:   "winter, Sale",
+		Variants: []goshopify.Variant{
+			{Id: 11, Sku: "SB-150", Option1: "150", Price: price("100.00")},
+			{Id: 12, Sku: "SB-160", Option1: "160", Price: price("110.00")},
+		},
+		Images: []goshopify.Image{
+			{Id: 21, Src: "https://cdn.shopify.com/s/files/1/products/front.jpg?v=123"},
+			{Id: 22, Src: "https://cdn.shopify.com/s/files/1/products/back.jpg?v=123"},
+		},
+	},
+	{Id: 2, Handle: "hat", Title: "Hat"},
+	{Id: 3, Handle: "discontinued", Title: "Discontinued"},
+}
+
+func TestDiff(t *testing.T) {
+	desired := []goshopify.Product{
+		{
+			Handle:  "snowboard",
+			Title:   "Snowboard",
+			Tags:    "sale,winter",
+			Options: []goshopify.ProductOption{{Name: "Size"}},
+			Variants: []goshopify.Variant{
+				{Sku: "SB-150", Option1: "150", Price: price("100")},
+				{Sku: "SB-170", Option1: "170", Price: price("120")},
+			},
+			Images: []goshopify.Image{
+				{Src: "https://erp.example.com/images/front.jpg"},
+				{Src: "https://erp.example.com/images/side.jpg"},
+			},
+		},
+		{Handle: "hat", Title: "Hat"},
+		{Handle: "gloves", Title: "Gloves", Images: []goshopify.Image{{Src: "https://erp.example.com/images/gloves.jpg"}}},
+	}
+
+	plan := Diff(desired, existingProducts, &Options{DeleteMissing: true})
+	if len(plan.Changes) != 3 {
+		t.Fatalf("Diff returned %d changes, expected 3: %+v", len(plan.Changes), plan.Changes)
+	}
+
+	update := plan.Changes[0]
+	expectedFields := []string{"variants[SB-170]", "variants[SB-160]", "images[side.jpg]", "images[back.jpg]"}
+	if update.Action != ActionUpdate || !reflect.DeepEqual(update.Fields, expectedFields) {
+		t.Errorf("Diff returned update %s %v, expected fields %v", update.Action, update.Fields, expectedFields)
+	}
+	if update.Product.Id != 1 || update.Product.Variants[0].Id != 11 || update.Product.Variants[1].Id != 0 {
+		t.Errorf("Diff returned update product %+v without the existing ids", update.Product)
+	}
+	if len(update.AddImages) != 1 || len(update.RemoveImages) != 1 || update.RemoveImages[0].Id != 22 {
+		t.Errorf("Diff returned images to add %+v and remove %+v", update.AddImages, update.RemoveImages)
+	}
+
+	create := plan.Changes[1]
+	if create.Action != ActionCreate || create.Handle != "gloves" || len(create.AddImages) != 1 || create.Product.Images != nil {
+		t.Errorf("Diff returned create %+v", create)
+	}
+
+	if del := plan.Changes[2]; del.Action != ActionDelete || del.Product.Id != 3 {
+		t.Errorf("Diff returned delete %+v", del)
+	}
+
+	if s := plan.String(); s != "1 to create, 1 to update, 1 to delete" {
+		t.Errorf("Plan.String returned %s", s)
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	desired := []goshopify.Product{
+		{Handle: "snowboard", Vendor: "Burton", Variants: []goshopify.Variant{
+			{Sku: "SB-150", Price: price("100")},
+			{Sku: "SB-160"},
+		}},
+		{Handle: "hat"},
+	}
+
+	plan := Diff(desired, existingProducts, nil)
+	if !plan.Empty() {
+		t.Errorf("Diff returned changes %+v, expected none", plan.Changes)
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	desired := []goshopify.Product{
+		{Handle: "snowboard", Title: "Pro Snowboard", Status: goshopify.ProductStatusDraft, Variants: []goshopify.Variant{
+			{Sku: "SB-150", Price: price("90"), Barcode: "123"},
+			{Sku: "SB-160"},
+		}},
+	}
+
+	plan := Diff(desired, existingProducts, nil)
+	expected := []string{"title", "status", "variants[SB-150].barcode", "variants[SB-150].price"}
+	if len(plan.Changes) != 1 || !reflect.DeepEqual(plan.Changes[0].Fields, expected) {
+		t.Errorf("Diff returned %+v, expected fields %v", plan.Changes, expected)
+	}
+}
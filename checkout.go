@@ -0,0 +1,259 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const checkoutsBasePath = "checkouts"
+
+// how often the Wait methods of CheckoutService poll Shopify
+var checkoutPollInterval = time.Second
+
+// CheckoutService is an interface for interfacing with the checkout endpoints
+// of the (deprecated) Checkout API used by sales channels.
+// See: https://shopify.dev/docs/api/admin-rest/2023-07/resources/checkout
+type CheckoutService interface {
+	Create(context.Context, Checkout) (*Checkout, error)
+	Get(context.Context, string) (*Checkout, error)
+	Update(context.Context, Checkout) (*Checkout, error)
+	Complete(context.Context, string) (*Checkout, error)
+	ShippingRates(context.Context, string) ([]CheckoutShippingRate, error)
+	CreatePayment(context.Context, string, CheckoutPayment) (*CheckoutPayment, error)
+	ListPayments(context.Context, string) ([]CheckoutPayment, error)
+	GetPayment(context.Context, string, uint64) (*CheckoutPayment, error)
+	WaitForShippingRates(context.Context, string) ([]CheckoutShippingRate, error)
+	WaitForCompletion(context.Context, string) (*Checkout, error)
+	WaitForPayment(context.Context, string, uint64) (*CheckoutPayment, error)
+}
+
+// CheckoutServiceOp handles communication with the checkout related methods
+// of the Shopify API.
+type CheckoutServiceOp struct {
+	client *Client
+}
+
+// Checkout represents a Shopify checkout of the Checkout API
+type Checkout struct {
+	Token               string                `json:"token,omitempty"`
+	CartToken           string                `json:"cart_token,omitempty"`
+	Email               string                `json:"email,omitempty"`
+	Phone               string                `json:"phone,omitempty"`
+	Currency            string                `json:"currency,omitempty"`
+	PresentmentCurrency string                `json:"presentment_currency,omitempty"`
+	LineItems           []LineItem            `json:"line_items,omitempty"`
+	ShippingAddress     *Address              `json:"shipping_address,omitempty"`
+	BillingAddress      *Address              `json:"billing_address,omitempty"`
+	ShippingLine        *CheckoutShippingLine `json:"shipping_line,omitempty"`
+	DiscountCode        string                `json:"discount_code,omitempty"`
+	Note                string                `json:"note,omitempty"`
+	RequiresShipping    bool                  `json:"requires_shipping,omitempty"`
+	TaxesIncluded       bool                  `json:"taxes_included,omitempty"`
+	TaxLines            []TaxLine             `json:"tax_lines,omitempty"`
+	SubtotalPrice       *decimal.Decimal      `json:"subtotal_price,omitempty"`
+	TotalTax            *decimal.Decimal      `json:"total_tax,omitempty"`
+	TotalPrice          *decimal.Decimal      `json:"total_price,omitempty"`
+	PaymentDue          *decimal.Decimal      `json:"payment_due,omitempty"`
+	PaymentURL          string                `json:"payment_url,omitempty"`
+	WebURL              string                `json:"web_url,omitempty"`
+	Order               *CheckoutOrder        `json:"order,omitempty"`
+	OrderId             uint64                `json:"order_id,omitempty"`
+	CompletedAt         *time.Time            `json:"completed_at,omitempty"`
+	CreatedAt           *time.Time            `json:"created_at,omitempty"`
+	UpdatedAt           *time.Time            `json:"updated_at,omitempty"`
+}
+
+// CheckoutOrder is the order created by completing a checkout
+type CheckoutOrder struct {
+	Id        uint64 `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	StatusURL string `json:"status_url,omitempty"`
+}
+
+// CheckoutShippingLine selects a shipping rate by its handle
+type CheckoutShippingLine struct {
+	Handle string           `json:"handle,omitempty"`
+	Title  string           `json:"title,omitempty"`
+	Price  *decimal.Decimal `json:"price,omitempty"`
+}
+
+// CheckoutShippingRate represents a shipping rate available to a checkout
+type CheckoutShippingRate struct {
+	Id     string           `json:"id,omitempty"`
+	Title  string           `json:"title,omitempty"`
+	Price  *decimal.Decimal `json:"price,omitempty"`
+	Handle string           `json:"handle,omitempty"`
+}
+
+// CheckoutPayment represents a payment on a checkout
+type CheckoutPayment struct {
+	Id                            uint64                  `json:"id,omitempty"`
+	Amount                        *decimal.Decimal        `json:"amount,omitempty"`
+	UniqueToken                   string                  `json:"unique_token,omitempty"`
+	SessionId                     string                  `json:"session_id,omitempty"`
+	RequestDetails                *CheckoutRequestDetails `json:"request_details,omitempty"`
+	Transaction                   *Transaction            `json:"transaction,omitempty"`
+	PaymentProcessingErrorMessage string                  `json:"payment_processing_error_message,omitempty"`
+	Fraudulent                    bool                    `json:"fraudulent,omitempty"`
+	NextAction                    *struct {
+		RedirectURL string `json:"redirect_url,omitempty"`
+	} `json:"next_action,omitempty"`
+}
+
+// CheckoutRequestDetails describes the buyer's browser when creating a payment
+type CheckoutRequestDetails struct {
+	IpAddress      string `json:"ip_address,omitempty"`
+	AcceptLanguage string `json:"accept_language,omitempty"`
+	UserAgent      string `json:"user_agent,omitempty"`
+}
+
+// CheckoutResource represents the result from the checkouts/<token>.json endpoint
+type CheckoutResource struct {
+	Checkout *Checkout `json:"checkout"`
+}
+
+// CheckoutShippingRatesResource represents the result from the checkouts/<token>/shipping_rates.json endpoint
+type CheckoutShippingRatesResource struct {
+	ShippingRates []CheckoutShippingRate `json:"shipping_rates"`
+}
+
+// CheckoutPaymentResource represents the result from the checkouts/<token>/payments/<id>.json endpoint
+type CheckoutPaymentResource struct {
+	Payment *CheckoutPayment `json:"payment"`
+}
+
+// CheckoutPaymentsResource represents the result from the checkouts/<token>/payments.json endpoint
+type CheckoutPaymentsResource struct {
+	Payments []CheckoutPayment `json:"payments"`
+}
+
+// Create a checkout
+func (s *CheckoutServiceOp) Create(ctx context.Context, checkout Checkout) (*Checkout, error) {
+	path := fmt.Sprintf("%s.json", checkoutsBasePath)
+	wrappedData := CheckoutResource{Checkout: &checkout}
+	resource := new(CheckoutResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.Checkout, err
+}
+
+// Get a checkout by token
+func (s *CheckoutServiceOp) Get(ctx context.Context, token string) (*Checkout, error) {
+	path := fmt.Sprintf("%s/%s.json", checkoutsBasePath, token)
+	resource := new(CheckoutResource)
+	err := s.client.Get(ctx, path, resource, nil)
+	return resource.Checkout, err
+}
+
+// Update a checkout
+func (s *CheckoutServiceOp) Update(ctx context.Context, checkout Checkout) (*Checkout, error) {
+	path := fmt.Sprintf("%s/%s.json", checkoutsBasePath, checkout.Token)
+	wrappedData := CheckoutResource{Checkout: &checkout}
+	resource := new(CheckoutResource)
+	err := s.client.Put(ctx, path, wrappedData, resource)
+	return resource.Checkout, err
+}
+
+// Complete a checkout which doesn't require payment. Completion is
+// asynchronous, use WaitForCompletion for the created order.
+func (s *CheckoutServiceOp) Complete(ctx context.Context, token string) (*Checkout, error) {
+	path := fmt.Sprintf("%s/%s/complete.json", checkoutsBasePath, token)
+	resource := new(CheckoutResource)
+	err := s.client.Post(ctx, path, nil, resource)
+	return resource.Checkout, err
+}
+
+// ShippingRates lists the shipping rates of a checkout, which is empty while
+// Shopify is still calculating them, see WaitForShippingRates.
+func (s *CheckoutServiceOp) ShippingRates(ctx context.Context, token string) ([]CheckoutShippingRate, error) {
+	path := fmt.Sprintf("%s/%s/shipping_rates.json", checkoutsBasePath, token)
+	resource := new(CheckoutShippingRatesResource)
+	err := s.client.Get(ctx, path, resource, nil)
+	return resource.ShippingRates, err
+}
+
+// CreatePayment creates a payment on a checkout using a vaulted card session.
+// Payments are processed asynchronously, see WaitForPayment.
+func (s *CheckoutServiceOp) CreatePayment(ctx context.Context, token string, payment CheckoutPayment) (*CheckoutPayment, error) {
+	path := fmt.Sprintf("%s/%s/payments.json", checkoutsBasePath, token)
+	wrappedData := CheckoutPaymentResource{Payment: &payment}
+	resource := new(CheckoutPaymentResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.Payment, err
+}
+
+// ListPayments lists the payments of a checkout
+func (s *CheckoutServiceOp) ListPayments(ctx context.Context, token string) ([]CheckoutPayment, error) {
+	path := fmt.Sprintf("%s/%s/payments.json", checkoutsBasePath, token)
+	resource := new(CheckoutPaymentsResource)
+	err := s.client.Get(ctx, path, resource, nil)
+	return resource.Payments, err
+}
+
+// GetPayment gets a payment of a checkout
+func (s *CheckoutServiceOp) GetPayment(ctx context.Context, token string, paymentId uint64) (*CheckoutPayment, error) {
+	path := fmt.Sprintf("%s/%s/payments/%d.json", checkoutsBasePath, token, paymentId)
+	resource := new(CheckoutPaymentResource)
+	err := s.client.Get(ctx, path, resource, nil)
+	return resource.Payment, err
+}
+
+// WaitForShippingRates polls the shipping rates of a checkout until Shopify
+// returns some or ctx is done.
+func (s *CheckoutServiceOp) WaitForShippingRates(ctx context.Context, token string) ([]CheckoutShippingRate, error) {
+	for {
+		rates, err := s.ShippingRates(ctx, token)
+		if err != nil || len(rates) > 0 {
+			return rates, err
+		}
+
+		err = checkoutPollWait(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// WaitForCompletion polls a checkout until it is completed and its order
+// created, or ctx is done.
+func (s *CheckoutServiceOp) WaitForCompletion(ctx context.Context, token string) (*Checkout, error) {
+	for {
+		checkout, err := s.Get(ctx, token)
+		if err != nil || (checkout != nil && (checkout.Order != nil || checkout.OrderId != 0)) {
+			return checkout, err
+		}
+
+		err = checkoutPollWait(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// WaitForPayment polls a payment until it has been processed, or ctx is
+// done. A processed payment has a Transaction or a
+// PaymentProcessingErrorMessage.
+func (s *CheckoutServiceOp) WaitForPayment(ctx context.Context, token string, paymentId uint64) (*CheckoutPayment, error) {
+	for {
+		payment, err := s.GetPayment(ctx, token, paymentId)
+		if err != nil || (payment != nil && (payment.Transaction != nil || payment.PaymentProcessingErrorMessage != "")) {
+			return payment, err
+		}
+
+		err = checkoutPollWait(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func checkoutPollWait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(checkoutPollInterval):
+		return nil
+	}
+}
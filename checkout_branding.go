@@ -0,0 +1,332 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const checkoutBrandingFields = `
+    designSystem {
+      colors {
+        global {
+          brand
+          accent
+          decorative
+          success
+          warning
+          critical
+          info
+        }
+      }
+      cornerRadius {
+        base
+        small
+        large
+      }
+      typography {
+        primary {
+          ...checkoutBrandingFontGroup
+        }
+        secondary {
+          ...checkoutBrandingFontGroup
+        }
+        size {
+          base
+          ratio
+        }
+      }
+    }`
+
+const checkoutBrandingFontGroupFragment = `fragment checkoutBrandingFont on CheckoutBrandingFont {
+  weight
+  ... on CheckoutBrandingCustomFont {
+    genericFile {
+      id
+    }
+  }
+}
+
+fragment checkoutBrandingFontGroup on CheckoutBrandingFontGroup {
+  name
+  loadingStrategy
+  base {
+    ...checkoutBrandingFont
+  }
+  bold {
+    ...checkoutBrandingFont
+  }
+}`
+
+const checkoutProfilesQuery = `query checkoutProfiles($after: String) {
+  checkoutProfiles(first: 250, after: $after) {
+    nodes {
+      id
+      name
+      isPublished
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const checkoutBrandingQuery = `query checkoutBranding($checkoutProfileId: ID!) {
+  checkoutBranding(checkoutProfileId: $checkoutProfileId) {` + checkoutBrandingFields + `
+  }
+}
+` + checkoutBrandingFontGroupFragment
+
+const checkoutBrandingUpsertMutation = `mutation checkoutBrandingUpsert($checkoutProfileId: ID!, $checkoutBrandingInput: CheckoutBrandingInput) {
+  checkoutBrandingUpsert(checkoutProfileId: $checkoutProfileId, checkoutBrandingInput: $checkoutBrandingInput) {
+    checkoutBranding {` + checkoutBrandingFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}
+` + checkoutBrandingFontGroupFragment
+
+// CheckoutBrandingService is an interface for the checkout branding of
+// Shopify Plus shops through the GraphQL API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/queries/checkoutBranding
+type CheckoutBrandingService interface {
+	ListProfiles(context.Context) ([]CheckoutProfile, error)
+	Get(context.Context, uint64) (*CheckoutBranding, error)
+	Upsert(context.Context, uint64, CheckoutBranding) (*CheckoutBranding, error)
+}
+
+// CheckoutBrandingServiceOp handles communication with the checkout branding
+// through the GraphQL API
+type CheckoutBrandingServiceOp struct {
+	client *Client
+}
+
+// CheckoutProfile is a checkout configuration of the shop, the published one
+// is live
+type CheckoutProfile struct {
+	Id          uint64
+	Name        string
+	IsPublished bool
+}
+
+// CheckoutBranding is the branding of a checkout profile. It marshals to the
+// CheckoutBrandingInput of the GraphQL API, so branding read with Get can be
+// stored as JSON, e.g. in version control, and applied with Upsert. Nil and
+// zero values are left unchanged by Upsert.
+type CheckoutBranding struct {
+	DesignSystem *CheckoutBrandingDesignSystem `json:"designSystem,omitempty"`
+}
+
+// CheckoutBrandingDesignSystem holds the colors, corner radius and
+// typography of a checkout
+type CheckoutBrandingDesignSystem struct {
+	Colors       *CheckoutBrandingColors       `json:"colors,omitempty"`
+	CornerRadius *CheckoutBrandingCornerRadius `json:"cornerRadius,omitempty"`
+	Typography   *CheckoutBrandingTypography   `json:"typography,omitempty"`
+}
+
+// CheckoutBrandingColors are the colors of a checkout
+type CheckoutBrandingColors struct {
+	Global *CheckoutBrandingGlobalColors `json:"global,omitempty"`
+}
+
+// CheckoutBrandingGlobalColors are the colors used across the checkout as
+// hex codes, e.g. "#0B5FFF"
+type CheckoutBrandingGlobalColors struct {
+	Brand      string `json:"brand,omitempty"`
+	Accent     string `json:"accent,omitempty"`
+	Decorative string `json:"decorative,omitempty"`
+	Success    string `json:"success,omitempty"`
+	Warning    string `json:"warning,omitempty"`
+	Critical   string `json:"critical,omitempty"`
+	Info       string `json:"info,omitempty"`
+}
+
+// CheckoutBrandingCornerRadius are the corner radiuses in pixels
+type CheckoutBrandingCornerRadius struct {
+	Base  int `json:"base,omitempty"`
+	Small int `json:"small,omitempty"`
+	Large int `json:"large,omitempty"`
+}
+
+// CheckoutBrandingTypography is the typography of a checkout: the primary
+// font for body text, the secondary font for headings and the font sizes
+type CheckoutBrandingTypography struct {
+	Primary   *CheckoutBrandingFontGroup `json:"primary,omitempty"`
+	Secondary *CheckoutBrandingFontGroup `json:"secondary,omitempty"`
+	Size      *CheckoutBrandingFontSize  `json:"size,omitempty"`
+}
+
+// CheckoutBrandingFontGroup is either a font of the Shopify font library or
+// custom fonts uploaded as files
+type CheckoutBrandingFontGroup struct {
+	ShopifyFontGroup *CheckoutBrandingShopifyFontGroup `json:"shopifyFontGroup,omitempty"`
+	CustomFontGroup  *CheckoutBrandingCustomFontGroup  `json:"customFontGroup,omitempty"`
+}
+
+// CheckoutBrandingShopifyFontGroup is a font of the Shopify font library by
+// name, e.g. "Inter"
+type CheckoutBrandingShopifyFontGroup struct {
+	Name            string `json:"name"`
+	BaseWeight      int    `json:"baseWeight,omitempty"`
+	BoldWeight      int    `json:"boldWeight,omitempty"`
+	LoadingStrategy string `json:"loadingStrategy,omitempty"`
+}
+
+// CheckoutBrandingCustomFontGroup are custom fonts for regular and bold text
+type CheckoutBrandingCustomFontGroup struct {
+	Base            CheckoutBrandingCustomFont `json:"base"`
+	Bold            CheckoutBrandingCustomFont `json:"bold"`
+	LoadingStrategy string                     `json:"loadingStrategy,omitempty"`
+}
+
+// CheckoutBrandingCustomFont is a font file uploaded to the shop, by the
+// GraphQL id of the GenericFile
+type CheckoutBrandingCustomFont struct {
+	GenericFileId string `json:"genericFileId"`
+	Weight        int    `json:"weight,omitempty"`
+}
+
+// CheckoutBrandingFontSize is the base font size in pixels and the ratio
+// between the sizes
+type CheckoutBrandingFontSize struct {
+	Base  float64 `json:"base,omitempty"`
+	Ratio float64 `json:"ratio,omitempty"`
+}
+
+type graphQLCheckoutBrandingFont struct {
+	Weight      int `json:"weight"`
+	GenericFile *struct {
+		Id string `json:"id"`
+	} `json:"genericFile"`
+}
+
+type graphQLCheckoutBrandingFontGroup struct {
+	Name            string                       `json:"name"`
+	LoadingStrategy string                       `json:"loadingStrategy"`
+	Base            *graphQLCheckoutBrandingFont `json:"base"`
+	Bold            *graphQLCheckoutBrandingFont `json:"bold"`
+}
+
+// fontGroup returns the input of the font group, custom fonts if the base
+// font is a file
+func (g *graphQLCheckoutBrandingFontGroup) fontGroup() *CheckoutBrandingFontGroup {
+	if g == nil {
+		return nil
+	}
+	if g.Base != nil && g.Base.GenericFile != nil {
+		custom := &CheckoutBrandingCustomFontGroup{
+			Base:            CheckoutBrandingCustomFont{GenericFileId: g.Base.GenericFile.Id, Weight: g.Base.Weight},
+			LoadingStrategy: g.LoadingStrategy,
+		}
+		if g.Bold != nil && g.Bold.GenericFile != nil {
+			custom.Bold = CheckoutBrandingCustomFont{GenericFileId: g.Bold.GenericFile.Id, Weight: g.Bold.Weight}
+		}
+		return &CheckoutBrandingFontGroup{CustomFontGroup: custom}
+	}
+
+	shopify := &CheckoutBrandingShopifyFontGroup{Name: g.Name, LoadingStrategy: g.LoadingStrategy}
+	if g.Base != nil {
+		shopify.BaseWeight = g.Base.Weight
+	}
+	if g.Bold != nil {
+		shopify.BoldWeight = g.Bold.Weight
+	}
+	return &CheckoutBrandingFontGroup{ShopifyFontGroup: shopify}
+}
+
+type graphQLCheckoutBranding struct {
+	DesignSystem *struct {
+		Colors       *CheckoutBrandingColors       `json:"colors"`
+		CornerRadius *CheckoutBrandingCornerRadius `json:"cornerRadius"`
+		Typography   *struct {
+			Primary   *graphQLCheckoutBrandingFontGroup `json:"primary"`
+			Secondary *graphQLCheckoutBrandingFontGroup `json:"secondary"`
+			Size      *CheckoutBrandingFontSize         `json:"size"`
+		} `json:"typography"`
+	} `json:"designSystem"`
+}
+
+func (g *graphQLCheckoutBranding) branding() *CheckoutBranding {
+	branding := &CheckoutBranding{}
+	if g == nil || g.DesignSystem == nil {
+		return branding
+	}
+
+	ds := &CheckoutBrandingDesignSystem{
+		Colors:       g.DesignSystem.Colors,
+		CornerRadius: g.DesignSystem.CornerRadius,
+	}
+	if t := g.DesignSystem.Typography; t != nil {
+		ds.Typography = &CheckoutBrandingTypography{
+			Primary:   t.Primary.fontGroup(),
+			Secondary: t.Secondary.fontGroup(),
+			Size:      t.Size,
+		}
+	}
+	branding.DesignSystem = ds
+	return branding
+}
+
+// ListProfiles lists the checkout profiles of the shop
+func (s *CheckoutBrandingServiceOp) ListProfiles(ctx context.Context) ([]CheckoutProfile, error) {
+	var profiles []CheckoutProfile
+	err := s.client.GraphQLEachNode(ctx, checkoutProfilesQuery, map[string]interface{}{}, "checkoutProfiles", func(node json.RawMessage) error {
+		var p struct {
+			Id          string `json:"id"`
+			Name        string `json:"name"`
+			IsPublished bool   `json:"isPublished"`
+		}
+		if err := json.Unmarshal(node, &p); err != nil {
+			return err
+		}
+		id, err := ParseGraphQLId(p.Id)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, CheckoutProfile{Id: id, Name: p.Name, IsPublished: p.IsPublished})
+		return nil
+	})
+	return profiles, err
+}
+
+// Get gets the branding of a checkout profile. A profile without branding
+// returns an empty CheckoutBranding.
+func (s *CheckoutBrandingServiceOp) Get(ctx context.Context, checkoutProfileId uint64) (*CheckoutBranding, error) {
+	resp := struct {
+		CheckoutBranding *graphQLCheckoutBranding `json:"checkoutBranding"`
+	}{}
+	vars := map[string]interface{}{"checkoutProfileId": GraphQLId("CheckoutProfile", checkoutProfileId)}
+	err := s.client.GraphQL.Query(ctx, checkoutBrandingQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.CheckoutBranding.branding(), nil
+}
+
+// Upsert updates the branding of a checkout profile and returns the
+// resulting branding
+func (s *CheckoutBrandingServiceOp) Upsert(ctx context.Context, checkoutProfileId uint64, branding CheckoutBranding) (*CheckoutBranding, error) {
+	resp := struct {
+		CheckoutBrandingUpsert struct {
+			CheckoutBranding *graphQLCheckoutBranding `json:"checkoutBranding"`
+			UserErrors       GraphQLUserErrors        `json:"userErrors"`
+		} `json:"checkoutBrandingUpsert"`
+	}{}
+	vars := map[string]interface{}{
+		"checkoutProfileId":     GraphQLId("CheckoutProfile", checkoutProfileId),
+		"checkoutBrandingInput": branding,
+	}
+	err := s.client.GraphQL.Query(ctx, checkoutBrandingUpsertMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.CheckoutBrandingUpsert.UserErrors) > 0 {
+		return nil, resp.CheckoutBrandingUpsert.UserErrors
+	}
+	return resp.CheckoutBrandingUpsert.CheckoutBranding.branding(), nil
+}
@@ -0,0 +1,117 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCheckoutBrandingListProfiles(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		checkoutProfilesQuery: `{"data":{"checkoutProfiles":{"nodes":[
+			{"id":"gid://shopify/CheckoutProfile/1","name":"Default","isPublished":true},
+			{"id":"gid://shopify/CheckoutProfile/2","name":"Holiday","isPublished":false}
+		],"pageInfo":{"hasNextPage":false,"endCursor":null}}}}`,
+	}))
+
+	profiles, err := client.CheckoutBranding.ListProfiles(context.Background())
+	if err != nil {
+		t.Fatalf("CheckoutBranding.ListProfiles returned error: %v", err)
+	}
+	expected := []CheckoutProfile{{Id: 1, Name: "Default", IsPublished: true}, {Id: 2, Name: "Holiday"}}
+	if !reflect.DeepEqual(profiles, expected) {
+		t.Errorf("CheckoutBranding.ListProfiles returned %+v, expected %+v", profiles, expected)
+	}
+}
+
+func TestCheckoutBrandingGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		checkoutBrandingQuery: `{"data":{"checkoutBranding":{"designSystem":{
+			"colors":{"global":{"brand":"#0B5FFF","accent":"#FF5F0B"}},
+			"cornerRadius":{"base":4,"small":2,"large":8},
+			"typography":{
+				"primary":{"name":"Inter","loadingStrategy":"SWAP","base":{"weight":400},"bold":{"weight":700}},
+				"secondary":{"name":"Brand","loadingStrategy":null,
+					"base":{"weight":400,"genericFile":{"id":"gid://shopify/GenericFile/1"}},
+					"bold":{"weight":700,"genericFile":{"id":"gid://shopify/GenericFile/2"}}},
+				"size":{"base":16,"ratio":1.2}}}}}}`,
+	}))
+
+	branding, err := client.CheckoutBranding.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("CheckoutBranding.Get returned error: %v", err)
+	}
+	if len(sent) != 1 || sent[0].Variables["checkoutProfileId"] != "gid://shopify/CheckoutProfile/1" {
+		t.Errorf("CheckoutBranding.Get sent %+v", sent)
+	}
+
+	expected := &CheckoutBranding{DesignSystem: &CheckoutBrandingDesignSystem{
+		Colors:       &CheckoutBrandingColors{Global: &CheckoutBrandingGlobalColors{Brand: "#0B5FFF", Accent: "#FF5F0B"}},
+		CornerRadius: &CheckoutBrandingCornerRadius{Base: 4, Small: 2, Large: 8},
+		Typography: &CheckoutBrandingTypography{
+			Primary: &CheckoutBrandingFontGroup{ShopifyFontGroup: &CheckoutBrandingShopifyFontGroup{
+				Name: "Inter", BaseWeight: 400, BoldWeight: 700, LoadingStrategy: "SWAP",
+			}},
+			Secondary: &CheckoutBrandingFontGroup{CustomFontGroup: &CheckoutBrandingCustomFontGroup{
+				Base: CheckoutBrandingCustomFont{GenericFileId: "gid://shopify/GenericFile/1", Weight: 400},
+				Bold: CheckoutBrandingCustomFont{GenericFileId: "gid://shopify/GenericFile/2", Weight: 700},
+			}},
+			Size: &CheckoutBrandingFontSize{Base: 16, Ratio: 1.2},
+		},
+	}}
+	if !reflect.DeepEqual(branding, expected) {
+		t.Errorf("CheckoutBranding.Get returned %+v, expected %+v", branding, expected)
+	}
+}
+
+func TestCheckoutBrandingUpsert(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		checkoutBrandingUpsertMutation: `{"data":{"checkoutBrandingUpsert":{"checkoutBranding":{"designSystem":{
+			"colors":{"global":{"brand":"#0B5FFF"}},"cornerRadius":null,"typography":null}},"userErrors":[]}}}`,
+	}))
+
+	branding := CheckoutBranding{DesignSystem: &CheckoutBrandingDesignSystem{
+		Colors: &CheckoutBrandingColors{Global: &CheckoutBrandingGlobalColors{Brand: "#0B5FFF"}},
+	}}
+	updated, err := client.CheckoutBranding.Upsert(context.Background(), 1, branding)
+	if err != nil {
+		t.Fatalf("CheckoutBranding.Upsert returned error: %v", err)
+	}
+	if !reflect.DeepEqual(updated, &branding) {
+		t.Errorf("CheckoutBranding.Upsert returned %+v, expected %+v", updated, branding)
+	}
+
+	input, _ := json.Marshal(sent[0].Variables["checkoutBrandingInput"])
+	if string(input) != `{"designSystem":{"colors":{"global":{"brand":"#0B5FFF"}}}}` {
+		t.Errorf("CheckoutBranding.Upsert sent input %s", input)
+	}
+}
+
+func TestCheckoutBrandingUpsertUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		checkoutBrandingUpsertMutation: `{"data":{"checkoutBrandingUpsert":{"checkoutBranding":null,
+			"userErrors":[{"field":["checkoutBrandingInput"],"message":"Checkout branding is only available to Plus shops","code":"UNAUTHORIZED"}]}}}`,
+	}))
+
+	_, err := client.CheckoutBranding.Upsert(context.Background(), 1, CheckoutBranding{})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("CheckoutBranding.Upsert returned %v, expected GraphQLUserErrors", err)
+	}
+}
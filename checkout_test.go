@@ -0,0 +1,189 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func setCheckoutPollInterval(d time.Duration) func() {
+	previous := checkoutPollInterval
+	checkoutPollInterval = d
+	return func() { checkoutPollInterval = previous }
+}
+
+func TestCheckoutCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			expected := `{"checkout":{"email":"bob@example.com","line_items":[{"variant_id":1,"quantity":2}]}}`
+			if string(body) != expected {
+				t.Errorf("Checkout.Create sent %s, expected %s", body, expected)
+			}
+			return httpmock.NewStringResponse(201, `{"checkout":{"token":"abc","email":"bob@example.com","total_price":"20.00"}}`), nil
+		})
+
+	checkout, err := client.Checkout.Create(context.Background(), Checkout{
+		Email:     "bob@example.com",
+		LineItems: []LineItem{{VariantId: 1, Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Checkout.Create returned error: %v", err)
+	}
+
+	if checkout.Token != "abc" || !checkout.TotalPrice.Equal(decimal.NewFromFloat(20)) {
+		t.Errorf("Checkout.Create returned %+v", checkout)
+	}
+}
+
+func TestCheckoutUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"checkout":{"token":"abc","shipping_line":{"handle":"shopify-Standard-5.00","title":"Standard","price":"5.00"}}}`))
+
+	checkout, err := client.Checkout.Update(context.Background(), Checkout{
+		Token:        "abc",
+		ShippingLine: &CheckoutShippingLine{Handle: "shopify-Standard-5.00"},
+	})
+	if err != nil {
+		t.Fatalf("Checkout.Update returned error: %v", err)
+	}
+
+	if checkout.ShippingLine == nil || checkout.ShippingLine.Title != "Standard" {
+		t.Errorf("Checkout.Update returned %+v", checkout)
+	}
+}
+
+func TestCheckoutWaitForShippingRates(t *testing.T) {
+	setup()
+	defer teardown()
+	defer setCheckoutPollInterval(time.Millisecond)()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/shipping_rates.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return httpmock.NewStringResponse(202, `{"shipping_rates":[]}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"shipping_rates":[{"id":"shopify-Standard-5.00","handle":"shopify-Standard-5.00","title":"Standard","price":"5.00"}]}`), nil
+		})
+
+	rates, err := client.Checkout.WaitForShippingRates(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Checkout.WaitForShippingRates returned error: %v", err)
+	}
+
+	price := decimal.NewFromFloat(5)
+	if calls != 3 || len(rates) != 1 || rates[0].Handle != "shopify-Standard-5.00" || !rates[0].Price.Equal(price) {
+		t.Errorf("Checkout.WaitForShippingRates returned %+v after %d calls", rates, calls)
+	}
+}
+
+func TestCheckoutWaitForShippingRatesCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+	defer setCheckoutPollInterval(time.Hour)()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/shipping_rates.json", client.pathPrefix),
+		httpmock.NewStringResponder(202, `{"shipping_rates":[]}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Checkout.WaitForShippingRates(ctx, "abc")
+	if err != context.DeadlineExceeded {
+		t.Errorf("Checkout.WaitForShippingRates err returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCheckoutCompleteAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+	defer setCheckoutPollInterval(time.Millisecond)()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/complete.json", client.pathPrefix),
+		httpmock.NewStringResponder(202, `{"checkout":{"token":"abc"}}`))
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 2 {
+				return httpmock.NewStringResponse(202, `{"checkout":{"token":"abc"}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"checkout":{"token":"abc","order":{"id":1,"name":"#1001"}}}`), nil
+		})
+
+	_, err := client.Checkout.Complete(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Checkout.Complete returned error: %v", err)
+	}
+
+	checkout, err := client.Checkout.WaitForCompletion(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Checkout.WaitForCompletion returned error: %v", err)
+	}
+
+	expected := &CheckoutOrder{Id: 1, Name: "#1001"}
+	if !reflect.DeepEqual(checkout.Order, expected) {
+		t.Errorf("Checkout.WaitForCompletion returned order %+v, expected %+v", checkout.Order, expected)
+	}
+}
+
+func TestCheckoutPayments(t *testing.T) {
+	setup()
+	defer teardown()
+	defer setCheckoutPollInterval(time.Millisecond)()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/payments.json", client.pathPrefix),
+		httpmock.NewStringResponder(202, `{"payment":{"id":5,"unique_token":"u1"}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/payments.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"payments":[{"id":5},{"id":6}]}`))
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/checkouts/abc/payments/5.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 2 {
+				return httpmock.NewStringResponse(202, `{"payment":{"id":5}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"payment":{"id":5,"transaction":{"id":9,"status":"success"}}}`), nil
+		})
+
+	amount := decimal.NewFromFloat(20)
+	payment, err := client.Checkout.CreatePayment(context.Background(), "abc", CheckoutPayment{
+		Amount:      &amount,
+		UniqueToken: "u1",
+		SessionId:   "east-123",
+	})
+	if err != nil || payment.Id != 5 {
+		t.Fatalf("Checkout.CreatePayment returned %+v, %v", payment, err)
+	}
+
+	payments, err := client.Checkout.ListPayments(context.Background(), "abc")
+	if err != nil || len(payments) != 2 {
+		t.Errorf("Checkout.ListPayments returned %+v, %v", payments, err)
+	}
+
+	payment, err = client.Checkout.WaitForPayment(context.Background(), "abc", 5)
+	if err != nil {
+		t.Fatalf("Checkout.WaitForPayment returned error: %v", err)
+	}
+
+	if payment.Transaction == nil || payment.Transaction.Id != 9 || payment.Transaction.Status != "success" {
+		t.Errorf("Checkout.WaitForPayment returned %+v", payment)
+	}
+}
@@ -0,0 +1,102 @@
+package goshopify
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without making a request while the client's
+// circuit breaker is open, see WithCircuitBreaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker opens after a number of consecutive failures and lets a
+// single probe request through once the cooldown has passed.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     circuitState
+	openedAt  time.Time
+
+	// overridden in tests
+	now func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may be sent
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		// let a single probe through
+		b.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// a probe is already in flight
+		return false
+	}
+
+	return true
+}
+
+// record records the outcome of a request that was allowed through
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// abandon releases a request the caller gave up on without recording an
+// outcome. An abandoned probe leaves the circuit open with its cooldown
+// passed, so the next request probes again.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+	}
+}
+
+// isCircuitFailure reports whether the outcome of an attempt indicates a
+// degraded shop, i.e. a transport error or timeout, or a 5xx response.
+func isCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
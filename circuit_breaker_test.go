@@ -0,0 +1,134 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.record(true)
+	if !b.allow() {
+		t.Errorf("circuitBreaker opened before reaching the threshold")
+	}
+
+	b.record(true)
+	if b.allow() {
+		t.Errorf("circuitBreaker did not open after reaching the threshold")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Errorf("circuitBreaker did not let a probe through after the cooldown")
+	}
+	if b.allow() {
+		t.Errorf("circuitBreaker let a second probe through")
+	}
+
+	// a failed probe opens the circuit again
+	b.record(true)
+	if b.allow() {
+		t.Errorf("circuitBreaker did not reopen after a failed probe")
+	}
+
+	now = now.Add(time.Minute)
+	b.allow()
+	b.record(false)
+	if !b.allow() || !b.allow() {
+		t.Errorf("circuitBreaker did not close after a successful probe")
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(2, time.Minute)
+
+	b.record(true)
+	b.record(false)
+	b.record(true)
+	if !b.allow() {
+		t.Errorf("circuitBreaker opened on non consecutive failures")
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCircuitBreaker(2, time.Hour)(client)
+	client.retries = 0
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(http.StatusInternalServerError, `{"errors":"Internal Server Error"}`), nil
+		})
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Shop.Get(context.Background(), nil)
+		if err == nil {
+			t.Errorf("Shop.Get expected an error")
+		}
+		if i == 2 && err != ErrCircuitOpen {
+			t.Errorf("Shop.Get err returned %v, expected %v", err, ErrCircuitOpen)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("circuit breaker let %d requests through, expected %d", calls, 2)
+	}
+}
+
+func TestWithCircuitBreakerCancelledProbe(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCircuitBreaker(1, time.Hour)(client)
+	client.retries = 0
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.breaker.now = func() time.Time { return now }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	status := http.StatusInternalServerError
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 2 {
+				// the caller gives up on the probe
+				cancel()
+				return nil, req.Context().Err()
+			}
+			return httpmock.NewStringResponse(status, `{"shop":{}}`), nil
+		})
+
+	if _, err := client.Shop.Get(context.Background(), nil); err == nil {
+		t.Fatalf("Shop.Get expected an error")
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := client.Shop.Get(ctx, nil); err == nil || err == ErrCircuitOpen {
+		t.Fatalf("Shop.Get returned %v, expected the cancellation", err)
+	}
+	if client.breaker.state != circuitOpen || client.breaker.failures != 1 {
+		t.Errorf("cancelled probe left the circuit %v with %d failures, expected it open with 1",
+			client.breaker.state, client.breaker.failures)
+	}
+
+	// the next request probes again, a cancelled probe doesn't block the circuit
+	status = http.StatusOK
+	if _, err := client.Shop.Get(context.Background(), nil); err != nil {
+		t.Errorf("Shop.Get returned %v after a cancelled probe", err)
+	}
+	if calls != 3 || client.breaker.state != circuitClosed {
+		t.Errorf("circuit breaker made %d calls and is %v, expected 3 and closed", calls, client.breaker.state)
+	}
+}
@@ -0,0 +1,200 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrTokenNotFound is returned by a TokenStore when no token is stored for a
+// shop, e.g. because the app was never installed or has been uninstalled.
+var ErrTokenNotFound = errors.New("access token not found")
+
+// TokenStore persists access tokens by shop domain. Implementations backed by
+// SQL, Redis or any other storage can be plugged into a ClientManager.
+type TokenStore interface {
+	GetToken(ctx context.Context, shop string) (string, error)
+	SaveToken(ctx context.Context, shop, token string) error
+	DeleteToken(ctx context.Context, shop string) error
+}
+
+// MemoryTokenStore is an in-memory TokenStore
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]string{}}
+}
+
+// GetToken returns the token stored for the shop or ErrTokenNotFound
+func (s *MemoryTokenStore) GetToken(ctx context.Context, shop string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	token, ok := s.tokens[shop]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// SaveToken stores the token for the shop
+func (s *MemoryTokenStore) SaveToken(ctx context.Context, shop, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[shop] = token
+	return nil
+}
+
+// DeleteToken removes the token for the shop
+func (s *MemoryTokenStore) DeleteToken(ctx context.Context, shop string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, shop)
+	return nil
+}
+
+// ClientManager maps shop domains to clients for apps installed on many
+// shops. Tokens are loaded from a TokenStore and clients are created lazily
+// and reused.
+type ClientManager struct {
+	app   App
+	store TokenStore
+	opts  []Option
+
+	// OnUninstall is called after a shop's token has been invalidated, e.g.
+	// because Shopify rejected the access token, see IsInvalidTokenError. Apps
+	// use it to mark the shop as uninstalled and stop queuing work for it.
+	OnUninstall func(ctx context.Context, shop string)
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientManager returns a ClientManager creating clients for the app with
+// the given options. Clients invalidate their shop automatically when Shopify
+// rejects their token.
+func NewClientManager(app App, store TokenStore, opts ...Option) *ClientManager {
+	return &ClientManager{
+		app:     app,
+		store:   store,
+		opts:    opts,
+		clients: map[string]*Client{},
+	}
+}
+
+// Client returns the client for the shop, loading its token from the store on
+// first use. It returns ErrTokenNotFound if the shop has no token.
+func (m *ClientManager) Client(ctx context.Context, shop string) (*Client, error) {
+	shop = ShopFullName(shop)
+
+	m.mu.Lock()
+	c, ok := m.clients[shop]
+	m.mu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	// the store is read without holding the lock, so a slow lookup doesn't
+	// hold back the clients of other shops
+	token, err := m.store.GetToken(ctx, shop)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err = m.newClient(shop, token)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.clients[shop]; ok {
+		// created or installed meanwhile
+		return existing, nil
+	}
+	m.clients[shop] = c
+	return c, nil
+}
+
+func (m *ClientManager) newClient(shop, token string) (*Client, error) {
+	opts := append(m.opts[:len(m.opts):len(m.opts)], WithInvalidTokenCallback(m.invalidTokenCallback))
+	return NewClient(m.app, shop, token, opts...)
+}
+
+// Install stores the token of a newly installed shop and returns its client
+func (m *ClientManager) Install(ctx context.Context, shop, token string) (*Client, error) {
+	shop = ShopFullName(shop)
+
+	err := m.store.SaveToken(ctx, shop, token)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := m.newClient(shop, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// replaces any client of the previous token, including one created
+	// concurrently from the token stored before
+	m.mu.Lock()
+	m.clients[shop] = c
+	m.mu.Unlock()
+
+	return c, nil
+}
+
+// Invalidate deletes the shop's token, drops its client and calls
+// OnUninstall.
+func (m *ClientManager) Invalidate(ctx context.Context, shop string) error {
+	shop = ShopFullName(shop)
+
+	m.mu.Lock()
+	delete(m.clients, shop)
+	m.mu.Unlock()
+
+	err := m.store.DeleteToken(ctx, shop)
+	if err != nil {
+		return err
+	}
+
+	if m.OnUninstall != nil {
+		m.OnUninstall(ctx, shop)
+	}
+
+	return nil
+}
+
+// invalidTokenCallback invalidates the shop only if the rejected token is
+// still the stored one, so a late rejection of a client created before Install
+// saved a fresh token neither deletes it nor uninstalls the shop.
+func (m *ClientManager) invalidTokenCallback(ctx context.Context, shop, token string, err error) {
+	m.mu.Lock()
+	if c, ok := m.clients[shop]; ok && c.Token() == token {
+		delete(m.clients, shop)
+	}
+	m.mu.Unlock()
+
+	stored, storeErr := m.store.GetToken(ctx, shop)
+	if storeErr != nil || stored != token {
+		return
+	}
+	_ = m.Invalidate(ctx, shop)
+}
+
+// CheckError invalidates the shop if err is a 401 returned by Shopify because
+// it rejected the access token, see IsInvalidTokenError. Other 401s, e.g. for
+// a missing scope, keep the shop. The original error is returned unchanged.
+func (m *ClientManager) CheckError(ctx context.Context, shop string, err error) error {
+	if IsInvalidTokenError(err) {
+		if invalidateErr := m.Invalidate(ctx, shop); invalidateErr != nil {
+			return invalidateErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+
+	_, err := store.GetToken(ctx, "fooshop.myshopify.com")
+	if err != ErrTokenNotFound {
+		t.Errorf("MemoryTokenStore.GetToken err returned %v, expected %v", err, ErrTokenNotFound)
+	}
+
+	_ = store.SaveToken(ctx, "fooshop.myshopify.com", "abcd")
+	token, err := store.GetToken(ctx, "fooshop.myshopify.com")
+	if err != nil || token != "abcd" {
+		t.Errorf("MemoryTokenStore.GetToken returned %s, %v, expected abcd", token, err)
+	}
+
+	_ = store.DeleteToken(ctx, "fooshop.myshopify.com")
+	_, err = store.GetToken(ctx, "fooshop.myshopify.com")
+	if err != ErrTokenNotFound {
+		t.Errorf("MemoryTokenStore.GetToken err returned %v, expected %v", err, ErrTokenNotFound)
+	}
+}
+
+func TestClientManagerClient(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	_ = store.SaveToken(ctx, "fooshop.myshopify.com", "abcd")
+
+	m := NewClientManager(app, store, WithVersion(testApiVersion))
+
+	c, err := m.Client(ctx, "fooshop")
+	if err != nil {
+		t.Fatalf("ClientManager.Client returned error: %v", err)
+	}
+
+	if c.token != "abcd" || c.baseURL.String() != "https://fooshop.myshopify.com" || c.apiVersion != testApiVersion {
+		t.Errorf("ClientManager.Client returned client for %s with token %s", c.baseURL, c.token)
+	}
+
+	again, _ := m.Client(ctx, "fooshop.myshopify.com")
+	if again != c {
+		t.Errorf("ClientManager.Client did not reuse the client")
+	}
+
+	_, err = m.Client(ctx, "barshop")
+	if err != ErrTokenNotFound {
+		t.Errorf("ClientManager.Client err returned %v, expected %v", err, ErrTokenNotFound)
+	}
+}
+
+// blockingTokenStore blocks the lookups of a shop until release is closed
+type blockingTokenStore struct {
+	*MemoryTokenStore
+	shop    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingTokenStore) GetToken(ctx context.Context, shop string) (string, error) {
+	if shop == s.shop {
+		close(s.started)
+		<-s.release
+	}
+	return s.MemoryTokenStore.GetToken(ctx, shop)
+}
+
+func TestClientManagerClientSlowStore(t *testing.T) {
+	ctx := context.Background()
+	store := &blockingTokenStore{
+		MemoryTokenStore: NewMemoryTokenStore(),
+		shop:             "slowshop.myshopify.com",
+		started:          make(chan struct{}),
+		release:          make(chan struct{}),
+	}
+	_ = store.SaveToken(ctx, "fooshop.myshopify.com", "abcd")
+	_ = store.SaveToken(ctx, "slowshop.myshopify.com", "efgh")
+	m := NewClientManager(app, store)
+
+	slow := make(chan *Client)
+	go func() {
+		c, _ := m.Client(ctx, "slowshop")
+		slow <- c
+	}()
+	<-store.started
+
+	done := make(chan struct{})
+	go func() {
+		if c, err := m.Client(ctx, "fooshop"); err != nil || c.Token() != "abcd" {
+			t.Errorf("ClientManager.Client returned %v, %v", c, err)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("ClientManager.Client waited for the lookup of another shop")
+	}
+
+	close(store.release)
+	if c := <-slow; c == nil || c.Token() != "efgh" {
+		t.Errorf("ClientManager.Client returned %v for the slow shop", c)
+	}
+}
+
+func TestClientManagerInstallAndInvalidate(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	m := NewClientManager(app, store)
+
+	var uninstalled []string
+	m.OnUninstall = func(ctx context.Context, shop string) {
+		uninstalled = append(uninstalled, shop)
+	}
+
+	c, err := m.Install(ctx, "fooshop", "abcd")
+	if err != nil || c.token != "abcd" {
+		t.Fatalf("ClientManager.Install returned %v, %v", c, err)
+	}
+
+	c, _ = m.Install(ctx, "fooshop", "efgh")
+	if c.token != "efgh" {
+		t.Errorf("ClientManager.Install did not replace the client, token is %s", c.token)
+	}
+
+	err = m.Invalidate(ctx, "fooshop")
+	if err != nil {
+		t.Errorf("ClientManager.Invalidate returned error: %v", err)
+	}
+
+	if len(uninstalled) != 1 || uninstalled[0] != "fooshop.myshopify.com" {
+		t.Errorf("ClientManager.OnUninstall called with %v", uninstalled)
+	}
+
+	_, err = m.Client(ctx, "fooshop")
+	if err != ErrTokenNotFound {
+		t.Errorf("ClientManager.Client err returned %v, expected %v", err, ErrTokenNotFound)
+	}
+}
+
+func TestClientManagerCheckError(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	m := NewClientManager(app, store, WithVersion(testApiVersion))
+
+	c, _ := m.Install(ctx, "fooshop", "abcd")
+	httpmock.ActivateNonDefault(c.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", c.pathPrefix),
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`))
+
+	_, err := c.Shop.Get(ctx, nil)
+	err = m.CheckError(ctx, "fooshop", err)
+	if err == nil {
+		t.Fatalf("ClientManager.CheckError swallowed the error")
+	}
+
+	_, err = m.Client(ctx, "fooshop")
+	if err != ErrTokenNotFound {
+		t.Errorf("ClientManager.CheckError did not invalidate the shop, got %v", err)
+	}
+
+	other := errors.New("other")
+	_, _ = m.Install(ctx, "fooshop", "abcd")
+	if m.CheckError(ctx, "fooshop", other) != other {
+		t.Errorf("ClientManager.CheckError changed the error")
+	}
+
+	if _, err = m.Client(ctx, "fooshop"); err != nil {
+		t.Errorf("ClientManager.CheckError invalidated the shop on a non 401 error")
+	}
+
+	// a 401 which isn't about the token keeps it
+	scope := ResponseError{Status: 401, Message: "[API] This action requires merchant approval for write_orders scope."}
+	if !reflect.DeepEqual(m.CheckError(ctx, "fooshop", scope), scope) {
+		t.Errorf("ClientManager.CheckError changed the error")
+	}
+	if _, err = m.Client(ctx, "fooshop"); err != nil {
+		t.Errorf("ClientManager.CheckError invalidated the shop on a 401 of another reason")
+	}
+}
+
+func TestClientManagerInvalidatesOnUnauthorized(t *testing.T) {
+	ctx := context.Background()
+	m := NewClientManager(app, NewMemoryTokenStore(), WithVersion(testApiVersion))
+
+	uninstalled := ""
+	m.OnUninstall = func(ctx context.Context, shop string) {
+		uninstalled = shop
+	}
+
+	c, _ := m.Install(ctx, "fooshop", "abcd")
+	httpmock.ActivateNonDefault(c.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", c.pathPrefix),
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`))
+
+	_, err := c.Shop.Get(ctx, nil)
+	if !IsInvalidTokenError(err) {
+		t.Errorf("Shop.Get err returned %v, expected an invalid token error", err)
+	}
+
+	if uninstalled != "fooshop.myshopify.com" {
+		t.Errorf("ClientManager.OnUninstall called with %q, expected fooshop.myshopify.com", uninstalled)
+	}
+}
+
+func TestClientManagerKeepsReinstalledToken(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	m := NewClientManager(app, store, WithVersion(testApiVersion))
+
+	uninstalled := ""
+	m.OnUninstall = func(ctx context.Context, shop string) {
+		uninstalled = shop
+	}
+
+	stale, _ := m.Install(ctx, "fooshop", "abcd")
+	httpmock.ActivateNonDefault(stale.Client)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", stale.pathPrefix),
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`))
+
+	// the shop reinstalls the app before the old client's request fails
+	fresh, _ := m.Install(ctx, "fooshop", "efgh")
+	_, _ = stale.Shop.Get(ctx, nil)
+
+	if uninstalled != "" {
+		t.Errorf("ClientManager.OnUninstall called with %q for a replaced token", uninstalled)
+	}
+	if token, err := store.GetToken(ctx, "fooshop.myshopify.com"); err != nil || token != "efgh" {
+		t.Errorf("ClientManager stored token %s, %v, expected efgh", token, err)
+	}
+	if c, _ := m.Client(ctx, "fooshop"); c != fresh {
+		t.Errorf("ClientManager.Client dropped the client of the fresh token")
+	}
+}
@@ -0,0 +1,63 @@
+package goshopify
+
+// Clone returns a copy of the client changed by the options, e.g. with
+// another token or API version for a tenant, leaving the client unchanged.
+// The copy shares the HTTP client, logger and metrics collector and, as they
+// track the shop's rate limits and failures, the priority queue and circuit
+// breaker, unless options replace them. Its rate limits start out empty.
+func (c *Client) Clone(opts ...Option) *Client {
+	baseURL := *c.baseURL
+
+	var graphQLReads map[string]bool
+	if c.graphQLReads != nil {
+		graphQLReads = make(map[string]bool, len(c.graphQLReads))
+		for resource, enabled := range c.graphQLReads {
+			graphQLReads[resource] = enabled
+		}
+	}
+
+	clone := &Client{
+		Client:           c.Client,
+		log:              c.log,
+		app:              c.app,
+		baseURL:          &baseURL,
+		shop:             c.shop,
+		pathPrefix:       c.pathPrefix,
+		apiVersion:       c.version(),
+		token:            c.Token(),
+		tokenRefresher:   c.tokenRefresher,
+		retries:          c.retries,
+		onInvalidToken:   c.onInvalidToken,
+		metrics:          c.metrics,
+		breaker:          c.breaker,
+		queue:            c.queue,
+		pageRetry:        c.pageRetry,
+		graphQLReads:     graphQLReads,
+		dryRun:           c.dryRun,
+		createValidation: c.createValidation,
+		strictEnums:      c.strictEnums,
+		gzipThreshold:    c.gzipThreshold,
+		requestTimeout:   c.requestTimeout,
+	}
+	clone.initServices()
+
+	for _, opt := range opts {
+		opt(clone)
+	}
+	return clone
+}
+
+// CurrentRateLimits returns the rate limits reported by the last response
+func (c *Client) CurrentRateLimits() RateLimitInfo {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.RateLimits
+}
+
+// version returns the API version of the client, which changes from "stable"
+// to the actual version with the first response
+func (c *Client) version() string {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	return c.apiVersion
+}
@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestClone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"shop":{"name":"%s"}}`, req.Header.Get("X-Shopify-Access-Token"))), nil
+		})
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/api/2024-07/shop.json",
+		httpmock.NewStringResponder(200, `{"shop":{"name":"2024-07"}}`))
+
+	clone := client.Clone(WithVersion("2024-07"))
+	clone.SetToken("tenant")
+
+	shop, err := clone.Shop.Get(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Shop.Get of the clone returned error: %v", err)
+	}
+	if shop.Name != "2024-07" {
+		t.Errorf("Shop.Get of the clone got %s, expected the 2024-07 version", shop.Name)
+	}
+
+	shop, err = client.Shop.Get(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+	if shop.Name != "abcd" || client.Token() != "abcd" || client.apiVersion != testApiVersion {
+		t.Errorf("Clone changed the client: shop %s, token %s, version %s", shop.Name, client.Token(), client.apiVersion)
+	}
+	if clone.retries != client.retries || clone.ShopDomain() != client.ShopDomain() {
+		t.Errorf("Clone didn't copy the configuration: %d retries, shop %s", clone.retries, clone.ShopDomain())
+	}
+}
+
+func TestCloneCopiesGraphQLReads(t *testing.T) {
+	c := MustNewClient(app, "fooshop", "abcd", WithGraphQLReads("products"))
+	clone := c.Clone(WithGraphQLReads("orders"))
+
+	if !clone.graphQLReads["products"] || !clone.graphQLReads["orders"] {
+		t.Errorf("Clone has graphql reads %v, expected products and orders", clone.graphQLReads)
+	}
+	if c.graphQLReads["orders"] {
+		t.Errorf("Clone changed the graphql reads of the client to %v", c.graphQLReads)
+	}
+}
+
+// TestClientConcurrentUse is meant to be run with -race
+func TestClientConcurrentUse(t *testing.T) {
+	c := MustNewClient(app, "fooshop", "abcd", WithRetry(2))
+	httpmock.ActivateNonDefault(c.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", c.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"shop":{"id":1}}`)
+			resp.Header.Set("X-Shopify-API-Version", "2024-07")
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "1/40")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", c.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{},"extensions":{"cost":{"requestedQueryCost":1,"actualQueryCost":1,"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":999,"restoreRate":50}}}}`))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := context.Background()
+			if _, err := c.Shop.Get(ctx, nil); err != nil {
+				t.Errorf("Shop.Get returned error: %v", err)
+			}
+			if err := c.GraphQL.Query(ctx, "{ shop { id } }", nil, nil); err != nil {
+				t.Errorf("GraphQL.Query returned error: %v", err)
+			}
+			c.CurrentRateLimits()
+			c.Clone().SetToken(fmt.Sprintf("token-%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	limits := c.CurrentRateLimits()
+	if limits.BucketSize != 40 || limits.GraphQLCost == nil || c.version() != "2024-07" {
+		t.Errorf("client has rate limits %+v and version %s after concurrent requests", limits, c.version())
+	}
+}
@@ -0,0 +1,508 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// scalarTypes maps the scalars of the Admin API to Go types, unknown scalars
+// are generated as string
+var scalarTypes = map[string]string{
+	"ID":            "string",
+	"String":        "string",
+	"Int":           "int",
+	"Float":         "float64",
+	"Boolean":       "bool",
+	"DateTime":      "time.Time",
+	"Decimal":       "decimal.Decimal",
+	"Money":         "decimal.Decimal",
+	"UnsignedInt64": "string",
+	"JSON":          "json.RawMessage",
+}
+
+var scalarImports = map[string]string{
+	"time.Time":       "time",
+	"decimal.Decimal": "github.com/shopspring/decimal",
+	"json.RawMessage": "encoding/json",
+}
+
+// sourceFile is a parsed .graphql file
+type sourceFile struct {
+	name string
+	doc  *document
+}
+
+type generator struct {
+	schema    *schema
+	fragments map[string]*fragment
+	imports   map[string]bool
+	names     map[string]bool
+	inputs    map[string]bool
+	buf       bytes.Buffer
+}
+
+// nestedObject is an object type to generate for a field selecting
+// subfields
+type nestedObject struct {
+	name       string
+	parent     string
+	selections []*selection
+}
+
+type collectedField struct {
+	key        string
+	typ        *schemaTypeRef
+	selections []*selection
+}
+
+// generate returns the formatted Go source for the operations of the files
+func generate(s *schema, pkg string, files []sourceFile) ([]byte, error) {
+	g := &generator{
+		schema:    s,
+		fragments: map[string]*fragment{},
+		imports:   map[string]bool{"context": true, "github.com/bold-commerce/go-shopify/v4": true},
+		names:     map[string]bool{},
+		inputs:    map[string]bool{},
+	}
+
+	for _, file := range files {
+		for name, f := range file.doc.fragments {
+			if _, ok := g.fragments[name]; ok {
+				return nil, fmt.Errorf("%s: fragment %s is defined twice", file.name, name)
+			}
+			g.fragments[name] = f
+		}
+	}
+
+	for _, file := range files {
+		for _, op := range file.doc.operations {
+			if err := g.operation(op); err != nil {
+				return nil, fmt.Errorf("%s: %s %s: %v", file.name, op.kind, op.name, err)
+			}
+		}
+	}
+
+	if err := g.inputTypes(); err != nil {
+		return nil, err
+	}
+
+	return g.source(pkg, files)
+}
+
+func (g *generator) source(pkg string, files []sourceFile) ([]byte, error) {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.name
+	}
+
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		if isStdImport(imports[i]) != isStdImport(imports[j]) {
+			return isStdImport(imports[i])
+		}
+		return imports[i] < imports[j]
+	})
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by graphqlgen from %s. DO NOT EDIT.\n\n", strings.Join(names, ", "))
+	fmt.Fprintf(out, "package %s\n\nimport (\n", pkg)
+	for i, path := range imports {
+		// standard library imports first, separated from the others
+		if i > 0 && !isStdImport(path) && isStdImport(imports[i-1]) {
+			out.WriteString("\n")
+		}
+		if path == "github.com/bold-commerce/go-shopify/v4" {
+			fmt.Fprintf(out, "\tgoshopify %q\n", path)
+		} else {
+			fmt.Fprintf(out, "\t%q\n", path)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+func (g *generator) declare(name string) error {
+	if g.names[name] {
+		return fmt.Errorf("generated name %s is declared twice", name)
+	}
+	g.names[name] = true
+	return nil
+}
+
+func (g *generator) operation(op *operation) error {
+	root, err := g.schema.rootType(op.kind)
+	if err != nil {
+		return err
+	}
+
+	name := exported(op.name)
+	for _, decl := range []string{name, name + "Query"} {
+		if err := g.declare(decl); err != nil {
+			return err
+		}
+	}
+
+	fragments, err := g.usedFragments(op.selections, map[string]bool{})
+	if err != nil {
+		return err
+	}
+	text := op.source
+	for _, f := range fragments {
+		text += "\n\n" + f.source
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %sQuery is the %s %s\nconst %sQuery = %s\n", name, op.name, op.kind, name, quote(text))
+
+	varsType := "nil"
+	if len(op.variables) > 0 {
+		varsType = name + "Variables"
+		if err := g.declare(varsType); err != nil {
+			return err
+		}
+		if err := g.variables(varsType, op); err != nil {
+			return err
+		}
+	}
+
+	if err := g.object(name+"Response", name, root, op.selections); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %s runs the %s %s\n", name, op.name, op.kind)
+	if varsType == "nil" {
+		fmt.Fprintf(&g.buf, "func %s(ctx context.Context, client goshopify.GraphQLService) (*%sResponse, error) {\n", name, name)
+		fmt.Fprintf(&g.buf, "\tresp := new(%sResponse)\n\terr := client.Query(ctx, %sQuery, nil, resp)\n", name, name)
+	} else {
+		fmt.Fprintf(&g.buf, "func %s(ctx context.Context, client goshopify.GraphQLService, vars %s) (*%sResponse, error) {\n", name, varsType, name)
+		fmt.Fprintf(&g.buf, "\tresp := new(%sResponse)\n\terr := client.Query(ctx, %sQuery, vars, resp)\n", name, name)
+	}
+	g.buf.WriteString("\treturn resp, err\n}\n")
+	return nil
+}
+
+// usedFragments returns the fragments spread in the selections, directly or
+// through other fragments, in order of first use
+func (g *generator) usedFragments(selections []*selection, seen map[string]bool) ([]*fragment, error) {
+	var used []*fragment
+	for _, s := range selections {
+		if s.spread != "" && !seen[s.spread] {
+			f, ok := g.fragments[s.spread]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %s", s.spread)
+			}
+			seen[s.spread] = true
+			used = append(used, f)
+
+			nested, err := g.usedFragments(f.selections, seen)
+			if err != nil {
+				return nil, err
+			}
+			used = append(used, nested...)
+		}
+
+		nested, err := g.usedFragments(s.selections, seen)
+		if err != nil {
+			return nil, err
+		}
+		used = append(used, nested...)
+	}
+	return used, nil
+}
+
+func (g *generator) variables(typeName string, op *operation) error {
+	fields := &bytes.Buffer{}
+	for _, v := range op.variables {
+		ref, err := g.resolveTypeRef(v.typ)
+		if err != nil {
+			return fmt.Errorf("variable $%s: %v", v.name, err)
+		}
+		goType, err := g.inputType(ref, true)
+		if err != nil {
+			return fmt.Errorf("variable $%s: %v", v.name, err)
+		}
+		fmt.Fprintf(fields, "\t%s %s `json:\"%s%s\"`\n", exported(v.name), goType, v.name, omitEmpty(ref))
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %s are the variables of the %s %s\ntype %s struct {\n%s}\n", typeName, op.name, op.kind, typeName, fields)
+	return nil
+}
+
+// object generates the type of a selection set on parent, followed by the
+// types of its nested selection sets named after prefix and their keys
+func (g *generator) object(typeName, prefix string, parent *schemaType, selections []*selection) error {
+	var fields []*collectedField
+	if err := g.collect(parent, selections, &fields, map[string]bool{}); err != nil {
+		return err
+	}
+
+	var nested []nestedObject
+	body := &bytes.Buffer{}
+	for _, f := range fields {
+		nestedName := prefix + exported(f.key)
+		goType, err := g.outputType(nestedName, f.typ, true, f.selections, &nested)
+		if err != nil {
+			return fmt.Errorf("%s: %v", f.key, err)
+		}
+		fmt.Fprintf(body, "\t%s %s `json:\"%s\"`\n", exported(f.key), goType, f.key)
+	}
+
+	if err := g.declare(typeName); err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.buf, "\n// %s holds the selected fields of %s\ntype %s struct {\n%s}\n", typeName, parent.Name, typeName, body)
+
+	for _, n := range nested {
+		t, err := g.schema.lookup(n.parent)
+		if err != nil {
+			return err
+		}
+		if err := g.object(n.name, n.name, t, n.selections); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collect flattens the fields selected on parent, including those of
+// fragments, merging the subselections of fields selected more than once
+func (g *generator) collect(parent *schemaType, selections []*selection, fields *[]*collectedField, spreading map[string]bool) error {
+	for _, s := range selections {
+		switch {
+		case s.spread != "":
+			f, ok := g.fragments[s.spread]
+			if !ok {
+				return fmt.Errorf("unknown fragment %s", s.spread)
+			}
+			if spreading[f.name] {
+				return fmt.Errorf("fragment %s spreads itself", f.name)
+			}
+			t, err := g.schema.lookup(f.typeCondition)
+			if err != nil {
+				return err
+			}
+			spreading[f.name] = true
+			err = g.collect(t, f.selections, fields, spreading)
+			delete(spreading, f.name)
+			if err != nil {
+				return err
+			}
+
+		case s.name == "":
+			t := parent
+			if s.typeCondition != "" {
+				var err error
+				if t, err = g.schema.lookup(s.typeCondition); err != nil {
+					return err
+				}
+			}
+			if err := g.collect(t, s.selections, fields, spreading); err != nil {
+				return err
+			}
+
+		default:
+			var typ *schemaTypeRef
+			if s.name == "__typename" {
+				typ = &schemaTypeRef{Kind: "NON_NULL", OfType: &schemaTypeRef{Kind: "SCALAR", Name: "String"}}
+			} else {
+				field := parent.field(s.name)
+				if field == nil {
+					return fmt.Errorf("%s has no field %s", parent.Name, s.name)
+				}
+				typ = field.Type
+			}
+
+			merged := false
+			for _, f := range *fields {
+				if f.key == s.key() {
+					if !f.typ.equal(typ) {
+						return fmt.Errorf("%s is selected with conflicting types", s.key())
+					}
+					f.selections = append(f.selections, s.selections...)
+					merged = true
+				}
+			}
+			if !merged {
+				*fields = append(*fields, &collectedField{key: s.key(), typ: typ, selections: s.selections})
+			}
+		}
+	}
+	return nil
+}
+
+// outputType returns the Go type of a selected field, queueing object types
+// for generation
+func (g *generator) outputType(name string, ref *schemaTypeRef, nullable bool, selections []*selection, nested *[]nestedObject) (string, error) {
+	switch ref.Kind {
+	case "NON_NULL":
+		return g.outputType(name, ref.OfType, false, selections, nested)
+	case "LIST":
+		elem, err := g.outputType(name, ref.OfType, true, selections, nested)
+		return "[]" + elem, err
+	case "OBJECT", "INTERFACE", "UNION":
+		if len(selections) == 0 {
+			return "", fmt.Errorf("field of type %s needs a selection set", ref.Name)
+		}
+		*nested = append(*nested, nestedObject{name: name, parent: ref.Name, selections: selections})
+		if nullable {
+			return "*" + name, nil
+		}
+		return name, nil
+	}
+
+	if len(selections) > 0 {
+		return "", fmt.Errorf("field of type %s cannot have a selection set", ref.Name)
+	}
+	return g.leafType(ref, nullable), nil
+}
+
+// inputType returns the Go type of a variable or input object field,
+// queueing input object types for generation
+func (g *generator) inputType(ref *schemaTypeRef, nullable bool) (string, error) {
+	switch ref.Kind {
+	case "NON_NULL":
+		return g.inputType(ref.OfType, false)
+	case "LIST":
+		elem, err := g.inputType(ref.OfType, true)
+		return "[]" + elem, err
+	case "INPUT_OBJECT":
+		if _, ok := g.inputs[ref.Name]; !ok {
+			g.inputs[ref.Name] = false
+		}
+		if nullable {
+			return "*" + exported(ref.Name), nil
+		}
+		return exported(ref.Name), nil
+	case "ENUM", "SCALAR":
+		return g.leafType(ref, nullable), nil
+	}
+	return "", fmt.Errorf("%s is not an input type", ref.Name)
+}
+
+// leafType returns the Go type of an enum or scalar. Only the struct types
+// are pointers when nullable, matching the REST structs of the package.
+func (g *generator) leafType(ref *schemaTypeRef, nullable bool) string {
+	goType := "string"
+	if ref.Kind == "SCALAR" {
+		if t, ok := scalarTypes[ref.Name]; ok {
+			goType = t
+		}
+	}
+	if path, ok := scalarImports[goType]; ok {
+		g.imports[path] = true
+		if nullable && goType != "json.RawMessage" {
+			return "*" + goType
+		}
+	}
+	return goType
+}
+
+// inputTypes generates the input objects used by variables until no new
+// ones are referenced
+func (g *generator) inputTypes() error {
+	for {
+		var pending []string
+		for name, generated := range g.inputs {
+			if !generated {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		sort.Strings(pending)
+
+		for _, name := range pending {
+			g.inputs[name] = true
+			if err := g.inputObject(name); err != nil {
+				return fmt.Errorf("input %s: %v", name, err)
+			}
+		}
+	}
+}
+
+func (g *generator) inputObject(name string) error {
+	t, err := g.schema.lookup(name)
+	if err != nil {
+		return err
+	}
+	typeName := exported(name)
+	if err := g.declare(typeName); err != nil {
+		return err
+	}
+
+	fields := &bytes.Buffer{}
+	for _, f := range t.InputFields {
+		goType, err := g.inputType(f.Type, true)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(fields, "\t%s %s `json:\"%s%s\"`\n", exported(f.Name), goType, f.Name, omitEmpty(f.Type))
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %s is the %s input object\ntype %s struct {\n%s}\n", typeName, name, typeName, fields)
+	return nil
+}
+
+// resolveTypeRef turns a variable type into a schema type reference
+func (g *generator) resolveTypeRef(t *typeRef) (*schemaTypeRef, error) {
+	var ref *schemaTypeRef
+	if t.elem != nil {
+		elem, err := g.resolveTypeRef(t.elem)
+		if err != nil {
+			return nil, err
+		}
+		ref = &schemaTypeRef{Kind: "LIST", OfType: elem}
+	} else {
+		named, err := g.schema.lookup(t.name)
+		if err != nil {
+			return nil, err
+		}
+		ref = &schemaTypeRef{Kind: named.Kind, Name: named.Name}
+	}
+
+	if t.nonNull {
+		ref = &schemaTypeRef{Kind: "NON_NULL", OfType: ref}
+	}
+	return ref, nil
+}
+
+func isStdImport(path string) bool {
+	return !strings.Contains(strings.SplitN(path, "/", 2)[0], ".")
+}
+
+func omitEmpty(ref *schemaTypeRef) string {
+	if ref.Kind == "NON_NULL" {
+		return ""
+	}
+	return ",omitempty"
+}
+
+// exported returns the exported Go name of a GraphQL name, e.g.
+// legacyResourceId becomes LegacyResourceId
+func exported(name string) string {
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return "X"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func quote(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "graphqlgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRunGolden(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "queries_gen.go")
+
+	err := run("testdata/schema.json", "queries", out, []string{"testdata/*.graphql"})
+	if err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+
+	actual, _ := ioutil.ReadFile(out)
+	if *update {
+		if err := ioutil.WriteFile("testdata/queries.golden", actual, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile("testdata/queries.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != string(expected) {
+		t.Errorf("generated code differs from testdata/queries.golden, run go test -update to see the changes:\n%s", actual)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	cases := []struct {
+		schema   string
+		pkg      string
+		patterns []string
+		expected string
+	}{
+		{"", "queries", []string{"testdata/*.graphql"}, "-schema is required"},
+		{"testdata/schema.json", "", []string{"testdata/*.graphql"}, "-package is required"},
+		{"testdata/schema.json", "queries", nil, "no .graphql files"},
+		{"testdata/schema.json", "queries", []string{"testdata/*.gql"}, "matches no files"},
+		{"testdata/order.graphql", "queries", []string{"testdata/*.graphql"}, "testdata/order.graphql"},
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, c := range cases {
+		err := run(c.schema, c.pkg, filepath.Join(dir, "out.go"), c.patterns)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("run(%s, %s, %v) returned %v, expected %s", c.schema, c.pkg, c.patterns, err, c.expected)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	s, err := loadSchema("testdata/schema.json")
+	if err != nil {
+		t.Fatalf("loadSchema returned error: %v", err)
+	}
+
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{`query q { order(id: "1") { missing } }`, "Order has no field missing"},
+		{`query q { order(id: "1") }`, "needs a selection set"},
+		{`query q { order(id: "1") { id { foo } } }`, "cannot have a selection set"},
+		{`query q { order(id: "1") { ...unknown } }`, "unknown fragment unknown"},
+		{`query q { order(id: "1") { id: name } order(id: "2") { id } }`, "conflicting types"},
+		{`query q($id: Unknown) { shop { name } }`, "unknown type Unknown"},
+		{`query q($id: Order) { shop { name } }`, "Order is not an input type"},
+		{`query q { shop { name } } query q { shop { name } }`, "declared twice"},
+		{`query q { order(id: "1") { ...f } } fragment f on Order { ...f }`, "fragment f spreads itself"},
+	}
+
+	for _, c := range cases {
+		doc, err := parseDocument(c.query)
+		if err != nil {
+			t.Fatalf("parseDocument(%s) returned error: %v", c.query, err)
+		}
+		_, err = generate(s, "queries", []sourceFile{{name: "q.graphql", doc: doc}})
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("generate(%s) returned %v, expected %s", c.query, err, c.expected)
+		}
+	}
+}
+
+func TestExported(t *testing.T) {
+	cases := map[string]string{
+		"legacyResourceId": "LegacyResourceId",
+		"id":               "Id",
+		"__typename":       "Typename",
+		"SEOInput":         "SEOInput",
+	}
+	for name, expected := range cases {
+		if actual := exported(name); actual != expected {
+			t.Errorf("exported(%s) returned %s, expected %s", name, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Command graphqlgen generates typed Go code for GraphQL operations against
+// the Shopify Admin API. For every named query or mutation in the given
+// .graphql files it generates the query text, a struct for its variables, a
+// struct for its response and a function running it through the package's
+// GraphQL client:
+//
+//	//go:generate go run github.com/bold-commerce/go-shopify/v4/cmd/graphqlgen -schema admin_schema.json -out queries_gen.go queries/*.graphql
+//
+// The schema is the result of an introspection query against the Admin API
+// version the app uses, saved as JSON.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "introspection result of the Admin API schema (JSON)")
+	out := flag.String("out", "graphql_gen.go", "file to write the generated code to")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated code, defaults to $GOPACKAGE as set by go generate")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: graphqlgen -schema schema.json [-out file.go] [-package name] files.graphql...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*schemaPath, *pkg, *out, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "graphqlgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, pkg, out string, patterns []string) error {
+	if schemaPath == "" {
+		return fmt.Errorf("-schema is required")
+	}
+	if pkg == "" {
+		return fmt.Errorf("-package is required outside of go generate")
+	}
+
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("%s matches no files", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no .graphql files given")
+	}
+	sort.Strings(paths)
+
+	s, err := loadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+
+	files := make([]sourceFile, 0, len(paths))
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		doc, err := parseDocument(string(data))
+		if err != nil {
+			return fmt.Errorf("%s:%v", path, err)
+		}
+		files = append(files, sourceFile{name: filepath.ToSlash(path), doc: doc})
+	}
+
+	src, err := generate(s, pkg, files)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// document is a parsed GraphQL executable document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string
+	name       string
+	variables  []*variableDefinition
+	selections []*selection
+	source     string
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []*selection
+	source        string
+}
+
+type variableDefinition struct {
+	name string
+	typ  *typeRef
+}
+
+// typeRef is a type as written in a variable definition, e.g. [ID!]!
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+// selection is a field, an inline fragment (typeCondition set) or a
+// fragment spread (spread set)
+type selection struct {
+	alias         string
+	name          string
+	selections    []*selection
+	typeCondition string
+	spread        string
+}
+
+func (s *selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenNumber
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+// parseDocument parses the operations and fragments of a GraphQL document.
+// Argument values and directives are validated syntactically but otherwise
+// ignored, as the generated code only depends on the selected fields.
+func parseDocument(src string) (doc *document, err error) {
+	p := &parser{src: src}
+	defer func() {
+		if r := recover(); r != nil {
+			perr, ok := r.(parseError)
+			if !ok {
+				panic(r)
+			}
+			err = perr
+		}
+	}()
+
+	doc = &document{fragments: map[string]*fragment{}}
+	p.next()
+	for p.tok.kind != tokenEOF {
+		start := p.tok.pos
+		switch {
+		case p.tok.kind == tokenPunct && p.tok.value == "{":
+			p.fail("anonymous operations are not supported, name the operation")
+		case p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation"):
+			op := p.parseOperation()
+			op.source = strings.TrimSpace(src[start:p.tok.pos])
+			doc.operations = append(doc.operations, op)
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			f := p.parseFragment()
+			f.source = strings.TrimSpace(src[start:p.tok.pos])
+			if _, ok := doc.fragments[f.name]; ok {
+				p.fail("fragment %s is defined twice", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			p.fail("unexpected %q, expected query, mutation or fragment", p.tok.value)
+		}
+	}
+	return doc, nil
+}
+
+type parseError struct {
+	line, column int
+	msg          string
+}
+
+func (e parseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.line, e.column, e.msg)
+}
+
+func (p *parser) fail(format string, args ...interface{}) {
+	line := 1 + strings.Count(p.src[:p.tok.pos], "\n")
+	column := p.tok.pos - strings.LastIndex(p.src[:p.tok.pos], "\n")
+	panic(parseError{line: line, column: column, msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) parseOperation() *operation {
+	op := &operation{kind: p.tok.value}
+	p.next()
+	if p.tok.kind != tokenName {
+		p.fail("anonymous operations are not supported, name the operation")
+	}
+	op.name = p.expectName()
+
+	if p.skipPunct("(") {
+		for !p.skipPunct(")") {
+			p.expectPunct("$")
+			v := &variableDefinition{name: p.expectName()}
+			p.expectPunct(":")
+			v.typ = p.parseType()
+			if p.skipPunct("=") {
+				p.parseValue()
+			}
+			p.parseDirectives()
+			op.variables = append(op.variables, v)
+		}
+	}
+	p.parseDirectives()
+	op.selections = p.parseSelectionSet()
+	return op
+}
+
+func (p *parser) parseFragment() *fragment {
+	p.next()
+	f := &fragment{name: p.expectName()}
+	if p.tok.kind != tokenName || p.tok.value != "on" {
+		p.fail("expected on after fragment name")
+	}
+	p.next()
+	f.typeCondition = p.expectName()
+	p.parseDirectives()
+	f.selections = p.parseSelectionSet()
+	return f
+}
+
+func (p *parser) parseType() *typeRef {
+	t := &typeRef{}
+	if p.skipPunct("[") {
+		t.elem = p.parseType()
+		p.expectPunct("]")
+	} else {
+		t.name = p.expectName()
+	}
+	t.nonNull = p.skipPunct("!")
+	return t
+}
+
+func (p *parser) parseSelectionSet() []*selection {
+	p.expectPunct("{")
+	var selections []*selection
+	for !p.skipPunct("}") {
+		selections = append(selections, p.parseSelection())
+	}
+	if len(selections) == 0 {
+		p.fail("empty selection set")
+	}
+	return selections
+}
+
+func (p *parser) parseSelection() *selection {
+	if p.skipPunct("...") {
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			s := &selection{spread: p.expectName()}
+			p.parseDirectives()
+			return s
+		}
+
+		s := &selection{}
+		if p.tok.kind == tokenName && p.tok.value == "on" {
+			p.next()
+			s.typeCondition = p.expectName()
+		}
+		p.parseDirectives()
+		s.selections = p.parseSelectionSet()
+		return s
+	}
+
+	s := &selection{name: p.expectName()}
+	if p.skipPunct(":") {
+		s.alias = s.name
+		s.name = p.expectName()
+	}
+	p.parseArguments()
+	p.parseDirectives()
+	if p.tok.kind == tokenPunct && p.tok.value == "{" {
+		s.selections = p.parseSelectionSet()
+	}
+	return s
+}
+
+func (p *parser) parseArguments() {
+	if !p.skipPunct("(") {
+		return
+	}
+	for !p.skipPunct(")") {
+		p.expectName()
+		p.expectPunct(":")
+		p.parseValue()
+	}
+}
+
+func (p *parser) parseDirectives() {
+	for p.skipPunct("@") {
+		p.expectName()
+		p.parseArguments()
+	}
+}
+
+func (p *parser) parseValue() {
+	switch {
+	case p.skipPunct("$"):
+		p.expectName()
+	case p.skipPunct("["):
+		for !p.skipPunct("]") {
+			p.parseValue()
+		}
+	case p.skipPunct("{"):
+		for !p.skipPunct("}") {
+			p.expectName()
+			p.expectPunct(":")
+			p.parseValue()
+		}
+	case p.tok.kind == tokenName || p.tok.kind == tokenNumber || p.tok.kind == tokenString:
+		p.next()
+	default:
+		p.fail("unexpected %q, expected a value", p.tok.value)
+	}
+}
+
+func (p *parser) expectName() string {
+	if p.tok.kind != tokenName {
+		p.fail("unexpected %q, expected a name", p.tok.value)
+	}
+	name := p.tok.value
+	p.next()
+	return name
+}
+
+func (p *parser) expectPunct(punct string) {
+	if !p.skipPunct(punct) {
+		p.fail("unexpected %q, expected %q", p.tok.value, punct)
+	}
+}
+
+func (p *parser) skipPunct(punct string) bool {
+	if p.tok.kind == tokenPunct && p.tok.value == punct {
+		p.next()
+		return true
+	}
+	if p.tok.kind == tokenEOF && strings.Contains("})]", punct) {
+		p.fail("unexpected end of document, expected %q", punct)
+	}
+	return false
+}
+
+// next reads the next token, skipping whitespace, commas and comments
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{kind: tokenPunct, value: "...", pos: start}
+	case strings.IndexByte("!$():=@[]{}|&", c) >= 0:
+		p.pos++
+		p.tok = token{kind: tokenPunct, value: string(c), pos: start}
+	case isNameChar(c) && (c < '0' || c > '9'):
+		for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenName, value: p.src[start:p.pos], pos: start}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.pos++
+		for p.pos < len(p.src) && strings.IndexByte("0123456789.eE+-", p.src[p.pos]) >= 0 {
+			p.pos++
+		}
+		p.tok = token{kind: tokenNumber, value: p.src[start:p.pos], pos: start}
+	case c == '"':
+		p.tok = token{kind: tokenString, value: p.readString(), pos: start}
+	default:
+		p.tok = token{kind: tokenPunct, value: string(c), pos: start}
+		p.fail("unexpected character %q", c)
+	}
+}
+
+func (p *parser) readString() string {
+	start := p.pos
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			p.tok.pos = start
+			p.fail("unterminated block string")
+		}
+		p.pos += end + 6
+		return p.src[start:p.pos]
+	}
+
+	p.pos++
+	for p.pos < len(p.src) && p.src[p.pos] != '"' && p.src[p.pos] != '\n' {
+		if p.src[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '"' {
+		p.tok.pos = start
+		p.fail("unterminated string")
+	}
+	p.pos++
+	return p.src[start:p.pos]
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	src := `
+# comment
+query getProducts($first: Int = 10, $ids: [ID!]!, $after: String) @cached {
+  products(first: $first, after: $after, query: "status:active", sortKey: TITLE, filter: {tags: ["a", "b"], min: -1.5e3}) {
+    nodes {
+      handle: title
+      ...productFields @include(if: true)
+      ... on Product { vendor }
+      ... { id }
+    }
+  }
+}
+
+fragment productFields on Product { description(truncateAt: 50) }
+`
+
+	doc, err := parseDocument(src)
+	if err != nil {
+		t.Fatalf("parseDocument returned error: %v", err)
+	}
+
+	if len(doc.operations) != 1 || len(doc.fragments) != 1 {
+		t.Fatalf("parseDocument returned %d operations, %d fragments", len(doc.operations), len(doc.fragments))
+	}
+
+	op := doc.operations[0]
+	if op.kind != "query" || op.name != "getProducts" || !strings.HasPrefix(op.source, "query getProducts") || !strings.HasSuffix(op.source, "}") {
+		t.Errorf("parseDocument returned operation %+v", op)
+	}
+
+	expectedVars := []*variableDefinition{
+		{name: "first", typ: &typeRef{name: "Int"}},
+		{name: "ids", typ: &typeRef{elem: &typeRef{name: "ID", nonNull: true}, nonNull: true}},
+		{name: "after", typ: &typeRef{name: "String"}},
+	}
+	if !reflect.DeepEqual(op.variables, expectedVars) {
+		t.Errorf("parseDocument returned variables %+v", op.variables)
+	}
+
+	nodes := op.selections[0].selections[0]
+	expectedNodes := []*selection{
+		{alias: "handle", name: "title"},
+		{spread: "productFields"},
+		{typeCondition: "Product", selections: []*selection{{name: "vendor"}}},
+		{selections: []*selection{{name: "id"}}},
+	}
+	if !reflect.DeepEqual(nodes.selections, expectedNodes) {
+		t.Errorf("parseDocument returned selections %+v", nodes.selections)
+	}
+
+	f := doc.fragments["productFields"]
+	if f.typeCondition != "Product" || f.source != "fragment productFields on Product { description(truncateAt: 50) }" {
+		t.Errorf("parseDocument returned fragment %+v", f)
+	}
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{`{ shop { name } }`, "1:1: anonymous operations are not supported"},
+		{`query { shop { name } }`, "1:7: anonymous operations are not supported"},
+		{"query q {\n  shop { name }", "2:16: unexpected end of document"},
+		{`query q { shop { } }`, "empty selection set"},
+		{`query q($id ID) { shop { name } }`, `expected ":"`},
+		{`query q { shop(id: ) { name } }`, "expected a value"},
+		{`query q { shop(id: "abc) { name } }`, "unterminated string"},
+		{`query q { shop { name } } fragment f Shop { name }`, "expected on after fragment name"},
+		{`fragment f on Shop { name } fragment f on Shop { name }`, "fragment f is defined twice"},
+		{`subscription s { shop { name } }`, "expected query, mutation or fragment"},
+		{`query q { shop { name ? } }`, "unexpected character"},
+	}
+
+	for _, c := range cases {
+		_, err := parseDocument(c.src)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("parseDocument(%q) returned %v, expected %s", c.src, err, c.expected)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// schema is the part of an introspection result the generator needs
+type schema struct {
+	QueryType    *namedRef     `json:"queryType"`
+	MutationType *namedRef     `json:"mutationType"`
+	Types        []*schemaType `json:"types"`
+
+	types map[string]*schemaType
+}
+
+type namedRef struct {
+	Name string `json:"name"`
+}
+
+type schemaType struct {
+	Kind        string         `json:"kind"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Fields      []*schemaField `json:"fields"`
+	InputFields []*schemaField `json:"inputFields"`
+}
+
+type schemaField struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Type        *schemaTypeRef `json:"type"`
+}
+
+// schemaTypeRef is a type reference of an introspection result, wrapping
+// types are NON_NULL or LIST with the wrapped type in OfType.
+type schemaTypeRef struct {
+	Kind   string         `json:"kind"`
+	Name   string         `json:"name"`
+	OfType *schemaTypeRef `json:"ofType"`
+}
+
+// loadSchema reads an introspection query result, as saved by most GraphQL
+// tooling, either with or without the surrounding data object.
+func loadSchema(path string) (*schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := struct {
+		Data struct {
+			Schema *schema `json:"__schema"`
+		} `json:"data"`
+		Schema *schema `json:"__schema"`
+	}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	s := result.Schema
+	if s == nil {
+		s = result.Data.Schema
+	}
+	if s == nil {
+		return nil, fmt.Errorf("%s: no __schema found, expected an introspection query result", path)
+	}
+
+	s.types = make(map[string]*schemaType, len(s.Types))
+	for _, t := range s.Types {
+		s.types[t.Name] = t
+	}
+	return s, nil
+}
+
+// rootType returns the root type of an operation kind
+func (s *schema) rootType(kind string) (*schemaType, error) {
+	var ref *namedRef
+	switch kind {
+	case "query":
+		ref = s.QueryType
+	case "mutation":
+		ref = s.MutationType
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("schema has no %s type", kind)
+	}
+	return s.lookup(ref.Name)
+}
+
+func (s *schema) lookup(name string) (*schemaType, error) {
+	t, ok := s.types[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+	return t, nil
+}
+
+// field returns the field of an object or interface type
+func (t *schemaType) field(name string) *schemaField {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// equal reports whether two references denote the same type
+func (r *schemaTypeRef) equal(o *schemaTypeRef) bool {
+	for r != nil && o != nil {
+		if r.Kind != o.Kind || r.Name != o.Name {
+			return false
+		}
+		r, o = r.OfType, o.OfType
+	}
+	return r == nil && o == nil
+}
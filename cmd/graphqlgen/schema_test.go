@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSchema(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		data     string
+		expected string
+	}{
+		{`{"data":{"__schema":{"queryType":{"name":"QueryRoot"},"types":[{"kind":"OBJECT","name":"QueryRoot"}]}}}`, ""},
+		{`{"__schema":{"queryType":{"name":"QueryRoot"},"types":[{"kind":"OBJECT","name":"QueryRoot"}]}}`, ""},
+		{`{"data":{}}`, "no __schema found"},
+		{`not json`, "invalid character"},
+	}
+
+	for i, c := range cases {
+		path := filepath.Join(dir, "schema.json")
+		if err := ioutil.WriteFile(path, []byte(c.data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := loadSchema(path)
+		if c.expected != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("case %d: loadSchema returned %v, expected %s", i, err, c.expected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d: loadSchema returned error: %v", i, err)
+		}
+
+		root, err := s.rootType("query")
+		if err != nil || root.Name != "QueryRoot" {
+			t.Errorf("case %d: rootType returned %v, %v", i, root, err)
+		}
+		if _, err := s.rootType("mutation"); err == nil {
+			t.Errorf("case %d: rootType returned a mutation type", i)
+		}
+	}
+}
+
+func TestSchemaTypeRefEqual(t *testing.T) {
+	nonNullString := &schemaTypeRef{Kind: "NON_NULL", OfType: &schemaTypeRef{Kind: "SCALAR", Name: "String"}}
+	cases := []struct {
+		a, b     *schemaTypeRef
+		expected bool
+	}{
+		{nonNullString, &schemaTypeRef{Kind: "NON_NULL", OfType: &schemaTypeRef{Kind: "SCALAR", Name: "String"}}, true},
+		{nonNullString, &schemaTypeRef{Kind: "SCALAR", Name: "String"}, false},
+		{nonNullString, &schemaTypeRef{Kind: "NON_NULL", OfType: &schemaTypeRef{Kind: "SCALAR", Name: "ID"}}, false},
+	}
+	for i, c := range cases {
+		if actual := c.a.equal(c.b); actual != c.expected {
+			t.Errorf("case %d: equal returned %v, expected %v", i, actual, c.expected)
+		}
+	}
+}
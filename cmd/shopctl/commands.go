@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+const commandsUsage = `  list <resource> [param=value...]      list a page of a REST resource, e.g. orders or products/1/variants
+  get <resource> <id> [param=value...]  get a REST resource by id
+  count <resource> [param=value...]     count a REST resource
+  export <resource> [param=value...]    export all pages of a REST resource as JSON lines
+  graphql [-vars json] <query|@file>    run a GraphQL query or mutation
+  webhooks list                         list the webhooks
+  webhooks create <topic> <address>     register a webhook
+  webhooks delete <id>                  delete a webhook
+  ratelimit [-interval d] [-count n]    print the REST call limit usage periodically
+`
+
+type cli struct {
+	client *goshopify.Client
+	out    io.Writer
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command given")
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "list":
+		return c.list(ctx, args)
+	case "get":
+		return c.get(ctx, args)
+	case "count":
+		return c.count(ctx, args)
+	case "export":
+		return c.export(ctx, args)
+	case "graphql":
+		return c.graphql(ctx, args)
+	case "webhooks":
+		return c.webhooks(ctx, args)
+	case "ratelimit":
+		return c.rateLimit(ctx, args)
+	}
+	return fmt.Errorf("unknown command %s", cmd)
+}
+
+// resourcePath returns the path of a REST resource with the given
+// param=value query parameters
+func resourcePath(resource string, params []string) (string, error) {
+	resource = strings.Trim(resource, "/")
+	if resource == "" {
+		return "", fmt.Errorf("no resource given")
+	}
+
+	query := url.Values{}
+	for _, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return "", fmt.Errorf("invalid parameter %s, expected param=value", param)
+		}
+		query.Add(kv[0], kv[1])
+	}
+
+	p := strings.TrimSuffix(resource, ".json") + ".json"
+	if len(query) > 0 {
+		p += "?" + query.Encode()
+	}
+	return p, nil
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: list <resource> [param=value...]")
+	}
+	p, err := resourcePath(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+
+	resource := map[string]json.RawMessage{}
+	if err := c.client.Get(ctx, p, &resource, nil); err != nil {
+		return err
+	}
+	return c.print(resource)
+}
+
+func (c *cli) get(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: get <resource> <id> [param=value...]")
+	}
+	id, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid id %s", args[1])
+	}
+	p, err := resourcePath(fmt.Sprintf("%s/%d", strings.Trim(args[0], "/"), id), args[2:])
+	if err != nil {
+		return err
+	}
+
+	resource := map[string]json.RawMessage{}
+	if err := c.client.Get(ctx, p, &resource, nil); err != nil {
+		return err
+	}
+	return c.print(resource)
+}
+
+func (c *cli) count(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: count <resource> [param=value...]")
+	}
+	p, err := resourcePath(strings.Trim(args[0], "/")+"/count", args[1:])
+	if err != nil {
+		return err
+	}
+
+	count, err := c.client.Count(ctx, p, nil)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.out, count)
+	return err
+}
+
+// export writes every resource of all pages as a line of JSON. Query
+// parameters only apply to the first page, the next pages are requested by
+// their page info.
+func (c *cli) export(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: export <resource> [param=value...]")
+	}
+	resource := strings.TrimSuffix(strings.Trim(args[0], "/"), ".json")
+	p, err := resourcePath(resource, append([]string{"limit=250"}, args[1:]...))
+	if err != nil {
+		return err
+	}
+	key := path.Base(resource)
+
+	var options interface{}
+	for {
+		page := map[string][]json.RawMessage{}
+		pagination, err := c.client.ListWithPagination(ctx, p, &page, options)
+		if err != nil {
+			return err
+		}
+		for _, item := range page[key] {
+			if _, err := fmt.Fprintf(c.out, "%s\n", item); err != nil {
+				return err
+			}
+		}
+
+		if pagination.NextPageOptions == nil {
+			return nil
+		}
+		p, options = resource+".json", pagination.NextPageOptions
+	}
+}
+
+func (c *cli) graphql(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("graphql", flag.ContinueOnError)
+	vars := flags.String("vars", "", "variables as a JSON object")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: graphql [-vars json] <query|@file>")
+	}
+
+	q := flags.Arg(0)
+	if strings.HasPrefix(q, "@") {
+		data, err := ioutil.ReadFile(q[1:])
+		if err != nil {
+			return err
+		}
+		q = string(data)
+	}
+
+	var variables map[string]interface{}
+	if *vars != "" {
+		if err := json.Unmarshal([]byte(*vars), &variables); err != nil {
+			return fmt.Errorf("invalid variables: %v", err)
+		}
+	}
+
+	var resp json.RawMessage
+	if err := c.client.GraphQL.Query(ctx, q, variables, &resp); err != nil {
+		return err
+	}
+	return c.print(resp)
+}
+
+func (c *cli) webhooks(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: webhooks list|create|delete")
+	}
+
+	switch args[0] {
+	case "list":
+		webhooks, err := c.client.Webhook.List(ctx, nil)
+		if err != nil {
+			return err
+		}
+		return c.print(webhooks)
+	case "create":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: webhooks create <topic> <address>")
+		}
+		webhook, err := c.client.Webhook.Create(ctx, goshopify.Webhook{Topic: args[1], Address: args[2], Format: "json"})
+		if err != nil {
+			return err
+		}
+		return c.print(webhook)
+	case "delete":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: webhooks delete <id>")
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid id %s", args[1])
+		}
+		return c.client.Webhook.Delete(ctx, id)
+	}
+	return fmt.Errorf("unknown webhooks command %s", args[0])
+}
+
+// rateLimit requests the shop's id every interval and prints the call limit
+// usage Shopify returns, until interrupted or count lines are printed
+func (c *cli) rateLimit(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("ratelimit", flag.ContinueOnError)
+	interval := flags.Duration("interval", 5*time.Second, "time between requests")
+	count := flags.Int("count", 0, "number of requests, 0 to run until interrupted")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	for i := 0; *count == 0 || i < *count; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(*interval):
+			}
+		}
+
+		resource := map[string]json.RawMessage{}
+		if err := c.client.Get(ctx, "shop.json?fields=id", &resource, nil); err != nil {
+			return err
+		}
+		limits := c.client.CurrentRateLimits()
+		_, err := fmt.Fprintf(c.out, "%s %d/%d\n", time.Now().Format(time.RFC3339), limits.RequestCount, limits.BucketSize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cli) print(v interface{}) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
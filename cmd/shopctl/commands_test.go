@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testApiVersion = "2024-07"
+
+// newTestCli returns a cli against a server answering the given responses by
+// request path and query
+func newTestCli(t *testing.T, responses map[string]string) (*cli, *bytes.Buffer, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/admin/api/"+testApiVersion+"/")
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		resp, ok := responses[r.Method+" "+key]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, key)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if key == "orders.json?limit=250&status=any" {
+			w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?limit=250&page_info=abc>; rel="next"`)
+		}
+		w.Header().Set("X-Shopify-Shop-Api-Call-Limit", "2/40")
+		w.Write([]byte(resp))
+	}))
+
+	client, err := newClient("fooshop", "token", testApiVersion, server.URL, 0)
+	if err != nil {
+		t.Fatalf("newClient returned error: %v", err)
+	}
+	out := &bytes.Buffer{}
+	return &cli{client: client, out: out}, out, server.Close
+}
+
+func TestList(t *testing.T) {
+	c, out, done := newTestCli(t, map[string]string{
+		"GET orders.json?status=any": `{"orders":[{"id":1}]}`,
+	})
+	defer done()
+
+	if err := c.run(context.Background(), []string{"list", "orders", "status=any"}); err != nil {
+		t.Fatalf("list returned error: %v", err)
+	}
+	expected := "{\n  \"orders\": [\n    {\n      \"id\": 1\n    }\n  ]\n}\n"
+	if out.String() != expected {
+		t.Errorf("list printed %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestGetAndCount(t *testing.T) {
+	c, out, done := newTestCli(t, map[string]string{
+		"GET products/1/variants/2.json":    `{"variant":{"id":2}}`,
+		"GET orders/count.json?status=open": `{"count":3}`,
+	})
+	defer done()
+
+	if err := c.run(context.Background(), []string{"get", "products/1/variants", "2"}); err != nil {
+		t.Fatalf("get returned error: %v", err)
+	}
+	if err := c.run(context.Background(), []string{"count", "orders", "status=open"}); err != nil {
+		t.Fatalf("count returned error: %v", err)
+	}
+	expected := "{\n  \"variant\": {\n    \"id\": 2\n  }\n}\n3\n"
+	if out.String() != expected {
+		t.Errorf("get and count printed %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestExport(t *testing.T) {
+	c, out, done := newTestCli(t, map[string]string{
+		"GET orders.json?limit=250&status=any":    `{"orders":[{"id":1},{"id":2}]}`,
+		"GET orders.json?limit=250&page_info=abc": `{"orders":[{"id":3}]}`,
+	})
+	defer done()
+
+	if err := c.run(context.Background(), []string{"export", "orders", "status=any"}); err != nil {
+		t.Fatalf("export returned error: %v", err)
+	}
+	expected := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+	if out.String() != expected {
+		t.Errorf("export printed %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestGraphQL(t *testing.T) {
+	c, out, done := newTestCli(t, map[string]string{
+		"POST graphql.json": `{"data":{"shop":{"name":"Foo"}}}`,
+	})
+	defer done()
+
+	f, err := ioutil.TempFile("", "shopctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{ shop { name } }")
+	f.Close()
+
+	if err := c.run(context.Background(), []string{"graphql", "-vars", `{"first":1}`, "@" + f.Name()}); err != nil {
+		t.Fatalf("graphql returned error: %v", err)
+	}
+	expected := "{\n  \"shop\": {\n    \"name\": \"Foo\"\n  }\n}\n"
+	if out.String() != expected {
+		t.Errorf("graphql printed %q, expected %q", out.String(), expected)
+	}
+}
+
+func TestWebhooksCreate(t *testing.T) {
+	c, out, done := newTestCli(t, map[string]string{
+		"POST webhooks.json": `{"webhook":{"id":1,"topic":"orders/create","address":"https://example.com/webhooks","format":"json"}}`,
+	})
+	defer done()
+
+	if err := c.run(context.Background(), []string{"webhooks", "create", "orders/create", "https://example.com/webhooks"}); err != nil {
+		t.Fatalf("webhooks create returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"id": 1`) {
+		t.Errorf("webhooks create printed %q", out.String())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c, out, done := newTestCli(t, map[string]string{
+		"GET shop.json?fields=id": `{"shop":{"id":1}}`,
+	})
+	defer done()
+
+	if err := c.run(context.Background(), []string{"ratelimit", "-interval", "1ms", "-count", "2"}); err != nil {
+		t.Fatalf("ratelimit returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], " 2/40") {
+		t.Errorf("ratelimit printed %q", out.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	c, _, done := newTestCli(t, nil)
+	defer done()
+
+	for _, args := range [][]string{
+		{"unknown"},
+		{"list"},
+		{"list", "orders", "status"},
+		{"get", "orders", "abc"},
+		{"webhooks", "create", "orders/create"},
+	} {
+		if err := c.run(context.Background(), args); err == nil {
+			t.Errorf("run(%v) returned no error", args)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Command shopctl runs ad-hoc operations against a shop through the Shopify
+// Admin API:
+//
+//	shopctl list orders status=any
+//	shopctl get products 632910392
+//	shopctl export customers > customers.jsonl
+//	shopctl count orders status=open
+//	shopctl graphql '{ shop { name } }'
+//	shopctl graphql -vars '{"id":"gid://shopify/Product/1"}' @product.graphql
+//	shopctl webhooks list
+//	shopctl webhooks create orders/create https://example.com/webhooks
+//	shopctl ratelimit -interval 5s
+//
+// The shop, access token and API version are read from SHOPIFY_SHOP,
+// SHOPIFY_ACCESS_TOKEN and SHOPIFY_API_VERSION, or the -shop, -token and
+// -version flags.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+func main() {
+	flags := flag.NewFlagSet("shopctl", flag.ExitOnError)
+	shop := flags.String("shop", os.Getenv("SHOPIFY_SHOP"), "shop name or myshopify domain, defaults to $SHOPIFY_SHOP")
+	token := flags.String("token", os.Getenv("SHOPIFY_ACCESS_TOKEN"), "access token, defaults to $SHOPIFY_ACCESS_TOKEN")
+	version := flags.String("version", os.Getenv("SHOPIFY_API_VERSION"), "API version, defaults to $SHOPIFY_API_VERSION or the client's default")
+	baseURL := flags.String("base-url", "", "base URL of the requests, e.g. a proxy, instead of the shop's myshopify domain")
+	retries := flags.Int("retries", 3, "retries of rate limited and failed requests")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: shopctl [flags] command [args]\n\ncommands:\n%s\nflags:\n", commandsUsage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	client, err := newClient(*shop, *token, *version, *baseURL, *retries)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "shopctl: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+
+	c := &cli{client: client, out: os.Stdout}
+	if err := c.run(ctx, flags.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "shopctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newClient(shop, token, version, baseURL string, retries int) (*goshopify.Client, error) {
+	if shop == "" || token == "" {
+		return nil, fmt.Errorf("the shop and access token are required")
+	}
+
+	opts := []goshopify.Option{goshopify.WithRetry(retries)}
+	if version != "" {
+		opts = append(opts, goshopify.WithVersion(version))
+	}
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, goshopify.WithBaseURL(u))
+	}
+	return goshopify.NewClient(goshopify.App{}, shop, token, opts...)
+}
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -13,10 +14,16 @@ const collectsBasePath = "collects"
 // See: https://help.shopify.com/api/reference/products/collect
 type CollectService interface {
 	List(context.Context, interface{}) ([]Collect, error)
+	ListAll(context.Context, interface{}) ([]Collect, error)
+	ListWithPagination(context.Context, interface{}) ([]Collect, *Pagination, error)
+	ListByProduct(context.Context, uint64) ([]Collect, error)
+	ListByCollection(context.Context, uint64) ([]Collect, error)
 	Count(context.Context, interface{}) (int, error)
 	Get(context.Context, uint64, interface{}) (*Collect, error)
 	Create(context.Context, Collect) (*Collect, error)
 	Delete(context.Context, uint64) error
+	AddProduct(context.Context, uint64, uint64) (*Collect, error)
+	RemoveProduct(context.Context, uint64, uint64) error
 }
 
 // CollectServiceOp handles communication with the collect related methods of
@@ -37,6 +44,13 @@ type Collect struct {
 	SortValue    string     `json:"sort_value,omitempty"`
 }
 
+// CollectListOptions filters the collects of a product or a collection
+type CollectListOptions struct {
+	ListOptions
+	ProductId    uint64 `url:"product_id,omitempty"`
+	CollectionId uint64 `url:"collection_id,omitempty"`
+}
+
 // Represents the result from the collects/X.json endpoint
 type CollectResource struct {
 	Collect *Collect `json:"collect"`
@@ -55,6 +69,53 @@ func (s *CollectServiceOp) List(ctx context.Context, options interface{}) ([]Col
 	return resource.Collects, err
 }
 
+// ListAll lists all collects, iterating over pages
+func (s *CollectServiceOp) ListAll(ctx context.Context, options interface{}) ([]Collect, error) {
+	collector := []Collect{}
+
+	for {
+		entities, pagination, err := s.ListWithPagination(ctx, options)
+
+		if err != nil {
+			return collector, err
+		}
+
+		collector = append(collector, entities...)
+
+		if pagination.NextPageOptions == nil {
+			break
+		}
+
+		options = pagination.NextPageOptions
+	}
+
+	return collector, nil
+}
+
+// ListWithPagination lists collects and return pagination to retrieve next/previous results.
+func (s *CollectServiceOp) ListWithPagination(ctx context.Context, options interface{}) ([]Collect, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", collectsBasePath)
+	resource := new(CollectsResource)
+
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Collects, pagination, nil
+}
+
+// ListByProduct lists all collects of a product, i.e. its custom collection
+// memberships
+func (s *CollectServiceOp) ListByProduct(ctx context.Context, productId uint64) ([]Collect, error) {
+	return s.ListAll(ctx, CollectListOptions{ListOptions: ListOptions{Limit: 250}, ProductId: productId})
+}
+
+// ListByCollection lists all collects of a collection, i.e. its products
+func (s *CollectServiceOp) ListByCollection(ctx context.Context, collectionId uint64) ([]Collect, error) {
+	return s.ListAll(ctx, CollectListOptions{ListOptions: ListOptions{Limit: 250}, CollectionId: collectionId})
+}
+
 // Count collects
 func (s *CollectServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", collectsBasePath)
@@ -71,6 +132,10 @@ func (s *CollectServiceOp) Get(ctx context.Context, collectId uint64, options in
 
 // Create collects
 func (s *CollectServiceOp) Create(ctx context.Context, collect Collect) (*Collect, error) {
+	if err := s.client.validateCreate("collect", collect); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", collectsBasePath)
 	wrappedData := CollectResource{Collect: &collect}
 	resource := new(CollectResource)
@@ -82,3 +147,50 @@ func (s *CollectServiceOp) Create(ctx context.Context, collect Collect) (*Collec
 func (s *CollectServiceOp) Delete(ctx context.Context, collectId uint64) error {
 	return s.client.Delete(ctx, fmt.Sprintf("%s/%d.json", collectsBasePath, collectId))
 }
+
+// findCollect returns the collect of the product in the collection, or nil
+func (s *CollectServiceOp) findCollect(ctx context.Context, collectionId uint64, productId uint64) (*Collect, error) {
+	collects, err := s.List(ctx, CollectListOptions{ProductId: productId, CollectionId: collectionId})
+	if err != nil {
+		return nil, err
+	}
+	for i := range collects {
+		if collects[i].CollectionId == collectionId && collects[i].ProductId == productId {
+			return &collects[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// AddProduct adds a product to a custom collection unless it is already
+// part of it, and returns the collect linking them
+func (s *CollectServiceOp) AddProduct(ctx context.Context, collectionId uint64, productId uint64) (*Collect, error) {
+	collect, err := s.findCollect(ctx, collectionId, productId)
+	if err != nil || collect != nil {
+		return collect, err
+	}
+
+	collect, err = s.Create(ctx, Collect{CollectionId: collectionId, ProductId: productId})
+	if respErr, ok := err.(ResponseError); ok && respErr.Status == http.StatusUnprocessableEntity {
+		// added concurrently since we looked
+		if existing, findErr := s.findCollect(ctx, collectionId, productId); findErr == nil && existing != nil {
+			return existing, nil
+		}
+	}
+	return collect, err
+}
+
+// RemoveProduct removes a product from a custom collection, it is not an
+// error if the product isn't part of the collection
+func (s *CollectServiceOp) RemoveProduct(ctx context.Context, collectionId uint64, productId uint64) error {
+	collect, err := s.findCollect(ctx, collectionId, productId)
+	if err != nil || collect == nil {
+		return err
+	}
+
+	err = s.Delete(ctx, collect.Id)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
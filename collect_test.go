@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -133,3 +134,152 @@ func TestCollectDelete(t *testing.T) {
 		t.Errorf("Collect.Delete returned error: %v", err)
 	}
 }
+
+func TestCollectListByProduct(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"product_id": "632910392", "limit": "250"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"collects": [{"id":1,"product_id":632910392,"collection_id":841564295}]}`))
+
+	collects, err := client.Collect.ListByProduct(context.Background(), 632910392)
+	if err != nil {
+		t.Errorf("Collect.ListByProduct returned error: %v", err)
+	}
+
+	expected := []Collect{{Id: 1, ProductId: 632910392, CollectionId: 841564295}}
+	if !reflect.DeepEqual(collects, expected) {
+		t.Errorf("Collect.ListByProduct returned %+v, expected %+v", collects, expected)
+	}
+}
+
+func TestCollectListByCollection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix)
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"collection_id": "841564295", "limit": "250"},
+		httpmock.ResponderFromResponse(&http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(`{"collects": [{"id":1},{"id":2}]}`),
+			Header:     http.Header{"Link": {`<http://valid.url?page_info=pg2&limit=250>; rel="next"`}},
+		}))
+	httpmock.RegisterResponderWithQuery("GET", listURL, map[string]string{"page_info": "pg2", "limit": "250"},
+		httpmock.NewStringResponder(200, `{"collects": [{"id":3}]}`))
+
+	collects, err := client.Collect.ListByCollection(context.Background(), 841564295)
+	if err != nil {
+		t.Errorf("Collect.ListByCollection returned error: %v", err)
+	}
+
+	expected := []Collect{{Id: 1}, {Id: 2}, {Id: 3}}
+	if !reflect.DeepEqual(collects, expected) {
+		t.Errorf("Collect.ListByCollection returned %+v, expected %+v", collects, expected)
+	}
+}
+
+func TestCollectAddProduct(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix)
+	params := map[string]string{"product_id": "632910392", "collection_id": "841564295"}
+	httpmock.RegisterResponderWithQuery("GET", listURL, params,
+		httpmock.NewStringResponder(200, `{"collects": []}`))
+	httpmock.RegisterResponder("POST", listURL,
+		httpmock.NewStringResponder(201, `{"collect": {"id":1,"product_id":632910392,"collection_id":841564295}}`))
+
+	collect, err := client.Collect.AddProduct(context.Background(), 841564295, 632910392)
+	if err != nil {
+		t.Errorf("Collect.AddProduct returned error: %v", err)
+	}
+	if collect == nil || collect.Id != 1 {
+		t.Errorf("Collect.AddProduct returned %+v", collect)
+	}
+	if calls := httpmock.GetCallCountInfo()["POST "+listURL]; calls != 1 {
+		t.Errorf("Collect.AddProduct created %d collects, expected 1", calls)
+	}
+}
+
+func TestCollectAddProductExisting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix)
+	params := map[string]string{"product_id": "632910392", "collection_id": "841564295"}
+	httpmock.RegisterResponderWithQuery("GET", listURL, params,
+		httpmock.NewStringResponder(200, `{"collects": [{"id":1,"product_id":632910392,"collection_id":841564295}]}`))
+	httpmock.RegisterResponder("POST", listURL, httpmock.NewStringResponder(201, `{"collect": {"id":2}}`))
+
+	collect, err := client.Collect.AddProduct(context.Background(), 841564295, 632910392)
+	if err != nil {
+		t.Errorf("Collect.AddProduct returned error: %v", err)
+	}
+	if collect == nil || collect.Id != 1 {
+		t.Errorf("Collect.AddProduct returned %+v, expected the existing collect", collect)
+	}
+	if calls := httpmock.GetCallCountInfo()["POST "+listURL]; calls != 0 {
+		t.Errorf("Collect.AddProduct created %d collects, expected none", calls)
+	}
+}
+
+func TestCollectAddProductConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix)
+	lists := 0
+	httpmock.RegisterResponder("GET", listURL, func(req *http.Request) (*http.Response, error) {
+		lists++
+		if lists == 1 {
+			return httpmock.NewStringResponse(200, `{"collects": []}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"collects": [{"id":3,"product_id":632910392,"collection_id":841564295}]}`), nil
+	})
+	httpmock.RegisterResponder("POST", listURL,
+		httpmock.NewStringResponder(422, `{"errors": {"product_id": ["is already taken"]}}`))
+
+	collect, err := client.Collect.AddProduct(context.Background(), 841564295, 632910392)
+	if err != nil {
+		t.Errorf("Collect.AddProduct returned error: %v", err)
+	}
+	if collect == nil || collect.Id != 3 {
+		t.Errorf("Collect.AddProduct returned %+v, expected the concurrently created collect", collect)
+	}
+}
+
+func TestCollectRemoveProduct(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix)
+	deleteURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/collects/1.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		httpmock.NewStringResponder(200, `{"collects": [{"id":1,"product_id":632910392,"collection_id":841564295}]}`))
+	httpmock.RegisterResponder("DELETE", deleteURL, httpmock.NewStringResponder(404, `{"errors": "Not Found"}`))
+
+	err := client.Collect.RemoveProduct(context.Background(), 841564295, 632910392)
+	if err != nil {
+		t.Errorf("Collect.RemoveProduct returned error: %v", err)
+	}
+	if calls := httpmock.GetCallCountInfo()["DELETE "+deleteURL]; calls != 1 {
+		t.Errorf("Collect.RemoveProduct deleted %d collects, expected 1", calls)
+	}
+}
+
+func TestCollectRemoveProductMissing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/collects.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"collects": []}`))
+
+	err := client.Collect.RemoveProduct(context.Background(), 841564295, 632910392)
+	if err != nil {
+		t.Errorf("Collect.RemoveProduct returned error: %v", err)
+	}
+	if info := httpmock.GetCallCountInfo(); len(info) != 1 {
+		t.Errorf("Collect.RemoveProduct made calls %v, expected only the list", info)
+	}
+}
@@ -0,0 +1,240 @@
+package goshopify
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const purchasingCompanyFragment = `fragment purchasingCompany on PurchasingCompany {
+  company {
+    id
+  }
+  location {
+    id
+  }
+  contact {
+    id
+  }
+}`
+
+const companyDraftOrderFields = `
+      nodes {
+        legacyResourceId
+        name
+        email
+        note2
+        tags
+        status
+        poNumber
+        currencyCode
+        invoiceUrl
+        invoiceSentAt
+        completedAt
+        createdAt
+        updatedAt
+        order {
+          legacyResourceId
+        }
+        subtotalPriceSet {
+          shopMoney {
+            amount
+          }
+        }
+        totalTaxSet {
+          shopMoney {
+            amount
+          }
+        }
+        totalPriceSet {
+          shopMoney {
+            amount
+          }
+        }
+        purchasingEntity {
+          ...purchasingCompany
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }`
+
+const companyDraftOrdersQuery = `query companyDraftOrders($id: ID!, $first: Int!, $after: String) {
+  company(id: $id) {
+    draftOrders(first: $first, after: $after) {` + companyDraftOrderFields + `
+    }
+  }
+}
+` + purchasingCompanyFragment
+
+const companyLocationDraftOrdersQuery = `query companyLocationDraftOrders($id: ID!, $first: Int!, $after: String) {
+  companyLocation(id: $id) {
+    draftOrders(first: $first, after: $after) {` + companyDraftOrderFields + `
+    }
+  }
+}
+` + purchasingCompanyFragment
+
+// CompanyOrdersOptions are the options of listing the orders or draft orders
+// of a B2B company
+type CompanyOrdersOptions struct {
+	// only list the orders of this company location
+	LocationId uint64
+	// number of orders per page, defaults to 50
+	First int
+	After string
+}
+
+// graphQLPurchasingCompany is the purchasingEntity of an order or draft
+// order, it is empty unless the order was placed for a company.
+type graphQLPurchasingCompany struct {
+	Company *struct {
+		Id string `json:"id"`
+	} `json:"company"`
+	Location *struct {
+		Id string `json:"id"`
+	} `json:"location"`
+	Contact *struct {
+		Id string `json:"id"`
+	} `json:"contact"`
+}
+
+func (p *graphQLPurchasingCompany) orderCompany() *OrderCompany {
+	if p == nil || p.Company == nil {
+		return nil
+	}
+
+	company := &OrderCompany{}
+	company.Id, _ = ParseGraphQLId(p.Company.Id)
+	if p.Location != nil {
+		company.LocationId, _ = ParseGraphQLId(p.Location.Id)
+	}
+	if p.Contact != nil {
+		company.ContactId, _ = ParseGraphQLId(p.Contact.Id)
+	}
+	return company
+}
+
+type companyDraftOrderNode struct {
+	LegacyResourceId string     `json:"legacyResourceId"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	Note             string     `json:"note2"`
+	Tags             []string   `json:"tags"`
+	Status           string     `json:"status"`
+	PoNumber         string     `json:"poNumber"`
+	CurrencyCode     string     `json:"currencyCode"`
+	InvoiceURL       string     `json:"invoiceUrl"`
+	InvoiceSentAt    *time.Time `json:"invoiceSentAt"`
+	CompletedAt      *time.Time `json:"completedAt"`
+	CreatedAt        *time.Time `json:"createdAt"`
+	UpdatedAt        *time.Time `json:"updatedAt"`
+	Order            *struct {
+		LegacyResourceId string `json:"legacyResourceId"`
+	} `json:"order"`
+	SubtotalPriceSet *graphQLMoneyBag          `json:"subtotalPriceSet"`
+	TotalTaxSet      *graphQLMoneyBag          `json:"totalTaxSet"`
+	TotalPriceSet    *graphQLMoneyBag          `json:"totalPriceSet"`
+	PurchasingEntity *graphQLPurchasingCompany `json:"purchasingEntity"`
+}
+
+func (n companyDraftOrderNode) draftOrder() DraftOrder {
+	id, _ := strconv.ParseUint(n.LegacyResourceId, 10, 64)
+	draftOrder := DraftOrder{
+		Id:            id,
+		Name:          n.Name,
+		Email:         n.Email,
+		Note:          n.Note,
+		Tags:          strings.Join(n.Tags, ", "),
+		Status:        strings.ToLower(n.Status),
+		PoNumber:      n.PoNumber,
+		Currency:      n.CurrencyCode,
+		InvoiceURL:    n.InvoiceURL,
+		InvoiceSentAt: n.InvoiceSentAt,
+		CompletedAt:   n.CompletedAt,
+		CreatedAt:     n.CreatedAt,
+		UpdatedAt:     n.UpdatedAt,
+		SubtotalPrice: n.SubtotalPriceSet.amountSet().Shop(),
+		Company:       n.PurchasingEntity.orderCompany(),
+	}
+	if n.Order != nil {
+		draftOrder.OrderId, _ = strconv.ParseUint(n.Order.LegacyResourceId, 10, 64)
+	}
+	// the REST resource has these as strings
+	if amount := n.TotalTaxSet.amountSet().Shop(); amount != nil {
+		draftOrder.TotalTax = amount.String()
+	}
+	if amount := n.TotalPriceSet.amountSet().Shop(); amount != nil {
+		draftOrder.TotalPrice = amount.String()
+	}
+	return draftOrder
+}
+
+// ListForCompany lists a page of the orders placed for a B2B company, or one
+// of its locations, through the GraphQL API. Only the summary fields of the
+// orders are set, see Search.
+func (s *OrderServiceOp) ListForCompany(ctx context.Context, companyId uint64, options *CompanyOrdersOptions) ([]Order, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &CompanyOrdersOptions{}
+	}
+
+	query := OrderSearchQuery{CompanyId: companyId, CompanyLocationId: options.LocationId}
+	return s.Search(ctx, query.String(), &OrderSearchOptions{First: options.First, After: options.After})
+}
+
+// ListForCompany lists a page of the draft orders of a B2B company, or one of
+// its locations, through the GraphQL API. Line items aren't set, use Get for
+// the full draft order.
+func (s *DraftOrderServiceOp) ListForCompany(ctx context.Context, companyId uint64, options *CompanyOrdersOptions) ([]DraftOrder, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &CompanyOrdersOptions{}
+	}
+
+	query := companyDraftOrdersQuery
+	vars := map[string]interface{}{
+		"id":    GraphQLId("Company", companyId),
+		"first": options.First,
+	}
+	if options.LocationId != 0 {
+		query = companyLocationDraftOrdersQuery
+		vars["id"] = GraphQLId("CompanyLocation", options.LocationId)
+	}
+	if options.First <= 0 {
+		vars["first"] = 50
+	}
+	if options.After != "" {
+		vars["after"] = options.After
+	}
+
+	type connection struct {
+		DraftOrders struct {
+			Nodes    []companyDraftOrderNode `json:"nodes"`
+			PageInfo GraphQLPageInfo         `json:"pageInfo"`
+		} `json:"draftOrders"`
+	}
+	resp := struct {
+		Company         *connection `json:"company"`
+		CompanyLocation *connection `json:"companyLocation"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, query, vars, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn := resp.Company
+	if conn == nil {
+		conn = resp.CompanyLocation
+	}
+	if conn == nil {
+		return []DraftOrder{}, &GraphQLPageInfo{}, nil
+	}
+
+	draftOrders := make([]DraftOrder, 0, len(conn.DraftOrders.Nodes))
+	for _, n := range conn.DraftOrders.Nodes {
+		draftOrders = append(draftOrders, n.draftOrder())
+	}
+	return draftOrders, &conn.DraftOrders.PageInfo, nil
+}
@@ -0,0 +1,152 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderSearchQueryCompany(t *testing.T) {
+	query := OrderSearchQuery{CompanyId: 1, CompanyLocationId: 2}.String()
+	expected := "company_id:1 AND company_location_id:2"
+	if query != expected {
+		t.Errorf("OrderSearchQuery.String returned %q, expected %q", query, expected)
+	}
+}
+
+func TestOrderListForCompany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"orders":{"nodes":[
+			{"legacyResourceId":"10","name":"#1001","purchasingEntity":{"company":{"id":"gid://shopify/Company/1"},"location":{"id":"gid://shopify/CompanyLocation/2"},"contact":{"id":"gid://shopify/CompanyContact/3"}}}
+		],"pageInfo":{"hasNextPage":false,"endCursor":"c1"}}}}`
+	})
+
+	orders, pageInfo, err := client.Order.ListForCompany(context.Background(), 1, &CompanyOrdersOptions{LocationId: 2, First: 10})
+	if err != nil {
+		t.Fatalf("Order.ListForCompany returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"first": float64(10), "query": "company_id:1 AND company_location_id:2", "reverse": false}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Order.ListForCompany sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	expected := []Order{{Id: 10, Name: "#1001", Company: &OrderCompany{Id: 1, LocationId: 2, ContactId: 3}}}
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.ListForCompany returned %+v, expected %+v", orders, expected)
+	}
+	if pageInfo.EndCursor != "c1" {
+		t.Errorf("Order.ListForCompany returned page info %+v", pageInfo)
+	}
+}
+
+func TestOrderSearchCustomerPurchasingEntity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// orders placed by customers have a Customer purchasing entity, which the
+	// fragment leaves empty
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"orders":{"nodes":[{"legacyResourceId":"10","purchasingEntity":{}}],"pageInfo":{}}}}`
+	})
+
+	orders, _, err := client.Order.Search(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("Order.Search returned error: %v", err)
+	}
+	if len(orders) != 1 || orders[0].Company != nil {
+		t.Errorf("Order.Search returned %+v, expected no company", orders)
+	}
+}
+
+func TestDraftOrderListForCompany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"company":{"draftOrders":{"nodes":[{
+			"legacyResourceId":"20",
+			"name":"#D1",
+			"note2":"net 30",
+			"tags":["b2b","wholesale"],
+			"status":"INVOICE_SENT",
+			"poNumber":"PO-1",
+			"currencyCode":"USD",
+			"order":null,
+			"subtotalPriceSet":{"shopMoney":{"amount":"90.0"},"presentmentMoney":{"amount":"90.0"}},
+			"totalTaxSet":{"shopMoney":{"amount":"10.0"},"presentmentMoney":{"amount":"10.0"}},
+			"totalPriceSet":{"shopMoney":{"amount":"100.0"},"presentmentMoney":{"amount":"100.0"}},
+			"purchasingEntity":{"company":{"id":"gid://shopify/Company/1"},"location":{"id":"gid://shopify/CompanyLocation/2"}}
+		}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`
+	})
+
+	draftOrders, pageInfo, err := client.DraftOrder.ListForCompany(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("DraftOrder.ListForCompany returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Company/1", "first": float64(50)}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("DraftOrder.ListForCompany sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	subtotal := decimal.NewFromFloat(90)
+	expected := []DraftOrder{{
+		Id:            20,
+		Name:          "#D1",
+		Note:          "net 30",
+		Tags:          "b2b, wholesale",
+		Status:        "invoice_sent",
+		PoNumber:      "PO-1",
+		Currency:      "USD",
+		SubtotalPrice: &subtotal,
+		TotalTax:      "10",
+		TotalPrice:    "100",
+		Company:       &OrderCompany{Id: 1, LocationId: 2},
+	}}
+	if len(draftOrders) != 1 || !draftOrders[0].SubtotalPrice.Equal(subtotal) {
+		t.Fatalf("DraftOrder.ListForCompany returned %+v, expected %+v", draftOrders, expected)
+	}
+	draftOrders[0].SubtotalPrice = &subtotal
+	if !reflect.DeepEqual(draftOrders, expected) {
+		t.Errorf("DraftOrder.ListForCompany returned %+v, expected %+v", draftOrders, expected)
+	}
+	if !pageInfo.HasNextPage || pageInfo.EndCursor != "c1" {
+		t.Errorf("DraftOrder.ListForCompany returned page info %+v", pageInfo)
+	}
+}
+
+func TestDraftOrderListForCompanyLocation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"companyLocation":{"draftOrders":{"nodes":[{"legacyResourceId":"21","order":{"legacyResourceId":"30"}}],"pageInfo":{}}}}}`
+	})
+
+	draftOrders, _, err := client.DraftOrder.ListForCompany(context.Background(), 1, &CompanyOrdersOptions{LocationId: 2, After: "c1"})
+	if err != nil {
+		t.Fatalf("DraftOrder.ListForCompany returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/CompanyLocation/2", "first": float64(50), "after": "c1"}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("DraftOrder.ListForCompany sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	expected := []DraftOrder{{Id: 21, OrderId: 30}}
+	if !reflect.DeepEqual(draftOrders, expected) {
+		t.Errorf("DraftOrder.ListForCompany returned %+v, expected %+v", draftOrders, expected)
+	}
+}
@@ -0,0 +1,63 @@
+package goshopify
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipBody compresses the body of a POST or PUT request when it is at least
+// the threshold of WithGzip, returning the body to send
+func (c *Client) gzipBody(req *http.Request, body []byte) ([]byte, error) {
+	if c.gzipThreshold <= 0 || len(body) < c.gzipThreshold {
+		return body, nil
+	}
+	if req.Method != http.MethodPost && req.Method != http.MethodPut {
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Encoding", "gzip")
+	req.ContentLength = int64(buf.Len())
+	return buf.Bytes(), nil
+}
+
+// gzipResponse decompresses a gzip encoded response. With WithGzip requests
+// ask for gzip explicitly, so the transport leaves decoding to the client.
+func gzipResponse(resp *http.Response) error {
+	if resp == nil || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+	resp.Body = &gzipReadCloser{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (r *gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
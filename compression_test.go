@@ -0,0 +1,105 @@
+package goshopify
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func gzipBytes(t *testing.T, b []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGzipRequestBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithGzip(1024)(client)
+
+	var received []byte
+	var encoding, acceptEncoding string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			encoding = req.Header.Get("Content-Encoding")
+			acceptEncoding = req.Header.Get("Accept-Encoding")
+			zr, err := gzip.NewReader(req.Body)
+			if err != nil {
+				t.Fatalf("request body isn't gzip compressed: %v", err)
+			}
+			received, _ = ioutil.ReadAll(zr)
+
+			resp := httpmock.NewBytesResponse(201, gzipBytes(t, []byte(`{"draft_order":{"id":1,"note":"compressed"}}`)))
+			resp.Header.Set("Content-Encoding", "gzip")
+			return resp, nil
+		})
+
+	note := strings.Repeat("x", 2048)
+	draftOrder, err := client.DraftOrder.Create(context.Background(), DraftOrder{Note: note})
+	if err != nil {
+		t.Fatalf("DraftOrder.Create returned error: %v", err)
+	}
+	if encoding != "gzip" || acceptEncoding != "gzip" {
+		t.Errorf("request had Content-Encoding %q and Accept-Encoding %q", encoding, acceptEncoding)
+	}
+	if !strings.Contains(string(received), note) {
+		t.Errorf("request body decompressed to %s", received)
+	}
+	if draftOrder.Id != 1 || draftOrder.Note != "compressed" {
+		t.Errorf("DraftOrder.Create returned %+v from a compressed response", draftOrder)
+	}
+}
+
+func TestGzipSmallRequestBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithGzip(1024)(client)
+
+	var encoding string
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			encoding = req.Header.Get("Content-Encoding")
+			return httpmock.NewStringResponse(201, `{"draft_order":{"id":1}}`), nil
+		})
+
+	if _, err := client.DraftOrder.Create(context.Background(), DraftOrder{Note: "short"}); err != nil {
+		t.Fatalf("DraftOrder.Create returned error: %v", err)
+	}
+	if encoding != "" {
+		t.Errorf("small request body was sent with Content-Encoding %q", encoding)
+	}
+}
+
+func TestGzipErrorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithGzip(1024)(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewBytesResponse(404, gzipBytes(t, []byte(`{"errors":"Not Found"}`)))
+			resp.Header.Set("Content-Encoding", "gzip")
+			return resp, nil
+		})
+
+	_, err := client.DraftOrder.Get(context.Background(), 1, nil)
+	if respErr, ok := err.(ResponseError); !ok || respErr.Message != "Not Found" {
+		t.Errorf("DraftOrder.Get returned %#v, expected the decompressed error", err)
+	}
+}
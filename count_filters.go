@@ -0,0 +1,74 @@
+package goshopify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+// countUnsupportedFilters are the filters the list endpoints of resources
+// support but their count endpoints silently ignore, keyed by resource.
+// Pagination parameters like limit and page_info don't change totals, so list
+// options without these filters can be passed to Count as is.
+var countUnsupportedFilters = map[string][]string{
+	"orders":             {"ids", "name", "since_id", "processed_at_min", "processed_at_max"},
+	"products":           {"ids", "since_id", "handle", "title", "status", "presentment_currencies"},
+	"customers":          {"ids", "since_id"},
+	"draft_orders":       {"ids"},
+	"custom_collections": {"ids", "since_id", "handle"},
+	"smart_collections":  {"ids", "since_id", "handle"},
+	"gift_cards":         {"since_id"},
+	"fulfillments":       {"since_id"},
+	"pages":              {"since_id", "handle"},
+	"redirects":          {"since_id"},
+	"script_tags":        {"since_id"},
+	"webhooks":           {"since_id"},
+}
+
+// CountFilterError is returned by Count when the options have filters the
+// count endpoint of the resource doesn't support, which would otherwise
+// return the total without them
+type CountFilterError struct {
+	Resource string
+	Filters  []string
+}
+
+func (e CountFilterError) Error() string {
+	return fmt.Sprintf("%s count doesn't support filters: %s", e.Resource, strings.Join(e.Filters, ", "))
+}
+
+// checkCountFilters checks the options of a count path, e.g.
+// products/1/variants/count.json, for unsupported filters
+func checkCountFilters(path string, options interface{}) error {
+	if options == nil {
+		return nil
+	}
+
+	resource := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/count.json")
+	if i := strings.LastIndex(resource, "/"); i >= 0 {
+		resource = resource[i+1:]
+	}
+	unsupported, ok := countUnsupportedFilters[resource]
+	if !ok {
+		return nil
+	}
+
+	values, err := query.Values(options)
+	if err != nil {
+		return err
+	}
+
+	var filters []string
+	for _, filter := range unsupported {
+		if _, ok := values[filter]; ok {
+			filters = append(filters, filter)
+		}
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	sort.Strings(filters)
+	return CountFilterError{Resource: resource, Filters: filters}
+}
@@ -0,0 +1,99 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCountWithListOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"status": "any", "financial_status": "paid", "limit": "250"}
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/count.json", client.pathPrefix),
+		params,
+		httpmock.NewStringResponder(200, `{"count": 3}`))
+
+	options := OrderListOptions{
+		ListOptions:     ListOptions{Limit: 250},
+		Status:          OrderStatusAny,
+		FinancialStatus: OrderFinancialStatusPaid,
+	}
+	cnt, err := client.Order.Count(context.Background(), options)
+	if err != nil {
+		t.Fatalf("Order.Count returned error: %v", err)
+	}
+	if cnt != 3 {
+		t.Errorf("Order.Count returned %d, expected 3", cnt)
+	}
+}
+
+func TestCountUnsupportedFilters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	date := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sinceId := uint64(10)
+	cases := []struct {
+		count    func() (int, error)
+		expected CountFilterError
+	}{
+		{
+			func() (int, error) {
+				return client.Order.Count(context.Background(), OrderListOptions{ProcessedAtMin: date, ProcessedAtMax: date})
+			},
+			CountFilterError{Resource: "orders", Filters: []string{"processed_at_max", "processed_at_min"}},
+		},
+		{
+			func() (int, error) {
+				return client.Order.Count(context.Background(), OrderCountOptions{SinceId: 10})
+			},
+			CountFilterError{Resource: "orders", Filters: []string{"since_id"}},
+		},
+		{
+			func() (int, error) {
+				return client.Product.Count(context.Background(), ProductListOptions{ListOptions: ListOptions{SinceId: &sinceId}, Handle: "shirt"})
+			},
+			CountFilterError{Resource: "products", Filters: []string{"handle", "since_id"}},
+		},
+		{
+			func() (int, error) {
+				return client.Customer.Count(context.Background(), ListOptions{Ids: []uint64{1, 2}})
+			},
+			CountFilterError{Resource: "customers", Filters: []string{"ids"}},
+		},
+	}
+
+	for _, c := range cases {
+		cnt, err := c.count()
+		if !reflect.DeepEqual(err, c.expected) {
+			t.Errorf("Count returned %v, expected %v", err, c.expected)
+		}
+		if cnt != 0 {
+			t.Errorf("Count returned %d with an error", cnt)
+		}
+	}
+
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("Count sent %d requests with unsupported filters", n)
+	}
+}
+
+func TestCheckCountFiltersNestedPath(t *testing.T) {
+	err := checkCountFilters("orders/1/fulfillments/count.json", ListOptions{SinceId: new(uint64)})
+	if _, ok := err.(CountFilterError); !ok {
+		t.Errorf("checkCountFilters returned %v, expected a CountFilterError", err)
+	}
+
+	// resources without known unsupported filters aren't checked
+	if err := checkCountFilters("collects/count.json", ListOptions{SinceId: new(uint64)}); err != nil {
+		t.Errorf("checkCountFilters returned %v for collects", err)
+	}
+}
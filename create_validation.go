@@ -0,0 +1,178 @@
+package goshopify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a problem with a field of a resource, e.g. a missing
+// required field. Field is the JSON path of the field, e.g.
+// line_items[0].quantity.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// ValidationError is returned by Create calls when a resource misses required
+// fields, see WithCreateValidation
+type ValidationError struct {
+	Resource string
+	Errors   []FieldError
+}
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Resource, strings.Join(msgs, ", "))
+}
+
+// createValidator is implemented by resources checking their required fields
+// before being created
+type createValidator interface {
+	createErrors() []FieldError
+}
+
+// validateCreate returns a ValidationError when create validation is enabled
+// and the resource misses required fields
+func (c *Client) validateCreate(resource string, v createValidator) error {
+	if !c.createValidation {
+		return nil
+	}
+	if errs := v.createErrors(); len(errs) > 0 {
+		return ValidationError{Resource: resource, Errors: errs}
+	}
+	return nil
+}
+
+func requiredField(field string) FieldError {
+	return FieldError{Field: field, Message: "is required"}
+}
+
+// lineItemsErrors checks the line items of orders and draft orders, which are
+// either variants or custom items with a title
+func lineItemsErrors(lineItems []LineItem) []FieldError {
+	if len(lineItems) == 0 {
+		return []FieldError{requiredField("line_items")}
+	}
+
+	var errs []FieldError
+	for i, item := range lineItems {
+		if item.VariantId == 0 && item.Title == "" {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("line_items[%d]", i), Message: "needs a variant_id or a title"})
+		}
+		if item.Quantity <= 0 {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("line_items[%d].quantity", i), Message: "must be positive"})
+		}
+	}
+	return errs
+}
+
+func (o Order) createErrors() []FieldError {
+	return lineItemsErrors(o.LineItems)
+}
+
+func (d DraftOrder) createErrors() []FieldError {
+	return lineItemsErrors(d.LineItems)
+}
+
+func (f Fulfillment) createErrors() []FieldError {
+	if len(f.LineItemsByFulfillmentOrder) == 0 {
+		return []FieldError{requiredField("line_items_by_fulfillment_order")}
+	}
+
+	var errs []FieldError
+	for i, items := range f.LineItemsByFulfillmentOrder {
+		if items.FulfillmentOrderId == 0 {
+			errs = append(errs, requiredField(fmt.Sprintf("line_items_by_fulfillment_order[%d].fulfillment_order_id", i)))
+		}
+	}
+	return errs
+}
+
+func (p Product) createErrors() []FieldError {
+	if p.Title == "" {
+		return []FieldError{requiredField("title")}
+	}
+	return nil
+}
+
+func (c Customer) createErrors() []FieldError {
+	if c.Email == "" && c.Phone == "" && c.FirstName == "" && c.LastName == "" {
+		return []FieldError{{Field: "email", Message: "or phone, first_name or last_name is required"}}
+	}
+	return nil
+}
+
+func (w Webhook) createErrors() []FieldError {
+	var errs []FieldError
+	if w.Address == "" {
+		errs = append(errs, requiredField("address"))
+	}
+	if w.Topic == "" {
+		errs = append(errs, requiredField("topic"))
+	}
+	return errs
+}
+
+func (m Metafield) createErrors() []FieldError {
+	var errs []FieldError
+	if m.Namespace == "" {
+		errs = append(errs, requiredField("namespace"))
+	}
+	if m.Key == "" {
+		errs = append(errs, requiredField("key"))
+	}
+	if m.Value == nil || m.Value == "" {
+		errs = append(errs, requiredField("value"))
+	}
+	if m.Type == "" {
+		errs = append(errs, requiredField("type"))
+	}
+	return errs
+}
+
+func (t Transaction) createErrors() []FieldError {
+	if t.Kind == "" {
+		return []FieldError{requiredField("kind")}
+	}
+	return nil
+}
+
+func (c Collect) createErrors() []FieldError {
+	var errs []FieldError
+	if c.ProductId == 0 {
+		errs = append(errs, requiredField("product_id"))
+	}
+	if c.CollectionId == 0 {
+		errs = append(errs, requiredField("collection_id"))
+	}
+	return errs
+}
+
+func (r Redirect) createErrors() []FieldError {
+	var errs []FieldError
+	if r.Path == "" {
+		errs = append(errs, requiredField("path"))
+	}
+	if r.Target == "" {
+		errs = append(errs, requiredField("target"))
+	}
+	return errs
+}
+
+func (s ScriptTag) createErrors() []FieldError {
+	var errs []FieldError
+	if s.Event == "" {
+		errs = append(errs, requiredField("event"))
+	}
+	if s.Src == "" {
+		errs = append(errs, requiredField("src"))
+	}
+	return errs
+}
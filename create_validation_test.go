@@ -0,0 +1,80 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCreateValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithCreateValidation())
+	httpmock.ActivateNonDefault(client.Client)
+
+	_, err := client.Order.Create(context.Background(), Order{
+		LineItems: []LineItem{{VariantId: 1, Quantity: 1}, {Quantity: 0}},
+	})
+	expected := ValidationError{Resource: "order", Errors: []FieldError{
+		{Field: "line_items[1]", Message: "needs a variant_id or a title"},
+		{Field: "line_items[1].quantity", Message: "must be positive"},
+	}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Order.Create returned %v, expected %v", err, expected)
+	}
+
+	_, err = client.Order.Create(context.Background(), Order{})
+	expected = ValidationError{Resource: "order", Errors: []FieldError{{Field: "line_items", Message: "is required"}}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Order.Create returned %v, expected %v", err, expected)
+	}
+
+	_, err = client.Order.CreateFulfillment(context.Background(), 1, Fulfillment{TrackingNumber: "123"})
+	expected = ValidationError{Resource: "fulfillment", Errors: []FieldError{{Field: "line_items_by_fulfillment_order", Message: "is required"}}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Fulfillment.Create returned %v, expected %v", err, expected)
+	}
+
+	_, err = client.Webhook.Create(context.Background(), Webhook{Topic: "orders/paid"})
+	if err == nil || err.Error() != "invalid webhook: address is required" {
+		t.Errorf("Webhook.Create returned %v", err)
+	}
+
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("invalid creates sent %d requests", n)
+	}
+}
+
+func TestCreateValidationValid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithCreateValidation())
+	httpmock.ActivateNonDefault(client.Client)
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order.json")))
+
+	_, err := client.Order.Create(context.Background(), Order{
+		LineItems: []LineItem{{VariantId: 1, Quantity: 1}, {Title: "Gift wrap", Quantity: 1}},
+	})
+	if err != nil {
+		t.Errorf("Order.Create returned error: %v", err)
+	}
+}
+
+func TestCreateValidationDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("product.json")))
+
+	if _, err := client.Product.Create(context.Background(), Product{}); err != nil {
+		t.Errorf("Product.Create returned error: %v", err)
+	}
+}
@@ -21,6 +21,7 @@ type CustomerService interface {
 	ListAll(context.Context, interface{}) ([]Customer, error)
 	ListWithPagination(ctx context.Context, options interface{}) ([]Customer, *Pagination, error)
 	Count(context.Context, interface{}) (int, error)
+	CountExact(context.Context, string) (int, error)
 	Get(context.Context, uint64, interface{}) (*Customer, error)
 	Search(context.Context, interface{}) ([]Customer, error)
 	Create(context.Context, Customer) (*Customer, error)
@@ -28,6 +29,7 @@ type CustomerService interface {
 	Delete(context.Context, uint64) error
 	ListOrders(context.Context, uint64, interface{}) ([]Order, error)
 	ListTags(context.Context, interface{}) ([]string, error)
+	ListTimelineEvents(context.Context, uint64, *TimelineEventsOptions) ([]TimelineEvent, *GraphQLPageInfo, error)
 
 	// MetafieldsService used for Customer resource to communicate with Metafields resource
 	MetafieldsService
@@ -164,6 +166,10 @@ func (s *CustomerServiceOp) Get(ctx context.Context, customerId uint64, options
 
 // Create a new customer
 func (s *CustomerServiceOp) Create(ctx context.Context, customer Customer) (*Customer, error) {
+	if err := s.client.validateCreate("customer", customer); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", customersBasePath)
 	wrappedData := CustomerResource{Customer: &customer}
 	resource := new(CustomerResource)
@@ -0,0 +1,396 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// number of emails or phones looked up by one customer search
+const customerImportSearchTerms = 25
+
+// CustomerImportResult is the outcome of importing a customer with a
+// CustomerImporter
+type CustomerImportResult struct {
+	// Customer is the created or updated customer, or the existing one when
+	// nothing changed. Nil when Err is set.
+	Customer *Customer
+
+	// Action is "created", "updated" or "unchanged"
+	Action string
+
+	// ConsentIgnored is set when the marketing consent of the imported
+	// customer was dropped, see CustomerImporter
+	ConsentIgnored bool
+
+	// Err is why the customer failed, see IsRetryableError
+	Err error
+}
+
+// CustomerImporter imports customers, e.g. when migrating a store from
+// another platform, without creating duplicates. Imported customers are
+// matched to existing ones and to each other by email, or by phone when they
+// have no email. A match is merged into the existing customer: its tags and
+// addresses are added and empty fields filled, nothing is overwritten.
+//
+// Marketing consent is only imported when it can be trusted: subscribed
+// states need the date consent was collected, consent is never set without
+// an email or phone to reach, and consent Shopify recorded more recently,
+// e.g. an unsubscribe since the export, is never overwritten.
+type CustomerImporter struct {
+	client *Client
+}
+
+// NewCustomerImporter returns a CustomerImporter for the client's shop
+func NewCustomerImporter(client *Client) *CustomerImporter {
+	return &CustomerImporter{client: client}
+}
+
+// customerImportGroup is the imported customers matching one customer,
+// merged into one write
+type customerImportGroup struct {
+	indexes  []int
+	customer Customer
+	existing *Customer
+}
+
+// Import imports the customers, looking existing ones up in batches and
+// writing every matched customer once. The results are in the order of
+// customers. If any customer failed the returned error is a BatchErrors
+// keyed by index.
+func (i *CustomerImporter) Import(ctx context.Context, customers []Customer) ([]CustomerImportResult, error) {
+	ctx = WithRequestPriority(ctx, PriorityBatch)
+	results := make([]CustomerImportResult, len(customers))
+	errs := BatchErrors{}
+
+	var groups []*customerImportGroup
+	all := groupImportedCustomers(customers)
+	for start := 0; start < len(all); start += customerImportSearchTerms {
+		end := start + customerImportSearchTerms
+		if end > len(all) {
+			end = len(all)
+		}
+		batch := all[start:end]
+
+		if err := i.matchExisting(ctx, batch); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			for _, g := range batch {
+				for _, index := range g.indexes {
+					errs[uint64(index)] = err
+				}
+			}
+			continue
+		}
+		groups = append(groups, batch...)
+	}
+
+	ids := make([]uint64, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, uint64(id))
+	}
+	written, err := i.client.GetMany(ctx, ids, func(ctx context.Context, id uint64) (interface{}, error) {
+		return i.write(ctx, groups[id])
+	})
+	writeErrs, _ := err.(BatchErrors)
+	if err != nil && writeErrs == nil {
+		return nil, err
+	}
+
+	for id, g := range groups {
+		for _, index := range g.indexes {
+			if err := writeErrs[uint64(id)]; err != nil {
+				errs[uint64(index)] = err
+				continue
+			}
+			results[index] = written[uint64(id)].(CustomerImportResult)
+		}
+	}
+	for index, err := range errs {
+		results[index] = CustomerImportResult{Err: err}
+	}
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+	return results, nil
+}
+
+// groupImportedCustomers merges the imported customers with the same email,
+// or phone without an email
+func groupImportedCustomers(customers []Customer) []*customerImportGroup {
+	var groups []*customerImportGroup
+	byKey := map[string]*customerImportGroup{}
+	for index, c := range customers {
+		c.Email = normalizeEmail(c.Email)
+		key := importedCustomerKey(c)
+		if g, ok := byKey[key]; ok && key != "" {
+			g.indexes = append(g.indexes, index)
+			mergeImportedCustomer(&g.customer, c)
+			continue
+		}
+
+		g := &customerImportGroup{indexes: []int{index}, customer: c}
+		g.customer.Addresses = addAddresses(nil, c.Addresses)
+		groups = append(groups, g)
+		byKey[key] = g
+	}
+	return groups
+}
+
+func importedCustomerKey(c Customer) string {
+	if c.Email != "" {
+		return "email:" + c.Email
+	}
+	if phone := normalizePhone(c.Phone); phone != "" {
+		return "phone:" + phone
+	}
+	return ""
+}
+
+// mergeImportedCustomer merges an imported duplicate into the customer
+// imported first, keeping the most recent marketing consent
+func mergeImportedCustomer(c *Customer, dup Customer) {
+	fillEmpty(&c.FirstName, dup.FirstName)
+	fillEmpty(&c.LastName, dup.LastName)
+	fillEmpty(&c.Phone, dup.Phone)
+	fillEmpty(&c.Note, dup.Note)
+	c.Tags = joinTags(addTags(splitTags(c.Tags), splitTags(dup.Tags)))
+	c.Addresses = addAddresses(c.Addresses, dup.Addresses)
+
+	if dup.EmailMarketingConsent != nil && (c.EmailMarketingConsent == nil ||
+		newerConsent(dup.EmailMarketingConsent.ConsentUpdatedAt, c.EmailMarketingConsent.ConsentUpdatedAt)) {
+		c.EmailMarketingConsent = dup.EmailMarketingConsent
+	}
+	if dup.SMSMarketingConsent != nil && (c.SMSMarketingConsent == nil ||
+		newerConsent(dup.SMSMarketingConsent.ConsentUpdatedAt, c.SMSMarketingConsent.ConsentUpdatedAt)) {
+		c.SMSMarketingConsent = dup.SMSMarketingConsent
+	}
+}
+
+// matchExisting looks up the existing customers of the groups with one
+// search
+func (i *CustomerImporter) matchExisting(ctx context.Context, groups []*customerImportGroup) error {
+	var terms []string
+	for _, g := range groups {
+		if key := importedCustomerKey(g.customer); key != "" {
+			field := strings.SplitN(key, ":", 2)
+			terms = append(terms, fmt.Sprintf("%s:%q", field[0], field[1]))
+		}
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	found, err := i.client.batchGet(ctx, 0, func(ctx context.Context, _ uint64) (interface{}, error) {
+		return i.client.Customer.Search(ctx, CustomerSearchOptions{Query: strings.Join(terms, " OR "), Limit: 250})
+	})
+	if err != nil {
+		return err
+	}
+
+	byEmail := map[string]*Customer{}
+	byPhone := map[string]*Customer{}
+	for _, c := range found.([]Customer) {
+		c := c
+		if email := normalizeEmail(c.Email); email != "" {
+			byEmail[email] = &c
+		}
+		if phone := normalizePhone(c.Phone); phone != "" {
+			byPhone[phone] = &c
+		}
+	}
+	for _, g := range groups {
+		if g.customer.Email != "" {
+			g.existing = byEmail[g.customer.Email]
+		} else if phone := normalizePhone(g.customer.Phone); phone != "" {
+			g.existing = byPhone[phone]
+		}
+	}
+	return nil
+}
+
+func (i *CustomerImporter) write(ctx context.Context, g *customerImportGroup) (CustomerImportResult, error) {
+	if g.existing == nil {
+		c := g.customer
+		result := CustomerImportResult{Action: "created"}
+		c.EmailMarketingConsent, result.ConsentIgnored = importEmailConsent(nil, c.EmailMarketingConsent, c.Email != "")
+		var ignored bool
+		c.SMSMarketingConsent, ignored = importSMSConsent(nil, c.SMSMarketingConsent, c.Phone != "")
+		result.ConsentIgnored = result.ConsentIgnored || ignored
+
+		created, err := i.client.Customer.Create(ctx, c)
+		if err != nil {
+			return result, err
+		}
+		result.Customer = created
+		return result, nil
+	}
+
+	update, result := mergeExistingCustomer(*g.existing, g.customer)
+	if result.Action == "unchanged" {
+		result.Customer = g.existing
+		return result, nil
+	}
+	updated, err := i.client.Customer.Update(ctx, update)
+	if err != nil {
+		return result, err
+	}
+	result.Customer = updated
+	return result, nil
+}
+
+// mergeExistingCustomer returns the update merging an imported customer into
+// the existing one
+func mergeExistingCustomer(existing Customer, c Customer) (Customer, CustomerImportResult) {
+	result := CustomerImportResult{Action: "unchanged"}
+	update := Customer{Id: existing.Id}
+	changed := func() { result.Action = "updated" }
+
+	for _, f := range []struct {
+		current  string
+		imported string
+		field    *string
+	}{
+		{existing.FirstName, c.FirstName, &update.FirstName},
+		{existing.LastName, c.LastName, &update.LastName},
+		{existing.Phone, c.Phone, &update.Phone},
+		{existing.Note, c.Note, &update.Note},
+	} {
+		if f.current == "" && f.imported != "" {
+			*f.field = f.imported
+			changed()
+		}
+	}
+
+	tags := splitTags(existing.Tags)
+	if merged := addTags(tags, splitTags(c.Tags)); len(merged) > len(tags) {
+		update.Tags = joinTags(merged)
+		changed()
+	}
+
+	if merged := addAddresses(existing.Addresses, c.Addresses); len(merged) > len(existing.Addresses) {
+		update.Addresses = merged
+		changed()
+	}
+
+	var ignored bool
+	update.EmailMarketingConsent, result.ConsentIgnored = importEmailConsent(existing.EmailMarketingConsent, c.EmailMarketingConsent, existing.Email != "")
+	update.SMSMarketingConsent, ignored = importSMSConsent(existing.SMSMarketingConsent, c.SMSMarketingConsent, existing.Phone != "" || c.Phone != "")
+	result.ConsentIgnored = result.ConsentIgnored || ignored
+	if update.EmailMarketingConsent != nil || update.SMSMarketingConsent != nil {
+		changed()
+	}
+	return update, result
+}
+
+// importEmailConsent returns the email marketing consent to set, nil when
+// it shouldn't change, and whether the imported consent was ignored
+func importEmailConsent(existing *EmailMarketingConsent, imported *EmailMarketingConsent, reachable bool) (*EmailMarketingConsent, bool) {
+	if imported == nil || imported.State == "" {
+		return nil, false
+	}
+	var state string
+	var at *time.Time
+	if existing != nil {
+		state, at = existing.State, existing.ConsentUpdatedAt
+	}
+	set, ignored := importConsent(state, at, imported.State, imported.ConsentUpdatedAt, reachable)
+	if !set {
+		return nil, ignored
+	}
+	return imported, false
+}
+
+// importSMSConsent is importEmailConsent for SMS marketing consent
+func importSMSConsent(existing *SMSMarketingConsent, imported *SMSMarketingConsent, reachable bool) (*SMSMarketingConsent, bool) {
+	if imported == nil || imported.State == "" {
+		return nil, false
+	}
+	var state string
+	var at *time.Time
+	if existing != nil {
+		state, at = existing.State, existing.ConsentUpdatedAt
+	}
+	set, ignored := importConsent(state, at, imported.State, imported.ConsentUpdatedAt, reachable)
+	if !set {
+		return nil, ignored
+	}
+	return imported, false
+}
+
+// importConsent tells whether an imported consent state replaces the
+// existing one, or else whether it was ignored by the consent rules
+func importConsent(state string, at *time.Time, importedState string, importedAt *time.Time, reachable bool) (set bool, ignored bool) {
+	if importedState == state {
+		return false, false
+	}
+	if !reachable || (importedState == "subscribed" && importedAt == nil) {
+		return false, true
+	}
+	if at != nil && !newerConsent(importedAt, at) {
+		return false, true
+	}
+	return true, false
+}
+
+// newerConsent tells whether consent collected at a is more recent than
+// consent collected at b, unknown dates are the oldest
+func newerConsent(a *time.Time, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	return b == nil || a.After(*b)
+}
+
+// addAddresses appends the addresses not in current yet
+func addAddresses(current []*CustomerAddress, addresses []*CustomerAddress) []*CustomerAddress {
+	result := append([]*CustomerAddress{}, current...)
+	keys := make(map[string]bool, len(current))
+	for _, a := range current {
+		keys[addressKey(a)] = true
+	}
+	for _, a := range addresses {
+		if a == nil || keys[addressKey(a)] {
+			continue
+		}
+		keys[addressKey(a)] = true
+		result = append(result, a)
+	}
+	return result
+}
+
+func addressKey(a *CustomerAddress) string {
+	country := a.CountryCode
+	if country == "" {
+		country = a.Country
+	}
+	fields := []string{a.Address1, a.Address2, a.City, strings.Replace(a.Zip, " ", "", -1), country}
+	for i, f := range fields {
+		fields[i] = strings.ToLower(strings.TrimSpace(f))
+	}
+	return strings.Join(fields, "|")
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizePhone keeps the digits and leading + of a phone number
+func normalizePhone(phone string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		if (r >= '0' && r <= '9') || (r == '+' && i == 0) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func fillEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
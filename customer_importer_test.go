@@ -0,0 +1,164 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCustomerImporterImport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var queries []string
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/search.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			queries = append(queries, req.URL.Query().Get("query"))
+			mu.Unlock()
+			return httpmock.NewStringResponse(200, `{"customers":[
+				{"id":1,"email":"jane@example.com","tags":"VIP",
+					"addresses":[{"id":10,"address1":"1 Main St","city":"Ottawa","zip":"K2P 0B0","country_code":"CA"}],
+					"email_marketing_consent":{"state":"unsubscribed","consent_updated_at":"2021-01-01T00:00:00Z"}},
+				{"id":3,"phone":"+15550001111","tags":"sms"}
+			]}`), nil
+		})
+
+	var updates []map[string]interface{}
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			var data map[string]map[string]interface{}
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("invalid customer body: %v", err)
+			}
+			mu.Lock()
+			updates = append(updates, data["customer"])
+			mu.Unlock()
+			return httpmock.NewStringResponse(200, `{"customer":{"id":1}}`), nil
+		})
+
+	var created map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &created); err != nil {
+				t.Errorf("invalid customer body: %v", err)
+			}
+			return httpmock.NewStringResponse(201, `{"customer":{"id":2}}`), nil
+		})
+
+	consentAt := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	results, err := NewCustomerImporter(client).Import(context.Background(), []Customer{
+		{
+			Email:                 "Jane@Example.com",
+			Tags:                  "vip",
+			Addresses:             []*CustomerAddress{{Address1: "1 main st", City: "Ottawa", Zip: "K2P0B0", CountryCode: "CA"}},
+			EmailMarketingConsent: &EmailMarketingConsent{State: "subscribed", ConsentUpdatedAt: &consentAt},
+		},
+		{
+			Email:     "jane@example.com",
+			FirstName: "Jane",
+			Tags:      "wholesale",
+			Addresses: []*CustomerAddress{{Address1: "2 Side St", City: "Ottawa", CountryCode: "CA"}},
+		},
+		{
+			Email:                 "new@example.com",
+			EmailMarketingConsent: &EmailMarketingConsent{State: "subscribed"},
+		},
+		{
+			Phone: "+1 (555) 000-1111",
+			Tags:  "SMS",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CustomerImporter.Import returned error: %v", err)
+	}
+
+	if len(queries) != 1 || queries[0] != `email:"jane@example.com" OR email:"new@example.com" OR phone:"+15550001111"` {
+		t.Errorf("CustomerImporter.Import searched %q", queries)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("CustomerImporter.Import made %d updates, expected 1", len(updates))
+	}
+	update := updates[0]
+	if update["tags"] != "VIP, wholesale" || update["first_name"] != "Jane" || update["email_marketing_consent"] != nil {
+		t.Errorf("CustomerImporter.Import sent update %v", update)
+	}
+	if addresses, _ := update["addresses"].([]interface{}); len(addresses) != 2 {
+		t.Errorf("CustomerImporter.Import sent addresses %v, expected the existing and the new one", update["addresses"])
+	}
+
+	if created["customer"]["email"] != "new@example.com" || created["customer"]["email_marketing_consent"] != nil {
+		t.Errorf("CustomerImporter.Import created %v", created)
+	}
+
+	expected := []struct {
+		id      uint64
+		action  string
+		ignored bool
+	}{
+		{1, "updated", true},
+		{1, "updated", true},
+		{2, "created", true},
+		{3, "unchanged", false},
+	}
+	for i, e := range expected {
+		r := results[i]
+		if r.Err != nil || r.Customer == nil || r.Customer.Id != e.id || r.Action != e.action || r.ConsentIgnored != e.ignored {
+			t.Errorf("CustomerImporter.Import result %d is %+v, expected %+v", i, r, e)
+		}
+	}
+}
+
+func TestCustomerImporterSearchError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/search.json", client.pathPrefix),
+		httpmock.NewStringResponder(500, `{"errors":"boom"}`))
+
+	results, err := NewCustomerImporter(client).Import(context.Background(), []Customer{{Email: "jane@example.com"}})
+	errs, ok := err.(BatchErrors)
+	if !ok || errs[0] == nil || results[0].Err == nil {
+		t.Errorf("CustomerImporter.Import returned %v, %+v, expected a BatchErrors for customer 0", err, results)
+	}
+}
+
+func TestImportConsent(t *testing.T) {
+	older := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		state      string
+		at         *time.Time
+		imported   string
+		importedAt *time.Time
+		reachable  bool
+		set        bool
+		ignored    bool
+	}{
+		{"", nil, "subscribed", &older, true, true, false},
+		{"", nil, "subscribed", nil, true, false, true},
+		{"", nil, "subscribed", &older, false, false, true},
+		{"not_subscribed", nil, "unsubscribed", nil, true, true, false},
+		{"unsubscribed", &newer, "subscribed", &older, true, false, true},
+		{"unsubscribed", &older, "subscribed", &newer, true, true, false},
+		{"subscribed", &older, "subscribed", &newer, true, false, false},
+	}
+	for i, c := range cases {
+		set, ignored := importConsent(c.state, c.at, c.imported, c.importedAt, c.reachable)
+		if set != c.set || ignored != c.ignored {
+			t.Errorf("case %d: importConsent returned %v, %v, expected %v, %v", i, set, ignored, c.set, c.ignored)
+		}
+	}
+}
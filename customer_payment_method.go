@@ -0,0 +1,257 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+)
+
+const customerPaymentMethodFields = `
+  id
+  revokedAt
+  revokedReason
+  customer {
+    id
+  }
+  instrument {
+    __typename
+    ... on CustomerCreditCard {
+      brand
+      name
+      lastDigits
+      maskedNumber
+      expiryMonth
+      expiryYear
+      expiresSoon
+    }
+    ... on CustomerShopPayAgreement {
+      name
+      lastDigits
+      maskedNumber
+      expiryMonth
+      expiryYear
+      expiresSoon
+    }
+    ... on CustomerPaypalBillingAgreement {
+      paypalAccountEmail
+    }
+  }`
+
+const customerPaymentMethodsQuery = `query customerPaymentMethods($id: ID!, $showRevoked: Boolean) {
+  customer(id: $id) {
+    paymentMethods(first: 50, showRevoked: $showRevoked) {
+      nodes {` + customerPaymentMethodFields + `
+      }
+    }
+  }
+}`
+
+const customerPaymentMethodQuery = `query customerPaymentMethod($id: ID!, $showRevoked: Boolean) {
+  customerPaymentMethod(id: $id, showRevoked: $showRevoked) {` + customerPaymentMethodFields + `
+  }
+}`
+
+const customerPaymentMethodRevokeMutation = `mutation customerPaymentMethodRevoke($customerPaymentMethodId: ID!) {
+  customerPaymentMethodRevoke(customerPaymentMethodId: $customerPaymentMethodId) {
+    revokedCustomerPaymentMethodId
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const customerPaymentMethodSendUpdateEmailMutation = `mutation customerPaymentMethodSendUpdateEmail($customerPaymentMethodId: ID!, $email: EmailInput) {
+  customerPaymentMethodSendUpdateEmail(customerPaymentMethodId: $customerPaymentMethodId, email: $email) {
+    customer {
+      id
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// CustomerPaymentMethodService is an interface for interfacing with the
+// vaulted payment methods of customers through the GraphQL API, as used by
+// subscription billing.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/objects/CustomerPaymentMethod
+type CustomerPaymentMethodService interface {
+	List(context.Context, uint64, bool) ([]CustomerPaymentMethod, error)
+	Get(context.Context, string) (*CustomerPaymentMethod, error)
+	Revoke(context.Context, string) error
+	SendUpdateEmail(context.Context, string, *CustomerPaymentMethodEmail) error
+}
+
+// CustomerPaymentMethodServiceOp handles communication with the customer
+// payment method related methods of the Shopify API.
+type CustomerPaymentMethodServiceOp struct {
+	client *Client
+}
+
+// CustomerPaymentMethod is a payment method vaulted for a customer. Its Id
+// is the GraphQL id, payment method ids aren't numeric.
+type CustomerPaymentMethod struct {
+	Id            string
+	CustomerId    uint64
+	RevokedAt     *time.Time
+	RevokedReason string
+	// CustomerCreditCard, CustomerShopPayAgreement or
+	// CustomerPaypalBillingAgreement
+	InstrumentType     string
+	Brand              string
+	Name               string
+	LastDigits         string
+	MaskedNumber       string
+	ExpiryMonth        int
+	ExpiryYear         int
+	ExpiresSoon        bool
+	PaypalAccountEmail string
+}
+
+// IsRevoked returns whether the payment method was revoked and can no longer
+// be charged
+func (m CustomerPaymentMethod) IsRevoked() bool {
+	return m.RevokedAt != nil
+}
+
+// CustomerPaymentMethodEmail customizes the email sent by SendUpdateEmail,
+// fields left empty use the shop's defaults.
+type CustomerPaymentMethodEmail struct {
+	From          string   `json:"from,omitempty"`
+	To            string   `json:"to,omitempty"`
+	Bcc           []string `json:"bcc,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	CustomMessage string   `json:"customMessage,omitempty"`
+}
+
+type graphQLCustomerPaymentMethod struct {
+	Id            string     `json:"id"`
+	RevokedAt     *time.Time `json:"revokedAt"`
+	RevokedReason string     `json:"revokedReason"`
+	Customer      *struct {
+		Id string `json:"id"`
+	} `json:"customer"`
+	Instrument *struct {
+		Typename           string `json:"__typename"`
+		Brand              string `json:"brand"`
+		Name               string `json:"name"`
+		LastDigits         string `json:"lastDigits"`
+		MaskedNumber       string `json:"maskedNumber"`
+		ExpiryMonth        int    `json:"expiryMonth"`
+		ExpiryYear         int    `json:"expiryYear"`
+		ExpiresSoon        bool   `json:"expiresSoon"`
+		PaypalAccountEmail string `json:"paypalAccountEmail"`
+	} `json:"instrument"`
+}
+
+func (m *graphQLCustomerPaymentMethod) paymentMethod() *CustomerPaymentMethod {
+	if m == nil {
+		return nil
+	}
+
+	method := &CustomerPaymentMethod{
+		Id:            m.Id,
+		RevokedAt:     m.RevokedAt,
+		RevokedReason: m.RevokedReason,
+	}
+	if m.Customer != nil {
+		method.CustomerId, _ = ParseGraphQLId(m.Customer.Id)
+	}
+	if i := m.Instrument; i != nil {
+		method.InstrumentType = i.Typename
+		method.Brand = i.Brand
+		method.Name = i.Name
+		method.LastDigits = i.LastDigits
+		method.MaskedNumber = i.MaskedNumber
+		method.ExpiryMonth = i.ExpiryMonth
+		method.ExpiryYear = i.ExpiryYear
+		method.ExpiresSoon = i.ExpiresSoon
+		method.PaypalAccountEmail = i.PaypalAccountEmail
+	}
+	return method
+}
+
+// List lists the payment methods of a customer, including revoked ones if
+// showRevoked is set.
+func (s *CustomerPaymentMethodServiceOp) List(ctx context.Context, customerId uint64, showRevoked bool) ([]CustomerPaymentMethod, error) {
+	resp := struct {
+		Customer *struct {
+			PaymentMethods struct {
+				Nodes []graphQLCustomerPaymentMethod `json:"nodes"`
+			} `json:"paymentMethods"`
+		} `json:"customer"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":          GraphQLId("Customer", customerId),
+		"showRevoked": showRevoked,
+	}
+	err := s.client.GraphQL.Query(ctx, customerPaymentMethodsQuery, vars, &resp)
+	if err != nil || resp.Customer == nil {
+		return nil, err
+	}
+
+	methods := make([]CustomerPaymentMethod, 0, len(resp.Customer.PaymentMethods.Nodes))
+	for i := range resp.Customer.PaymentMethods.Nodes {
+		methods = append(methods, *resp.Customer.PaymentMethods.Nodes[i].paymentMethod())
+	}
+	return methods, nil
+}
+
+// Get gets a payment method by its GraphQL id, revoked or not
+func (s *CustomerPaymentMethodServiceOp) Get(ctx context.Context, paymentMethodId string) (*CustomerPaymentMethod, error) {
+	resp := struct {
+		CustomerPaymentMethod *graphQLCustomerPaymentMethod `json:"customerPaymentMethod"`
+	}{}
+
+	vars := map[string]interface{}{"id": paymentMethodId, "showRevoked": true}
+	err := s.client.GraphQL.Query(ctx, customerPaymentMethodQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.CustomerPaymentMethod.paymentMethod(), nil
+}
+
+// Revoke revokes a payment method, it can't be charged afterwards
+func (s *CustomerPaymentMethodServiceOp) Revoke(ctx context.Context, paymentMethodId string) error {
+	resp := struct {
+		CustomerPaymentMethodRevoke struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"customerPaymentMethodRevoke"`
+	}{}
+
+	vars := map[string]interface{}{"customerPaymentMethodId": paymentMethodId}
+	err := s.client.GraphQL.Query(ctx, customerPaymentMethodRevokeMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.CustomerPaymentMethodRevoke.UserErrors) > 0 {
+		return resp.CustomerPaymentMethodRevoke.UserErrors
+	}
+	return nil
+}
+
+// SendUpdateEmail sends the customer an email with a link to update the
+// payment method, e.g. after a failed subscription billing attempt. A nil
+// email uses the shop's defaults.
+func (s *CustomerPaymentMethodServiceOp) SendUpdateEmail(ctx context.Context, paymentMethodId string, email *CustomerPaymentMethodEmail) error {
+	resp := struct {
+		CustomerPaymentMethodSendUpdateEmail struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"customerPaymentMethodSendUpdateEmail"`
+	}{}
+
+	vars := map[string]interface{}{"customerPaymentMethodId": paymentMethodId}
+	if email != nil {
+		vars["email"] = email
+	}
+	err := s.client.GraphQL.Query(ctx, customerPaymentMethodSendUpdateEmailMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.CustomerPaymentMethodSendUpdateEmail.UserErrors) > 0 {
+		return resp.CustomerPaymentMethodSendUpdateEmail.UserErrors
+	}
+	return nil
+}
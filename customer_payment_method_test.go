@@ -0,0 +1,145 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCustomerPaymentMethodList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"customer":{"paymentMethods":{"nodes":[
+			{"id":"gid://shopify/CustomerPaymentMethod/abc","customer":{"id":"gid://shopify/Customer/7"},"instrument":{
+				"__typename":"CustomerCreditCard","brand":"visa","name":"Bob Bobsen","lastDigits":"4242","maskedNumber":"•••• 4242","expiryMonth":12,"expiryYear":2030,"expiresSoon":false
+			}},
+			{"id":"gid://shopify/CustomerPaymentMethod/def","revokedAt":"2024-01-01T00:00:00Z","revokedReason":"MANUALLY_REVOKED","customer":{"id":"gid://shopify/Customer/7"},"instrument":{
+				"__typename":"CustomerPaypalBillingAgreement","paypalAccountEmail":"bob@example.com"
+			}}
+		]}}}}`
+	})
+
+	methods, err := client.CustomerPaymentMethod.List(context.Background(), 7, true)
+	if err != nil {
+		t.Fatalf("CustomerPaymentMethod.List returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Customer/7", "showRevoked": true}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("CustomerPaymentMethod.List sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	revokedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := []CustomerPaymentMethod{
+		{
+			Id:             "gid://shopify/CustomerPaymentMethod/abc",
+			CustomerId:     7,
+			InstrumentType: "CustomerCreditCard",
+			Brand:          "visa",
+			Name:           "Bob Bobsen",
+			LastDigits:     "4242",
+			MaskedNumber:   "•••• 4242",
+			ExpiryMonth:    12,
+			ExpiryYear:     2030,
+		},
+		{
+			Id:                 "gid://shopify/CustomerPaymentMethod/def",
+			CustomerId:         7,
+			RevokedAt:          &revokedAt,
+			RevokedReason:      "MANUALLY_REVOKED",
+			InstrumentType:     "CustomerPaypalBillingAgreement",
+			PaypalAccountEmail: "bob@example.com",
+		},
+	}
+	if !reflect.DeepEqual(methods, expected) {
+		t.Errorf("CustomerPaymentMethod.List returned %+v, expected %+v", methods, expected)
+	}
+
+	if methods[0].IsRevoked() || !methods[1].IsRevoked() {
+		t.Errorf("CustomerPaymentMethod.IsRevoked returned %v, %v", methods[0].IsRevoked(), methods[1].IsRevoked())
+	}
+}
+
+func TestCustomerPaymentMethodGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"customerPaymentMethod":{"id":"gid://shopify/CustomerPaymentMethod/abc","instrument":{"__typename":"CustomerShopPayAgreement","lastDigits":"1111"}}}}`
+	})
+
+	method, err := client.CustomerPaymentMethod.Get(context.Background(), "gid://shopify/CustomerPaymentMethod/abc")
+	if err != nil {
+		t.Fatalf("CustomerPaymentMethod.Get returned error: %v", err)
+	}
+
+	if vars["id"] != "gid://shopify/CustomerPaymentMethod/abc" || method.InstrumentType != "CustomerShopPayAgreement" || method.LastDigits != "1111" {
+		t.Errorf("CustomerPaymentMethod.Get sent %+v, returned %+v", vars, method)
+	}
+}
+
+func TestCustomerPaymentMethodRevoke(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"customerPaymentMethodRevoke":{"revokedCustomerPaymentMethodId":"gid://shopify/CustomerPaymentMethod/abc","userErrors":[]}}}`
+	})
+
+	err := client.CustomerPaymentMethod.Revoke(context.Background(), "gid://shopify/CustomerPaymentMethod/abc")
+	if err != nil {
+		t.Errorf("CustomerPaymentMethod.Revoke returned error: %v", err)
+	}
+	if vars["customerPaymentMethodId"] != "gid://shopify/CustomerPaymentMethod/abc" {
+		t.Errorf("CustomerPaymentMethod.Revoke sent %+v", vars)
+	}
+}
+
+func TestCustomerPaymentMethodSendUpdateEmail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"customerPaymentMethodSendUpdateEmail":{"customer":{"id":"gid://shopify/Customer/7"},"userErrors":[]}}}`
+	})
+
+	err := client.CustomerPaymentMethod.SendUpdateEmail(context.Background(), "gid://shopify/CustomerPaymentMethod/abc", &CustomerPaymentMethodEmail{
+		Subject:       "Your payment failed",
+		CustomMessage: "Please update your card",
+	})
+	if err != nil {
+		t.Errorf("CustomerPaymentMethod.SendUpdateEmail returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"customerPaymentMethodId": "gid://shopify/CustomerPaymentMethod/abc",
+		"email":                   map[string]interface{}{"subject": "Your payment failed", "customMessage": "Please update your card"},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("CustomerPaymentMethod.SendUpdateEmail sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"customerPaymentMethodSendUpdateEmail":{"customer":null,"userErrors":[{"field":["customerPaymentMethodId"],"message":"Payment method is revoked"}]}}}`
+	})
+
+	err = client.CustomerPaymentMethod.SendUpdateEmail(context.Background(), "gid://shopify/CustomerPaymentMethod/def", nil)
+	if err == nil || err.Error() != "customerPaymentMethodId: Payment method is revoked" {
+		t.Errorf("CustomerPaymentMethod.SendUpdateEmail err returned %v", err)
+	}
+	if _, ok := vars["email"]; ok {
+		t.Errorf("CustomerPaymentMethod.SendUpdateEmail sent an email for a nil email: %+v", vars)
+	}
+}
@@ -0,0 +1,143 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const customerSavedSearchesBasePath = "customer_saved_searches"
+
+// CustomerSavedSearchService is an interface for interacting with the
+// customer saved search endpoints of the Shopify API.
+// See https://shopify.dev/docs/api/admin-rest/latest/resources/customersavedsearch
+type CustomerSavedSearchService interface {
+	List(context.Context, interface{}) ([]CustomerSavedSearch, error)
+	Count(context.Context, interface{}) (int, error)
+	Get(context.Context, uint64, interface{}) (*CustomerSavedSearch, error)
+	Create(context.Context, CustomerSavedSearch) (*CustomerSavedSearch, error)
+	Update(context.Context, CustomerSavedSearch) (*CustomerSavedSearch, error)
+	Delete(context.Context, uint64) error
+	ListCustomers(context.Context, uint64, interface{}) ([]Customer, error)
+	ListCustomersWithPagination(context.Context, uint64, interface{}) ([]Customer, *Pagination, error)
+	ListAllCustomers(context.Context, uint64, interface{}) ([]Customer, error)
+}
+
+// CustomerSavedSearchServiceOp handles communication with the customer saved
+// search related methods of the Shopify API.
+type CustomerSavedSearchServiceOp struct {
+	client *Client
+}
+
+// CustomerSavedSearch represents a Shopify customer saved search, a named
+// customer search query such as "accepts_marketing:1 country:Canada"
+type CustomerSavedSearch struct {
+	Id        uint64     `json:"id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Query     string     `json:"query,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CustomerSavedSearchResource represents the result from the
+// customer_saved_searches/X.json endpoint
+type CustomerSavedSearchResource struct {
+	CustomerSavedSearch *CustomerSavedSearch `json:"customer_saved_search"`
+}
+
+// CustomerSavedSearchesResource represents the result from the
+// customer_saved_searches.json endpoint
+type CustomerSavedSearchesResource struct {
+	CustomerSavedSearches []CustomerSavedSearch `json:"customer_saved_searches"`
+}
+
+// List customer saved searches
+func (s *CustomerSavedSearchServiceOp) List(ctx context.Context, options interface{}) ([]CustomerSavedSearch, error) {
+	path := fmt.Sprintf("%s.json", customerSavedSearchesBasePath)
+	resource := new(CustomerSavedSearchesResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.CustomerSavedSearches, err
+}
+
+// Count customer saved searches
+func (s *CustomerSavedSearchServiceOp) Count(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/count.json", customerSavedSearchesBasePath)
+	return s.client.Count(ctx, path, options)
+}
+
+// Get individual customer saved search
+func (s *CustomerSavedSearchServiceOp) Get(ctx context.Context, savedSearchId uint64, options interface{}) (*CustomerSavedSearch, error) {
+	path := fmt.Sprintf("%s/%d.json", customerSavedSearchesBasePath, savedSearchId)
+	resource := new(CustomerSavedSearchResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.CustomerSavedSearch, err
+}
+
+// Create a new customer saved search
+func (s *CustomerSavedSearchServiceOp) Create(ctx context.Context, savedSearch CustomerSavedSearch) (*CustomerSavedSearch, error) {
+	path := fmt.Sprintf("%s.json", customerSavedSearchesBasePath)
+	wrappedData := CustomerSavedSearchResource{CustomerSavedSearch: &savedSearch}
+	resource := new(CustomerSavedSearchResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.CustomerSavedSearch, err
+}
+
+// Update an existing customer saved search
+func (s *CustomerSavedSearchServiceOp) Update(ctx context.Context, savedSearch CustomerSavedSearch) (*CustomerSavedSearch, error) {
+	path := fmt.Sprintf("%s/%d.json", customerSavedSearchesBasePath, savedSearch.Id)
+	wrappedData := CustomerSavedSearchResource{CustomerSavedSearch: &savedSearch}
+	resource := new(CustomerSavedSearchResource)
+	err := s.client.Put(ctx, path, wrappedData, resource)
+	return resource.CustomerSavedSearch, err
+}
+
+// Delete an existing customer saved search
+func (s *CustomerSavedSearchServiceOp) Delete(ctx context.Context, savedSearchId uint64) error {
+	return s.client.Delete(ctx, fmt.Sprintf("%s/%d.json", customerSavedSearchesBasePath, savedSearchId))
+}
+
+// ListCustomers lists the customers matching a saved search
+func (s *CustomerSavedSearchServiceOp) ListCustomers(ctx context.Context, savedSearchId uint64, options interface{}) ([]Customer, error) {
+	path := fmt.Sprintf("%s/%d/customers.json", customerSavedSearchesBasePath, savedSearchId)
+	resource := new(CustomersResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Customers, err
+}
+
+// ListCustomersWithPagination lists the customers matching a saved search and
+// return pagination to retrieve next/previous results.
+func (s *CustomerSavedSearchServiceOp) ListCustomersWithPagination(ctx context.Context, savedSearchId uint64, options interface{}) ([]Customer, *Pagination, error) {
+	path := fmt.Sprintf("%s/%d/customers.json", customerSavedSearchesBasePath, savedSearchId)
+	resource := new(CustomersResource)
+
+	pagination, err := s.client.ListWithPagination(ctx, path, resource, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource.Customers, pagination, nil
+}
+
+// ListAllCustomers lists all customers matching a saved search, iterating
+// over pages
+func (s *CustomerSavedSearchServiceOp) ListAllCustomers(ctx context.Context, savedSearchId uint64, options interface{}) ([]Customer, error) {
+	collector := []Customer{}
+
+	for {
+		entities, pagination, err := s.ListCustomersWithPagination(ctx, savedSearchId, options)
+
+		if err != nil {
+			return collector, err
+		}
+
+		collector = append(collector, entities...)
+
+		if pagination.NextPageOptions == nil {
+			break
+		}
+
+		options = pagination.NextPageOptions
+	}
+
+	return collector, nil
+}
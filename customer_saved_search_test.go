@@ -0,0 +1,199 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCustomerSavedSearchList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("customer_saved_searches.json")))
+
+	savedSearches, err := client.CustomerSavedSearch.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.List returned error: %v", err)
+	}
+
+	createdAt := time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)
+	expected := []CustomerSavedSearch{
+		{Id: 20610973, Name: "Canadian Snowboarders", Query: "Bob country:Canada", CreatedAt: &createdAt, UpdatedAt: &createdAt},
+		{Id: 789629109, Name: "Accepts Marketing", Query: "accepts_marketing:1"},
+	}
+	if len(savedSearches) != 2 {
+		t.Fatalf("CustomerSavedSearch.List returned %d saved searches, expected 2", len(savedSearches))
+	}
+	if !savedSearches[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("CustomerSavedSearch.CreatedAt returned %v, expected %v", savedSearches[0].CreatedAt, createdAt)
+	}
+	savedSearches[0].CreatedAt, savedSearches[0].UpdatedAt = &createdAt, &createdAt
+	if !reflect.DeepEqual(savedSearches, expected) {
+		t.Errorf("CustomerSavedSearch.List returned %+v, expected %+v", savedSearches, expected)
+	}
+}
+
+func TestCustomerSavedSearchCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/count.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"count": 2}`))
+
+	cnt, err := client.CustomerSavedSearch.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.Count returned error: %v", err)
+	}
+	if cnt != 2 {
+		t.Errorf("CustomerSavedSearch.Count returned %d, expected 2", cnt)
+	}
+}
+
+func TestCustomerSavedSearchGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/20610973.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"customer_saved_search": {"id":20610973,"name":"Canadian Snowboarders","query":"Bob country:Canada"}}`))
+
+	savedSearch, err := client.CustomerSavedSearch.Get(context.Background(), 20610973, nil)
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.Get returned error: %v", err)
+	}
+
+	expected := &CustomerSavedSearch{Id: 20610973, Name: "Canadian Snowboarders", Query: "Bob country:Canada"}
+	if !reflect.DeepEqual(savedSearch, expected) {
+		t.Errorf("CustomerSavedSearch.Get returned %+v, expected %+v", savedSearch, expected)
+	}
+}
+
+func TestCustomerSavedSearchCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body []byte
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ = ioutil.ReadAll(req.Body)
+			return httpmock.NewStringResponse(201, `{"customer_saved_search": {"id":1,"name":"Spent 50","query":"total_spent:>50"}}`), nil
+		})
+
+	savedSearch, err := client.CustomerSavedSearch.Create(context.Background(), CustomerSavedSearch{Name: "Spent 50", Query: "total_spent:>50"})
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.Create returned error: %v", err)
+	}
+	if savedSearch.Id != 1 {
+		t.Errorf("CustomerSavedSearch.Create returned %+v", savedSearch)
+	}
+
+	sent := CustomerSavedSearchResource{}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.CustomerSavedSearch.Query != "total_spent:>50" {
+		t.Errorf("CustomerSavedSearch.Create sent %s", body)
+	}
+}
+
+func TestCustomerSavedSearchUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"customer_saved_search": {"id":1,"name":"Big spenders"}}`))
+
+	savedSearch, err := client.CustomerSavedSearch.Update(context.Background(), CustomerSavedSearch{Id: 1, Name: "Big spenders"})
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.Update returned error: %v", err)
+	}
+	if savedSearch.Name != "Big spenders" {
+		t.Errorf("CustomerSavedSearch.Update returned %+v", savedSearch)
+	}
+}
+
+func TestCustomerSavedSearchDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, "{}"))
+
+	err := client.CustomerSavedSearch.Delete(context.Background(), 1)
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.Delete returned error: %v", err)
+	}
+}
+
+func TestCustomerSavedSearchListCustomers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	params := map[string]string{"order": "last_order_date DESC"}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/1/customers.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"customers": [{"id":1},{"id":2}]}`))
+
+	customers, err := client.CustomerSavedSearch.ListCustomers(context.Background(), 1, struct {
+		Order string `url:"order"`
+	}{"last_order_date DESC"})
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.ListCustomers returned error: %v", err)
+	}
+
+	expected := []Customer{{Id: 1}, {Id: 2}}
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("CustomerSavedSearch.ListCustomers returned %+v, expected %+v", customers, expected)
+	}
+}
+
+func TestCustomerSavedSearchListAllCustomers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/1/customers.json", client.pathPrefix)
+	pages := []struct {
+		url, link, body string
+	}{
+		{listURL, `<http://valid.url?page_info=pg2>; rel="next"`, `{"customers": [{"id":1},{"id":2}]}`},
+		{listURL + "?page_info=pg2", `<http://valid.url?page_info=pg1>; rel="previous"`, `{"customers": [{"id":3}]}`},
+	}
+	for _, page := range pages {
+		response := &http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(page.body),
+			Header:     http.Header{"Link": {page.link}},
+		}
+		httpmock.RegisterResponder("GET", page.url, httpmock.ResponderFromResponse(response))
+	}
+
+	customers, err := client.CustomerSavedSearch.ListAllCustomers(context.Background(), 1, nil)
+	if err != nil {
+		t.Errorf("CustomerSavedSearch.ListAllCustomers returned error: %v", err)
+	}
+
+	expected := []Customer{{Id: 1}, {Id: 2}, {Id: 3}}
+	if !reflect.DeepEqual(customers, expected) {
+		t.Errorf("CustomerSavedSearch.ListAllCustomers returned %+v, expected %+v", customers, expected)
+	}
+}
+
+func TestCustomerSavedSearchListCustomersWithPaginationError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customer_saved_searches/1/customers.json", client.pathPrefix),
+		httpmock.NewStringResponder(500, ""))
+
+	customers, pagination, err := client.CustomerSavedSearch.ListCustomersWithPagination(context.Background(), 1, nil)
+	if customers != nil || pagination != nil || err == nil {
+		t.Errorf("CustomerSavedSearch.ListCustomersWithPagination returned %v, %v, %v, expected an error", customers, pagination, err)
+	}
+}
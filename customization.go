@@ -0,0 +1,285 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const customizationFields = `
+      id
+      title
+      enabled
+      functionId
+      metafields(first: 50) {
+        nodes {
+          id
+          namespace
+          key
+          type
+          value
+        }
+      }`
+
+// %[1]s is the mutation prefix, e.g. deliveryCustomization, %[2]s the input
+// type, e.g. DeliveryCustomizationInput
+const customizationCreateMutation = `mutation %[1]sCreate($input: %[2]s!) {
+  %[1]sCreate(%[1]s: $input) {
+    %[1]s {` + customizationFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const customizationUpdateMutation = `mutation %[1]sUpdate($id: ID!, $input: %[2]s!) {
+  %[1]sUpdate(id: $id, %[1]s: $input) {
+    %[1]s {` + customizationFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const customizationActivationMutation = `mutation %[1]sActivation($ids: [ID!]!, $enabled: Boolean!) {
+  %[1]sActivation(ids: $ids, enabled: $enabled) {
+    ids
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// DeliveryCustomizationService is an interface for managing the delivery
+// customizations of a delivery customization Function, through the GraphQL
+// API.
+// See: https://shopify.dev/docs/api/functions/reference/delivery-customization
+type DeliveryCustomizationService interface {
+	Create(context.Context, Customization) (*Customization, error)
+	Update(context.Context, Customization) (*Customization, error)
+	Activate(context.Context, []uint64, bool) ([]uint64, error)
+}
+
+// DeliveryCustomizationServiceOp handles communication with the delivery
+// customization related methods of the Shopify API.
+type DeliveryCustomizationServiceOp struct {
+	client *Client
+}
+
+// PaymentCustomizationService is an interface for managing the payment
+// customizations of a payment customization Function, through the GraphQL
+// API.
+// See: https://shopify.dev/docs/api/functions/reference/payment-customization
+type PaymentCustomizationService interface {
+	Create(context.Context, Customization) (*Customization, error)
+	Update(context.Context, Customization) (*Customization, error)
+	Activate(context.Context, []uint64, bool) ([]uint64, error)
+}
+
+// PaymentCustomizationServiceOp handles communication with the payment
+// customization related methods of the Shopify API.
+type PaymentCustomizationServiceOp struct {
+	client *Client
+}
+
+// Customization is a delivery or payment customization, configuring a
+// checkout Function. The function reads its configuration from the
+// Metafields.
+type Customization struct {
+	Id         uint64
+	Title      string
+	FunctionId string
+	// nil leaves the customization as is on Update
+	Enabled    *bool
+	Metafields []Metafield
+}
+
+type graphQLCustomization struct {
+	Id         string `json:"id"`
+	Title      string `json:"title"`
+	Enabled    bool   `json:"enabled"`
+	FunctionId string `json:"functionId"`
+	Metafields struct {
+		Nodes []graphQLMetafield `json:"nodes"`
+	} `json:"metafields"`
+}
+
+func (c *graphQLCustomization) customization() *Customization {
+	if c == nil {
+		return nil
+	}
+
+	id, _ := ParseGraphQLId(c.Id)
+	enabled := c.Enabled
+	customization := &Customization{
+		Id:         id,
+		Title:      c.Title,
+		FunctionId: c.FunctionId,
+		Enabled:    &enabled,
+	}
+	for _, metafield := range c.Metafields.Nodes {
+		customization.Metafields = append(customization.Metafields, metafield.metafield())
+	}
+	return customization
+}
+
+type customizationPayload struct {
+	DeliveryCustomization *graphQLCustomization `json:"deliveryCustomization"`
+	PaymentCustomization  *graphQLCustomization `json:"paymentCustomization"`
+	Ids                   []string              `json:"ids"`
+	UserErrors            GraphQLUserErrors     `json:"userErrors"`
+}
+
+func (p customizationPayload) customization() *Customization {
+	if p.DeliveryCustomization != nil {
+		return p.DeliveryCustomization.customization()
+	}
+	return p.PaymentCustomization.customization()
+}
+
+func customizationInput(customization Customization) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	if customization.Title != "" {
+		input["title"] = customization.Title
+	}
+	if customization.FunctionId != "" {
+		input["functionId"] = customization.FunctionId
+	}
+	if customization.Enabled != nil {
+		input["enabled"] = *customization.Enabled
+	}
+	if len(customization.Metafields) > 0 {
+		metafields := make([]map[string]interface{}, 0, len(customization.Metafields))
+		for _, metafield := range customization.Metafields {
+			m, err := metafieldInput(metafield)
+			if err != nil {
+				return nil, err
+			}
+			metafields = append(metafields, m)
+		}
+		input["metafields"] = metafields
+	}
+	return input, nil
+}
+
+// customizationMutations are the customization mutation templates by action
+var customizationMutations = map[string]string{
+	"Create":     customizationCreateMutation,
+	"Update":     customizationUpdateMutation,
+	"Activation": customizationActivationMutation,
+}
+
+// mutateCustomization runs the action mutation of a customization resource,
+// e.g. deliveryCustomizationCreate for DeliveryCustomization and Create.
+func mutateCustomization(ctx context.Context, client *Client, resource, action string, vars map[string]interface{}) (*customizationPayload, error) {
+	field := strings.ToLower(resource[:1]) + resource[1:]
+	query := fmt.Sprintf(customizationMutations[action], field, resource+"Input")
+
+	resp := map[string]*customizationPayload{}
+	err := client.GraphQL.Query(ctx, query, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := resp[field+action]
+	if payload == nil {
+		return &customizationPayload{}, nil
+	}
+	if len(payload.UserErrors) > 0 {
+		return nil, payload.UserErrors
+	}
+	return payload, nil
+}
+
+func createCustomization(ctx context.Context, client *Client, resource string, customization Customization) (*Customization, error) {
+	input, err := customizationInput(customization)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{"input": input}
+	payload, err := mutateCustomization(ctx, client, resource, "Create", vars)
+	if err != nil {
+		return nil, err
+	}
+	return payload.customization(), nil
+}
+
+func updateCustomization(ctx context.Context, client *Client, resource string, customization Customization) (*Customization, error) {
+	input, err := customizationInput(customization)
+	if err != nil {
+		return nil, err
+	}
+	// the function of a customization can't be changed
+	delete(input, "functionId")
+
+	vars := map[string]interface{}{"id": GraphQLId(resource, customization.Id), "input": input}
+	payload, err := mutateCustomization(ctx, client, resource, "Update", vars)
+	if err != nil {
+		return nil, err
+	}
+	return payload.customization(), nil
+}
+
+func activateCustomizations(ctx context.Context, client *Client, resource string, ids []uint64, enabled bool) ([]uint64, error) {
+	gids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		gids = append(gids, GraphQLId(resource, id))
+	}
+
+	vars := map[string]interface{}{"ids": gids, "enabled": enabled}
+	payload, err := mutateCustomization(ctx, client, resource, "Activation", vars)
+	if err != nil {
+		return nil, err
+	}
+
+	activated := make([]uint64, 0, len(payload.Ids))
+	for _, gid := range payload.Ids {
+		id, err := ParseGraphQLId(gid)
+		if err != nil {
+			return nil, err
+		}
+		activated = append(activated, id)
+	}
+	return activated, nil
+}
+
+// Create creates a delivery customization for the function FunctionId
+func (s *DeliveryCustomizationServiceOp) Create(ctx context.Context, customization Customization) (*Customization, error) {
+	return createCustomization(ctx, s.client, "DeliveryCustomization", customization)
+}
+
+// Update updates a delivery customization
+func (s *DeliveryCustomizationServiceOp) Update(ctx context.Context, customization Customization) (*Customization, error) {
+	return updateCustomization(ctx, s.client, "DeliveryCustomization", customization)
+}
+
+// Activate enables or disables delivery customizations, it returns the ids
+// of the updated customizations.
+func (s *DeliveryCustomizationServiceOp) Activate(ctx context.Context, ids []uint64, enabled bool) ([]uint64, error) {
+	return activateCustomizations(ctx, s.client, "DeliveryCustomization", ids, enabled)
+}
+
+// Create creates a payment customization for the function FunctionId
+func (s *PaymentCustomizationServiceOp) Create(ctx context.Context, customization Customization) (*Customization, error) {
+	return createCustomization(ctx, s.client, "PaymentCustomization", customization)
+}
+
+// Update updates a payment customization
+func (s *PaymentCustomizationServiceOp) Update(ctx context.Context, customization Customization) (*Customization, error) {
+	return updateCustomization(ctx, s.client, "PaymentCustomization", customization)
+}
+
+// Activate enables or disables payment customizations, it returns the ids of
+// the updated customizations.
+func (s *PaymentCustomizationServiceOp) Activate(ctx context.Context, ids []uint64, enabled bool) ([]uint64, error) {
+	return activateCustomizations(ctx, s.client, "PaymentCustomization", ids, enabled)
+}
@@ -0,0 +1,165 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeliveryCustomizationCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query, vars = req.Query, req.Variables
+		return `{"data":{"deliveryCustomizationCreate":{"deliveryCustomization":{
+			"id":"gid://shopify/DeliveryCustomization/1",
+			"title":"Hide express",
+			"enabled":true,
+			"functionId":"fn-1",
+			"metafields":{"nodes":[{"id":"gid://shopify/Metafield/5","namespace":"$app:delivery","key":"config","type":"json","value":"{}"}]}
+		},"userErrors":[]}}}`
+	})
+
+	enabled := true
+	customization, err := client.DeliveryCustomization.Create(context.Background(), Customization{
+		Title:      "Hide express",
+		FunctionId: "fn-1",
+		Enabled:    &enabled,
+		Metafields: []Metafield{{Namespace: "$app:delivery", Key: "config", Type: MetafieldTypeJSON, Value: "{}"}},
+	})
+	if err != nil {
+		t.Fatalf("DeliveryCustomization.Create returned error: %v", err)
+	}
+
+	if !strings.Contains(query, "deliveryCustomizationCreate(deliveryCustomization: $input)") ||
+		!strings.Contains(query, "$input: DeliveryCustomizationInput!") {
+		t.Errorf("DeliveryCustomization.Create sent query %s", query)
+	}
+
+	expectedVars := map[string]interface{}{"input": map[string]interface{}{
+		"title":      "Hide express",
+		"functionId": "fn-1",
+		"enabled":    true,
+		"metafields": []interface{}{map[string]interface{}{
+			"namespace": "$app:delivery",
+			"key":       "config",
+			"type":      "json",
+			"value":     "{}",
+		}},
+	}}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("DeliveryCustomization.Create sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	expected := &Customization{
+		Id:         1,
+		Title:      "Hide express",
+		FunctionId: "fn-1",
+		Enabled:    &enabled,
+		Metafields: []Metafield{{
+			Id:                5,
+			Namespace:         "$app:delivery",
+			Key:               "config",
+			Type:              MetafieldTypeJSON,
+			Value:             "{}",
+			AdminGraphqlApiId: "gid://shopify/Metafield/5",
+		}},
+	}
+	if !reflect.DeepEqual(customization, expected) {
+		t.Errorf("DeliveryCustomization.Create returned %+v, expected %+v", customization, expected)
+	}
+}
+
+func TestPaymentCustomizationUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query, vars = req.Query, req.Variables
+		return `{"data":{"paymentCustomizationUpdate":{"paymentCustomization":{
+			"id":"gid://shopify/PaymentCustomization/2","title":"Hide COD","enabled":false,"functionId":"fn-2","metafields":{"nodes":[]}
+		},"userErrors":[]}}}`
+	})
+
+	customization, err := client.PaymentCustomization.Update(context.Background(), Customization{
+		Id:         2,
+		Title:      "Hide COD",
+		FunctionId: "fn-2",
+	})
+	if err != nil {
+		t.Fatalf("PaymentCustomization.Update returned error: %v", err)
+	}
+
+	if !strings.Contains(query, "paymentCustomizationUpdate(id: $id, paymentCustomization: $input)") {
+		t.Errorf("PaymentCustomization.Update sent query %s", query)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id":    "gid://shopify/PaymentCustomization/2",
+		"input": map[string]interface{}{"title": "Hide COD"},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("PaymentCustomization.Update sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if customization.Id != 2 || customization.Enabled == nil || *customization.Enabled {
+		t.Errorf("PaymentCustomization.Update returned %+v", customization)
+	}
+}
+
+func TestPaymentCustomizationActivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query, vars = req.Query, req.Variables
+		return `{"data":{"paymentCustomizationActivation":{
+			"ids":["gid://shopify/PaymentCustomization/1","gid://shopify/PaymentCustomization/2"],"userErrors":[]
+		}}}`
+	})
+
+	ids, err := client.PaymentCustomization.Activate(context.Background(), []uint64{1, 2}, true)
+	if err != nil {
+		t.Fatalf("PaymentCustomization.Activate returned error: %v", err)
+	}
+
+	if !strings.Contains(query, "paymentCustomizationActivation(ids: $ids, enabled: $enabled)") {
+		t.Errorf("PaymentCustomization.Activate sent query %s", query)
+	}
+
+	expectedVars := map[string]interface{}{
+		"ids":     []interface{}{"gid://shopify/PaymentCustomization/1", "gid://shopify/PaymentCustomization/2"},
+		"enabled": true,
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("PaymentCustomization.Activate sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if !reflect.DeepEqual(ids, []uint64{1, 2}) {
+		t.Errorf("PaymentCustomization.Activate returned %v", ids)
+	}
+}
+
+func TestDeliveryCustomizationActivateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"deliveryCustomizationActivation":{"ids":null,
+			"userErrors":[{"field":["ids"],"message":"Maximum delivery customizations reached","code":"MAXIMUM_ACTIVE_DELIVERY_CUSTOMIZATIONS"}]
+		}}}`
+	})
+
+	_, err := client.DeliveryCustomization.Activate(context.Background(), []uint64{1}, true)
+	if err == nil || err.Error() != "ids: Maximum delivery customizations reached" {
+		t.Errorf("DeliveryCustomization.Activate err returned %v", err)
+	}
+}
@@ -0,0 +1,597 @@
+package goshopify
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+const deliveryProfileFields = `
+  id
+  name
+  default
+  profileLocationGroups {
+    locationGroup {
+      id
+      locations(first: 250) {
+        nodes {
+          id
+        }
+      }
+    }
+    locationGroupZones(first: 50) {
+      nodes {
+        zone {
+          id
+          name
+          countries {
+            code {
+              countryCode
+              restOfWorld
+            }
+            provinces {
+              code
+            }
+          }
+        }
+        methodDefinitions(first: 50) {
+          nodes {
+            id
+            name
+            description
+            active
+            rateProvider {
+              ... on DeliveryRateDefinition {
+                price {
+                  amount
+                  currencyCode
+                }
+              }
+            }
+            methodConditions {
+              field
+              operator
+              conditionCriteria {
+                __typename
+                ... on MoneyV2 {
+                  amount
+                  currencyCode
+                }
+                ... on Weight {
+                  unit
+                  value
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }`
+
+const deliveryProfilesQuery = `query deliveryProfiles($first: Int!, $after: String) {
+  deliveryProfiles(first: $first, after: $after) {
+    nodes {` + deliveryProfileFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const deliveryProfileQuery = `query deliveryProfile($id: ID!) {
+  deliveryProfile(id: $id) {` + deliveryProfileFields + `
+  }
+}`
+
+const deliveryProfileCreateMutation = `mutation deliveryProfileCreate($profile: DeliveryProfileInput!) {
+  deliveryProfileCreate(profile: $profile) {
+    profile {` + deliveryProfileFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const deliveryProfileUpdateMutation = `mutation deliveryProfileUpdate($id: ID!, $profile: DeliveryProfileInput!) {
+  deliveryProfileUpdate(id: $id, profile: $profile) {
+    profile {` + deliveryProfileFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const deliveryProfileRemoveMutation = `mutation deliveryProfileRemove($id: ID!) {
+  deliveryProfileRemove(id: $id) {
+    job {
+      id
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// Fields and operators of DeliveryCondition
+const (
+	DeliveryConditionFieldTotalPrice  = "TOTAL_PRICE"
+	DeliveryConditionFieldTotalWeight = "TOTAL_WEIGHT"
+
+	DeliveryConditionGreaterThanOrEqualTo = "GREATER_THAN_OR_EQUAL_TO"
+	DeliveryConditionLessThanOrEqualTo    = "LESS_THAN_OR_EQUAL_TO"
+)
+
+// DeliveryProfileService is an interface for interfacing with the delivery
+// profiles of the shop through the GraphQL API.
+// See: https://shopify.dev/docs/apps/build/purchase-options/deliveries/delivery-profiles
+type DeliveryProfileService interface {
+	List(context.Context) ([]DeliveryProfile, error)
+	Get(context.Context, uint64) (*DeliveryProfile, error)
+	Create(context.Context, DeliveryProfile) (*DeliveryProfile, error)
+	Update(context.Context, DeliveryProfile) (*DeliveryProfile, error)
+	Delete(context.Context, uint64) error
+}
+
+// DeliveryProfileServiceOp handles communication with the delivery profile
+// related methods of the Shopify API.
+type DeliveryProfileServiceOp struct {
+	client *Client
+}
+
+// DeliveryProfile groups the locations products ship from with the zones and
+// rates they ship to.
+type DeliveryProfile struct {
+	Id             uint64
+	Name           string
+	Default        bool
+	LocationGroups []DeliveryLocationGroup
+}
+
+// DeliveryLocationGroup is a group of locations sharing the same zones
+type DeliveryLocationGroup struct {
+	Id          uint64
+	LocationIds []uint64
+	Zones       []DeliveryZone
+}
+
+// DeliveryZone is a set of countries and their shipping rates
+type DeliveryZone struct {
+	Id                uint64
+	Name              string
+	Countries         []DeliveryCountry
+	MethodDefinitions []DeliveryMethodDefinition
+}
+
+// DeliveryCountry is a country of a zone. A country without Provinces
+// includes all of its provinces.
+type DeliveryCountry struct {
+	Code        string
+	RestOfWorld bool
+	Provinces   []string
+}
+
+// DeliveryMethodDefinition is a shipping rate of a zone. Price is nil for
+// rates calculated by a carrier service, which aren't supported by Create.
+type DeliveryMethodDefinition struct {
+	Id          uint64
+	Name        string
+	Description string
+	Active      bool
+	Price       *decimal.Decimal
+	Currency    string
+	Conditions  []DeliveryCondition
+}
+
+// DeliveryCondition restricts a rate to orders of a total price or weight.
+// Currency is set for price conditions, WeightUnit for weight conditions.
+type DeliveryCondition struct {
+	Field      string
+	Operator   string
+	Value      *decimal.Decimal
+	Currency   string
+	WeightUnit string
+}
+
+// Copy returns a deep copy of the profile without any ids, ready to be
+// created in another shop. Locations are replaced according to locationIds,
+// which maps location ids of this shop to those of the other shop, unmapped
+// locations are left out along with groups left without locations.
+func (p DeliveryProfile) Copy(locationIds map[uint64]uint64) DeliveryProfile {
+	profile := DeliveryProfile{Name: p.Name}
+	for _, group := range p.LocationGroups {
+		g := DeliveryLocationGroup{}
+		for _, id := range group.LocationIds {
+			if mapped, ok := locationIds[id]; ok {
+				g.LocationIds = append(g.LocationIds, mapped)
+			}
+		}
+		if len(g.LocationIds) == 0 {
+			continue
+		}
+
+		for _, zone := range group.Zones {
+			z := DeliveryZone{Name: zone.Name}
+			for _, country := range zone.Countries {
+				country.Provinces = append([]string(nil), country.Provinces...)
+				z.Countries = append(z.Countries, country)
+			}
+			for _, method := range zone.MethodDefinitions {
+				method.Id = 0
+				method.Price = copyDecimal(method.Price)
+				conditions := method.Conditions
+				method.Conditions = nil
+				for _, condition := range conditions {
+					condition.Value = copyDecimal(condition.Value)
+					method.Conditions = append(method.Conditions, condition)
+				}
+				z.MethodDefinitions = append(z.MethodDefinitions, method)
+			}
+			g.Zones = append(g.Zones, z)
+		}
+		profile.LocationGroups = append(profile.LocationGroups, g)
+	}
+	return profile
+}
+
+func copyDecimal(d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	return &c
+}
+
+type graphQLDeliveryProfile struct {
+	Id                    string `json:"id"`
+	Name                  string `json:"name"`
+	Default               bool   `json:"default"`
+	ProfileLocationGroups []struct {
+		LocationGroup struct {
+			Id        string `json:"id"`
+			Locations struct {
+				Nodes []struct {
+					Id string `json:"id"`
+				} `json:"nodes"`
+			} `json:"locations"`
+		} `json:"locationGroup"`
+		LocationGroupZones struct {
+			Nodes []struct {
+				Zone struct {
+					Id        string `json:"id"`
+					Name      string `json:"name"`
+					Countries []struct {
+						Code struct {
+							CountryCode string `json:"countryCode"`
+							RestOfWorld bool   `json:"restOfWorld"`
+						} `json:"code"`
+						Provinces []struct {
+							Code string `json:"code"`
+						} `json:"provinces"`
+					} `json:"countries"`
+				} `json:"zone"`
+				MethodDefinitions struct {
+					Nodes []graphQLDeliveryMethodDefinition `json:"nodes"`
+				} `json:"methodDefinitions"`
+			} `json:"nodes"`
+		} `json:"locationGroupZones"`
+	} `json:"profileLocationGroups"`
+}
+
+type graphQLDeliveryMethodDefinition struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Active       bool   `json:"active"`
+	RateProvider struct {
+		Price *graphQLMoney `json:"price"`
+	} `json:"rateProvider"`
+	MethodConditions []struct {
+		Field             string `json:"field"`
+		Operator          string `json:"operator"`
+		ConditionCriteria struct {
+			Typename     string           `json:"__typename"`
+			Amount       *decimal.Decimal `json:"amount"`
+			CurrencyCode string           `json:"currencyCode"`
+			Unit         string           `json:"unit"`
+			Value        *decimal.Decimal `json:"value"`
+		} `json:"conditionCriteria"`
+	} `json:"methodConditions"`
+}
+
+func (m graphQLDeliveryMethodDefinition) methodDefinition() DeliveryMethodDefinition {
+	id, _ := ParseGraphQLId(m.Id)
+	method := DeliveryMethodDefinition{
+		Id:          id,
+		Name:        m.Name,
+		Description: m.Description,
+		Active:      m.Active,
+	}
+	if m.RateProvider.Price != nil {
+		method.Price = m.RateProvider.Price.Amount
+		method.Currency = m.RateProvider.Price.CurrencyCode
+	}
+
+	for _, c := range m.MethodConditions {
+		condition := DeliveryCondition{Field: c.Field, Operator: c.Operator}
+		if c.ConditionCriteria.Typename == "Weight" {
+			condition.Value = c.ConditionCriteria.Value
+			condition.WeightUnit = c.ConditionCriteria.Unit
+		} else {
+			condition.Value = c.ConditionCriteria.Amount
+			condition.Currency = c.ConditionCriteria.CurrencyCode
+		}
+		method.Conditions = append(method.Conditions, condition)
+	}
+	return method
+}
+
+func (p *graphQLDeliveryProfile) deliveryProfile() *DeliveryProfile {
+	if p == nil {
+		return nil
+	}
+
+	id, _ := ParseGraphQLId(p.Id)
+	profile := &DeliveryProfile{Id: id, Name: p.Name, Default: p.Default}
+	for _, plg := range p.ProfileLocationGroups {
+		groupId, _ := ParseGraphQLId(plg.LocationGroup.Id)
+		group := DeliveryLocationGroup{Id: groupId}
+		for _, location := range plg.LocationGroup.Locations.Nodes {
+			locationId, _ := ParseGraphQLId(location.Id)
+			group.LocationIds = append(group.LocationIds, locationId)
+		}
+
+		for _, lgz := range plg.LocationGroupZones.Nodes {
+			zoneId, _ := ParseGraphQLId(lgz.Zone.Id)
+			zone := DeliveryZone{Id: zoneId, Name: lgz.Zone.Name}
+			for _, c := range lgz.Zone.Countries {
+				country := DeliveryCountry{Code: c.Code.CountryCode, RestOfWorld: c.Code.RestOfWorld}
+				for _, province := range c.Provinces {
+					country.Provinces = append(country.Provinces, province.Code)
+				}
+				zone.Countries = append(zone.Countries, country)
+			}
+			for _, method := range lgz.MethodDefinitions.Nodes {
+				zone.MethodDefinitions = append(zone.MethodDefinitions, method.methodDefinition())
+			}
+			group.Zones = append(group.Zones, zone)
+		}
+		profile.LocationGroups = append(profile.LocationGroups, group)
+	}
+	return profile
+}
+
+// deliveryProfileInput converts a profile to a GraphQL DeliveryProfileInput.
+// Location groups without an Id are created, the others are updated with
+// their zones and method definitions without an Id.
+func deliveryProfileInput(profile DeliveryProfile) map[string]interface{} {
+	input := map[string]interface{}{}
+	if profile.Name != "" {
+		input["name"] = profile.Name
+	}
+
+	var groupsToCreate, groupsToUpdate []map[string]interface{}
+	for _, group := range profile.LocationGroups {
+		g := map[string]interface{}{}
+		var zones []map[string]interface{}
+		for _, zone := range group.Zones {
+			if zone.Id == 0 {
+				zones = append(zones, deliveryZoneInput(zone))
+			}
+		}
+		if len(zones) > 0 {
+			g["zonesToCreate"] = zones
+		}
+
+		locations := make([]string, 0, len(group.LocationIds))
+		for _, id := range group.LocationIds {
+			locations = append(locations, GraphQLId("Location", id))
+		}
+
+		if group.Id == 0 {
+			g["locations"] = locations
+			groupsToCreate = append(groupsToCreate, g)
+		} else {
+			g["id"] = GraphQLId("DeliveryLocationGroup", group.Id)
+			g["locationsToAdd"] = locations
+			groupsToUpdate = append(groupsToUpdate, g)
+		}
+	}
+	if len(groupsToCreate) > 0 {
+		input["locationGroupsToCreate"] = groupsToCreate
+	}
+	if len(groupsToUpdate) > 0 {
+		input["locationGroupsToUpdate"] = groupsToUpdate
+	}
+	return input
+}
+
+func deliveryZoneInput(zone DeliveryZone) map[string]interface{} {
+	countries := make([]map[string]interface{}, 0, len(zone.Countries))
+	for _, country := range zone.Countries {
+		c := map[string]interface{}{}
+		if country.RestOfWorld {
+			c["restOfWorld"] = true
+		} else {
+			c["code"] = country.Code
+		}
+		if len(country.Provinces) > 0 {
+			provinces := make([]map[string]interface{}, 0, len(country.Provinces))
+			for _, code := range country.Provinces {
+				provinces = append(provinces, map[string]interface{}{"code": code})
+			}
+			c["provinces"] = provinces
+		} else {
+			c["includeAllProvinces"] = true
+		}
+		countries = append(countries, c)
+	}
+
+	methods := make([]map[string]interface{}, 0, len(zone.MethodDefinitions))
+	for _, method := range zone.MethodDefinitions {
+		methods = append(methods, deliveryMethodDefinitionInput(method))
+	}
+
+	return map[string]interface{}{
+		"name":                      zone.Name,
+		"countries":                 countries,
+		"methodDefinitionsToCreate": methods,
+	}
+}
+
+func deliveryMethodDefinitionInput(method DeliveryMethodDefinition) map[string]interface{} {
+	input := map[string]interface{}{
+		"name":   method.Name,
+		"active": method.Active,
+	}
+	if method.Description != "" {
+		input["description"] = method.Description
+	}
+	if method.Price != nil {
+		input["rateDefinition"] = map[string]interface{}{
+			"price": map[string]interface{}{"amount": method.Price.String(), "currencyCode": method.Currency},
+		}
+	}
+
+	var priceConditions, weightConditions []map[string]interface{}
+	for _, condition := range method.Conditions {
+		if condition.Value == nil {
+			continue
+		}
+		switch condition.Field {
+		case DeliveryConditionFieldTotalPrice:
+			priceConditions = append(priceConditions, map[string]interface{}{
+				"operator": condition.Operator,
+				"criteria": map[string]interface{}{"amount": condition.Value.String(), "currencyCode": condition.Currency},
+			})
+		case DeliveryConditionFieldTotalWeight:
+			value, _ := condition.Value.Float64()
+			weightConditions = append(weightConditions, map[string]interface{}{
+				"operator": condition.Operator,
+				"criteria": map[string]interface{}{"value": value, "unit": condition.WeightUnit},
+			})
+		}
+	}
+	if len(priceConditions) > 0 {
+		input["priceConditionsToCreate"] = priceConditions
+	}
+	if len(weightConditions) > 0 {
+		input["weightConditionsToCreate"] = weightConditions
+	}
+	return input
+}
+
+// List lists the delivery profiles of the shop with their location groups,
+// zones and rates.
+func (s *DeliveryProfileServiceOp) List(ctx context.Context) ([]DeliveryProfile, error) {
+	profiles := []DeliveryProfile{}
+	vars := map[string]interface{}{"first": 10}
+	for {
+		resp := struct {
+			DeliveryProfiles struct {
+				Nodes    []graphQLDeliveryProfile `json:"nodes"`
+				PageInfo GraphQLPageInfo          `json:"pageInfo"`
+			} `json:"deliveryProfiles"`
+		}{}
+
+		err := s.client.GraphQL.Query(ctx, deliveryProfilesQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range resp.DeliveryProfiles.Nodes {
+			profiles = append(profiles, *resp.DeliveryProfiles.Nodes[i].deliveryProfile())
+		}
+
+		if !resp.DeliveryProfiles.PageInfo.HasNextPage {
+			return profiles, nil
+		}
+		vars["after"] = resp.DeliveryProfiles.PageInfo.EndCursor
+	}
+}
+
+// Get gets a delivery profile with its location groups, zones and rates
+func (s *DeliveryProfileServiceOp) Get(ctx context.Context, profileId uint64) (*DeliveryProfile, error) {
+	resp := struct {
+		DeliveryProfile *graphQLDeliveryProfile `json:"deliveryProfile"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("DeliveryProfile", profileId)}
+	err := s.client.GraphQL.Query(ctx, deliveryProfileQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DeliveryProfile.deliveryProfile(), nil
+}
+
+// Create creates a delivery profile with its location groups, zones and
+// rates, see DeliveryProfile.Copy to copy a profile of another shop.
+func (s *DeliveryProfileServiceOp) Create(ctx context.Context, profile DeliveryProfile) (*DeliveryProfile, error) {
+	resp := struct {
+		DeliveryProfileCreate struct {
+			Profile    *graphQLDeliveryProfile `json:"profile"`
+			UserErrors GraphQLUserErrors       `json:"userErrors"`
+		} `json:"deliveryProfileCreate"`
+	}{}
+
+	vars := map[string]interface{}{"profile": deliveryProfileInput(profile)}
+	err := s.client.GraphQL.Query(ctx, deliveryProfileCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.DeliveryProfileCreate.UserErrors) > 0 {
+		return nil, resp.DeliveryProfileCreate.UserErrors
+	}
+	return resp.DeliveryProfileCreate.Profile.deliveryProfile(), nil
+}
+
+// Update renames a delivery profile and adds the location groups, zones and
+// locations of profile without an Id. Existing zones and rates are left
+// as is.
+func (s *DeliveryProfileServiceOp) Update(ctx context.Context, profile DeliveryProfile) (*DeliveryProfile, error) {
+	resp := struct {
+		DeliveryProfileUpdate struct {
+			Profile    *graphQLDeliveryProfile `json:"profile"`
+			UserErrors GraphQLUserErrors       `json:"userErrors"`
+		} `json:"deliveryProfileUpdate"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":      GraphQLId("DeliveryProfile", profile.Id),
+		"profile": deliveryProfileInput(profile),
+	}
+	err := s.client.GraphQL.Query(ctx, deliveryProfileUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.DeliveryProfileUpdate.UserErrors) > 0 {
+		return nil, resp.DeliveryProfileUpdate.UserErrors
+	}
+	return resp.DeliveryProfileUpdate.Profile.deliveryProfile(), nil
+}
+
+// Delete removes a delivery profile, its products move to the default
+// profile. The removal is completed asynchronously by Shopify.
+func (s *DeliveryProfileServiceOp) Delete(ctx context.Context, profileId uint64) error {
+	resp := struct {
+		DeliveryProfileRemove struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"deliveryProfileRemove"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("DeliveryProfile", profileId)}
+	err := s.client.GraphQL.Query(ctx, deliveryProfileRemoveMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.DeliveryProfileRemove.UserErrors) > 0 {
+		return resp.DeliveryProfileRemove.UserErrors
+	}
+	return nil
+}
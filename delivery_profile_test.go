@@ -0,0 +1,243 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func deliveryProfileTests(t *testing.T, profile *DeliveryProfile) {
+	t.Helper()
+
+	five := decimal.NewFromFloat(5)
+	fifty := decimal.NewFromFloat(50)
+	half := decimal.NewFromFloat(0.5)
+	expected := &DeliveryProfile{
+		Id:      1,
+		Name:    "General profile",
+		Default: true,
+		LocationGroups: []DeliveryLocationGroup{{
+			Id:          2,
+			LocationIds: []uint64{10, 11},
+			Zones: []DeliveryZone{{
+				Id:        3,
+				Name:      "Domestic",
+				Countries: []DeliveryCountry{{Code: "US", Provinces: []string{"CA", "NY"}}},
+				MethodDefinitions: []DeliveryMethodDefinition{
+					{
+						Id:          4,
+						Name:        "Standard",
+						Description: "3-5 business days",
+						Active:      true,
+						Price:       &five,
+						Currency:    "USD",
+						Conditions: []DeliveryCondition{
+							{Field: DeliveryConditionFieldTotalPrice, Operator: DeliveryConditionLessThanOrEqualTo, Value: &fifty, Currency: "USD"},
+							{Field: DeliveryConditionFieldTotalWeight, Operator: DeliveryConditionGreaterThanOrEqualTo, Value: &half, WeightUnit: "KILOGRAMS"},
+						},
+					},
+					{Id: 5, Name: "Carrier", Active: true},
+				},
+			}},
+		}},
+	}
+
+	// decimals can't be compared with DeepEqual, compare their strings
+	if fmt.Sprintf("%+v", profile) != fmt.Sprintf("%+v", expected) {
+		t.Errorf("DeliveryProfile returned %+v, expected %+v", profile, expected)
+	}
+}
+
+func TestDeliveryProfileGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return string(loadFixture("delivery_profile.json"))
+	})
+
+	profile, err := client.DeliveryProfile.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("DeliveryProfile.Get returned error: %v", err)
+	}
+
+	if vars["id"] != "gid://shopify/DeliveryProfile/1" {
+		t.Errorf("DeliveryProfile.Get sent %+v", vars)
+	}
+	deliveryProfileTests(t, profile)
+}
+
+func TestDeliveryProfileList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	responses := []string{
+		`{"data":{"deliveryProfiles":{"nodes":[{"id":"gid://shopify/DeliveryProfile/1","name":"General profile","default":true}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}`,
+		`{"data":{"deliveryProfiles":{"nodes":[{"id":"gid://shopify/DeliveryProfile/2","name":"Oversized"}],"pageInfo":{"hasNextPage":false}}}}`,
+	}
+	calls := 0
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		calls++
+		return responses[calls-1]
+	})
+
+	profiles, err := client.DeliveryProfile.List(context.Background())
+	if err != nil {
+		t.Fatalf("DeliveryProfile.List returned error: %v", err)
+	}
+
+	if vars["after"] != "c1" {
+		t.Errorf("DeliveryProfile.List sent %+v on the second page", vars)
+	}
+
+	expected := []DeliveryProfile{{Id: 1, Name: "General profile", Default: true}, {Id: 2, Name: "Oversized"}}
+	if !reflect.DeepEqual(profiles, expected) {
+		t.Errorf("DeliveryProfile.List returned %+v, expected %+v", profiles, expected)
+	}
+}
+
+func TestDeliveryProfileCopyCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return string(loadFixture("delivery_profile.json"))
+	})
+	source, err := client.DeliveryProfile.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("DeliveryProfile.Get returned error: %v", err)
+	}
+
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"deliveryProfileCreate":{"profile":{"id":"gid://shopify/DeliveryProfile/9","name":"General profile"},"userErrors":[]}}}`
+	})
+
+	// location 11 has no counterpart in the other shop
+	profile, err := client.DeliveryProfile.Create(context.Background(), source.Copy(map[uint64]uint64{10: 20}))
+	if err != nil {
+		t.Fatalf("DeliveryProfile.Create returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"profile": map[string]interface{}{
+		"name": "General profile",
+		"locationGroupsToCreate": []interface{}{map[string]interface{}{
+			"locations": []interface{}{"gid://shopify/Location/20"},
+			"zonesToCreate": []interface{}{map[string]interface{}{
+				"name": "Domestic",
+				"countries": []interface{}{map[string]interface{}{
+					"code":      "US",
+					"provinces": []interface{}{map[string]interface{}{"code": "CA"}, map[string]interface{}{"code": "NY"}},
+				}},
+				"methodDefinitionsToCreate": []interface{}{
+					map[string]interface{}{
+						"name":           "Standard",
+						"description":    "3-5 business days",
+						"active":         true,
+						"rateDefinition": map[string]interface{}{"price": map[string]interface{}{"amount": "5", "currencyCode": "USD"}},
+						"priceConditionsToCreate": []interface{}{map[string]interface{}{
+							"operator": "LESS_THAN_OR_EQUAL_TO",
+							"criteria": map[string]interface{}{"amount": "50", "currencyCode": "USD"},
+						}},
+						"weightConditionsToCreate": []interface{}{map[string]interface{}{
+							"operator": "GREATER_THAN_OR_EQUAL_TO",
+							"criteria": map[string]interface{}{"value": 0.5, "unit": "KILOGRAMS"},
+						}},
+					},
+					map[string]interface{}{"name": "Carrier", "active": true},
+				},
+			}},
+		}},
+	}}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("DeliveryProfile.Create sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if profile.Id != 9 {
+		t.Errorf("DeliveryProfile.Create returned %+v", profile)
+	}
+}
+
+func TestDeliveryProfileDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"deliveryProfileRemove":{"job":{"id":"gid://shopify/Job/1"},"userErrors":[]}}}`
+	})
+
+	err := client.DeliveryProfile.Delete(context.Background(), 2)
+	if err != nil {
+		t.Errorf("DeliveryProfile.Delete returned error: %v", err)
+	}
+	if vars["id"] != "gid://shopify/DeliveryProfile/2" {
+		t.Errorf("DeliveryProfile.Delete sent %+v", vars)
+	}
+
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"deliveryProfileRemove":{"job":null,"userErrors":[{"field":["id"],"message":"Cannot delete the default profile"}]}}}`
+	})
+
+	err = client.DeliveryProfile.Delete(context.Background(), 1)
+	if err == nil || err.Error() != "id: Cannot delete the default profile" {
+		t.Errorf("DeliveryProfile.Delete err returned %v", err)
+	}
+}
+
+func TestDeliveryProfileCopy(t *testing.T) {
+	price := decimal.NewFromFloat(5)
+	original := DeliveryProfile{
+		Id:   1,
+		Name: "General profile",
+		LocationGroups: []DeliveryLocationGroup{
+			{
+				Id:          2,
+				LocationIds: []uint64{10},
+				Zones: []DeliveryZone{{
+					Id:        3,
+					Name:      "Domestic",
+					Countries: []DeliveryCountry{{Code: "US", Provinces: []string{"CA"}}},
+					MethodDefinitions: []DeliveryMethodDefinition{{
+						Id:         4,
+						Name:       "Standard",
+						Price:      &price,
+						Conditions: []DeliveryCondition{{Field: "TOTAL_PRICE", Operator: "LESS_THAN_OR_EQUAL_TO"}},
+					}},
+				}},
+			},
+			// no location of this group exists in the other shop
+			{Id: 5, LocationIds: []uint64{11}, Zones: []DeliveryZone{{Id: 6, Name: "International"}}},
+		},
+	}
+
+	copied := original.Copy(map[uint64]uint64{10: 20})
+	if len(copied.LocationGroups) != 1 || !reflect.DeepEqual(copied.LocationGroups[0].LocationIds, []uint64{20}) {
+		t.Fatalf("DeliveryProfile.Copy returned location groups %+v", copied.LocationGroups)
+	}
+
+	zone := copied.LocationGroups[0].Zones[0]
+	zone.Countries[0].Code = "CA"
+	zone.Countries[0].Provinces[0] = "ON"
+	zone.MethodDefinitions[0].Conditions[0].Operator = "GREATER_THAN_OR_EQUAL_TO"
+	*zone.MethodDefinitions[0].Price = decimal.NewFromFloat(10)
+
+	originalZone := original.LocationGroups[0].Zones[0]
+	if !reflect.DeepEqual(originalZone.Countries, []DeliveryCountry{{Code: "US", Provinces: []string{"CA"}}}) {
+		t.Errorf("changing the copy changed the original countries to %+v", originalZone.Countries)
+	}
+	if originalZone.MethodDefinitions[0].Conditions[0].Operator != "LESS_THAN_OR_EQUAL_TO" || !originalZone.MethodDefinitions[0].Price.Equal(decimal.NewFromFloat(5)) {
+		t.Errorf("changing the copy changed the original rate to %+v", originalZone.MethodDefinitions[0])
+	}
+}
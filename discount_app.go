@@ -0,0 +1,461 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const appDiscountFields = `
+      title
+      status
+      startsAt
+      endsAt
+      discountClass
+      appDiscountType {
+        functionId
+      }
+      combinesWith {
+        orderDiscounts
+        productDiscounts
+        shippingDiscounts
+      }`
+
+const appDiscountNodeFields = `
+  id
+  metafields(first: 50) {
+    nodes {
+      id
+      namespace
+      key
+      type
+      value
+    }
+  }
+  discount {
+    __typename
+    ... on DiscountAutomaticApp {` + appDiscountFields + `
+    }
+    ... on DiscountCodeApp {` + appDiscountFields + `
+      appliesOncePerCustomer
+      usageLimit
+      codes(first: 1) {
+        nodes {
+          code
+        }
+      }
+    }
+  }`
+
+const discountAutomaticAppCreateMutation = `mutation discountAutomaticAppCreate($automaticAppDiscount: DiscountAutomaticAppInput!) {
+  discountAutomaticAppCreate(automaticAppDiscount: $automaticAppDiscount) {
+    automaticAppDiscount {
+      discountId` + appDiscountFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const discountAutomaticAppUpdateMutation = `mutation discountAutomaticAppUpdate($id: ID!, $automaticAppDiscount: DiscountAutomaticAppInput!) {
+  discountAutomaticAppUpdate(id: $id, automaticAppDiscount: $automaticAppDiscount) {
+    automaticAppDiscount {
+      discountId` + appDiscountFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const discountCodeAppCreateMutation = `mutation discountCodeAppCreate($codeAppDiscount: DiscountCodeAppInput!) {
+  discountCodeAppCreate(codeAppDiscount: $codeAppDiscount) {
+    codeAppDiscount {
+      discountId` + appDiscountFields + `
+      appliesOncePerCustomer
+      usageLimit
+      codes(first: 1) {
+        nodes {
+          code
+        }
+      }
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const discountNodeQuery = `query discountNode($id: ID!) {
+  discountNode(id: $id) {` + appDiscountNodeFields + `
+  }
+}`
+
+const discountNodesQuery = `query discountNodes($first: Int!, $after: String, $query: String) {
+  discountNodes(first: $first, after: $after, query: $query) {
+    nodes {` + appDiscountNodeFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+// AppDiscountService is an interface for managing discounts implemented by
+// a Shopify Function of the app, through the GraphQL API.
+// See: https://shopify.dev/docs/apps/build/discounts
+type AppDiscountService interface {
+	CreateAutomatic(context.Context, AppDiscount) (*AppDiscount, error)
+	UpdateAutomatic(context.Context, AppDiscount) (*AppDiscount, error)
+	CreateCode(context.Context, AppDiscount) (*AppDiscount, error)
+	Get(context.Context, uint64) (*AppDiscount, error)
+	List(context.Context, *AppDiscountListOptions) ([]AppDiscount, *GraphQLPageInfo, error)
+}
+
+// AppDiscountServiceOp handles communication with the app discount related
+// methods of the Shopify API.
+type AppDiscountServiceOp struct {
+	client *Client
+}
+
+// AppDiscount is an automatic or code discount implemented by a Shopify
+// Function. The function reads its configuration from the Metafields.
+type AppDiscount struct {
+	// id of the discount node
+	Id            uint64
+	Title         string
+	FunctionId    string
+	Status        string
+	DiscountClass string
+	StartsAt      *time.Time
+	EndsAt        *time.Time
+	CombinesWith  *DiscountCombinesWith
+	Metafields    []Metafield
+
+	// set for code discounts only
+	Code                   string
+	AppliesOncePerCustomer bool
+	UsageLimit             *int
+}
+
+// DiscountCombinesWith tells which other discount classes a discount
+// combines with.
+type DiscountCombinesWith struct {
+	OrderDiscounts    bool `json:"orderDiscounts"`
+	ProductDiscounts  bool `json:"productDiscounts"`
+	ShippingDiscounts bool `json:"shippingDiscounts"`
+}
+
+// AppDiscountListOptions are the filter and paging options of
+// AppDiscountService.List
+type AppDiscountListOptions struct {
+	// query in the Shopify search syntax, see SearchQuery
+	Query string
+	// number of discounts per page, defaults to 50
+	First int
+	After string
+}
+
+type graphQLAppDiscount struct {
+	Typename        string                `json:"__typename"`
+	DiscountId      string                `json:"discountId"`
+	Title           string                `json:"title"`
+	Status          string                `json:"status"`
+	StartsAt        *time.Time            `json:"startsAt"`
+	EndsAt          *time.Time            `json:"endsAt"`
+	DiscountClass   string                `json:"discountClass"`
+	CombinesWith    *DiscountCombinesWith `json:"combinesWith"`
+	AppDiscountType struct {
+		FunctionId string `json:"functionId"`
+	} `json:"appDiscountType"`
+	AppliesOncePerCustomer bool `json:"appliesOncePerCustomer"`
+	UsageLimit             *int `json:"usageLimit"`
+	Codes                  struct {
+		Nodes []struct {
+			Code string `json:"code"`
+		} `json:"nodes"`
+	} `json:"codes"`
+}
+
+func (d *graphQLAppDiscount) appDiscount() *AppDiscount {
+	if d == nil {
+		return nil
+	}
+
+	id, _ := ParseGraphQLId(d.DiscountId)
+	discount := &AppDiscount{
+		Id:                     id,
+		Title:                  d.Title,
+		FunctionId:             d.AppDiscountType.FunctionId,
+		Status:                 d.Status,
+		DiscountClass:          d.DiscountClass,
+		StartsAt:               d.StartsAt,
+		EndsAt:                 d.EndsAt,
+		CombinesWith:           d.CombinesWith,
+		AppliesOncePerCustomer: d.AppliesOncePerCustomer,
+		UsageLimit:             d.UsageLimit,
+	}
+	if len(d.Codes.Nodes) > 0 {
+		discount.Code = d.Codes.Nodes[0].Code
+	}
+	return discount
+}
+
+type graphQLAppDiscountNode struct {
+	Id         string `json:"id"`
+	Metafields struct {
+		Nodes []graphQLMetafield `json:"nodes"`
+	} `json:"metafields"`
+	Discount *graphQLAppDiscount `json:"discount"`
+}
+
+func (n *graphQLAppDiscountNode) appDiscount() *AppDiscount {
+	if n == nil || n.Discount == nil {
+		return nil
+	}
+	switch n.Discount.Typename {
+	case "DiscountAutomaticApp", "DiscountCodeApp":
+	default:
+		return nil
+	}
+
+	discount := n.Discount.appDiscount()
+	discount.Id, _ = ParseGraphQLId(n.Id)
+	for _, metafield := range n.Metafields.Nodes {
+		discount.Metafields = append(discount.Metafields, metafield.metafield())
+	}
+	return discount
+}
+
+type graphQLMetafield struct {
+	Id        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+}
+
+func (m graphQLMetafield) metafield() Metafield {
+	id, _ := ParseGraphQLId(m.Id)
+	return Metafield{
+		Id:                id,
+		Namespace:         m.Namespace,
+		Key:               m.Key,
+		Type:              MetafieldType(m.Type),
+		Value:             m.Value,
+		AdminGraphqlApiId: m.Id,
+	}
+}
+
+// metafieldInput converts a metafield to a GraphQL MetafieldInput, values
+// which aren't strings are sent JSON encoded.
+func metafieldInput(metafield Metafield) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	if metafield.Id != 0 {
+		input["id"] = GraphQLId("Metafield", metafield.Id)
+	}
+	if metafield.Namespace != "" {
+		input["namespace"] = metafield.Namespace
+	}
+	if metafield.Key != "" {
+		input["key"] = metafield.Key
+	}
+	if metafield.Type != "" {
+		input["type"] = string(metafield.Type)
+	}
+
+	switch v := metafield.Value.(type) {
+	case nil:
+	case string:
+		input["value"] = v
+	default:
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("metafield %s.%s: %v", metafield.Namespace, metafield.Key, err)
+		}
+		input["value"] = string(value)
+	}
+	return input, nil
+}
+
+func appDiscountInput(discount AppDiscount) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	if discount.Title != "" {
+		input["title"] = discount.Title
+	}
+	if discount.FunctionId != "" {
+		input["functionId"] = discount.FunctionId
+	}
+	if discount.StartsAt != nil {
+		input["startsAt"] = discount.StartsAt.UTC().Format(time.RFC3339)
+	}
+	if discount.EndsAt != nil {
+		input["endsAt"] = discount.EndsAt.UTC().Format(time.RFC3339)
+	}
+	if discount.CombinesWith != nil {
+		input["combinesWith"] = discount.CombinesWith
+	}
+	if len(discount.Metafields) > 0 {
+		metafields := make([]map[string]interface{}, 0, len(discount.Metafields))
+		for _, metafield := range discount.Metafields {
+			m, err := metafieldInput(metafield)
+			if err != nil {
+				return nil, err
+			}
+			metafields = append(metafields, m)
+		}
+		input["metafields"] = metafields
+	}
+	return input, nil
+}
+
+// CreateAutomatic creates an automatic discount applied by the function
+// FunctionId.
+func (s *AppDiscountServiceOp) CreateAutomatic(ctx context.Context, discount AppDiscount) (*AppDiscount, error) {
+	input, err := appDiscountInput(discount)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		DiscountAutomaticAppCreate struct {
+			AutomaticAppDiscount *graphQLAppDiscount `json:"automaticAppDiscount"`
+			UserErrors           GraphQLUserErrors   `json:"userErrors"`
+		} `json:"discountAutomaticAppCreate"`
+	}{}
+
+	vars := map[string]interface{}{"automaticAppDiscount": input}
+	err = s.client.GraphQL.Query(ctx, discountAutomaticAppCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.DiscountAutomaticAppCreate.UserErrors) > 0 {
+		return nil, resp.DiscountAutomaticAppCreate.UserErrors
+	}
+	return resp.DiscountAutomaticAppCreate.AutomaticAppDiscount.appDiscount(), nil
+}
+
+// UpdateAutomatic updates an automatic app discount. Existing metafields are
+// updated by Id, others are added.
+func (s *AppDiscountServiceOp) UpdateAutomatic(ctx context.Context, discount AppDiscount) (*AppDiscount, error) {
+	input, err := appDiscountInput(discount)
+	if err != nil {
+		return nil, err
+	}
+	// the function of a discount can't be changed
+	delete(input, "functionId")
+
+	resp := struct {
+		DiscountAutomaticAppUpdate struct {
+			AutomaticAppDiscount *graphQLAppDiscount `json:"automaticAppDiscount"`
+			UserErrors           GraphQLUserErrors   `json:"userErrors"`
+		} `json:"discountAutomaticAppUpdate"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":                   GraphQLId("DiscountAutomaticNode", discount.Id),
+		"automaticAppDiscount": input,
+	}
+	err = s.client.GraphQL.Query(ctx, discountAutomaticAppUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.DiscountAutomaticAppUpdate.UserErrors) > 0 {
+		return nil, resp.DiscountAutomaticAppUpdate.UserErrors
+	}
+	return resp.DiscountAutomaticAppUpdate.AutomaticAppDiscount.appDiscount(), nil
+}
+
+// CreateCode creates a discount applied by the function FunctionId when the
+// customer enters Code.
+func (s *AppDiscountServiceOp) CreateCode(ctx context.Context, discount AppDiscount) (*AppDiscount, error) {
+	input, err := appDiscountInput(discount)
+	if err != nil {
+		return nil, err
+	}
+	input["code"] = discount.Code
+	input["appliesOncePerCustomer"] = discount.AppliesOncePerCustomer
+	if discount.UsageLimit != nil {
+		input["usageLimit"] = *discount.UsageLimit
+	}
+
+	resp := struct {
+		DiscountCodeAppCreate struct {
+			CodeAppDiscount *graphQLAppDiscount `json:"codeAppDiscount"`
+			UserErrors      GraphQLUserErrors   `json:"userErrors"`
+		} `json:"discountCodeAppCreate"`
+	}{}
+
+	vars := map[string]interface{}{"codeAppDiscount": input}
+	err = s.client.GraphQL.Query(ctx, discountCodeAppCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.DiscountCodeAppCreate.UserErrors) > 0 {
+		return nil, resp.DiscountCodeAppCreate.UserErrors
+	}
+	return resp.DiscountCodeAppCreate.CodeAppDiscount.appDiscount(), nil
+}
+
+// Get gets an app discount with its metafields. It returns nil if the
+// discount does not exist or isn't an app discount.
+func (s *AppDiscountServiceOp) Get(ctx context.Context, discountId uint64) (*AppDiscount, error) {
+	resp := struct {
+		DiscountNode *graphQLAppDiscountNode `json:"discountNode"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("DiscountNode", discountId)}
+	err := s.client.GraphQL.Query(ctx, discountNodeQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DiscountNode.appDiscount(), nil
+}
+
+// List lists a page of app discounts with their metafields, discounts which
+// aren't app discounts are left out.
+func (s *AppDiscountServiceOp) List(ctx context.Context, options *AppDiscountListOptions) ([]AppDiscount, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &AppDiscountListOptions{}
+	}
+
+	vars := map[string]interface{}{"first": options.First}
+	if options.First <= 0 {
+		vars["first"] = 50
+	}
+	if options.After != "" {
+		vars["after"] = options.After
+	}
+	if options.Query != "" {
+		vars["query"] = options.Query
+	}
+
+	resp := struct {
+		DiscountNodes struct {
+			Nodes    []graphQLAppDiscountNode `json:"nodes"`
+			PageInfo GraphQLPageInfo          `json:"pageInfo"`
+		} `json:"discountNodes"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, discountNodesQuery, vars, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	discounts := []AppDiscount{}
+	for i := range resp.DiscountNodes.Nodes {
+		if discount := resp.DiscountNodes.Nodes[i].appDiscount(); discount != nil {
+			discounts = append(discounts, *discount)
+		}
+	}
+	return discounts, &resp.DiscountNodes.PageInfo, nil
+}
@@ -0,0 +1,241 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppDiscountCreateAutomatic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"discountAutomaticAppCreate":{"automaticAppDiscount":{
+			"discountId":"gid://shopify/DiscountAutomaticNode/1",
+			"title":"Volume discount",
+			"status":"ACTIVE",
+			"startsAt":"2024-01-01T00:00:00Z",
+			"discountClass":"PRODUCT",
+			"appDiscountType":{"functionId":"fn-1"},
+			"combinesWith":{"orderDiscounts":false,"productDiscounts":true,"shippingDiscounts":true}
+		},"userErrors":[]}}}`
+	})
+
+	startsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	discount, err := client.AppDiscount.CreateAutomatic(context.Background(), AppDiscount{
+		Title:        "Volume discount",
+		FunctionId:   "fn-1",
+		StartsAt:     &startsAt,
+		CombinesWith: &DiscountCombinesWith{ProductDiscounts: true, ShippingDiscounts: true},
+		Metafields: []Metafield{{
+			Namespace: "volume-discount",
+			Key:       "function-configuration",
+			Type:      MetafieldTypeJSON,
+			Value:     map[string]interface{}{"percentage": 10},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("AppDiscount.CreateAutomatic returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"automaticAppDiscount": map[string]interface{}{
+		"title":        "Volume discount",
+		"functionId":   "fn-1",
+		"startsAt":     "2024-01-01T00:00:00Z",
+		"combinesWith": map[string]interface{}{"orderDiscounts": false, "productDiscounts": true, "shippingDiscounts": true},
+		"metafields": []interface{}{map[string]interface{}{
+			"namespace": "volume-discount",
+			"key":       "function-configuration",
+			"type":      "json",
+			"value":     `{"percentage":10}`,
+		}},
+	}}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("AppDiscount.CreateAutomatic sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	expected := &AppDiscount{
+		Id:            1,
+		Title:         "Volume discount",
+		FunctionId:    "fn-1",
+		Status:        "ACTIVE",
+		DiscountClass: "PRODUCT",
+		StartsAt:      &startsAt,
+		CombinesWith:  &DiscountCombinesWith{ProductDiscounts: true, ShippingDiscounts: true},
+	}
+	if !reflect.DeepEqual(discount, expected) {
+		t.Errorf("AppDiscount.CreateAutomatic returned %+v, expected %+v", discount, expected)
+	}
+}
+
+func TestAppDiscountUpdateAutomatic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"discountAutomaticAppUpdate":{"automaticAppDiscount":{
+			"discountId":"gid://shopify/DiscountAutomaticNode/1","title":"Volume discount","appDiscountType":{"functionId":"fn-1"}
+		},"userErrors":[]}}}`
+	})
+
+	_, err := client.AppDiscount.UpdateAutomatic(context.Background(), AppDiscount{
+		Id:         1,
+		FunctionId: "fn-1",
+		Metafields: []Metafield{{Id: 5, Value: `{"percentage":15}`}},
+	})
+	if err != nil {
+		t.Fatalf("AppDiscount.UpdateAutomatic returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id": "gid://shopify/DiscountAutomaticNode/1",
+		"automaticAppDiscount": map[string]interface{}{
+			"metafields": []interface{}{map[string]interface{}{
+				"id":    "gid://shopify/Metafield/5",
+				"value": `{"percentage":15}`,
+			}},
+		},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("AppDiscount.UpdateAutomatic sent %+v, expected %+v", vars, expectedVars)
+	}
+}
+
+func TestAppDiscountCreateCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"discountCodeAppCreate":{"codeAppDiscount":{
+			"discountId":"gid://shopify/DiscountCodeNode/2",
+			"title":"Welcome",
+			"appDiscountType":{"functionId":"fn-1"},
+			"appliesOncePerCustomer":true,
+			"usageLimit":100,
+			"codes":{"nodes":[{"code":"WELCOME"}]}
+		},"userErrors":[]}}}`
+	})
+
+	limit := 100
+	discount, err := client.AppDiscount.CreateCode(context.Background(), AppDiscount{
+		Title:                  "Welcome",
+		FunctionId:             "fn-1",
+		Code:                   "WELCOME",
+		AppliesOncePerCustomer: true,
+		UsageLimit:             &limit,
+	})
+	if err != nil {
+		t.Fatalf("AppDiscount.CreateCode returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"codeAppDiscount": map[string]interface{}{
+		"title":                  "Welcome",
+		"functionId":             "fn-1",
+		"code":                   "WELCOME",
+		"appliesOncePerCustomer": true,
+		"usageLimit":             float64(100),
+	}}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("AppDiscount.CreateCode sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if discount.Id != 2 || discount.Code != "WELCOME" || !discount.AppliesOncePerCustomer || *discount.UsageLimit != 100 {
+		t.Errorf("AppDiscount.CreateCode returned %+v", discount)
+	}
+}
+
+func TestAppDiscountCreateCodeUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"discountCodeAppCreate":{"codeAppDiscount":null,
+			"userErrors":[{"field":["codeAppDiscount","code"],"message":"Code must be unique","code":"TAKEN"}]}}}`
+	})
+
+	_, err := client.AppDiscount.CreateCode(context.Background(), AppDiscount{Code: "WELCOME"})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("AppDiscount.CreateCode err returned %v, expected GraphQLUserErrors", err)
+	}
+}
+
+func TestAppDiscountGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"discountNode":{
+			"id":"gid://shopify/DiscountNode/1",
+			"metafields":{"nodes":[{"id":"gid://shopify/Metafield/5","namespace":"volume-discount","key":"function-configuration","type":"json","value":"{\"percentage\":10}"}]},
+			"discount":{"__typename":"DiscountAutomaticApp","title":"Volume discount","appDiscountType":{"functionId":"fn-1"}}
+		}}}`
+	})
+
+	discount, err := client.AppDiscount.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("AppDiscount.Get returned error: %v", err)
+	}
+
+	if vars["id"] != "gid://shopify/DiscountNode/1" {
+		t.Errorf("AppDiscount.Get sent %+v", vars)
+	}
+
+	expected := &AppDiscount{
+		Id:         1,
+		Title:      "Volume discount",
+		FunctionId: "fn-1",
+		Metafields: []Metafield{{
+			Id:                5,
+			Namespace:         "volume-discount",
+			Key:               "function-configuration",
+			Type:              MetafieldTypeJSON,
+			Value:             `{"percentage":10}`,
+			AdminGraphqlApiId: "gid://shopify/Metafield/5",
+		}},
+	}
+	if !reflect.DeepEqual(discount, expected) {
+		t.Errorf("AppDiscount.Get returned %+v, expected %+v", discount, expected)
+	}
+}
+
+func TestAppDiscountList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"discountNodes":{"nodes":[
+			{"id":"gid://shopify/DiscountNode/1","metafields":{"nodes":[]},"discount":{"__typename":"DiscountAutomaticApp","title":"Volume discount"}},
+			{"id":"gid://shopify/DiscountNode/2","metafields":{"nodes":[]},"discount":{"__typename":"DiscountCodeBasic","title":"Basic"}},
+			{"id":"gid://shopify/DiscountNode/3","metafields":{"nodes":[]},"discount":{"__typename":"DiscountCodeApp","title":"Welcome","codes":{"nodes":[{"code":"WELCOME"}]}}}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`
+	})
+
+	discounts, pageInfo, err := client.AppDiscount.List(context.Background(), &AppDiscountListOptions{Query: "status:active"})
+	if err != nil {
+		t.Fatalf("AppDiscount.List returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"first": float64(50), "query": "status:active"}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("AppDiscount.List sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if len(discounts) != 2 || discounts[0].Id != 1 || discounts[1].Id != 3 || discounts[1].Code != "WELCOME" {
+		t.Errorf("AppDiscount.List returned %+v", discounts)
+	}
+	if !pageInfo.HasNextPage || pageInfo.EndCursor != "abc" {
+		t.Errorf("AppDiscount.List returned page info %+v", pageInfo)
+	}
+}
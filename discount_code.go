@@ -17,6 +17,8 @@ type DiscountCodeService interface {
 	List(context.Context, uint64) ([]PriceRuleDiscountCode, error)
 	Get(context.Context, uint64, uint64) (*PriceRuleDiscountCode, error)
 	Delete(context.Context, uint64, uint64) error
+	Lookup(context.Context, string) (*PriceRuleDiscountCode, error)
+	CreateOrGet(context.Context, uint64, PriceRuleDiscountCode) (*PriceRuleDiscountCode, error)
 }
 
 // DiscountCodeServiceOp handles communication with the discount code
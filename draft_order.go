@@ -25,6 +25,12 @@ type DraftOrderService interface {
 	Delete(context.Context, uint64) error
 	Invoice(context.Context, uint64, DraftOrderInvoice) (*DraftOrderInvoice, error)
 	Complete(context.Context, uint64, bool) (*DraftOrder, error)
+	Calculate(context.Context, DraftOrder) (*DraftOrderCalculation, error)
+	ListForCompany(context.Context, uint64, *CompanyOrdersOptions) ([]DraftOrder, *GraphQLPageInfo, error)
+	DeleteMany(context.Context, []uint64) error
+	CompleteMany(context.Context, []uint64, bool) (map[uint64]*DraftOrder, error)
+	AddTagsMany(context.Context, []uint64, ...string) (map[uint64]*DraftOrder, error)
+	RemoveTagsMany(context.Context, []uint64, ...string) (map[uint64]*DraftOrder, error)
 
 	// MetafieldsService used for DrafT Order resource to communicate with Metafields resource
 	MetafieldsService
@@ -42,6 +48,7 @@ type DraftOrder struct {
 	OrderId         uint64           `json:"order_id,omitempty"`
 	Name            string           `json:"name,omitempty"`
 	Customer        *Customer        `json:"customer,omitempty"`
+	Company         *OrderCompany    `json:"company,omitempty"`
 	ShippingAddress *Address         `json:"shipping_address,omitempty"`
 	BillingAddress  *Address         `json:"billing_address,omitempty"`
 	Note            string           `json:"note,omitempty"`
@@ -53,6 +60,7 @@ type DraftOrder struct {
 	LineItems       []LineItem       `json:"line_items,omitempty"`
 	ShippingLine    *ShippingLines   `json:"shipping_line,omitempty"`
 	Tags            string           `json:"tags,omitempty"`
+	PoNumber        string           `json:"po_number,omitempty"`
 	TaxLines        []TaxLine        `json:"tax_lines,omitempty"`
 	AppliedDiscount *AppliedDiscount `json:"applied_discount,omitempty"`
 	TaxesIncluded   bool             `json:"taxes_included,omitempty"`
@@ -107,7 +115,7 @@ type DraftOrderListOptions struct {
 	UpdatedAtMin *time.Time  `url:"updated_at_min,omitempty"`
 	UpdatedAtMax *time.Time  `url:"updated_at_max,omitempty"`
 	Ids          string      `url:"ids,omitempty"`
-	Status       orderStatus `url:"status,omitempty"`
+	Status       OrderStatus `url:"status,omitempty"`
 }
 
 // DraftOrderCountOptions represents the possible options to the count draft orders endpoint
@@ -116,11 +124,15 @@ type DraftOrderCountOptions struct {
 	Limit   int         `url:"limit,omitempty"`
 	SinceId uint64      `url:"since_id,omitempty"`
 	Ids     string      `url:"ids,omitempty"`
-	Status  orderStatus `url:"status,omitempty"`
+	Status  OrderStatus `url:"status,omitempty"`
 }
 
 // Create draft order
 func (s *DraftOrderServiceOp) Create(ctx context.Context, draftOrder DraftOrder) (*DraftOrder, error) {
+	if err := s.client.validateCreate("draft order", draftOrder); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", draftOrdersBasePath)
 	wrappedData := DraftOrderResource{DraftOrder: &draftOrder}
 	resource := new(DraftOrderResource)
@@ -0,0 +1,132 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeleteMany deletes draft orders concurrently, see GetMany. Draft orders
+// that don't exist anymore count as deleted. If any draft order couldn't be
+// deleted the returned error is a BatchErrors.
+func (s *DraftOrderServiceOp) DeleteMany(ctx context.Context, draftOrderIds []uint64) error {
+	_, err := s.client.GetMany(ctx, draftOrderIds, func(ctx context.Context, id uint64) (interface{}, error) {
+		err := s.Delete(ctx, id)
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	})
+	return err
+}
+
+// CompleteMany completes draft orders concurrently, turning them into
+// orders, and returns the completed draft orders by id. If any draft order
+// couldn't be completed the returned error is a BatchErrors, the other draft
+// orders are returned regardless.
+func (s *DraftOrderServiceOp) CompleteMany(ctx context.Context, draftOrderIds []uint64, paymentPending bool) (map[uint64]*DraftOrder, error) {
+	results, err := s.client.GetMany(ctx, draftOrderIds, func(ctx context.Context, id uint64) (interface{}, error) {
+		return s.Complete(ctx, id, paymentPending)
+	})
+	return draftOrdersById(results), err
+}
+
+// AddTagsMany adds tags to draft orders concurrently, keeping their other
+// tags, and returns the updated draft orders by id. See CompleteMany for
+// errors.
+func (s *DraftOrderServiceOp) AddTagsMany(ctx context.Context, draftOrderIds []uint64, tags ...string) (map[uint64]*DraftOrder, error) {
+	return s.updateTagsMany(ctx, draftOrderIds, func(current []string) []string {
+		return addTags(current, tags)
+	})
+}
+
+// RemoveTagsMany removes tags from draft orders concurrently and returns the
+// updated draft orders by id. See CompleteMany for errors.
+func (s *DraftOrderServiceOp) RemoveTagsMany(ctx context.Context, draftOrderIds []uint64, tags ...string) (map[uint64]*DraftOrder, error) {
+	return s.updateTagsMany(ctx, draftOrderIds, func(current []string) []string {
+		return removeTags(current, tags)
+	})
+}
+
+func (s *DraftOrderServiceOp) updateTagsMany(ctx context.Context, draftOrderIds []uint64, change func([]string) []string) (map[uint64]*DraftOrder, error) {
+	results, err := s.client.GetMany(ctx, draftOrderIds, func(ctx context.Context, id uint64) (interface{}, error) {
+		draftOrder, err := s.Get(ctx, id, struct {
+			Fields string `url:"fields"`
+		}{"id,tags"})
+		if err != nil {
+			return nil, err
+		}
+
+		current := splitTags(draftOrder.Tags)
+		tags := joinTags(change(current))
+		if tags == joinTags(current) {
+			return draftOrder, nil
+		}
+
+		// Update omits empty tags, removing the last tag needs an explicit
+		// empty string
+		path := fmt.Sprintf("%s/%d.json", draftOrdersBasePath, id)
+		data := map[string]interface{}{
+			"draft_order": map[string]interface{}{"id": id, "tags": tags},
+		}
+		resource := new(DraftOrderResource)
+		err = s.client.Put(ctx, path, data, resource)
+		return resource.DraftOrder, err
+	})
+	return draftOrdersById(results), err
+}
+
+func draftOrdersById(results map[uint64]interface{}) map[uint64]*DraftOrder {
+	draftOrders := make(map[uint64]*DraftOrder, len(results))
+	for id, v := range results {
+		if draftOrder, ok := v.(*DraftOrder); ok && draftOrder != nil {
+			draftOrders[id] = draftOrder
+		}
+	}
+	return draftOrders
+}
+
+// splitTags splits a comma separated list of tags, dropping empty ones
+func splitTags(tags string) []string {
+	var split []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			split = append(split, tag)
+		}
+	}
+	return split
+}
+
+func joinTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// addTags appends the tags not in current yet, tags are case insensitive
+func addTags(current []string, tags []string) []string {
+	result := append([]string{}, current...)
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && indexTag(result, tag) < 0 {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func removeTags(current []string, tags []string) []string {
+	var result []string
+	for _, tag := range current {
+		if indexTag(tags, tag) < 0 {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func indexTag(tags []string, tag string) int {
+	for i, t := range tags {
+		if strings.EqualFold(strings.TrimSpace(t), tag) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,112 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestDraftOrderDeleteMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, id := range []int{1, 2} {
+		httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/%d.json", client.pathPrefix, id),
+			httpmock.NewStringResponder(200, "{}"))
+	}
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/3.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/4.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors":{"base":["cannot be deleted"]}}`))
+
+	err := client.DraftOrder.DeleteMany(context.Background(), []uint64{1, 2, 3, 4})
+	errs, ok := err.(BatchErrors)
+	if !ok {
+		t.Fatalf("DraftOrder.DeleteMany returned %v, expected BatchErrors", err)
+	}
+	if len(errs) != 1 || errs[4] == nil {
+		t.Errorf("DraftOrder.DeleteMany returned errors %v, expected only draft order 4", errs)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	for id := 1; id <= 4; id++ {
+		key := fmt.Sprintf("DELETE https://fooshop.myshopify.com/%s/draft_orders/%d.json", client.pathPrefix, id)
+		if info[key] != 1 {
+			t.Errorf("DraftOrder.DeleteMany deleted draft order %d %d times", id, info[key])
+		}
+	}
+}
+
+func TestDraftOrderCompleteMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, id := range []int{1, 2} {
+		httpmock.RegisterResponderWithQuery("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/%d/complete.json", client.pathPrefix, id),
+			"payment_pending=true",
+			httpmock.NewStringResponder(200, fmt.Sprintf(`{"draft_order":{"id":%d,"status":"completed","order_id":%d}}`, id, id+100)))
+	}
+
+	draftOrders, err := client.DraftOrder.CompleteMany(context.Background(), []uint64{1, 2}, true)
+	if err != nil {
+		t.Fatalf("DraftOrder.CompleteMany returned error: %v", err)
+	}
+	if len(draftOrders) != 2 || draftOrders[1].OrderId != 101 || draftOrders[2].Status != "completed" {
+		t.Errorf("DraftOrder.CompleteMany returned %+v", draftOrders)
+	}
+}
+
+func TestDraftOrderTagsMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tags := map[int]string{1: "wholesale, stale", 2: "Stale", 3: ""}
+	var mu sync.Mutex
+	sent := map[int]interface{}{}
+	for id := range tags {
+		id := id
+		url := fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/%d.json", client.pathPrefix, id)
+		httpmock.RegisterResponderWithQuery("GET", url, "fields=id%2Ctags",
+			httpmock.NewStringResponder(200, fmt.Sprintf(`{"draft_order":{"id":%d,"tags":%q}}`, id, tags[id])))
+		httpmock.RegisterResponder("PUT", url, func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			data := map[string]map[string]interface{}{}
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("invalid draft order body: %v", err)
+			}
+			mu.Lock()
+			sent[id] = data["draft_order"]["tags"]
+			mu.Unlock()
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"draft_order":{"id":%d,"tags":%q}}`, id, data["draft_order"]["tags"])), nil
+		})
+	}
+
+	draftOrders, err := client.DraftOrder.RemoveTagsMany(context.Background(), []uint64{1, 2, 3}, "stale")
+	if err != nil {
+		t.Fatalf("DraftOrder.RemoveTagsMany returned error: %v", err)
+	}
+	expected := map[int]interface{}{1: "wholesale", 2: ""}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("DraftOrder.RemoveTagsMany sent tags %v, expected %v", sent, expected)
+	}
+	if len(draftOrders) != 3 || draftOrders[3].Id != 3 {
+		t.Errorf("DraftOrder.RemoveTagsMany returned %+v", draftOrders)
+	}
+
+	sent = map[int]interface{}{}
+	_, err = client.DraftOrder.AddTagsMany(context.Background(), []uint64{1, 3}, "STALE", "reviewed", " ")
+	if err != nil {
+		t.Fatalf("DraftOrder.AddTagsMany returned error: %v", err)
+	}
+	expected = map[int]interface{}{1: "wholesale, stale, reviewed", 3: "STALE, reviewed"}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("DraftOrder.AddTagsMany sent tags %v, expected %v", sent, expected)
+	}
+}
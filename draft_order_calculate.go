@@ -0,0 +1,287 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const draftOrderCalculateMutation = `mutation draftOrderCalculate($input: DraftOrderInput!) {
+  draftOrderCalculate(input: $input) {
+    calculatedDraftOrder {
+      currencyCode
+      subtotalPriceSet { ...moneyBag }
+      totalDiscountsSet { ...moneyBag }
+      totalShippingPriceSet { ...moneyBag }
+      totalTaxSet { ...moneyBag }
+      totalPriceSet { ...moneyBag }
+      taxLines {
+        title
+        rate
+        priceSet { ...moneyBag }
+      }
+      availableShippingRates {
+        handle
+        title
+        price {
+          amount
+          currencyCode
+        }
+      }
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}
+
+fragment moneyBag on MoneyBag {
+  shopMoney {
+    amount
+    currencyCode
+  }
+  presentmentMoney {
+    amount
+    currencyCode
+  }
+}`
+
+// DraftOrderCalculation holds the totals Shopify calculates for a draft order
+// without creating it, see DraftOrderService.Calculate.
+type DraftOrderCalculation struct {
+	Currency               string
+	SubtotalPriceSet       *AmountSet
+	TotalDiscountsSet      *AmountSet
+	TotalShippingPriceSet  *AmountSet
+	TotalTaxSet            *AmountSet
+	TotalPriceSet          *AmountSet
+	TaxLines               []TaxLine
+	AvailableShippingRates []DraftOrderShippingRate
+}
+
+// DraftOrderShippingRate is a shipping rate available to a draft order, its
+// Handle can be passed as the ShippingLines.Handle of the draft order.
+type DraftOrderShippingRate struct {
+	Handle string
+	Title  string
+	Price  *decimal.Decimal
+}
+
+// Calculate returns the taxes, discounts, shipping and totals of the draft
+// order as it would be created, using the GraphQL draftOrderCalculate
+// mutation.
+func (s *DraftOrderServiceOp) Calculate(ctx context.Context, draftOrder DraftOrder) (*DraftOrderCalculation, error) {
+	resp := struct {
+		DraftOrderCalculate struct {
+			CalculatedDraftOrder *struct {
+				CurrencyCode          string           `json:"currencyCode"`
+				SubtotalPriceSet      *graphQLMoneyBag `json:"subtotalPriceSet"`
+				TotalDiscountsSet     *graphQLMoneyBag `json:"totalDiscountsSet"`
+				TotalShippingPriceSet *graphQLMoneyBag `json:"totalShippingPriceSet"`
+				TotalTaxSet           *graphQLMoneyBag `json:"totalTaxSet"`
+				TotalPriceSet         *graphQLMoneyBag `json:"totalPriceSet"`
+				TaxLines              []struct {
+					Title    string           `json:"title"`
+					Rate     *decimal.Decimal `json:"rate"`
+					PriceSet *graphQLMoneyBag `json:"priceSet"`
+				} `json:"taxLines"`
+				AvailableShippingRates []struct {
+					Handle string       `json:"handle"`
+					Title  string       `json:"title"`
+					Price  graphQLMoney `json:"price"`
+				} `json:"availableShippingRates"`
+			} `json:"calculatedDraftOrder"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"draftOrderCalculate"`
+	}{}
+
+	vars := map[string]interface{}{"input": draftOrderInput(draftOrder)}
+	err := s.client.GraphQL.Query(ctx, draftOrderCalculateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := resp.DraftOrderCalculate
+	if len(result.UserErrors) > 0 {
+		return nil, result.UserErrors
+	}
+	if result.CalculatedDraftOrder == nil {
+		return nil, fmt.Errorf("no calculated draft order returned")
+	}
+
+	calculated := result.CalculatedDraftOrder
+	calculation := &DraftOrderCalculation{
+		Currency:              calculated.CurrencyCode,
+		SubtotalPriceSet:      calculated.SubtotalPriceSet.amountSet(),
+		TotalDiscountsSet:     calculated.TotalDiscountsSet.amountSet(),
+		TotalShippingPriceSet: calculated.TotalShippingPriceSet.amountSet(),
+		TotalTaxSet:           calculated.TotalTaxSet.amountSet(),
+		TotalPriceSet:         calculated.TotalPriceSet.amountSet(),
+	}
+
+	for _, taxLine := range calculated.TaxLines {
+		priceSet := taxLine.PriceSet.amountSet()
+		tl := TaxLine{Title: taxLine.Title, Rate: taxLine.Rate, PriceSet: priceSet}
+		if priceSet != nil {
+			tl.Price = priceSet.ShopMoney.Amount
+		}
+		calculation.TaxLines = append(calculation.TaxLines, tl)
+	}
+
+	for _, rate := range calculated.AvailableShippingRates {
+		calculation.AvailableShippingRates = append(calculation.AvailableShippingRates, DraftOrderShippingRate{
+			Handle: rate.Handle,
+			Title:  rate.Title,
+			Price:  rate.Price.Amount,
+		})
+	}
+
+	return calculation, nil
+}
+
+// draftOrderInput converts a draft order to a GraphQL DraftOrderInput
+func draftOrderInput(draftOrder DraftOrder) map[string]interface{} {
+	input := map[string]interface{}{}
+
+	if draftOrder.Email != "" {
+		input["email"] = draftOrder.Email
+	}
+	if draftOrder.Note != "" {
+		input["note"] = draftOrder.Note
+	}
+	if draftOrder.PoNumber != "" {
+		input["poNumber"] = draftOrder.PoNumber
+	}
+	if draftOrder.TaxExempt != nil {
+		input["taxExempt"] = *draftOrder.TaxExempt
+	}
+	if draftOrder.Tags != "" {
+		tags := []string{}
+		for _, tag := range strings.Split(draftOrder.Tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		input["tags"] = tags
+	}
+	if len(draftOrder.NoteAttributes) > 0 {
+		input["customAttributes"] = attributesInput(draftOrder.NoteAttributes)
+	}
+	if draftOrder.ShippingAddress != nil {
+		input["shippingAddress"] = mailingAddressInput(draftOrder.ShippingAddress)
+	}
+	if draftOrder.BillingAddress != nil {
+		input["billingAddress"] = mailingAddressInput(draftOrder.BillingAddress)
+	}
+	if draftOrder.AppliedDiscount != nil {
+		input["appliedDiscount"] = appliedDiscountInput(draftOrder.AppliedDiscount)
+	}
+	if draftOrder.ShippingLine != nil {
+		shippingLine := map[string]interface{}{}
+		if draftOrder.ShippingLine.Handle != "" {
+			shippingLine["shippingRateHandle"] = draftOrder.ShippingLine.Handle
+		}
+		if draftOrder.ShippingLine.Title != "" {
+			shippingLine["title"] = draftOrder.ShippingLine.Title
+		}
+		if draftOrder.ShippingLine.Price != nil {
+			shippingLine["price"] = draftOrder.ShippingLine.Price.String()
+		}
+		input["shippingLine"] = shippingLine
+	}
+
+	switch {
+	case draftOrder.Company != nil:
+		company := map[string]interface{}{
+			"companyId":         GraphQLId("Company", draftOrder.Company.Id),
+			"companyLocationId": GraphQLId("CompanyLocation", draftOrder.Company.LocationId),
+		}
+		if draftOrder.Company.ContactId != 0 {
+			company["companyContactId"] = GraphQLId("CompanyContact", draftOrder.Company.ContactId)
+		}
+		input["purchasingEntity"] = map[string]interface{}{"purchasingCompany": company}
+	case draftOrder.Customer != nil && draftOrder.Customer.Id != 0:
+		input["purchasingEntity"] = map[string]interface{}{"customerId": GraphQLId("Customer", draftOrder.Customer.Id)}
+	}
+
+	lineItems := make([]map[string]interface{}, 0, len(draftOrder.LineItems))
+	for _, li := range draftOrder.LineItems {
+		lineItem := map[string]interface{}{"quantity": li.Quantity}
+		if li.VariantId != 0 {
+			lineItem["variantId"] = GraphQLId("ProductVariant", li.VariantId)
+		} else {
+			// custom line item
+			lineItem["title"] = li.Title
+			lineItem["requiresShipping"] = li.RequiresShipping
+			lineItem["taxable"] = li.Taxable
+			if li.Price != nil {
+				lineItem["originalUnitPrice"] = li.Price.String()
+			}
+			if li.SKU != "" {
+				lineItem["sku"] = li.SKU
+			}
+		}
+		if len(li.Properties) > 0 {
+			lineItem["customAttributes"] = attributesInput(li.Properties)
+		}
+		if li.AppliedDiscount != nil {
+			lineItem["appliedDiscount"] = appliedDiscountInput(li.AppliedDiscount)
+		}
+		lineItems = append(lineItems, lineItem)
+	}
+	input["lineItems"] = lineItems
+
+	return input
+}
+
+func attributesInput(attributes []NoteAttribute) []map[string]interface{} {
+	input := make([]map[string]interface{}, 0, len(attributes))
+	for _, attr := range attributes {
+		value := ""
+		if attr.Value != nil {
+			value = fmt.Sprint(attr.Value)
+		}
+		input = append(input, map[string]interface{}{"key": attr.Name, "value": value})
+	}
+	return input
+}
+
+func appliedDiscountInput(discount *AppliedDiscount) map[string]interface{} {
+	input := map[string]interface{}{
+		"title":       discount.Title,
+		"description": discount.Description,
+		"valueType":   strings.ToUpper(discount.ValueType),
+	}
+	if value, err := decimal.NewFromString(discount.Value); err == nil {
+		input["value"], _ = value.Float64()
+	}
+	if discount.Amount != "" {
+		input["amount"] = discount.Amount
+	}
+	return input
+}
+
+func mailingAddressInput(address *Address) map[string]interface{} {
+	input := map[string]interface{}{}
+	fields := map[string]string{
+		"address1":     address.Address1,
+		"address2":     address.Address2,
+		"city":         address.City,
+		"company":      address.Company,
+		"countryCode":  address.CountryCode,
+		"firstName":    address.FirstName,
+		"lastName":     address.LastName,
+		"phone":        address.Phone,
+		"provinceCode": address.ProvinceCode,
+		"zip":          address.Zip,
+	}
+	for k, v := range fields {
+		if v != "" {
+			input[k] = v
+		}
+	}
+	return input
+}
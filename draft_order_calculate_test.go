@@ -0,0 +1,113 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestDraftOrderCalculate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var input map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		input = req.Variables["input"].(map[string]interface{})
+
+		return `{"data":{"draftOrderCalculate":{"calculatedDraftOrder":{
+			"currencyCode":"USD",
+			"subtotalPriceSet":{"shopMoney":{"amount":"20.0","currencyCode":"USD"},"presentmentMoney":{"amount":"20.0","currencyCode":"USD"}},
+			"totalTaxSet":{"shopMoney":{"amount":"1.3","currencyCode":"USD"},"presentmentMoney":{"amount":"1.3","currencyCode":"USD"}},
+			"totalPriceSet":{"shopMoney":{"amount":"26.3","currencyCode":"USD"},"presentmentMoney":{"amount":"26.3","currencyCode":"USD"}},
+			"taxLines":[{"title":"State Tax","rate":0.065,"priceSet":{"shopMoney":{"amount":"1.3","currencyCode":"USD"},"presentmentMoney":{"amount":"1.3","currencyCode":"USD"}}}],
+			"availableShippingRates":[{"handle":"shopify-Standard-5.00","title":"Standard","price":{"amount":"5.0","currencyCode":"USD"}}]
+		},"userErrors":[]}}}`
+	})
+
+	price := decimal.NewFromFloat(10)
+	draftOrder := DraftOrder{
+		Email:    "bob@example.com",
+		Tags:     "vip, rush",
+		Customer: &Customer{Id: 7},
+		LineItems: []LineItem{
+			{VariantId: 1, Quantity: 1},
+			{Title: "Engraving", Price: &price, Quantity: 1, Taxable: true},
+		},
+		AppliedDiscount: &AppliedDiscount{Title: "Rep discount", Value: "10", ValueType: "percentage"},
+	}
+
+	calculation, err := client.DraftOrder.Calculate(context.Background(), draftOrder)
+	if err != nil {
+		t.Fatalf("DraftOrder.Calculate returned error: %v", err)
+	}
+
+	expectedInput := map[string]interface{}{
+		"email":            "bob@example.com",
+		"tags":             []interface{}{"vip", "rush"},
+		"purchasingEntity": map[string]interface{}{"customerId": "gid://shopify/Customer/7"},
+		"appliedDiscount":  map[string]interface{}{"title": "Rep discount", "description": "", "value": float64(10), "valueType": "PERCENTAGE"},
+		"lineItems": []interface{}{
+			map[string]interface{}{"variantId": "gid://shopify/ProductVariant/1", "quantity": float64(1)},
+			map[string]interface{}{"title": "Engraving", "originalUnitPrice": "10", "quantity": float64(1), "requiresShipping": false, "taxable": true},
+		},
+	}
+	if !reflect.DeepEqual(input, expectedInput) {
+		t.Errorf("DraftOrder.Calculate sent input %v, expected %v", input, expectedInput)
+	}
+
+	if calculation.Currency != "USD" || !calculation.TotalPriceSet.ShopMoney.Amount.Equal(decimal.NewFromFloat(26.3)) ||
+		!calculation.TotalTaxSet.PresentmentMoney.Amount.Equal(decimal.NewFromFloat(1.3)) {
+		t.Errorf("DraftOrder.Calculate returned %+v", calculation)
+	}
+	if calculation.TotalDiscountsSet != nil {
+		t.Errorf("DraftOrder.Calculate returned TotalDiscountsSet %+v, expected nil", calculation.TotalDiscountsSet)
+	}
+
+	if len(calculation.TaxLines) != 1 || calculation.TaxLines[0].Title != "State Tax" ||
+		!calculation.TaxLines[0].Price.Equal(decimal.NewFromFloat(1.3)) || !calculation.TaxLines[0].Rate.Equal(decimal.NewFromFloat(0.065)) {
+		t.Errorf("DraftOrder.Calculate returned tax lines %+v", calculation.TaxLines)
+	}
+
+	if len(calculation.AvailableShippingRates) != 1 || calculation.AvailableShippingRates[0].Handle != "shopify-Standard-5.00" ||
+		!calculation.AvailableShippingRates[0].Price.Equal(decimal.NewFromFloat(5)) {
+		t.Errorf("DraftOrder.Calculate returned shipping rates %+v", calculation.AvailableShippingRates)
+	}
+}
+
+func TestDraftOrderCalculateUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"draftOrderCalculate":{"calculatedDraftOrder":null,
+			"userErrors":[{"field":["input","lineItems","0","variantId"],"message":"Product variant does not exist"}]}}}`))
+
+	_, err := client.DraftOrder.Calculate(context.Background(), DraftOrder{LineItems: []LineItem{{VariantId: 1, Quantity: 1}}})
+
+	expected := GraphQLUserErrors{{Field: []string{"input", "lineItems", "0", "variantId"}, Message: "Product variant does not exist"}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("DraftOrder.Calculate err returned %v, expected %v", err, expected)
+	}
+
+	expectedMessage := "input.lineItems.0.variantId: Product variant does not exist"
+	if err.Error() != expectedMessage {
+		t.Errorf("GraphQLUserErrors.Error returned %s, expected %s", err.Error(), expectedMessage)
+	}
+}
+
+func TestDraftOrderCalculateNoCalculation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{"draftOrderCalculate":{"calculatedDraftOrder":null,"userErrors":[]}}}`))
+
+	calculation, err := client.DraftOrder.Calculate(context.Background(), DraftOrder{LineItems: []LineItem{{VariantId: 1, Quantity: 1}}})
+	if calculation != nil || err == nil {
+		t.Errorf("DraftOrder.Calculate returned %+v, %v, expected an error", calculation, err)
+	}
+}
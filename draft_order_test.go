@@ -3,6 +3,8 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -65,6 +67,48 @@ func TestDraftOrderGet(t *testing.T) {
 	draftOrderTests(t, *draftOrder)
 }
 
+func TestDraftOrderCreateWithPoNumber(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			expected := `{"draft_order":{"po_number":"PO-42"}}`
+			if string(body) != expected {
+				t.Errorf("DraftOrder.Create sent %s, expected %s", body, expected)
+			}
+			return httpmock.NewStringResponse(201, `{"draft_order":{"id":1,"po_number":"PO-42"}}`), nil
+		})
+
+	draftOrder, err := client.DraftOrder.Create(context.Background(), DraftOrder{PoNumber: "PO-42"})
+	if err != nil {
+		t.Fatalf("DraftOrder.Create returned error: %v", err)
+	}
+
+	if draftOrder.PoNumber != "PO-42" {
+		t.Errorf("DraftOrder.PoNumber returned %s, expected PO-42", draftOrder.PoNumber)
+	}
+}
+
+func TestDraftOrderGetCompany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/draft_orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"draft_order":{"id":1,"company":{"id":2,"location_id":3}}}`))
+
+	draftOrder, err := client.DraftOrder.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("DraftOrder.Get returned error: %v", err)
+	}
+
+	expected := &OrderCompany{Id: 2, LocationId: 3}
+	if !reflect.DeepEqual(draftOrder.Company, expected) {
+		t.Errorf("DraftOrder.Company returned %+v, expected %+v", draftOrder.Company, expected)
+	}
+}
+
 func TestDraftOrderCreate(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,128 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DryRunRequest is a request the client built but did not send, see
+// WithDryRun. Credentials are redacted from the header.
+type DryRunRequest struct {
+	Method string
+	URL    string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   json.RawMessage
+}
+
+// DryRunError is returned instead of sending a request in dry-run mode. It
+// holds the request and the problems found validating it.
+type DryRunError struct {
+	Request  DryRunRequest
+	Problems []string
+}
+
+func (e DryRunError) Error() string {
+	if len(e.Problems) > 0 {
+		return fmt.Sprintf("dry run: %s %s is invalid: %s", e.Request.Method, e.Request.Path, strings.Join(e.Problems, "; "))
+	}
+	return fmt.Sprintf("dry run: %s %s not sent", e.Request.Method, e.Request.Path)
+}
+
+// Valid reports whether validating the request found no problems
+func (e DryRunError) Valid() bool {
+	return len(e.Problems) == 0
+}
+
+// DryRun returns the request of a DryRunError
+func DryRun(err error) (DryRunRequest, bool) {
+	dryRunErr, ok := err.(DryRunError)
+	return dryRunErr.Request, ok
+}
+
+// newDryRunError builds and validates the dry run of a request with its body
+func (c *Client) newDryRunError(req *http.Request, body []byte) DryRunError {
+	header := req.Header.Clone()
+	for _, h := range []string{"X-Shopify-Access-Token", "Authorization"} {
+		if header.Get(h) != "" {
+			header.Set(h, "REDACTED")
+		}
+	}
+
+	dryRun := DryRunRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Path:   strings.TrimPrefix(req.URL.Path, "/"),
+		Query:  req.URL.Query(),
+		Header: header,
+	}
+	if len(body) > 0 {
+		dryRun.Body = json.RawMessage(body)
+	}
+
+	return DryRunError{Request: dryRun, Problems: c.validateRequest(req, body)}
+}
+
+// validateRequest returns the problems of a request that Shopify would reject
+// or misinterpret
+func (c *Client) validateRequest(req *http.Request, body []byte) []string {
+	var problems []string
+
+	switch req.Method {
+	case http.MethodGet, http.MethodDelete:
+		if len(body) > 0 {
+			problems = append(problems, fmt.Sprintf("%s request has a payload", req.Method))
+		}
+	case http.MethodPost, http.MethodPut:
+		problems = append(problems, validatePayload(body)...)
+	default:
+		problems = append(problems, fmt.Sprintf("unsupported method %s", req.Method))
+	}
+
+	if req.URL.Host != c.baseURL.Host {
+		problems = append(problems, fmt.Sprintf("host %s isn't the shop's %s", req.URL.Host, c.baseURL.Host))
+	}
+	relPath := c.relativePath(req.URL)
+	if !strings.HasPrefix(relPath, c.pathPrefix+"/") {
+		problems = append(problems, fmt.Sprintf("path isn't under %s", c.pathPrefix))
+	}
+	for _, segment := range strings.Split(relPath, "/") {
+		switch segment {
+		case "":
+			problems = append(problems, "path has an empty segment")
+		case "0", "0.json":
+			problems = append(problems, "path has a zero id")
+		}
+	}
+
+	return problems
+}
+
+// validatePayload checks that a payload is a JSON object and that the
+// resource a REST payload wraps, e.g. {"product": {...}}, isn't missing.
+// Actions like closing an order are posted without payload.
+func validatePayload(body []byte) []string {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(body, &root); err != nil {
+		return []string{fmt.Sprintf("payload isn't a JSON object: %v", err)}
+	}
+	if len(root) == 0 {
+		return []string{"payload is empty"}
+	}
+	if len(root) == 1 {
+		for key, value := range root {
+			if string(value) == "null" {
+				return []string{fmt.Sprintf("payload %s is null", key)}
+			}
+		}
+	}
+	return nil
+}
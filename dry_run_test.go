@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestDryRunCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithDryRun())
+	httpmock.ActivateNonDefault(client.Client)
+
+	_, err := client.Product.Create(context.Background(), Product{Title: "Shirt"})
+	dryRunErr, ok := err.(DryRunError)
+	if !ok {
+		t.Fatalf("Product.Create returned %v, expected a DryRunError", err)
+	}
+	if !dryRunErr.Valid() {
+		t.Errorf("Product.Create dry run has problems %v", dryRunErr.Problems)
+	}
+
+	req := dryRunErr.Request
+	if req.Method != "POST" || req.Path != "admin/api/"+testApiVersion+"/products.json" {
+		t.Errorf("dry run request is %s %s", req.Method, req.Path)
+	}
+	if req.URL != "https://fooshop.myshopify.com/"+req.Path {
+		t.Errorf("dry run request url is %s", req.URL)
+	}
+	if req.Header.Get("X-Shopify-Access-Token") != "REDACTED" {
+		t.Errorf("dry run request didn't redact the access token: %v", req.Header)
+	}
+
+	var body map[string]map[string]interface{}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		t.Fatalf("dry run request body is invalid: %v", err)
+	}
+	if body["product"]["title"] != "Shirt" {
+		t.Errorf("dry run request body is %s", req.Body)
+	}
+
+	if n := httpmock.GetTotalCallCount(); n != 0 {
+		t.Errorf("dry run sent %d requests", n)
+	}
+}
+
+func TestDryRunQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithDryRun())
+	httpmock.ActivateNonDefault(client.Client)
+
+	_, err := client.Order.List(context.Background(), OrderListOptions{Status: OrderStatusAny})
+	req, ok := DryRun(err)
+	if !ok {
+		t.Fatalf("Order.List returned %v, expected a DryRunError", err)
+	}
+	if !reflect.DeepEqual(req.Query["status"], []string{"any"}) || req.Body != nil {
+		t.Errorf("dry run request has query %v and body %s", req.Query, req.Body)
+	}
+}
+
+func TestDryRunProblems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithDryRun())
+	httpmock.ActivateNonDefault(client.Client)
+
+	_, err := client.Product.Update(context.Background(), Product{Title: "Shirt"})
+	dryRunErr, ok := err.(DryRunError)
+	if !ok {
+		t.Fatalf("Product.Update returned %v, expected a DryRunError", err)
+	}
+	if !reflect.DeepEqual(dryRunErr.Problems, []string{"path has a zero id"}) {
+		t.Errorf("Product.Update dry run has problems %v", dryRunErr.Problems)
+	}
+
+	err = client.Post(context.Background(), "products.json", ProductResource{}, nil)
+	dryRunErr, _ = err.(DryRunError)
+	if !reflect.DeepEqual(dryRunErr.Problems, []string{"payload product is null"}) {
+		t.Errorf("Post dry run has problems %v", dryRunErr.Problems)
+	}
+
+	// actions posted without payload are valid
+	err = client.Post(context.Background(), "orders/1/close.json", nil, nil)
+	if dryRunErr, _ := err.(DryRunError); !dryRunErr.Valid() {
+		t.Errorf("Post dry run has problems %v", dryRunErr.Problems)
+	}
+}
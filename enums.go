@@ -0,0 +1,363 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UnknownEnumValueError is returned when parsing an unknown value of an enum
+// type, or when a response has one with WithStrictEnums set
+type UnknownEnumValueError struct {
+	Type  string
+	Value string
+}
+
+func (e UnknownEnumValueError) Error() string {
+	return fmt.Sprintf("unknown %s %q", e.Type, e.Value)
+}
+
+var (
+	orderStatusValues = []string{
+		string(OrderStatusOpen), string(OrderStatusClosed), string(OrderStatusCancelled), string(OrderStatusAny),
+	}
+	orderFulfillmentStatusValues = []string{
+		string(OrderFulfillmentStatusShipped), string(OrderFulfillmentStatusPartial),
+		string(OrderFulfillmentStatusUnshipped), string(OrderFulfillmentStatusAny),
+		string(OrderFulfillmentStatusUnfulfilled), string(OrderFulfillmentStatusFulfilled),
+		string(OrderFulfillmentStatusRestocked), string(OrderFulfillmentStatusNotEligible),
+	}
+	orderFinancialStatusValues = []string{
+		string(OrderFinancialStatusAuthorized), string(OrderFinancialStatusPending), string(OrderFinancialStatusPaid),
+		string(OrderFinancialStatusPartiallyPaid), string(OrderFinancialStatusRefunded),
+		string(OrderFinancialStatusVoided), string(OrderFinancialStatusPartiallyRefunded),
+		string(OrderFinancialStatusAny), string(OrderFinancialStatusUnpaid),
+	}
+	orderCancelReasonValues = []string{
+		string(OrderCancelReasonCustomer), string(OrderCancelReasonFraud), string(OrderCancelReasonInventory),
+		string(OrderCancelReasonDeclined), string(OrderCancelReasonOther), string(OrderCancelReasonStaff),
+	}
+	discountAllocationMethodValues = []string{
+		string(DiscountAllocationMethodAcross), string(DiscountAllocationMethodEach), string(DiscountAllocationMethodOne),
+	}
+	discountTargetSelectionValues = []string{
+		string(DiscountTargetSelectionAll), string(DiscountTargetSelectionEntitled), string(DiscountTargetSelectionExplicit),
+	}
+	discountTargetTypeValues = []string{
+		string(DiscountTargetTypeLineItem), string(DiscountTargetTypeShippingLine),
+	}
+	discountTypeValues = []string{
+		string(DiscountTypeAutomatic), string(DiscountTypeDiscountCode), string(DiscountTypeManual), string(DiscountTypeScript),
+	}
+	discountValueTypeValues = []string{
+		string(DiscountValueTypeFixedAmount), string(DiscountValueTypePercentage),
+	}
+	orderInventoryBehaviourValues = []string{
+		string(OrderInventoryBehaviourBypass), string(OrderInventoryBehaviourDecrementIgnoringPolicy),
+		string(OrderInventoryBehaviourDecrementObeyingPolicy),
+	}
+	orderRiskRecommendationValues = []string{
+		string(OrderRecommendationCancel), string(OrderRecommendationInvestigate), string(OrderRecommendationAccept),
+		string(OrderRecommendationNone),
+	}
+	variantInventoryPolicyValues = []string{
+		string(VariantInventoryPolicyDeny), string(VariantInventoryPolicyContinue),
+	}
+)
+
+func validEnum(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEnum parses user input, e.g. " Paid", into a known value of an enum
+// type
+func parseEnum(typ string, value string, values []string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if !validEnum(v, values) {
+		return "", UnknownEnumValueError{Type: typ, Value: value}
+	}
+	return v, nil
+}
+
+// unmarshalEnum decodes the JSON string of an enum field, null decodes to the
+// empty value. Unknown values are kept, see WithStrictEnums.
+func unmarshalEnum(data []byte) (string, error) {
+	var v string
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// enum is a string type with known values, e.g. OrderFinancialStatus
+type enum interface {
+	Valid() bool
+}
+
+// checkEnums returns an UnknownEnumValueError for the first enum field of the
+// decoded response v whose value isn't known, see WithStrictEnums
+func checkEnums(v interface{}) error {
+	return checkEnumValue(reflect.ValueOf(v))
+}
+
+func checkEnumValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return checkEnumValue(v.Elem())
+	case reflect.String:
+		if e, ok := v.Interface().(enum); ok && v.Len() > 0 && !e.Valid() {
+			return UnknownEnumValueError{Type: v.Type().Name(), Value: v.String()}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// skip unexported fields, e.g. of decimal.Decimal
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := checkEnumValue(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkEnumValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkEnumValue(iter.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseOrderStatus parses an OrderStatus, e.g. from a query parameter
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	v, err := parseEnum("OrderStatus", s, orderStatusValues)
+	return OrderStatus(v), err
+}
+
+func (s OrderStatus) String() string { return string(s) }
+
+// Valid tells whether s is a known OrderStatus
+func (s OrderStatus) Valid() bool { return validEnum(string(s), orderStatusValues) }
+
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*s = OrderStatus(v)
+	return err
+}
+
+// ParseOrderFulfillmentStatus parses an OrderFulfillmentStatus, e.g. from a
+// query parameter
+func ParseOrderFulfillmentStatus(s string) (OrderFulfillmentStatus, error) {
+	v, err := parseEnum("OrderFulfillmentStatus", s, orderFulfillmentStatusValues)
+	return OrderFulfillmentStatus(v), err
+}
+
+func (s OrderFulfillmentStatus) String() string { return string(s) }
+
+// Valid tells whether s is a known OrderFulfillmentStatus
+func (s OrderFulfillmentStatus) Valid() bool {
+	return validEnum(string(s), orderFulfillmentStatusValues)
+}
+
+func (s *OrderFulfillmentStatus) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*s = OrderFulfillmentStatus(v)
+	return err
+}
+
+// ParseOrderFinancialStatus parses an OrderFinancialStatus, e.g. from a query
+// parameter
+func ParseOrderFinancialStatus(s string) (OrderFinancialStatus, error) {
+	v, err := parseEnum("OrderFinancialStatus", s, orderFinancialStatusValues)
+	return OrderFinancialStatus(v), err
+}
+
+func (s OrderFinancialStatus) String() string { return string(s) }
+
+// Valid tells whether s is a known OrderFinancialStatus
+func (s OrderFinancialStatus) Valid() bool {
+	return validEnum(string(s), orderFinancialStatusValues)
+}
+
+func (s *OrderFinancialStatus) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*s = OrderFinancialStatus(v)
+	return err
+}
+
+// ParseOrderCancelReason parses an OrderCancelReason
+func ParseOrderCancelReason(s string) (OrderCancelReason, error) {
+	v, err := parseEnum("OrderCancelReason", s, orderCancelReasonValues)
+	return OrderCancelReason(v), err
+}
+
+func (r OrderCancelReason) String() string { return string(r) }
+
+// Valid tells whether r is a known OrderCancelReason
+func (r OrderCancelReason) Valid() bool { return validEnum(string(r), orderCancelReasonValues) }
+
+func (r *OrderCancelReason) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*r = OrderCancelReason(v)
+	return err
+}
+
+// ParseDiscountAllocationMethod parses a DiscountAllocationMethod
+func ParseDiscountAllocationMethod(s string) (DiscountAllocationMethod, error) {
+	v, err := parseEnum("DiscountAllocationMethod", s, discountAllocationMethodValues)
+	return DiscountAllocationMethod(v), err
+}
+
+func (m DiscountAllocationMethod) String() string { return string(m) }
+
+// Valid tells whether m is a known DiscountAllocationMethod
+func (m DiscountAllocationMethod) Valid() bool {
+	return validEnum(string(m), discountAllocationMethodValues)
+}
+
+func (m *DiscountAllocationMethod) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*m = DiscountAllocationMethod(v)
+	return err
+}
+
+// ParseDiscountTargetSelection parses a DiscountTargetSelection
+func ParseDiscountTargetSelection(s string) (DiscountTargetSelection, error) {
+	v, err := parseEnum("DiscountTargetSelection", s, discountTargetSelectionValues)
+	return DiscountTargetSelection(v), err
+}
+
+func (s DiscountTargetSelection) String() string { return string(s) }
+
+// Valid tells whether s is a known DiscountTargetSelection
+func (s DiscountTargetSelection) Valid() bool {
+	return validEnum(string(s), discountTargetSelectionValues)
+}
+
+func (s *DiscountTargetSelection) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*s = DiscountTargetSelection(v)
+	return err
+}
+
+// ParseDiscountTargetType parses a DiscountTargetType
+func ParseDiscountTargetType(s string) (DiscountTargetType, error) {
+	v, err := parseEnum("DiscountTargetType", s, discountTargetTypeValues)
+	return DiscountTargetType(v), err
+}
+
+func (t DiscountTargetType) String() string { return string(t) }
+
+// Valid tells whether t is a known DiscountTargetType
+func (t DiscountTargetType) Valid() bool { return validEnum(string(t), discountTargetTypeValues) }
+
+func (t *DiscountTargetType) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*t = DiscountTargetType(v)
+	return err
+}
+
+// ParseDiscountType parses a DiscountType
+func ParseDiscountType(s string) (DiscountType, error) {
+	v, err := parseEnum("DiscountType", s, discountTypeValues)
+	return DiscountType(v), err
+}
+
+func (t DiscountType) String() string { return string(t) }
+
+// Valid tells whether t is a known DiscountType
+func (t DiscountType) Valid() bool { return validEnum(string(t), discountTypeValues) }
+
+func (t *DiscountType) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*t = DiscountType(v)
+	return err
+}
+
+// ParseDiscountValueType parses a DiscountValueType
+func ParseDiscountValueType(s string) (DiscountValueType, error) {
+	v, err := parseEnum("DiscountValueType", s, discountValueTypeValues)
+	return DiscountValueType(v), err
+}
+
+func (t DiscountValueType) String() string { return string(t) }
+
+// Valid tells whether t is a known DiscountValueType
+func (t DiscountValueType) Valid() bool { return validEnum(string(t), discountValueTypeValues) }
+
+func (t *DiscountValueType) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*t = DiscountValueType(v)
+	return err
+}
+
+// ParseOrderInventoryBehaviour parses an OrderInventoryBehaviour
+func ParseOrderInventoryBehaviour(s string) (OrderInventoryBehaviour, error) {
+	v, err := parseEnum("OrderInventoryBehaviour", s, orderInventoryBehaviourValues)
+	return OrderInventoryBehaviour(v), err
+}
+
+func (b OrderInventoryBehaviour) String() string { return string(b) }
+
+// Valid tells whether b is a known OrderInventoryBehaviour
+func (b OrderInventoryBehaviour) Valid() bool {
+	return validEnum(string(b), orderInventoryBehaviourValues)
+}
+
+func (b *OrderInventoryBehaviour) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*b = OrderInventoryBehaviour(v)
+	return err
+}
+
+// ParseOrderRiskRecommendation parses an OrderRiskRecommendation
+func ParseOrderRiskRecommendation(s string) (OrderRiskRecommendation, error) {
+	v, err := parseEnum("OrderRiskRecommendation", s, orderRiskRecommendationValues)
+	return OrderRiskRecommendation(v), err
+}
+
+func (r OrderRiskRecommendation) String() string { return string(r) }
+
+// Valid tells whether r is a known OrderRiskRecommendation
+func (r OrderRiskRecommendation) Valid() bool {
+	return validEnum(string(r), orderRiskRecommendationValues)
+}
+
+func (r *OrderRiskRecommendation) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*r = OrderRiskRecommendation(v)
+	return err
+}
+
+// ParseVariantInventoryPolicy parses a VariantInventoryPolicy
+func ParseVariantInventoryPolicy(s string) (VariantInventoryPolicy, error) {
+	v, err := parseEnum("VariantInventoryPolicy", s, variantInventoryPolicyValues)
+	return VariantInventoryPolicy(v), err
+}
+
+func (p VariantInventoryPolicy) String() string { return string(p) }
+
+// Valid tells whether p is a known VariantInventoryPolicy
+func (p VariantInventoryPolicy) Valid() bool {
+	return validEnum(string(p), variantInventoryPolicyValues)
+}
+
+func (p *VariantInventoryPolicy) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data)
+	*p = VariantInventoryPolicy(v)
+	return err
+}
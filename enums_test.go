@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestParseOrderFinancialStatus(t *testing.T) {
+	status, err := ParseOrderFinancialStatus(" Partially_Paid")
+	if err != nil || status != OrderFinancialStatusPartiallyPaid {
+		t.Errorf("ParseOrderFinancialStatus returned %v, %v, expected %v", status, err, OrderFinancialStatusPartiallyPaid)
+	}
+
+	_, err = ParseOrderFinancialStatus("bogus")
+	expected := UnknownEnumValueError{Type: "OrderFinancialStatus", Value: "bogus"}
+	if err != expected {
+		t.Errorf("ParseOrderFinancialStatus returned %v, expected %v", err, expected)
+	}
+
+	if _, err = ParseOrderStatus(""); err == nil {
+		t.Errorf("ParseOrderStatus accepted an empty status")
+	}
+}
+
+func TestEnumValid(t *testing.T) {
+	cases := []struct {
+		valid    bool
+		expected bool
+	}{
+		{OrderStatusAny.Valid(), true},
+		{OrderStatus("archived").Valid(), false},
+		{OrderFulfillmentStatusRestocked.Valid(), true},
+		{OrderCancelReasonStaff.Valid(), true},
+		{DiscountTypeScript.Valid(), true},
+		{DiscountValueType("free").Valid(), false},
+		{OrderRecommendationNone.Valid(), true},
+		{VariantInventoryPolicy("Deny").Valid(), false},
+	}
+	for i, c := range cases {
+		if c.valid != c.expected {
+			t.Errorf("case %d: Valid returned %v, expected %v", i, c.valid, c.expected)
+		}
+	}
+
+	if s := OrderFinancialStatusPaid.String(); s != "paid" {
+		t.Errorf("OrderFinancialStatus.String returned %s, expected paid", s)
+	}
+}
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	data := []byte(`{"financial_status":"expired","fulfillment_status":null,"cancel_reason":"fraud"}`)
+
+	order := Order{}
+	if err := json.Unmarshal(data, &order); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if order.FinancialStatus != "expired" || order.FulfillmentStatus != "" || order.CancelReason != OrderCancelReasonFraud {
+		t.Errorf("json.Unmarshal decoded %v, %v, %v", order.FinancialStatus, order.FulfillmentStatus, order.CancelReason)
+	}
+}
+
+func TestWithStrictEnums(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"financial_status":"paid","line_items":[{"id":2}],"cancel_reason":"expired"}}`))
+
+	order, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil || order.CancelReason != "expired" {
+		t.Errorf("Order.Get returned %+v, %v, expected the unknown cancel reason", order, err)
+	}
+
+	strict := client.Clone(WithStrictEnums())
+	_, err = strict.Order.Get(context.Background(), 1, nil)
+	expected := UnknownEnumValueError{Type: "OrderCancelReason", Value: "expired"}
+	if err != expected {
+		t.Errorf("Order.Get returned %v, expected %v", err, expected)
+	}
+
+	// the strictness is per client
+	if _, err = client.Order.Get(context.Background(), 1, nil); err != nil {
+		t.Errorf("Order.Get returned error: %v", err)
+	}
+}
+
+func TestCheckEnums(t *testing.T) {
+	cases := []struct {
+		v        interface{}
+		expected error
+	}{
+		{&Order{FinancialStatus: OrderFinancialStatusPaid}, nil},
+		{&Order{}, nil},
+		{&Order{LineItems: []LineItem{{}}, DiscountApplications: []DiscountApplication{{Type: "bogus"}}}, UnknownEnumValueError{Type: "DiscountType", Value: "bogus"}},
+		{map[string]interface{}{"variant": &Variant{InventoryPolicy: "Deny"}}, UnknownEnumValueError{Type: "VariantInventoryPolicy", Value: "Deny"}},
+		{nil, nil},
+	}
+	for i, c := range cases {
+		if err := checkEnums(c.v); err != c.expected {
+			t.Errorf("case %d: checkEnums returned %v, expected %v", i, err, c.expected)
+		}
+	}
+}
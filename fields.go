@@ -0,0 +1,168 @@
+package goshopify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Field is the name of a resource field for the fields option, which limits
+// the fields of the returned resources. Shopify ignores unknown names and
+// returns every field instead, so build the option with Fields.
+type Field string
+
+// Order fields
+const (
+	OrderFieldId                Field = "id"
+	OrderFieldName              Field = "name"
+	OrderFieldEmail             Field = "email"
+	OrderFieldCreatedAt         Field = "created_at"
+	OrderFieldUpdatedAt         Field = "updated_at"
+	OrderFieldCancelledAt       Field = "cancelled_at"
+	OrderFieldProcessedAt       Field = "processed_at"
+	OrderFieldCustomer          Field = "customer"
+	OrderFieldCurrency          Field = "currency"
+	OrderFieldTotalPrice        Field = "total_price"
+	OrderFieldSubtotalPrice     Field = "subtotal_price"
+	OrderFieldTotalTax          Field = "total_tax"
+	OrderFieldFinancialStatus   Field = "financial_status"
+	OrderFieldFulfillmentStatus Field = "fulfillment_status"
+	OrderFieldLineItems         Field = "line_items"
+	OrderFieldShippingAddress   Field = "shipping_address"
+	OrderFieldBillingAddress    Field = "billing_address"
+	OrderFieldRefunds           Field = "refunds"
+	OrderFieldTags              Field = "tags"
+	OrderFieldNote              Field = "note"
+)
+
+// Product fields
+const (
+	ProductFieldId          Field = "id"
+	ProductFieldTitle       Field = "title"
+	ProductFieldBodyHTML    Field = "body_html"
+	ProductFieldVendor      Field = "vendor"
+	ProductFieldProductType Field = "product_type"
+	ProductFieldHandle      Field = "handle"
+	ProductFieldCreatedAt   Field = "created_at"
+	ProductFieldUpdatedAt   Field = "updated_at"
+	ProductFieldPublishedAt Field = "published_at"
+	ProductFieldTags        Field = "tags"
+	ProductFieldStatus      Field = "status"
+	ProductFieldOptions     Field = "options"
+	ProductFieldVariants    Field = "variants"
+	ProductFieldImage       Field = "image"
+	ProductFieldImages      Field = "images"
+)
+
+// Variant fields
+const (
+	VariantFieldId                Field = "id"
+	VariantFieldProductId         Field = "product_id"
+	VariantFieldTitle             Field = "title"
+	VariantFieldSku               Field = "sku"
+	VariantFieldBarcode           Field = "barcode"
+	VariantFieldPrice             Field = "price"
+	VariantFieldCompareAtPrice    Field = "compare_at_price"
+	VariantFieldInventoryItemId   Field = "inventory_item_id"
+	VariantFieldInventoryQuantity Field = "inventory_quantity"
+	VariantFieldUpdatedAt         Field = "updated_at"
+)
+
+// Customer fields
+const (
+	CustomerFieldId          Field = "id"
+	CustomerFieldEmail       Field = "email"
+	CustomerFieldFirstName   Field = "first_name"
+	CustomerFieldLastName    Field = "last_name"
+	CustomerFieldPhone       Field = "phone"
+	CustomerFieldState       Field = "state"
+	CustomerFieldTags        Field = "tags"
+	CustomerFieldOrdersCount Field = "orders_count"
+	CustomerFieldTotalSpent  Field = "total_spent"
+	CustomerFieldCreatedAt   Field = "created_at"
+	CustomerFieldUpdatedAt   Field = "updated_at"
+)
+
+// UnknownFieldError is returned by Fields for a name which isn't a field of
+// the resource
+type UnknownFieldError struct {
+	Resource string
+	Field    Field
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown %s field %q", e.Resource, e.Field)
+}
+
+// resourceFields caches the json field names of resource types
+var resourceFields sync.Map
+
+// Fields returns the fields option for the resource, e.g.
+// Fields(Order{}, OrderFieldId, OrderFieldTotalPrice) returns
+// "id,total_price", or an UnknownFieldError if a name isn't a json field of
+// the resource.
+func Fields(resource interface{}, fields ...Field) (string, error) {
+	t := reflect.TypeOf(resource)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("fields of %T: resource must be a struct", resource)
+	}
+
+	known, ok := resourceFields.Load(t)
+	if !ok {
+		known, _ = resourceFields.LoadOrStore(t, jsonFieldNames(t))
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !known.(map[string]bool)[string(f)] {
+			return "", UnknownFieldError{Resource: t.Name(), Field: f}
+		}
+		names = append(names, string(f))
+	}
+	return strings.Join(names, ","), nil
+}
+
+// MustFields is like Fields but panics on unknown names. It's meant for
+// options built from constants.
+func MustFields(resource interface{}, fields ...Field) string {
+	s, err := Fields(resource, fields...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// jsonFieldNames returns the json names of the fields of a struct type,
+// including those of embedded structs
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n := range jsonFieldNames(ft) {
+					names[n] = true
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
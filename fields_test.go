@@ -0,0 +1,96 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestFields(t *testing.T) {
+	cases := []struct {
+		resource interface{}
+		fields   []Field
+		expected string
+	}{
+		{Order{}, []Field{OrderFieldId, OrderFieldTotalPrice, OrderFieldLineItems}, "id,total_price,line_items"},
+		{&Product{}, []Field{ProductFieldId, ProductFieldVariants}, "id,variants"},
+		{Variant{}, []Field{VariantFieldSku, VariantFieldInventoryItemId}, "sku,inventory_item_id"},
+		{Customer{}, []Field{CustomerFieldEmail, CustomerFieldTags}, "email,tags"},
+		{Order{}, nil, ""},
+	}
+	for _, c := range cases {
+		fields, err := Fields(c.resource, c.fields...)
+		if err != nil {
+			t.Errorf("Fields(%T, %v) returned error: %v", c.resource, c.fields, err)
+		}
+		if fields != c.expected {
+			t.Errorf("Fields(%T, %v) returned %q, expected %q", c.resource, c.fields, fields, c.expected)
+		}
+	}
+}
+
+func TestFieldsUnknown(t *testing.T) {
+	_, err := Fields(Order{}, OrderFieldId, "totl_price")
+	expected := UnknownFieldError{Resource: "Order", Field: "totl_price"}
+	if err != expected {
+		t.Errorf("Fields returned %v, expected %v", err, expected)
+	}
+
+	// a field of another resource
+	var unknown UnknownFieldError
+	if _, err := Fields(Customer{}, ProductFieldVendor); !errors.As(err, &unknown) || unknown.Field != ProductFieldVendor {
+		t.Errorf("Fields returned %v, expected an UnknownFieldError", err)
+	}
+
+	if _, err := Fields("order", OrderFieldId); err == nil {
+		t.Error("Fields of a string returned no error")
+	}
+}
+
+func TestMustFieldsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustFields of an unknown field didn't panic")
+		}
+	}()
+	MustFields(Product{}, "titel")
+}
+
+func TestFieldsListOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery(
+		"GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		map[string]string{"fields": "id,name"},
+		httpmock.NewStringResponder(200, `{"orders":[{"id":1,"name":"#1001"}]}`))
+
+	options := OrderListOptions{ListOptions: ListOptions{Fields: MustFields(Order{}, OrderFieldId, OrderFieldName)}}
+	orders, err := client.Order.List(context.Background(), options)
+	if err != nil {
+		t.Errorf("Order.List returned error: %v", err)
+	}
+	if len(orders) != 1 || orders[0].Name != "#1001" {
+		t.Errorf("Order.List returned %+v", orders)
+	}
+}
+
+func TestFieldConstantsExist(t *testing.T) {
+	for _, c := range []struct {
+		r interface{}
+		f []Field
+	}{
+		{Order{}, []Field{OrderFieldId, OrderFieldName, OrderFieldEmail, OrderFieldCreatedAt, OrderFieldUpdatedAt, OrderFieldCancelledAt, OrderFieldProcessedAt, OrderFieldCustomer, OrderFieldCurrency, OrderFieldTotalPrice, OrderFieldSubtotalPrice, OrderFieldTotalTax, OrderFieldFinancialStatus, OrderFieldFulfillmentStatus, OrderFieldLineItems, OrderFieldShippingAddress, OrderFieldBillingAddress, OrderFieldRefunds, OrderFieldTags, OrderFieldNote}},
+		{Product{}, []Field{ProductFieldId, ProductFieldTitle, ProductFieldBodyHTML, ProductFieldVendor, ProductFieldProductType, ProductFieldHandle, ProductFieldCreatedAt, ProductFieldUpdatedAt, ProductFieldPublishedAt, ProductFieldTags, ProductFieldStatus, ProductFieldOptions, ProductFieldVariants, ProductFieldImage, ProductFieldImages}},
+		{Variant{}, []Field{VariantFieldId, VariantFieldProductId, VariantFieldTitle, VariantFieldSku, VariantFieldBarcode, VariantFieldPrice, VariantFieldCompareAtPrice, VariantFieldInventoryItemId, VariantFieldInventoryQuantity, VariantFieldUpdatedAt}},
+		{Customer{}, []Field{CustomerFieldId, CustomerFieldEmail, CustomerFieldFirstName, CustomerFieldLastName, CustomerFieldPhone, CustomerFieldState, CustomerFieldTags, CustomerFieldOrdersCount, CustomerFieldTotalSpent, CustomerFieldCreatedAt, CustomerFieldUpdatedAt}},
+	} {
+		if _, err := Fields(c.r, c.f...); err != nil {
+			t.Errorf("%T: %v", c.r, err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// maximum size of a Flow trigger payload accepted by Shopify
+const flowTriggerMaxPayloadSize = 50000
+
+const flowTriggerReceiveMutation = `mutation flowTriggerReceive($handle: String, $payload: JSON) {
+  flowTriggerReceive(handle: $handle, payload: $payload) {
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// FlowService is an interface for interfacing with Shopify Flow through the
+// GraphQL API.
+// See: https://shopify.dev/docs/apps/build/flow/triggers
+type FlowService interface {
+	TriggerReceive(context.Context, string, interface{}) error
+}
+
+// FlowServiceOp handles communication with the Flow related methods of the
+// Shopify API.
+type FlowServiceOp struct {
+	client *Client
+}
+
+// FlowTriggerPayload holds the properties of a Flow trigger, keyed by the
+// property names of the trigger extension. The setters format values the
+// way Flow expects them for each property type.
+type FlowTriggerPayload map[string]interface{}
+
+// NewFlowTriggerPayload returns an empty payload
+func NewFlowTriggerPayload() FlowTriggerPayload {
+	return FlowTriggerPayload{}
+}
+
+// String sets a single line or multi line text property
+func (p FlowTriggerPayload) String(name, value string) FlowTriggerPayload {
+	p[name] = value
+	return p
+}
+
+// Number sets a number property
+func (p FlowTriggerPayload) Number(name string, value decimal.Decimal) FlowTriggerPayload {
+	// a json number rather than the quoted string decimal marshals to
+	p[name] = json.Number(value.String())
+	return p
+}
+
+// Boolean sets a boolean property
+func (p FlowTriggerPayload) Boolean(name string, value bool) FlowTriggerPayload {
+	p[name] = value
+	return p
+}
+
+// Time sets a date or date and time property
+func (p FlowTriggerPayload) Time(name string, value time.Time) FlowTriggerPayload {
+	p[name] = value.UTC().Format(time.RFC3339)
+	return p
+}
+
+// Set sets a property as is, e.g. a list or a nested object
+func (p FlowTriggerPayload) Set(name string, value interface{}) FlowTriggerPayload {
+	p[name] = value
+	return p
+}
+
+// CustomerId sets the customer reference of the trigger
+func (p FlowTriggerPayload) CustomerId(id uint64) FlowTriggerPayload {
+	p["customer_id"] = id
+	return p
+}
+
+// OrderId sets the order reference of the trigger
+func (p FlowTriggerPayload) OrderId(id uint64) FlowTriggerPayload {
+	p["order_id"] = id
+	return p
+}
+
+// ProductId sets the product reference of the trigger
+func (p FlowTriggerPayload) ProductId(id uint64) FlowTriggerPayload {
+	p["product_id"] = id
+	return p
+}
+
+// TriggerReceive fires the Flow trigger with the given handle, which is the
+// handle of the trigger extension. The payload is usually a
+// FlowTriggerPayload but may be anything marshalling to a JSON object.
+func (s *FlowServiceOp) TriggerReceive(ctx context.Context, handle string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if len(data) > flowTriggerMaxPayloadSize {
+		return fmt.Errorf("flow trigger %s payload of %d bytes exceeds %d bytes", handle, len(data), flowTriggerMaxPayloadSize)
+	}
+
+	resp := struct {
+		FlowTriggerReceive struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"flowTriggerReceive"`
+	}{}
+
+	vars := map[string]interface{}{
+		"handle":  handle,
+		"payload": json.RawMessage(data),
+	}
+	err = s.client.GraphQL.Query(ctx, flowTriggerReceiveMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.FlowTriggerReceive.UserErrors) > 0 {
+		return resp.FlowTriggerReceive.UserErrors
+	}
+	return nil
+}
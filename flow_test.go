@@ -0,0 +1,78 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFlowTriggerReceive(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"flowTriggerReceive":{"userErrors":[]}}}`
+	})
+
+	payload := NewFlowTriggerPayload().
+		OrderId(10).
+		CustomerId(7).
+		String("Reason", "points expired").
+		Number("Points", decimal.NewFromFloat(12.5)).
+		Boolean("Vip", true).
+		Time("Expired at", time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("EST", -5*3600))).
+		Set("Tiers", []string{"gold"})
+
+	err := client.Flow.TriggerReceive(context.Background(), "points-expired", payload)
+	if err != nil {
+		t.Fatalf("Flow.TriggerReceive returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"handle": "points-expired",
+		"payload": map[string]interface{}{
+			"order_id":    float64(10),
+			"customer_id": float64(7),
+			"Reason":      "points expired",
+			"Points":      12.5,
+			"Vip":         true,
+			"Expired at":  "2024-01-01T17:00:00Z",
+			"Tiers":       []interface{}{"gold"},
+		},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Flow.TriggerReceive sent %+v, expected %+v", vars, expectedVars)
+	}
+}
+
+func TestFlowTriggerReceiveUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"flowTriggerReceive":{"userErrors":[{"field":["body"],"message":"Errors validating schema"}]}}}`
+	})
+
+	err := client.Flow.TriggerReceive(context.Background(), "points-expired", NewFlowTriggerPayload())
+	if err == nil || err.Error() != "body: Errors validating schema" {
+		t.Errorf("Flow.TriggerReceive err returned %v", err)
+	}
+}
+
+func TestFlowTriggerReceivePayloadTooLarge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	payload := NewFlowTriggerPayload().String("Note", strings.Repeat("a", flowTriggerMaxPayloadSize))
+
+	err := client.Flow.TriggerReceive(context.Background(), "points-expired", payload)
+	if err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("Flow.TriggerReceive err returned %v, expected a payload size error", err)
+	}
+}
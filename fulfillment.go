@@ -18,6 +18,7 @@ type FulfillmentService interface {
 	Complete(context.Context, uint64) (*Fulfillment, error)
 	Transition(context.Context, uint64) (*Fulfillment, error)
 	Cancel(context.Context, uint64) (*Fulfillment, error)
+	CreateOrGet(context.Context, Fulfillment) (*Fulfillment, error)
 }
 
 // FulfillmentsService is an interface for other Shopify resources
@@ -128,6 +129,10 @@ func (s *FulfillmentServiceOp) Get(ctx context.Context, fulfillmentId uint64, op
 
 // Create a new fulfillment
 func (s *FulfillmentServiceOp) Create(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
+	if err := s.client.validateCreate("fulfillment", fulfillment); err != nil {
+		return nil, err
+	}
+
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceId)
 	path := fmt.Sprintf("%s.json", prefix)
 	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
@@ -19,25 +19,44 @@ type FulfillmentEventService interface {
 	Delete(ctx context.Context, orderId uint64, fulfillmentId uint64, eventId uint64) error
 }
 
+// FulfillmentEventStatus is the tracking status reported by a fulfillment
+// event, Shopify notifies the customer for some of them.
+type FulfillmentEventStatus string
+
+const (
+	FulfillmentEventStatusLabelPrinted      FulfillmentEventStatus = "label_printed"
+	FulfillmentEventStatusLabelPurchased    FulfillmentEventStatus = "label_purchased"
+	FulfillmentEventStatusAttemptedDelivery FulfillmentEventStatus = "attempted_delivery"
+	FulfillmentEventStatusReadyForPickup    FulfillmentEventStatus = "ready_for_pickup"
+	FulfillmentEventStatusPickedUp          FulfillmentEventStatus = "picked_up"
+	FulfillmentEventStatusConfirmed         FulfillmentEventStatus = "confirmed"
+	FulfillmentEventStatusInTransit         FulfillmentEventStatus = "in_transit"
+	FulfillmentEventStatusOutForDelivery    FulfillmentEventStatus = "out_for_delivery"
+	FulfillmentEventStatusDelivered         FulfillmentEventStatus = "delivered"
+	FulfillmentEventStatusDelayed           FulfillmentEventStatus = "delayed"
+	FulfillmentEventStatusFailure           FulfillmentEventStatus = "failure"
+	FulfillmentEventStatusCarrierPickedUp   FulfillmentEventStatus = "carrier_picked_up"
+)
+
 // FulfillmentEvent represents a Shopify fulfillment event.
 type FulfillmentEvent struct {
-	Id                  uint64  `json:"id"`
-	Address1            string  `json:"address1"`
-	City                string  `json:"city"`
-	Country             string  `json:"country"`
-	CreatedAt           string  `json:"created_at"`
-	EstimatedDeliveryAt string  `json:"estimated_delivery_at"`
-	FulfillmentId       uint64  `json:"fulfillment_id"`
-	HappenedAt          string  `json:"happened_at"`
-	Latitude            float64 `json:"latitude"`
-	Longitude           float64 `json:"longitude"`
-	Message             string  `json:"message"`
-	OrderId             uint64  `json:"order_id"`
-	Province            string  `json:"province"`
-	ShopId              uint64  `json:"shop_id"`
-	Status              string  `json:"status"`
-	UpdatedAt           string  `json:"updated_at"`
-	Zip                 string  `json:"zip"`
+	Id                  uint64                 `json:"id,omitempty"`
+	Address1            string                 `json:"address1,omitempty"`
+	City                string                 `json:"city,omitempty"`
+	Country             string                 `json:"country,omitempty"`
+	CreatedAt           string                 `json:"created_at,omitempty"`
+	EstimatedDeliveryAt string                 `json:"estimated_delivery_at,omitempty"`
+	FulfillmentId       uint64                 `json:"fulfillment_id,omitempty"`
+	HappenedAt          string                 `json:"happened_at,omitempty"`
+	Latitude            *float64               `json:"latitude,omitempty"`
+	Longitude           *float64               `json:"longitude,omitempty"`
+	Message             string                 `json:"message,omitempty"`
+	OrderId             uint64                 `json:"order_id,omitempty"`
+	Province            string                 `json:"province,omitempty"`
+	ShopId              uint64                 `json:"shop_id,omitempty"`
+	Status              FulfillmentEventStatus `json:"status,omitempty"`
+	UpdatedAt           string                 `json:"updated_at,omitempty"`
+	Zip                 string                 `json:"zip,omitempty"`
 }
 
 type FulfillmentEventCreateRequest struct {
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -39,8 +40,6 @@ func TestFulfillmentEventServiceOp_List(t *testing.T) {
 			Country:             "",
 			Zip:                 "",
 			Address1:            "",
-			Latitude:            0,
-			Longitude:           0,
 			ShopId:              548380009,
 			CreatedAt:           "2023-10-20T23:39:23-04:00",
 			UpdatedAt:           "2023-10-20T23:39:23-04:00",
@@ -83,8 +82,6 @@ func TestFulfillmentEventServiceOp_Get(t *testing.T) {
 		Country:             "",
 		Zip:                 "",
 		Address1:            "",
-		Latitude:            0,
-		Longitude:           0,
 		ShopId:              548380009,
 		CreatedAt:           "2023-10-20T23:39:27-04:00",
 		UpdatedAt:           "2023-10-20T23:39:27-04:00",
@@ -120,8 +117,6 @@ func TestFulfillmentEventServiceOp_Create(t *testing.T) {
 		Country:             "",
 		Zip:                 "",
 		Address1:            "",
-		Latitude:            0,
-		Longitude:           0,
 		ShopId:              548380009,
 		CreatedAt:           "2023-10-20T23:39:27-04:00",
 		UpdatedAt:           "2023-10-20T23:39:27-04:00",
@@ -144,8 +139,6 @@ func TestFulfillmentEventServiceOp_Create(t *testing.T) {
 		Country:             "",
 		Zip:                 "",
 		Address1:            "",
-		Latitude:            0,
-		Longitude:           0,
 		ShopId:              548380009,
 		CreatedAt:           "2023-10-20T23:39:27-04:00",
 		UpdatedAt:           "2023-10-20T23:39:27-04:00",
@@ -157,6 +150,62 @@ func TestFulfillmentEventServiceOp_Create(t *testing.T) {
 	}
 }
 
+func TestFulfillmentEventServiceOp_CreateOmitsEmptyFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orderId := uint64(1234567890)
+	fulfillmentId := uint64(987654321)
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/%d/fulfillments/%d/events.json", client.pathPrefix, orderId, fulfillmentId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			expected := `{"event":{"city":"Ottawa","status":"out_for_delivery"}}`
+			if string(body) != expected {
+				t.Errorf("FulfillmentEvent.Create sent %s, expected %s", body, expected)
+			}
+			return httpmock.NewBytesResponse(201, loadFixture("fulfillment_event.json")), nil
+		},
+	)
+
+	event := FulfillmentEvent{Status: FulfillmentEventStatusOutForDelivery, City: "Ottawa"}
+	_, err := client.FulfillmentEvent.Create(context.Background(), orderId, fulfillmentId, event)
+	if err != nil {
+		t.Errorf("FulfillmentEvent.Create returned error: %v", err)
+	}
+}
+
+func TestFulfillmentEventServiceOp_CreateZeroCoordinates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orderId := uint64(1234567890)
+	fulfillmentId := uint64(987654321)
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/%d/fulfillments/%d/events.json", client.pathPrefix, orderId, fulfillmentId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			expected := `{"event":{"latitude":0,"longitude":-78.5,"status":"in_transit"}}`
+			if string(body) != expected {
+				t.Errorf("FulfillmentEvent.Create sent %s, expected %s", body, expected)
+			}
+			return httpmock.NewBytesResponse(201, loadFixture("fulfillment_event.json")), nil
+		},
+	)
+
+	// on the equator
+	latitude, longitude := 0.0, -78.5
+	event := FulfillmentEvent{Status: FulfillmentEventStatusInTransit, Latitude: &latitude, Longitude: &longitude}
+	_, err := client.FulfillmentEvent.Create(context.Background(), orderId, fulfillmentId, event)
+	if err != nil {
+		t.Errorf("FulfillmentEvent.Create returned error: %v", err)
+	}
+}
+
 func TestFulfillmentEventServiceOp_Delete(t *testing.T) {
 	setup()
 	defer teardown()
@@ -11,6 +11,8 @@ import (
 // https://shopify.dev/docs/api/admin-rest/2023-01/resources/fulfillmentorder#resource-object
 type FulfillmentOrderService interface {
 	List(context.Context, uint64, interface{}) ([]FulfillmentOrder, error)
+	ListOnHold(context.Context, uint64, interface{}) ([]FulfillmentOrder, error)
+	ListScheduled(context.Context, uint64, interface{}) ([]FulfillmentOrder, error)
 	Get(context.Context, uint64, interface{}) (*FulfillmentOrder, error)
 	Cancel(context.Context, uint64) (*FulfillmentOrder, error)
 	Close(context.Context, uint64, string) (*FulfillmentOrder, error)
@@ -18,8 +20,11 @@ type FulfillmentOrderService interface {
 	Open(context.Context, uint64) (*FulfillmentOrder, error)
 	ReleaseHold(context.Context, uint64) (*FulfillmentOrder, error)
 	Reschedule(context.Context, uint64) (*FulfillmentOrder, error)
+	RescheduleTo(context.Context, uint64, time.Time) (*FulfillmentOrder, error)
 	SetDeadline(context.Context, []uint64, time.Time) error
 	Move(context.Context, uint64, FulfillmentOrderMoveRequest) (*FulfillmentOrderMoveResource, error)
+	LocationsForMove(context.Context, uint64, interface{}) ([]FulfillmentOrderLocationForMove, error)
+	DeliveryEstimates(context.Context, uint64) ([]FulfillmentOrderDeliveryEstimate, error)
 }
 
 // FulfillmentOrderHoldReason represents the reason for a fulfillment hold
@@ -33,6 +38,19 @@ const (
 	HoldReasonOther            FulfillmentOrderHoldReason = "other"
 )
 
+// FulfillmentOrderStatus represents the status of a FulfillmentOrder
+type FulfillmentOrderStatus string
+
+const (
+	FulfillmentOrderStatusOpen       FulfillmentOrderStatus = "open"
+	FulfillmentOrderStatusInProgress FulfillmentOrderStatus = "in_progress"
+	FulfillmentOrderStatusCancelled  FulfillmentOrderStatus = "cancelled"
+	FulfillmentOrderStatusIncomplete FulfillmentOrderStatus = "incomplete"
+	FulfillmentOrderStatusClosed     FulfillmentOrderStatus = "closed"
+	FulfillmentOrderStatusScheduled  FulfillmentOrderStatus = "scheduled"
+	FulfillmentOrderStatusOnHold     FulfillmentOrderStatus = "on_hold"
+)
+
 // FulfillmentOrderServiceOp handles communication with the fulfillment order
 // related methods of the Shopify API.
 type FulfillmentOrderServiceOp struct {
@@ -49,12 +67,47 @@ type FulfillmentOrderMoveRequest struct {
 	LineItems     []FulfillmentOrderLineItemQuantity `json:"fulfillment_order_line_items,omitempty"`
 }
 
+// FulfillmentOrderDeliveryMethodType is the way a fulfillment order reaches
+// the customer
+type FulfillmentOrderDeliveryMethodType string
+
+const (
+	FulfillmentOrderDeliveryMethodTypeShipping    FulfillmentOrderDeliveryMethodType = "shipping"
+	FulfillmentOrderDeliveryMethodTypeLocal       FulfillmentOrderDeliveryMethodType = "local"
+	FulfillmentOrderDeliveryMethodTypePickUp      FulfillmentOrderDeliveryMethodType = "pick_up"
+	FulfillmentOrderDeliveryMethodTypePickupPoint FulfillmentOrderDeliveryMethodType = "pickup_point"
+	FulfillmentOrderDeliveryMethodTypeRetail      FulfillmentOrderDeliveryMethodType = "retail"
+	FulfillmentOrderDeliveryMethodTypeNone        FulfillmentOrderDeliveryMethodType = "none"
+)
+
 // FulfillmentOrderDeliveryMethod represents a delivery method for a FulfillmentOrder
 type FulfillmentOrderDeliveryMethod struct {
-	Id                  uint64    `json:"id,omitempty"`
-	MethodType          string    `json:"method_type,omitempty"`
-	MinDeliveryDateTime time.Time `json:"min_delivery_date_time,omitempty"`
-	MaxDeliveryDateTime time.Time `json:"max_delivery_date_time,omitempty"`
+	Id                  uint64                             `json:"id,omitempty"`
+	MethodType          FulfillmentOrderDeliveryMethodType `json:"method_type,omitempty"`
+	MinDeliveryDateTime time.Time                          `json:"min_delivery_date_time,omitempty"`
+	MaxDeliveryDateTime time.Time                          `json:"max_delivery_date_time,omitempty"`
+	PresentedName       string                             `json:"presented_name,omitempty"`
+	ServiceCode         string                             `json:"service_code,omitempty"`
+	SourceReference     string                             `json:"source_reference,omitempty"`
+
+	AdditionalInformation *FulfillmentOrderDeliveryMethodAdditionalInformation `json:"additional_information,omitempty"`
+
+	// BrandedPromise is the delivery promise shown to the customer at
+	// checkout, e.g. Shop Promise
+	BrandedPromise *FulfillmentOrderBrandedPromise `json:"branded_promise,omitempty"`
+}
+
+// FulfillmentOrderBrandedPromise is a branded delivery promise
+type FulfillmentOrderBrandedPromise struct {
+	Handle string `json:"handle,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// FulfillmentOrderDeliveryMethodAdditionalInformation holds the customer's
+// instructions for a local delivery or pickup
+type FulfillmentOrderDeliveryMethodAdditionalInformation struct {
+	Instructions string `json:"instructions,omitempty"`
+	Phone        string `json:"phone,omitempty"`
 }
 
 // FulfillmentOrderDestination represents a destination for a FulfillmentOrder
@@ -71,12 +124,15 @@ type FulfillmentOrderDestination struct {
 	Phone     string `json:"phone,omitempty"`
 	Province  string `json:"province,omitempty"`
 	Zip       string `json:"zip,omitempty"`
+
+	CountryCode string `json:"country_code,omitempty"`
 }
 
 // FulfillmentOrderHold represents a fulfillment hold for a FulfillmentOrder
 type FulfillmentOrderHold struct {
-	Reason      FulfillmentOrderHoldReason `json:"reason,omitempty"`
-	ReasonNotes string                     `json:"reason_notes,omitempty"`
+	Reason              FulfillmentOrderHoldReason `json:"reason,omitempty"`
+	ReasonNotes         string                     `json:"reason_notes,omitempty"`
+	HeldByRequestingApp bool                       `json:"held_by_requesting_app,omitempty"`
 }
 
 // FulfillmentOrderInternationalDuties represents an InternationalDuty for a FulfillmentOrder
@@ -136,12 +192,56 @@ type FulfillmentOrder struct {
 	OrderId             uint64                              `json:"order_id,omitempty"`
 	RequestStatus       string                              `json:"request_status,omitempty"`
 	ShopId              uint64                              `json:"shop_id,omitempty"`
-	Status              string                              `json:"status,omitempty"`
+	Status              FulfillmentOrderStatus              `json:"status,omitempty"`
 	SupportedActions    []string                            `json:"supported_actions,omitempty"`
 	CreatedAt           *time.Time                          `json:"created_at,omitempty"`
 	UpdatedAt           *time.Time                          `json:"updated_at,omitempty"`
 }
 
+// IsOnHold returns whether the fulfillment order is on hold, fulfillment
+// services must not ship it until the hold is released.
+func (fo FulfillmentOrder) IsOnHold() bool {
+	return fo.Status == FulfillmentOrderStatusOnHold || len(fo.FulfillmentHolds) > 0
+}
+
+// IsPickUp returns whether the customer picks the fulfillment order up at a
+// location, see PickUpLocation.
+func (fo FulfillmentOrder) IsPickUp() bool {
+	return fo.DeliveryMethod.MethodType == FulfillmentOrderDeliveryMethodTypePickUp
+}
+
+// IsLocalDelivery returns whether the merchant delivers the fulfillment order
+// locally.
+func (fo FulfillmentOrder) IsLocalDelivery() bool {
+	return fo.DeliveryMethod.MethodType == FulfillmentOrderDeliveryMethodTypeLocal
+}
+
+// IsShipping returns whether the fulfillment order is shipped to the
+// customer, either to the Destination or to a pickup point.
+func (fo FulfillmentOrder) IsShipping() bool {
+	switch fo.DeliveryMethod.MethodType {
+	case FulfillmentOrderDeliveryMethodTypeShipping, FulfillmentOrderDeliveryMethodTypePickupPoint:
+		return true
+	}
+	return false
+}
+
+// PickUpLocation returns the location the customer picks the fulfillment
+// order up at, or nil if it isn't picked up.
+func (fo FulfillmentOrder) PickUpLocation() *FulfillmentOrderAssignedLocation {
+	if !fo.IsPickUp() {
+		return nil
+	}
+	location := fo.AssignedLocation
+	return &location
+}
+
+// IsScheduled returns whether the fulfillment order is scheduled, i.e. it
+// becomes open at FulfillAt.
+func (fo FulfillmentOrder) IsScheduled() bool {
+	return fo.Status == FulfillmentOrderStatusScheduled
+}
+
 // FulfillmentOrdersResource represents the result from the fulfillment_orders.json endpoint
 type FulfillmentOrdersResource struct {
 	FulfillmentOrders []FulfillmentOrder `json:"fulfillment_orders"`
@@ -158,6 +258,22 @@ type FulfillmentOrderMoveResource struct {
 	MovedFulfillmentOrder    FulfillmentOrder `json:"moved_fulfillment_order"`
 }
 
+// FulfillmentOrderLocationForMove represents a location a fulfillment order
+// could be moved to. Message explains why the location is not Movable.
+type FulfillmentOrderLocationForMove struct {
+	Location struct {
+		Id   uint64 `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	} `json:"location"`
+	Message string `json:"message,omitempty"`
+	Movable bool   `json:"movable"`
+}
+
+// FulfillmentOrderLocationsForMoveResource represents the result from the locations_for_move.json endpoint
+type FulfillmentOrderLocationsForMoveResource struct {
+	LocationsForMove []FulfillmentOrderLocationForMove `json:"locations_for_move"`
+}
+
 // FulfillmentOrderPathPrefix returns the prefix for a fulfillmentOrder path
 func FulfillmentOrderPathPrefix(resource string, resourceId uint64) string {
 	return fmt.Sprintf("%s/%d", resource, resourceId)
@@ -172,6 +288,31 @@ func (s *FulfillmentOrderServiceOp) List(ctx context.Context, orderId uint64, op
 	return resource.FulfillmentOrders, err
 }
 
+// ListOnHold gets the FulfillmentOrder items for an order which are on hold
+func (s *FulfillmentOrderServiceOp) ListOnHold(ctx context.Context, orderId uint64, options interface{}) ([]FulfillmentOrder, error) {
+	return s.listWhere(ctx, orderId, options, FulfillmentOrder.IsOnHold)
+}
+
+// ListScheduled gets the scheduled FulfillmentOrder items for an order
+func (s *FulfillmentOrderServiceOp) ListScheduled(ctx context.Context, orderId uint64, options interface{}) ([]FulfillmentOrder, error) {
+	return s.listWhere(ctx, orderId, options, FulfillmentOrder.IsScheduled)
+}
+
+func (s *FulfillmentOrderServiceOp) listWhere(ctx context.Context, orderId uint64, options interface{}, keep func(FulfillmentOrder) bool) ([]FulfillmentOrder, error) {
+	fulfillmentOrders, err := s.List(ctx, orderId, options)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []FulfillmentOrder{}
+	for _, fo := range fulfillmentOrders {
+		if keep(fo) {
+			filtered = append(filtered, fo)
+		}
+	}
+	return filtered, nil
+}
+
 // Get gets an individual fulfillment order
 func (s *FulfillmentOrderServiceOp) Get(ctx context.Context, fulfillmentId uint64, options interface{}) (*FulfillmentOrder, error) {
 	prefix := FulfillmentOrderPathPrefix("fulfillment_orders", fulfillmentId)
@@ -254,6 +395,22 @@ func (s *FulfillmentOrderServiceOp) Reschedule(ctx context.Context, fulfillmentI
 	return resource.FulfillmentOrder, err
 }
 
+// RescheduleTo sets the fulfill_at time of a scheduled fulfillment order
+func (s *FulfillmentOrderServiceOp) RescheduleTo(ctx context.Context, fulfillmentId uint64, fulfillAt time.Time) (*FulfillmentOrder, error) {
+	req := struct {
+		FulfillmentOrder struct {
+			NewFulfillAt time.Time `json:"new_fulfill_at"`
+		} `json:"fulfillment_order"`
+	}{}
+	req.FulfillmentOrder.NewFulfillAt = fulfillAt
+
+	prefix := FulfillmentOrderPathPrefix("fulfillment_orders", fulfillmentId)
+	path := fmt.Sprintf("%s/reschedule.json", prefix)
+	resource := new(FulfillmentOrderResource)
+	err := s.client.Post(ctx, path, req, resource)
+	return resource.FulfillmentOrder, err
+}
+
 // SetDeadline sets deadline for fulfillment orders
 func (s *FulfillmentOrderServiceOp) SetDeadline(ctx context.Context, fulfillmentIds []uint64, deadline time.Time) error {
 	req := struct {
@@ -282,3 +439,13 @@ func (s *FulfillmentOrderServiceOp) Move(ctx context.Context, fulfillmentId uint
 	err := s.client.Post(ctx, path, wrappedRequest, resource)
 	return resource, err
 }
+
+// LocationsForMove lists the locations a fulfillment order can potentially be
+// moved to, with the reason for those it can't be moved to.
+func (s *FulfillmentOrderServiceOp) LocationsForMove(ctx context.Context, fulfillmentId uint64, options interface{}) ([]FulfillmentOrderLocationForMove, error) {
+	prefix := FulfillmentOrderPathPrefix("fulfillment_orders", fulfillmentId)
+	path := fmt.Sprintf("%s/locations_for_move.json", prefix)
+	resource := new(FulfillmentOrderLocationsForMoveResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.LocationsForMove, err
+}
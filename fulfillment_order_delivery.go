@@ -0,0 +1,243 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const fulfillmentOrderDeliveriesQuery = `query fulfillmentOrderDeliveries($id: ID!, $after: String) {
+  order(id: $id) {
+    fulfillmentOrders(first: 50, after: $after) {
+      nodes {
+        id
+        status
+        fulfillAt
+        fulfillBy
+        internationalDuties {
+          incoterm
+        }
+        destination {
+          id
+          address1
+          address2
+          city
+          company
+          countryCode
+          email
+          firstName
+          lastName
+          phone
+          province
+          zip
+        }
+        deliveryMethod {
+          id
+          methodType
+          minDeliveryDateTime
+          maxDeliveryDateTime
+          presentedName
+          serviceCode
+          sourceReference
+          brandedPromise {
+            handle
+            name
+          }
+        }
+        fulfillments(first: 20) {
+          nodes {
+            id
+            displayStatus
+            estimatedDeliveryAt
+            inTransitAt
+            deliveredAt
+          }
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// FulfillmentOrderDeliveryEstimate is where and when a fulfillment order of
+// an order is expected to be delivered
+type FulfillmentOrderDeliveryEstimate struct {
+	FulfillmentOrderId  uint64
+	Status              FulfillmentOrderStatus
+	FulfillAt           *time.Time
+	FulfillBy           *time.Time
+	Destination         *FulfillmentOrderDestination
+	InternationalDuties *FulfillmentOrderInternationalDuties
+	DeliveryMethod      FulfillmentOrderDeliveryMethod
+	// Shipments are the fulfillments made for the fulfillment order
+	Shipments []FulfillmentDeliveryEstimate
+}
+
+// FulfillmentDeliveryEstimate are the delivery dates of a fulfillment
+// reported by the carrier
+type FulfillmentDeliveryEstimate struct {
+	FulfillmentId       uint64
+	DisplayStatus       string
+	EstimatedDeliveryAt *time.Time
+	InTransitAt         *time.Time
+	DeliveredAt         *time.Time
+}
+
+// DeliveryWindow returns the delivery dates promised at checkout, nil when
+// there are none
+func (m FulfillmentOrderDeliveryMethod) DeliveryWindow() (min, max *time.Time) {
+	if !m.MinDeliveryDateTime.IsZero() {
+		t := m.MinDeliveryDateTime
+		min = &t
+	}
+	if !m.MaxDeliveryDateTime.IsZero() {
+		t := m.MaxDeliveryDateTime
+		max = &t
+	}
+	return min, max
+}
+
+// ExpectedDelivery returns the dates the fulfillment order is expected to be
+// delivered between. The carrier estimates of its shipments are preferred
+// over the window promised at checkout, both are nil when nothing is known.
+func (e FulfillmentOrderDeliveryEstimate) ExpectedDelivery() (earliest, latest *time.Time) {
+	for _, s := range e.Shipments {
+		at := s.DeliveredAt
+		if at == nil {
+			at = s.EstimatedDeliveryAt
+		}
+		if at == nil {
+			continue
+		}
+		if earliest == nil || at.Before(*earliest) {
+			earliest = at
+		}
+		if latest == nil || at.After(*latest) {
+			latest = at
+		}
+	}
+	if earliest != nil {
+		return earliest, latest
+	}
+	return e.DeliveryMethod.DeliveryWindow()
+}
+
+type graphQLFulfillmentOrderDelivery struct {
+	Id                  string                               `json:"id"`
+	Status              string                               `json:"status"`
+	FulfillAt           *time.Time                           `json:"fulfillAt"`
+	FulfillBy           *time.Time                           `json:"fulfillBy"`
+	InternationalDuties *FulfillmentOrderInternationalDuties `json:"internationalDuties"`
+	Destination         *struct {
+		Id          string `json:"id"`
+		Address1    string `json:"address1"`
+		Address2    string `json:"address2"`
+		City        string `json:"city"`
+		Company     string `json:"company"`
+		CountryCode string `json:"countryCode"`
+		Email       string `json:"email"`
+		FirstName   string `json:"firstName"`
+		LastName    string `json:"lastName"`
+		Phone       string `json:"phone"`
+		Province    string `json:"province"`
+		Zip         string `json:"zip"`
+	} `json:"destination"`
+	DeliveryMethod *struct {
+		Id                  string                          `json:"id"`
+		MethodType          string                          `json:"methodType"`
+		MinDeliveryDateTime *time.Time                      `json:"minDeliveryDateTime"`
+		MaxDeliveryDateTime *time.Time                      `json:"maxDeliveryDateTime"`
+		PresentedName       string                          `json:"presentedName"`
+		ServiceCode         string                          `json:"serviceCode"`
+		SourceReference     string                          `json:"sourceReference"`
+		BrandedPromise      *FulfillmentOrderBrandedPromise `json:"brandedPromise"`
+	} `json:"deliveryMethod"`
+	Fulfillments struct {
+		Nodes []struct {
+			Id                  string     `json:"id"`
+			DisplayStatus       string     `json:"displayStatus"`
+			EstimatedDeliveryAt *time.Time `json:"estimatedDeliveryAt"`
+			InTransitAt         *time.Time `json:"inTransitAt"`
+			DeliveredAt         *time.Time `json:"deliveredAt"`
+		} `json:"nodes"`
+	} `json:"fulfillments"`
+}
+
+func (g graphQLFulfillmentOrderDelivery) estimate() FulfillmentOrderDeliveryEstimate {
+	e := FulfillmentOrderDeliveryEstimate{
+		Status:              FulfillmentOrderStatus(strings.ToLower(g.Status)),
+		FulfillAt:           g.FulfillAt,
+		FulfillBy:           g.FulfillBy,
+		InternationalDuties: g.InternationalDuties,
+	}
+	e.FulfillmentOrderId, _ = ParseGraphQLId(g.Id)
+
+	if d := g.Destination; d != nil {
+		e.Destination = &FulfillmentOrderDestination{
+			Address1:    d.Address1,
+			Address2:    d.Address2,
+			City:        d.City,
+			Company:     d.Company,
+			CountryCode: d.CountryCode,
+			Email:       d.Email,
+			FirstName:   d.FirstName,
+			LastName:    d.LastName,
+			Phone:       d.Phone,
+			Province:    d.Province,
+			Zip:         d.Zip,
+		}
+		e.Destination.Id, _ = ParseGraphQLId(d.Id)
+	}
+
+	if m := g.DeliveryMethod; m != nil {
+		e.DeliveryMethod = FulfillmentOrderDeliveryMethod{
+			MethodType:      FulfillmentOrderDeliveryMethodType(strings.ToLower(m.MethodType)),
+			PresentedName:   m.PresentedName,
+			ServiceCode:     m.ServiceCode,
+			SourceReference: m.SourceReference,
+			BrandedPromise:  m.BrandedPromise,
+		}
+		e.DeliveryMethod.Id, _ = ParseGraphQLId(m.Id)
+		if m.MinDeliveryDateTime != nil {
+			e.DeliveryMethod.MinDeliveryDateTime = *m.MinDeliveryDateTime
+		}
+		if m.MaxDeliveryDateTime != nil {
+			e.DeliveryMethod.MaxDeliveryDateTime = *m.MaxDeliveryDateTime
+		}
+	}
+
+	for _, f := range g.Fulfillments.Nodes {
+		shipment := FulfillmentDeliveryEstimate{
+			DisplayStatus:       strings.ToLower(f.DisplayStatus),
+			EstimatedDeliveryAt: f.EstimatedDeliveryAt,
+			InTransitAt:         f.InTransitAt,
+			DeliveredAt:         f.DeliveredAt,
+		}
+		shipment.FulfillmentId, _ = ParseGraphQLId(f.Id)
+		e.Shipments = append(e.Shipments, shipment)
+	}
+	return e
+}
+
+// DeliveryEstimates returns the destination, international duties and the
+// expected delivery dates of the fulfillment orders of an order, read
+// through GraphQL to include the branded delivery promise and the carrier
+// estimates of its shipments
+func (s *FulfillmentOrderServiceOp) DeliveryEstimates(ctx context.Context, orderId uint64) ([]FulfillmentOrderDeliveryEstimate, error) {
+	vars := map[string]interface{}{"id": GraphQLId("Order", orderId)}
+
+	var estimates []FulfillmentOrderDeliveryEstimate
+	err := s.client.GraphQLEachNode(ctx, fulfillmentOrderDeliveriesQuery, vars, "order.fulfillmentOrders", func(node json.RawMessage) error {
+		var fo graphQLFulfillmentOrderDelivery
+		if err := json.Unmarshal(node, &fo); err != nil {
+			return err
+		}
+		estimates = append(estimates, fo.estimate())
+		return nil
+	})
+	return estimates, err
+}
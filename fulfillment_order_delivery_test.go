@@ -0,0 +1,92 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFulfillmentOrderDeliveryEstimates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"": `{"data":{"order":{"fulfillmentOrders":{
+			"nodes":[
+				{"id":"gid://shopify/FulfillmentOrder/1","status":"IN_PROGRESS","fulfillBy":"2024-03-02T00:00:00Z",
+				 "internationalDuties":{"incoterm":"DDP"},
+				 "destination":{"id":"gid://shopify/FulfillmentOrderDestination/5","address1":"123 Main St","city":"Ottawa","countryCode":"CA","province":"Ontario","zip":"K2P0V6","firstName":"Bob"},
+				 "deliveryMethod":{"id":"gid://shopify/DeliveryMethod/6","methodType":"SHIPPING","presentedName":"Express",
+				   "minDeliveryDateTime":"2024-03-04T00:00:00Z","maxDeliveryDateTime":"2024-03-06T00:00:00Z",
+				   "brandedPromise":{"handle":"shop_promise","name":"Shop Promise"}},
+				 "fulfillments":{"nodes":[
+				   {"id":"gid://shopify/Fulfillment/7","displayStatus":"IN_TRANSIT","inTransitAt":"2024-03-02T12:00:00Z","estimatedDeliveryAt":"2024-03-05T00:00:00Z"}
+				 ]}}
+			],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+		"abc": `{"data":{"order":{"fulfillmentOrders":{
+			"nodes":[
+				{"id":"gid://shopify/FulfillmentOrder/2","status":"OPEN","destination":null,
+				 "deliveryMethod":{"id":"gid://shopify/DeliveryMethod/8","methodType":"PICK_UP","minDeliveryDateTime":null,"maxDeliveryDateTime":null},
+				 "fulfillments":{"nodes":[]}}
+			],
+			"pageInfo":{"hasNextPage":false}}}}}`,
+	}))
+
+	estimates, err := client.FulfillmentOrder.DeliveryEstimates(context.Background(), 450789469)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.DeliveryEstimates returned error: %v", err)
+	}
+	if len(sent) != 2 || sent[0].Variables["id"] != "gid://shopify/Order/450789469" {
+		t.Errorf("FulfillmentOrder.DeliveryEstimates sent %v", sent)
+	}
+	if len(estimates) != 2 {
+		t.Fatalf("FulfillmentOrder.DeliveryEstimates returned %d estimates, expected 2", len(estimates))
+	}
+
+	shipped := estimates[0]
+	if shipped.FulfillmentOrderId != 1 || shipped.Status != FulfillmentOrderStatusInProgress || shipped.InternationalDuties.IncoTerm != "DDP" {
+		t.Errorf("FulfillmentOrder.DeliveryEstimates returned %+v", shipped)
+	}
+	expectedDestination := &FulfillmentOrderDestination{Id: 5, Address1: "123 Main St", City: "Ottawa", CountryCode: "CA", Province: "Ontario", Zip: "K2P0V6", FirstName: "Bob"}
+	if !reflect.DeepEqual(shipped.Destination, expectedDestination) {
+		t.Errorf("FulfillmentOrder.DeliveryEstimates returned destination %+v, expected %+v", shipped.Destination, expectedDestination)
+	}
+	method := shipped.DeliveryMethod
+	if method.Id != 6 || method.MethodType != FulfillmentOrderDeliveryMethodTypeShipping || method.BrandedPromise == nil || method.BrandedPromise.Name != "Shop Promise" {
+		t.Errorf("FulfillmentOrder.DeliveryEstimates returned delivery method %+v", method)
+	}
+	if len(shipped.Shipments) != 1 || shipped.Shipments[0].FulfillmentId != 7 || shipped.Shipments[0].DisplayStatus != "in_transit" {
+		t.Errorf("FulfillmentOrder.DeliveryEstimates returned shipments %+v", shipped.Shipments)
+	}
+
+	earliest, latest := shipped.ExpectedDelivery()
+	estimated := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	if earliest == nil || !earliest.Equal(estimated) || latest == nil || !latest.Equal(estimated) {
+		t.Errorf("ExpectedDelivery returned %v - %v, expected the carrier estimate %v", earliest, latest, estimated)
+	}
+
+	pickup := estimates[1]
+	if pickup.Destination != nil || pickup.DeliveryMethod.MethodType != FulfillmentOrderDeliveryMethodTypePickUp {
+		t.Errorf("FulfillmentOrder.DeliveryEstimates returned %+v", pickup)
+	}
+	if earliest, latest := pickup.ExpectedDelivery(); earliest != nil || latest != nil {
+		t.Errorf("ExpectedDelivery returned %v - %v without any dates", earliest, latest)
+	}
+}
+
+func TestFulfillmentOrderDeliveryWindow(t *testing.T) {
+	min := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	max := min.AddDate(0, 0, 2)
+	estimate := FulfillmentOrderDeliveryEstimate{
+		DeliveryMethod: FulfillmentOrderDeliveryMethod{MinDeliveryDateTime: min, MaxDeliveryDateTime: max},
+		Shipments:      []FulfillmentDeliveryEstimate{{FulfillmentId: 1, DisplayStatus: "confirmed"}},
+	}
+
+	earliest, latest := estimate.ExpectedDelivery()
+	if earliest == nil || !earliest.Equal(min) || latest == nil || !latest.Equal(max) {
+		t.Errorf("ExpectedDelivery returned %v - %v, expected the promised window %v - %v", earliest, latest, min, max)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -39,6 +41,45 @@ func TestFulfillmentOrderList(t *testing.T) {
 	}
 }
 
+func TestFulfillmentOrderListOnHoldAndScheduled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillment_orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_orders": [
+			{"id":1,"status":"open"},
+			{"id":2,"status":"on_hold","fulfillment_holds":[{"reason":"awaiting_payment","reason_notes":"wire"}]},
+			{"id":3,"status":"scheduled","fulfill_at":"2024-01-01T00:00:00Z"}
+		]}`))
+
+	fulfillmentOrderService := &FulfillmentOrderServiceOp{client: client}
+
+	onHold, err := fulfillmentOrderService.ListOnHold(context.Background(), 123, nil)
+	if err != nil {
+		t.Errorf("FulfillmentOrder.ListOnHold returned error: %v", err)
+	}
+
+	expectedOnHold := []FulfillmentOrder{{
+		Id:     2,
+		Status: FulfillmentOrderStatusOnHold,
+		FulfillmentHolds: []FulfillmentOrderHold{
+			{Reason: HoldReasonAwaitingPayment, ReasonNotes: "wire"},
+		},
+	}}
+	if !reflect.DeepEqual(onHold, expectedOnHold) {
+		t.Errorf("FulfillmentOrder.ListOnHold returned %+v, expected %+v", onHold, expectedOnHold)
+	}
+
+	scheduled, err := fulfillmentOrderService.ListScheduled(context.Background(), 123, nil)
+	if err != nil {
+		t.Errorf("FulfillmentOrder.ListScheduled returned error: %v", err)
+	}
+
+	if len(scheduled) != 1 || scheduled[0].Id != 3 || scheduled[0].FulfillAt == nil {
+		t.Errorf("FulfillmentOrder.ListScheduled returned %+v, expected fulfillment order 3", scheduled)
+	}
+}
+
 func TestFulfillmentOrderGet(t *testing.T) {
 	setup()
 	defer teardown()
@@ -65,6 +106,45 @@ func TestFulfillmentOrderGet(t *testing.T) {
 	}
 }
 
+func TestFulfillmentOrderDeliveryMethod(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/123/fulfillment_orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_orders": [
+			{"id":1,"delivery_method":{"method_type":"shipping","presented_name":"Standard","service_code":"standard"}},
+			{"id":2,"assigned_location":{"location_id":5,"name":"Downtown store"},"delivery_method":{"method_type":"pick_up","presented_name":"Pickup","additional_information":{"instructions":"Side door","phone":"555-1234"}}},
+			{"id":3,"delivery_method":{"method_type":"local","min_delivery_date_time":"2024-01-01T09:00:00Z","max_delivery_date_time":"2024-01-01T17:00:00Z"}}
+		]}`))
+
+	fulfillmentOrderService := &FulfillmentOrderServiceOp{client: client}
+
+	fulfillmentOrders, err := fulfillmentOrderService.List(context.Background(), 123, nil)
+	if err != nil {
+		t.Fatalf("FulfillmentOrder.List returned error: %v", err)
+	}
+
+	shipping, pickUp, local := fulfillmentOrders[0], fulfillmentOrders[1], fulfillmentOrders[2]
+	if !shipping.IsShipping() || shipping.IsPickUp() || shipping.PickUpLocation() != nil || shipping.DeliveryMethod.ServiceCode != "standard" {
+		t.Errorf("FulfillmentOrder %d is not a shipping order: %+v", shipping.Id, shipping.DeliveryMethod)
+	}
+
+	expectedInformation := &FulfillmentOrderDeliveryMethodAdditionalInformation{Instructions: "Side door", Phone: "555-1234"}
+	if !pickUp.IsPickUp() || pickUp.IsShipping() || !reflect.DeepEqual(pickUp.DeliveryMethod.AdditionalInformation, expectedInformation) {
+		t.Errorf("FulfillmentOrder %d is not a pick up order: %+v", pickUp.Id, pickUp.DeliveryMethod)
+	}
+
+	expectedLocation := &FulfillmentOrderAssignedLocation{LocationId: 5, Name: "Downtown store"}
+	if location := pickUp.PickUpLocation(); !reflect.DeepEqual(location, expectedLocation) {
+		t.Errorf("FulfillmentOrder.PickUpLocation returned %+v, expected %+v", location, expectedLocation)
+	}
+
+	expectedMax := time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC)
+	if !local.IsLocalDelivery() || local.IsShipping() || !local.DeliveryMethod.MaxDeliveryDateTime.Equal(expectedMax) {
+		t.Errorf("FulfillmentOrder %d is not a local delivery order: %+v", local.Id, local.DeliveryMethod)
+	}
+}
+
 func TestFulfillmentOrderCancel(t *testing.T) {
 	setup()
 	defer teardown()
@@ -202,6 +282,33 @@ func TestFulfillmentOrderReschedule(t *testing.T) {
 	}
 }
 
+func TestFulfillmentOrderRescheduleTo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/255858046/reschedule.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			expected := `{"fulfillment_order":{"new_fulfill_at":"2024-03-01T10:00:00Z"}}`
+			if string(body) != expected {
+				t.Errorf("FulfillmentOrder.RescheduleTo sent %s, expected %s", body, expected)
+			}
+			return httpmock.NewBytesResponse(200, loadFixture("fulfillment_order.json")), nil
+		})
+
+	fulfillmentOrderService := &FulfillmentOrderServiceOp{client: client}
+	fulfillmentId := uint64(255858046)
+
+	result, err := fulfillmentOrderService.RescheduleTo(context.Background(), fulfillmentId, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Errorf("FulfillmentOrder.RescheduleTo returned error: %v", err)
+	}
+
+	if result == nil || result.Id != fulfillmentId {
+		t.Errorf("FulfillmentOrder.RescheduleTo returned %+v, expected id %d", result, fulfillmentId)
+	}
+}
+
 func TestFulfillmentOrderSetDeadline(t *testing.T) {
 	setup()
 	defer teardown()
@@ -217,3 +324,36 @@ func TestFulfillmentOrderSetDeadline(t *testing.T) {
 		t.Errorf("FulfillmentOrder.SetDeadline returned error: %v", err)
 	}
 }
+
+func TestFulfillmentOrderLocationsForMove(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/255858046/locations_for_move.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"locations_for_move":[
+			{"location":{"id":24826418,"name":"Apple Api Shipwire"},"message":"Current location.","movable":false},
+			{"location":{"id":1072404542,"name":"Alpha Location"},"message":"No items are stocked at this location.","movable":true}
+		]}`))
+
+	fulfillmentOrderService := &FulfillmentOrderServiceOp{client: client}
+
+	locations, err := fulfillmentOrderService.LocationsForMove(context.Background(), 255858046, nil)
+	if err != nil {
+		t.Errorf("FulfillmentOrder.LocationsForMove returned error: %v", err)
+	}
+
+	if len(locations) != 2 {
+		t.Fatalf("FulfillmentOrder.LocationsForMove returned %d locations, expected 2", len(locations))
+	}
+
+	expected := FulfillmentOrderLocationForMove{Message: "No items are stocked at this location.", Movable: true}
+	expected.Location.Id = 1072404542
+	expected.Location.Name = "Alpha Location"
+	if !reflect.DeepEqual(locations[1], expected) {
+		t.Errorf("FulfillmentOrder.LocationsForMove returned %+v, expected %+v", locations[1], expected)
+	}
+
+	if locations[0].Movable || locations[0].Message != "Current location." {
+		t.Errorf("FulfillmentOrder.LocationsForMove returned %+v, expected an ineligible location", locations[0])
+	}
+}
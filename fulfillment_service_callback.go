@@ -0,0 +1,103 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Kinds of FulfillmentOrderNotification
+const (
+	FulfillmentOrderNotificationKindFulfillmentRequest  = "FULFILLMENT_REQUEST"
+	FulfillmentOrderNotificationKindCancellationRequest = "CANCELLATION_REQUEST"
+)
+
+// FulfillmentOrderNotification is the body Shopify posts to
+// <callback_url>/fulfillment_order_notification when a merchant requests a
+// fulfillment or cancellation, see AssignedFulfillmentOrderService.Get for
+// fetching the fulfillment orders concerned.
+type FulfillmentOrderNotification struct {
+	Kind string `json:"kind"`
+}
+
+// FulfillmentServiceStockRequest is the request Shopify sends to
+// <callback_url>/fetch_stock.json when inventory_management is enabled.
+// An empty Sku asks for the stock of all SKUs.
+type FulfillmentServiceStockRequest struct {
+	Shop              string
+	Sku               string
+	MaxRetrievalCount int
+}
+
+// FulfillmentServiceStockResponse maps SKUs to their stock level
+type FulfillmentServiceStockResponse map[string]int
+
+// FulfillmentServiceTrackingRequest is the request Shopify sends to
+// <callback_url>/fetch_tracking_numbers.json when tracking_support is enabled.
+type FulfillmentServiceTrackingRequest struct {
+	Shop       string
+	OrderNames []string
+}
+
+// FulfillmentServiceTrackingResponse is the response expected for a
+// FulfillmentServiceTrackingRequest, mapping order names to tracking numbers.
+type FulfillmentServiceTrackingResponse struct {
+	TrackingNumbers map[string]string `json:"tracking_numbers"`
+	Message         string            `json:"message,omitempty"`
+	Success         bool              `json:"success"`
+}
+
+// ParseFulfillmentOrderNotification verifies the HMAC of a fulfillment order
+// notification request and decodes its body.
+func (app App) ParseFulfillmentOrderNotification(httpRequest *http.Request) (*FulfillmentOrderNotification, error) {
+	delivery, err := app.ParseWebhookRequest(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := new(FulfillmentOrderNotification)
+	err = delivery.Decode(notification)
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// ParseFulfillmentServiceStockRequest reads the query of a fetch_stock
+// request
+func ParseFulfillmentServiceStockRequest(httpRequest *http.Request) *FulfillmentServiceStockRequest {
+	query := httpRequest.URL.Query()
+	max, _ := strconv.Atoi(query.Get("max_retrieval_count"))
+	return &FulfillmentServiceStockRequest{
+		Shop:              query.Get("shop"),
+		Sku:               query.Get("sku"),
+		MaxRetrievalCount: max,
+	}
+}
+
+// ParseFulfillmentServiceTrackingRequest reads the query of a
+// fetch_tracking_numbers request
+func ParseFulfillmentServiceTrackingRequest(httpRequest *http.Request) *FulfillmentServiceTrackingRequest {
+	query := httpRequest.URL.Query()
+	return &FulfillmentServiceTrackingRequest{
+		Shop:       query.Get("shop"),
+		OrderNames: query["order_names[]"],
+	}
+}
+
+// writeCallbackResponse writes v as the json response of a callback
+func writeCallbackResponse(w http.ResponseWriter, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Write writes the stock levels as the response of a fetch_stock request
+func (r FulfillmentServiceStockResponse) Write(w http.ResponseWriter) error {
+	return writeCallbackResponse(w, r)
+}
+
+// Write writes the tracking numbers as the response of a
+// fetch_tracking_numbers request
+func (r FulfillmentServiceTrackingResponse) Write(w http.ResponseWriter) error {
+	return writeCallbackResponse(w, r)
+}
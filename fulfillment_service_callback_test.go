@@ -0,0 +1,71 @@
+package goshopify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseFulfillmentOrderNotification(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body := `{"kind":"FULFILLMENT_REQUEST"}`
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest("POST", "/fulfillment_order_notification", bytes.NewBufferString(body))
+	req.Header.Set(shopifyChecksumHeader, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	notification, err := app.ParseFulfillmentOrderNotification(req)
+	if err != nil {
+		t.Fatalf("App.ParseFulfillmentOrderNotification returned error: %v", err)
+	}
+
+	if notification.Kind != FulfillmentOrderNotificationKindFulfillmentRequest {
+		t.Errorf("FulfillmentOrderNotification.Kind returned %s, expected %s", notification.Kind, FulfillmentOrderNotificationKindFulfillmentRequest)
+	}
+
+	req = httptest.NewRequest("POST", "/fulfillment_order_notification", bytes.NewBufferString(body))
+	_, err = app.ParseFulfillmentOrderNotification(req)
+	if err == nil {
+		t.Error("App.ParseFulfillmentOrderNotification should fail without a valid hmac")
+	}
+}
+
+func TestParseFulfillmentServiceStockRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/fetch_stock.json?shop=fooshop.myshopify.com&sku=abc&max_retrieval_count=200", nil)
+
+	expected := &FulfillmentServiceStockRequest{Shop: "fooshop.myshopify.com", Sku: "abc", MaxRetrievalCount: 200}
+	actual := ParseFulfillmentServiceStockRequest(req)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ParseFulfillmentServiceStockRequest returned %+v, expected %+v", actual, expected)
+	}
+
+	w := httptest.NewRecorder()
+	err := FulfillmentServiceStockResponse{"abc": 5}.Write(w)
+	if err != nil || w.Body.String() != "{\"abc\":5}\n" || w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("FulfillmentServiceStockResponse.Write wrote %q, %v", w.Body.String(), err)
+	}
+}
+
+func TestParseFulfillmentServiceTrackingRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/fetch_tracking_numbers.json?shop=fooshop.myshopify.com&order_names[]=%231001.1&order_names[]=%231002.1", nil)
+
+	expected := &FulfillmentServiceTrackingRequest{Shop: "fooshop.myshopify.com", OrderNames: []string{"#1001.1", "#1002.1"}}
+	actual := ParseFulfillmentServiceTrackingRequest(req)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ParseFulfillmentServiceTrackingRequest returned %+v, expected %+v", actual, expected)
+	}
+
+	w := httptest.NewRecorder()
+	err := FulfillmentServiceTrackingResponse{TrackingNumbers: map[string]string{"#1001.1": "qwerty"}, Success: true}.Write(w)
+	expectedBody := "{\"tracking_numbers\":{\"#1001.1\":\"qwerty\"},\"success\":true}\n"
+	if err != nil || w.Body.String() != expectedBody {
+		t.Errorf("FulfillmentServiceTrackingResponse.Write wrote %q, %v, expected %q", w.Body.String(), err, expectedBody)
+	}
+}
@@ -20,6 +20,11 @@ type GiftCardService interface {
 	List(context.Context) ([]GiftCard, error)
 	Disable(context.Context, uint64) (*GiftCard, error)
 	Count(context.Context, interface{}) (int, error)
+	CreateWithCode(context.Context, GiftCard) (*GiftCard, error)
+	UpdateDetails(context.Context, GiftCard) (*GiftCard, error)
+	Deactivate(context.Context, uint64) (*GiftCard, error)
+	Credit(context.Context, uint64, GiftCardAdjustment) (*GiftCardTransaction, error)
+	Debit(context.Context, uint64, GiftCardAdjustment) (*GiftCardTransaction, error)
 }
 
 // giftCardServiceOp handles communication with the gift card related methods of the Shopify API.
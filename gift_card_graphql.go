@@ -0,0 +1,353 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const giftCardFields = `
+  id
+  balance {
+    amount
+    currencyCode
+  }
+  initialValue {
+    amount
+    currencyCode
+  }
+  lastCharacters
+  note
+  expiresOn
+  templateSuffix
+  deactivatedAt
+  createdAt
+  updatedAt
+  customer {
+    id
+  }`
+
+const giftCardCreateMutation = `mutation giftCardCreate($input: GiftCardCreateInput!) {
+  giftCardCreate(input: $input) {
+    giftCard {` + giftCardFields + `
+    }
+    giftCardCode
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const giftCardUpdateMutation = `mutation giftCardUpdate($id: ID!, $input: GiftCardUpdateInput!) {
+  giftCardUpdate(id: $id, input: $input) {
+    giftCard {` + giftCardFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const giftCardDeactivateMutation = `mutation giftCardDeactivate($id: ID!) {
+  giftCardDeactivate(id: $id) {
+    giftCard {` + giftCardFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const giftCardTransactionFields = `
+      id
+      amount {
+        amount
+        currencyCode
+      }
+      note
+      processedAt
+      giftCard {
+        balance {
+          amount
+          currencyCode
+        }
+      }`
+
+const giftCardCreditMutation = `mutation giftCardCredit($id: ID!, $creditInput: GiftCardCreditInput!) {
+  giftCardCredit(id: $id, creditInput: $creditInput) {
+    giftCardCreditTransaction {` + giftCardTransactionFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const giftCardDebitMutation = `mutation giftCardDebit($id: ID!, $debitInput: GiftCardDebitInput!) {
+  giftCardDebit(id: $id, debitInput: $debitInput) {
+    giftCardDebitTransaction {` + giftCardTransactionFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// GiftCardAdjustment is a credit or debit of a gift card balance
+type GiftCardAdjustment struct {
+	Amount decimal.Decimal
+	// currency of the amount, must match the currency of the gift card
+	Currency    string
+	Note        string
+	ProcessedAt *time.Time
+}
+
+// GiftCardTransaction is the result of crediting or debiting a gift card
+type GiftCardTransaction struct {
+	Id          uint64
+	Amount      *decimal.Decimal
+	Currency    string
+	Note        string
+	ProcessedAt *time.Time
+	// balance of the gift card after the transaction
+	Balance *decimal.Decimal
+}
+
+type graphQLGiftCard struct {
+	Id             string       `json:"id"`
+	Balance        graphQLMoney `json:"balance"`
+	InitialValue   graphQLMoney `json:"initialValue"`
+	LastCharacters string       `json:"lastCharacters"`
+	Note           string       `json:"note"`
+	ExpiresOn      string       `json:"expiresOn"`
+	TemplateSuffix string       `json:"templateSuffix"`
+	DeactivatedAt  *time.Time   `json:"deactivatedAt"`
+	CreatedAt      *time.Time   `json:"createdAt"`
+	UpdatedAt      *time.Time   `json:"updatedAt"`
+	Customer       *struct {
+		Id string `json:"id"`
+	} `json:"customer"`
+}
+
+func (g *graphQLGiftCard) giftCard() *GiftCard {
+	if g == nil {
+		return nil
+	}
+
+	id, _ := ParseGraphQLId(g.Id)
+	giftCard := &GiftCard{
+		Id:             id,
+		Balance:        g.Balance.Amount,
+		InitalValue:    g.InitialValue.Amount,
+		Currency:       g.Balance.CurrencyCode,
+		LastCharacters: g.LastCharacters,
+		Note:           g.Note,
+		ExpiresOn:      g.ExpiresOn,
+		TemplateSuffix: g.TemplateSuffix,
+		DisabledAt:     g.DeactivatedAt,
+		CreatedAt:      g.CreatedAt,
+		UpdatedAt:      g.UpdatedAt,
+	}
+	if g.Customer != nil {
+		customerId, _ := ParseGraphQLId(g.Customer.Id)
+		giftCard.CustomerId = &CustomerId{CustomerId: customerId}
+	}
+	return giftCard
+}
+
+type graphQLGiftCardTransaction struct {
+	Id          string       `json:"id"`
+	Amount      graphQLMoney `json:"amount"`
+	Note        string       `json:"note"`
+	ProcessedAt *time.Time   `json:"processedAt"`
+	GiftCard    struct {
+		Balance graphQLMoney `json:"balance"`
+	} `json:"giftCard"`
+}
+
+func (g *graphQLGiftCardTransaction) transaction() *GiftCardTransaction {
+	if g == nil {
+		return nil
+	}
+
+	id, _ := ParseGraphQLId(g.Id)
+	return &GiftCardTransaction{
+		Id:          id,
+		Amount:      g.Amount.Amount,
+		Currency:    g.Amount.CurrencyCode,
+		Note:        g.Note,
+		ProcessedAt: g.ProcessedAt,
+		Balance:     g.GiftCard.Balance.Amount,
+	}
+}
+
+// CreateWithCode creates a gift card through the GraphQL API, which unlike
+// Create accepts a custom Code. The full code is only returned by this call,
+// later reads only have LastCharacters.
+func (s *GiftCardServiceOp) CreateWithCode(ctx context.Context, giftCard GiftCard) (*GiftCard, error) {
+	input := giftCardUpdateInput(giftCard)
+	if giftCard.Code != "" {
+		input["code"] = giftCard.Code
+	}
+	if giftCard.InitalValue != nil {
+		input["initialValue"] = giftCard.InitalValue.String()
+	}
+
+	resp := struct {
+		GiftCardCreate struct {
+			GiftCard     *graphQLGiftCard  `json:"giftCard"`
+			GiftCardCode string            `json:"giftCardCode"`
+			UserErrors   GraphQLUserErrors `json:"userErrors"`
+		} `json:"giftCardCreate"`
+	}{}
+
+	vars := map[string]interface{}{"input": input}
+	err := s.client.GraphQL.Query(ctx, giftCardCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GiftCardCreate.UserErrors) > 0 {
+		return nil, resp.GiftCardCreate.UserErrors
+	}
+
+	created := resp.GiftCardCreate.GiftCard.giftCard()
+	if created != nil {
+		created.Code = resp.GiftCardCreate.GiftCardCode
+	}
+	return created, nil
+}
+
+// UpdateDetails updates the note, expiry, customer and template suffix of a
+// gift card through the GraphQL API.
+func (s *GiftCardServiceOp) UpdateDetails(ctx context.Context, giftCard GiftCard) (*GiftCard, error) {
+	resp := struct {
+		GiftCardUpdate struct {
+			GiftCard   *graphQLGiftCard  `json:"giftCard"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"giftCardUpdate"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":    GraphQLId("GiftCard", giftCard.Id),
+		"input": giftCardUpdateInput(giftCard),
+	}
+	err := s.client.GraphQL.Query(ctx, giftCardUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GiftCardUpdate.UserErrors) > 0 {
+		return nil, resp.GiftCardUpdate.UserErrors
+	}
+	return resp.GiftCardUpdate.GiftCard.giftCard(), nil
+}
+
+// Deactivate permanently deactivates a gift card through the GraphQL API
+func (s *GiftCardServiceOp) Deactivate(ctx context.Context, giftCardId uint64) (*GiftCard, error) {
+	resp := struct {
+		GiftCardDeactivate struct {
+			GiftCard   *graphQLGiftCard  `json:"giftCard"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"giftCardDeactivate"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("GiftCard", giftCardId)}
+	err := s.client.GraphQL.Query(ctx, giftCardDeactivateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GiftCardDeactivate.UserErrors) > 0 {
+		return nil, resp.GiftCardDeactivate.UserErrors
+	}
+	return resp.GiftCardDeactivate.GiftCard.giftCard(), nil
+}
+
+// Credit adds the adjustment amount to the balance of a gift card
+func (s *GiftCardServiceOp) Credit(ctx context.Context, giftCardId uint64, adjustment GiftCardAdjustment) (*GiftCardTransaction, error) {
+	resp := struct {
+		GiftCardCredit struct {
+			Transaction *graphQLGiftCardTransaction `json:"giftCardCreditTransaction"`
+			UserErrors  GraphQLUserErrors           `json:"userErrors"`
+		} `json:"giftCardCredit"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":          GraphQLId("GiftCard", giftCardId),
+		"creditInput": giftCardAdjustmentInput("creditAmount", adjustment),
+	}
+	err := s.client.GraphQL.Query(ctx, giftCardCreditMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GiftCardCredit.UserErrors) > 0 {
+		return nil, resp.GiftCardCredit.UserErrors
+	}
+	return resp.GiftCardCredit.Transaction.transaction(), nil
+}
+
+// Debit subtracts the adjustment amount from the balance of a gift card
+func (s *GiftCardServiceOp) Debit(ctx context.Context, giftCardId uint64, adjustment GiftCardAdjustment) (*GiftCardTransaction, error) {
+	resp := struct {
+		GiftCardDebit struct {
+			Transaction *graphQLGiftCardTransaction `json:"giftCardDebitTransaction"`
+			UserErrors  GraphQLUserErrors           `json:"userErrors"`
+		} `json:"giftCardDebit"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":         GraphQLId("GiftCard", giftCardId),
+		"debitInput": giftCardAdjustmentInput("debitAmount", adjustment),
+	}
+	err := s.client.GraphQL.Query(ctx, giftCardDebitMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.GiftCardDebit.UserErrors) > 0 {
+		return nil, resp.GiftCardDebit.UserErrors
+	}
+	return resp.GiftCardDebit.Transaction.transaction(), nil
+}
+
+// giftCardUpdateInput converts the updatable fields of a gift card to a
+// GraphQL GiftCardUpdateInput, which GiftCardCreateInput extends.
+func giftCardUpdateInput(giftCard GiftCard) map[string]interface{} {
+	input := map[string]interface{}{}
+	if giftCard.Note != "" {
+		input["note"] = giftCard.Note
+	}
+	if giftCard.ExpiresOn != "" {
+		input["expiresOn"] = giftCard.ExpiresOn
+	}
+	if giftCard.TemplateSuffix != "" {
+		input["templateSuffix"] = giftCard.TemplateSuffix
+	}
+	if giftCard.CustomerId != nil && giftCard.CustomerId.CustomerId != 0 {
+		input["customerId"] = GraphQLId("Customer", giftCard.CustomerId.CustomerId)
+	}
+	return input
+}
+
+func giftCardAdjustmentInput(amountKey string, adjustment GiftCardAdjustment) map[string]interface{} {
+	input := map[string]interface{}{
+		amountKey: map[string]interface{}{
+			"amount":       adjustment.Amount.String(),
+			"currencyCode": adjustment.Currency,
+		},
+	}
+	if adjustment.Note != "" {
+		input["note"] = adjustment.Note
+	}
+	if adjustment.ProcessedAt != nil {
+		input["processedAt"] = adjustment.ProcessedAt.UTC().Format(time.RFC3339)
+	}
+	return input
+}
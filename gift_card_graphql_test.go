@@ -0,0 +1,185 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestGiftCardCreateWithCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"giftCardCreate":{"giftCard":{
+			"id":"gid://shopify/GiftCard/1",
+			"balance":{"amount":"25.0","currencyCode":"USD"},
+			"initialValue":{"amount":"25.0","currencyCode":"USD"},
+			"lastCharacters":"1234",
+			"note":"loyalty reward",
+			"customer":{"id":"gid://shopify/Customer/7"}
+		},"giftCardCode":"loyal1234","userErrors":[]}}}`
+	})
+
+	value := decimal.NewFromFloat(25)
+	giftCard, err := client.GiftCard.CreateWithCode(context.Background(), GiftCard{
+		Code:        "loyal1234",
+		InitalValue: &value,
+		Note:        "loyalty reward",
+		CustomerId:  &CustomerId{CustomerId: 7},
+	})
+	if err != nil {
+		t.Fatalf("GiftCard.CreateWithCode returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"input": map[string]interface{}{
+		"code":         "loyal1234",
+		"initialValue": "25",
+		"note":         "loyalty reward",
+		"customerId":   "gid://shopify/Customer/7",
+	}}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("GiftCard.CreateWithCode sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if giftCard.Id != 1 || giftCard.Code != "loyal1234" || giftCard.Currency != "USD" ||
+		!giftCard.Balance.Equal(value) || giftCard.CustomerId.CustomerId != 7 {
+		t.Errorf("GiftCard.CreateWithCode returned %+v", giftCard)
+	}
+}
+
+func TestGiftCardCreateWithCodeUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"giftCardCreate":{"giftCard":null,"giftCardCode":null,
+			"userErrors":[{"field":["input","code"],"message":"Code has already been taken","code":"TAKEN"}]}}}`
+	})
+
+	_, err := client.GiftCard.CreateWithCode(context.Background(), GiftCard{Code: "taken"})
+	expected := GraphQLUserErrors{{Field: []string{"input", "code"}, Message: "Code has already been taken", Code: "TAKEN"}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("GiftCard.CreateWithCode err returned %#v, expected %#v", err, expected)
+	}
+}
+
+func TestGiftCardUpdateDetails(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"giftCardUpdate":{"giftCard":{
+			"id":"gid://shopify/GiftCard/1","note":"updated","expiresOn":"2030-01-01"
+		},"userErrors":[]}}}`
+	})
+
+	giftCard, err := client.GiftCard.UpdateDetails(context.Background(), GiftCard{Id: 1, Note: "updated", ExpiresOn: "2030-01-01"})
+	if err != nil {
+		t.Fatalf("GiftCard.UpdateDetails returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id":    "gid://shopify/GiftCard/1",
+		"input": map[string]interface{}{"note": "updated", "expiresOn": "2030-01-01"},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("GiftCard.UpdateDetails sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	expected := &GiftCard{Id: 1, Note: "updated", ExpiresOn: "2030-01-01"}
+	if !reflect.DeepEqual(giftCard, expected) {
+		t.Errorf("GiftCard.UpdateDetails returned %+v, expected %+v", giftCard, expected)
+	}
+}
+
+func TestGiftCardDeactivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"giftCardDeactivate":{"giftCard":{
+			"id":"gid://shopify/GiftCard/1","deactivatedAt":"2024-01-01T00:00:00Z"
+		},"userErrors":[]}}}`
+	})
+
+	giftCard, err := client.GiftCard.Deactivate(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GiftCard.Deactivate returned error: %v", err)
+	}
+
+	if vars["id"] != "gid://shopify/GiftCard/1" {
+		t.Errorf("GiftCard.Deactivate sent %+v", vars)
+	}
+	if giftCard.Id != 1 || giftCard.DisabledAt == nil {
+		t.Errorf("GiftCard.Deactivate returned %+v", giftCard)
+	}
+}
+
+func TestGiftCardCreditDebit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		name     string
+		call     func(context.Context, uint64, GiftCardAdjustment) (*GiftCardTransaction, error)
+		response string
+		inputKey string
+		amount   string
+	}{
+		{
+			name:     "Credit",
+			call:     client.GiftCard.Credit,
+			response: `{"data":{"giftCardCredit":{"giftCardCreditTransaction":{"id":"gid://shopify/GiftCardCreditTransaction/9","amount":{"amount":"5.0","currencyCode":"USD"},"note":"points","giftCard":{"balance":{"amount":"30.0","currencyCode":"USD"}}},"userErrors":[]}}}`,
+			inputKey: "creditInput",
+			amount:   "creditAmount",
+		},
+		{
+			name:     "Debit",
+			call:     client.GiftCard.Debit,
+			response: `{"data":{"giftCardDebit":{"giftCardDebitTransaction":{"id":"gid://shopify/GiftCardDebitTransaction/9","amount":{"amount":"-5.0","currencyCode":"USD"},"note":"points","giftCard":{"balance":{"amount":"20.0","currencyCode":"USD"}}},"userErrors":[]}}}`,
+			inputKey: "debitInput",
+			amount:   "debitAmount",
+		},
+	}
+
+	for _, c := range cases {
+		var vars map[string]interface{}
+		registerGraphQL(t, nil, func(req graphQLRequest) string {
+			vars = req.Variables
+			return c.response
+		})
+
+		transaction, err := c.call(context.Background(), 1, GiftCardAdjustment{
+			Amount:   decimal.NewFromFloat(5),
+			Currency: "USD",
+			Note:     "points",
+		})
+		if err != nil {
+			t.Fatalf("GiftCard.%s returned error: %v", c.name, err)
+		}
+
+		expectedVars := map[string]interface{}{
+			"id": "gid://shopify/GiftCard/1",
+			c.inputKey: map[string]interface{}{
+				c.amount: map[string]interface{}{"amount": "5", "currencyCode": "USD"},
+				"note":   "points",
+			},
+		}
+		if !reflect.DeepEqual(vars, expectedVars) {
+			t.Errorf("GiftCard.%s sent %+v, expected %+v", c.name, vars, expectedVars)
+		}
+
+		if transaction.Id != 9 || transaction.Currency != "USD" || transaction.Note != "points" || transaction.Balance == nil {
+			t.Errorf("GiftCard.%s returned %+v", c.name, transaction)
+		}
+	}
+}
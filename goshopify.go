@@ -17,6 +17,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -54,7 +55,10 @@ type RateLimitInfo struct {
 	RetryAfterSeconds float64
 }
 
-// Client manages communication with the Shopify API.
+// Client manages communication with the Shopify API. A Client is safe for
+// concurrent use. Its configuration is set by the options passed to
+// NewClient and must not be changed afterwards, use Clone for variations,
+// e.g. per tenant.
 type Client struct {
 	// HTTP client used to communicate with the Shopify API.
 	Client *http.Client
@@ -68,36 +72,87 @@ type Client struct {
 	// its own client.
 	baseURL *url.URL
 
+	// The shop's myshopify domain, which differs from the host of baseURL
+	// when it is overridden, see WithBaseURL
+	shop string
+
 	// URL Prefix, defaults to "admin" see WithVersion
 	pathPrefix string
 
 	// version you're currently using of the api, defaults to "stable"
+	// until the first response tells the actual version, guarded by stateMu
 	apiVersion string
 
-	// A permanent access token
-	token string
+	// A permanent access token, guarded by tokenMu as it can be rotated, see
+	// SetToken and WithTokenRefresher
+	token          string
+	tokenMu        sync.RWMutex
+	tokenRefresher TokenRefresher
 
 	// max number of retries, defaults to 0 for no retries see WithRetry option
-	retries  int
+	retries int
+
+	// guards the state changing with responses: apiVersion, attempts and
+	// RateLimits
+	stateMu  sync.Mutex
 	attempts int
 
+	// Deprecated: reading the field races with requests running concurrently,
+	// use CurrentRateLimits
 	RateLimits RateLimitInfo
 
+	// called when Shopify rejects the access token, see WithInvalidTokenCallback
+	onInvalidToken InvalidTokenCallback
+
+	// optional metrics collector, see WithMetrics
+	metrics MetricsCollector
+
+	// optional circuit breaker, see WithCircuitBreaker
+	breaker *circuitBreaker
+
+	// optional priority queue, see WithPriorityQueue
+	queue *priorityQueue
+
+	// optional retries of failed pages, see WithPaginationRetry
+	pageRetry *pageRetry
+
+	// resources read through the GraphQL API, see WithGraphQLReads
+	graphQLReads map[string]bool
+
+	// build and validate requests without sending them, see WithDryRun
+	dryRun bool
+
+	// check required fields before creating resources, see WithCreateValidation
+	createValidation bool
+
+	// reject unknown enum values of responses, see WithStrictEnums
+	strictEnums bool
+
+	// minimum size of gzip compressed request bodies, see WithGzip
+	gzipThreshold int
+
+	// default timeout of calls without deadline, see WithRequestTimeout
+	requestTimeout time.Duration
+
 	// Services used for communicating with the API
 	Product                    ProductService
 	CustomCollection           CustomCollectionService
 	SmartCollection            SmartCollectionService
 	Customer                   CustomerService
 	CustomerAddress            CustomerAddressService
+	CustomerPaymentMethod      CustomerPaymentMethodService
+	CustomerSavedSearch        CustomerSavedSearchService
 	Order                      OrderService
 	Fulfillment                FulfillmentService
 	DraftOrder                 DraftOrderService
 	AbandonedCheckout          AbandonedCheckoutService
+	Checkout                   CheckoutService
 	Shop                       ShopService
 	Webhook                    WebhookService
 	Variant                    VariantService
 	Image                      ImageService
 	Transaction                TransactionService
+	Refund                     RefundService
 	Theme                      ThemeService
 	Asset                      AssetService
 	ScriptTag                  ScriptTagService
@@ -113,9 +168,13 @@ type Client struct {
 	Collection                 CollectionService
 	Location                   LocationService
 	DiscountCode               DiscountCodeService
+	AppDiscount                AppDiscountService
+	DeliveryCustomization      DeliveryCustomizationService
+	PaymentCustomization       PaymentCustomizationService
 	PriceRule                  PriceRuleService
 	InventoryItem              InventoryItemService
 	ShippingZone               ShippingZoneService
+	DeliveryProfile            DeliveryProfileService
 	ProductListing             ProductListingService
 	InventoryLevel             InventoryLevelService
 	AccessScopes               AccessScopesService
@@ -123,14 +182,27 @@ type Client struct {
 	CarrierService             CarrierServiceService
 	Payouts                    PayoutsService
 	GiftCard                   GiftCardService
+	StoreCredit                StoreCreditService
 	FulfillmentOrder           FulfillmentOrderService
 	GraphQL                    GraphQLService
+	BulkOperation              BulkOperationService
 	AssignedFulfillmentOrder   AssignedFulfillmentOrderService
 	FulfillmentEvent           FulfillmentEventService
 	FulfillmentRequest         FulfillmentRequestService
+	CancellationRequest        CancellationRequestService
 	PaymentsTransactions       PaymentsTransactionsService
 	OrderRisk                  OrderRiskService
 	ApiPermissions             ApiPermissionsService
+	Flow                       FlowService
+	User                       UserService
+	ShopifyQL                  ShopifyQLService
+	Tags                       TagsService
+	AppMetafield               AppMetafieldService
+	Channel                    ChannelService
+	CheckoutBranding           CheckoutBrandingService
+	SubscriptionContract       SubscriptionContractService
+	SellingPlanGroup           SellingPlanGroupService
+	AppInstallation            AppInstallationService
 }
 
 // A general response error that follows a similar layout to Shopify's response
@@ -190,6 +262,40 @@ type RateLimitError struct {
 	RetryAfter int
 }
 
+// InvalidTokenCallback is called with the shop domain and the access token
+// Shopify rejected. The client may have been given a fresh token since the
+// request was sent.
+type InvalidTokenCallback func(ctx context.Context, shop, token string, err error)
+
+// messages of the 401 errors Shopify returns for a rejected access token
+var invalidTokenMessages = []string{
+	"invalid api key or access token",
+	"invalid_api_key_or_access_token",
+}
+
+// IsInvalidTokenError reports whether err is a 401 returned by Shopify
+// because it rejected the access token, i.e. "[API] Invalid API key or
+// access token". This happens when the token has been revoked, usually
+// because the app was uninstalled. Other 401s don't mean the token is
+// invalid.
+func IsInvalidTokenError(err error) bool {
+	var respErr ResponseError
+	if !errors.As(err, &respErr) || respErr.Status != http.StatusUnauthorized {
+		return false
+	}
+
+	msgs := append([]string{respErr.Message}, respErr.Errors...)
+	for _, msg := range msgs {
+		msg = strings.ToLower(msg)
+		for _, invalid := range invalidTokenMessages {
+			if strings.Contains(msg, invalid) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Creates an API request. A relative URL can be provided in urlStr, which will
 // be resolved to the BaseURL of the Client. Relative URLS should always be
 // specified without a preceding slash. If specified, the value pointed to by
@@ -237,10 +343,13 @@ func (c *Client) NewRequest(ctx context.Context, method, relPath string, body, o
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
+	if c.gzipThreshold > 0 {
+		req.Header.Add("Accept-Encoding", "gzip")
+	}
 	req.Header.Add("User-Agent", UserAgent)
 
-	if c.token != "" {
-		req.Header.Add("X-Shopify-Access-Token", c.token)
+	if token := c.Token(); token != "" {
+		req.Header.Add("X-Shopify-Access-Token", token)
 	} else if c.app.Password != "" {
 		req.SetBasicAuth(c.app.ApiKey, c.app.Password)
 	}
@@ -270,39 +379,66 @@ func MustNewClient(app App, shopName, token string, opts ...Option) *Client {
 
 // Returns a new Shopify API client with an already authenticated shopname and
 // token. The shopName parameter is the shop's myshopify domain,
-// e.g. "theshop.myshopify.com", or simply "theshop"
+// e.g. "theshop.myshopify.com", or simply "theshop". It returns an error for
+// invalid shop names unless the base URL is overridden with WithBaseURL.
+// The shopName can be empty for clients only verifying requests.
 func NewClient(app App, shopName, token string, opts ...Option) (*Client, error) {
-	baseURL, err := url.Parse(ShopBaseUrl(shopName))
-	if err != nil {
-		return nil, err
-	}
-
 	c := &Client{
 		Client: &http.Client{
 			Timeout: time.Second * defaultHttpTimeout,
 		},
 		log:        &LeveledLogger{},
 		app:        app,
-		baseURL:    baseURL,
+		shop:       ShopFullName(shopName),
 		token:      token,
 		apiVersion: defaultApiVersion,
 		pathPrefix: defaultApiPathPrefix,
 	}
 
+	c.initServices()
+
+	// apply any options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.baseURL == nil {
+		// clients without shop are used to verify webhooks
+		if strings.TrimSpace(shopName) != "" {
+			if err := ValidateShopName(shopName); err != nil {
+				return nil, err
+			}
+		}
+		baseURL, err := url.Parse(ShopBaseUrl(shopName))
+		if err != nil {
+			return nil, err
+		}
+		c.baseURL = baseURL
+	}
+
+	return c, nil
+}
+
+// initServices creates the services of the client
+func (c *Client) initServices() {
 	c.Product = &ProductServiceOp{client: c}
 	c.CustomCollection = &CustomCollectionServiceOp{client: c}
 	c.SmartCollection = &SmartCollectionServiceOp{client: c}
 	c.Customer = &CustomerServiceOp{client: c}
 	c.CustomerAddress = &CustomerAddressServiceOp{client: c}
+	c.CustomerPaymentMethod = &CustomerPaymentMethodServiceOp{client: c}
+	c.CustomerSavedSearch = &CustomerSavedSearchServiceOp{client: c}
 	c.Order = &OrderServiceOp{client: c}
 	c.Fulfillment = &FulfillmentServiceOp{client: c}
 	c.DraftOrder = &DraftOrderServiceOp{client: c}
 	c.AbandonedCheckout = &AbandonedCheckoutServiceOp{client: c}
+	c.Checkout = &CheckoutServiceOp{client: c}
 	c.Shop = &ShopServiceOp{client: c}
 	c.Webhook = &WebhookServiceOp{client: c}
 	c.Variant = &VariantServiceOp{client: c}
 	c.Image = &ImageServiceOp{client: c}
 	c.Transaction = &TransactionServiceOp{client: c}
+	c.Refund = &RefundServiceOp{client: c}
 	c.Theme = &ThemeServiceOp{client: c}
 	c.Asset = &AssetServiceOp{client: c}
 	c.ScriptTag = &ScriptTagServiceOp{client: c}
@@ -318,9 +454,13 @@ func NewClient(app App, shopName, token string, opts ...Option) (*Client, error)
 	c.Collection = &CollectionServiceOp{client: c}
 	c.Location = &LocationServiceOp{client: c}
 	c.DiscountCode = &DiscountCodeServiceOp{client: c}
+	c.AppDiscount = &AppDiscountServiceOp{client: c}
+	c.DeliveryCustomization = &DeliveryCustomizationServiceOp{client: c}
+	c.PaymentCustomization = &PaymentCustomizationServiceOp{client: c}
 	c.PriceRule = &PriceRuleServiceOp{client: c}
 	c.InventoryItem = &InventoryItemServiceOp{client: c}
 	c.ShippingZone = &ShippingZoneServiceOp{client: c}
+	c.DeliveryProfile = &DeliveryProfileServiceOp{client: c}
 	c.ProductListing = &ProductListingServiceOp{client: c}
 	c.InventoryLevel = &InventoryLevelServiceOp{client: c}
 	c.AccessScopes = &AccessScopesServiceOp{client: c}
@@ -328,21 +468,39 @@ func NewClient(app App, shopName, token string, opts ...Option) (*Client, error)
 	c.CarrierService = &CarrierServiceOp{client: c}
 	c.Payouts = &PayoutsServiceOp{client: c}
 	c.GiftCard = &GiftCardServiceOp{client: c}
+	c.StoreCredit = &StoreCreditServiceOp{client: c}
 	c.FulfillmentOrder = &FulfillmentOrderServiceOp{client: c}
 	c.GraphQL = &GraphQLServiceOp{client: c}
+	c.BulkOperation = &BulkOperationServiceOp{client: c}
 	c.AssignedFulfillmentOrder = &AssignedFulfillmentOrderServiceOp{client: c}
 	c.FulfillmentEvent = &FulfillmentEventServiceOp{client: c}
 	c.FulfillmentRequest = &FulfillmentRequestServiceOp{client: c}
+	c.CancellationRequest = &CancellationRequestServiceOp{client: c}
 	c.PaymentsTransactions = &PaymentsTransactionsServiceOp{client: c}
 	c.OrderRisk = &OrderRiskServiceOp{client: c}
 	c.ApiPermissions = &ApiPermissionsServiceOp{client: c}
+	c.Flow = &FlowServiceOp{client: c}
+	c.User = &UserServiceOp{client: c}
+	c.ShopifyQL = &ShopifyQLServiceOp{client: c}
+	c.Tags = &TagsServiceOp{client: c}
+	c.AppMetafield = &AppMetafieldServiceOp{client: c}
+	c.Channel = &ChannelServiceOp{client: c}
+	c.CheckoutBranding = &CheckoutBrandingServiceOp{client: c}
+	c.SubscriptionContract = &SubscriptionContractServiceOp{client: c}
+	c.SellingPlanGroup = &SellingPlanGroupServiceOp{client: c}
+	c.AppInstallation = &AppInstallationServiceOp{client: c}
+}
 
-	// apply any options
-	for _, opt := range opts {
-		opt(c)
-	}
+// relativePath returns the path of a request url relative to the base URL,
+// e.g. admin/api/2024-01/orders.json
+func (c *Client) relativePath(u *url.URL) string {
+	return strings.TrimPrefix(strings.TrimPrefix(u.Path, c.baseURL.Path), "/")
+}
 
-	return c, nil
+// ShopDomain returns the myshopify domain of the client's shop, e.g.
+// "theshop.myshopify.com"
+func (c *Client) ShopDomain() string {
+	return c.shop
 }
 
 // Do sends an API request and populates the given interface with the parsed
@@ -362,7 +520,13 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 	var resp *http.Response
 	var err error
 	retries := c.retries
-	c.attempts = 0
+	refreshed := false
+	attempts := 0
+	defer func() {
+		c.stateMu.Lock()
+		c.attempts = attempts
+		c.stateMu.Unlock()
+	}()
 	c.logRequest(req)
 
 	// copy request body so it can be re-used
@@ -375,10 +539,47 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 		}
 	}
 
+	if c.dryRun {
+		return nil, c.newDryRunError(req, body)
+	}
+
+	body, err = c.gzipBody(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	callerCtx := req.Context()
+	req, cancel := c.withRequestTimeout(req)
+	defer cancel()
+
 	for {
-		c.attempts++
+		attempts++
+		if c.queue != nil {
+			if err := c.queue.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		if c.breaker != nil && !c.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
 		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		start := time.Now()
 		resp, err = c.Client.Do(req)
+		if err == nil && c.gzipThreshold > 0 {
+			err = gzipResponse(resp)
+		}
+		c.observeRequest(req, resp, time.Since(start))
+		recordResponse(req, resp)
+		if c.breaker != nil {
+			if err != nil && callerCtx.Err() != nil {
+				// the caller gave up before the shop answered
+				c.breaker.abandon()
+			} else {
+				c.breaker.record(isCircuitFailure(resp, err))
+			}
+		}
 		c.logResponse(resp)
 		if err != nil {
 			return nil, err // http client errors, not api responses
@@ -389,9 +590,27 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 			break // no errors, break out of the retry loop
 		}
 
+		if !refreshed && c.tokenRefresher != nil && IsInvalidTokenError(respErr) {
+			// retry once with a fresh token, not counting as a retry
+			refreshed = true
+			if c.refreshToken(req) {
+				resp.Body.Close()
+				continue
+			}
+		}
+
+		if c.onInvalidToken != nil && IsInvalidTokenError(respErr) {
+			c.onInvalidToken(req.Context(), c.shop, req.Header.Get("X-Shopify-Access-Token"), respErr)
+		}
+
 		// retry scenario, close resp and any continue will retry
 		resp.Body.Close()
 
+		if rateLimitErr, isRetryErr := respErr.(RateLimitError); isRetryErr && c.queue != nil {
+			// hold back every request sharing the queue, not just this one
+			c.queue.block(time.Duration(rateLimitErr.RetryAfter) * time.Second)
+		}
+
 		if retries <= 1 {
 			return nil, respErr
 		}
@@ -401,7 +620,15 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 
 			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
 			c.log.Debugf("rate limited waiting %s", wait.String())
-			time.Sleep(wait)
+			c.observeRetry(req, resp.StatusCode)
+			if c.queue == nil {
+				// otherwise the queue delays the retry
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(wait):
+				}
+			}
 			retries--
 			continue
 		}
@@ -410,6 +637,7 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 		switch resp.StatusCode {
 		case http.StatusServiceUnavailable:
 			c.log.Debugf("service unavailable, retrying")
+			c.observeRetry(req, resp.StatusCode)
 			doRetry = true
 			retries--
 		}
@@ -424,10 +652,14 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 
 	defer resp.Body.Close()
 
-	if c.apiVersion == defaultApiVersion && resp.Header.Get("X-Shopify-API-Version") != "" {
-		// if using stable on first request set the api version
-		c.apiVersion = resp.Header.Get("X-Shopify-API-Version")
-		c.log.Infof("api version not set, now using %s", c.apiVersion)
+	if version := resp.Header.Get("X-Shopify-API-Version"); version != "" {
+		c.stateMu.Lock()
+		if c.apiVersion == defaultApiVersion {
+			// if using stable on first request set the api version
+			c.apiVersion = version
+			c.log.Infof("api version not set, now using %s", version)
+		}
+		c.stateMu.Unlock()
 	}
 
 	if v != nil {
@@ -436,18 +668,53 @@ func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, er
 		if err != nil {
 			return nil, err
 		}
+		if c.strictEnums {
+			if err := checkEnums(v); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	if s := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/"); len(s) == 2 {
-		c.RateLimits.RequestCount, _ = strconv.Atoi(s[0])
-		c.RateLimits.BucketSize, _ = strconv.Atoi(s[1])
+	callLimit := strings.Split(resp.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/")
+	c.stateMu.Lock()
+	if len(callLimit) == 2 {
+		c.RateLimits.RequestCount, _ = strconv.Atoi(callLimit[0])
+		c.RateLimits.BucketSize, _ = strconv.Atoi(callLimit[1])
 	}
-
 	c.RateLimits.RetryAfterSeconds, _ = strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+	limits := c.RateLimits
+	c.stateMu.Unlock()
+
+	if len(callLimit) == 2 {
+		if c.metrics != nil {
+			c.metrics.ObserveCallLimit(c.shop, limits.RequestCount, limits.BucketSize)
+		}
+		if c.queue != nil {
+			c.queue.observeCallLimit(limits.RequestCount, limits.BucketSize)
+		}
+	}
 
 	return resp.Header, nil
 }
 
+func (c *Client) observeRequest(req *http.Request, resp *http.Response, duration time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	c.metrics.ObserveRequest(c.shop, req.Method, c.metricsResource(req), status, duration)
+}
+
+func (c *Client) observeRetry(req *http.Request, status int) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ObserveRetry(c.shop, c.metricsResource(req), status)
+}
+
 func (c *Client) logRequest(req *http.Request) {
 	if req == nil {
 		return
@@ -628,7 +895,14 @@ type CountOptions struct {
 	UpdatedAtMax time.Time `url:"updated_at_max,omitempty"`
 }
 
+// Count gets the count of a resource. The options can be the list options of
+// the resource, filters the count endpoint doesn't support return a
+// CountFilterError instead of a total that ignores them.
 func (c *Client) Count(ctx context.Context, path string, options interface{}) (int, error) {
+	if err := checkCountFilters(path, options); err != nil {
+		return 0, err
+	}
+
 	resource := struct {
 		Count int `json:"count"`
 	}{}
@@ -678,7 +952,7 @@ func (c *Client) Get(ctx context.Context, path string, resource, options interfa
 // ListWithPagination performs a GET request for the given path and saves the result in the
 // given resource and returns the pagination.
 func (c *Client) ListWithPagination(ctx context.Context, path string, resource, options interface{}) (*Pagination, error) {
-	headers, err := c.createAndDoGetHeaders(ctx, "GET", path, nil, options, resource)
+	headers, err := c.listPage(ctx, path, resource, options)
 	if err != nil {
 		return nil, err
 	}
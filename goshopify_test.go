@@ -996,3 +996,59 @@ func TestListWithPagination(t *testing.T) {
 		t.Fatalf("Expected prev page: %s   got: %s", "123", pagination.PreviousPageOptions.PageInfo)
 	}
 }
+
+func TestInvalidTokenCallback(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calledShop, calledToken string
+	var calledErr error
+	WithInvalidTokenCallback(func(ctx context.Context, shop, token string, err error) {
+		calledShop = shop
+		calledToken = token
+		calledErr = err
+	})(client)
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/2",
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+
+	req, _ := client.NewRequest(context.Background(), "GET", "foo/2", nil, nil)
+	_ = client.Do(req, nil)
+	if calledShop != "" {
+		t.Errorf("invalid token callback called for a 404")
+	}
+
+	req, _ = client.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	err := client.Do(req, nil)
+	if !IsInvalidTokenError(err) {
+		t.Errorf("Do(): expected an invalid token error, actual %#v", err)
+	}
+
+	if calledShop != "fooshop.myshopify.com" || calledToken != client.Token() || !reflect.DeepEqual(calledErr, err) {
+		t.Errorf("invalid token callback called with %s, %s, %v", calledShop, calledToken, calledErr)
+	}
+}
+
+func TestIsInvalidTokenError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{ResponseError{Status: 401, Message: "[API] Invalid API key or access token (unrecognized login or wrong password)"}, true},
+		{fmt.Errorf("wrapped: %w", ResponseError{Status: 401, Message: "[API] Invalid API key or access token"}), true},
+		{ResponseError{Status: 401, Errors: []string{"invalid_api_key_or_access_token"}}, true},
+		{ResponseError{Status: 401, Message: "[API] This action requires merchant approval for write_orders scope."}, false},
+		{ResponseError{Status: 401}, false},
+		{ResponseError{Status: 403, Message: "[API] Invalid API key or access token"}, false},
+		{errors.New("401"), false},
+		{nil, false},
+	}
+
+	for _, c := range cases {
+		if actual := IsInvalidTokenError(c.err); actual != c.expected {
+			t.Errorf("IsInvalidTokenError(%v) = %t, expected %t", c.err, actual, c.expected)
+		}
+	}
+}
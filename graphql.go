@@ -2,8 +2,14 @@ package goshopify
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // GraphQLService is an interface to interact with the graphql endpoint
@@ -43,6 +49,81 @@ type GraphQLThrottleStatus struct {
 	RestoreRate        float64 `json:"restoreRate"`
 }
 
+// GraphQLPageInfo is the pageInfo of a GraphQL connection
+type GraphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQLUserError is an error reported in the userErrors field of a mutation
+type GraphQLUserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+	Code    string   `json:"code,omitempty"`
+}
+
+// GraphQLUserErrors is returned when a mutation reports user errors
+type GraphQLUserErrors []GraphQLUserError
+
+func (e GraphQLUserErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, userErr := range e {
+		if len(userErr.Field) > 0 {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", strings.Join(userErr.Field, "."), userErr.Message))
+		} else {
+			msgs = append(msgs, userErr.Message)
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GraphQLId returns the GraphQL id of a REST resource, e.g.
+// GraphQLId("ProductVariant", 1) is "gid://shopify/ProductVariant/1"
+func GraphQLId(resource string, id uint64) string {
+	return fmt.Sprintf("gid://shopify/%s/%d", resource, id)
+}
+
+// ParseGraphQLId returns the REST id of a GraphQL id
+func ParseGraphQLId(gid string) (uint64, error) {
+	i := strings.LastIndex(gid, "/")
+	if !strings.HasPrefix(gid, "gid://shopify/") || i < 0 {
+		return 0, fmt.Errorf("invalid GraphQL id %q", gid)
+	}
+
+	// some ids carry parameters, e.g. gid://shopify/InventoryLevel/1?inventory_item_id=2
+	id := gid[i+1:]
+	if q := strings.Index(id, "?"); q >= 0 {
+		id = id[:q]
+	}
+	return strconv.ParseUint(id, 10, 64)
+}
+
+// graphQLMoney is a MoneyV2
+type graphQLMoney struct {
+	Amount       *decimal.Decimal `json:"amount"`
+	CurrencyCode string           `json:"currencyCode"`
+}
+
+func (m graphQLMoney) amountSetEntry() AmountSetEntry {
+	return AmountSetEntry{Amount: m.Amount, CurrencyCode: m.CurrencyCode}
+}
+
+// graphQLMoneyBag is a MoneyBag, the GraphQL counterpart of AmountSet
+type graphQLMoneyBag struct {
+	ShopMoney        graphQLMoney `json:"shopMoney"`
+	PresentmentMoney graphQLMoney `json:"presentmentMoney"`
+}
+
+func (m *graphQLMoneyBag) amountSet() *AmountSet {
+	if m == nil {
+		return nil
+	}
+	return &AmountSet{
+		ShopMoney:        m.ShopMoney.amountSetEntry(),
+		PresentmentMoney: m.PresentmentMoney.amountSetEntry(),
+	}
+}
+
 type graphQLError struct {
 	Message    string                  `json:"message"`
 	Extensions *graphQLErrorExtensions `json:"extensions"`
@@ -90,8 +171,13 @@ func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp inter
 
 		if gr.Extensions != nil {
 			retryAfterSecs = gr.Extensions.Cost.RetryAfterSeconds()
+			s.client.stateMu.Lock()
 			s.client.RateLimits.GraphQLCost = &gr.Extensions.Cost
 			s.client.RateLimits.RetryAfterSeconds = retryAfterSecs
+			s.client.stateMu.Unlock()
+			if s.client.metrics != nil {
+				s.client.metrics.ObserveGraphQLCost(s.client.shop, gr.Extensions.Cost)
+			}
 		}
 
 		if len(gr.Errors) > 0 {
@@ -120,7 +206,14 @@ func (s *GraphQLServiceOp) Query(ctx context.Context, q string, vars, resp inter
 			if doRetry {
 				wait := time.Duration(math.Ceil(retryAfterSecs)) * time.Second
 				s.client.log.Debugf("rate limited waiting %s", wait.String())
-				time.Sleep(wait)
+				if s.client.metrics != nil {
+					s.client.metrics.ObserveRetry(s.client.shop, "graphql", http.StatusOK)
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
 				continue
 			}
 
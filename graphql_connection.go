@@ -0,0 +1,176 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// graphQLConnection is a page of any GraphQL connection, selected either
+// with edges or with nodes
+type graphQLConnection struct {
+	Edges []struct {
+		Node json.RawMessage `json:"node"`
+	} `json:"edges"`
+	Nodes    []json.RawMessage `json:"nodes"`
+	PageInfo *GraphQLPageInfo  `json:"pageInfo"`
+}
+
+// GraphQLConnectionIterator walks the nodes of a GraphQL connection page by
+// page, in the manner of bufio.Scanner:
+//
+//	it := client.GraphQLConnection(query, vars, "orders")
+//	for it.Next(ctx) {
+//		order := struct{ Name string }{}
+//		if err := it.Decode(&order); err != nil {
+//			return err
+//		}
+//	}
+//	return it.Err()
+//
+// The query must declare an $after: String variable, pass it as the after
+// argument of the connection and select pageInfo { hasNextPage endCursor }.
+// Before fetching the next page the iterator waits until the shop's
+// throttle bucket has restored enough points for the query, so that walking
+// a large connection does not run into THROTTLED errors.
+type GraphQLConnectionIterator struct {
+	client *Client
+	query  string
+	vars   map[string]interface{}
+	path   []string
+
+	nodes    []json.RawMessage
+	node     json.RawMessage
+	pageInfo *GraphQLPageInfo
+	err      error
+}
+
+// GraphQLConnection returns an iterator over the nodes of the connection at
+// path in the query's data, e.g. "orders" or "customer.orders".
+func (c *Client) GraphQLConnection(query string, vars map[string]interface{}, path string) *GraphQLConnectionIterator {
+	copied := make(map[string]interface{}, len(vars)+1)
+	for k, v := range vars {
+		copied[k] = v
+	}
+
+	return &GraphQLConnectionIterator{
+		client: c,
+		query:  query,
+		vars:   copied,
+		path:   strings.Split(path, "."),
+	}
+}
+
+// Next advances to the next node, fetching the next page when needed. It
+// returns false once the connection is exhausted or an error occurred, see
+// Err.
+func (it *GraphQLConnectionIterator) Next(ctx context.Context) bool {
+	for len(it.nodes) == 0 {
+		if it.err != nil || (it.pageInfo != nil && !it.pageInfo.HasNextPage) {
+			it.node = nil
+			return false
+		}
+		it.err = it.fetch(ctx)
+	}
+
+	it.node, it.nodes = it.nodes[0], it.nodes[1:]
+	return true
+}
+
+// Node returns the raw JSON of the current node
+func (it *GraphQLConnectionIterator) Node() json.RawMessage {
+	return it.node
+}
+
+// Decode unmarshals the current node into v
+func (it *GraphQLConnectionIterator) Decode(v interface{}) error {
+	return json.Unmarshal(it.node, v)
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *GraphQLConnectionIterator) Err() error {
+	return it.err
+}
+
+// PageInfo returns the page info of the last fetched page, e.g. to resume
+// the iteration later from its EndCursor.
+func (it *GraphQLConnectionIterator) PageInfo() *GraphQLPageInfo {
+	return it.pageInfo
+}
+
+func (it *GraphQLConnectionIterator) fetch(ctx context.Context) error {
+	if it.pageInfo != nil {
+		it.vars["after"] = it.pageInfo.EndCursor
+		if err := it.client.waitForGraphQLPoints(ctx); err != nil {
+			return err
+		}
+	}
+
+	var data json.RawMessage
+	if err := it.client.GraphQL.Query(ctx, it.query, it.vars, &data); err != nil {
+		return err
+	}
+
+	for _, field := range it.path {
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("graphql connection %s: %v", strings.Join(it.path, "."), err)
+		}
+		data = fields[field]
+	}
+
+	conn := graphQLConnection{}
+	if len(data) > 0 && string(data) != "null" {
+		if err := json.Unmarshal(data, &conn); err != nil {
+			return fmt.Errorf("graphql connection %s: %v", strings.Join(it.path, "."), err)
+		}
+	}
+	if conn.PageInfo == nil {
+		return fmt.Errorf("graphql connection %s: pageInfo not selected", strings.Join(it.path, "."))
+	}
+
+	it.pageInfo = conn.PageInfo
+	it.nodes = conn.Nodes
+	for _, edge := range conn.Edges {
+		it.nodes = append(it.nodes, edge.Node)
+	}
+	return nil
+}
+
+// waitForGraphQLPoints waits until the throttle bucket has restored enough
+// points to run the last query again
+func (c *Client) waitForGraphQLPoints(ctx context.Context) error {
+	cost := c.CurrentRateLimits().GraphQLCost
+	if cost == nil || cost.ThrottleStatus.RestoreRate <= 0 {
+		return nil
+	}
+
+	missing := float64(cost.RequestedQueryCost) - cost.ThrottleStatus.CurrentlyAvailable
+	if missing <= 0 {
+		return nil
+	}
+
+	wait := time.Duration(math.Ceil(missing/cost.ThrottleStatus.RestoreRate*1000)) * time.Millisecond
+	c.log.Debugf("waiting %s for graphql points", wait.String())
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+// GraphQLEachNode calls fn with every node of the connection at path, see
+// GraphQLConnection. Iteration stops at the first error returned by fn.
+func (c *Client) GraphQLEachNode(ctx context.Context, query string, vars map[string]interface{}, path string, fn func(node json.RawMessage) error) error {
+	it := c.GraphQLConnection(query, vars, path)
+	for it.Next(ctx) {
+		if err := fn(it.Node()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,165 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConnectionQuery = `query customerOrders($id: ID!, $after: String) {
+	customer(id: $id) {
+		orders(first: 2, after: $after) {
+			edges { node { name } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+func TestGraphQLConnection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"": `{"data":{"customer":{"orders":{
+			"edges":[{"node":{"name":"#1001"}},{"node":{"name":"#1002"}}],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+		"abc": `{"data":{"customer":{"orders":{
+			"edges":[{"node":{"name":"#1003"}}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"def"}}}}}`,
+	}))
+
+	vars := map[string]interface{}{"id": "gid://shopify/Customer/1"}
+	it := client.GraphQLConnection(testConnectionQuery, vars, "customer.orders")
+
+	var names []string
+	for it.Next(context.Background()) {
+		order := struct{ Name string }{}
+		if err := it.Decode(&order); err != nil {
+			t.Fatalf("GraphQLConnectionIterator.Decode returned error: %v", err)
+		}
+		names = append(names, order.Name)
+	}
+	if it.Err() != nil {
+		t.Fatalf("GraphQLConnectionIterator returned error: %v", it.Err())
+	}
+
+	expectedNames := []string{"#1001", "#1002", "#1003"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("GraphQLConnectionIterator returned %v, expected %v", names, expectedNames)
+	}
+
+	expectedSent := []graphQLRequest{
+		{Query: testConnectionQuery, Variables: map[string]interface{}{"id": "gid://shopify/Customer/1"}},
+		{Query: testConnectionQuery, Variables: map[string]interface{}{"id": "gid://shopify/Customer/1", "after": "abc"}},
+	}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("GraphQLConnectionIterator sent %+v, expected %+v", sent, expectedSent)
+	}
+	if len(vars) != 1 {
+		t.Errorf("GraphQLConnectionIterator modified the given variables %+v", vars)
+	}
+	if it.PageInfo().EndCursor != "def" || it.Next(context.Background()) {
+		t.Errorf("GraphQLConnectionIterator continued after the last page")
+	}
+}
+
+func TestGraphQLConnectionNodesAndEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"":  `{"data":{"orders":{"nodes":[],"pageInfo":{"hasNextPage":true,"endCursor":"a"}}}}`,
+		"a": `{"data":{"orders":{"nodes":[{"name":"#1001"}],"pageInfo":{"hasNextPage":false,"endCursor":"b"}}}}`,
+	}))
+
+	var nodes []string
+	err := client.GraphQLEachNode(context.Background(), "query", nil, "orders", func(node json.RawMessage) error {
+		nodes = append(nodes, string(node))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Client.GraphQLEachNode returned error: %v", err)
+	}
+	if !reflect.DeepEqual(nodes, []string{`{"name":"#1001"}`}) || len(sent) != 2 {
+		t.Errorf("Client.GraphQLEachNode returned %v after %d requests", nodes, len(sent))
+	}
+}
+
+func TestGraphQLConnectionErrors(t *testing.T) {
+	cases := []struct {
+		response string
+		path     string
+		expected string
+	}{
+		{`{"data":{"orders":{"nodes":[]}}}`, "orders", "graphql connection orders: pageInfo not selected"},
+		{`{"data":{"customer":null}}`, "customer.orders", "graphql connection customer.orders"},
+		{`{"errors":[{"message":"Field 'foo' doesn't exist on type 'QueryRoot'"}]}`, "orders", "Field 'foo' doesn't exist on type 'QueryRoot'"},
+	}
+
+	for _, c := range cases {
+		setup()
+		var sent []graphQLRequest
+		registerGraphQL(t, &sent, graphQLPages(map[string]string{"": c.response}))
+
+		it := client.GraphQLConnection("query", nil, c.path)
+		if it.Next(context.Background()) || it.Err() == nil || !strings.Contains(it.Err().Error(), c.expected) {
+			t.Errorf("GraphQLConnectionIterator returned %v, expected %s", it.Err(), c.expected)
+		}
+		teardown()
+	}
+}
+
+func TestGraphQLEachNodeStops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"": `{"data":{"orders":{"nodes":[{"name":"#1001"},{"name":"#1002"}],"pageInfo":{"hasNextPage":true,"endCursor":"a"}}}}`,
+	}))
+
+	stop := errors.New("stop")
+	calls := 0
+	err := client.GraphQLEachNode(context.Background(), "query", nil, "orders", func(node json.RawMessage) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 || len(sent) != 1 {
+		t.Errorf("Client.GraphQLEachNode returned %v after %d calls and %d requests", err, calls, len(sent))
+	}
+}
+
+func TestWaitForGraphQLPoints(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.RateLimits.GraphQLCost = &GraphQLCost{
+		RequestedQueryCost: 102,
+		ThrottleStatus: GraphQLThrottleStatus{
+			MaximumAvailable:   1000,
+			CurrentlyAvailable: 100,
+			RestoreRate:        100,
+		},
+	}
+
+	start := time.Now()
+	if err := client.waitForGraphQLPoints(context.Background()); err != nil {
+		t.Fatalf("Client.waitForGraphQLPoints returned error: %v", err)
+	}
+	if waited := time.Since(start); waited < 20*time.Millisecond {
+		t.Errorf("Client.waitForGraphQLPoints waited %s, expected 20ms", waited)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.RateLimits.GraphQLCost.RequestedQueryCost = 1000
+	if err := client.waitForGraphQLPoints(ctx); err != context.Canceled {
+		t.Errorf("Client.waitForGraphQLPoints returned %v, expected %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,74 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// %s is the count field, e.g. ordersCount
+const graphQLCountQuery = `query count($query: String, $limit: Int) {
+  %s(query: $query, limit: $limit) {
+    count
+    precision
+  }
+}`
+
+// precision of an exact GraphQL count, other counts are lower bounds
+const graphQLCountPrecisionExact = "EXACT"
+
+// InexactCountError is returned by CountExact when Shopify only returned a
+// lower bound of the count.
+type InexactCountError struct {
+	Count     int
+	Precision string
+}
+
+func (e InexactCountError) Error() string {
+	return fmt.Sprintf("count is %s %d", e.Precision, e.Count)
+}
+
+// GraphQLCount counts resources with a GraphQL count field, e.g. ordersCount,
+// without its default limit. query is in the Shopify search syntax, see
+// SearchQuery. Unlike the REST count endpoints it isn't capped nor limited to
+// recent resources.
+func (c *Client) GraphQLCount(ctx context.Context, field, query string) (int, error) {
+	resp := map[string]*struct {
+		Count     int    `json:"count"`
+		Precision string `json:"precision"`
+	}{}
+
+	vars := map[string]interface{}{
+		// an explicit null lifts the default limit of 10000
+		"limit": nil,
+	}
+	if query != "" {
+		vars["query"] = query
+	}
+
+	err := c.GraphQL.Query(ctx, fmt.Sprintf(graphQLCountQuery, field), vars, &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	count := resp[field]
+	if count == nil {
+		return 0, fmt.Errorf("no %s in response", field)
+	}
+	if count.Precision != graphQLCountPrecisionExact {
+		return count.Count, InexactCountError{Count: count.Count, Precision: count.Precision}
+	}
+	return count.Count, nil
+}
+
+// CountExact counts the orders matching a query in the Shopify search syntax,
+// e.g. OrderSearchQuery{}.String(), through the GraphQL API. Unlike Count it
+// includes orders older than 60 days.
+func (s *OrderServiceOp) CountExact(ctx context.Context, query string) (int, error) {
+	return s.client.GraphQLCount(ctx, "ordersCount", query)
+}
+
+// CountExact counts the customers matching a query in the Shopify search
+// syntax through the GraphQL API.
+func (s *CustomerServiceOp) CountExact(ctx context.Context, query string) (int, error) {
+	return s.client.GraphQLCount(ctx, "customersCount", query)
+}
@@ -0,0 +1,83 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOrderCountExact(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query, vars = req.Query, req.Variables
+		return `{"data":{"ordersCount":{"count":123456,"precision":"EXACT"}}}`
+	})
+
+	count, err := client.Order.CountExact(context.Background(), "status:any")
+	if err != nil {
+		t.Fatalf("Order.CountExact returned error: %v", err)
+	}
+
+	if !strings.Contains(query, "ordersCount(query: $query, limit: $limit)") {
+		t.Errorf("Order.CountExact sent query %s", query)
+	}
+
+	expectedVars := map[string]interface{}{"query": "status:any", "limit": nil}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Order.CountExact sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if count != 123456 {
+		t.Errorf("Order.CountExact returned %d, expected %d", count, 123456)
+	}
+}
+
+func TestCustomerCountExact(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query, vars = req.Query, req.Variables
+		return `{"data":{"customersCount":{"count":42,"precision":"EXACT"}}}`
+	})
+
+	count, err := client.Customer.CountExact(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Customer.CountExact returned error: %v", err)
+	}
+
+	if !strings.Contains(query, "customersCount(") {
+		t.Errorf("Customer.CountExact sent query %s", query)
+	}
+	if _, ok := vars["query"]; ok {
+		t.Errorf("Customer.CountExact sent a query for an empty query: %+v", vars)
+	}
+	if count != 42 {
+		t.Errorf("Customer.CountExact returned %d, expected %d", count, 42)
+	}
+}
+
+func TestGraphQLCountInexact(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"ordersCount":{"count":10000,"precision":"AT_LEAST"}}}`
+	})
+
+	count, err := client.Order.CountExact(context.Background(), "")
+	expected := InexactCountError{Count: 10000, Precision: "AT_LEAST"}
+	if err != expected {
+		t.Errorf("Order.CountExact err returned %v, expected %v", err, expected)
+	}
+	if count != 10000 {
+		t.Errorf("Order.CountExact returned %d, expected the lower bound %d", count, 10000)
+	}
+}
@@ -0,0 +1,566 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Resources whose reads can be served through the GraphQL API, see
+// WithGraphQLReads
+const (
+	GraphQLReadOrders   = "orders"
+	GraphQLReadProducts = "products"
+)
+
+// readsGraphQL reports whether reads of the resource go through GraphQL
+func (c *Client) readsGraphQL(resource string) bool {
+	return c.graphQLReads[resource]
+}
+
+// graphQLNotFound mirrors the error the REST API returns for unknown ids
+func graphQLNotFound() error {
+	return ResponseError{Status: http.StatusNotFound, Message: "Not Found"}
+}
+
+// graphQLReadsPageSize is the number of line items, images and variants per
+// page of GraphQL reads. Their nested fields make 250 nodes a page exceed the
+// 1000 points a single query may cost.
+const graphQLReadsPageSize = 50
+
+const graphQLAddressFields = `address1 address2 city company country countryCodeV2 firstName lastName
+	latitude longitude name phone province provinceCode zip`
+
+const orderGetQuery = `query orderGet($id: ID!, $first: Int!) {
+	order(id: $id) {
+		legacyResourceId
+		name
+		email
+		phone
+		note
+		tags
+		test
+		createdAt
+		updatedAt
+		processedAt
+		closedAt
+		cancelledAt
+		displayFinancialStatus
+		displayFulfillmentStatus
+		currencyCode
+		totalPriceSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+		currentTotalPriceSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+		subtotalPriceSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+		totalDiscountsSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+		totalTaxSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+		customAttributes { key value }
+		customer { legacyResourceId email firstName lastName phone }
+		billingAddress { ` + graphQLAddressFields + ` }
+		shippingAddress { ` + graphQLAddressFields + ` }
+		purchasingEntity { ` + purchasingCompanyFragment + ` }
+		lineItems(first: $first) {
+			nodes { ` + graphQLLineItemFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+const graphQLLineItemFields = `id name title variantTitle sku vendor quantity taxable requiresShipping
+	isGiftCard unfulfilledQuantity
+	originalUnitPriceSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+	totalDiscountSet { shopMoney { amount currencyCode } presentmentMoney { amount currencyCode } }
+	customAttributes { key value }
+	product { legacyResourceId }
+	variant { legacyResourceId }`
+
+// orderLineItemsQuery gets the line items of an order after the first page
+const orderLineItemsQuery = `query orderLineItems($id: ID!, $first: Int!, $after: String) {
+	order(id: $id) {
+		lineItems(first: $first, after: $after) {
+			nodes { ` + graphQLLineItemFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+const productGetQuery = `query productGet($id: ID!, $first: Int!) {
+	product(id: $id) {
+		legacyResourceId
+		title
+		descriptionHtml
+		vendor
+		productType
+		handle
+		createdAt
+		updatedAt
+		publishedAt
+		status
+		tags
+		templateSuffix
+		options { id name position values }
+		images(first: $first) {
+			nodes { ` + graphQLProductImageFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+		variants(first: $first) {
+			nodes { ` + graphQLProductVariantFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+const graphQLProductImageFields = `id altText width height url`
+
+const graphQLProductVariantFields = `legacyResourceId title sku position price compareAtPrice barcode taxable
+	inventoryQuantity inventoryPolicy createdAt updatedAt
+	selectedOptions { value }
+	inventoryItem { legacyResourceId }
+	image { id }`
+
+// productImagesQuery gets the images of a product after the first page
+const productImagesQuery = `query productImages($id: ID!, $first: Int!, $after: String) {
+	product(id: $id) {
+		images(first: $first, after: $after) {
+			nodes { ` + graphQLProductImageFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+// productVariantsQuery gets the variants of a product after the first page
+const productVariantsQuery = `query productVariants($id: ID!, $first: Int!, $after: String) {
+	product(id: $id) {
+		variants(first: $first, after: $after) {
+			nodes { ` + graphQLProductVariantFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+type graphQLAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func noteAttributes(attributes []graphQLAttribute) []NoteAttribute {
+	if len(attributes) == 0 {
+		return nil
+	}
+	result := make([]NoteAttribute, len(attributes))
+	for i, a := range attributes {
+		result[i] = NoteAttribute{Name: a.Key, Value: a.Value}
+	}
+	return result
+}
+
+type graphQLAddress struct {
+	Address1      string  `json:"address1"`
+	Address2      string  `json:"address2"`
+	City          string  `json:"city"`
+	Company       string  `json:"company"`
+	Country       string  `json:"country"`
+	CountryCodeV2 string  `json:"countryCodeV2"`
+	FirstName     string  `json:"firstName"`
+	LastName      string  `json:"lastName"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	Name          string  `json:"name"`
+	Phone         string  `json:"phone"`
+	Province      string  `json:"province"`
+	ProvinceCode  string  `json:"provinceCode"`
+	Zip           string  `json:"zip"`
+}
+
+func (a *graphQLAddress) address() *Address {
+	if a == nil {
+		return nil
+	}
+	return &Address{
+		Address1:     a.Address1,
+		Address2:     a.Address2,
+		City:         a.City,
+		Company:      a.Company,
+		Country:      a.Country,
+		CountryCode:  a.CountryCodeV2,
+		FirstName:    a.FirstName,
+		LastName:     a.LastName,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+		Name:         a.Name,
+		Phone:        a.Phone,
+		Province:     a.Province,
+		ProvinceCode: a.ProvinceCode,
+		Zip:          a.Zip,
+	}
+}
+
+type graphQLLegacyResource struct {
+	LegacyResourceId string `json:"legacyResourceId"`
+}
+
+func (r *graphQLLegacyResource) id() uint64 {
+	if r == nil {
+		return 0
+	}
+	id, _ := strconv.ParseUint(r.LegacyResourceId, 10, 64)
+	return id
+}
+
+type graphQLLineItem struct {
+	Id                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	Title                string                 `json:"title"`
+	VariantTitle         string                 `json:"variantTitle"`
+	Sku                  string                 `json:"sku"`
+	Vendor               string                 `json:"vendor"`
+	Quantity             int                    `json:"quantity"`
+	Taxable              bool                   `json:"taxable"`
+	RequiresShipping     bool                   `json:"requiresShipping"`
+	IsGiftCard           bool                   `json:"isGiftCard"`
+	UnfulfilledQuantity  int                    `json:"unfulfilledQuantity"`
+	OriginalUnitPriceSet *graphQLMoneyBag       `json:"originalUnitPriceSet"`
+	TotalDiscountSet     *graphQLMoneyBag       `json:"totalDiscountSet"`
+	CustomAttributes     []graphQLAttribute     `json:"customAttributes"`
+	Product              *graphQLLegacyResource `json:"product"`
+	Variant              *graphQLLegacyResource `json:"variant"`
+}
+
+func (n graphQLLineItem) lineItem() LineItem {
+	id, _ := ParseGraphQLId(n.Id)
+	item := LineItem{
+		Id:                  id,
+		ProductId:           n.Product.id(),
+		VariantId:           n.Variant.id(),
+		Quantity:            n.Quantity,
+		PriceSet:            n.OriginalUnitPriceSet.amountSet(),
+		TotalDiscountSet:    n.TotalDiscountSet.amountSet(),
+		Title:               n.Title,
+		VariantTitle:        n.VariantTitle,
+		Name:                n.Name,
+		SKU:                 n.Sku,
+		Vendor:              n.Vendor,
+		GiftCard:            n.IsGiftCard,
+		Taxable:             n.Taxable,
+		RequiresShipping:    n.RequiresShipping,
+		Properties:          noteAttributes(n.CustomAttributes),
+		ProductExists:       n.Product != nil,
+		FulfillableQuantity: n.UnfulfilledQuantity,
+	}
+	if item.PriceSet != nil {
+		item.Price = item.PriceSet.ShopMoney.Amount
+	}
+	if item.TotalDiscountSet != nil {
+		item.TotalDiscount = item.TotalDiscountSet.ShopMoney.Amount
+	}
+	return item
+}
+
+type graphQLOrder struct {
+	orderSearchNode
+	Phone                string                `json:"phone"`
+	Test                 bool                  `json:"test"`
+	ClosedAt             *time.Time            `json:"closedAt"`
+	TotalPriceSet        *graphQLMoneyBag      `json:"totalPriceSet"`
+	CurrentTotalPriceSet *graphQLMoneyBag      `json:"currentTotalPriceSet"`
+	SubtotalPriceSet     *graphQLMoneyBag      `json:"subtotalPriceSet"`
+	TotalDiscountsSet    *graphQLMoneyBag      `json:"totalDiscountsSet"`
+	TotalTaxSet          *graphQLMoneyBag      `json:"totalTaxSet"`
+	CustomAttributes     []graphQLAttribute    `json:"customAttributes"`
+	Customer             *graphQLOrderCustomer `json:"customer"`
+	BillingAddress       *graphQLAddress       `json:"billingAddress"`
+	ShippingAddress      *graphQLAddress       `json:"shippingAddress"`
+	LineItems            struct {
+		Nodes    []graphQLLineItem `json:"nodes"`
+		PageInfo GraphQLPageInfo   `json:"pageInfo"`
+	} `json:"lineItems"`
+}
+
+type graphQLOrderCustomer struct {
+	graphQLLegacyResource
+	Email     string `json:"email"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Phone     string `json:"phone"`
+}
+
+func (n graphQLOrder) order() *Order {
+	order := n.orderSearchNode.order()
+	order.Phone = n.Phone
+	order.Test = n.Test
+	order.ClosedAt = n.ClosedAt
+	order.TotalPriceSet = n.TotalPriceSet.amountSet()
+	order.CurrentTotalPriceSet = n.CurrentTotalPriceSet.amountSet()
+	order.SubtotalPriceSet = n.SubtotalPriceSet.amountSet()
+	order.TotalDiscountsSet = n.TotalDiscountsSet.amountSet()
+	order.TotalTaxSet = n.TotalTaxSet.amountSet()
+	order.NoteAttributes = noteAttributes(n.CustomAttributes)
+	order.BillingAddress = n.BillingAddress.address()
+	order.ShippingAddress = n.ShippingAddress.address()
+
+	if n.TotalPriceSet != nil {
+		order.TotalPrice = n.TotalPriceSet.ShopMoney.Amount
+	}
+	if n.CurrentTotalPriceSet != nil {
+		order.CurrentTotalPrice = n.CurrentTotalPriceSet.ShopMoney.Amount
+	}
+	if n.SubtotalPriceSet != nil {
+		order.SubtotalPrice = n.SubtotalPriceSet.ShopMoney.Amount
+	}
+	if n.TotalDiscountsSet != nil {
+		order.TotalDiscounts = n.TotalDiscountsSet.ShopMoney.Amount
+	}
+	if n.TotalTaxSet != nil {
+		order.TotalTax = n.TotalTaxSet.ShopMoney.Amount
+	}
+	if n.Customer != nil {
+		order.Customer = &Customer{
+			Id:        n.Customer.id(),
+			Email:     n.Customer.Email,
+			FirstName: n.Customer.FirstName,
+			LastName:  n.Customer.LastName,
+			Phone:     n.Customer.Phone,
+		}
+	}
+	for _, item := range n.LineItems.Nodes {
+		order.LineItems = append(order.LineItems, item.lineItem())
+	}
+	return &order
+}
+
+// getGraphQL reads the order through the GraphQL API, see WithGraphQLReads
+func (s *OrderServiceOp) getGraphQL(ctx context.Context, orderId uint64) (*Order, error) {
+	resp := struct {
+		Order *graphQLOrder `json:"order"`
+	}{}
+	vars := map[string]interface{}{"id": GraphQLId("Order", orderId), "first": graphQLReadsPageSize}
+	if err := s.client.GraphQL.Query(ctx, orderGetQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Order == nil {
+		return nil, graphQLNotFound()
+	}
+
+	lineItems := &resp.Order.LineItems
+	err := s.client.graphQLRemainingNodes(ctx, orderLineItemsQuery, vars, "order.lineItems", lineItems.PageInfo, func(node json.RawMessage) error {
+		item := graphQLLineItem{}
+		if err := json.Unmarshal(node, &item); err != nil {
+			return err
+		}
+		lineItems.Nodes = append(lineItems.Nodes, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Order.order(), nil
+}
+
+// graphQLRemainingNodes calls fn with the nodes of a connection following
+// its first page, so reads aren't truncated at a page. The query gets the
+// connection of the resource with the id and page size in vars after the
+// $after cursor.
+func (c *Client) graphQLRemainingNodes(ctx context.Context, query string, vars map[string]interface{}, path string, pageInfo GraphQLPageInfo, fn func(node json.RawMessage) error) error {
+	if !pageInfo.HasNextPage {
+		return nil
+	}
+	return c.GraphQLEachNode(ctx, query, map[string]interface{}{"id": vars["id"], "first": vars["first"], "after": pageInfo.EndCursor}, path, fn)
+}
+
+type graphQLProductVariant struct {
+	LegacyResourceId  string                 `json:"legacyResourceId"`
+	Title             string                 `json:"title"`
+	Sku               string                 `json:"sku"`
+	Position          int                    `json:"position"`
+	Price             *decimal.Decimal       `json:"price"`
+	CompareAtPrice    *decimal.Decimal       `json:"compareAtPrice"`
+	Barcode           string                 `json:"barcode"`
+	Taxable           bool                   `json:"taxable"`
+	InventoryQuantity int                    `json:"inventoryQuantity"`
+	InventoryPolicy   string                 `json:"inventoryPolicy"`
+	CreatedAt         *time.Time             `json:"createdAt"`
+	UpdatedAt         *time.Time             `json:"updatedAt"`
+	InventoryItem     *graphQLLegacyResource `json:"inventoryItem"`
+	Image             *struct {
+		Id string `json:"id"`
+	} `json:"image"`
+	SelectedOptions []struct {
+		Value string `json:"value"`
+	} `json:"selectedOptions"`
+}
+
+func (n graphQLProductVariant) variant(productId uint64) Variant {
+	id, _ := strconv.ParseUint(n.LegacyResourceId, 10, 64)
+	variant := Variant{
+		Id:                id,
+		ProductId:         productId,
+		Title:             n.Title,
+		Sku:               n.Sku,
+		Position:          n.Position,
+		InventoryPolicy:   VariantInventoryPolicy(strings.ToLower(n.InventoryPolicy)),
+		Price:             n.Price,
+		CompareAtPrice:    n.CompareAtPrice,
+		InventoryItemId:   n.InventoryItem.id(),
+		CreatedAt:         n.CreatedAt,
+		UpdatedAt:         n.UpdatedAt,
+		Taxable:           n.Taxable,
+		Barcode:           n.Barcode,
+		InventoryQuantity: n.InventoryQuantity,
+		AdminGraphqlApiId: GraphQLId("ProductVariant", id),
+	}
+	if n.Image != nil {
+		variant.ImageId, _ = ParseGraphQLId(n.Image.Id)
+	}
+	for i, option := range n.SelectedOptions {
+		switch i {
+		case 0:
+			variant.Option1 = option.Value
+		case 1:
+			variant.Option2 = option.Value
+		case 2:
+			variant.Option3 = option.Value
+		}
+	}
+	return variant
+}
+
+type graphQLProduct struct {
+	LegacyResourceId string     `json:"legacyResourceId"`
+	Title            string     `json:"title"`
+	DescriptionHtml  string     `json:"descriptionHtml"`
+	Vendor           string     `json:"vendor"`
+	ProductType      string     `json:"productType"`
+	Handle           string     `json:"handle"`
+	CreatedAt        *time.Time `json:"createdAt"`
+	UpdatedAt        *time.Time `json:"updatedAt"`
+	PublishedAt      *time.Time `json:"publishedAt"`
+	Status           string     `json:"status"`
+	Tags             []string   `json:"tags"`
+	TemplateSuffix   string     `json:"templateSuffix"`
+	Options          []struct {
+		Id       string   `json:"id"`
+		Name     string   `json:"name"`
+		Position int      `json:"position"`
+		Values   []string `json:"values"`
+	} `json:"options"`
+	Images struct {
+		Nodes    []graphQLProductImage `json:"nodes"`
+		PageInfo GraphQLPageInfo       `json:"pageInfo"`
+	} `json:"images"`
+	Variants struct {
+		Nodes    []graphQLProductVariant `json:"nodes"`
+		PageInfo GraphQLPageInfo         `json:"pageInfo"`
+	} `json:"variants"`
+}
+
+type graphQLProductImage struct {
+	Id      string `json:"id"`
+	AltText string `json:"altText"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Url     string `json:"url"`
+}
+
+func (n graphQLProduct) product() *Product {
+	id, _ := strconv.ParseUint(n.LegacyResourceId, 10, 64)
+	product := &Product{
+		Id:                id,
+		Title:             n.Title,
+		BodyHTML:          n.DescriptionHtml,
+		Vendor:            n.Vendor,
+		ProductType:       n.ProductType,
+		Handle:            n.Handle,
+		CreatedAt:         n.CreatedAt,
+		UpdatedAt:         n.UpdatedAt,
+		PublishedAt:       n.PublishedAt,
+		Tags:              strings.Join(n.Tags, ", "),
+		Status:            ProductStatus(strings.ToLower(n.Status)),
+		TemplateSuffix:    n.TemplateSuffix,
+		AdminGraphqlApiId: GraphQLId("Product", id),
+	}
+
+	for _, o := range n.Options {
+		optionId, _ := ParseGraphQLId(o.Id)
+		product.Options = append(product.Options, ProductOption{
+			Id:        optionId,
+			ProductId: id,
+			Name:      o.Name,
+			Position:  o.Position,
+			Values:    o.Values,
+		})
+	}
+
+	imagePositions := map[uint64]int{}
+	for i, img := range n.Images.Nodes {
+		imageId, _ := ParseGraphQLId(img.Id)
+		imagePositions[imageId] = i
+		product.Images = append(product.Images, Image{
+			Id:                imageId,
+			ProductId:         id,
+			Position:          i + 1,
+			Width:             img.Width,
+			Height:            img.Height,
+			Src:               img.Url,
+			Alt:               img.AltText,
+			AdminGraphqlApiId: img.Id,
+		})
+	}
+
+	for _, v := range n.Variants.Nodes {
+		variant := v.variant(id)
+		product.Variants = append(product.Variants, variant)
+		if i, ok := imagePositions[variant.ImageId]; ok {
+			product.Images[i].VariantIds = append(product.Images[i].VariantIds, variant.Id)
+		}
+	}
+
+	if len(product.Images) > 0 {
+		product.Image = product.Images[0]
+	}
+	return product
+}
+
+// getGraphQL reads the product through the GraphQL API, see WithGraphQLReads
+func (s *ProductServiceOp) getGraphQL(ctx context.Context, productId uint64) (*Product, error) {
+	resp := struct {
+		Product *graphQLProduct `json:"product"`
+	}{}
+	vars := map[string]interface{}{"id": GraphQLId("Product", productId), "first": graphQLReadsPageSize}
+	if err := s.client.GraphQL.Query(ctx, productGetQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Product == nil {
+		return nil, graphQLNotFound()
+	}
+
+	images := &resp.Product.Images
+	err := s.client.graphQLRemainingNodes(ctx, productImagesQuery, vars, "product.images", images.PageInfo, func(node json.RawMessage) error {
+		image := graphQLProductImage{}
+		if err := json.Unmarshal(node, &image); err != nil {
+			return err
+		}
+		images.Nodes = append(images.Nodes, image)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	variants := &resp.Product.Variants
+	err = s.client.graphQLRemainingNodes(ctx, productVariantsQuery, vars, "product.variants", variants.PageInfo, func(node json.RawMessage) error {
+		variant := graphQLProductVariant{}
+		if err := json.Unmarshal(node, &variant); err != nil {
+			return err
+		}
+		variants.Nodes = append(variants.Nodes, variant)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Product.product(), nil
+}
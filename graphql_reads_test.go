@@ -0,0 +1,272 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderGetGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLReads(GraphQLReadOrders)(client)
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"order":{
+			"legacyResourceId":"450789469",
+			"name":"#1001",
+			"email":"bob.norman@example.com",
+			"phone":"+15555550100",
+			"tags":["vip","wholesale"],
+			"test":true,
+			"displayFinancialStatus":"PAID",
+			"displayFulfillmentStatus":"FULFILLED",
+			"currencyCode":"USD",
+			"totalPriceSet":{"shopMoney":{"amount":"409.94","currencyCode":"USD"},"presentmentMoney":{"amount":"540.12","currencyCode":"CAD"}},
+			"subtotalPriceSet":{"shopMoney":{"amount":"398.0","currencyCode":"USD"},"presentmentMoney":{"amount":"524.39","currencyCode":"CAD"}},
+			"customAttributes":[{"key":"gift","value":"yes"}],
+			"customer":{"legacyResourceId":"207119551","email":"bob.norman@example.com","firstName":"Bob","lastName":"Norman"},
+			"shippingAddress":{"address1":"123 Amoebobacterieae St","city":"Ottawa","countryCodeV2":"CA","provinceCode":"ON","zip":"K2P0V6"},
+			"lineItems":{"nodes":[{
+				"id":"gid://shopify/LineItem/466157049",
+				"title":"IPod Nano - 8gb",
+				"sku":"IPOD2008GREEN",
+				"quantity":1,
+				"unfulfilledQuantity":0,
+				"originalUnitPriceSet":{"shopMoney":{"amount":"199.0","currencyCode":"USD"},"presentmentMoney":{"amount":"262.19","currencyCode":"CAD"}},
+				"product":{"legacyResourceId":"632910392"},
+				"variant":{"legacyResourceId":"39072856"}
+			}]}
+		}}}`
+	})
+
+	order, err := client.Order.Get(context.Background(), 450789469, map[string]string{"fields": "id"})
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Order/450789469", "first": float64(50)}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Order.Get sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if order.Id != 450789469 || order.Tags != "vip, wholesale" || !order.Test {
+		t.Errorf("Order.Get returned %+v", order)
+	}
+	if order.FinancialStatus != OrderFinancialStatusPaid || order.FulfillmentStatus != OrderFulfillmentStatusFulfilled {
+		t.Errorf("Order.Get returned statuses %s, %s", order.FinancialStatus, order.FulfillmentStatus)
+	}
+	if !order.TotalPrice.Equal(decimal.RequireFromString("409.94")) || !order.SubtotalPrice.Equal(decimal.RequireFromString("398")) {
+		t.Errorf("Order.Get returned total %s, subtotal %s", order.TotalPrice, order.SubtotalPrice)
+	}
+	if order.TotalPriceSet.In("CAD") == nil {
+		t.Errorf("Order.Get returned total price set %+v", order.TotalPriceSet)
+	}
+	if order.Customer == nil || order.Customer.Id != 207119551 || order.Customer.FirstName != "Bob" {
+		t.Errorf("Order.Get returned customer %+v", order.Customer)
+	}
+	if order.BillingAddress != nil || order.ShippingAddress == nil || order.ShippingAddress.CountryCode != "CA" {
+		t.Errorf("Order.Get returned addresses %+v, %+v", order.BillingAddress, order.ShippingAddress)
+	}
+
+	expectedAttributes := []NoteAttribute{{Name: "gift", Value: "yes"}}
+	if !reflect.DeepEqual(order.NoteAttributes, expectedAttributes) {
+		t.Errorf("Order.Get returned note attributes %+v, expected %+v", order.NoteAttributes, expectedAttributes)
+	}
+
+	if len(order.LineItems) != 1 {
+		t.Fatalf("Order.Get returned %d line items, expected 1", len(order.LineItems))
+	}
+	item := order.LineItems[0]
+	if item.Id != 466157049 || item.ProductId != 632910392 || item.VariantId != 39072856 || item.SKU != "IPOD2008GREEN" {
+		t.Errorf("Order.Get returned line item %+v", item)
+	}
+	if !item.Price.Equal(decimal.RequireFromString("199")) || !item.ProductExists {
+		t.Errorf("Order.Get returned line item price %s, product exists %v", item.Price, item.ProductExists)
+	}
+}
+
+func TestOrderGetGraphQLNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLReads(GraphQLReadOrders)(client)
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"order":null}}`
+	})
+
+	_, err := client.Order.Get(context.Background(), 1, nil)
+	if rerr, ok := err.(ResponseError); !ok || rerr.GetStatus() != 404 {
+		t.Errorf("Order.Get returned error %v, expected a 404 ResponseError", err)
+	}
+}
+
+func TestProductGetGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLReads(GraphQLReadProducts)(client)
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"product":{
+			"legacyResourceId":"632910392",
+			"title":"IPod Nano - 8GB",
+			"descriptionHtml":"<p>It's the small iPod with one very big idea.</p>",
+			"vendor":"Apple",
+			"handle":"ipod-nano",
+			"status":"ACTIVE",
+			"tags":["Emotive","Flash Memory"],
+			"options":[{"id":"gid://shopify/ProductOption/594680422","name":"Color","position":1,"values":["Pink","Red"]}],
+			"images":{"nodes":[
+				{"id":"gid://shopify/ProductImage/850703190","altText":"front","width":123,"height":456,"url":"https://cdn.shopify.com/ipod-nano.png"}
+			]},
+			"variants":{"nodes":[
+				{"legacyResourceId":"808950810","title":"Pink","sku":"IPOD2008PINK","position":1,"price":"199.00","inventoryPolicy":"CONTINUE",
+				 "selectedOptions":[{"value":"Pink"}],"inventoryItem":{"legacyResourceId":"808950810"},"image":{"id":"gid://shopify/ProductImage/850703190"}},
+				{"legacyResourceId":"49148385","title":"Red","sku":"IPOD2008RED","position":2,"price":"199.00","inventoryPolicy":"DENY",
+				 "selectedOptions":[{"value":"Red"}],"image":null}
+			]}
+		}}}`
+	})
+
+	product, err := client.Product.Get(context.Background(), 632910392, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Product/632910392", "first": float64(50)}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Product.Get sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	if product.Id != 632910392 || product.Status != ProductStatusActive || product.Tags != "Emotive, Flash Memory" {
+		t.Errorf("Product.Get returned %+v", product)
+	}
+	if product.AdminGraphqlApiId != "gid://shopify/Product/632910392" {
+		t.Errorf("Product.Get returned admin graphql api id %s", product.AdminGraphqlApiId)
+	}
+
+	expectedOptions := []ProductOption{{Id: 594680422, ProductId: 632910392, Name: "Color", Position: 1, Values: []string{"Pink", "Red"}}}
+	if !reflect.DeepEqual(product.Options, expectedOptions) {
+		t.Errorf("Product.Get returned options %+v, expected %+v", product.Options, expectedOptions)
+	}
+
+	if len(product.Variants) != 2 {
+		t.Fatalf("Product.Get returned %d variants, expected 2", len(product.Variants))
+	}
+	pink := product.Variants[0]
+	if pink.Id != 808950810 || pink.ProductId != 632910392 || pink.Option1 != "Pink" || pink.ImageId != 850703190 || pink.InventoryItemId != 808950810 {
+		t.Errorf("Product.Get returned variant %+v", pink)
+	}
+	if pink.InventoryPolicy != VariantInventoryPolicyContinue || product.Variants[1].InventoryPolicy != VariantInventoryPolicyDeny {
+		t.Errorf("Product.Get returned inventory policies %s, %s", pink.InventoryPolicy, product.Variants[1].InventoryPolicy)
+	}
+
+	if len(product.Images) != 1 || product.Image.Src != "https://cdn.shopify.com/ipod-nano.png" {
+		t.Fatalf("Product.Get returned images %+v", product.Images)
+	}
+	expectedVariantIds := []uint64{808950810}
+	if product.Images[0].Position != 1 || !reflect.DeepEqual(product.Images[0].VariantIds, expectedVariantIds) {
+		t.Errorf("Product.Get returned image %+v", product.Images[0])
+	}
+}
+
+func TestProductGetRESTByDefault(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLReads(GraphQLReadOrders)(client)
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{}}`
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":1}}`))
+
+	product, err := client.Product.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if product.Id != 1 || vars != nil {
+		t.Errorf("Product.Get returned %+v, graphql variables %+v", product, vars)
+	}
+}
+
+func TestProductGetGraphQLPaginatesVariants(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLReads(GraphQLReadProducts)(client)
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		productGetQuery: `{"data":{"product":{
+			"legacyResourceId":"632910392",
+			"images":{"nodes":[{"id":"gid://shopify/ProductImage/850703190","url":"https://cdn.shopify.com/ipod.png"}],
+				"pageInfo":{"hasNextPage":false}},
+			"variants":{"nodes":[{"legacyResourceId":"1","sku":"SKU-1"}],
+				"pageInfo":{"hasNextPage":true,"endCursor":"cursor1"}}
+		}}}`,
+		productVariantsQuery: `{"data":{"product":{"variants":{
+			"nodes":[{"legacyResourceId":"2","sku":"SKU-2","image":{"id":"gid://shopify/ProductImage/850703190"}}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"cursor2"}
+		}}}}`,
+	}))
+
+	product, err := client.Product.Get(context.Background(), 632910392, nil)
+	if err != nil {
+		t.Fatalf("Product.Get returned error: %v", err)
+	}
+	if len(product.Variants) != 2 || product.Variants[1].Sku != "SKU-2" {
+		t.Fatalf("Product.Get returned variants %+v, expected both pages", product.Variants)
+	}
+	if !reflect.DeepEqual(product.Images[0].VariantIds, []uint64{2}) {
+		t.Errorf("Product.Get returned image variants %v, expected [2]", product.Images[0].VariantIds)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Product/632910392", "first": float64(50), "after": "cursor1"}
+	if len(sent) != 2 || !reflect.DeepEqual(sent[1].Variables, expectedVars) {
+		t.Errorf("Product.Get sent %+v, expected the variants after cursor1", sent)
+	}
+}
+
+func TestOrderGetGraphQLPaginatesLineItems(t *testing.T) {
+	setup()
+	defer teardown()
+	WithGraphQLReads(GraphQLReadOrders)(client)
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderGetQuery: `{"data":{"order":{
+			"legacyResourceId":"450789469",
+			"lineItems":{"nodes":[{"id":"gid://shopify/LineItem/1","quantity":1}],
+				"pageInfo":{"hasNextPage":true,"endCursor":"cursor1"}}
+		}}}`,
+		orderLineItemsQuery: `{"data":{"order":{"lineItems":{
+			"nodes":[{"id":"gid://shopify/LineItem/2","quantity":2}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"cursor2"}
+		}}}}`,
+	}))
+
+	order, err := client.Order.Get(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+	if len(order.LineItems) != 2 || order.LineItems[1].Id != 2 || order.LineItems[1].Quantity != 2 {
+		t.Errorf("Order.Get returned line items %+v, expected both pages", order.LineItems)
+	}
+
+	// 250 line items a page with their nested fields cost more than a query may
+	for _, req := range sent {
+		if req.Variables["first"] != float64(50) {
+			t.Errorf("Order.Get sent %s with %+v, expected 50 line items a page", req.Query, req.Variables)
+		}
+	}
+}
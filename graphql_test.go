@@ -2,14 +2,71 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 )
 
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// registerGraphQL answers GraphQL requests with the response respond returns
+// for them, recording the requests in sent unless it is nil
+func registerGraphQL(t *testing.T, sent *[]graphQLRequest, respond func(req graphQLRequest) string) {
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			var data graphQLRequest
+			if err := json.NewDecoder(req.Body).Decode(&data); err != nil {
+				t.Errorf("invalid graphql request body: %v", err)
+			}
+			if sent != nil {
+				*sent = append(*sent, data)
+			}
+			return httpmock.NewStringResponse(200, respond(data)), nil
+		})
+}
+
+// graphQLQueryResponses answers requests with the response of their query
+func graphQLQueryResponses(t *testing.T, responses map[string]string) func(graphQLRequest) string {
+	return func(req graphQLRequest) string {
+		resp, ok := responses[req.Query]
+		if !ok {
+			t.Errorf("unexpected graphql query %s", req.Query)
+		}
+		return resp
+	}
+}
+
+// graphQLResponsesInOrder answers requests with the responses in order
+func graphQLResponsesInOrder(t *testing.T, responses ...string) func(graphQLRequest) string {
+	calls := 0
+	return func(req graphQLRequest) string {
+		calls++
+		if calls > len(responses) {
+			t.Errorf("unexpected graphql request %d: %s", calls, req.Query)
+			return ""
+		}
+		return responses[calls-1]
+	}
+}
+
+// graphQLPages answers the requests of a connection with the page for their
+// after variable
+func graphQLPages(pages map[string]string) func(graphQLRequest) string {
+	return func(req graphQLRequest) string {
+		after, _ := req.Variables["after"].(string)
+		return pages[after]
+	}
+}
+
 func TestGraphQLQuery(t *testing.T) {
 	setup()
 	defer teardown()
@@ -297,6 +354,43 @@ func TestGraphQLQueryWithThrottledError(t *testing.T) {
 	}
 }
 
+func TestGraphQLQueryThrottledCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder(
+		"POST",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `
+			{
+				"errors":[{"message":"Throttled","extensions":{"code":"THROTTLED"}}],
+				"extensions":{
+					"cost":{
+						"requestedQueryCost":400,
+						"throttleStatus":{
+							"maximumAvailable":1000.0,
+							"currentlyAvailable":300,
+							"restoreRate":50.0
+						}
+					}
+				}
+			}`),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.GraphQL.Query(ctx, "query {}", nil, &struct{}{})
+	if err != context.DeadlineExceeded {
+		t.Errorf("GraphQL.Query returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	// the throttle asks to wait 2s before retrying
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GraphQL.Query returned after %s, expected it to stop waiting when cancelled", elapsed)
+	}
+}
+
 func TestGraphQLCostRetryAfterSeconds(t *testing.T) {
 	cases := []struct {
 		description string
@@ -371,3 +465,28 @@ func TestGraphQLCostRetryAfterSeconds(t *testing.T) {
 func makeIntPointer(v int) *int {
 	return &v
 }
+
+func TestGraphQLId(t *testing.T) {
+	gid := GraphQLId("ProductVariant", 123)
+	if gid != "gid://shopify/ProductVariant/123" {
+		t.Errorf("GraphQLId returned %s, expected gid://shopify/ProductVariant/123", gid)
+	}
+
+	cases := []struct {
+		gid      string
+		expected uint64
+		err      bool
+	}{
+		{"gid://shopify/ProductVariant/123", 123, false},
+		{"gid://shopify/InventoryLevel/456?inventory_item_id=789", 456, false},
+		{"gid://shopify/Order/abc", 0, true},
+		{"123", 0, true},
+	}
+
+	for _, c := range cases {
+		id, err := ParseGraphQLId(c.gid)
+		if id != c.expected || (err != nil) != c.err {
+			t.Errorf("ParseGraphQLId(%s) returned %d, %v, expected %d", c.gid, id, err, c.expected)
+		}
+	}
+}
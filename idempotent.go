@@ -0,0 +1,197 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// messages of 422 errors Shopify returns when creating a resource that
+// already exists
+var alreadyExistsMessages = []string{
+	"already been taken",
+	"must be unique",
+	"already exists",
+}
+
+// IsAlreadyExistsError reports whether err is a 422 returned by Shopify
+// because the created resource already exists, e.g. "code must be unique"
+func IsAlreadyExistsError(err error) bool {
+	var respErr ResponseError
+	if !errors.As(err, &respErr) || respErr.Status != http.StatusUnprocessableEntity {
+		return false
+	}
+
+	msgs := append([]string{respErr.Message}, respErr.Errors...)
+	for _, msg := range msgs {
+		msg = strings.ToLower(msg)
+		for _, exists := range alreadyExistsMessages {
+			if strings.Contains(msg, exists) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTimeoutError reports whether a request timed out, leaving it unknown
+// whether Shopify processed it
+func isTimeoutError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IdempotentCreate calls create and, when it fails because the resource
+// already exists or the request timed out, calls find to look the resource up
+// by a key set on it, e.g. a discount code or tracking number. A found
+// resource counts as created. A timed out create that find doesn't see is
+// retried once, going through find again if the retry finds the resource
+// already exists. A timed out create still being processed when find runs
+// isn't seen by it, so for resources Shopify doesn't keep unique by their
+// key, e.g. fulfillments, the retry can create a duplicate.
+func IdempotentCreate(ctx context.Context, create func(context.Context) error, find func(context.Context) (bool, error)) error {
+	err := create(ctx)
+	for retried := false; err != nil; retried = true {
+		timedOut := isTimeoutError(err)
+		if !timedOut && !IsAlreadyExistsError(err) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+
+		found, findErr := find(ctx)
+		if findErr != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+		if !timedOut || retried {
+			return err
+		}
+		err = create(ctx)
+	}
+	return nil
+}
+
+// CreateOrGet creates a discount code or gets it if the code already exists
+// for the price rule, e.g. because a timed out create was processed
+func (s *DiscountCodeServiceOp) CreateOrGet(ctx context.Context, priceRuleId uint64, dc PriceRuleDiscountCode) (*PriceRuleDiscountCode, error) {
+	if dc.Code == "" {
+		return nil, errors.New("discount code: code is required")
+	}
+
+	var result *PriceRuleDiscountCode
+	err := IdempotentCreate(ctx,
+		func(ctx context.Context) error {
+			created, err := s.Create(ctx, priceRuleId, dc)
+			result = created
+			return err
+		},
+		func(ctx context.Context) (bool, error) {
+			existing, err := s.Lookup(ctx, dc.Code)
+			if isNotFound(err) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			if existing.PriceRuleId != priceRuleId {
+				return false, fmt.Errorf("discount code %s belongs to price rule %d", dc.Code, existing.PriceRuleId)
+			}
+			result = existing
+			return true, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Lookup gets a discount code by its code
+func (s *DiscountCodeServiceOp) Lookup(ctx context.Context, code string) (*PriceRuleDiscountCode, error) {
+	resource := new(DiscountCodeResource)
+	err := s.client.Get(ctx, "discount_codes/lookup.json", resource, struct {
+		Code string `url:"code"`
+	}{code})
+	return resource.PriceRuleDiscountCode, err
+}
+
+// CreateOrGet creates a fulfillment or gets the fulfillment of the order with
+// the same tracking number, e.g. because a timed out create was processed.
+// The tracking number is required as it identifies the fulfillment.
+func (s *FulfillmentServiceOp) CreateOrGet(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
+	trackingNumber := fulfillment.TrackingInfo.Number
+	if trackingNumber == "" {
+		trackingNumber = fulfillment.TrackingNumber
+	}
+	if trackingNumber == "" {
+		return nil, errors.New("fulfillment: tracking number is required")
+	}
+
+	var result *Fulfillment
+	err := IdempotentCreate(ctx,
+		func(ctx context.Context) error {
+			created, err := s.Create(ctx, fulfillment)
+			result = created
+			return err
+		},
+		func(ctx context.Context) (bool, error) {
+			orderId, err := s.orderId(ctx, fulfillment)
+			if err != nil {
+				return false, err
+			}
+
+			resource := new(FulfillmentsResource)
+			err = s.client.Get(ctx, fmt.Sprintf("%s/%d/fulfillments.json", ordersBasePath, orderId), resource, nil)
+			if err != nil {
+				return false, err
+			}
+			for i, existing := range resource.Fulfillments {
+				if existing.Status == "cancelled" {
+					continue
+				}
+				if existing.TrackingNumber == trackingNumber || existing.TrackingInfo.Number == trackingNumber || containsString(existing.TrackingNumbers, trackingNumber) {
+					result = &resource.Fulfillments[i]
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// orderId returns the id of the order a fulfillment is created for
+func (s *FulfillmentServiceOp) orderId(ctx context.Context, fulfillment Fulfillment) (uint64, error) {
+	if fulfillment.OrderId != 0 {
+		return fulfillment.OrderId, nil
+	}
+	if s.resource == ordersResourceName {
+		return s.resourceId, nil
+	}
+	if len(fulfillment.LineItemsByFulfillmentOrder) == 0 {
+		return 0, errors.New("fulfillment: can't tell the order of the fulfillment")
+	}
+
+	fulfillmentOrder, err := s.client.FulfillmentOrder.Get(ctx, fulfillment.LineItemsByFulfillmentOrder[0].FulfillmentOrderId, nil)
+	if err != nil {
+		return 0, err
+	}
+	return fulfillmentOrder.OrderId, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
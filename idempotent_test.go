@@ -0,0 +1,173 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsAlreadyExistsError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{ResponseError{Status: 422, Errors: []string{"code: must be unique"}}, true},
+		{ResponseError{Status: 422, Message: "Name has already been taken"}, true},
+		{fmt.Errorf("wrapped: %w", ResponseError{Status: 422, Errors: []string{"handle already exists"}}), true},
+		{ResponseError{Status: 422, Errors: []string{"title can't be blank"}}, false},
+		{ResponseError{Status: 409, Message: "already exists"}, false},
+		{errors.New("already exists"), false},
+	}
+
+	for _, c := range cases {
+		if actual := IsAlreadyExistsError(c.err); actual != c.expected {
+			t.Errorf("IsAlreadyExistsError(%v) = %v, expected %v", c.err, actual, c.expected)
+		}
+	}
+}
+
+func TestDiscountCodeCreateOrGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, priceRuleId := range []uint64{1, 507328175} {
+		httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/price_rules/%d/discount_codes.json", client.pathPrefix, priceRuleId),
+			httpmock.NewStringResponder(422, `{"errors":{"code":["must be unique. Please try a different code."]}}`))
+	}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/discount_codes/lookup.json", client.pathPrefix),
+		map[string]string{"code": "SUMMERSALE10OFF"},
+		httpmock.NewStringResponder(200, `{"discount_code":{"id":1054381139,"price_rule_id":507328175,"code":"SUMMERSALE10OFF"}}`))
+
+	dc, err := client.DiscountCode.CreateOrGet(context.Background(), 507328175, PriceRuleDiscountCode{Code: "SUMMERSALE10OFF"})
+	if err != nil {
+		t.Fatalf("DiscountCode.CreateOrGet returned error: %v", err)
+	}
+	if dc.Id != 1054381139 {
+		t.Errorf("DiscountCode.CreateOrGet returned %+v, expected the existing code", dc)
+	}
+
+	// the code of another price rule is still an error
+	_, err = client.DiscountCode.CreateOrGet(context.Background(), 1, PriceRuleDiscountCode{Code: "SUMMERSALE10OFF"})
+	if !IsAlreadyExistsError(err) {
+		t.Errorf("DiscountCode.CreateOrGet returned %v, expected the create error", err)
+	}
+}
+
+func TestFulfillmentCreateOrGetTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	creates := 0
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillments.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			creates++
+			return nil, timeoutError{}
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_orders/1046000778.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillment_order":{"id":1046000778,"order_id":450789469}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillments":[
+			{"id":1,"status":"cancelled","tracking_number":"1Z1234"},
+			{"id":2,"status":"success","tracking_number":"1Z1234"}
+		]}`))
+
+	fulfillment, err := client.Fulfillment.CreateOrGet(context.Background(), Fulfillment{
+		TrackingInfo:                FulfillmentTrackingInfo{Number: "1Z1234"},
+		LineItemsByFulfillmentOrder: []LineItemByFulfillmentOrder{{FulfillmentOrderId: 1046000778}},
+	})
+	if err != nil {
+		t.Fatalf("Fulfillment.CreateOrGet returned error: %v", err)
+	}
+	if fulfillment.Id != 2 || creates != 1 {
+		t.Errorf("Fulfillment.CreateOrGet returned %+v after %d creates, expected the existing fulfillment", fulfillment, creates)
+	}
+}
+
+func TestFulfillmentCreateOrGetRetry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	creates := 0
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			creates++
+			if creates == 1 {
+				return nil, timeoutError{}
+			}
+			return httpmock.NewStringResponse(201, `{"fulfillment":{"id":3,"tracking_number":"1Z1234"}}`), nil
+		})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/fulfillments.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"fulfillments":[]}`))
+
+	fulfillmentService := &FulfillmentServiceOp{client: client, resource: ordersResourceName, resourceId: 450789469}
+	fulfillment, err := fulfillmentService.CreateOrGet(context.Background(), Fulfillment{TrackingNumber: "1Z1234"})
+	if err != nil {
+		t.Fatalf("Fulfillment.CreateOrGet returned error: %v", err)
+	}
+	if fulfillment.Id != 3 || creates != 2 {
+		t.Errorf("Fulfillment.CreateOrGet returned %+v after %d creates, expected a retried create", fulfillment, creates)
+	}
+
+	if _, err := fulfillmentService.CreateOrGet(context.Background(), Fulfillment{}); err == nil {
+		t.Error("Fulfillment.CreateOrGet accepted a fulfillment without tracking number")
+	}
+}
+
+func TestIdempotentCreateOtherError(t *testing.T) {
+	createErr := ResponseError{Status: 422, Errors: []string{"title can't be blank"}}
+	finds := 0
+	err := IdempotentCreate(context.Background(),
+		func(ctx context.Context) error { return createErr },
+		func(ctx context.Context) (bool, error) {
+			finds++
+			return true, nil
+		})
+	if !reflect.DeepEqual(err, createErr) || finds != 0 {
+		t.Errorf("IdempotentCreate returned %v after %d finds, expected the create error", err, finds)
+	}
+}
+
+func TestIdempotentCreateRetryAlreadyExists(t *testing.T) {
+	// the timed out create is processed after find missed it, so the retry
+	// fails as the resource already exists
+	creates, finds := 0, 0
+	err := IdempotentCreate(context.Background(),
+		func(ctx context.Context) error {
+			creates++
+			if creates == 1 {
+				return timeoutError{}
+			}
+			return ResponseError{Status: 422, Errors: []string{"code: must be unique"}}
+		},
+		func(ctx context.Context) (bool, error) {
+			finds++
+			return finds > 1, nil
+		})
+	if err != nil || creates != 2 || finds != 2 {
+		t.Errorf("IdempotentCreate returned %v after %d creates and %d finds, expected the found resource", err, creates, finds)
+	}
+}
+
+func TestIdempotentCreateRetriesOnce(t *testing.T) {
+	creates := 0
+	err := IdempotentCreate(context.Background(),
+		func(ctx context.Context) error {
+			creates++
+			return timeoutError{}
+		},
+		func(ctx context.Context) (bool, error) { return false, nil })
+	if _, ok := err.(timeoutError); !ok || creates != 2 {
+		t.Errorf("IdempotentCreate returned %v after %d creates, expected the timeout after 2", err, creates)
+	}
+}
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -16,6 +17,7 @@ type ImageService interface {
 	Create(context.Context, uint64, Image) (*Image, error)
 	Update(context.Context, uint64, Image) (*Image, error)
 	Delete(context.Context, uint64, uint64) error
+	Upload(context.Context, uint64, io.Reader, ImageUpload) (*Image, error)
 }
 
 // ImageServiceOp handles communication with the image related methods of
@@ -0,0 +1,282 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// images up to this size are sent as attachments, larger ones are staged
+const imageAttachmentMaxSize = 4 << 20
+
+const productCreateMediaMutation = `mutation productCreateMedia($productId: ID!, $media: [CreateMediaInput!]!) {
+  productCreateMedia(productId: $productId, media: $media) {
+    media {
+      id
+      status
+    }
+    mediaUserErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const productMediaImageQuery = `query productMediaImage($id: ID!) {
+  node(id: $id) {
+    ... on MediaImage {
+      status
+      mediaErrors {
+        message
+      }
+      image {
+        id
+        url
+        width
+        height
+      }
+    }
+  }
+}`
+
+const productVariantAppendMediaMutation = `mutation productVariantAppendMedia($productId: ID!, $variantMedia: [ProductVariantAppendMediaInput!]!) {
+  productVariantAppendMedia(productId: $productId, variantMedia: $variantMedia) {
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const productReorderMediaMutation = `mutation productReorderMedia($id: ID!, $moves: [MoveInput!]!) {
+  productReorderMedia(id: $id, moves: $moves) {
+    mediaUserErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// extensions of the image files without a name, by content type
+var imageExtensions = map[string]string{
+	"image/gif":  ".gif",
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ImageUpload describes an image of a product uploaded from a reader
+type ImageUpload struct {
+	// Filename of the image, e.g. "front.jpg". Without one the image is
+	// named after its content type, e.g. "image.png".
+	Filename   string
+	Alt        string
+	Position   int
+	VariantIds []uint64
+
+	// Staged uploads the image to a staged upload which is attached to the
+	// product as media, rather than sending it base64 encoded with the image.
+	// Images over 4MB are always staged.
+	Staged bool
+}
+
+// Upload creates an image of a product from the content of the reader, with
+// its alt text, position and variants. The content must be a gif, jpeg, png
+// or webp image. Small images are created in a single call of the product
+// image API. Staged images are attached with the GraphQL productCreateMedia
+// mutation, Upload then waits until Shopify has processed the media before
+// adding it to the variants and moving it to its position.
+func (s *ImageServiceOp) Upload(ctx context.Context, productId uint64, r io.Reader, upload ImageUpload) (*Image, error) {
+	head, err := ioutil.ReadAll(io.LimitReader(r, imageAttachmentMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	contentType := http.DetectContentType(head)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("uploaded image has content type %s", contentType)
+	}
+
+	image := Image{
+		Filename:   upload.Filename,
+		Alt:        upload.Alt,
+		Position:   upload.Position,
+		VariantIds: upload.VariantIds,
+	}
+	if image.Filename == "" {
+		image.Filename = "image" + imageExtensions[contentType]
+	}
+
+	if !upload.Staged && len(head) <= imageAttachmentMaxSize {
+		image.Attachment = base64.StdEncoding.EncodeToString(head)
+		return s.Create(ctx, productId, image)
+	}
+
+	target, err := s.client.createStagedUpload(ctx, map[string]interface{}{
+		"resource":   "IMAGE",
+		"filename":   image.Filename,
+		"mimeType":   contentType,
+		"httpMethod": "POST",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if target.ResourceUrl == "" {
+		return nil, fmt.Errorf("staged upload target has no resource url")
+	}
+
+	err = s.client.postStagedUpload(ctx, target, image.Filename, func(file io.Writer) error {
+		_, err := io.Copy(file, io.MultiReader(bytes.NewReader(head), r))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createMediaImage(ctx, productId, target.ResourceUrl, upload)
+}
+
+// graphQLMediaImage is a MediaImage of productMediaImageQuery
+type graphQLMediaImage struct {
+	Status      string `json:"status"`
+	MediaErrors []struct {
+		Message string `json:"message"`
+	} `json:"mediaErrors"`
+	Image *struct {
+		Id     string `json:"id"`
+		Url    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	} `json:"image"`
+}
+
+// createMediaImage attaches the staged image to the product as media and
+// returns it once processed, added to the variants and moved to its position
+func (s *ImageServiceOp) createMediaImage(ctx context.Context, productId uint64, resourceUrl string, upload ImageUpload) (*Image, error) {
+	productGid := GraphQLId("Product", productId)
+
+	createResp := struct {
+		ProductCreateMedia struct {
+			Media []struct {
+				Id     string `json:"id"`
+				Status string `json:"status"`
+			} `json:"media"`
+			MediaUserErrors GraphQLUserErrors `json:"mediaUserErrors"`
+		} `json:"productCreateMedia"`
+	}{}
+	vars := map[string]interface{}{
+		"productId": productGid,
+		"media": []map[string]interface{}{{
+			"originalSource":   resourceUrl,
+			"alt":              upload.Alt,
+			"mediaContentType": "IMAGE",
+		}},
+	}
+	err := s.client.GraphQL.Query(ctx, productCreateMediaMutation, vars, &createResp)
+	if err != nil {
+		return nil, err
+	}
+	if len(createResp.ProductCreateMedia.MediaUserErrors) > 0 {
+		return nil, createResp.ProductCreateMedia.MediaUserErrors
+	}
+	if len(createResp.ProductCreateMedia.Media) == 0 {
+		return nil, fmt.Errorf("no product media returned")
+	}
+	mediaId := createResp.ProductCreateMedia.Media[0].Id
+
+	// variants can only show media which has been processed
+	resource, err := WaitFor(ctx, func(ctx context.Context) (interface{}, error) {
+		resp := struct {
+			Node *graphQLMediaImage `json:"node"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, productMediaImageQuery, map[string]interface{}{"id": mediaId}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Node == nil {
+			return nil, graphQLNotFound()
+		}
+		return resp.Node, nil
+	}, func(resource interface{}) bool {
+		status := resource.(*graphQLMediaImage).Status
+		return status == "READY" || status == "FAILED"
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	media := resource.(*graphQLMediaImage)
+	if media.Status == "FAILED" || media.Image == nil {
+		messages := make([]string, 0, len(media.MediaErrors))
+		for _, mediaErr := range media.MediaErrors {
+			messages = append(messages, mediaErr.Message)
+		}
+		return nil, fmt.Errorf("processing the uploaded image failed: %s", strings.Join(messages, ", "))
+	}
+
+	if len(upload.VariantIds) > 0 {
+		variantMedia := make([]map[string]interface{}, 0, len(upload.VariantIds))
+		for _, variantId := range upload.VariantIds {
+			variantMedia = append(variantMedia, map[string]interface{}{
+				"variantId": GraphQLId("ProductVariant", variantId),
+				"mediaIds":  []string{mediaId},
+			})
+		}
+		appendResp := struct {
+			ProductVariantAppendMedia struct {
+				UserErrors GraphQLUserErrors `json:"userErrors"`
+			} `json:"productVariantAppendMedia"`
+		}{}
+		vars := map[string]interface{}{"productId": productGid, "variantMedia": variantMedia}
+		err = s.client.GraphQL.Query(ctx, productVariantAppendMediaMutation, vars, &appendResp)
+		if err != nil {
+			return nil, err
+		}
+		if len(appendResp.ProductVariantAppendMedia.UserErrors) > 0 {
+			return nil, appendResp.ProductVariantAppendMedia.UserErrors
+		}
+	}
+
+	if upload.Position > 0 {
+		reorderResp := struct {
+			ProductReorderMedia struct {
+				MediaUserErrors GraphQLUserErrors `json:"mediaUserErrors"`
+			} `json:"productReorderMedia"`
+		}{}
+		// positions of moves start at 0
+		vars := map[string]interface{}{
+			"id":    productGid,
+			"moves": []map[string]interface{}{{"id": mediaId, "newPosition": strconv.Itoa(upload.Position - 1)}},
+		}
+		err = s.client.GraphQL.Query(ctx, productReorderMediaMutation, vars, &reorderResp)
+		if err != nil {
+			return nil, err
+		}
+		if len(reorderResp.ProductReorderMedia.MediaUserErrors) > 0 {
+			return nil, reorderResp.ProductReorderMedia.MediaUserErrors
+		}
+	}
+
+	imageId, err := ParseGraphQLId(media.Image.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{
+		Id:                imageId,
+		ProductId:         productId,
+		Position:          upload.Position,
+		Width:             media.Image.Width,
+		Height:            media.Image.Height,
+		Src:               media.Image.Url,
+		Alt:               upload.Alt,
+		VariantIds:        upload.VariantIds,
+		AdminGraphqlApiId: media.Image.Id,
+	}, nil
+}
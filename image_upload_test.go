@@ -0,0 +1,213 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+// registerImageCreate mocks the product image API, returning the created
+// image
+func registerImageCreate(t *testing.T, sent *Image) {
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1/images.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resource := ImageResource{}
+			if err := json.NewDecoder(req.Body).Decode(&resource); err != nil || resource.Image == nil {
+				t.Errorf("invalid image request body: %v", err)
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			*sent = *resource.Image
+			return httpmock.NewBytesResponse(200, loadFixture("image.json")), nil
+		})
+}
+
+func TestImageUploadAttachment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent Image
+	registerImageCreate(t, &sent)
+
+	image, err := client.Image.Upload(context.Background(), 1, bytes.NewReader(testPNG), ImageUpload{
+		Alt:        "Front",
+		Position:   2,
+		VariantIds: []uint64{808950810, 808950811},
+	})
+	if err != nil {
+		t.Fatalf("Image.Upload returned error: %v", err)
+	}
+	imageTests(t, *image)
+
+	expected := Image{
+		Filename:   "image.png",
+		Attachment: base64.StdEncoding.EncodeToString(testPNG),
+		Alt:        "Front",
+		Position:   2,
+		VariantIds: []uint64{808950810, 808950811},
+	}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Image.Upload sent %+v, expected %+v", sent, expected)
+	}
+}
+
+func TestImageUploadStaged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const resourceUrl = "https://shopify-staged-uploads.storage.googleapis.com/tmp/21759409/products/front.png"
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		stagedUploadsCreateMutation: `{"data":{"stagedUploadsCreate":{"stagedTargets":[{
+			"url":"` + testStagedUploadUrl + `",
+			"resourceUrl":"` + resourceUrl + `",
+			"parameters":[{"name":"key","value":"tmp/21759409/products/front.png"},{"name":"policy","value":"abc"}]
+		}],"userErrors":[]}}}`,
+		productCreateMediaMutation: `{"data":{"productCreateMedia":{"media":[{"id":"gid://shopify/MediaImage/5","status":"UPLOADED"}],
+			"mediaUserErrors":[]}}}`,
+		productMediaImageQuery: `{"data":{"node":{"status":"READY","mediaErrors":[],
+			"image":{"id":"gid://shopify/ProductImage/850703190","url":"https://cdn.shopify.com/s/files/1/0006/9093/3842/products/front.png","width":110,"height":140}}}}`,
+		productVariantAppendMediaMutation: `{"data":{"productVariantAppendMedia":{"userErrors":[]}}}`,
+		productReorderMediaMutation:       `{"data":{"productReorderMedia":{"mediaUserErrors":[]}}}`,
+	}))
+
+	var uploaded []byte
+	var policy string
+	httpmock.RegisterResponder("POST", testStagedUploadUrl,
+		func(req *http.Request) (*http.Response, error) {
+			reader, err := req.MultipartReader()
+			if err != nil {
+				t.Fatalf("staged upload is not multipart: %v", err)
+			}
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				data, _ := ioutil.ReadAll(part)
+				switch part.FormName() {
+				case "policy":
+					policy = string(data)
+				case "file":
+					if part.FileName() != "front.png" {
+						t.Errorf("staged upload file is named %s", part.FileName())
+					}
+					uploaded = data
+				}
+			}
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+
+	image, err := client.Image.Upload(context.Background(), 1, bytes.NewReader(testPNG), ImageUpload{
+		Filename:   "front.png",
+		Alt:        "Front",
+		Position:   2,
+		VariantIds: []uint64{808950810},
+		Staged:     true,
+	})
+	if err != nil {
+		t.Fatalf("Image.Upload returned error: %v", err)
+	}
+
+	expectedInput := []interface{}{map[string]interface{}{
+		"resource":   "IMAGE",
+		"filename":   "front.png",
+		"mimeType":   "image/png",
+		"httpMethod": "POST",
+	}}
+	if len(sent) != 5 || !reflect.DeepEqual(sent[0].Variables["input"], expectedInput) {
+		t.Fatalf("Image.Upload staged %+v", sent)
+	}
+	if policy != "abc" || !bytes.Equal(uploaded, testPNG) {
+		t.Errorf("Image.Upload uploaded policy %q and %d bytes", policy, len(uploaded))
+	}
+
+	expectedVars := []map[string]interface{}{
+		{
+			"productId": "gid://shopify/Product/1",
+			"media": []interface{}{map[string]interface{}{
+				"originalSource": resourceUrl, "alt": "Front", "mediaContentType": "IMAGE",
+			}},
+		},
+		{"id": "gid://shopify/MediaImage/5"},
+		{
+			"productId": "gid://shopify/Product/1",
+			"variantMedia": []interface{}{map[string]interface{}{
+				"variantId": "gid://shopify/ProductVariant/808950810",
+				"mediaIds":  []interface{}{"gid://shopify/MediaImage/5"},
+			}},
+		},
+		{
+			"id":    "gid://shopify/Product/1",
+			"moves": []interface{}{map[string]interface{}{"id": "gid://shopify/MediaImage/5", "newPosition": "1"}},
+		},
+	}
+	for i, expected := range expectedVars {
+		if !reflect.DeepEqual(sent[i+1].Variables, expected) {
+			t.Errorf("Image.Upload sent variables %v, expected %v", sent[i+1].Variables, expected)
+		}
+	}
+
+	expected := &Image{
+		Id:                850703190,
+		ProductId:         1,
+		Position:          2,
+		Width:             110,
+		Height:            140,
+		Src:               "https://cdn.shopify.com/s/files/1/0006/9093/3842/products/front.png",
+		Alt:               "Front",
+		VariantIds:        []uint64{808950810},
+		AdminGraphqlApiId: "gid://shopify/ProductImage/850703190",
+	}
+	if !reflect.DeepEqual(image, expected) {
+		t.Errorf("Image.Upload returned %+v, expected %+v", image, expected)
+	}
+}
+
+func TestImageUploadStagedFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		stagedUploadsCreateMutation: `{"data":{"stagedUploadsCreate":{"stagedTargets":[{
+			"url":"` + testStagedUploadUrl + `",
+			"resourceUrl":"https://shopify-staged-uploads.storage.googleapis.com/tmp/21759409/products/front.png",
+			"parameters":[]
+		}],"userErrors":[]}}}`,
+		productCreateMediaMutation: `{"data":{"productCreateMedia":{"media":[{"id":"gid://shopify/MediaImage/5","status":"UPLOADED"}],
+			"mediaUserErrors":[]}}}`,
+		productMediaImageQuery: `{"data":{"node":{"status":"FAILED","mediaErrors":[{"message":"Image is corrupt"}],"image":null}}}`,
+	}))
+	httpmock.RegisterResponder("POST", testStagedUploadUrl, httpmock.NewStringResponder(201, ""))
+
+	_, err := client.Image.Upload(context.Background(), 1, bytes.NewReader(testPNG), ImageUpload{Staged: true})
+	if err == nil || !strings.Contains(err.Error(), "Image is corrupt") {
+		t.Errorf("Image.Upload returned %v, expected the media error", err)
+	}
+}
+
+func TestImageUploadNotAnImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Image.Upload(context.Background(), 1, strings.NewReader("name,price\ntee,10\n"), ImageUpload{})
+	if err == nil || !strings.Contains(err.Error(), "text/plain") {
+		t.Errorf("Image.Upload returned error %v, expected a content type error", err)
+	}
+}
@@ -0,0 +1,220 @@
+package goshopify
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ImageCrop is the part of an image kept when resizing it to other
+// proportions
+type ImageCrop string
+
+const (
+	ImageCropCenter ImageCrop = "center"
+	ImageCropTop    ImageCrop = "top"
+	ImageCropBottom ImageCrop = "bottom"
+	ImageCropLeft   ImageCrop = "left"
+	ImageCropRight  ImageCrop = "right"
+)
+
+// ImageFormat is the format the CDN converts an image to. Without one the
+// CDN already serves webp to browsers accepting it.
+type ImageFormat string
+
+const (
+	ImageFormatJPG            ImageFormat = "jpg"
+	ImageFormatProgressiveJPG ImageFormat = "pjpg"
+	ImageFormatPNG            ImageFormat = "png"
+	ImageFormatWebP           ImageFormat = "webp"
+)
+
+// ImageTransform is how the CDN serves an image. Zero fields leave the image
+// as it is, e.g. only setting Width keeps the proportions.
+type ImageTransform struct {
+	Width  int
+	Height int
+	// Crop needs both Width and Height
+	Crop ImageCrop
+	// Scale multiplies the dimensions for high density screens, 2 or 3
+	Scale  int
+	Format ImageFormat
+}
+
+const shopifyCDNHost = "cdn.shopify.com"
+
+// query parameters of the CDN transforming images
+var imageTransformParams = []string{"width", "height", "crop", "format"}
+
+// size suffixes of the legacy CDN urls, e.g. tee_400x400_crop_center@2x.png
+var imageSizeSuffix = regexp.MustCompile(`(_(pico|icon|thumb|small|compact|medium|large|grande|original|master|\d+x\d*|x\d+))?(_crop_(top|center|bottom|left|right))?(@[23]x)?(\.progressive)?$`)
+
+// IsShopifyImageURL tells whether the url is an image served by Shopify's CDN,
+// either from cdn.shopify.com or the /cdn/shop path of a storefront
+func IsShopifyImageURL(src string) bool {
+	u, err := parseImageURL(src)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Hostname(), shopifyCDNHost) {
+		return strings.HasPrefix(u.Path, "/s/files/")
+	}
+	return strings.HasPrefix(u.Path, "/cdn/shop/")
+}
+
+// IsShopImageURL tells whether the url is an image of the shop's CDN: a file
+// of the shop on cdn.shopify.com, or under /cdn/shop of its myshopify or
+// primary domain. Use it before fetching image urls received from clients.
+func IsShopImageURL(src string, shop *Shop) bool {
+	if shop == nil {
+		return false
+	}
+	u, err := parseImageURL(src)
+	if err != nil {
+		return false
+	}
+
+	host := u.Hostname()
+	if strings.EqualFold(host, shopifyCDNHost) {
+		return shop.Id != 0 && strings.HasPrefix(u.Path, shopFilesPath(shop.Id))
+	}
+	if !strings.HasPrefix(u.Path, "/cdn/shop/") {
+		return false
+	}
+	for _, domain := range []string{shop.MyshopifyDomain, shop.Domain} {
+		if domain != "" && strings.EqualFold(host, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// shopFilesPath returns the path of a shop's files on cdn.shopify.com. The
+// id is zero padded to groups of 4 digits, e.g. /s/files/1/0533/2089/ for
+// shop 5332089.
+func shopFilesPath(shopId uint64) string {
+	digits := strconv.FormatUint(shopId, 10)
+	width := 8
+	if len(digits) > width {
+		width = (len(digits) + 3) / 4 * 4
+	}
+	digits = strings.Repeat("0", width-len(digits)) + digits
+
+	groups := make([]string, 0, width/4)
+	for i := 0; i < width; i += 4 {
+		groups = append(groups, digits[i:i+4])
+	}
+	return "/s/files/1/" + strings.Join(groups, "/") + "/"
+}
+
+// OriginalImageURL returns the url of the image as uploaded, without the
+// size suffix of legacy urls, e.g. tee_400x400.png, nor the transform
+// parameters. The version parameter is kept. Note a file uploaded with a
+// name ending like a size, e.g. tee_small.png, loses it.
+func OriginalImageURL(src string) (string, error) {
+	u, err := parseImageURL(src)
+	if err != nil {
+		return "", err
+	}
+	if !IsShopifyImageURL(src) {
+		return "", fmt.Errorf("%q isn't a Shopify CDN image", src)
+	}
+
+	dir, file := path.Split(u.Path)
+	ext := path.Ext(file)
+	name := strings.TrimSuffix(file, ext)
+	if stripped := imageSizeSuffix.ReplaceAllString(name, ""); stripped != "" {
+		name = stripped
+	}
+	u.Path = dir + name + ext
+	u.RawPath = ""
+
+	query := u.Query()
+	for _, param := range imageTransformParams {
+		query.Del(param)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// TransformImageURL returns the url of the image served by the CDN with the
+// transform, replacing any size or transform of the url. Unlike legacy size
+// suffixes, the parameters work on both cdn.shopify.com and storefront urls.
+func TransformImageURL(src string, transform ImageTransform) (string, error) {
+	if err := transform.validate(); err != nil {
+		return "", err
+	}
+	original, err := OriginalImageURL(src)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(original)
+	if err != nil {
+		return "", err
+	}
+
+	scale := transform.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	query := u.Query()
+	if transform.Width > 0 {
+		query.Set("width", strconv.Itoa(transform.Width*scale))
+	}
+	if transform.Height > 0 {
+		query.Set("height", strconv.Itoa(transform.Height*scale))
+	}
+	if transform.Crop != "" {
+		query.Set("crop", string(transform.Crop))
+	}
+	if transform.Format != "" {
+		query.Set("format", string(transform.Format))
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func (t ImageTransform) validate() error {
+	if t.Width < 0 || t.Height < 0 {
+		return fmt.Errorf("invalid image size %dx%d", t.Width, t.Height)
+	}
+	if t.Scale < 0 || t.Scale > 3 {
+		return fmt.Errorf("invalid image scale %d", t.Scale)
+	}
+	switch t.Crop {
+	case "":
+	case ImageCropCenter, ImageCropTop, ImageCropBottom, ImageCropLeft, ImageCropRight:
+		if t.Width == 0 || t.Height == 0 {
+			return errors.New("cropping an image needs both its width and height")
+		}
+	default:
+		return fmt.Errorf("invalid image crop %q", t.Crop)
+	}
+	switch t.Format {
+	case "", ImageFormatJPG, ImageFormatProgressiveJPG, ImageFormatPNG, ImageFormatWebP:
+	default:
+		return fmt.Errorf("invalid image format %q", t.Format)
+	}
+	return nil
+}
+
+// parseImageURL parses absolute urls, including the protocol relative urls
+// of Liquid, e.g. //cdn.shopify.com/s/files/...
+func parseImageURL(src string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(src))
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" || (u.Scheme != "" && u.Scheme != "https" && u.Scheme != "http") || u.User != nil {
+		return nil, fmt.Errorf("%q isn't an absolute image url", src)
+	}
+	// a path climbing out of the shop's files could point to another shop's
+	if u.Path != path.Clean(u.Path) {
+		return nil, fmt.Errorf("%q isn't a clean image url", src)
+	}
+	return u, nil
+}
@@ -0,0 +1,137 @@
+package goshopify
+
+import (
+	"testing"
+)
+
+const cdnImage = "https://cdn.shopify.com/s/files/1/0533/2089/products/tee.png?v=1704207600"
+
+func TestOriginalImageURL(t *testing.T) {
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{cdnImage, cdnImage},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/products/tee_400x400.png?v=1704207600", cdnImage},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/products/tee_400x.png?v=1704207600", cdnImage},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/products/tee_x400.png?v=1704207600", cdnImage},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/products/tee_grande.png?v=1704207600", cdnImage},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/products/tee_400x400_crop_center@2x.progressive.png?v=1704207600", cdnImage},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/products/tee.png?v=1704207600&width=400&crop=center&format=webp", cdnImage},
+		{"//cdn.shopify.com/s/files/1/0533/2089/products/tee_small.png", "//cdn.shopify.com/s/files/1/0533/2089/products/tee.png"},
+		{"https://fooshop.com/cdn/shop/files/summer_tee_300x.jpg?v=1&width=300", "https://fooshop.com/cdn/shop/files/summer_tee.jpg?v=1"},
+		// underscores which aren't sizes are kept
+		{"https://cdn.shopify.com/s/files/1/0533/2089/files/red_tee_2.png", "https://cdn.shopify.com/s/files/1/0533/2089/files/red_tee_2.png"},
+	}
+	for _, c := range cases {
+		actual, err := OriginalImageURL(c.src)
+		if err != nil {
+			t.Errorf("OriginalImageURL(%q) returned error: %v", c.src, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("OriginalImageURL(%q) returned %q, expected %q", c.src, actual, c.expected)
+		}
+	}
+}
+
+func TestTransformImageURL(t *testing.T) {
+	cases := []struct {
+		src       string
+		transform ImageTransform
+		expected  string
+	}{
+		{
+			cdnImage,
+			ImageTransform{Width: 400},
+			"https://cdn.shopify.com/s/files/1/0533/2089/products/tee.png?v=1704207600&width=400",
+		},
+		{
+			"https://cdn.shopify.com/s/files/1/0533/2089/products/tee_1024x1024.png?v=1704207600",
+			ImageTransform{Width: 400, Height: 300, Crop: ImageCropTop, Scale: 2, Format: ImageFormatWebP},
+			"https://cdn.shopify.com/s/files/1/0533/2089/products/tee.png?crop=top&format=webp&height=600&v=1704207600&width=800",
+		},
+		{
+			"https://fooshop.com/cdn/shop/files/tee.jpg?v=1&width=1000&height=1000",
+			ImageTransform{Height: 200},
+			"https://fooshop.com/cdn/shop/files/tee.jpg?height=200&v=1",
+		},
+	}
+	for _, c := range cases {
+		actual, err := TransformImageURL(c.src, c.transform)
+		if err != nil {
+			t.Errorf("TransformImageURL(%q) returned error: %v", c.src, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("TransformImageURL(%q) returned %q, expected %q", c.src, actual, c.expected)
+		}
+	}
+}
+
+func TestTransformImageURLInvalid(t *testing.T) {
+	cases := []struct {
+		src       string
+		transform ImageTransform
+	}{
+		{"https://example.com/tee.png", ImageTransform{Width: 400}},
+		{"/s/files/1/0533/2089/products/tee.png", ImageTransform{Width: 400}},
+		{"ftp://cdn.shopify.com/s/files/1/0533/2089/products/tee.png", ImageTransform{Width: 400}},
+		{cdnImage, ImageTransform{Width: -1}},
+		{cdnImage, ImageTransform{Width: 400, Scale: 4}},
+		{cdnImage, ImageTransform{Width: 400, Crop: ImageCropCenter}},
+		{cdnImage, ImageTransform{Width: 400, Height: 400, Crop: "middle"}},
+		{cdnImage, ImageTransform{Format: "tiff"}},
+	}
+	for _, c := range cases {
+		if actual, err := TransformImageURL(c.src, c.transform); err == nil {
+			t.Errorf("TransformImageURL(%q, %+v) returned %q, expected an error", c.src, c.transform, actual)
+		}
+	}
+}
+
+func TestIsShopImageURL(t *testing.T) {
+	shop := &Shop{Id: 5332089, Domain: "fooshop.com", MyshopifyDomain: "fooshop.myshopify.com"}
+	cases := []struct {
+		src      string
+		shop     *Shop
+		expected bool
+	}{
+		{cdnImage, shop, true},
+		{"//CDN.shopify.com/s/files/1/0533/2089/files/tee.png", shop, true},
+		{"https://fooshop.com/cdn/shop/files/tee.png", shop, true},
+		{"https://fooshop.myshopify.com/cdn/shop/products/tee.png", shop, true},
+		{"https://cdn.shopify.com/s/files/1/0621/3412/4573/products/tee.png", &Shop{Id: 62134124573}, true},
+		// another shop's files
+		{"https://cdn.shopify.com/s/files/1/0533/2090/products/tee.png", shop, false},
+		{"https://cdn.shopify.com/s/files/1/0533/20890/products/tee.png", shop, false},
+		{"https://othershop.com/cdn/shop/files/tee.png", shop, false},
+		{"https://fooshop.com.evil.com/cdn/shop/files/tee.png", shop, false},
+		{"https://fooshop.com/files/tee.png", shop, false},
+		{"https://user@fooshop.com/cdn/shop/files/tee.png", shop, false},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/../../0533/2090/products/tee.png", shop, false},
+		{"https://cdn.shopify.com/s/files/1/0533/2089/%2e%2e/%2e%2e/0533/2090/products/tee.png", shop, false},
+		{"javascript:alert(1)", shop, false},
+		{cdnImage, nil, false},
+	}
+	for _, c := range cases {
+		if actual := IsShopImageURL(c.src, c.shop); actual != c.expected {
+			t.Errorf("IsShopImageURL(%q) returned %v, expected %v", c.src, actual, c.expected)
+		}
+	}
+}
+
+func TestIsShopifyImageURL(t *testing.T) {
+	cases := map[string]bool{
+		cdnImage: true,
+		"https://anyshop.com/cdn/shop/files/tee.png":   true,
+		"https://cdn.shopify.com/shopifycloud/tee.png": false,
+		"https://example.com/tee.png":                  false,
+		"not a url":                                    false,
+	}
+	for src, expected := range cases {
+		if actual := IsShopifyImageURL(src); actual != expected {
+			t.Errorf("IsShopifyImageURL(%q) returned %v, expected %v", src, actual, expected)
+		}
+	}
+}
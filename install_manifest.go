@@ -0,0 +1,338 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const metafieldDefinitionIdQuery = `query metafieldDefinitionId($ownerType: MetafieldOwnerType!, $namespace: String!, $key: String!) {
+  metafieldDefinitions(first: 1, ownerType: $ownerType, namespace: $namespace, key: $key) {
+    nodes {
+      id
+    }
+  }
+}`
+
+const metafieldDefinitionCreateMutation = `mutation metafieldDefinitionCreate($definition: MetafieldDefinitionInput!) {
+  metafieldDefinitionCreate(definition: $definition) {
+    createdDefinition {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// InstallManifest declares what an app needs set up on a shop: the access
+// scopes it must have been granted, its webhook subscriptions, metafield
+// definitions and carrier and fulfillment services. EnsureInstalled converges
+// a shop to the manifest, so it can run on every install and app start.
+type InstallManifest struct {
+	Scopes               []string
+	Webhooks             []WebhookSubscription
+	MetafieldDefinitions []MetafieldDefinition
+	CarrierServices      []CarrierService
+	FulfillmentServices  []FulfillmentServiceData
+
+	// PruneWebhooks deletes the webhook subscriptions of the app which
+	// aren't in the manifest, e.g. topics the app no longer handles
+	PruneWebhooks bool
+}
+
+// MetafieldDefinition is the definition of a metafield, e.g. of products, by
+// namespace and key. OwnerType is the GraphQL MetafieldOwnerType, e.g.
+// PRODUCT.
+type MetafieldDefinition struct {
+	Name        string
+	Namespace   string
+	Key         string
+	Type        MetafieldType
+	OwnerType   string
+	Description string
+}
+
+// InstallChange is a change EnsureInstalled made to a shop
+type InstallChange struct {
+	// e.g. "webhook", "metafield_definition", "carrier_service" or
+	// "fulfillment_service"
+	Resource string
+	// e.g. the webhook topic or the service name
+	Name string
+	// "created", "updated" or "deleted"
+	Action string
+}
+
+// MissingScopesError is returned by EnsureInstalled when the app hasn't been
+// granted scopes of the manifest. The merchant has to approve them through
+// the OAuth flow again.
+type MissingScopesError struct {
+	Scopes []string
+}
+
+func (e MissingScopesError) Error() string {
+	return fmt.Sprintf("missing access scopes: %s", strings.Join(e.Scopes, ", "))
+}
+
+// EnsureInstalled converges the shop of the client to the manifest: it
+// checks the granted scopes, then creates or updates what's missing or
+// differs, leaving what already matches alone. It returns the changes made,
+// including those made before an error.
+func (m InstallManifest) EnsureInstalled(ctx context.Context, client *Client) ([]InstallChange, error) {
+	if err := m.checkScopes(ctx, client); err != nil {
+		return nil, err
+	}
+
+	var changes []InstallChange
+	steps := []func(context.Context, *Client) ([]InstallChange, error){
+		m.ensureWebhooks,
+		m.ensureMetafieldDefinitions,
+		m.ensureCarrierServices,
+		m.ensureFulfillmentServices,
+	}
+	for _, step := range steps {
+		c, err := step(ctx, client)
+		changes = append(changes, c...)
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+func (m InstallManifest) checkScopes(ctx context.Context, client *Client) error {
+	if len(m.Scopes) == 0 {
+		return nil
+	}
+
+	scopes, err := client.AccessScopes.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	granted := make(map[string]bool, len(scopes))
+	for _, s := range scopes {
+		granted[s.Handle] = true
+		// write scopes imply the read scope
+		if strings.HasPrefix(s.Handle, "write_") {
+			granted["read_"+strings.TrimPrefix(s.Handle, "write_")] = true
+		}
+	}
+
+	var missing []string
+	for _, s := range m.Scopes {
+		if !granted[s] {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) > 0 {
+		return MissingScopesError{Scopes: missing}
+	}
+	return nil
+}
+
+// webhookKey identifies a webhook subscription by topic and callback url
+func webhookKey(w WebhookSubscription) string {
+	return WebhookSubscriptionTopic(w.Topic) + " " + w.CallbackUrl
+}
+
+// webhookMatches tells whether an existing subscription has the settings of
+// one in the manifest
+func webhookMatches(existing WebhookSubscription, wanted WebhookSubscription) bool {
+	sorted := func(s []string) []string {
+		s = append([]string{}, s...)
+		sort.Strings(s)
+		return s
+	}
+	return (wanted.Format == "" || strings.EqualFold(existing.Format, wanted.Format)) &&
+		existing.Filter == wanted.Filter &&
+		reflect.DeepEqual(sorted(existing.IncludeFields), sorted(wanted.IncludeFields)) &&
+		reflect.DeepEqual(sorted(existing.MetafieldNamespaces), sorted(wanted.MetafieldNamespaces))
+}
+
+func (m InstallManifest) ensureWebhooks(ctx context.Context, client *Client) ([]InstallChange, error) {
+	if len(m.Webhooks) == 0 && !m.PruneWebhooks {
+		return nil, nil
+	}
+
+	existing, err := client.Webhook.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]WebhookSubscription, len(existing))
+	for _, w := range existing {
+		byKey[webhookKey(w)] = w
+	}
+
+	var changes []InstallChange
+	wanted := make(map[string]bool, len(m.Webhooks))
+	for _, w := range m.Webhooks {
+		key := webhookKey(w)
+		wanted[key] = true
+		change := InstallChange{Resource: "webhook", Name: WebhookSubscriptionTopic(w.Topic)}
+
+		current, ok := byKey[key]
+		switch {
+		case !ok:
+			_, err = client.Webhook.CreateSubscription(ctx, w)
+			change.Action = "created"
+		case !webhookMatches(current, w):
+			w.Id = current.Id
+			_, err = client.Webhook.UpdateSubscription(ctx, w)
+			change.Action = "updated"
+		default:
+			continue
+		}
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, change)
+	}
+
+	if m.PruneWebhooks {
+		for _, w := range existing {
+			if wanted[webhookKey(w)] {
+				continue
+			}
+			if err := client.Webhook.DeleteSubscription(ctx, w.Id); err != nil {
+				return changes, err
+			}
+			changes = append(changes, InstallChange{Resource: "webhook", Name: w.Topic, Action: "deleted"})
+		}
+	}
+	return changes, nil
+}
+
+func (m InstallManifest) ensureMetafieldDefinitions(ctx context.Context, client *Client) ([]InstallChange, error) {
+	var changes []InstallChange
+	for _, d := range m.MetafieldDefinitions {
+		exists := struct {
+			MetafieldDefinitions struct {
+				Nodes []struct {
+					Id string `json:"id"`
+				} `json:"nodes"`
+			} `json:"metafieldDefinitions"`
+		}{}
+		vars := map[string]interface{}{"ownerType": d.OwnerType, "namespace": d.Namespace, "key": d.Key}
+		if err := client.GraphQL.Query(ctx, metafieldDefinitionIdQuery, vars, &exists); err != nil {
+			return changes, err
+		}
+		if len(exists.MetafieldDefinitions.Nodes) > 0 {
+			continue
+		}
+
+		created := struct {
+			MetafieldDefinitionCreate struct {
+				UserErrors GraphQLUserErrors `json:"userErrors"`
+			} `json:"metafieldDefinitionCreate"`
+		}{}
+		definition := map[string]interface{}{
+			"name":      d.Name,
+			"namespace": d.Namespace,
+			"key":       d.Key,
+			"type":      string(d.Type),
+			"ownerType": d.OwnerType,
+		}
+		if d.Description != "" {
+			definition["description"] = d.Description
+		}
+		err := client.GraphQL.Query(ctx, metafieldDefinitionCreateMutation, map[string]interface{}{"definition": definition}, &created)
+		if err != nil {
+			return changes, err
+		}
+		if len(created.MetafieldDefinitionCreate.UserErrors) > 0 {
+			return changes, created.MetafieldDefinitionCreate.UserErrors
+		}
+		changes = append(changes, InstallChange{
+			Resource: "metafield_definition",
+			Name:     d.OwnerType + " " + d.Namespace + "." + d.Key,
+			Action:   "created",
+		})
+	}
+	return changes, nil
+}
+
+func (m InstallManifest) ensureCarrierServices(ctx context.Context, client *Client) ([]InstallChange, error) {
+	if len(m.CarrierServices) == 0 {
+		return nil, nil
+	}
+
+	existing, err := client.CarrierService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]CarrierService, len(existing))
+	for _, c := range existing {
+		byName[c.Name] = c
+	}
+
+	var changes []InstallChange
+	for _, c := range m.CarrierServices {
+		change := InstallChange{Resource: "carrier_service", Name: c.Name}
+
+		current, ok := byName[c.Name]
+		switch {
+		case !ok:
+			_, err = client.CarrierService.Create(ctx, c)
+			change.Action = "created"
+		case current.CallbackUrl != c.CallbackUrl ||
+			(c.Active != nil && (current.Active == nil || *current.Active != *c.Active)) ||
+			current.ServiceDiscovery != c.ServiceDiscovery:
+			c.Id = current.Id
+			_, err = client.CarrierService.Update(ctx, c)
+			change.Action = "updated"
+		default:
+			continue
+		}
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (m InstallManifest) ensureFulfillmentServices(ctx context.Context, client *Client) ([]InstallChange, error) {
+	if len(m.FulfillmentServices) == 0 {
+		return nil, nil
+	}
+
+	existing, err := client.FulfillmentService.List(ctx, FulfillmentServiceOptions{Scope: "current_client"})
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]FulfillmentServiceData, len(existing))
+	for _, f := range existing {
+		byName[f.Name] = f
+	}
+
+	var changes []InstallChange
+	for _, f := range m.FulfillmentServices {
+		change := InstallChange{Resource: "fulfillment_service", Name: f.Name}
+
+		current, ok := byName[f.Name]
+		switch {
+		case !ok:
+			_, err = client.FulfillmentService.Create(ctx, f)
+			change.Action = "created"
+		case current.CallbackURL != f.CallbackURL ||
+			current.InventoryManagement != f.InventoryManagement ||
+			current.TrackingSupport != f.TrackingSupport ||
+			current.FulfillmentOrdersOptIn != f.FulfillmentOrdersOptIn:
+			f.Id = current.Id
+			_, err = client.FulfillmentService.Update(ctx, f)
+			change.Action = "updated"
+		default:
+			continue
+		}
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
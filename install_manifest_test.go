@@ -0,0 +1,104 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestInstallManifestEnsureInstalled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/oauth/access_scopes.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"access_scopes":[{"handle":"write_orders"},{"handle":"read_shipping"}]}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"carrier_services":[{"id":1,"name":"Rates","callback_url":"https://app.example.com/old-rates","active":true}]}`))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/carrier_services/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"carrier_service":{"id":1,"name":"Rates","callback_url":"https://app.example.com/rates","active":true}}`))
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/fulfillment_services.json", client.pathPrefix),
+		map[string]string{"scope": "current_client"},
+		httpmock.NewStringResponder(200, `{"fulfillment_services":[{"id":2,"name":"Warehouse","callback_url":"https://app.example.com/fulfillment","inventory_management":true}]}`))
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		webhookSubscriptionsQuery: `{"data":{"webhookSubscriptions":{"nodes":[
+			{"id":"gid://shopify/WebhookSubscription/10","topic":"ORDERS_CREATE","format":"JSON","includeFields":["id"],"metafieldNamespaces":[],"filter":"",
+				"apiVersion":{"handle":"2024-01"},"endpoint":{"__typename":"WebhookHttpEndpoint","callbackUrl":"https://app.example.com/webhooks"}},
+			{"id":"gid://shopify/WebhookSubscription/11","topic":"ORDERS_UPDATED","format":"JSON","includeFields":[],"metafieldNamespaces":[],"filter":"",
+				"apiVersion":{"handle":"2024-01"},"endpoint":{"__typename":"WebhookHttpEndpoint","callbackUrl":"https://app.example.com/webhooks"}},
+			{"id":"gid://shopify/WebhookSubscription/12","topic":"PRODUCTS_UPDATE","format":"JSON","includeFields":[],"metafieldNamespaces":[],"filter":"",
+				"apiVersion":{"handle":"2024-01"},"endpoint":{"__typename":"WebhookHttpEndpoint","callbackUrl":"https://app.example.com/webhooks"}}
+		],"pageInfo":{"hasNextPage":false,"endCursor":null}}}}`,
+		webhookSubscriptionCreateMutation: `{"data":{"webhookSubscriptionCreate":{"webhookSubscription":
+			{"id":"gid://shopify/WebhookSubscription/13","topic":"APP_UNINSTALLED","format":"JSON","apiVersion":{"handle":"2024-01"},
+				"endpoint":{"__typename":"WebhookHttpEndpoint","callbackUrl":"https://app.example.com/webhooks"}},"userErrors":[]}}}`,
+		webhookSubscriptionUpdateMutation: `{"data":{"webhookSubscriptionUpdate":{"webhookSubscription":
+			{"id":"gid://shopify/WebhookSubscription/11","topic":"ORDERS_UPDATED","format":"JSON","includeFields":["id","tags"],"apiVersion":{"handle":"2024-01"},
+				"endpoint":{"__typename":"WebhookHttpEndpoint","callbackUrl":"https://app.example.com/webhooks"}},"userErrors":[]}}}`,
+		webhookSubscriptionDeleteMutation: `{"data":{"webhookSubscriptionDelete":{"deletedWebhookSubscriptionId":"gid://shopify/WebhookSubscription/12","userErrors":[]}}}`,
+		metafieldDefinitionIdQuery:        `{"data":{"metafieldDefinitions":{"nodes":[]}}}`,
+		metafieldDefinitionCreateMutation: `{"data":{"metafieldDefinitionCreate":{"createdDefinition":{"id":"gid://shopify/MetafieldDefinition/1"},"userErrors":[]}}}`,
+	}))
+
+	active := true
+	manifest := InstallManifest{
+		Scopes: []string{"read_orders", "write_orders", "read_shipping"},
+		Webhooks: []WebhookSubscription{
+			{Topic: "orders/create", CallbackUrl: "https://app.example.com/webhooks", IncludeFields: []string{"id"}},
+			{Topic: "orders/updated", CallbackUrl: "https://app.example.com/webhooks", IncludeFields: []string{"id", "tags"}},
+			{Topic: "app/uninstalled", CallbackUrl: "https://app.example.com/webhooks"},
+		},
+		MetafieldDefinitions: []MetafieldDefinition{
+			{Name: "Care guide", Namespace: "app", Key: "care_guide", Type: MetafieldTypeMultiLineTextField, OwnerType: "PRODUCT"},
+		},
+		CarrierServices:     []CarrierService{{Name: "Rates", CallbackUrl: "https://app.example.com/rates", Active: &active}},
+		FulfillmentServices: []FulfillmentServiceData{{Name: "Warehouse", CallbackURL: "https://app.example.com/fulfillment", InventoryManagement: true}},
+		PruneWebhooks:       true,
+	}
+
+	changes, err := manifest.EnsureInstalled(context.Background(), client)
+	if err != nil {
+		t.Fatalf("InstallManifest.EnsureInstalled returned error: %v", err)
+	}
+	expected := []InstallChange{
+		{Resource: "webhook", Name: "ORDERS_UPDATED", Action: "updated"},
+		{Resource: "webhook", Name: "APP_UNINSTALLED", Action: "created"},
+		{Resource: "webhook", Name: "PRODUCTS_UPDATE", Action: "deleted"},
+		{Resource: "metafield_definition", Name: "PRODUCT app.care_guide", Action: "created"},
+		{Resource: "carrier_service", Name: "Rates", Action: "updated"},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("InstallManifest.EnsureInstalled returned %+v, expected %+v", changes, expected)
+	}
+
+	definition := map[string]interface{}{
+		"name": "Care guide", "namespace": "app", "key": "care_guide", "type": "multi_line_text_field", "ownerType": "PRODUCT",
+	}
+	for _, req := range sent {
+		if req.Query == metafieldDefinitionCreateMutation && !reflect.DeepEqual(req.Variables["definition"], definition) {
+			t.Errorf("InstallManifest.EnsureInstalled sent definition %v, expected %v", req.Variables["definition"], definition)
+		}
+	}
+}
+
+func TestInstallManifestMissingScopes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/oauth/access_scopes.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"access_scopes":[{"handle":"read_orders"}]}`))
+
+	manifest := InstallManifest{
+		Scopes:   []string{"read_orders", "write_orders", "read_products"},
+		Webhooks: []WebhookSubscription{{Topic: "orders/create", CallbackUrl: "https://app.example.com/webhooks"}},
+	}
+	_, err := manifest.EnsureInstalled(context.Background(), client)
+	expected := MissingScopesError{Scopes: []string{"write_orders", "read_products"}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("InstallManifest.EnsureInstalled returned %v, expected %v", err, expected)
+	}
+}
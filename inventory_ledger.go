@@ -0,0 +1,194 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+const inventoryScheduledChangesQuery = `query inventoryScheduledChanges($id: ID!, $locationId: ID!, $after: String) {
+  inventoryItem(id: $id) {
+    inventoryLevel(locationId: $locationId) {
+      scheduledChanges(first: 250, after: $after) {
+        nodes {
+          expectedAt
+          fromName
+          toName
+          quantity
+          ledgerDocumentUri
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+// InventoryScheduledChange is a planned move of quantities between states,
+// e.g. incoming stock of a purchase order becoming available
+type InventoryScheduledChange struct {
+	InventoryItemId   uint64
+	LocationId        uint64
+	ExpectedAt        *time.Time
+	FromName          InventoryQuantityName
+	ToName            InventoryQuantityName
+	Quantity          int
+	LedgerDocumentUri string
+}
+
+// ScheduledChanges returns the scheduled changes of an inventory item at a
+// location with their ledger documents
+func (s *InventoryLevelServiceOp) ScheduledChanges(ctx context.Context, inventoryItemId uint64, locationId uint64) ([]InventoryScheduledChange, error) {
+	vars := map[string]interface{}{
+		"id":         GraphQLId("InventoryItem", inventoryItemId),
+		"locationId": GraphQLId("Location", locationId),
+	}
+
+	var changes []InventoryScheduledChange
+	err := s.client.GraphQLEachNode(ctx, inventoryScheduledChangesQuery, vars, "inventoryItem.inventoryLevel.scheduledChanges", func(node json.RawMessage) error {
+		n := struct {
+			ExpectedAt        *time.Time `json:"expectedAt"`
+			FromName          string     `json:"fromName"`
+			ToName            string     `json:"toName"`
+			Quantity          int        `json:"quantity"`
+			LedgerDocumentUri string     `json:"ledgerDocumentUri"`
+		}{}
+		if err := json.Unmarshal(node, &n); err != nil {
+			return err
+		}
+		changes = append(changes, InventoryScheduledChange{
+			InventoryItemId:   inventoryItemId,
+			LocationId:        locationId,
+			ExpectedAt:        n.ExpectedAt,
+			FromName:          InventoryQuantityName(n.FromName),
+			ToName:            InventoryQuantityName(n.ToName),
+			Quantity:          n.Quantity,
+			LedgerDocumentUri: n.LedgerDocumentUri,
+		})
+		return nil
+	})
+	return changes, err
+}
+
+// InventoryLedgerEntry is a quantity change recorded in an InventoryLedger
+// with the adjustment group it belongs to
+type InventoryLedgerEntry struct {
+	InventoryChange
+	AdjustmentGroupId    uint64
+	Reason               string
+	ReferenceDocumentUri string
+	CreatedAt            *time.Time
+}
+
+// InventoryLedgerFilter selects entries of an InventoryLedger, zero fields
+// match everything
+type InventoryLedgerFilter struct {
+	InventoryItemId uint64
+	LocationId      uint64
+	Name            InventoryQuantityName
+	Reason          string
+	Since           time.Time
+}
+
+func (f InventoryLedgerFilter) match(e InventoryLedgerEntry) bool {
+	switch {
+	case f.InventoryItemId != 0 && e.InventoryItemId != f.InventoryItemId:
+		return false
+	case f.LocationId != 0 && e.LocationId != f.LocationId:
+		return false
+	case f.Name != "" && e.Name != f.Name:
+		return false
+	case f.Reason != "" && e.Reason != f.Reason:
+		return false
+	case !f.Since.IsZero() && (e.CreatedAt == nil || e.CreatedAt.Before(f.Since)):
+		return false
+	}
+	return true
+}
+
+// InventoryLedger records the inventory adjustment groups returned by
+// SetQuantities and AdjustQuantities to trace stock movements. The Admin API
+// doesn't list past adjustments, so the ledger only knows about the changes
+// recorded in it. It is safe for concurrent use.
+type InventoryLedger struct {
+	mu      sync.Mutex
+	entries []InventoryLedgerEntry
+}
+
+// Record adds the changes of adjustment groups to the ledger, nil groups are
+// ignored so results can be recorded without checking errors first
+func (l *InventoryLedger) Record(groups ...*InventoryAdjustmentGroup) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, change := range group.Changes {
+			l.entries = append(l.entries, InventoryLedgerEntry{
+				InventoryChange:      change,
+				AdjustmentGroupId:    group.Id,
+				Reason:               group.Reason,
+				ReferenceDocumentUri: group.ReferenceDocumentUri,
+				CreatedAt:            group.CreatedAt,
+			})
+		}
+	}
+}
+
+// Entries returns the recorded entries matching the filter in the order
+// they were recorded
+func (l *InventoryLedger) Entries(filter InventoryLedgerFilter) []InventoryLedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var entries []InventoryLedgerEntry
+	for _, e := range l.entries {
+		if filter.match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// InventoryMovement is the sum of the deltas of ledger entries with the same
+// reason and documents
+type InventoryMovement struct {
+	Reason               string
+	ReferenceDocumentUri string
+	LedgerDocumentUri    string
+	Delta                int
+	Changes              int
+}
+
+// Movements sums the deltas of the entries matching the filter by reason and
+// documents, largest decreases first, answering where stock went
+func (l *InventoryLedger) Movements(filter InventoryLedgerFilter) []InventoryMovement {
+	type key struct{ reason, reference, ledger string }
+
+	index := map[key]int{}
+	var movements []InventoryMovement
+	for _, e := range l.Entries(filter) {
+		k := key{e.Reason, e.ReferenceDocumentUri, e.LedgerDocumentUri}
+		i, ok := index[k]
+		if !ok {
+			i = len(movements)
+			index[k] = i
+			movements = append(movements, InventoryMovement{
+				Reason:               e.Reason,
+				ReferenceDocumentUri: e.ReferenceDocumentUri,
+				LedgerDocumentUri:    e.LedgerDocumentUri,
+			})
+		}
+		movements[i].Delta += e.Delta
+		movements[i].Changes++
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].Delta < movements[j].Delta })
+	return movements
+}
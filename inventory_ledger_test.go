@@ -0,0 +1,87 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInventoryLevelScheduledChanges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"": `{"data":{"inventoryItem":{"inventoryLevel":{"scheduledChanges":{
+			"nodes":[{"expectedAt":"2024-03-10T00:00:00Z","fromName":"incoming","toName":"available","quantity":20,"ledgerDocumentUri":"gid://my-erp/PurchaseOrder/9"}],
+			"pageInfo":{"hasNextPage":false}}}}}}`,
+	}))
+
+	changes, err := client.InventoryLevel.ScheduledChanges(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("InventoryLevel.ScheduledChanges returned error: %v", err)
+	}
+
+	if len(sent) != 1 || sent[0].Variables["id"] != "gid://shopify/InventoryItem/1" || sent[0].Variables["locationId"] != "gid://shopify/Location/2" {
+		t.Errorf("InventoryLevel.ScheduledChanges sent %v", sent)
+	}
+	expectedAt := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	expected := []InventoryScheduledChange{{
+		InventoryItemId:   1,
+		LocationId:        2,
+		ExpectedAt:        &expectedAt,
+		FromName:          InventoryQuantityIncoming,
+		ToName:            InventoryQuantityAvailable,
+		Quantity:          20,
+		LedgerDocumentUri: "gid://my-erp/PurchaseOrder/9",
+	}}
+	if len(changes) != 1 || !changes[0].ExpectedAt.Equal(expectedAt) {
+		t.Fatalf("InventoryLevel.ScheduledChanges returned %+v", changes)
+	}
+	changes[0].ExpectedAt = &expectedAt
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("InventoryLevel.ScheduledChanges returned %+v, expected %+v", changes, expected)
+	}
+}
+
+func TestInventoryLedger(t *testing.T) {
+	monday := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	ledger := &InventoryLedger{}
+	ledger.Record(
+		&InventoryAdjustmentGroup{Id: 1, Reason: InventoryReasonShrinkage, ReferenceDocumentUri: "gid://my-erp/Count/1", CreatedAt: &monday,
+			Changes: []InventoryChange{
+				{Name: InventoryQuantityAvailable, InventoryItemId: 10, LocationId: 20, Delta: -3},
+				{Name: InventoryQuantityOnHand, InventoryItemId: 10, LocationId: 20, Delta: -3},
+			}},
+		nil,
+		&InventoryAdjustmentGroup{Id: 2, Reason: InventoryReasonReceived, CreatedAt: &tuesday,
+			Changes: []InventoryChange{
+				{Name: InventoryQuantityAvailable, InventoryItemId: 10, LocationId: 20, Delta: 5},
+				{Name: InventoryQuantityAvailable, InventoryItemId: 11, LocationId: 20, Delta: 7},
+			}},
+		&InventoryAdjustmentGroup{Id: 3, Reason: InventoryReasonShrinkage, ReferenceDocumentUri: "gid://my-erp/Count/1", CreatedAt: &tuesday,
+			Changes: []InventoryChange{
+				{Name: InventoryQuantityAvailable, InventoryItemId: 10, LocationId: 20, Delta: -1},
+			}},
+	)
+
+	entries := ledger.Entries(InventoryLedgerFilter{InventoryItemId: 10, Name: InventoryQuantityAvailable})
+	if len(entries) != 3 || entries[0].AdjustmentGroupId != 1 || entries[1].Reason != InventoryReasonReceived {
+		t.Errorf("InventoryLedger.Entries returned %+v", entries)
+	}
+	if entries := ledger.Entries(InventoryLedgerFilter{Since: tuesday}); len(entries) != 3 {
+		t.Errorf("InventoryLedger.Entries since tuesday returned %d entries, expected 3", len(entries))
+	}
+
+	movements := ledger.Movements(InventoryLedgerFilter{InventoryItemId: 10, Name: InventoryQuantityAvailable})
+	expected := []InventoryMovement{
+		{Reason: InventoryReasonShrinkage, ReferenceDocumentUri: "gid://my-erp/Count/1", Delta: -4, Changes: 2},
+		{Reason: InventoryReasonReceived, Delta: 5, Changes: 1},
+	}
+	if !reflect.DeepEqual(movements, expected) {
+		t.Errorf("InventoryLedger.Movements returned %+v, expected %+v", movements, expected)
+	}
+}
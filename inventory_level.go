@@ -17,6 +17,13 @@ type InventoryLevelService interface {
 	Delete(context.Context, uint64, uint64) error
 	Connect(context.Context, InventoryLevel) (*InventoryLevel, error)
 	Set(context.Context, InventoryLevel) (*InventoryLevel, error)
+	SetQuantities(context.Context, InventorySetQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	AdjustQuantities(context.Context, InventoryAdjustQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	MoveQuantities(context.Context, InventoryMoveQuantitiesInput) (*InventoryAdjustmentGroup, error)
+	Reserve(context.Context, InventoryReservation) ([]*InventoryAdjustmentGroup, error)
+	ReleaseReservation(context.Context, InventoryReservation) ([]*InventoryAdjustmentGroup, error)
+	GetQuantities(context.Context, uint64, ...InventoryQuantityName) ([]InventoryQuantities, error)
+	ScheduledChanges(context.Context, uint64, uint64) ([]InventoryScheduledChange, error)
 }
 
 // InventoryLevelServiceOp is the default implementation of the InventoryLevelService interface
@@ -0,0 +1,421 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const inventoryAdjustmentGroupFields = `
+      id
+      createdAt
+      reason
+      referenceDocumentUri
+      changes {
+        name
+        delta
+        quantityAfterChange
+        ledgerDocumentUri
+        item {
+          legacyResourceId
+        }
+        location {
+          legacyResourceId
+        }
+      }`
+
+const inventorySetQuantitiesMutation = `mutation inventorySetQuantities($input: InventorySetQuantitiesInput!) {
+  inventorySetQuantities(input: $input) {
+    inventoryAdjustmentGroup {` + inventoryAdjustmentGroupFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const inventoryAdjustQuantitiesMutation = `mutation inventoryAdjustQuantities($input: InventoryAdjustQuantitiesInput!) {
+  inventoryAdjustQuantities(input: $input) {
+    inventoryAdjustmentGroup {` + inventoryAdjustmentGroupFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const inventoryMoveQuantitiesMutation = `mutation inventoryMoveQuantities($input: InventoryMoveQuantitiesInput!) {
+  inventoryMoveQuantities(input: $input) {
+    inventoryAdjustmentGroup {` + inventoryAdjustmentGroupFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const inventoryQuantitiesQuery = `query inventoryQuantities($id: ID!, $names: [String!]!, $after: String) {
+  inventoryItem(id: $id) {
+    inventoryLevels(first: 250, after: $after) {
+      nodes {
+        location {
+          legacyResourceId
+        }
+        quantities(names: $names) {
+          name
+          quantity
+          updatedAt
+        }
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+// InventoryQuantityName is a state of inventory quantities
+type InventoryQuantityName string
+
+// https://shopify.dev/docs/apps/fulfillment/inventory-management-apps/quantities-states
+const (
+	InventoryQuantityAvailable      InventoryQuantityName = "available"
+	InventoryQuantityCommitted      InventoryQuantityName = "committed"
+	InventoryQuantityDamaged        InventoryQuantityName = "damaged"
+	InventoryQuantityIncoming       InventoryQuantityName = "incoming"
+	InventoryQuantityOnHand         InventoryQuantityName = "on_hand"
+	InventoryQuantityQualityControl InventoryQuantityName = "quality_control"
+	InventoryQuantityReserved       InventoryQuantityName = "reserved"
+	InventoryQuantitySafetyStock    InventoryQuantityName = "safety_stock"
+)
+
+// Reasons of inventory changes
+const (
+	InventoryReasonCorrection          = "correction"
+	InventoryReasonCycleCountAvailable = "cycle_count_available"
+	InventoryReasonDamaged             = "damaged"
+	InventoryReasonMovementCreated     = "movement_created"
+	InventoryReasonMovementReceived    = "movement_received"
+	InventoryReasonPromotion           = "promotion"
+	InventoryReasonQualityControl      = "quality_control"
+	InventoryReasonReceived            = "received"
+	InventoryReasonReservationCreated  = "reservation_created"
+	InventoryReasonReservationDeleted  = "reservation_deleted"
+	InventoryReasonRestock             = "restock"
+	InventoryReasonSafetyStock         = "safety_stock"
+	InventoryReasonShrinkage           = "shrinkage"
+	InventoryReasonOther               = "other"
+)
+
+// InventorySetQuantitiesInput sets the available or on_hand quantities of
+// inventory items at locations to absolute values
+type InventorySetQuantitiesInput struct {
+	Name   InventoryQuantityName
+	Reason string
+	// ReferenceDocumentUri identifies what caused the change, e.g.
+	// "gid://my-erp/StockCount/42"
+	ReferenceDocumentUri string
+	// set the quantities without checking CompareQuantity
+	IgnoreCompareQuantity bool
+	Quantities            []InventorySetQuantity
+}
+
+// InventorySetQuantity is the quantity of an inventory item at a location.
+// CompareQuantity is the quantity expected before the change, the change
+// fails if it doesn't match.
+type InventorySetQuantity struct {
+	InventoryItemId uint64
+	LocationId      uint64
+	Quantity        int
+	CompareQuantity *int
+}
+
+// InventoryAdjustQuantitiesInput changes quantities of a state by deltas
+type InventoryAdjustQuantitiesInput struct {
+	Name                 InventoryQuantityName
+	Reason               string
+	ReferenceDocumentUri string
+	Changes              []InventoryQuantityChange
+}
+
+// InventoryQuantityChange is a change of the quantity of an inventory item
+// at a location. LedgerDocumentUri is required for states other than
+// available.
+type InventoryQuantityChange struct {
+	InventoryItemId   uint64
+	LocationId        uint64
+	Delta             int
+	LedgerDocumentUri string
+}
+
+// InventoryMoveQuantitiesInput moves quantities between states, e.g. from
+// available to reserved
+type InventoryMoveQuantitiesInput struct {
+	Reason               string
+	ReferenceDocumentUri string
+	Changes              []InventoryMoveQuantityChange
+}
+
+// InventoryMoveQuantityChange moves a quantity of an inventory item at a
+// location from a state to another. The ledger document uris are required
+// for states other than available.
+type InventoryMoveQuantityChange struct {
+	InventoryItemId       uint64
+	LocationId            uint64
+	Quantity              int
+	From                  InventoryQuantityName
+	FromLedgerDocumentUri string
+	To                    InventoryQuantityName
+	ToLedgerDocumentUri   string
+}
+
+// InventoryAdjustmentGroup is the record of an inventory change
+type InventoryAdjustmentGroup struct {
+	Id                   uint64
+	CreatedAt            *time.Time
+	Reason               string
+	ReferenceDocumentUri string
+	Changes              []InventoryChange
+}
+
+// InventoryChange is one quantity change of an InventoryAdjustmentGroup
+type InventoryChange struct {
+	Name                InventoryQuantityName
+	InventoryItemId     uint64
+	LocationId          uint64
+	Delta               int
+	QuantityAfterChange *int
+	LedgerDocumentUri   string
+}
+
+// InventoryQuantities are the quantities of an inventory item at a location
+// by state
+type InventoryQuantities struct {
+	InventoryItemId uint64
+	LocationId      uint64
+	Quantities      map[InventoryQuantityName]int
+	UpdatedAt       *time.Time
+}
+
+type graphQLInventoryAdjustmentGroup struct {
+	Id                   string     `json:"id"`
+	CreatedAt            *time.Time `json:"createdAt"`
+	Reason               string     `json:"reason"`
+	ReferenceDocumentUri string     `json:"referenceDocumentUri"`
+	Changes              []struct {
+		Name                string                 `json:"name"`
+		Delta               int                    `json:"delta"`
+		QuantityAfterChange *int                   `json:"quantityAfterChange"`
+		LedgerDocumentUri   string                 `json:"ledgerDocumentUri"`
+		Item                *graphQLLegacyResource `json:"item"`
+		Location            *graphQLLegacyResource `json:"location"`
+	} `json:"changes"`
+}
+
+func (g *graphQLInventoryAdjustmentGroup) adjustmentGroup() *InventoryAdjustmentGroup {
+	if g == nil {
+		return nil
+	}
+
+	group := &InventoryAdjustmentGroup{
+		CreatedAt:            g.CreatedAt,
+		Reason:               g.Reason,
+		ReferenceDocumentUri: g.ReferenceDocumentUri,
+	}
+	group.Id, _ = ParseGraphQLId(g.Id)
+	for _, c := range g.Changes {
+		group.Changes = append(group.Changes, InventoryChange{
+			Name:                InventoryQuantityName(c.Name),
+			InventoryItemId:     c.Item.id(),
+			LocationId:          c.Location.id(),
+			Delta:               c.Delta,
+			QuantityAfterChange: c.QuantityAfterChange,
+			LedgerDocumentUri:   c.LedgerDocumentUri,
+		})
+	}
+	return group
+}
+
+type inventoryAdjustmentResult struct {
+	InventoryAdjustmentGroup *graphQLInventoryAdjustmentGroup `json:"inventoryAdjustmentGroup"`
+	UserErrors               GraphQLUserErrors                `json:"userErrors"`
+}
+
+func (r inventoryAdjustmentResult) result() (*InventoryAdjustmentGroup, error) {
+	if len(r.UserErrors) > 0 {
+		return nil, r.UserErrors
+	}
+	return r.InventoryAdjustmentGroup.adjustmentGroup(), nil
+}
+
+// SetQuantities sets the available or on_hand quantities of inventory items
+// through the GraphQL inventorySetQuantities mutation. Unlike Set, it can
+// correct on_hand quantities and records a reason and reference document.
+func (s *InventoryLevelServiceOp) SetQuantities(ctx context.Context, input InventorySetQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	quantities := make([]map[string]interface{}, 0, len(input.Quantities))
+	for _, q := range input.Quantities {
+		quantity := map[string]interface{}{
+			"inventoryItemId": GraphQLId("InventoryItem", q.InventoryItemId),
+			"locationId":      GraphQLId("Location", q.LocationId),
+			"quantity":        q.Quantity,
+		}
+		if q.CompareQuantity != nil {
+			quantity["compareQuantity"] = *q.CompareQuantity
+		}
+		quantities = append(quantities, quantity)
+	}
+
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"name":                  string(input.Name),
+			"reason":                input.Reason,
+			"referenceDocumentUri":  input.ReferenceDocumentUri,
+			"ignoreCompareQuantity": input.IgnoreCompareQuantity,
+			"quantities":            quantities,
+		},
+	}
+	if input.ReferenceDocumentUri == "" {
+		delete(vars["input"].(map[string]interface{}), "referenceDocumentUri")
+	}
+
+	resp := struct {
+		InventorySetQuantities inventoryAdjustmentResult `json:"inventorySetQuantities"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, inventorySetQuantitiesMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.InventorySetQuantities.result()
+}
+
+// AdjustQuantities changes quantities of a state of inventory items by
+// deltas through the GraphQL inventoryAdjustQuantities mutation
+func (s *InventoryLevelServiceOp) AdjustQuantities(ctx context.Context, input InventoryAdjustQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	changes := make([]map[string]interface{}, 0, len(input.Changes))
+	for _, c := range input.Changes {
+		change := map[string]interface{}{
+			"inventoryItemId": GraphQLId("InventoryItem", c.InventoryItemId),
+			"locationId":      GraphQLId("Location", c.LocationId),
+			"delta":           c.Delta,
+		}
+		if c.LedgerDocumentUri != "" {
+			change["ledgerDocumentUri"] = c.LedgerDocumentUri
+		}
+		changes = append(changes, change)
+	}
+
+	adjust := map[string]interface{}{
+		"name":    string(input.Name),
+		"reason":  input.Reason,
+		"changes": changes,
+	}
+	if input.ReferenceDocumentUri != "" {
+		adjust["referenceDocumentUri"] = input.ReferenceDocumentUri
+	}
+
+	resp := struct {
+		InventoryAdjustQuantities inventoryAdjustmentResult `json:"inventoryAdjustQuantities"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, inventoryAdjustQuantitiesMutation, map[string]interface{}{"input": adjust}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.InventoryAdjustQuantities.result()
+}
+
+// MoveQuantities moves quantities of inventory items between states through
+// the GraphQL inventoryMoveQuantities mutation. The total of the states is
+// unchanged, e.g. moving available to reserved leaves on_hand alone.
+func (s *InventoryLevelServiceOp) MoveQuantities(ctx context.Context, input InventoryMoveQuantitiesInput) (*InventoryAdjustmentGroup, error) {
+	side := func(name InventoryQuantityName, locationId uint64, ledgerDocumentUri string) map[string]interface{} {
+		terminal := map[string]interface{}{
+			"name":       string(name),
+			"locationId": GraphQLId("Location", locationId),
+		}
+		if ledgerDocumentUri != "" {
+			terminal["ledgerDocumentUri"] = ledgerDocumentUri
+		}
+		return terminal
+	}
+
+	changes := make([]map[string]interface{}, 0, len(input.Changes))
+	for _, c := range input.Changes {
+		changes = append(changes, map[string]interface{}{
+			"inventoryItemId": GraphQLId("InventoryItem", c.InventoryItemId),
+			"quantity":        c.Quantity,
+			"from":            side(c.From, c.LocationId, c.FromLedgerDocumentUri),
+			"to":              side(c.To, c.LocationId, c.ToLedgerDocumentUri),
+		})
+	}
+
+	move := map[string]interface{}{
+		"reason":               input.Reason,
+		"referenceDocumentUri": input.ReferenceDocumentUri,
+		"changes":              changes,
+	}
+
+	resp := struct {
+		InventoryMoveQuantities inventoryAdjustmentResult `json:"inventoryMoveQuantities"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, inventoryMoveQuantitiesMutation, map[string]interface{}{"input": move}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.InventoryMoveQuantities.result()
+}
+
+// GetQuantities returns the quantities of an inventory item by state at
+// every location it is stocked at. All states are returned when no names
+// are given.
+func (s *InventoryLevelServiceOp) GetQuantities(ctx context.Context, inventoryItemId uint64, names ...InventoryQuantityName) ([]InventoryQuantities, error) {
+	if len(names) == 0 {
+		names = []InventoryQuantityName{
+			InventoryQuantityAvailable, InventoryQuantityCommitted, InventoryQuantityDamaged, InventoryQuantityIncoming,
+			InventoryQuantityOnHand, InventoryQuantityQualityControl, InventoryQuantityReserved, InventoryQuantitySafetyStock,
+		}
+	}
+	nameStrings := make([]string, 0, len(names))
+	for _, name := range names {
+		nameStrings = append(nameStrings, string(name))
+	}
+
+	vars := map[string]interface{}{
+		"id":    GraphQLId("InventoryItem", inventoryItemId),
+		"names": nameStrings,
+	}
+
+	var levels []InventoryQuantities
+	err := s.client.GraphQLEachNode(ctx, inventoryQuantitiesQuery, vars, "inventoryItem.inventoryLevels", func(node json.RawMessage) error {
+		n := struct {
+			Location   *graphQLLegacyResource `json:"location"`
+			Quantities []struct {
+				Name      string     `json:"name"`
+				Quantity  int        `json:"quantity"`
+				UpdatedAt *time.Time `json:"updatedAt"`
+			} `json:"quantities"`
+		}{}
+		if err := json.Unmarshal(node, &n); err != nil {
+			return err
+		}
+
+		level := InventoryQuantities{
+			InventoryItemId: inventoryItemId,
+			LocationId:      n.Location.id(),
+			Quantities:      make(map[InventoryQuantityName]int, len(n.Quantities)),
+		}
+		for _, q := range n.Quantities {
+			level.Quantities[InventoryQuantityName(strings.ToLower(q.Name))] = q.Quantity
+			if q.UpdatedAt != nil && (level.UpdatedAt == nil || q.UpdatedAt.After(*level.UpdatedAt)) {
+				level.UpdatedAt = q.UpdatedAt
+			}
+		}
+		levels = append(levels, level)
+		return nil
+	})
+	return levels, err
+}
@@ -0,0 +1,195 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestInventoryLevelSetQuantities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"inventorySetQuantities":{
+			"inventoryAdjustmentGroup":{
+				"id":"gid://shopify/InventoryAdjustmentGroup/5",
+				"createdAt":"2024-03-01T14:00:00Z",
+				"reason":"cycle_count_available",
+				"referenceDocumentUri":"gid://my-erp/StockCount/42",
+				"changes":[
+					{"name":"on_hand","delta":-2,"quantityAfterChange":8,"item":{"legacyResourceId":"1"},"location":{"legacyResourceId":"2"}},
+					{"name":"available","delta":-2,"quantityAfterChange":6,"item":{"legacyResourceId":"1"},"location":{"legacyResourceId":"2"}}
+				]
+			},
+			"userErrors":[]
+		}}}`
+	})
+
+	expected := 10
+	group, err := client.InventoryLevel.SetQuantities(context.Background(), InventorySetQuantitiesInput{
+		Name:                 InventoryQuantityOnHand,
+		Reason:               InventoryReasonCycleCountAvailable,
+		ReferenceDocumentUri: "gid://my-erp/StockCount/42",
+		Quantities: []InventorySetQuantity{
+			{InventoryItemId: 1, LocationId: 2, Quantity: 8, CompareQuantity: &expected},
+			{InventoryItemId: 3, LocationId: 2, Quantity: 0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InventoryLevel.SetQuantities returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"name":                  "on_hand",
+			"reason":                "cycle_count_available",
+			"referenceDocumentUri":  "gid://my-erp/StockCount/42",
+			"ignoreCompareQuantity": false,
+			"quantities": []interface{}{
+				map[string]interface{}{
+					"inventoryItemId": "gid://shopify/InventoryItem/1",
+					"locationId":      "gid://shopify/Location/2",
+					"quantity":        float64(8),
+					"compareQuantity": float64(10),
+				},
+				map[string]interface{}{
+					"inventoryItemId": "gid://shopify/InventoryItem/3",
+					"locationId":      "gid://shopify/Location/2",
+					"quantity":        float64(0),
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("InventoryLevel.SetQuantities sent variables %v, expected %v", vars, expectedVars)
+	}
+
+	if group.Id != 5 || group.Reason != InventoryReasonCycleCountAvailable || len(group.Changes) != 2 {
+		t.Fatalf("InventoryLevel.SetQuantities returned %+v", group)
+	}
+	change := group.Changes[0]
+	if change.Name != InventoryQuantityOnHand || change.InventoryItemId != 1 || change.LocationId != 2 ||
+		change.Delta != -2 || change.QuantityAfterChange == nil || *change.QuantityAfterChange != 8 {
+		t.Errorf("InventoryLevel.SetQuantities returned change %+v", change)
+	}
+}
+
+func TestInventoryLevelAdjustQuantities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"inventoryAdjustQuantities":{
+			"inventoryAdjustmentGroup":{
+				"id":"gid://shopify/InventoryAdjustmentGroup/6",
+				"reason":"damaged",
+				"changes":[
+					{"name":"damaged","delta":3,"ledgerDocumentUri":"gid://my-erp/Damage/7","item":{"legacyResourceId":"1"},"location":{"legacyResourceId":"2"}}
+				]
+			},
+			"userErrors":[]
+		}}}`
+	})
+
+	group, err := client.InventoryLevel.AdjustQuantities(context.Background(), InventoryAdjustQuantitiesInput{
+		Name:   InventoryQuantityDamaged,
+		Reason: InventoryReasonDamaged,
+		Changes: []InventoryQuantityChange{
+			{InventoryItemId: 1, LocationId: 2, Delta: 3, LedgerDocumentUri: "gid://my-erp/Damage/7"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InventoryLevel.AdjustQuantities returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"name":   "damaged",
+			"reason": "damaged",
+			"changes": []interface{}{
+				map[string]interface{}{
+					"inventoryItemId":   "gid://shopify/InventoryItem/1",
+					"locationId":        "gid://shopify/Location/2",
+					"delta":             float64(3),
+					"ledgerDocumentUri": "gid://my-erp/Damage/7",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("InventoryLevel.AdjustQuantities sent variables %v, expected %v", vars, expectedVars)
+	}
+	if group.Id != 6 || len(group.Changes) != 1 || group.Changes[0].LedgerDocumentUri != "gid://my-erp/Damage/7" {
+		t.Errorf("InventoryLevel.AdjustQuantities returned %+v", group)
+	}
+}
+
+func TestInventoryLevelAdjustQuantitiesUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"inventoryAdjustQuantities":{
+			"inventoryAdjustmentGroup":null,
+			"userErrors":[{"field":["input","changes","0","ledgerDocumentUri"],"message":"A ledger document URI is required"}]
+		}}}`
+	})
+
+	_, err := client.InventoryLevel.AdjustQuantities(context.Background(), InventoryAdjustQuantitiesInput{
+		Name:    InventoryQuantityReserved,
+		Reason:  InventoryReasonOther,
+		Changes: []InventoryQuantityChange{{InventoryItemId: 1, LocationId: 2, Delta: 1}},
+	})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("InventoryLevel.AdjustQuantities returned %v, expected user errors", err)
+	}
+}
+
+func TestInventoryLevelGetQuantities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"": `{"data":{"inventoryItem":{"inventoryLevels":{
+			"nodes":[{"location":{"legacyResourceId":"2"},"quantities":[
+				{"name":"on_hand","quantity":8,"updatedAt":"2024-03-01T14:00:00Z"},
+				{"name":"reserved","quantity":1,"updatedAt":"2024-03-02T14:00:00Z"}
+			]}],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`,
+		"abc": `{"data":{"inventoryItem":{"inventoryLevels":{
+			"nodes":[{"location":{"legacyResourceId":"3"},"quantities":[
+				{"name":"on_hand","quantity":0},
+				{"name":"reserved","quantity":0}
+			]}],
+			"pageInfo":{"hasNextPage":false}}}}}`,
+	}))
+
+	levels, err := client.InventoryLevel.GetQuantities(context.Background(), 1, InventoryQuantityOnHand, InventoryQuantityReserved)
+	if err != nil {
+		t.Fatalf("InventoryLevel.GetQuantities returned error: %v", err)
+	}
+
+	if len(sent) != 2 || sent[0].Variables["id"] != "gid://shopify/InventoryItem/1" ||
+		!reflect.DeepEqual(sent[0].Variables["names"], []interface{}{"on_hand", "reserved"}) {
+		t.Errorf("InventoryLevel.GetQuantities sent %v", sent)
+	}
+	if len(levels) != 2 {
+		t.Fatalf("InventoryLevel.GetQuantities returned %d levels, expected 2", len(levels))
+	}
+	expected := map[InventoryQuantityName]int{InventoryQuantityOnHand: 8, InventoryQuantityReserved: 1}
+	if levels[0].LocationId != 2 || levels[0].InventoryItemId != 1 || !reflect.DeepEqual(levels[0].Quantities, expected) {
+		t.Errorf("InventoryLevel.GetQuantities returned %+v", levels[0])
+	}
+	if levels[0].UpdatedAt == nil || levels[0].UpdatedAt.Day() != 2 {
+		t.Errorf("InventoryLevel.GetQuantities returned updated at %v, expected the latest", levels[0].UpdatedAt)
+	}
+	if levels[1].LocationId != 3 || levels[1].UpdatedAt != nil {
+		t.Errorf("InventoryLevel.GetQuantities returned %+v", levels[1])
+	}
+}
@@ -0,0 +1,150 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// changes moved by a single inventoryMoveQuantities mutation
+const inventoryMoveBatchSize = 250
+
+// how long undoing a failed reservation may take, it doesn't use the
+// caller's context which may be why the reservation failed
+const inventoryCompensationTimeout = time.Minute
+
+// InventoryReservation is the inventory held for an order managed outside of
+// Shopify, e.g. by an OMS or a marketplace. Its quantities are moved from
+// available to reserved, so they can't be sold twice, and back to available
+// when the order is cancelled.
+type InventoryReservation struct {
+	// ReferenceDocumentUri identifies the order, e.g.
+	// "gid://my-oms/Order/42". It's also the ledger document of the reserved
+	// quantities and is required.
+	ReferenceDocumentUri string
+	Lines                []InventoryReservationLine
+}
+
+// InventoryReservationLine is a quantity of an inventory item reserved at a
+// location
+type InventoryReservationLine struct {
+	InventoryItemId uint64
+	LocationId      uint64
+	Quantity        int
+}
+
+// InventoryReservationError is returned when a reservation or its release
+// failed. The quantities already moved are moved back, if that failed too
+// CompensationErr is set and Moved lists the lines left moved.
+type InventoryReservationError struct {
+	Err             error
+	CompensationErr error
+	Moved           []InventoryReservationLine
+}
+
+func (e *InventoryReservationError) Error() string {
+	if e.CompensationErr != nil {
+		return fmt.Sprintf("inventory reservation failed: %s, undoing %d moved lines failed: %s", e.Err, len(e.Moved), e.CompensationErr)
+	}
+	return fmt.Sprintf("inventory reservation failed: %s", e.Err)
+}
+
+func (e *InventoryReservationError) Unwrap() error {
+	return e.Err
+}
+
+// Reserve moves the quantities of the reservation from available to
+// reserved. Reservations of more than 250 lines take several mutations, if
+// one fails the lines already reserved are released, even when ctx is done,
+// and an *InventoryReservationError is returned, leaving inventory as it
+// was.
+func (s *InventoryLevelServiceOp) Reserve(ctx context.Context, reservation InventoryReservation) ([]*InventoryAdjustmentGroup, error) {
+	return s.moveReservation(ctx, reservation, true)
+}
+
+// ReleaseReservation moves the quantities of the reservation from reserved
+// back to available, e.g. when its order is cancelled. Like Reserve, a
+// release failing part way is undone.
+func (s *InventoryLevelServiceOp) ReleaseReservation(ctx context.Context, reservation InventoryReservation) ([]*InventoryAdjustmentGroup, error) {
+	return s.moveReservation(ctx, reservation, false)
+}
+
+func (s *InventoryLevelServiceOp) moveReservation(ctx context.Context, reservation InventoryReservation, reserve bool) ([]*InventoryAdjustmentGroup, error) {
+	if reservation.ReferenceDocumentUri == "" {
+		return nil, errors.New("inventory reservation requires a ReferenceDocumentUri")
+	}
+	for _, line := range reservation.Lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("inventory reservation of item %d at location %d has quantity %d", line.InventoryItemId, line.LocationId, line.Quantity)
+		}
+	}
+
+	var groups []*InventoryAdjustmentGroup
+	for start := 0; start < len(reservation.Lines); start += inventoryMoveBatchSize {
+		end := start + inventoryMoveBatchSize
+		if end > len(reservation.Lines) {
+			end = len(reservation.Lines)
+		}
+
+		group, err := s.MoveQuantities(ctx, reservationMove(reservation.ReferenceDocumentUri, reservation.Lines[start:end], reserve))
+		if err != nil {
+			return nil, s.compensateReservation(reservation.ReferenceDocumentUri, reservation.Lines[:start], reserve, err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// compensateReservation moves the lines which were moved before err back
+func (s *InventoryLevelServiceOp) compensateReservation(uri string, moved []InventoryReservationLine, reserve bool, err error) error {
+	resErr := &InventoryReservationError{Err: err}
+	if len(moved) == 0 {
+		return resErr
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), inventoryCompensationTimeout)
+	defer cancel()
+	for start := 0; start < len(moved); start += inventoryMoveBatchSize {
+		end := start + inventoryMoveBatchSize
+		if end > len(moved) {
+			end = len(moved)
+		}
+
+		_, compErr := s.MoveQuantities(ctx, reservationMove(uri, moved[start:end], !reserve))
+		if compErr != nil {
+			resErr.CompensationErr = compErr
+			resErr.Moved = moved[start:]
+			break
+		}
+	}
+	return resErr
+}
+
+func reservationMove(uri string, lines []InventoryReservationLine, reserve bool) InventoryMoveQuantitiesInput {
+	move := InventoryMoveQuantitiesInput{
+		Reason:               InventoryReasonReservationCreated,
+		ReferenceDocumentUri: uri,
+		Changes:              make([]InventoryMoveQuantityChange, 0, len(lines)),
+	}
+	if !reserve {
+		move.Reason = InventoryReasonReservationDeleted
+	}
+
+	for _, line := range lines {
+		change := InventoryMoveQuantityChange{
+			InventoryItemId: line.InventoryItemId,
+			LocationId:      line.LocationId,
+			Quantity:        line.Quantity,
+		}
+		if reserve {
+			change.From = InventoryQuantityAvailable
+			change.To, change.ToLedgerDocumentUri = InventoryQuantityReserved, uri
+		} else {
+			change.From, change.FromLedgerDocumentUri = InventoryQuantityReserved, uri
+			change.To = InventoryQuantityAvailable
+		}
+		move.Changes = append(move.Changes, change)
+	}
+	return move
+}
@@ -0,0 +1,244 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+const inventoryMoveResponse = `{"data":{"inventoryMoveQuantities":{
+	"inventoryAdjustmentGroup":{
+		"id":"gid://shopify/InventoryAdjustmentGroup/%d",
+		"createdAt":"2024-03-01T14:00:00Z",
+		"reason":"reservation_created",
+		"referenceDocumentUri":"gid://my-oms/Order/42",
+		"changes":[]
+	},
+	"userErrors":[]
+}}}`
+
+const inventoryMoveUserErrorResponse = `{"data":{"inventoryMoveQuantities":{
+	"inventoryAdjustmentGroup":null,
+	"userErrors":[{"field":["input","changes","0","quantity"],"message":"Not enough available inventory"}]
+}}}`
+
+func reservationLines(n int) []InventoryReservationLine {
+	lines := make([]InventoryReservationLine, 0, n)
+	for i := 1; i <= n; i++ {
+		lines = append(lines, InventoryReservationLine{InventoryItemId: uint64(i), LocationId: 7, Quantity: 1})
+	}
+	return lines
+}
+
+func TestInventoryLevelReserve(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLResponsesInOrder(t, fmt.Sprintf(inventoryMoveResponse, 5)))
+
+	groups, err := client.InventoryLevel.Reserve(context.Background(), InventoryReservation{
+		ReferenceDocumentUri: "gid://my-oms/Order/42",
+		Lines:                []InventoryReservationLine{{InventoryItemId: 1, LocationId: 7, Quantity: 3}},
+	})
+	if err != nil {
+		t.Fatalf("InventoryLevel.Reserve returned error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Id != 5 {
+		t.Errorf("InventoryLevel.Reserve returned %+v", groups)
+	}
+
+	expected := map[string]interface{}{
+		"input": map[string]interface{}{
+			"reason":               "reservation_created",
+			"referenceDocumentUri": "gid://my-oms/Order/42",
+			"changes": []interface{}{
+				map[string]interface{}{
+					"inventoryItemId": "gid://shopify/InventoryItem/1",
+					"quantity":        float64(3),
+					"from": map[string]interface{}{
+						"name":       "available",
+						"locationId": "gid://shopify/Location/7",
+					},
+					"to": map[string]interface{}{
+						"name":              "reserved",
+						"locationId":        "gid://shopify/Location/7",
+						"ledgerDocumentUri": "gid://my-oms/Order/42",
+					},
+				},
+			},
+		},
+	}
+	if len(sent) != 1 || sent[0].Query != inventoryMoveQuantitiesMutation || !reflect.DeepEqual(sent[0].Variables, expected) {
+		t.Errorf("InventoryLevel.Reserve sent %+v, expected %v", sent, expected)
+	}
+}
+
+func TestInventoryLevelReleaseReservation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLResponsesInOrder(t, fmt.Sprintf(inventoryMoveResponse, 6)))
+
+	_, err := client.InventoryLevel.ReleaseReservation(context.Background(), InventoryReservation{
+		ReferenceDocumentUri: "gid://my-oms/Order/42",
+		Lines:                []InventoryReservationLine{{InventoryItemId: 1, LocationId: 7, Quantity: 3}},
+	})
+	if err != nil {
+		t.Fatalf("InventoryLevel.ReleaseReservation returned error: %v", err)
+	}
+
+	input := sent[0].Variables["input"].(map[string]interface{})
+	change := input["changes"].([]interface{})[0].(map[string]interface{})
+	from := change["from"].(map[string]interface{})
+	to := change["to"].(map[string]interface{})
+	if input["reason"] != "reservation_deleted" || from["name"] != "reserved" || from["ledgerDocumentUri"] != "gid://my-oms/Order/42" ||
+		to["name"] != "available" || to["ledgerDocumentUri"] != nil {
+		t.Errorf("InventoryLevel.ReleaseReservation sent %v", input)
+	}
+}
+
+func TestInventoryLevelReserveCompensates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLResponsesInOrder(t,
+		fmt.Sprintf(inventoryMoveResponse, 1),
+		inventoryMoveUserErrorResponse,
+		fmt.Sprintf(inventoryMoveResponse, 2),
+	))
+
+	groups, err := client.InventoryLevel.Reserve(context.Background(), InventoryReservation{
+		ReferenceDocumentUri: "gid://my-oms/Order/42",
+		Lines:                reservationLines(inventoryMoveBatchSize + 1),
+	})
+	if groups != nil {
+		t.Errorf("InventoryLevel.Reserve returned %+v", groups)
+	}
+	var resErr *InventoryReservationError
+	if !errors.As(err, &resErr) || resErr.CompensationErr != nil {
+		t.Fatalf("InventoryLevel.Reserve returned error %#v", err)
+	}
+	var userErrs GraphQLUserErrors
+	if !errors.As(err, &userErrs) {
+		t.Errorf("InventoryLevel.Reserve error %v doesn't wrap the user errors", err)
+	}
+
+	if len(sent) != 3 {
+		t.Fatalf("InventoryLevel.Reserve sent %d mutations, expected 3", len(sent))
+	}
+	undo := sent[2].Variables["input"].(map[string]interface{})
+	if undo["reason"] != "reservation_deleted" || len(undo["changes"].([]interface{})) != inventoryMoveBatchSize {
+		t.Errorf("InventoryLevel.Reserve undid with %v", undo["reason"])
+	}
+}
+
+func TestInventoryLevelReserveCompensationFails(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLResponsesInOrder(t,
+		fmt.Sprintf(inventoryMoveResponse, 1),
+		inventoryMoveUserErrorResponse,
+		inventoryMoveUserErrorResponse,
+	))
+
+	lines := reservationLines(inventoryMoveBatchSize + 1)
+	_, err := client.InventoryLevel.Reserve(context.Background(), InventoryReservation{
+		ReferenceDocumentUri: "gid://my-oms/Order/42",
+		Lines:                lines,
+	})
+	resErr, ok := err.(*InventoryReservationError)
+	if !ok || resErr.CompensationErr == nil {
+		t.Fatalf("InventoryLevel.Reserve returned error %#v", err)
+	}
+	if !reflect.DeepEqual(resErr.Moved, lines[:inventoryMoveBatchSize]) {
+		t.Errorf("InventoryLevel.Reserve left %d lines moved, expected %d", len(resErr.Moved), inventoryMoveBatchSize)
+	}
+}
+
+func TestInventoryLevelReserveFirstBatchFails(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLResponsesInOrder(t, inventoryMoveUserErrorResponse))
+
+	_, err := client.InventoryLevel.Reserve(context.Background(), InventoryReservation{
+		ReferenceDocumentUri: "gid://my-oms/Order/42",
+		Lines:                reservationLines(2),
+	})
+	var resErr *InventoryReservationError
+	var userErrs GraphQLUserErrors
+	if !errors.As(err, &resErr) || !errors.As(err, &userErrs) || resErr.Moved != nil {
+		t.Errorf("InventoryLevel.Reserve returned error %#v, expected the user errors", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("InventoryLevel.Reserve sent %d mutations, expected 1", len(sent))
+	}
+}
+
+func TestInventoryLevelReserveCancelledCompensates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	var reasons []interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			body, _ := ioutil.ReadAll(req.Body)
+			var data graphQLRequest
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("invalid graphql request body: %v", err)
+			}
+			reasons = append(reasons, data.Variables["input"].(map[string]interface{})["reason"])
+			if calls == 2 {
+				// the caller gives up during the second batch
+				cancel()
+				return nil, context.Canceled
+			}
+			return httpmock.NewStringResponse(200, fmt.Sprintf(inventoryMoveResponse, calls)), nil
+		})
+
+	_, err := client.InventoryLevel.Reserve(ctx, InventoryReservation{
+		ReferenceDocumentUri: "gid://my-oms/Order/42",
+		Lines:                reservationLines(inventoryMoveBatchSize + 1),
+	})
+	var resErr *InventoryReservationError
+	if !errors.As(err, &resErr) || resErr.CompensationErr != nil {
+		t.Fatalf("InventoryLevel.Reserve returned error %#v, expected the reservation undone", err)
+	}
+	expected := []interface{}{"reservation_created", "reservation_created", "reservation_deleted"}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("InventoryLevel.Reserve sent mutations %v, expected %v", reasons, expected)
+	}
+}
+
+func TestInventoryLevelReserveInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []InventoryReservation{
+		{Lines: reservationLines(1)},
+		{ReferenceDocumentUri: "gid://my-oms/Order/42", Lines: []InventoryReservationLine{{InventoryItemId: 1, LocationId: 7}}},
+	}
+	for _, c := range cases {
+		if _, err := client.InventoryLevel.Reserve(context.Background(), c); err == nil {
+			t.Errorf("InventoryLevel.Reserve(%+v) returned no error", c)
+		}
+	}
+}
@@ -146,6 +146,10 @@ func (s *MetafieldServiceOp) Get(ctx context.Context, metafieldId uint64, option
 
 // Create a new metafield
 func (s *MetafieldServiceOp) Create(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	if err := s.client.validateCreate("metafield", metafield); err != nil {
+		return nil, err
+	}
+
 	prefix := MetafieldPathPrefix(s.resource, s.resourceId)
 	path := fmt.Sprintf("%s.json", prefix)
 	wrappedData := MetafieldResource{Metafield: &metafield}
@@ -0,0 +1,44 @@
+package goshopify
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetricsCollector receives measurements about the requests made by a
+// client. It can be backed by Prometheus, StatsD or any other metrics
+// system, see WithMetrics.
+type MetricsCollector interface {
+	// ObserveRequest is called once per HTTP attempt. The resource is the
+	// request path without the api prefix, with ids replaced by ":id", e.g.
+	// "orders/:id/fulfillments". Status is 0 when no response was received.
+	ObserveRequest(shop, method, resource string, status int, duration time.Duration)
+
+	// ObserveRetry is called before a request is retried after the given
+	// status, e.g. 429 or 503.
+	ObserveRetry(shop, resource string, status int)
+
+	// ObserveCallLimit is called with the REST call limit bucket usage
+	// reported by Shopify.
+	ObserveCallLimit(shop string, used, size int)
+
+	// ObserveGraphQLCost is called with the cost reported by every GraphQL
+	// query.
+	ObserveGraphQLCost(shop string, cost GraphQLCost)
+}
+
+// metricsResource returns the resource label for a request path
+func (c *Client) metricsResource(req *http.Request) string {
+	p := strings.TrimPrefix(c.relativePath(req.URL), c.pathPrefix)
+	p = strings.TrimSuffix(strings.Trim(p, "/"), ".json")
+
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		if s != "" && strings.Trim(s, "0123456789") == "" {
+			segments[i] = ":id"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,120 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+type testMetrics struct {
+	requests   []string
+	retries    []string
+	callLimits [][2]int
+	costs      []GraphQLCost
+}
+
+func (m *testMetrics) ObserveRequest(shop, method, resource string, status int, duration time.Duration) {
+	m.requests = append(m.requests, fmt.Sprintf("%s %s %s %d", shop, method, resource, status))
+}
+
+func (m *testMetrics) ObserveRetry(shop, resource string, status int) {
+	m.retries = append(m.retries, fmt.Sprintf("%s %s %d", shop, resource, status))
+}
+
+func (m *testMetrics) ObserveCallLimit(shop string, used, size int) {
+	m.callLimits = append(m.callLimits, [2]int{used, size})
+}
+
+func (m *testMetrics) ObserveGraphQLCost(shop string, cost GraphQLCost) {
+	m.costs = append(m.costs, cost)
+}
+
+func TestMetricsResource(t *testing.T) {
+	c := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion))
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{"/admin/api/9999-99/orders.json", "orders"},
+		{"/admin/api/9999-99/orders/123/fulfillments/456.json", "orders/:id/fulfillments/:id"},
+		{"/admin/api/9999-99/graphql.json", "graphql"},
+		{"/admin/oauth/access_token", "admin/oauth/access_token"},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest("GET", "https://fooshop.myshopify.com"+tc.path, nil)
+		if actual := c.metricsResource(req); actual != tc.expected {
+			t.Errorf("metricsResource(%s) = %s, expected %s", tc.path, actual, tc.expected)
+		}
+	}
+}
+
+func TestMetricsRequestsAndRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	metrics := &testMetrics{}
+	WithMetrics(metrics)(client)
+
+	attempts := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`)
+				return resp, nil
+			}
+			resp := httpmock.NewStringResponse(http.StatusOK, `{"order":{"id":1}}`)
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "39/40")
+			return resp, nil
+		})
+
+	_, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	expectedRequests := []string{
+		"fooshop.myshopify.com GET orders/:id 429",
+		"fooshop.myshopify.com GET orders/:id 200",
+	}
+	if !reflect.DeepEqual(metrics.requests, expectedRequests) {
+		t.Errorf("ObserveRequest called with %v, expected %v", metrics.requests, expectedRequests)
+	}
+
+	expectedRetries := []string{"fooshop.myshopify.com orders/:id 429"}
+	if !reflect.DeepEqual(metrics.retries, expectedRetries) {
+		t.Errorf("ObserveRetry called with %v, expected %v", metrics.retries, expectedRetries)
+	}
+
+	expectedCallLimits := [][2]int{{39, 40}}
+	if !reflect.DeepEqual(metrics.callLimits, expectedCallLimits) {
+		t.Errorf("ObserveCallLimit called with %v, expected %v", metrics.callLimits, expectedCallLimits)
+	}
+}
+
+func TestMetricsGraphQLCost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	metrics := &testMetrics{}
+	WithMetrics(metrics)(client)
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"data":{},"extensions":{"cost":{"requestedQueryCost":1,"actualQueryCost":1,"throttleStatus":{"maximumAvailable":1000,"currentlyAvailable":999,"restoreRate":50}}}}`))
+
+	err := client.GraphQL.Query(context.Background(), "query {}", nil, nil)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if len(metrics.costs) != 1 || metrics.costs[0].ThrottleStatus.CurrentlyAvailable != 999 {
+		t.Errorf("ObserveGraphQLCost called with %+v", metrics.costs)
+	}
+}
@@ -0,0 +1,89 @@
+package goshopify
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var moneyPlaceholderRegex = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// moneyPlaceholder is how a placeholder of a money format renders amounts
+type moneyPlaceholder struct {
+	decimals  int32
+	thousands string
+	decimal   string
+}
+
+// the placeholders of money formats
+// See: https://help.shopify.com/en/manual/international/pricing/currency-formatting
+var moneyPlaceholders = map[string]moneyPlaceholder{
+	"amount":                      {2, ",", "."},
+	"amount_no_decimals":          {0, ",", ""},
+	"amount_with_comma_separator": {2, ".", ","},
+	"amount_no_decimals_with_comma_separator": {0, ".", ""},
+	"amount_with_apostrophe_separator":        {2, "'", "."},
+	"amount_no_decimals_with_space_separator": {0, " ", ""},
+	"amount_with_space_separator":             {2, " ", ","},
+	"amount_with_period_and_space_separator":  {2, " ", "."},
+}
+
+// FormatMoney formats an amount with a money format of the shop, e.g.
+// "${{amount}}" or "{{amount_with_comma_separator}} €", the way Shopify
+// renders prices. It returns an error for unknown placeholders.
+func FormatMoney(format string, amount decimal.Decimal) (string, error) {
+	var err error
+	formatted := moneyPlaceholderRegex.ReplaceAllStringFunc(format, func(m string) string {
+		name := moneyPlaceholderRegex.FindStringSubmatch(m)[1]
+		p, ok := moneyPlaceholders[name]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("unknown money format placeholder %q", name)
+			}
+			return m
+		}
+		return p.format(amount)
+	})
+	if err != nil {
+		return "", err
+	}
+	return formatted, nil
+}
+
+func (p moneyPlaceholder) format(amount decimal.Decimal) string {
+	rounded := amount.Round(p.decimals)
+	parts := strings.SplitN(rounded.Abs().StringFixed(p.decimals), ".", 2)
+
+	// group the integer part by thousands
+	integer := parts[0]
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(p.thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	s := grouped.String()
+	if len(parts) == 2 {
+		s += p.decimal + parts[1]
+	}
+	if rounded.IsNegative() {
+		s = "-" + s
+	}
+	return s
+}
+
+// FormatMoney formats an amount in the currency of the shop with its
+// money_format, e.g. "$1,234.50"
+func (s *Shop) FormatMoney(amount decimal.Decimal) (string, error) {
+	return FormatMoney(s.MoneyFormat, amount)
+}
+
+// FormatMoneyWithCurrency formats an amount in the currency of the shop with
+// its money_with_currency_format, e.g. "$1,234.50 USD"
+func (s *Shop) FormatMoneyWithCurrency(amount decimal.Decimal) (string, error) {
+	return FormatMoney(s.MoneyWithCurrencyFormat, amount)
+}
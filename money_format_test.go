@@ -0,0 +1,55 @@
+package goshopify
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFormatMoney(t *testing.T) {
+	cases := []struct {
+		format   string
+		amount   string
+		expected string
+	}{
+		{"${{amount}}", "1234.5", "$1,234.50"},
+		{"${{ amount }} USD", "1234567.891", "$1,234,567.89 USD"},
+		{"${{amount}}", "0.5", "$0.50"},
+		{"${{amount}}", "-1234.5", "$-1,234.50"},
+		{"${{amount_no_decimals}}", "1234.5", "$1,235"},
+		{"{{amount_with_comma_separator}} €", "1234.5", "1.234,50 €"},
+		{"{{amount_no_decimals_with_comma_separator}} kr", "1234567", "1.234.567 kr"},
+		{"CHF {{amount_with_apostrophe_separator}}", "1234.5", "CHF 1'234.50"},
+		{"{{amount_no_decimals_with_space_separator}} Ft", "1234567", "1 234 567 Ft"},
+		{"{{amount_with_space_separator}} zł", "1234.5", "1 234,50 zł"},
+		{"{{amount_with_period_and_space_separator}} CHF", "1234.5", "1 234.50 CHF"},
+		{"<span class=money>${{amount}}</span>", "12", "<span class=money>$12.00</span>"},
+	}
+	for _, c := range cases {
+		formatted, err := FormatMoney(c.format, decimal.RequireFromString(c.amount))
+		if err != nil {
+			t.Errorf("FormatMoney(%q, %s) returned error: %v", c.format, c.amount, err)
+		}
+		if formatted != c.expected {
+			t.Errorf("FormatMoney(%q, %s) returned %q, expected %q", c.format, c.amount, formatted, c.expected)
+		}
+	}
+}
+
+func TestFormatMoneyUnknownPlaceholder(t *testing.T) {
+	if _, err := FormatMoney("${{amont}}", decimal.NewFromInt(1)); err == nil {
+		t.Error("FormatMoney of an unknown placeholder returned no error")
+	}
+}
+
+func TestShopFormatMoney(t *testing.T) {
+	shop := Shop{MoneyFormat: "{{amount_with_comma_separator}} €", MoneyWithCurrencyFormat: "{{amount_with_comma_separator}} EUR"}
+	amount := decimal.RequireFromString("19.9")
+
+	if formatted, _ := shop.FormatMoney(amount); formatted != "19,90 €" {
+		t.Errorf("Shop.FormatMoney returned %q", formatted)
+	}
+	if formatted, _ := shop.FormatMoneyWithCurrency(amount); formatted != "19,90 EUR" {
+		t.Errorf("Shop.FormatMoneyWithCurrency returned %q", formatted)
+	}
+}
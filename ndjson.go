@@ -0,0 +1,84 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ListPageFunc fetches a single page of resources for the given list options.
+// The returned resources must be a slice, e.g. the []Order returned by
+// OrderService.ListWithPagination.
+type ListPageFunc func(ctx context.Context, options interface{}) (interface{}, *Pagination, error)
+
+// NDJSONWriter streams resources to an io.Writer as newline delimited JSON,
+// one resource per line. It is suited for piping exports directly into tools
+// like BigQuery or S3 without holding the whole result set in memory.
+type NDJSONWriter struct {
+	enc   *json.Encoder
+	count int
+}
+
+// NewNDJSONWriter returns a NDJSONWriter writing to w
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &NDJSONWriter{enc: enc}
+}
+
+// Count returns the number of resources written so far
+func (w *NDJSONWriter) Count() int {
+	return w.count
+}
+
+// Encode writes a single resource as one line
+func (w *NDJSONWriter) Encode(v interface{}) error {
+	err := w.enc.Encode(v)
+	if err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// EncodeSlice writes every element of the given slice as its own line
+func (w *NDJSONWriter) EncodeSlice(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("ndjson: expected a slice, got %T", v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		err := w.Encode(rv.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncodePages iterates over every page returned by fetch, starting with the
+// given options, and writes each resource as soon as its page arrives. Only a
+// single page is held in memory at a time.
+func (w *NDJSONWriter) EncodePages(ctx context.Context, fetch ListPageFunc, options interface{}) error {
+	for {
+		entities, pagination, err := fetch(ctx, options)
+		if err != nil {
+			return err
+		}
+
+		err = w.EncodeSlice(entities)
+		if err != nil {
+			return err
+		}
+
+		if pagination == nil || pagination.NextPageOptions == nil {
+			return nil
+		}
+
+		options = pagination.NextPageOptions
+	}
+}
@@ -0,0 +1,93 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestNDJSONWriterEncodeSlice(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewNDJSONWriter(buf)
+
+	err := w.EncodeSlice([]Order{{Id: 1, Name: "#1001"}, {Id: 2, Note: "<b>"}})
+	if err != nil {
+		t.Errorf("NDJSONWriter.EncodeSlice returned error: %v", err)
+	}
+
+	expected := "{\"id\":1,\"name\":\"#1001\"}\n{\"id\":2,\"note\":\"<b>\"}\n"
+	if buf.String() != expected {
+		t.Errorf("NDJSONWriter.EncodeSlice wrote %q, expected %q", buf.String(), expected)
+	}
+
+	if w.Count() != 2 {
+		t.Errorf("NDJSONWriter.Count returned %d, expected %d", w.Count(), 2)
+	}
+}
+
+func TestNDJSONWriterEncodeSliceError(t *testing.T) {
+	w := NewNDJSONWriter(new(bytes.Buffer))
+
+	err := w.EncodeSlice(Order{Id: 1})
+	expected := "ndjson: expected a slice, got goshopify.Order"
+	if err == nil || err.Error() != expected {
+		t.Errorf("NDJSONWriter.EncodeSlice err returned %+v, expected %+v", err, expected)
+	}
+}
+
+func TestNDJSONWriterEncodePages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix)
+	pages := []struct {
+		url  string
+		link string
+		body string
+	}{
+		{listURL, `<http://valid.url?page_info=pg2>; rel="next"`, `{"orders": [{"id":1},{"id":2}]}`},
+		{listURL + "?page_info=pg2", `<http://valid.url?page_info=pg1>; rel="previous"`, `{"orders": [{"id":3}]}`},
+	}
+	for _, p := range pages {
+		response := &http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(p.body),
+			Header:     http.Header{"Link": {p.link}},
+		}
+		httpmock.RegisterResponder("GET", p.url, httpmock.ResponderFromResponse(response))
+	}
+
+	buf := new(bytes.Buffer)
+	w := NewNDJSONWriter(buf)
+	fetch := func(ctx context.Context, options interface{}) (interface{}, *Pagination, error) {
+		return client.Order.ListWithPagination(ctx, options)
+	}
+
+	err := w.EncodePages(context.Background(), fetch, nil)
+	if err != nil {
+		t.Errorf("NDJSONWriter.EncodePages returned error: %v", err)
+	}
+
+	expected := "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"
+	if buf.String() != expected {
+		t.Errorf("NDJSONWriter.EncodePages wrote %q, expected %q", buf.String(), expected)
+	}
+}
+
+func TestNDJSONWriterEncodePagesError(t *testing.T) {
+	w := NewNDJSONWriter(new(bytes.Buffer))
+	fetchErr := errors.New("fetch failed")
+	fetch := func(ctx context.Context, options interface{}) (interface{}, *Pagination, error) {
+		return nil, nil, fetchErr
+	}
+
+	err := w.EncodePages(context.Background(), fetch, nil)
+	if err != fetchErr {
+		t.Errorf("NDJSONWriter.EncodePages err returned %+v, expected %+v", err, fetchErr)
+	}
+}
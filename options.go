@@ -3,6 +3,9 @@ package goshopify
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // Option is used to configure client with options
@@ -40,3 +43,168 @@ func WithHTTPClient(client *http.Client) Option {
 		c.Client = client
 	}
 }
+
+// WithInvalidTokenCallback sets a callback invoked whenever Shopify answers
+// with a 401 rejecting the access token, see IsInvalidTokenError, so apps can
+// mark the shop as uninstalled and stop queuing work for it. Other 401s, e.g.
+// for a missing scope, don't invoke it. The error is still returned to the
+// caller.
+func WithInvalidTokenCallback(callback InvalidTokenCallback) Option {
+	return func(c *Client) {
+		c.onInvalidToken = callback
+	}
+}
+
+// WithMetrics sets a collector receiving request, retry and rate limit
+// measurements, e.g. to export them to Prometheus.
+func WithMetrics(metrics MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
+// WithCircuitBreaker opens the client's circuit after the given number of
+// consecutive 5xx responses or transport errors. While open, requests fail
+// immediately with ErrCircuitOpen. Once the cooldown has passed a single probe
+// request is let through which closes the circuit again on success.
+// As clients are per shop, so is the circuit breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// WithPriorityQueue delays requests while the shop is rate limited and lets
+// requests tagged with PriorityInteractive go before PriorityBatch ones, see
+// WithRequestPriority. Batch requests also hold back while fewer than reserve
+// calls are left in the shop's REST call limit bucket, keeping headroom for
+// interactive requests.
+func WithPriorityQueue(reserve int) Option {
+	return func(c *Client) {
+		c.queue = newPriorityQueue(reserve)
+	}
+}
+
+// WithGraphQLReads serves Get of the given resources, e.g. GraphQLReadOrders,
+// through equivalent GraphQL queries mapped back into the REST structs, easing
+// the migration of apps off the REST API without changing call sites. The
+// REST options passed to Get are ignored in that case and fields GraphQL does
+// not expose are left empty.
+func WithGraphQLReads(resources ...string) Option {
+	return func(c *Client) {
+		if c.graphQLReads == nil {
+			c.graphQLReads = make(map[string]bool, len(resources))
+		}
+		for _, resource := range resources {
+			c.graphQLReads[resource] = true
+		}
+	}
+}
+
+// WithPaginationRetry retries a page of a paginated list failing with an
+// error IsRetryableError accepts up to attempts times in total, waiting
+// backoff in between, and then resumes from the same cursor. Long exports
+// with ListAll or NDJSONWriter.EncodePages then survive transient errors
+// instead of aborting. A nil backoff waits 1s doubling up to 30s. Page
+// retries come on top of the request retries of WithRetry.
+func WithPaginationRetry(attempts int, backoff Backoff) Option {
+	return func(c *Client) {
+		if backoff == nil {
+			backoff = defaultPageRetryBackoff
+		}
+		c.pageRetry = &pageRetry{attempts: attempts, backoff: backoff}
+	}
+}
+
+// WithDryRun builds and validates requests without sending them, e.g. to
+// snapshot what a migration would send. Every call returns a DryRunError with
+// the request and the problems found validating it instead of a response.
+func WithDryRun() Option {
+	return func(c *Client) {
+		c.dryRun = true
+	}
+}
+
+// WithCreateValidation checks the required fields of resources on Create
+// calls, e.g. the line items of orders, and returns a ValidationError without
+// making the API call when any is missing.
+func WithCreateValidation() Option {
+	return func(c *Client) {
+		c.createValidation = true
+	}
+}
+
+// WithStrictEnums makes a call fail with an UnknownEnumValueError when an
+// enum field of the response, e.g. the financial status of an order, has a
+// value this package doesn't know. Shopify adds values over time, so without
+// it unknown values are kept as they are; use the Parse functions, e.g.
+// ParseOrderFinancialStatus, to validate values explicitly.
+func WithStrictEnums() Option {
+	return func(c *Client) {
+		c.strictEnums = true
+	}
+}
+
+// WithTokenRefresher sets a function fetching a fresh access token when
+// Shopify answers with a 401, e.g. after the credentials were rotated. The
+// client then swaps its token and retries the request once. The invalid
+// token callback is only invoked when the fresh token is rejected too.
+func WithTokenRefresher(refresher TokenRefresher) Option {
+	return func(c *Client) {
+		c.tokenRefresher = refresher
+	}
+}
+
+// WithBaseURL overrides the base URL of API requests, which is the shop's
+// myshopify domain otherwise, e.g. to send them to a mock server, through a
+// proxy or to a custom admin domain. The shop name passed to NewClient still
+// identifies the shop, e.g. in callbacks and metrics, but isn't validated.
+func WithBaseURL(baseURL *url.URL) Option {
+	return func(c *Client) {
+		u := *baseURL
+		if !strings.HasSuffix(u.Path, "/") {
+			// keep the last path segment when resolving request paths
+			u.Path += "/"
+		}
+		c.baseURL = &u
+	}
+}
+
+// WithGzip compresses the bodies of POST and PUT requests of at least
+// threshold bytes, e.g. large orders or bulk metafields, which speeds up
+// requests over high-latency links. Requests also ask for gzip compressed
+// responses explicitly and the client decompresses them. A threshold of 0
+// disables compression.
+func WithGzip(threshold int) Option {
+	return func(c *Client) {
+		c.gzipThreshold = threshold
+	}
+}
+
+// WithRequestTimeout sets a default timeout of calls whose context has no
+// deadline, covering retries and the wait for rate limits. Calls exceeding
+// it fail with context.DeadlineExceeded instead of hanging on stalled
+// connections.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithTimeouts sets the timeout of connecting to Shopify, including the TLS
+// handshake, and of reading the response headers once the request is sent.
+// A zero timeout leaves the transport's one. The client's transport must be
+// an *http.Transport, so the option must follow WithHTTPClient; the HTTP
+// client passed isn't changed but copied.
+func WithTimeouts(connect, read time.Duration) Option {
+	return func(c *Client) {
+		t, ok := timeoutTransport(c.Client.Transport, connect, read)
+		if !ok {
+			c.log.Warnf("timeouts not set, the http client's transport isn't an *http.Transport")
+			return
+		}
+		client := *c.Client
+		client.Transport = t
+		c.Client = &client
+	}
+}
@@ -1,10 +1,15 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/jarcoal/httpmock"
 )
 
 func TestWithVersion(t *testing.T) {
@@ -72,3 +77,55 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Errorf("WithVersion client.Client = %s, expected %s", c.Client.Timeout, expected)
 	}
 }
+
+func TestWithInvalidTokenCallback(t *testing.T) {
+	called := false
+	c := MustNewClient(app, "fooshop", "abcd", WithInvalidTokenCallback(func(ctx context.Context, shop, token string, err error) {
+		called = true
+	}))
+
+	if c.onInvalidToken == nil {
+		t.Fatalf("WithInvalidTokenCallback client.onInvalidToken is nil")
+	}
+
+	c.onInvalidToken(context.Background(), "fooshop.myshopify.com", "abcd", nil)
+	if !called {
+		t.Errorf("WithInvalidTokenCallback client.onInvalidToken did not call the callback")
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.example.com/shopify/fooshop")
+	c := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithBaseURL(proxy))
+	httpmock.ActivateNonDefault(c.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://proxy.example.com/shopify/fooshop/admin/api/"+testApiVersion+"/shop.json",
+		httpmock.NewStringResponder(200, `{"shop":{"id":1}}`))
+
+	metrics := &testMetrics{}
+	WithMetrics(metrics)(c)
+
+	if _, err := c.Shop.Get(context.Background(), nil); err != nil {
+		t.Fatalf("Shop.Get through the base url returned error: %v", err)
+	}
+	if !reflect.DeepEqual(metrics.requests, []string{"fooshop.myshopify.com GET shop 200"}) || c.ShopDomain() != "fooshop.myshopify.com" {
+		t.Errorf("WithBaseURL client observed requests %v for shop %s", metrics.requests, c.ShopDomain())
+	}
+	if proxy.Path != "/shopify/fooshop" {
+		t.Errorf("WithBaseURL changed the passed url to %s", proxy)
+	}
+
+	// shop names aren't validated with a base url
+	if _, err := NewClient(app, "admin.example.com", "abcd", WithBaseURL(proxy)); err != nil {
+		t.Errorf("NewClient with a base url returned error: %v", err)
+	}
+}
+
+func TestNewClientInvalidShopName(t *testing.T) {
+	for _, name := range []string{"my_shop", "myshop.example.com", "evil.com/myshop"} {
+		if _, err := NewClient(app, name, "abcd"); err == nil {
+			t.Errorf("NewClient accepted shop name %s", name)
+		}
+	}
+}
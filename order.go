@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -22,13 +23,25 @@ type OrderService interface {
 	ListAll(context.Context, interface{}) ([]Order, error)
 	ListWithPagination(context.Context, interface{}) ([]Order, *Pagination, error)
 	Count(context.Context, interface{}) (int, error)
+	CountExact(context.Context, string) (int, error)
 	Get(context.Context, uint64, interface{}) (*Order, error)
+	GetMany(context.Context, []uint64, interface{}) ([]Order, error)
+	Search(context.Context, string, *OrderSearchOptions) ([]Order, *GraphQLPageInfo, error)
+	GetByName(context.Context, string, interface{}) (*Order, error)
+	ListForCompany(context.Context, uint64, *CompanyOrdersOptions) ([]Order, *GraphQLPageInfo, error)
 	Create(context.Context, Order) (*Order, error)
 	Update(context.Context, Order) (*Order, error)
 	Cancel(context.Context, uint64, interface{}) (*Order, error)
 	Close(context.Context, uint64) (*Order, error)
 	Open(context.Context, uint64) (*Order, error)
 	Delete(context.Context, uint64) error
+	ListTimelineEvents(context.Context, uint64, *TimelineEventsOptions) ([]TimelineEvent, *GraphQLPageInfo, error)
+	AddTags(context.Context, []uint64, ...string) error
+	RemoveTags(context.Context, []uint64, ...string) error
+	GetRetailDetails(context.Context, uint64) (*OrderRetailDetails, error)
+	SendInvoice(context.Context, uint64, DraftOrderInvoice) error
+	ResendShippingConfirmation(context.Context, uint64) error
+	Import(context.Context, Order) (*Order, error)
 
 	// MetafieldsService used for Order resource to communicate with Metafields resource
 	MetafieldsService
@@ -43,162 +56,182 @@ type OrderServiceOp struct {
 	client *Client
 }
 
-type orderStatus string
+// OrderStatus is the status of orders to list or count
+type OrderStatus string
 
 // https://shopify.dev/docs/api/admin-rest/2023-07/resources/order#get-orders?status=any
 const (
 	// Show only open orders.
-	OrderStatusOpen orderStatus = "open"
+	OrderStatusOpen OrderStatus = "open"
 
 	// Show only closed orders.
-	OrderStatusClosed orderStatus = "closed"
+	OrderStatusClosed OrderStatus = "closed"
 
 	// Show only cancelled orders.
-	OrderStatusCancelled orderStatus = "cancelled"
+	OrderStatusCancelled OrderStatus = "cancelled"
 
 	// Show orders of any status, open, closed, cancellerd, or archived.
-	OrderStatusAny orderStatus = "any"
+	OrderStatusAny OrderStatus = "any"
 )
 
-type orderFulfillmentStatus string
+// OrderFulfillmentStatus is the fulfillment status of an order
+type OrderFulfillmentStatus string
 
 // https://shopify.dev/docs/api/admin-rest/2023-07/resources/order#get-orders?status=any
 const (
 	// Show orders that have been shipped.
-	OrderFulfillmentStatusShipped orderFulfillmentStatus = "shipped"
+	OrderFulfillmentStatusShipped OrderFulfillmentStatus = "shipped"
 
 	// Show partially shipped orders.
-	OrderFulfillmentStatusPartial orderFulfillmentStatus = "partial"
+	OrderFulfillmentStatusPartial OrderFulfillmentStatus = "partial"
 
 	// Show orders that have not yet been shipped.
-	OrderFulfillmentStatusUnshipped orderFulfillmentStatus = "unshipped"
+	OrderFulfillmentStatusUnshipped OrderFulfillmentStatus = "unshipped"
 
 	// Show orders of any fulfillment status.
-	OrderFulfillmentStatusAny orderFulfillmentStatus = "any"
+	OrderFulfillmentStatusAny OrderFulfillmentStatus = "any"
 
 	// Returns orders with fulfillment_status of null or partial.
-	OrderFulfillmentStatusUnfulfilled orderFulfillmentStatus = "unfulfilled"
+	OrderFulfillmentStatusUnfulfilled OrderFulfillmentStatus = "unfulfilled"
 
 	//"fulfilled" used to be an acceptable value? Was it deprecated? It isn't noted
 	//in the Shopify docs at the provided URL, but it was used in tests and still
 	//seems to function.
-	OrderFulfillmentStatusFulfilled orderFulfillmentStatus = "fulfilled"
+	OrderFulfillmentStatusFulfilled OrderFulfillmentStatus = "fulfilled"
+
+	// The items of the order were restocked, the status of returned orders.
+	OrderFulfillmentStatusRestocked OrderFulfillmentStatus = "restocked"
+
+	// The line item can't be fulfilled, the status of line items only.
+	OrderFulfillmentStatusNotEligible OrderFulfillmentStatus = "not_eligible"
 )
 
-type orderFinancialStatus string
+// OrderFinancialStatus is the financial status of an order
+type OrderFinancialStatus string
 
 // https://shopify.dev/docs/api/admin-rest/2023-07/resources/order#get-orders?status=any
 const (
 	// Show only authorized orders.
-	OrderFinancialStatusAuthorized orderFinancialStatus = "authorized"
+	OrderFinancialStatusAuthorized OrderFinancialStatus = "authorized"
 
 	// Show only pending orders.
-	OrderFinancialStatusPending orderFinancialStatus = "pending"
+	OrderFinancialStatusPending OrderFinancialStatus = "pending"
 
 	// Show only paid orders.
-	OrderFinancialStatusPaid orderFinancialStatus = "paid"
+	OrderFinancialStatusPaid OrderFinancialStatus = "paid"
 
 	// Show only partially paid orders.
-	OrderFinancialStatusPartiallyPaid orderFinancialStatus = "partially_paid"
+	OrderFinancialStatusPartiallyPaid OrderFinancialStatus = "partially_paid"
 
 	// Show only refunded orders.
-	OrderFinancialStatusRefunded orderFinancialStatus = "refunded"
+	OrderFinancialStatusRefunded OrderFinancialStatus = "refunded"
 
 	// Show only voided orders.
-	OrderFinancialStatusVoided orderFinancialStatus = "voided"
+	OrderFinancialStatusVoided OrderFinancialStatus = "voided"
 
 	// Show only partially refunded orders.
-	OrderFinancialStatusPartiallyRefunded orderFinancialStatus = "partially_refunded"
+	OrderFinancialStatusPartiallyRefunded OrderFinancialStatus = "partially_refunded"
 
 	// Show orders of any financial status.
-	OrderFinancialStatusAny orderFinancialStatus = "any"
+	OrderFinancialStatusAny OrderFinancialStatus = "any"
 
 	// Show authorized and partially paid orders.
-	OrderFinancialStatusUnpaid orderFinancialStatus = "unpaid"
+	OrderFinancialStatusUnpaid OrderFinancialStatus = "unpaid"
 )
 
-type orderCancelReason string
+// OrderCancelReason is the reason an order was cancelled
+type OrderCancelReason string
 
 const (
 	// The customer canceled the order.
-	OrderCancelReasonCustomer orderCancelReason = "customer"
+	OrderCancelReasonCustomer OrderCancelReason = "customer"
 
 	// The order was fraudulent.
-	OrderCancelReasonFraud orderCancelReason = "fraud"
+	OrderCancelReasonFraud OrderCancelReason = "fraud"
 
 	// Items in the order were not in inventory.
-	OrderCancelReasonInventory orderCancelReason = "inventory"
+	OrderCancelReasonInventory OrderCancelReason = "inventory"
 
 	// The payment was declined.
-	OrderCancelReasonDeclined orderCancelReason = "declined"
+	OrderCancelReasonDeclined OrderCancelReason = "declined"
 
 	// Cancelled for some other reason.
-	OrderCancelReasonOther orderCancelReason = "other"
+	OrderCancelReasonOther OrderCancelReason = "other"
+
+	// Cancelled by a staff member, e.g. by mistake.
+	OrderCancelReasonStaff OrderCancelReason = "staff"
 )
 
-type discountAllocationMethod string
+// DiscountAllocationMethod is how a discount application is allocated to lines
+type DiscountAllocationMethod string
 
 const (
 	// The value is spread across all entitled lines.
-	DiscountAllocationMethodAcross discountAllocationMethod = "across"
+	DiscountAllocationMethodAcross DiscountAllocationMethod = "across"
 
 	// The value is applied onto every entitled line.
-	DiscountAllocationMethodEach discountAllocationMethod = "each"
+	DiscountAllocationMethodEach DiscountAllocationMethod = "each"
 
 	// The value is applied onto a single line.
-	DiscountAllocationMethodOne discountAllocationMethod = "one"
+	DiscountAllocationMethodOne DiscountAllocationMethod = "one"
 )
 
-type discountTargetSelection string
+// DiscountTargetSelection is the lines a discount application is allocated to
+type DiscountTargetSelection string
 
 const (
 	// The discount is allocated onto all lines
-	DiscountTargetSelectionAll discountTargetSelection = "all"
+	DiscountTargetSelectionAll DiscountTargetSelection = "all"
 
 	// The discount is allocated only onto lines it is entitled for.
-	DiscountTargetSelectionEntitled discountTargetSelection = "entitled"
+	DiscountTargetSelectionEntitled DiscountTargetSelection = "entitled"
 
 	// The discount is allocated onto explicitly selected lines.
-	DiscountTargetSelectionExplicit discountTargetSelection = "explicit"
+	DiscountTargetSelectionExplicit DiscountTargetSelection = "explicit"
 )
 
-type discountTargetType string
+// DiscountTargetType is the type of lines a discount application applies to
+type DiscountTargetType string
 
 const (
 	// The discount applies to line items.
-	DiscountTargetTypeLineItem discountTargetType = "line_item"
+	DiscountTargetTypeLineItem DiscountTargetType = "line_item"
 
 	// The discount applies to shipping lines.
-	DiscountTargetTypeShippingLine discountTargetType = "shipping_line"
+	DiscountTargetTypeShippingLine DiscountTargetType = "shipping_line"
 )
 
-type discountType string
+// DiscountType is how a discount was applied
+type DiscountType string
 
 const (
 	// The discount was applied automatically, such as by a Buy X Get Y automatic discount.
-	DiscountTypeAutomatic discountType = "automatic"
+	DiscountTypeAutomatic DiscountType = "automatic"
 
 	// The discount was applied by a discount code.
-	DiscountTypeDiscountCode discountType = "discount_code"
+	DiscountTypeDiscountCode DiscountType = "discount_code"
 
 	// The discount was manually applied by the merchant (for example, by using an app or creating a draft order).
-	DiscountTypeManual discountType = "manual"
+	DiscountTypeManual DiscountType = "manual"
 
 	// The discount was applied by a Shopify Script.
-	DiscountTypeScript discountType = "script"
+	DiscountTypeScript DiscountType = "script"
 )
 
-type discountValueType string
+// DiscountValueType is the type of value of a discount application
+type DiscountValueType string
 
 const (
 	// A fixed amount discount value in the currency of the order.
-	DiscountValueTypeFixedAmount discountValueType = "fixed_amount"
+	DiscountValueTypeFixedAmount DiscountValueType = "fixed_amount"
 
 	// A percentage discount value.
-	DiscountValueTypePercentage discountValueType = "percentage"
+	DiscountValueTypePercentage DiscountValueType = "percentage"
 )
 
-// A struct for all available order count options
+// A struct for all available order count options. The list options can be
+// used too. Count returns a CountFilterError for SinceId, which the count
+// endpoint ignores.
 type OrderCountOptions struct {
 	Page              int                    `url:"page,omitempty"`
 	Limit             int                    `url:"limit,omitempty"`
@@ -209,18 +242,18 @@ type OrderCountOptions struct {
 	UpdatedAtMax      time.Time              `url:"updated_at_max,omitempty"`
 	Order             string                 `url:"order,omitempty"`
 	Fields            string                 `url:"fields,omitempty"`
-	Status            orderStatus            `url:"status,omitempty"`
-	FinancialStatus   orderFinancialStatus   `url:"financial_status,omitempty"`
-	FulfillmentStatus orderFulfillmentStatus `url:"fulfillment_status,omitempty"`
+	Status            OrderStatus            `url:"status,omitempty"`
+	FinancialStatus   OrderFinancialStatus   `url:"financial_status,omitempty"`
+	FulfillmentStatus OrderFulfillmentStatus `url:"fulfillment_status,omitempty"`
 }
 
 // A struct for all available order list options.
 // See: https://help.shopify.com/api/reference/order#index
 type OrderListOptions struct {
 	ListOptions
-	Status            orderStatus            `url:"status,omitempty"`
-	FinancialStatus   orderFinancialStatus   `url:"financial_status,omitempty"`
-	FulfillmentStatus orderFulfillmentStatus `url:"fulfillment_status,omitempty"`
+	Status            OrderStatus            `url:"status,omitempty"`
+	FinancialStatus   OrderFinancialStatus   `url:"financial_status,omitempty"`
+	FulfillmentStatus OrderFulfillmentStatus `url:"fulfillment_status,omitempty"`
 	ProcessedAtMin    time.Time              `url:"processed_at_min,omitempty"`
 	ProcessedAtMax    time.Time              `url:"processed_at_max,omitempty"`
 	Order             string                 `url:"order,omitempty"`
@@ -238,17 +271,17 @@ type OrderCancelOptions struct {
 }
 
 // The behaviour to use when updating inventory.
-type orderInventoryBehaviour string
+type OrderInventoryBehaviour string
 
 const (
 	// Do not claim inventory.
-	OrderInventoryBehaviourBypass orderInventoryBehaviour = "bypass"
+	OrderInventoryBehaviourBypass OrderInventoryBehaviour = "bypass"
 
 	// Ignore the product's inventory policy and claim inventory.
-	OrderInventoryBehaviourDecrementIgnoringPolicy orderInventoryBehaviour = "decrement_ignoring_policy"
+	OrderInventoryBehaviourDecrementIgnoringPolicy OrderInventoryBehaviour = "decrement_ignoring_policy"
 
 	// Follow the product's inventory policy and claim inventory, if possible.
-	OrderInventoryBehaviourDecrementObeyingPolicy orderInventoryBehaviour = "decrement_obeying_policy"
+	OrderInventoryBehaviourDecrementObeyingPolicy OrderInventoryBehaviour = "decrement_obeying_policy"
 )
 
 // Order represents a Shopify order
@@ -262,6 +295,7 @@ type Order struct {
 	ClosedAt                 *time.Time              `json:"closed_at,omitempty"`
 	ProcessedAt              *time.Time              `json:"processed_at,omitempty"`
 	Customer                 *Customer               `json:"customer,omitempty"`
+	Company                  *OrderCompany           `json:"company,omitempty"`
 	BillingAddress           *Address                `json:"billing_address,omitempty"`
 	ShippingAddress          *Address                `json:"shipping_address,omitempty"`
 	Currency                 string                  `json:"currency,omitempty"`
@@ -269,23 +303,31 @@ type Order struct {
 	TotalPriceSet            *AmountSet              `json:"total_price_set,omitempty"`
 	TotalShippingPriceSet    *AmountSet              `json:"total_shipping_price_set,omitempty"`
 	CurrentTotalPrice        *decimal.Decimal        `json:"current_total_price,omitempty"`
+	CurrentTotalPriceSet     *AmountSet              `json:"current_total_price_set,omitempty"`
 	SubtotalPrice            *decimal.Decimal        `json:"subtotal_price,omitempty"`
+	SubtotalPriceSet         *AmountSet              `json:"subtotal_price_set,omitempty"`
 	CurrentSubtotalPrice     *decimal.Decimal        `json:"current_subtotal_price,omitempty"`
+	CurrentSubtotalPriceSet  *AmountSet              `json:"current_subtotal_price_set,omitempty"`
 	TotalDiscounts           *decimal.Decimal        `json:"total_discounts,omitempty"`
-	TotalDiscountSet         *AmountSet              `json:"total_discount_set,omitempty"`
+	TotalDiscountsSet        *AmountSet              `json:"total_discounts_set,omitempty"`
+	TotalDiscountSet         *AmountSet              `json:"total_discount_set,omitempty"` // Deprecated: Shopify sends total_discounts_set, use TotalDiscountsSet
 	CurrentTotalDiscounts    *decimal.Decimal        `json:"current_total_discounts,omitempty"`
 	CurrentTotalDiscountsSet *AmountSet              `json:"current_total_discounts_set,omitempty"`
 	TotalLineItemsPrice      *decimal.Decimal        `json:"total_line_items_price,omitempty"`
+	TotalLineItemsPriceSet   *AmountSet              `json:"total_line_items_price_set,omitempty"`
 	TaxesIncluded            bool                    `json:"taxes_included,omitempty"`
 	TotalTax                 *decimal.Decimal        `json:"total_tax,omitempty"`
 	TotalTaxSet              *AmountSet              `json:"total_tax_set,omitempty"`
 	CurrentTotalTax          *decimal.Decimal        `json:"current_total_tax,omitempty"`
 	CurrentTotalTaxSet       *AmountSet              `json:"current_total_tax_set,omitempty"`
+	CurrentTotalDutiesSet    *AmountSet              `json:"current_total_duties_set,omitempty"`
+	OriginalTotalDutiesSet   *AmountSet              `json:"original_total_duties_set,omitempty"`
+	DutiesIncluded           bool                    `json:"duties_included,omitempty"`
 	TaxLines                 []TaxLine               `json:"tax_lines,omitempty"`
 	TotalWeight              int                     `json:"total_weight,omitempty"`
-	FinancialStatus          orderFinancialStatus    `json:"financial_status,omitempty"`
+	FinancialStatus          OrderFinancialStatus    `json:"financial_status,omitempty"`
 	Fulfillments             []Fulfillment           `json:"fulfillments,omitempty"`
-	FulfillmentStatus        orderFulfillmentStatus  `json:"fulfillment_status,omitempty"`
+	FulfillmentStatus        OrderFulfillmentStatus  `json:"fulfillment_status,omitempty"`
 	Token                    string                  `json:"token,omitempty"`
 	CartToken                string                  `json:"cart_token,omitempty"`
 	Number                   int                     `json:"number,omitempty"`
@@ -294,7 +336,7 @@ type Order struct {
 	Test                     bool                    `json:"test,omitempty"`
 	BrowserIp                string                  `json:"browser_ip,omitempty"`
 	BuyerAcceptsMarketing    bool                    `json:"buyer_accepts_marketing,omitempty"`
-	CancelReason             orderCancelReason       `json:"cancel_reason,omitempty"`
+	CancelReason             OrderCancelReason       `json:"cancel_reason,omitempty"`
 	NoteAttributes           []NoteAttribute         `json:"note_attributes,omitempty"`
 	DiscountCodes            []DiscountCode          `json:"discount_codes,omitempty"`
 	DiscountApplications     []DiscountApplication   `json:"discount_applications,omitempty"`
@@ -316,20 +358,38 @@ type Order struct {
 	OrderStatusUrl           string                  `json:"order_status_url,omitempty"`
 	Gateway                  string                  `json:"gateway,omitempty"`
 	Confirmed                bool                    `json:"confirmed,omitempty"`
+	ConfirmationNumber       string                  `json:"confirmation_number,omitempty"`
+	PoNumber                 string                  `json:"po_number,omitempty"`
+	TaxExempt                bool                    `json:"tax_exempt,omitempty"`
+	EstimatedTaxes           bool                    `json:"estimated_taxes,omitempty"`
 	CheckoutToken            string                  `json:"checkout_token,omitempty"`
 	Reference                string                  `json:"reference,omitempty"`
 	SourceIdentifier         string                  `json:"source_identifier,omitempty"`
 	SourceURL                string                  `json:"source_url,omitempty"`
 	DeviceId                 uint64                  `json:"device_id,omitempty"`
+	PosLocationId            uint64                  `json:"pos_location_id,omitempty"`
 	Phone                    string                  `json:"phone,omitempty"`
 	LandingSiteRef           string                  `json:"landing_site_ref,omitempty"`
 	CheckoutId               uint64                  `json:"checkout_id,omitempty"`
 	ContactEmail             string                  `json:"contact_email,omitempty"`
 	Metafields               []Metafield             `json:"metafields,omitempty"`
-	SendReceipt              bool                    `json:"send_receipt,omitempty"`
-	SendFulfillmentReceipt   bool                    `json:"send_fulfillment_receipt,omitempty"`
+	SendReceipt              bool                    `json:"send_receipt,omitempty"`             // Create only, see SendInvoice
+	SendFulfillmentReceipt   bool                    `json:"send_fulfillment_receipt,omitempty"` // Create only, see ResendShippingConfirmation
 	PresentmentCurrency      string                  `json:"presentment_currency,omitempty"`
-	InventoryBehaviour       orderInventoryBehaviour `json:"inventory_behaviour,omitempty"`
+	InventoryBehaviour       OrderInventoryBehaviour `json:"inventory_behaviour,omitempty"`
+
+	CurrentTotalAdditionalFeesSet  *AmountSet      `json:"current_total_additional_fees_set,omitempty"`
+	OriginalTotalAdditionalFeesSet *AmountSet      `json:"original_total_additional_fees_set,omitempty"`
+	AdditionalFees                 []AdditionalFee `json:"additional_fees,omitempty"`
+
+	TotalOutstanding *decimal.Decimal `json:"total_outstanding,omitempty"`
+}
+
+// OrderCompany is the B2B company an order or draft order was placed for
+type OrderCompany struct {
+	Id         uint64 `json:"id,omitempty"`
+	LocationId uint64 `json:"location_id,omitempty"`
+	ContactId  uint64 `json:"contact_id,omitempty"`
 }
 
 type Address struct {
@@ -358,15 +418,15 @@ type DiscountCode struct {
 }
 
 type DiscountApplication struct {
-	AllocationMethod discountAllocationMethod `json:"allocation_method,omitempty"`
+	AllocationMethod DiscountAllocationMethod `json:"allocation_method,omitempty"`
 	Code             string                   `json:"code"`
 	Description      string                   `json:"description"`
-	TargetSelection  discountTargetSelection  `json:"target_selection"`
-	TargetType       discountTargetType       `json:"target_type"`
+	TargetSelection  DiscountTargetSelection  `json:"target_selection"`
+	TargetType       DiscountTargetType       `json:"target_type"`
 	Title            string                   `json:"title"`
-	Type             discountType             `json:"type"`
+	Type             DiscountType             `json:"type"`
 	Value            *decimal.Decimal         `json:"value"`
-	ValueType        discountValueType        `json:"value_type"`
+	ValueType        DiscountValueType        `json:"value_type"`
 }
 
 type LineItem struct {
@@ -375,7 +435,9 @@ type LineItem struct {
 	VariantId                  uint64                 `json:"variant_id,omitempty"`
 	Quantity                   int                    `json:"quantity,omitempty"`
 	Price                      *decimal.Decimal       `json:"price,omitempty"`
+	PriceSet                   *AmountSet             `json:"price_set,omitempty"`
 	TotalDiscount              *decimal.Decimal       `json:"total_discount,omitempty"`
+	TotalDiscountSet           *AmountSet             `json:"total_discount_set,omitempty"`
 	Title                      string                 `json:"title,omitempty"`
 	VariantTitle               string                 `json:"variant_title,omitempty"`
 	Name                       string                 `json:"name,omitempty"`
@@ -391,8 +453,10 @@ type LineItem struct {
 	ProductExists              bool                   `json:"product_exists,omitempty"`
 	FulfillableQuantity        int                    `json:"fulfillable_quantity,omitempty"`
 	Grams                      int                    `json:"grams,omitempty"`
-	FulfillmentStatus          orderFulfillmentStatus `json:"fulfillment_status,omitempty"`
+	FulfillmentStatus          OrderFulfillmentStatus `json:"fulfillment_status,omitempty"`
 	TaxLines                   []TaxLine              `json:"tax_lines,omitempty"`
+	Duties                     []Duty                 `json:"duties,omitempty"`
+	AttributedStaffs           []AttributedStaff      `json:"attributed_staffs,omitempty"`
 
 	// Deprecated: See 2022-10 release notes: https://shopify.dev/docs/api/release-notes/2022-10
 	OriginLocation *Address `json:"origin_location,omitempty"`
@@ -402,6 +466,38 @@ type LineItem struct {
 
 	AppliedDiscount     *AppliedDiscount      `json:"applied_discount,omitempty"`
 	DiscountAllocations []DiscountAllocations `json:"discount_allocations,omitempty"`
+
+	// CurrentQuantity is the quantity after order edits removed items, nil
+	// when Shopify didn't send it
+	CurrentQuantity *int `json:"current_quantity,omitempty"`
+}
+
+// Duty represents the import duty charged on a line item of a cross-border
+// order
+type Duty struct {
+	Id                   string          `json:"id,omitempty"`
+	HarmonizedSystemCode string          `json:"harmonized_system_code,omitempty"`
+	CountryCodeOfOrigin  string          `json:"country_code_of_origin,omitempty"`
+	ShopMoney            *AmountSetEntry `json:"shop_money,omitempty"`
+	PresentmentMoney     *AmountSetEntry `json:"presentment_money,omitempty"`
+	TaxLines             []TaxLine       `json:"tax_lines,omitempty"`
+	AdminGraphqlApiId    string          `json:"admin_graphql_api_id,omitempty"`
+}
+
+// AdditionalFee represents a fee charged on an order, such as the Colorado
+// retail delivery fee
+type AdditionalFee struct {
+	Id       uint64     `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	PriceSet *AmountSet `json:"price_set,omitempty"`
+	TaxLines []TaxLine  `json:"tax_lines,omitempty"`
+}
+
+// AttributedStaff attributes a quantity of a line item to a staff member,
+// e.g. for POS commissions. Id is the staff member's GraphQL id.
+type AttributedStaff struct {
+	Id       string `json:"id,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
 }
 
 type DiscountAllocations struct {
@@ -415,11 +511,61 @@ type AmountSet struct {
 	PresentmentMoney AmountSetEntry `json:"presentment_money,omitempty"`
 }
 
+// In returns the amount of the set in currency, which may be either the
+// shop or the presentment currency. It returns nil if neither matches.
+func (s *AmountSet) In(currency string) *decimal.Decimal {
+	switch {
+	case s == nil:
+		return nil
+	case strings.EqualFold(s.PresentmentMoney.CurrencyCode, currency):
+		return s.PresentmentMoney.Amount
+	case strings.EqualFold(s.ShopMoney.CurrencyCode, currency):
+		return s.ShopMoney.Amount
+	}
+	return nil
+}
+
+// Presentment returns the amount in the currency the customer was presented
+func (s *AmountSet) Presentment() *decimal.Decimal {
+	if s == nil {
+		return nil
+	}
+	return s.PresentmentMoney.Amount
+}
+
+// Shop returns the amount in the shop currency
+func (s *AmountSet) Shop() *decimal.Decimal {
+	if s == nil {
+		return nil
+	}
+	return s.ShopMoney.Amount
+}
+
 type AmountSetEntry struct {
 	Amount       *decimal.Decimal `json:"amount,omitempty"`
 	CurrencyCode string           `json:"currency_code,omitempty"`
 }
 
+// UnmarshalJSON also accepts the currency key some resources, e.g.
+// transactions, use instead of currency_code.
+func (e *AmountSetEntry) UnmarshalJSON(data []byte) error {
+	type alias AmountSetEntry
+	aux := &struct {
+		Currency string `json:"currency"`
+		*alias
+	}{alias: (*alias)(e)}
+
+	err := json.Unmarshal(data, aux)
+	if err != nil {
+		return err
+	}
+
+	if e.CurrencyCode == "" {
+		e.CurrencyCode = aux.Currency
+	}
+	return nil
+}
+
 // UnmarshalJSON custom unmarsaller for LineItem required to mitigate some older orders having LineItem.Properies
 // which are empty JSON objects rather than the expected array.
 func (li *LineItem) UnmarshalJSON(data []byte) error {
@@ -529,9 +675,19 @@ func (sl *ShippingLines) UnmarshalJSON(data []byte) error {
 }
 
 type TaxLine struct {
-	Title string           `json:"title,omitempty"`
-	Price *decimal.Decimal `json:"price,omitempty"`
-	Rate  *decimal.Decimal `json:"rate,omitempty"`
+	Title    string           `json:"title,omitempty"`
+	Price    *decimal.Decimal `json:"price,omitempty"`
+	PriceSet *AmountSet       `json:"price_set,omitempty"`
+	Rate     *decimal.Decimal `json:"rate,omitempty"`
+
+	// ChannelLiable is set when the sales channel, e.g. a marketplace, is
+	// liable for remitting the tax rather than the merchant.
+	ChannelLiable bool `json:"channel_liable,omitempty"`
+}
+
+// MerchantLiable reports whether the merchant is liable for remitting the tax
+func (t TaxLine) MerchantLiable() bool {
+	return !t.ChannelLiable
 }
 
 type Transaction struct {
@@ -543,6 +699,7 @@ type Transaction struct {
 	Status         string           `json:"status,omitempty"`
 	Message        string           `json:"message,omitempty"`
 	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	ProcessedAt    *time.Time       `json:"processed_at,omitempty"`
 	Test           bool             `json:"test,omitempty"`
 	Authorization  string           `json:"authorization,omitempty"`
 	Currency       string           `json:"currency,omitempty"`
@@ -554,6 +711,78 @@ type Transaction struct {
 	SourceName     string           `json:"source_name,omitempty"`
 	Source         string           `json:"source,omitempty"`
 	PaymentDetails *PaymentDetails  `json:"payment_details,omitempty"`
+
+	Receipt                    TransactionReceipt          `json:"receipt,omitempty"`
+	TotalUnsettledSet          *AmountSet                  `json:"total_unsettled_set,omitempty"`
+	PaymentsRefundAttributes   *PaymentsRefundAttributes   `json:"payments_refund_attributes,omitempty"`
+	CurrencyExchangeAdjustment *CurrencyExchangeAdjustment `json:"currency_exchange_adjustment,omitempty"`
+
+	// MaximumRefundable is set on the suggested_refund transactions returned
+	// by RefundService.Calculate
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
+}
+
+// TransactionReceipt is the raw receipt of a transaction, its fields depend
+// on the payment gateway.
+type TransactionReceipt json.RawMessage
+
+// MarshalJSON returns the raw receipt
+func (r TransactionReceipt) MarshalJSON() ([]byte, error) {
+	if len(r) == 0 {
+		return []byte("null"), nil
+	}
+	return r, nil
+}
+
+// UnmarshalJSON keeps a copy of the raw receipt
+func (r *TransactionReceipt) UnmarshalJSON(data []byte) error {
+	*r = append((*r)[0:0], data...)
+	return nil
+}
+
+// Decode unmarshals the receipt into v, e.g. a struct describing the fields
+// of a specific gateway.
+func (r TransactionReceipt) Decode(v interface{}) error {
+	if len(r) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r, v)
+}
+
+// Fields returns the receipt as a map, which is empty when there is no
+// receipt.
+func (r TransactionReceipt) Fields() (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	err := r.Decode(&fields)
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	return fields, err
+}
+
+// Get returns the receipt field as a string, or "" if it isn't set or
+// isn't a string.
+func (r TransactionReceipt) Get(field string) string {
+	fields, _ := r.Fields()
+	s, _ := fields[field].(string)
+	return s
+}
+
+// PaymentsRefundAttributes holds the status of a refund processed by
+// Shopify Payments
+type PaymentsRefundAttributes struct {
+	Status                  string `json:"status,omitempty"`
+	AcquirerReferenceNumber string `json:"acquirer_reference_number,omitempty"`
+}
+
+// CurrencyExchangeAdjustment describes the adjustment applied to a
+// transaction due to currency conversion
+type CurrencyExchangeAdjustment struct {
+	Id             uint64           `json:"id,omitempty"`
+	Adjustment     *decimal.Decimal `json:"adjustment,omitempty"`
+	OriginalAmount *decimal.Decimal `json:"original_amount,omitempty"`
+	FinalAmount    *decimal.Decimal `json:"final_amount,omitempty"`
+	Currency       string           `json:"currency,omitempty"`
 }
 
 type ClientDetails struct {
@@ -575,6 +804,19 @@ type Refund struct {
 	RefundLineItems  []RefundLineItem  `json:"refund_line_items,omitempty"`
 	Transactions     []Transaction     `json:"transactions,omitempty"`
 	OrderAdjustments []OrderAdjustment `json:"order_adjustments,omitempty"`
+
+	Shipping *RefundShipping `json:"shipping,omitempty"`
+	Currency string          `json:"currency,omitempty"`
+	Notify   bool            `json:"notify,omitempty"`
+}
+
+// RefundShipping is the shipping part of a refund, either the full remaining
+// shipping or an amount
+type RefundShipping struct {
+	FullRefund        bool             `json:"full_refund,omitempty"`
+	Amount            *decimal.Decimal `json:"amount,omitempty"`
+	Tax               *decimal.Decimal `json:"tax,omitempty"`
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
 }
 
 type OrderAdjustment struct {
@@ -605,6 +847,10 @@ type RefundLineItem struct {
 	TotalTax    *decimal.Decimal `json:"total_tax,omitempty"`
 	SubTotalSet *AmountSet       `json:"subtotal_set,omitempty"`
 	TotalTaxSet *AmountSet       `json:"total_tax_set,omitempty"`
+
+	RestockType string           `json:"restock_type,omitempty"`
+	LocationId  uint64           `json:"location_id,omitempty"`
+	Price       *decimal.Decimal `json:"price,omitempty"`
 }
 
 // List orders
@@ -659,14 +905,38 @@ func (s *OrderServiceOp) Count(ctx context.Context, options interface{}) (int, e
 
 // Get individual order
 func (s *OrderServiceOp) Get(ctx context.Context, orderId uint64, options interface{}) (*Order, error) {
+	if s.client.readsGraphQL(GraphQLReadOrders) {
+		return s.getGraphQL(ctx, orderId)
+	}
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, orderId)
 	resource := new(OrderResource)
 	err := s.client.Get(ctx, path, resource, options)
 	return resource.Order, err
 }
 
+// GetMany gets the orders with the given ids concurrently, see Client.GetMany.
+// The orders are returned in the order of ids, failed ids are left out and
+// reported in the returned BatchErrors.
+func (s *OrderServiceOp) GetMany(ctx context.Context, orderIds []uint64, options interface{}) ([]Order, error) {
+	results, err := s.client.GetMany(ctx, orderIds, func(ctx context.Context, id uint64) (interface{}, error) {
+		return s.Get(ctx, id, options)
+	})
+
+	orders := make([]Order, 0, len(results))
+	for _, id := range orderIds {
+		if order, ok := results[id].(*Order); ok && order != nil {
+			orders = append(orders, *order)
+		}
+	}
+	return orders, err
+}
+
 // Create order
 func (s *OrderServiceOp) Create(ctx context.Context, order Order) (*Order, error) {
+	if err := s.client.validateCreate("order", order); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", ordersBasePath)
 	wrappedData := OrderResource{Order: &order}
 	resource := new(OrderResource)
@@ -674,7 +944,8 @@ func (s *OrderServiceOp) Create(ctx context.Context, order Order) (*Order, error
 	return resource.Order, err
 }
 
-// Update order
+// Update order. Updates don't email the customer, SendReceipt and
+// SendFulfillmentReceipt are ignored.
 func (s *OrderServiceOp) Update(ctx context.Context, order Order) (*Order, error) {
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, order.Id)
 	wrappedData := OrderResource{Order: &order}
@@ -0,0 +1,230 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrOrderNotCached is returned by an OrderStore when no order is stored for
+// an id
+var ErrOrderNotCached = errors.New("order not cached")
+
+// OrderStore persists orders by shop domain and id. Implementations backed by
+// Redis or any other storage can be plugged into an OrderCache. An OrderCache
+// serializes its own writes of an order, a store shared by several processes
+// must make SaveOrder a compare-and-set on updated_at instead, or webhooks
+// handled concurrently may cache an older order last.
+type OrderStore interface {
+	GetOrder(ctx context.Context, shop string, id uint64) (*Order, error)
+	SaveOrder(ctx context.Context, shop string, order Order) error
+	DeleteOrder(ctx context.Context, shop string, id uint64) error
+}
+
+type orderStoreKey struct {
+	shop string
+	id   uint64
+}
+
+// MemoryOrderStore is an in-memory OrderStore
+type MemoryOrderStore struct {
+	mu     sync.RWMutex
+	orders map[orderStoreKey]Order
+}
+
+// NewMemoryOrderStore returns an empty MemoryOrderStore
+func NewMemoryOrderStore() *MemoryOrderStore {
+	return &MemoryOrderStore{orders: map[orderStoreKey]Order{}}
+}
+
+// GetOrder returns the order stored for the shop and id or ErrOrderNotCached
+func (s *MemoryOrderStore) GetOrder(ctx context.Context, shop string, id uint64) (*Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	order, ok := s.orders[orderStoreKey{shop, id}]
+	if !ok {
+		return nil, ErrOrderNotCached
+	}
+	return &order, nil
+}
+
+// SaveOrder stores the order for the shop
+func (s *MemoryOrderStore) SaveOrder(ctx context.Context, shop string, order Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[orderStoreKey{shop, order.Id}] = order
+	return nil
+}
+
+// DeleteOrder removes the order for the shop and id
+func (s *MemoryOrderStore) DeleteOrder(ctx context.Context, shop string, id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.orders, orderStoreKey{shop, id})
+	return nil
+}
+
+// OrderCache caches the orders of a shop in an OrderStore. It is populated by
+// order webhooks, as a WebhookHandler, and by Sync, and falls back to the API
+// for orders it doesn't have. Webhook subscriptions feeding the cache must not
+// restrict the payload fields.
+type OrderCache struct {
+	client *Client
+	store  OrderStore
+
+	mu    sync.Mutex
+	locks map[orderStoreKey]*orderLock
+	// orders deleted by an orders/delete delivery, kept so a late delivery
+	// of another topic doesn't cache them again
+	deleted map[orderStoreKey]bool
+}
+
+type orderLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewOrderCache returns an OrderCache of the client's shop
+func NewOrderCache(client *Client, store OrderStore) *OrderCache {
+	return &OrderCache{
+		client:  client,
+		store:   store,
+		locks:   map[orderStoreKey]*orderLock{},
+		deleted: map[orderStoreKey]bool{},
+	}
+}
+
+// lock serializes the writes of an order, returning the unlock func
+func (c *OrderCache) lock(key orderStoreKey) func() {
+	c.mu.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &orderLock{}
+		c.locks[key] = l
+	}
+	l.refs++
+	c.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		c.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.locks, key)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// GetCached gets an order from the store or, when it isn't cached, from the
+// API and caches it
+func (c *OrderCache) GetCached(ctx context.Context, id uint64) (*Order, error) {
+	order, err := c.store.GetOrder(ctx, c.client.shop, id)
+	if err == nil {
+		return order, nil
+	}
+	if !errors.Is(err, ErrOrderNotCached) {
+		return nil, err
+	}
+
+	order, err = c.client.Order.Get(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.saveNewer(ctx, c.client.shop, *order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Invalidate removes an order from the cache, its next GetCached gets it from
+// the API
+func (c *OrderCache) Invalidate(ctx context.Context, id uint64) error {
+	return c.store.DeleteOrder(ctx, c.client.shop, id)
+}
+
+// Sync lists the orders matching the options and caches them. It returns the
+// number of orders cached.
+func (c *OrderCache) Sync(ctx context.Context, options interface{}) (int, error) {
+	orders, err := c.client.Order.ListAll(ctx, options)
+	if err != nil {
+		return 0, err
+	}
+	for i, order := range orders {
+		if err := c.saveNewer(ctx, c.client.shop, order); err != nil {
+			return i, err
+		}
+	}
+	return len(orders), nil
+}
+
+// HandleWebhook caches the orders of orders/create, orders/updated,
+// orders/paid, orders/cancelled, orders/fulfilled and
+// orders/partially_fulfilled deliveries, and invalidates them on
+// orders/delete. Webhooks can arrive out of order, so an order older than the
+// cached one, by updated_at, is ignored, as are deliveries of a deleted order
+// following its orders/delete. Deliveries of other topics are ignored.
+func (c *OrderCache) HandleWebhook(ctx context.Context, delivery *WebhookDelivery) error {
+	shop := delivery.ShopDomain
+	if shop == "" {
+		shop = c.client.shop
+	}
+
+	switch delivery.Topic {
+	case "orders/create", "orders/updated", "orders/paid", "orders/cancelled", "orders/fulfilled", "orders/partially_fulfilled":
+		order := Order{}
+		if err := delivery.Decode(&order); err != nil {
+			return err
+		}
+		return c.saveNewer(ctx, shop, order)
+	case "orders/delete":
+		order := struct {
+			Id uint64 `json:"id"`
+		}{}
+		if err := delivery.Decode(&order); err != nil {
+			return err
+		}
+		return c.delete(ctx, shop, order.Id)
+	}
+	return nil
+}
+
+// delete removes a deleted order from the store, leaving a tombstone
+func (c *OrderCache) delete(ctx context.Context, shop string, id uint64) error {
+	key := orderStoreKey{shop, id}
+	defer c.lock(key)()
+
+	c.mu.Lock()
+	c.deleted[key] = true
+	c.mu.Unlock()
+	return c.store.DeleteOrder(ctx, shop, id)
+}
+
+// saveNewer stores the order unless the cached one was updated after it or
+// the order was deleted. Orders without updated_at always replace the cached
+// one.
+func (c *OrderCache) saveNewer(ctx context.Context, shop string, order Order) error {
+	key := orderStoreKey{shop, order.Id}
+	defer c.lock(key)()
+
+	c.mu.Lock()
+	deleted := c.deleted[key]
+	c.mu.Unlock()
+	if deleted {
+		return nil
+	}
+
+	if order.UpdatedAt != nil {
+		cached, err := c.store.GetOrder(ctx, shop, order.Id)
+		if err != nil && !errors.Is(err, ErrOrderNotCached) {
+			return err
+		}
+		if err == nil && cached.UpdatedAt != nil && order.UpdatedAt.Before(*cached.UpdatedAt) {
+			return nil
+		}
+	}
+	return c.store.SaveOrder(ctx, shop, order)
+}
@@ -0,0 +1,187 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestOrderCacheGetCached(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"name":"#1001"}}`))
+
+	cache := NewOrderCache(client, NewMemoryOrderStore())
+	for i := 0; i < 2; i++ {
+		order, err := cache.GetCached(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("OrderCache.GetCached returned error: %v", err)
+		}
+		if order.Id != 1 || order.Name != "#1001" {
+			t.Errorf("OrderCache.GetCached returned %+v", order)
+		}
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 1 {
+		t.Errorf("OrderCache.GetCached made %d calls, expected 1", calls)
+	}
+
+	if err := cache.Invalidate(context.Background(), 1); err != nil {
+		t.Fatalf("OrderCache.Invalidate returned error: %v", err)
+	}
+	if _, err := cache.GetCached(context.Background(), 1); err != nil {
+		t.Fatalf("OrderCache.GetCached returned error: %v", err)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 2 {
+		t.Errorf("OrderCache.GetCached made %d calls after invalidation, expected 2", calls)
+	}
+}
+
+func TestOrderCacheHandleWebhook(t *testing.T) {
+	setup()
+	defer teardown()
+
+	store := NewMemoryOrderStore()
+	cache := NewOrderCache(client, store)
+	ctx := context.Background()
+
+	err := cache.HandleWebhook(ctx, &WebhookDelivery{
+		Topic:      "orders/create",
+		ShopDomain: "fooshop.myshopify.com",
+		Body:       []byte(`{"id":1,"name":"#1001"}`),
+	})
+	if err != nil {
+		t.Fatalf("OrderCache.HandleWebhook returned error: %v", err)
+	}
+
+	order, err := cache.GetCached(ctx, 1)
+	if err != nil {
+		t.Fatalf("OrderCache.GetCached returned error: %v", err)
+	}
+	if order.Name != "#1001" {
+		t.Errorf("OrderCache.GetCached returned %+v", order)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 0 {
+		t.Errorf("OrderCache.GetCached made %d calls, expected 0", calls)
+	}
+
+	deliveries := []struct {
+		topic string
+		body  string
+	}{
+		{"orders/updated", `{"id":1,"name":"#1001","financial_status":"paid","updated_at":"2024-03-01T10:00:05Z"}`},
+		// orders/paid sent before orders/updated but delivered after it
+		{"orders/paid", `{"id":1,"name":"#1001","financial_status":"pending","updated_at":"2024-03-01T10:00:01Z"}`},
+	}
+	for _, d := range deliveries {
+		err = cache.HandleWebhook(ctx, &WebhookDelivery{
+			Topic:      d.topic,
+			ShopDomain: "fooshop.myshopify.com",
+			Body:       []byte(d.body),
+		})
+		if err != nil {
+			t.Fatalf("OrderCache.HandleWebhook(%s) returned error: %v", d.topic, err)
+		}
+	}
+	order, err = store.GetOrder(ctx, "fooshop.myshopify.com", 1)
+	if err != nil || order.FinancialStatus != OrderFinancialStatusPaid {
+		t.Errorf("MemoryOrderStore.GetOrder returned %+v, %v, expected the order of orders/updated", order, err)
+	}
+
+	err = cache.HandleWebhook(ctx, &WebhookDelivery{
+		Topic:      "orders/delete",
+		ShopDomain: "fooshop.myshopify.com",
+		Body:       []byte(`{"id":1}`),
+	})
+	if err != nil {
+		t.Fatalf("OrderCache.HandleWebhook returned error: %v", err)
+	}
+	if _, err := store.GetOrder(ctx, "fooshop.myshopify.com", 1); err != ErrOrderNotCached {
+		t.Errorf("MemoryOrderStore.GetOrder returned %v after orders/delete, expected %v", err, ErrOrderNotCached)
+	}
+
+	// orders/updated delivered after orders/delete
+	err = cache.HandleWebhook(ctx, &WebhookDelivery{
+		Topic:      "orders/updated",
+		ShopDomain: "fooshop.myshopify.com",
+		Body:       []byte(`{"id":1,"name":"#1001","updated_at":"2024-03-01T10:00:09Z"}`),
+	})
+	if err != nil {
+		t.Fatalf("OrderCache.HandleWebhook returned error: %v", err)
+	}
+	if _, err := store.GetOrder(ctx, "fooshop.myshopify.com", 1); err != ErrOrderNotCached {
+		t.Errorf("MemoryOrderStore.GetOrder returned %v after a late orders/updated, expected %v", err, ErrOrderNotCached)
+	}
+}
+
+// slowOrderStore widens the window between reading and saving an order
+type slowOrderStore struct {
+	*MemoryOrderStore
+}
+
+func (s slowOrderStore) GetOrder(ctx context.Context, shop string, id uint64) (*Order, error) {
+	order, err := s.MemoryOrderStore.GetOrder(ctx, shop, id)
+	time.Sleep(10 * time.Millisecond)
+	return order, err
+}
+
+func TestOrderCacheHandleWebhookConcurrently(t *testing.T) {
+	setup()
+	defer teardown()
+
+	store := slowOrderStore{NewMemoryOrderStore()}
+	cache := NewOrderCache(client, store)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for _, body := range []string{
+		`{"id":1,"financial_status":"paid","updated_at":"2024-03-01T10:00:05Z"}`,
+		`{"id":1,"financial_status":"pending","updated_at":"2024-03-01T10:00:01Z"}`,
+	} {
+		body := body
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := cache.HandleWebhook(ctx, &WebhookDelivery{Topic: "orders/updated", Body: []byte(body)})
+			if err != nil {
+				t.Errorf("OrderCache.HandleWebhook returned error: %v", err)
+			}
+		}()
+		// the older order is read before the newer one is saved
+		time.Sleep(time.Millisecond)
+	}
+	wg.Wait()
+
+	order, err := store.GetOrder(ctx, "fooshop.myshopify.com", 1)
+	if err != nil || order.FinancialStatus != OrderFinancialStatusPaid {
+		t.Errorf("MemoryOrderStore.GetOrder returned %+v, %v, expected the newer order", order, err)
+	}
+}
+
+func TestOrderCacheSync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"orders":[{"id":1},{"id":2}]}`))
+
+	store := NewMemoryOrderStore()
+	cache := NewOrderCache(client, store)
+	synced, err := cache.Sync(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("OrderCache.Sync returned error: %v", err)
+	}
+	if synced != 2 {
+		t.Errorf("OrderCache.Sync returned %d, expected 2", synced)
+	}
+	for _, id := range []uint64{1, 2} {
+		if _, err := store.GetOrder(context.Background(), "fooshop.myshopify.com", id); err != nil {
+			t.Errorf("MemoryOrderStore.GetOrder(%d) returned error: %v", id, err)
+		}
+	}
+}
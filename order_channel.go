@@ -0,0 +1,233 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// App ids of the sales channels built into Shopify, as set in the app_id of
+// the orders they create
+const (
+	OnlineStoreAppId   = 580111
+	PointOfSaleAppId   = 129785
+	DraftOrdersAppId   = 1354745
+	ShopifyMobileAppId = 85946
+)
+
+const channelsQuery = `query channels($after: String) {
+  channels(first: 250, after: $after) {
+    nodes {
+      id
+      name
+      handle
+      app {
+        id
+        title
+        handle
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const appQuery = `query app($id: ID!) {
+  app(id: $id) {
+    id
+    title
+    handle
+  }
+}`
+
+// OrderChannel is the kind of sales channel an order was placed through
+type OrderChannel string
+
+const (
+	OrderChannelOnlineStore OrderChannel = "online_store"
+	OrderChannelPOS         OrderChannel = "pos"
+	OrderChannelDraftOrder  OrderChannel = "draft_order"
+	OrderChannelMobile      OrderChannel = "mobile"
+	OrderChannelApp         OrderChannel = "app"
+	OrderChannelUnknown     OrderChannel = "unknown"
+)
+
+// Channel returns the kind of sales channel the order was placed through,
+// from its app_id or, for orders without one, its source_name. Orders of
+// other apps, e.g. marketplaces, are OrderChannelApp.
+func (o *Order) Channel() OrderChannel {
+	switch o.AppId {
+	case OnlineStoreAppId:
+		return OrderChannelOnlineStore
+	case PointOfSaleAppId:
+		return OrderChannelPOS
+	case DraftOrdersAppId:
+		return OrderChannelDraftOrder
+	case ShopifyMobileAppId:
+		return OrderChannelMobile
+	case 0:
+		// attributed by source name below
+	default:
+		return OrderChannelApp
+	}
+
+	switch o.SourceName {
+	case "web":
+		return OrderChannelOnlineStore
+	case "pos":
+		return OrderChannelPOS
+	case "shopify_draft_order":
+		return OrderChannelDraftOrder
+	case "iphone", "android":
+		return OrderChannelMobile
+	case "":
+		return OrderChannelUnknown
+	}
+	// apps set their own source name, often their app id
+	return OrderChannelApp
+}
+
+// AppInfo identifies an app, e.g. the app that created an order
+type AppInfo struct {
+	Id     uint64
+	Title  string
+	Handle string
+}
+
+// SalesChannel is a sales channel installed on the shop with the app
+// providing it
+type SalesChannel struct {
+	Id     uint64
+	Name   string
+	Handle string
+	App    *AppInfo
+}
+
+// OrderAttribution is the sales channel and app an order is attributed to.
+// AppTitle is the title of the app, e.g. "Online Store" or the name of a
+// marketplace app.
+type OrderAttribution struct {
+	Channel    OrderChannel
+	AppId      uint64
+	AppTitle   string
+	SourceName string
+}
+
+// ChannelService is an interface for the sales channels of the shop and the
+// apps orders are attributed to, through the GraphQL API.
+// See: https://shopify.dev/docs/api/admin-graphql/latest/objects/Channel
+type ChannelService interface {
+	List(context.Context) ([]SalesChannel, error)
+	GetApp(context.Context, uint64) (*AppInfo, error)
+	Attribute(context.Context, Order) (*OrderAttribution, error)
+}
+
+// ChannelServiceOp handles communication with the sales channels through the
+// GraphQL API
+type ChannelServiceOp struct {
+	client *Client
+
+	mu   sync.Mutex
+	apps map[uint64]*AppInfo
+}
+
+type graphQLAppInfo struct {
+	Id     string `json:"id"`
+	Title  string `json:"title"`
+	Handle string `json:"handle"`
+}
+
+func (g graphQLAppInfo) app() (*AppInfo, error) {
+	id, err := ParseGraphQLId(g.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &AppInfo{Id: id, Title: g.Title, Handle: g.Handle}, nil
+}
+
+// List lists the sales channels installed on the shop
+func (s *ChannelServiceOp) List(ctx context.Context) ([]SalesChannel, error) {
+	var channels []SalesChannel
+	err := s.client.GraphQLEachNode(ctx, channelsQuery, map[string]interface{}{}, "channels", func(node json.RawMessage) error {
+		var c struct {
+			Id     string          `json:"id"`
+			Name   string          `json:"name"`
+			Handle string          `json:"handle"`
+			App    *graphQLAppInfo `json:"app"`
+		}
+		if err := json.Unmarshal(node, &c); err != nil {
+			return err
+		}
+		id, err := ParseGraphQLId(c.Id)
+		if err != nil {
+			return err
+		}
+		channel := SalesChannel{Id: id, Name: c.Name, Handle: c.Handle}
+		if c.App != nil {
+			if channel.App, err = c.App.app(); err != nil {
+				return err
+			}
+		}
+		channels = append(channels, channel)
+		return nil
+	})
+	return channels, err
+}
+
+// GetApp gets an app by id, e.g. the app_id of an order. Apps are cached by
+// id for the lifetime of the service.
+func (s *ChannelServiceOp) GetApp(ctx context.Context, appId uint64) (*AppInfo, error) {
+	s.mu.Lock()
+	app, ok := s.apps[appId]
+	s.mu.Unlock()
+	if ok {
+		return app, nil
+	}
+
+	resp := struct {
+		App *graphQLAppInfo `json:"app"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, appQuery, map[string]interface{}{"id": GraphQLId("App", appId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.App == nil {
+		return nil, graphQLNotFound()
+	}
+	app, err = resp.App.app()
+	if err != nil {
+		return nil, fmt.Errorf("app %d: %w", appId, err)
+	}
+
+	s.mu.Lock()
+	if s.apps == nil {
+		s.apps = make(map[uint64]*AppInfo)
+	}
+	s.apps[appId] = app
+	s.mu.Unlock()
+	return app, nil
+}
+
+// Attribute attributes an order to its sales channel and the app that
+// created it, looking up the title of the app. Orders without an app_id are
+// attributed by their source_name only.
+func (s *ChannelServiceOp) Attribute(ctx context.Context, order Order) (*OrderAttribution, error) {
+	attribution := &OrderAttribution{
+		Channel:    order.Channel(),
+		AppId:      uint64(order.AppId),
+		SourceName: order.SourceName,
+	}
+	if order.AppId == 0 {
+		return attribution, nil
+	}
+
+	app, err := s.GetApp(ctx, attribution.AppId)
+	if err != nil {
+		return nil, err
+	}
+	attribution.AppTitle = app.Title
+	return attribution, nil
+}
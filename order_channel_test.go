@@ -0,0 +1,105 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestOrderChannel(t *testing.T) {
+	cases := []struct {
+		order    Order
+		expected OrderChannel
+	}{
+		{Order{AppId: OnlineStoreAppId, SourceName: "web"}, OrderChannelOnlineStore},
+		{Order{AppId: PointOfSaleAppId, SourceName: "pos"}, OrderChannelPOS},
+		{Order{AppId: DraftOrdersAppId}, OrderChannelDraftOrder},
+		{Order{AppId: ShopifyMobileAppId}, OrderChannelMobile},
+		{Order{AppId: 2417881, SourceName: "2417881"}, OrderChannelApp},
+		{Order{SourceName: "pos"}, OrderChannelPOS},
+		{Order{SourceName: "iphone"}, OrderChannelMobile},
+		{Order{SourceName: "amazon"}, OrderChannelApp},
+		{Order{}, OrderChannelUnknown},
+	}
+	for _, c := range cases {
+		if actual := c.order.Channel(); actual != c.expected {
+			t.Errorf("Order{AppId: %d, SourceName: %q}.Channel() returned %q, expected %q", c.order.AppId, c.order.SourceName, actual, c.expected)
+		}
+	}
+}
+
+func TestChannelList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		channelsQuery: `{"data":{"channels":{"nodes":[
+			{"id":"gid://shopify/Channel/1","name":"Online Store","handle":"online_store","app":{"id":"gid://shopify/App/580111","title":"Online Store","handle":"online_store"}},
+			{"id":"gid://shopify/Channel/2","name":"Point of Sale","handle":"pos","app":null}
+		],"pageInfo":{"hasNextPage":false,"endCursor":null}}}}`,
+	}))
+
+	channels, err := client.Channel.List(context.Background())
+	if err != nil {
+		t.Fatalf("Channel.List returned error: %v", err)
+	}
+	expected := []SalesChannel{
+		{Id: 1, Name: "Online Store", Handle: "online_store", App: &AppInfo{Id: OnlineStoreAppId, Title: "Online Store", Handle: "online_store"}},
+		{Id: 2, Name: "Point of Sale", Handle: "pos"},
+	}
+	if !reflect.DeepEqual(channels, expected) {
+		t.Errorf("Channel.List returned %+v, expected %+v", channels, expected)
+	}
+}
+
+func TestChannelAttribute(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		appQuery: `{"data":{"app":{"id":"gid://shopify/App/2417881","title":"Marketplace Connect","handle":"marketplace-connect"}}}`,
+	}))
+
+	order := Order{Id: 1, AppId: 2417881, SourceName: "2417881"}
+	attribution, err := client.Channel.Attribute(context.Background(), order)
+	if err != nil {
+		t.Fatalf("Channel.Attribute returned error: %v", err)
+	}
+	expected := &OrderAttribution{Channel: OrderChannelApp, AppId: 2417881, AppTitle: "Marketplace Connect", SourceName: "2417881"}
+	if !reflect.DeepEqual(attribution, expected) {
+		t.Errorf("Channel.Attribute returned %+v, expected %+v", attribution, expected)
+	}
+	if len(sent) != 1 || sent[0].Variables["id"] != "gid://shopify/App/2417881" {
+		t.Errorf("Channel.Attribute sent %+v", sent)
+	}
+
+	// the app is cached
+	if _, err := client.Channel.Attribute(context.Background(), order); err != nil {
+		t.Fatalf("Channel.Attribute returned error: %v", err)
+	}
+	if len(sent) != 1 {
+		t.Errorf("Channel.Attribute sent %d requests, expected the app to be cached", len(sent))
+	}
+
+	// orders without an app aren't looked up
+	attribution, err = client.Channel.Attribute(context.Background(), Order{SourceName: "pos"})
+	if err != nil || attribution.Channel != OrderChannelPOS || len(sent) != 1 {
+		t.Errorf("Channel.Attribute returned %+v, %v", attribution, err)
+	}
+}
+
+func TestChannelGetAppNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		appQuery: `{"data":{"app":null}}`,
+	}))
+
+	if _, err := client.Channel.GetApp(context.Background(), 1); err == nil {
+		t.Error("Channel.GetApp of an unknown app returned no error")
+	}
+}
@@ -0,0 +1,191 @@
+package goshopify
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// RefundedQuantity returns how many units of the line item have been
+// refunded across all refunds of the order
+func (o *Order) RefundedQuantity(lineItemId uint64) int {
+	refunded := 0
+	for _, refund := range o.Refunds {
+		for _, rli := range refund.RefundLineItems {
+			id := rli.LineItemId
+			if id == 0 && rli.LineItem != nil {
+				id = rli.LineItem.Id
+			}
+			if id == lineItemId {
+				refunded += rli.Quantity
+			}
+		}
+	}
+	return refunded
+}
+
+// FulfilledQuantity returns how many units of the line item are part of a
+// successful fulfillment
+func (o *Order) FulfilledQuantity(lineItemId uint64) int {
+	fulfilled := 0
+	for _, f := range o.Fulfillments {
+		if f.Status != "" && f.Status != "success" {
+			continue
+		}
+		for _, li := range f.LineItems {
+			if li.Id == lineItemId {
+				fulfilled += li.Quantity
+			}
+		}
+	}
+	return fulfilled
+}
+
+// RemainingQuantity returns the quantity of the line item still on the
+// order, i.e. without units refunded, including returned units, or removed
+// by an order edit
+func (o *Order) RemainingQuantity(li LineItem) int {
+	remaining := li.Quantity - o.RefundedQuantity(li.Id)
+	if li.CurrentQuantity != nil && *li.CurrentQuantity < remaining {
+		remaining = *li.CurrentQuantity
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// UnfulfilledLineItems returns the line items that still have to be
+// fulfilled, with Quantity set to the number of units left to fulfill.
+// Refunded and removed units are not counted, except returns which were
+// fulfilled before being refunded, and a cancelled order has nothing left
+// to fulfill.
+func (o *Order) UnfulfilledLineItems() []LineItem {
+	if o.CancelledAt != nil {
+		return nil
+	}
+
+	var items []LineItem
+	for _, li := range o.LineItems {
+		left := li.Quantity - o.FulfilledQuantity(li.Id) - o.unfulfilledRefundedQuantity(li.Id)
+		if li.CurrentQuantity != nil {
+			// current_quantity is also reduced by refunds, what's left are
+			// the units removed by order edits
+			if removed := li.Quantity - o.RefundedQuantity(li.Id) - *li.CurrentQuantity; removed > 0 {
+				left -= removed
+			}
+		}
+		if left <= 0 {
+			continue
+		}
+		li.Quantity = left
+		items = append(items, li)
+	}
+	return items
+}
+
+// unfulfilledRefundedQuantity returns how many refunded units of the line
+// item weren't fulfilled. Returns were fulfilled, other refunds, e.g. with
+// restock type cancel or no_restock, are of units never shipped.
+func (o *Order) unfulfilledRefundedQuantity(lineItemId uint64) int {
+	refunded := 0
+	for _, refund := range o.Refunds {
+		for _, rli := range refund.RefundLineItems {
+			id := rli.LineItemId
+			if id == 0 && rli.LineItem != nil {
+				id = rli.LineItem.Id
+			}
+			if id == lineItemId && rli.RestockType != "return" {
+				refunded += rli.Quantity
+			}
+		}
+	}
+	return refunded
+}
+
+// IsFullyFulfilled reports whether every unit remaining on the order has
+// been fulfilled
+func (o *Order) IsFullyFulfilled() bool {
+	return o.CancelledAt == nil && len(o.LineItems) > 0 && len(o.UnfulfilledLineItems()) == 0
+}
+
+// transactions returns the order's transactions together with those of its
+// refunds, each transaction once
+func (o *Order) transactions() []Transaction {
+	seen := map[uint64]bool{}
+	var all []Transaction
+	add := func(txs []Transaction) {
+		for _, tx := range txs {
+			if tx.Id != 0 {
+				if seen[tx.Id] {
+					continue
+				}
+				seen[tx.Id] = true
+			}
+			all = append(all, tx)
+		}
+	}
+	add(o.Transactions)
+	for _, refund := range o.Refunds {
+		add(refund.Transactions)
+	}
+	return all
+}
+
+// TotalPaid returns the amount captured from the customer, before refunds
+func (o *Order) TotalPaid() decimal.Decimal {
+	paid := decimal.Zero
+	for _, tx := range o.transactions() {
+		if tx.Status == "success" && (tx.Kind == "sale" || tx.Kind == "capture") && tx.Amount != nil {
+			paid = paid.Add(*tx.Amount)
+		}
+	}
+	return paid
+}
+
+// TotalRefunded returns the amount refunded to the customer
+func (o *Order) TotalRefunded() decimal.Decimal {
+	refunded := decimal.Zero
+	for _, tx := range o.transactions() {
+		if tx.Status == "success" && tx.Kind == "refund" && tx.Amount != nil {
+			refunded = refunded.Add(*tx.Amount)
+		}
+	}
+	return refunded
+}
+
+// IsFullyRefunded reports whether everything the customer paid has been
+// refunded. It relies on the financial status when Shopify reports it and
+// on the order's transactions otherwise.
+func (o *Order) IsFullyRefunded() bool {
+	switch o.FinancialStatus {
+	case OrderFinancialStatusRefunded:
+		return true
+	case "":
+	default:
+		return false
+	}
+
+	paid := o.TotalPaid()
+	return paid.IsPositive() && o.TotalRefunded().GreaterThanOrEqual(paid)
+}
+
+// OutstandingBalance returns the amount the customer still owes. It is
+// total_outstanding when Shopify sent it, and otherwise the current total
+// price, which excludes refunded and removed items, minus the net amount
+// paid. Overpaid orders have a negative balance.
+func (o *Order) OutstandingBalance() decimal.Decimal {
+	if o.TotalOutstanding != nil {
+		return *o.TotalOutstanding
+	}
+
+	total := o.CurrentTotalPrice
+	if total == nil {
+		total = o.TotalPrice
+	}
+	if total == nil {
+		return decimal.Zero
+	}
+
+	// refunded items have left the current total, so the refunds paid back
+	// for them no longer count as paid
+	return total.Sub(o.TotalPaid().Sub(o.TotalRefunded()))
+}
@@ -0,0 +1,205 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func helperOrder(t *testing.T) Order {
+	t.Helper()
+
+	// three units of 1 and two units of 2, one unit of 1 refunded, one unit
+	// of 2 removed by an order edit, one unit of 1 fulfilled
+	data := `{
+		"id": 1,
+		"financial_status": "partially_refunded",
+		"total_price": "50.00",
+		"current_total_price": "30.00",
+		"line_items": [
+			{"id": 1, "quantity": 3, "current_quantity": 2, "price": "10.00"},
+			{"id": 2, "quantity": 2, "current_quantity": 1, "price": "10.00"},
+			{"id": 3, "quantity": 1, "current_quantity": 0, "price": "0.00"}
+		],
+		"fulfillments": [
+			{"id": 1, "status": "success", "line_items": [{"id": 1, "quantity": 1}]},
+			{"id": 2, "status": "cancelled", "line_items": [{"id": 2, "quantity": 1}]}
+		],
+		"transactions": [
+			{"id": 1, "kind": "sale", "status": "success", "amount": "50.00"},
+			{"id": 2, "kind": "sale", "status": "failure", "amount": "50.00"}
+		],
+		"refunds": [{
+			"id": 1,
+			"refund_line_items": [{"id": 1, "line_item_id": 1, "quantity": 1}],
+			"transactions": [{"id": 3, "kind": "refund", "status": "success", "amount": "10.00"}]
+		}]
+	}`
+
+	order := Order{}
+	if err := json.Unmarshal([]byte(data), &order); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestOrderRemainingQuantity(t *testing.T) {
+	order := helperOrder(t)
+
+	cases := []struct {
+		lineItem  int
+		refunded  int
+		fulfilled int
+		remaining int
+	}{
+		{0, 1, 1, 2},
+		{1, 0, 0, 1},
+		{2, 0, 0, 0},
+	}
+	for _, c := range cases {
+		li := order.LineItems[c.lineItem]
+		if got := order.RefundedQuantity(li.Id); got != c.refunded {
+			t.Errorf("line item %d: RefundedQuantity = %d, expected %d", li.Id, got, c.refunded)
+		}
+		if got := order.FulfilledQuantity(li.Id); got != c.fulfilled {
+			t.Errorf("line item %d: FulfilledQuantity = %d, expected %d", li.Id, got, c.fulfilled)
+		}
+		if got := order.RemainingQuantity(li); got != c.remaining {
+			t.Errorf("line item %d: RemainingQuantity = %d, expected %d", li.Id, got, c.remaining)
+		}
+	}
+
+	// without current_quantity only refunds reduce the quantity
+	li := LineItem{Id: 1, Quantity: 3}
+	if got := order.RemainingQuantity(li); got != 2 {
+		t.Errorf("RemainingQuantity without current_quantity = %d, expected 2", got)
+	}
+}
+
+func TestOrderUnfulfilledLineItems(t *testing.T) {
+	order := helperOrder(t)
+
+	items := order.UnfulfilledLineItems()
+	if len(items) != 2 {
+		t.Fatalf("UnfulfilledLineItems returned %d items, expected 2: %+v", len(items), items)
+	}
+	if items[0].Id != 1 || items[0].Quantity != 1 {
+		t.Errorf("first unfulfilled item = %d x %d, expected 1 x 1", items[0].Id, items[0].Quantity)
+	}
+	if items[1].Id != 2 || items[1].Quantity != 1 {
+		t.Errorf("second unfulfilled item = %d x %d, expected 2 x 1", items[1].Id, items[1].Quantity)
+	}
+	if order.LineItems[0].Quantity != 3 {
+		t.Error("UnfulfilledLineItems modified the order's line items")
+	}
+	if order.IsFullyFulfilled() {
+		t.Error("IsFullyFulfilled = true, expected false")
+	}
+
+	order.Fulfillments = append(order.Fulfillments, Fulfillment{
+		Status:    "success",
+		LineItems: []LineItem{{Id: 1, Quantity: 1}, {Id: 2, Quantity: 1}},
+	})
+	if !order.IsFullyFulfilled() {
+		t.Error("IsFullyFulfilled = false, expected true")
+	}
+
+	cancelled := helperOrder(t)
+	now := time.Now()
+	cancelled.CancelledAt = &now
+	if items := cancelled.UnfulfilledLineItems(); items != nil {
+		t.Errorf("UnfulfilledLineItems of a cancelled order = %+v, expected nil", items)
+	}
+}
+
+func TestOrderUnfulfilledLineItemsReturned(t *testing.T) {
+	// three units, two fulfilled and one of them returned
+	data := `{
+		"id": 1,
+		"line_items": [{"id": 1, "quantity": 3, "current_quantity": 2, "price": "10.00"}],
+		"fulfillments": [{"id": 1, "status": "success", "line_items": [{"id": 1, "quantity": 2}]}],
+		"refunds": [{
+			"id": 1,
+			"refund_line_items": [{"id": 1, "line_item_id": 1, "quantity": 1, "restock_type": "return"}]
+		}]
+	}`
+	order := Order{}
+	if err := json.Unmarshal([]byte(data), &order); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := order.RemainingQuantity(order.LineItems[0]); got != 2 {
+		t.Errorf("RemainingQuantity = %d, expected 2", got)
+	}
+	items := order.UnfulfilledLineItems()
+	if len(items) != 1 || items[0].Quantity != 1 {
+		t.Errorf("UnfulfilledLineItems returned %+v, expected 1 unit left", items)
+	}
+
+	// the unit left is cancelled instead
+	order.Refunds[0].RefundLineItems[0].RestockType = "cancel"
+	if items := order.UnfulfilledLineItems(); len(items) != 0 {
+		t.Errorf("UnfulfilledLineItems returned %+v, expected none", items)
+	}
+}
+
+func TestOrderIsFullyRefunded(t *testing.T) {
+	order := helperOrder(t)
+	if order.IsFullyRefunded() {
+		t.Error("partially refunded order: IsFullyRefunded = true")
+	}
+	if !order.TotalPaid().Equal(decimal.NewFromInt(50)) {
+		t.Errorf("TotalPaid = %s, expected 50", order.TotalPaid())
+	}
+	if !order.TotalRefunded().Equal(decimal.NewFromInt(10)) {
+		t.Errorf("TotalRefunded = %s, expected 10", order.TotalRefunded())
+	}
+
+	order.FinancialStatus = OrderFinancialStatusRefunded
+	if !order.IsFullyRefunded() {
+		t.Error("refunded order: IsFullyRefunded = false")
+	}
+
+	// without a financial status the transactions decide, and a refund
+	// listed both on the order and on its refund counts once
+	order.FinancialStatus = ""
+	forty := decimal.NewFromInt(40)
+	order.Refunds = append(order.Refunds, Refund{
+		Transactions: []Transaction{{Id: 4, Kind: "refund", Status: "success", Amount: &forty}},
+	})
+	order.Transactions = append(order.Transactions, order.Refunds[0].Transactions...)
+	if !order.IsFullyRefunded() {
+		t.Errorf("IsFullyRefunded = false with %s of %s refunded", order.TotalRefunded(), order.TotalPaid())
+	}
+
+	if (&Order{}).IsFullyRefunded() {
+		t.Error("unpaid order: IsFullyRefunded = true")
+	}
+}
+
+func TestOrderOutstandingBalance(t *testing.T) {
+	order := helperOrder(t)
+
+	// 30 current total, 50 paid, 10 refunded
+	if got := order.OutstandingBalance(); !got.Equal(decimal.NewFromInt(-10)) {
+		t.Errorf("OutstandingBalance = %s, expected -10", got)
+	}
+
+	outstanding := decimal.NewFromInt(5)
+	order.TotalOutstanding = &outstanding
+	if got := order.OutstandingBalance(); !got.Equal(outstanding) {
+		t.Errorf("OutstandingBalance = %s, expected total_outstanding 5", got)
+	}
+
+	total := decimal.NewFromInt(20)
+	unpaid := Order{TotalPrice: &total}
+	if got := unpaid.OutstandingBalance(); !got.Equal(total) {
+		t.Errorf("OutstandingBalance of an unpaid order = %s, expected 20", got)
+	}
+
+	if got := (&Order{}).OutstandingBalance(); !got.IsZero() {
+		t.Errorf("OutstandingBalance without totals = %s, expected 0", got)
+	}
+}
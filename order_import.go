@@ -0,0 +1,51 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Import creates a historical order, e.g. when migrating a store to Shopify.
+// The order keeps its ProcessedAt date, which is required, and its
+// SourceName, FinancialStatus and Transactions. Import never emails the
+// customer and bypasses inventory unless InventoryBehaviour is set.
+// Transactions without a status or date are backfilled as successful at the
+// date of the order.
+func (s *OrderServiceOp) Import(ctx context.Context, order Order) (*Order, error) {
+	if errs := order.importErrors(); len(errs) > 0 {
+		return nil, ValidationError{Resource: "order", Errors: errs}
+	}
+
+	order.SendReceipt = false
+	order.SendFulfillmentReceipt = false
+	if order.InventoryBehaviour == "" {
+		order.InventoryBehaviour = OrderInventoryBehaviourBypass
+	}
+
+	transactions := make([]Transaction, 0, len(order.Transactions))
+	for _, t := range order.Transactions {
+		if t.Status == "" {
+			t.Status = "success"
+		}
+		if t.ProcessedAt == nil {
+			t.ProcessedAt = order.ProcessedAt
+		}
+		transactions = append(transactions, t)
+	}
+	order.Transactions = transactions
+
+	return s.Create(ctx, order)
+}
+
+func (o Order) importErrors() []FieldError {
+	errs := o.createErrors()
+	if o.ProcessedAt == nil {
+		errs = append(errs, requiredField("processed_at"))
+	}
+	for i, t := range o.Transactions {
+		if t.Kind == "" {
+			errs = append(errs, requiredField(fmt.Sprintf("transactions[%d].kind", i)))
+		}
+	}
+	return errs
+}
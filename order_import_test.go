@@ -0,0 +1,80 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderImport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent map[string]map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Errorf("invalid order body: %v", err)
+			}
+			return httpmock.NewStringResponse(201, `{"order":{"id":1}}`), nil
+		})
+
+	processedAt := time.Date(2019, time.March, 4, 10, 0, 0, 0, time.UTC)
+	amount := decimal.NewFromInt(10)
+	o, err := client.Order.Import(context.Background(), Order{
+		ProcessedAt:     &processedAt,
+		SourceName:      "magento",
+		FinancialStatus: OrderFinancialStatusPaid,
+		SendReceipt:     true,
+		LineItems:       []LineItem{{Title: "Soda", Quantity: 1}},
+		Transactions:    []Transaction{{Kind: "sale", Amount: &amount}},
+	})
+	if err != nil {
+		t.Fatalf("Order.Import returned error: %v", err)
+	}
+	if o.Id != 1 {
+		t.Errorf("Order.Import returned id %d, expected 1", o.Id)
+	}
+
+	order := sent["order"]
+	if order["processed_at"] != "2019-03-04T10:00:00Z" || order["source_name"] != "magento" || order["financial_status"] != "paid" {
+		t.Errorf("Order.Import sent %v", order)
+	}
+	if order["send_receipt"] != nil || order["inventory_behaviour"] != "bypass" {
+		t.Errorf("Order.Import sent send_receipt %v, inventory_behaviour %v", order["send_receipt"], order["inventory_behaviour"])
+	}
+	transactions, _ := order["transactions"].([]interface{})
+	if len(transactions) != 1 {
+		t.Fatalf("Order.Import sent transactions %v", order["transactions"])
+	}
+	transaction := transactions[0].(map[string]interface{})
+	if transaction["status"] != "success" || transaction["processed_at"] != "2019-03-04T10:00:00Z" {
+		t.Errorf("Order.Import sent transaction %v", transaction)
+	}
+}
+
+func TestOrderImportInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Order.Import(context.Background(), Order{
+		LineItems:    []LineItem{{VariantId: 1, Quantity: 1}},
+		Transactions: []Transaction{{Status: "success"}},
+	})
+	validationErr, ok := err.(ValidationError)
+	if !ok {
+		t.Fatalf("Order.Import returned %v, expected a ValidationError", err)
+	}
+	expected := []FieldError{requiredField("processed_at"), requiredField("transactions[0].kind")}
+	if fmt.Sprint(validationErr.Errors) != fmt.Sprint(expected) {
+		t.Errorf("Order.Import returned errors %v, expected %v", validationErr.Errors, expected)
+	}
+}
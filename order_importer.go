@@ -0,0 +1,205 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// OrderImportResult is the outcome of importing an order with an
+// OrderImporter
+type OrderImportResult struct {
+	// Index of the order in the imported stream
+	Index int
+
+	// SourceIdentifier of the order, e.g. its id on the platform it's
+	// migrated from
+	SourceIdentifier string
+
+	// Order is the created order, nil when Err is set
+	Order *Order
+
+	// CustomerCreated is set when the customer of the order didn't exist
+	// and was created
+	CustomerCreated bool
+
+	// Err is why the order failed, see IsRetryableError
+	Err error
+}
+
+// OrderImporter imports a stream of historical orders, e.g. when migrating a
+// store from another platform. Every order is created with Order.Import,
+// keeping its dates, transactions and fulfillment status, after linking it
+// to the existing customer with its email or creating the customer.
+// Orders are imported concurrently as PriorityBatch requests and rate limits
+// are waited out, like GetMany.
+type OrderImporter struct {
+	client *Client
+
+	// Concurrency is the number of orders imported at once, 4 if not set
+	Concurrency int
+
+	mu        sync.Mutex
+	customers map[string]*importedCustomer
+}
+
+// importedCustomer is the customer of an email, its mutex is held while
+// looking it up or creating it
+type importedCustomer struct {
+	mu sync.Mutex
+	id uint64
+}
+
+// NewOrderImporter returns an OrderImporter for the client's shop
+func NewOrderImporter(client *Client) *OrderImporter {
+	return &OrderImporter{client: client, customers: map[string]*importedCustomer{}}
+}
+
+// Import imports the orders received until the channel is closed, calling
+// report with the result of every order. report is never called
+// concurrently. Import stops reading orders once ctx is done and returns
+// its error.
+func (i *OrderImporter) Import(ctx context.Context, orders <-chan Order, report func(OrderImportResult)) error {
+	ctx = WithRequestPriority(ctx, PriorityBatch)
+
+	concurrency := i.Concurrency
+	if concurrency <= 0 {
+		concurrency = batchConcurrency
+	}
+
+	type indexedOrder struct {
+		index int
+		order Order
+	}
+	work := make(chan indexedOrder)
+
+	var reportMu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range work {
+				result := i.importOrder(ctx, o.order)
+				result.Index = o.index
+
+				reportMu.Lock()
+				report(result)
+				reportMu.Unlock()
+			}
+		}()
+	}
+
+	index := 0
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case order, ok := <-orders:
+			if !ok {
+				break loop
+			}
+			select {
+			case <-ctx.Done():
+				break loop
+			case work <- indexedOrder{index: index, order: order}:
+			}
+			index++
+		}
+	}
+	close(work)
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (i *OrderImporter) importOrder(ctx context.Context, order Order) OrderImportResult {
+	result := OrderImportResult{SourceIdentifier: order.SourceIdentifier}
+
+	created, err := i.client.batchGet(ctx, 0, func(ctx context.Context, _ uint64) (interface{}, error) {
+		return i.linkCustomer(ctx, &order)
+	})
+	if err != nil {
+		result.Err = fmt.Errorf("customer: %w", err)
+		return result
+	}
+	result.CustomerCreated = created.(bool)
+
+	imported, err := i.client.batchGet(ctx, 0, func(ctx context.Context, _ uint64) (interface{}, error) {
+		return i.client.Order.Import(ctx, order)
+	})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Order = imported.(*Order)
+	return result
+}
+
+// linkCustomer replaces the customer of the order by the existing customer
+// with its email, creating it if there is none. Each email is looked up once
+// at a time so concurrent orders of a new customer don't create it twice,
+// while orders of other customers go on.
+func (i *OrderImporter) linkCustomer(ctx context.Context, order *Order) (bool, error) {
+	email := order.Email
+	if order.Customer != nil {
+		if order.Customer.Id != 0 {
+			return false, nil
+		}
+		if order.Customer.Email != "" {
+			email = order.Customer.Email
+		}
+	}
+	if email == "" {
+		return false, nil
+	}
+	key := strings.ToLower(email)
+
+	i.mu.Lock()
+	entry, ok := i.customers[key]
+	if !ok {
+		entry = &importedCustomer{}
+		i.customers[key] = entry
+	}
+	i.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.id != 0 {
+		order.Customer = &Customer{Id: entry.id}
+		return false, nil
+	}
+
+	found, err := i.client.Customer.Search(ctx, CustomerSearchOptions{
+		Query:  fmt.Sprintf("email:%q", email),
+		Fields: "id,email",
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, c := range found {
+		if strings.EqualFold(c.Email, email) {
+			entry.id = c.Id
+			order.Customer = &Customer{Id: c.Id}
+			return false, nil
+		}
+	}
+
+	customer := Customer{}
+	if order.Customer != nil {
+		customer = *order.Customer
+	} else if order.BillingAddress != nil {
+		customer.FirstName = order.BillingAddress.FirstName
+		customer.LastName = order.BillingAddress.LastName
+	}
+	customer.Email = email
+	created, err := i.client.Customer.Create(ctx, customer)
+	if err != nil {
+		return false, err
+	}
+	entry.id = created.Id
+	order.Customer = &Customer{Id: created.Id}
+	return true, nil
+}
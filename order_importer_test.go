@@ -0,0 +1,177 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestOrderImporterImport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	searches := 0
+	createdCustomers := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/search.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			searches++
+			mu.Unlock()
+			if req.URL.Query().Get("query") == `email:"known@example.com"` {
+				return httpmock.NewStringResponse(200, `{"customers":[{"id":7,"email":"Known@example.com"}]}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"customers":[]}`), nil
+		})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			createdCustomers++
+			mu.Unlock()
+			return httpmock.NewStringResponse(201, `{"customer":{"id":8,"email":"new@example.com"}}`), nil
+		})
+
+	customerIds := map[string]float64{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			var data struct {
+				Order struct {
+					SourceIdentifier string                 `json:"source_identifier"`
+					Customer         map[string]interface{} `json:"customer"`
+				} `json:"order"`
+			}
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Errorf("invalid order body: %v", err)
+			}
+			if data.Order.SourceIdentifier == "bad" {
+				return httpmock.NewStringResponse(422, `{"errors":{"line_items":["is invalid"]}}`), nil
+			}
+			mu.Lock()
+			customerIds[data.Order.SourceIdentifier], _ = data.Order.Customer["id"].(float64)
+			mu.Unlock()
+			return httpmock.NewStringResponse(201, `{"order":{"id":1}}`), nil
+		})
+
+	processedAt := time.Date(2019, time.March, 4, 10, 0, 0, 0, time.UTC)
+	order := func(ref string, email string) Order {
+		return Order{
+			SourceIdentifier: ref,
+			Email:            email,
+			ProcessedAt:      &processedAt,
+			LineItems:        []LineItem{{Title: "Soda", Quantity: 1}},
+		}
+	}
+
+	orders := make(chan Order, 4)
+	orders <- order("1", "known@example.com")
+	orders <- order("2", "new@example.com")
+	orders <- order("3", "NEW@example.com")
+	orders <- order("bad", "known@example.com")
+	close(orders)
+
+	var results []OrderImportResult
+	err := NewOrderImporter(client).Import(context.Background(), orders, func(r OrderImportResult) {
+		results = append(results, r)
+	})
+	if err != nil {
+		t.Fatalf("OrderImporter.Import returned error: %v", err)
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+	if len(results) != 4 {
+		t.Fatalf("OrderImporter.Import reported %d results, expected 4", len(results))
+	}
+	for _, r := range results[:3] {
+		if r.Err != nil || r.Order == nil || r.Order.Id != 1 {
+			t.Errorf("OrderImporter.Import reported %+v, expected order 1", r)
+		}
+	}
+	if results[3].SourceIdentifier != "bad" || results[3].Err == nil {
+		t.Errorf("OrderImporter.Import reported %+v, expected an error", results[3])
+	}
+	if results[0].CustomerCreated || results[1].CustomerCreated == results[2].CustomerCreated {
+		t.Errorf("OrderImporter.Import reported customers created %v, %v, %v", results[0].CustomerCreated, results[1].CustomerCreated, results[2].CustomerCreated)
+	}
+
+	expected := map[string]float64{"1": 7, "2": 8, "3": 8}
+	if fmt.Sprint(customerIds) != fmt.Sprint(expected) {
+		t.Errorf("OrderImporter.Import linked customers %v, expected %v", customerIds, expected)
+	}
+	if searches != 2 || createdCustomers != 1 {
+		t.Errorf("OrderImporter.Import made %d searches and created %d customers, expected 2 and 1", searches, createdCustomers)
+	}
+}
+
+func TestOrderImporterCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	orders := make(chan Order)
+	err := NewOrderImporter(client).Import(ctx, orders, func(r OrderImportResult) {
+		t.Errorf("OrderImporter.Import reported %+v after cancel", r)
+	})
+	if err != context.Canceled {
+		t.Errorf("OrderImporter.Import returned %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestOrderImporterLooksUpCustomersConcurrently(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// the search of slow@ only returns once fast@ is searched, which would
+	// time out if customers were looked up one at a time
+	fastSearched := make(chan struct{})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/customers/search.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("query") == `email:"fast@example.com"` {
+				close(fastSearched)
+				return httpmock.NewStringResponse(200, `{"customers":[{"id":2,"email":"fast@example.com"}]}`), nil
+			}
+			select {
+			case <-fastSearched:
+				return httpmock.NewStringResponse(200, `{"customers":[{"id":1,"email":"slow@example.com"}]}`), nil
+			case <-time.After(time.Second):
+				return httpmock.NewStringResponse(200, `{"customers":[]}`), nil
+			}
+		})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		httpmock.NewStringResponder(201, `{"order":{"id":1}}`))
+
+	processedAt := time.Date(2019, time.March, 4, 10, 0, 0, 0, time.UTC)
+	lineItems := []LineItem{{Title: "Soda", Quantity: 1}}
+	orders := make(chan Order, 2)
+	orders <- Order{SourceIdentifier: "1", Email: "slow@example.com", ProcessedAt: &processedAt, LineItems: lineItems}
+	orders <- Order{SourceIdentifier: "2", Email: "fast@example.com", ProcessedAt: &processedAt, LineItems: lineItems}
+	close(orders)
+
+	importer := NewOrderImporter(client)
+	importer.Concurrency = 2
+	var results []OrderImportResult
+	err := importer.Import(context.Background(), orders, func(r OrderImportResult) {
+		results = append(results, r)
+	})
+	if err != nil {
+		t.Fatalf("OrderImporter.Import returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("OrderImporter.Import reported %d results, expected 2", len(results))
+	}
+	for _, r := range results {
+		if r.Err != nil || r.CustomerCreated {
+			t.Errorf("OrderImporter.Import reported %+v, expected the existing customer", r)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+)
+
+const orderInvoiceSendMutation = `mutation orderInvoiceSend($id: ID!, $email: EmailInput) {
+  orderInvoiceSend(id: $id, email: $email) {
+    order {
+      id
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const fulfillmentTrackingInfoQuery = `query fulfillmentTrackingInfo($id: ID!) {
+  fulfillment(id: $id) {
+    trackingInfo {
+      company
+      number
+      url
+    }
+  }
+}`
+
+const fulfillmentTrackingInfoUpdateMutation = `mutation fulfillmentTrackingInfoUpdate($fulfillmentId: ID!, $trackingInfoInput: FulfillmentTrackingInput!) {
+  fulfillmentTrackingInfoUpdate(fulfillmentId: $fulfillmentId, trackingInfoInput: $trackingInfoInput, notifyCustomer: true) {
+    fulfillment {
+      id
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// SendInvoice emails the invoice of an order, e.g. one with an outstanding
+// balance, to the customer or the address of the email. Empty fields of the
+// email use the defaults of the shop's notification template.
+func (s *OrderServiceOp) SendInvoice(ctx context.Context, orderId uint64, email DraftOrderInvoice) error {
+	vars := map[string]interface{}{"id": GraphQLId("Order", orderId)}
+	input := map[string]interface{}{}
+	if email.To != "" {
+		input["to"] = email.To
+	}
+	if email.From != "" {
+		input["from"] = email.From
+	}
+	if email.Subject != "" {
+		input["subject"] = email.Subject
+	}
+	if email.CustomMessage != "" {
+		input["customMessage"] = email.CustomMessage
+	}
+	if len(email.Bcc) > 0 {
+		input["bcc"] = email.Bcc
+	}
+	if len(input) > 0 {
+		vars["email"] = input
+	}
+
+	resp := struct {
+		OrderInvoiceSend struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"orderInvoiceSend"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, orderInvoiceSendMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.OrderInvoiceSend.UserErrors) > 0 {
+		return resp.OrderInvoiceSend.UserErrors
+	}
+	return nil
+}
+
+// ResendShippingConfirmation emails the shipping confirmation of a
+// fulfillment to the customer again, by updating its tracking info to what it
+// already is with notifyCustomer set
+func (s *OrderServiceOp) ResendShippingConfirmation(ctx context.Context, fulfillmentId uint64) error {
+	id := GraphQLId("Fulfillment", fulfillmentId)
+
+	current := struct {
+		Fulfillment *struct {
+			TrackingInfo []struct {
+				Company string `json:"company"`
+				Number  string `json:"number"`
+				URL     string `json:"url"`
+			} `json:"trackingInfo"`
+		} `json:"fulfillment"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, fulfillmentTrackingInfoQuery, map[string]interface{}{"id": id}, &current)
+	if err != nil {
+		return err
+	}
+	if current.Fulfillment == nil {
+		return graphQLNotFound()
+	}
+
+	tracking := map[string]interface{}{}
+	if len(current.Fulfillment.TrackingInfo) > 0 {
+		numbers := make([]string, 0, len(current.Fulfillment.TrackingInfo))
+		urls := make([]string, 0, len(current.Fulfillment.TrackingInfo))
+		for _, t := range current.Fulfillment.TrackingInfo {
+			numbers = append(numbers, t.Number)
+			if t.URL != "" {
+				urls = append(urls, t.URL)
+			}
+		}
+		tracking["company"] = current.Fulfillment.TrackingInfo[0].Company
+		tracking["numbers"] = numbers
+		if len(urls) > 0 {
+			tracking["urls"] = urls
+		}
+	}
+
+	resp := struct {
+		FulfillmentTrackingInfoUpdate struct {
+			Fulfillment *struct {
+				Id string `json:"id"`
+			} `json:"fulfillment"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"fulfillmentTrackingInfoUpdate"`
+	}{}
+	vars := map[string]interface{}{"fulfillmentId": id, "trackingInfoInput": tracking}
+	err = s.client.GraphQL.Query(ctx, fulfillmentTrackingInfoUpdateMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.FulfillmentTrackingInfoUpdate.UserErrors) > 0 {
+		return resp.FulfillmentTrackingInfoUpdate.UserErrors
+	}
+	if resp.FulfillmentTrackingInfoUpdate.Fulfillment == nil {
+		return errors.New("fulfillmentTrackingInfoUpdate returned no fulfillment")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestOrderSendInvoice(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderInvoiceSendMutation: `{"data":{"orderInvoiceSend":{"order":{"id":"gid://shopify/Order/1"},"userErrors":[]}}}`,
+	}))
+
+	err := client.Order.SendInvoice(context.Background(), 1, DraftOrderInvoice{
+		To:            "jon@example.com",
+		CustomMessage: "Pay up",
+	})
+	if err != nil {
+		t.Fatalf("Order.SendInvoice returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id":    "gid://shopify/Order/1",
+		"email": map[string]interface{}{"to": "jon@example.com", "customMessage": "Pay up"},
+	}
+	if len(sent) != 1 || !reflect.DeepEqual(sent[0].Variables, expected) {
+		t.Errorf("Order.SendInvoice sent %+v, expected variables %+v", sent, expected)
+	}
+}
+
+func TestOrderSendInvoiceUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderInvoiceSendMutation: `{"data":{"orderInvoiceSend":{"order":null,"userErrors":[
+			{"field":["id"],"message":"Order has no email"}
+		]}}}`,
+	}))
+
+	err := client.Order.SendInvoice(context.Background(), 1, DraftOrderInvoice{})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("Order.SendInvoice returned %v, expected GraphQLUserErrors", err)
+	}
+	if len(sent) != 1 || sent[0].Variables["email"] != nil {
+		t.Errorf("Order.SendInvoice sent %+v, expected no email input", sent)
+	}
+}
+
+func TestOrderResendShippingConfirmation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		fulfillmentTrackingInfoQuery: `{"data":{"fulfillment":{"trackingInfo":[
+			{"company":"UPS","number":"1Z001","url":"https://ups.com/1Z001"},
+			{"company":"UPS","number":"1Z002","url":""}
+		]}}}`,
+		fulfillmentTrackingInfoUpdateMutation: `{"data":{"fulfillmentTrackingInfoUpdate":{
+			"fulfillment":{"id":"gid://shopify/Fulfillment/5"},"userErrors":[]
+		}}}`,
+	}))
+
+	err := client.Order.ResendShippingConfirmation(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("Order.ResendShippingConfirmation returned error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("Order.ResendShippingConfirmation sent %d requests, expected 2", len(sent))
+	}
+	expected := map[string]interface{}{
+		"fulfillmentId": "gid://shopify/Fulfillment/5",
+		"trackingInfoInput": map[string]interface{}{
+			"company": "UPS",
+			"numbers": []interface{}{"1Z001", "1Z002"},
+			"urls":    []interface{}{"https://ups.com/1Z001"},
+		},
+	}
+	if !reflect.DeepEqual(sent[1].Variables, expected) {
+		t.Errorf("Order.ResendShippingConfirmation sent variables %+v, expected %+v", sent[1].Variables, expected)
+	}
+}
+
+func TestOrderResendShippingConfirmationNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		fulfillmentTrackingInfoQuery: `{"data":{"fulfillment":null}}`,
+	}))
+
+	err := client.Order.ResendShippingConfirmation(context.Background(), 5)
+	if err == nil || len(sent) != 1 {
+		t.Errorf("Order.ResendShippingConfirmation returned %v after %d requests, expected not found", err, len(sent))
+	}
+}
@@ -0,0 +1,86 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestOrderPOSDetails(t *testing.T) {
+	order := Order{AppId: PointOfSaleAppId, SourceName: "pos", LocationId: 48752903, DeviceId: 3, UserId: 799407056}
+	expected := &OrderPOSDetails{LocationId: 48752903, DeviceId: 3, UserId: 799407056}
+	if details := order.POSDetails(); !reflect.DeepEqual(details, expected) {
+		t.Errorf("Order.POSDetails returned %+v, expected %+v", details, expected)
+	}
+
+	order = Order{SourceName: "pos", PosLocationId: 48752903}
+	if id := order.POSLocationId(); id != 48752903 {
+		t.Errorf("Order.POSLocationId returned %d, expected the pos_location_id", id)
+	}
+
+	order = Order{AppId: OnlineStoreAppId, SourceName: "web", LocationId: 48752903}
+	if order.IsPOS() || order.POSDetails() != nil || order.POSLocationId() != 0 {
+		t.Errorf("online store order has POS details %+v", order.POSDetails())
+	}
+}
+
+func TestFilterPOSOrders(t *testing.T) {
+	orders := []Order{
+		{Id: 1, SourceName: "pos", LocationId: 10},
+		{Id: 2, SourceName: "web"},
+		{Id: 3, AppId: PointOfSaleAppId, LocationId: 20},
+	}
+
+	ids := func(orders []Order) []uint64 {
+		var ids []uint64
+		for _, o := range orders {
+			ids = append(ids, o.Id)
+		}
+		return ids
+	}
+	if pos := ids(FilterPOSOrders(orders)); !reflect.DeepEqual(pos, []uint64{1, 3}) {
+		t.Errorf("FilterPOSOrders returned orders %v, expected [1 3]", pos)
+	}
+	if pos := ids(FilterPOSOrders(orders, 20)); !reflect.DeepEqual(pos, []uint64{3}) {
+		t.Errorf("FilterPOSOrders of location 20 returned orders %v, expected [3]", pos)
+	}
+}
+
+func TestOrderSearchQueryPOS(t *testing.T) {
+	q := OrderSearchQuery{SourceName: OrderSourceNamePOS, LocationId: 48752903}
+	expected := `source_name:pos AND location_id:48752903`
+	if q.String() != expected {
+		t.Errorf("OrderSearchQuery.String returned %s, expected %s", q.String(), expected)
+	}
+}
+
+func TestOrderGetRetailDetails(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderRetailDetailsQuery: `{"data":{"order":{"sourceName":"pos",
+			"retailLocation":{"id":"gid://shopify/Location/48752903","name":"Downtown"},
+			"staffMember":{"id":"gid://shopify/StaffMember/799407056","name":"Jo Smith"}}}}`,
+	}))
+
+	details, err := client.Order.GetRetailDetails(context.Background(), 450789469)
+	if err != nil {
+		t.Fatalf("Order.GetRetailDetails returned error: %v", err)
+	}
+	expected := &OrderRetailDetails{
+		OrderId:         450789469,
+		SourceName:      "pos",
+		LocationId:      48752903,
+		LocationName:    "Downtown",
+		StaffMemberId:   799407056,
+		StaffMemberName: "Jo Smith",
+	}
+	if !reflect.DeepEqual(details, expected) {
+		t.Errorf("Order.GetRetailDetails returned %+v, expected %+v", details, expected)
+	}
+	if len(sent) != 1 || sent[0].Variables["id"] != "gid://shopify/Order/450789469" {
+		t.Errorf("Order.GetRetailDetails sent %+v", sent)
+	}
+}
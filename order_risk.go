@@ -21,6 +21,8 @@ type OrderRiskService interface {
 	Create(context.Context, uint64, OrderRisk) (*OrderRisk, error)
 	Update(context.Context, uint64, uint64, OrderRisk) (*OrderRisk, error)
 	Delete(context.Context, uint64, uint64) error
+	CreateAssessment(context.Context, OrderRiskAssessment) (*OrderRiskAssessment, error)
+	GetAssessments(context.Context, uint64) (*OrderRiskAssessments, error)
 }
 
 // OrderRiskServiceOp handles communication with the order related methods of the
@@ -38,17 +40,19 @@ type OrderRiskResource struct {
 type OrdersRisksResource struct {
 	OrderRisk []OrderRisk `json:"risks"`
 }
-type orderRiskRecommendation string
+
+// OrderRiskRecommendation is the action recommended for an order risk
+type OrderRiskRecommendation string
 
 const (
 	// order is fraudulent.
-	OrderRecommendationCancel orderRiskRecommendation = "cancel"
+	OrderRecommendationCancel OrderRiskRecommendation = "cancel"
 
 	// medium level of risk that this order is fraudulent.
-	OrderRecommendationInvestigate orderRiskRecommendation = "investigate"
+	OrderRecommendationInvestigate OrderRiskRecommendation = "investigate"
 
 	// level of risk that this order is fraudulent.
-	OrderRecommendationAccept orderRiskRecommendation = "accept"
+	OrderRecommendationAccept OrderRiskRecommendation = "accept"
 )
 
 // A struct for all available order Risk list options.
@@ -68,7 +72,7 @@ type OrderRisk struct {
 	Message         string                  `json:"message,omitempty"`
 	Score           string                  `json:"score,omitempty"`
 	Source          string                  `json:"source,omitempty"`
-	Recommendation  orderRiskRecommendation `json:"recommendation,omitempty"`
+	Recommendation  OrderRiskRecommendation `json:"recommendation,omitempty"`
 }
 
 // List OrderRisk
@@ -0,0 +1,207 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const orderRiskAssessmentFields = `
+      riskLevel
+      provider {
+        title
+      }
+      facts {
+        description
+        sentiment
+      }`
+
+const orderRiskAssessmentCreateMutation = `mutation orderRiskAssessmentCreate($input: OrderRiskAssessmentCreateInput!) {
+  orderRiskAssessmentCreate(orderRiskAssessmentInput: $input) {
+    orderRiskAssessment {` + orderRiskAssessmentFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const orderRiskAssessmentsQuery = `query orderRiskAssessments($id: ID!) {
+  order(id: $id) {
+    risk {
+      recommendation
+      assessments {` + orderRiskAssessmentFields + `
+      }
+    }
+  }
+}`
+
+// OrderRiskLevel is the risk level of an order risk assessment
+type OrderRiskLevel string
+
+const (
+	OrderRiskLevelHigh    OrderRiskLevel = "high"
+	OrderRiskLevelMedium  OrderRiskLevel = "medium"
+	OrderRiskLevelLow     OrderRiskLevel = "low"
+	OrderRiskLevelNone    OrderRiskLevel = "none"
+	OrderRiskLevelPending OrderRiskLevel = "pending"
+)
+
+// OrderRecommendationNone is the recommendation of orders without risk
+// assessments
+const OrderRecommendationNone OrderRiskRecommendation = "none"
+
+// OrderRiskFactSentiment tells whether a risk fact makes an order more or
+// less risky
+type OrderRiskFactSentiment string
+
+const (
+	OrderRiskFactPositive OrderRiskFactSentiment = "positive"
+	OrderRiskFactNeutral  OrderRiskFactSentiment = "neutral"
+	OrderRiskFactNegative OrderRiskFactSentiment = "negative"
+)
+
+// OrderRiskFact is a fact a provider found assessing the risk of an order,
+// e.g. "Billing address matches the IP location"
+type OrderRiskFact struct {
+	Description string
+	Sentiment   OrderRiskFactSentiment
+}
+
+// OrderRiskAssessment is the risk assessment of an order by a provider, e.g.
+// a fraud app. Provider is empty for Shopify's own assessment.
+type OrderRiskAssessment struct {
+	OrderId   uint64
+	RiskLevel OrderRiskLevel
+	Facts     []OrderRiskFact
+	Provider  string
+}
+
+// OrderRiskAssessments are the risk assessments of an order with the
+// recommendation resulting from them
+type OrderRiskAssessments struct {
+	Recommendation OrderRiskRecommendation
+	Assessments    []OrderRiskAssessment
+}
+
+type graphQLOrderRiskAssessment struct {
+	RiskLevel string `json:"riskLevel"`
+	Provider  *struct {
+		Title string `json:"title"`
+	} `json:"provider"`
+	Facts []struct {
+		Description string `json:"description"`
+		Sentiment   string `json:"sentiment"`
+	} `json:"facts"`
+}
+
+func (g graphQLOrderRiskAssessment) assessment(orderId uint64) OrderRiskAssessment {
+	a := OrderRiskAssessment{OrderId: orderId, RiskLevel: OrderRiskLevel(strings.ToLower(g.RiskLevel))}
+	if g.Provider != nil {
+		a.Provider = g.Provider.Title
+	}
+	for _, f := range g.Facts {
+		a.Facts = append(a.Facts, OrderRiskFact{
+			Description: f.Description,
+			Sentiment:   OrderRiskFactSentiment(strings.ToLower(f.Sentiment)),
+		})
+	}
+	return a
+}
+
+func (a OrderRiskAssessment) validate() error {
+	if a.OrderId == 0 {
+		return errors.New("order risk assessment: order id is required")
+	}
+	switch a.RiskLevel {
+	case OrderRiskLevelHigh, OrderRiskLevelMedium, OrderRiskLevelLow, OrderRiskLevelNone, OrderRiskLevelPending:
+	default:
+		return fmt.Errorf("order risk assessment: invalid risk level %q", a.RiskLevel)
+	}
+	for _, f := range a.Facts {
+		if f.Description == "" {
+			return errors.New("order risk assessment: facts need a description")
+		}
+		switch f.Sentiment {
+		case OrderRiskFactPositive, OrderRiskFactNeutral, OrderRiskFactNegative:
+		default:
+			return fmt.Errorf("order risk assessment: invalid fact sentiment %q", f.Sentiment)
+		}
+	}
+	return nil
+}
+
+// CreateAssessment attaches the risk assessment of the app to an order
+// through the GraphQL orderRiskAssessmentCreate mutation, replacing Create
+// which is deprecated for new app installs
+func (s *OrderRiskServiceOp) CreateAssessment(ctx context.Context, assessment OrderRiskAssessment) (*OrderRiskAssessment, error) {
+	if err := assessment.validate(); err != nil {
+		return nil, err
+	}
+
+	facts := make([]map[string]interface{}, 0, len(assessment.Facts))
+	for _, f := range assessment.Facts {
+		facts = append(facts, map[string]interface{}{
+			"description": f.Description,
+			"sentiment":   strings.ToUpper(string(f.Sentiment)),
+		})
+	}
+	vars := map[string]interface{}{
+		"input": map[string]interface{}{
+			"orderId":   GraphQLId("Order", assessment.OrderId),
+			"riskLevel": strings.ToUpper(string(assessment.RiskLevel)),
+			"facts":     facts,
+		},
+	}
+
+	resp := struct {
+		OrderRiskAssessmentCreate struct {
+			OrderRiskAssessment *graphQLOrderRiskAssessment `json:"orderRiskAssessment"`
+			UserErrors          GraphQLUserErrors           `json:"userErrors"`
+		} `json:"orderRiskAssessmentCreate"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, orderRiskAssessmentCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.OrderRiskAssessmentCreate.UserErrors) > 0 {
+		return nil, resp.OrderRiskAssessmentCreate.UserErrors
+	}
+	if resp.OrderRiskAssessmentCreate.OrderRiskAssessment == nil {
+		return nil, errors.New("orderRiskAssessmentCreate returned no assessment")
+	}
+
+	created := resp.OrderRiskAssessmentCreate.OrderRiskAssessment.assessment(assessment.OrderId)
+	return &created, nil
+}
+
+// GetAssessments gets the risk assessments of an order by all providers and
+// the resulting recommendation
+func (s *OrderRiskServiceOp) GetAssessments(ctx context.Context, orderId uint64) (*OrderRiskAssessments, error) {
+	resp := struct {
+		Order *struct {
+			Risk struct {
+				Recommendation string                       `json:"recommendation"`
+				Assessments    []graphQLOrderRiskAssessment `json:"assessments"`
+			} `json:"risk"`
+		} `json:"order"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, orderRiskAssessmentsQuery, map[string]interface{}{"id": GraphQLId("Order", orderId)}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Order == nil {
+		return nil, graphQLNotFound()
+	}
+
+	assessments := &OrderRiskAssessments{
+		Recommendation: OrderRiskRecommendation(strings.ToLower(resp.Order.Risk.Recommendation)),
+	}
+	for _, a := range resp.Order.Risk.Assessments {
+		assessments.Assessments = append(assessments.Assessments, a.assessment(orderId))
+	}
+	return assessments, nil
+}
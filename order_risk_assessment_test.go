@@ -0,0 +1,129 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestOrderRiskCreateAssessment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderRiskAssessmentCreateMutation: `{"data":{"orderRiskAssessmentCreate":{"orderRiskAssessment":{
+			"riskLevel":"HIGH","provider":{"title":"Fraud Guard"},
+			"facts":[{"description":"Billing address doesn't match the IP location","sentiment":"NEGATIVE"}]
+		},"userErrors":[]}}}`,
+	}))
+
+	assessment := OrderRiskAssessment{
+		OrderId:   450789469,
+		RiskLevel: OrderRiskLevelHigh,
+		Facts: []OrderRiskFact{
+			{Description: "Billing address doesn't match the IP location", Sentiment: OrderRiskFactNegative},
+		},
+	}
+	created, err := client.OrderRisk.CreateAssessment(context.Background(), assessment)
+	if err != nil {
+		t.Fatalf("OrderRisk.CreateAssessment returned error: %v", err)
+	}
+
+	expected := assessment
+	expected.Provider = "Fraud Guard"
+	if !reflect.DeepEqual(*created, expected) {
+		t.Errorf("OrderRisk.CreateAssessment returned %+v, expected %+v", *created, expected)
+	}
+
+	expectedVars := map[string]interface{}{"input": map[string]interface{}{
+		"orderId":   "gid://shopify/Order/450789469",
+		"riskLevel": "HIGH",
+		"facts": []interface{}{map[string]interface{}{
+			"description": "Billing address doesn't match the IP location",
+			"sentiment":   "NEGATIVE",
+		}},
+	}}
+	if len(sent) != 1 || !reflect.DeepEqual(sent[0].Variables, expectedVars) {
+		t.Errorf("OrderRisk.CreateAssessment sent %+v, expected variables %v", sent, expectedVars)
+	}
+}
+
+func TestOrderRiskCreateAssessmentInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []OrderRiskAssessment{
+		{RiskLevel: OrderRiskLevelLow},
+		{OrderId: 1, RiskLevel: "critical"},
+		{OrderId: 1, RiskLevel: OrderRiskLevelLow, Facts: []OrderRiskFact{{Sentiment: OrderRiskFactPositive}}},
+		{OrderId: 1, RiskLevel: OrderRiskLevelLow, Facts: []OrderRiskFact{{Description: "Known customer", Sentiment: "good"}}},
+	}
+	for _, c := range cases {
+		if _, err := client.OrderRisk.CreateAssessment(context.Background(), c); err == nil {
+			t.Errorf("OrderRisk.CreateAssessment accepted %+v", c)
+		}
+	}
+}
+
+func TestOrderRiskCreateAssessmentUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderRiskAssessmentCreateMutation: `{"data":{"orderRiskAssessmentCreate":{"orderRiskAssessment":null,
+			"userErrors":[{"field":["orderRiskAssessmentInput","orderId"],"message":"Order does not exist","code":"NOT_FOUND"}]}}}`,
+	}))
+
+	_, err := client.OrderRisk.CreateAssessment(context.Background(), OrderRiskAssessment{OrderId: 1, RiskLevel: OrderRiskLevelNone})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("OrderRisk.CreateAssessment returned %v, expected user errors", err)
+	}
+}
+
+func TestOrderRiskGetAssessments(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderRiskAssessmentsQuery: `{"data":{"order":{"risk":{"recommendation":"INVESTIGATE","assessments":[
+			{"riskLevel":"MEDIUM","provider":null,"facts":[{"description":"Card verification passed","sentiment":"POSITIVE"}]},
+			{"riskLevel":"PENDING","provider":{"title":"Fraud Guard"},"facts":[]}
+		]}}}}`,
+	}))
+
+	assessments, err := client.OrderRisk.GetAssessments(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("OrderRisk.GetAssessments returned error: %v", err)
+	}
+
+	expected := &OrderRiskAssessments{
+		Recommendation: OrderRecommendationInvestigate,
+		Assessments: []OrderRiskAssessment{
+			{OrderId: 1, RiskLevel: OrderRiskLevelMedium, Facts: []OrderRiskFact{{Description: "Card verification passed", Sentiment: OrderRiskFactPositive}}},
+			{OrderId: 1, RiskLevel: OrderRiskLevelPending, Provider: "Fraud Guard"},
+		},
+	}
+	if !reflect.DeepEqual(assessments, expected) {
+		t.Errorf("OrderRisk.GetAssessments returned %+v, expected %+v", assessments, expected)
+	}
+	if sent[0].Variables["id"] != "gid://shopify/Order/1" {
+		t.Errorf("OrderRisk.GetAssessments sent variables %v", sent[0].Variables)
+	}
+}
+
+func TestOrderRiskGetAssessmentsNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		orderRiskAssessmentsQuery: `{"data":{"order":null}}`,
+	}))
+
+	if _, err := client.OrderRisk.GetAssessments(context.Background(), 1); !isNotFound(err) {
+		t.Errorf("OrderRisk.GetAssessments returned %v, expected not found", err)
+	}
+}
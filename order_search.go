@@ -0,0 +1,271 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const orderSearchQuery = `query orderSearch($first: Int!, $after: String, $query: String, $sortKey: OrderSortKeys, $reverse: Boolean) {
+  orders(first: $first, after: $after, query: $query, sortKey: $sortKey, reverse: $reverse) {
+    nodes {
+      legacyResourceId
+      name
+      email
+      note
+      tags
+      createdAt
+      updatedAt
+      processedAt
+      cancelledAt
+      displayFinancialStatus
+      displayFulfillmentStatus
+      currencyCode
+      totalPriceSet {
+        shopMoney {
+          amount
+        }
+      }
+      purchasingEntity {
+        ...purchasingCompany
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}
+` + purchasingCompanyFragment
+
+// OrderNotFoundError is returned by OrderService.GetByName when no order has
+// the given name.
+type OrderNotFoundError struct {
+	Name string
+}
+
+func (e OrderNotFoundError) Error() string {
+	return fmt.Sprintf("no order named %s", e.Name)
+}
+
+// AmbiguousOrderError is returned by OrderService.GetByName when more than one
+// order has the given name.
+type AmbiguousOrderError struct {
+	Name     string
+	OrderIds []uint64
+}
+
+func (e AmbiguousOrderError) Error() string {
+	return fmt.Sprintf("%d orders named %s", len(e.OrderIds), e.Name)
+}
+
+// OrderSearchQuery holds the common filters of an orders search, see
+// OrderService.Search. Zero values are left out of the query.
+type OrderSearchQuery struct {
+	Status                OrderStatus
+	FinancialStatus       OrderFinancialStatus
+	FulfillmentStatus     OrderFulfillmentStatus
+	Tags                  []string
+	ExcludeTags           []string
+	CreatedAtMin          time.Time
+	CreatedAtMax          time.Time
+	UpdatedAtMin          time.Time
+	UpdatedAtMax          time.Time
+	FulfillmentLocationId uint64
+	Email                 string
+	CompanyId             uint64
+	CompanyLocationId     uint64
+	SourceName            string
+	LocationId            uint64
+}
+
+// Query returns the filters as a SearchQuery, which can be extended with
+// further terms.
+func (o OrderSearchQuery) Query() *SearchQuery {
+	q := NewSearchQuery()
+	if o.Status != "" && o.Status != OrderStatusAny {
+		q.Field("status", string(o.Status))
+	}
+	if o.FinancialStatus != "" && o.FinancialStatus != OrderFinancialStatusAny {
+		q.Field("financial_status", string(o.FinancialStatus))
+	}
+	if o.FulfillmentStatus != "" && o.FulfillmentStatus != OrderFulfillmentStatusAny {
+		q.Field("fulfillment_status", string(o.FulfillmentStatus))
+	}
+	for _, tag := range o.Tags {
+		q.Field("tag", tag)
+	}
+	for _, tag := range o.ExcludeTags {
+		q.Not("tag", tag)
+	}
+	q.TimeRange("created_at", o.CreatedAtMin, o.CreatedAtMax)
+	q.TimeRange("updated_at", o.UpdatedAtMin, o.UpdatedAtMax)
+	if o.FulfillmentLocationId != 0 {
+		q.Field("fulfillment_location_id", strconv.FormatUint(o.FulfillmentLocationId, 10))
+	}
+	if o.Email != "" {
+		q.Field("email", o.Email)
+	}
+	if o.CompanyId != 0 {
+		q.Field("company_id", strconv.FormatUint(o.CompanyId, 10))
+	}
+	if o.CompanyLocationId != 0 {
+		q.Field("company_location_id", strconv.FormatUint(o.CompanyLocationId, 10))
+	}
+	if o.SourceName != "" {
+		q.Field("source_name", o.SourceName)
+	}
+	if o.LocationId != 0 {
+		q.Field("location_id", strconv.FormatUint(o.LocationId, 10))
+	}
+	return q
+}
+
+// String returns the search query
+func (o OrderSearchQuery) String() string {
+	return o.Query().String()
+}
+
+// OrderSearchOptions are the paging and sorting options of an orders search
+type OrderSearchOptions struct {
+	// number of orders per page, defaults to 50
+	First   int
+	After   string
+	SortKey string
+	Reverse bool
+}
+
+type orderSearchNode struct {
+	LegacyResourceId         string     `json:"legacyResourceId"`
+	Name                     string     `json:"name"`
+	Email                    string     `json:"email"`
+	Note                     string     `json:"note"`
+	Tags                     []string   `json:"tags"`
+	CreatedAt                *time.Time `json:"createdAt"`
+	UpdatedAt                *time.Time `json:"updatedAt"`
+	ProcessedAt              *time.Time `json:"processedAt"`
+	CancelledAt              *time.Time `json:"cancelledAt"`
+	DisplayFinancialStatus   string     `json:"displayFinancialStatus"`
+	DisplayFulfillmentStatus string     `json:"displayFulfillmentStatus"`
+	CurrencyCode             string     `json:"currencyCode"`
+	TotalPriceSet            struct {
+		ShopMoney struct {
+			Amount *decimal.Decimal `json:"amount"`
+		} `json:"shopMoney"`
+	} `json:"totalPriceSet"`
+	PurchasingEntity *graphQLPurchasingCompany `json:"purchasingEntity"`
+}
+
+func (n orderSearchNode) order() Order {
+	id, _ := strconv.ParseUint(n.LegacyResourceId, 10, 64)
+	order := Order{
+		Id:              id,
+		Name:            n.Name,
+		Email:           n.Email,
+		Note:            n.Note,
+		Tags:            strings.Join(n.Tags, ", "),
+		CreatedAt:       n.CreatedAt,
+		UpdatedAt:       n.UpdatedAt,
+		ProcessedAt:     n.ProcessedAt,
+		CancelledAt:     n.CancelledAt,
+		FinancialStatus: OrderFinancialStatus(strings.ToLower(n.DisplayFinancialStatus)),
+		Currency:        n.CurrencyCode,
+		TotalPrice:      n.TotalPriceSet.ShopMoney.Amount,
+		Company:         n.PurchasingEntity.orderCompany(),
+	}
+
+	switch n.DisplayFulfillmentStatus {
+	case "FULFILLED":
+		order.FulfillmentStatus = OrderFulfillmentStatusFulfilled
+	case "PARTIALLY_FULFILLED":
+		order.FulfillmentStatus = OrderFulfillmentStatusPartial
+	}
+
+	return order
+}
+
+// Search orders with a query in the Shopify search syntax through the GraphQL
+// API, which supports filters the REST list endpoint does not, e.g.
+// OrderSearchQuery{Tags: []string{"vip"}}.String(). Only the summary fields
+// of the orders are set, use Get for the full order.
+func (s *OrderServiceOp) Search(ctx context.Context, query string, options *OrderSearchOptions) ([]Order, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &OrderSearchOptions{}
+	}
+
+	vars := map[string]interface{}{
+		"first":   options.First,
+		"query":   query,
+		"reverse": options.Reverse,
+	}
+	if options.First <= 0 {
+		vars["first"] = 50
+	}
+	if options.After != "" {
+		vars["after"] = options.After
+	}
+	if options.SortKey != "" {
+		vars["sortKey"] = options.SortKey
+	}
+
+	resp := struct {
+		Orders struct {
+			Nodes    []orderSearchNode `json:"nodes"`
+			PageInfo GraphQLPageInfo   `json:"pageInfo"`
+		} `json:"orders"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, orderSearchQuery, vars, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders := make([]Order, 0, len(resp.Orders.Nodes))
+	for _, n := range resp.Orders.Nodes {
+		orders = append(orders, n.order())
+	}
+
+	return orders, &resp.Orders.PageInfo, nil
+}
+
+// GetByName gets the order with the given name, e.g. "#1001". It returns an
+// OrderNotFoundError or AmbiguousOrderError unless exactly one order has that
+// name. The options are passed on to Get.
+func (s *OrderServiceOp) GetByName(ctx context.Context, name string, options interface{}) (*Order, error) {
+	query := NewSearchQuery().Field("name", name).String()
+
+	// the search also matches similar names, e.g. #10012 for #1001, and
+	// doesn't rank the exact match first, go through all of them
+	var ids []uint64
+	searchOptions := &OrderSearchOptions{First: 250}
+	for {
+		orders, pageInfo, err := s.Search(ctx, query, searchOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, order := range orders {
+			if strings.EqualFold(order.Name, name) {
+				ids = append(ids, order.Id)
+			}
+		}
+
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			break
+		}
+		searchOptions.After = pageInfo.EndCursor
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, OrderNotFoundError{Name: name}
+	case 1:
+		return s.Get(ctx, ids[0], options)
+	default:
+		return nil, AmbiguousOrderError{Name: name, OrderIds: ids}
+	}
+}
@@ -0,0 +1,178 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderSearchQuery(t *testing.T) {
+	q := OrderSearchQuery{
+		Status:                OrderStatusAny,
+		FinancialStatus:       OrderFinancialStatusPaid,
+		FulfillmentStatus:     OrderFulfillmentStatusAny,
+		Tags:                  []string{"vip", "gift wrap"},
+		ExcludeTags:           []string{"test"},
+		CreatedAtMin:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		FulfillmentLocationId: 123,
+	}
+
+	expected := `financial_status:paid AND tag:vip AND tag:"gift wrap" AND -tag:test AND ` +
+		`created_at:>='2024-01-01T00:00:00Z' AND fulfillment_location_id:123`
+	if q.String() != expected {
+		t.Errorf("OrderSearchQuery.String returned %s, expected %s", q.String(), expected)
+	}
+}
+
+func TestOrderSearch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+
+		return `{"data":{"orders":{"nodes":[{
+			"legacyResourceId":"450789469",
+			"name":"#1001",
+			"email":"bob@example.com",
+			"tags":["vip","gift"],
+			"createdAt":"2024-01-02T03:04:05Z",
+			"displayFinancialStatus":"PARTIALLY_REFUNDED",
+			"displayFulfillmentStatus":"PARTIALLY_FULFILLED",
+			"currencyCode":"USD",
+			"totalPriceSet":{"shopMoney":{"amount":"12.50"}}
+		}],"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`
+	})
+
+	orders, pageInfo, err := client.Order.Search(context.Background(), "tag:vip", &OrderSearchOptions{After: "xyz", SortKey: "CREATED_AT"})
+	if err != nil {
+		t.Fatalf("Order.Search returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"first":   float64(50),
+		"query":   "tag:vip",
+		"reverse": false,
+		"after":   "xyz",
+		"sortKey": "CREATED_AT",
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Order.Search sent variables %v, expected %v", vars, expectedVars)
+	}
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	price := decimal.NewFromFloat(12.5)
+	expected := []Order{{
+		Id:                450789469,
+		Name:              "#1001",
+		Email:             "bob@example.com",
+		Tags:              "vip, gift",
+		CreatedAt:         &createdAt,
+		FinancialStatus:   OrderFinancialStatusPartiallyRefunded,
+		FulfillmentStatus: OrderFulfillmentStatusPartial,
+		Currency:          "USD",
+		TotalPrice:        &price,
+	}}
+	if len(orders) != 1 || !orders[0].TotalPrice.Equal(price) || !orders[0].CreatedAt.Equal(createdAt) {
+		t.Fatalf("Order.Search returned %+v, expected %+v", orders, expected)
+	}
+	orders[0].TotalPrice, orders[0].CreatedAt = &price, &createdAt
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.Search returned %+v, expected %+v", orders, expected)
+	}
+
+	expectedPageInfo := &GraphQLPageInfo{HasNextPage: true, EndCursor: "abc"}
+	if !reflect.DeepEqual(pageInfo, expectedPageInfo) {
+		t.Errorf("Order.Search returned page info %+v, expected %+v", pageInfo, expectedPageInfo)
+	}
+}
+
+func TestOrderGetByName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		name     string
+		nodes    string
+		expected *Order
+		err      error
+	}{
+		{
+			"#1001",
+			`[{"legacyResourceId":"1","name":"#1001"},{"legacyResourceId":"2","name":"#10012"}]`,
+			&Order{Id: 1},
+			nil,
+		},
+		{
+			"#1002",
+			`[{"legacyResourceId":"2","name":"#10012"}]`,
+			nil,
+			OrderNotFoundError{Name: "#1002"},
+		},
+		{
+			"#1003",
+			`[{"legacyResourceId":"3","name":"#1003"},{"legacyResourceId":"4","name":"#1003"}]`,
+			nil,
+			AmbiguousOrderError{Name: "#1003", OrderIds: []uint64{3, 4}},
+		},
+	}
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1}}`))
+
+	for _, c := range cases {
+		var query interface{}
+		registerGraphQL(t, nil, func(req graphQLRequest) string {
+			query = req.Variables["query"]
+			return `{"data":{"orders":{"nodes":` + c.nodes + `}}}`
+		})
+
+		order, err := client.Order.GetByName(context.Background(), c.name, nil)
+		if !reflect.DeepEqual(order, c.expected) {
+			t.Errorf("Order.GetByName(%s) returned %+v, expected %+v", c.name, order, c.expected)
+		}
+		if !reflect.DeepEqual(err, c.err) {
+			t.Errorf("Order.GetByName(%s) err returned %v, expected %v", c.name, err, c.err)
+		}
+
+		expectedQuery := `name:"` + c.name + `"`
+		if query != expectedQuery {
+			t.Errorf("Order.GetByName(%s) searched %v, expected %s", c.name, query, expectedQuery)
+		}
+	}
+}
+
+func TestOrderGetByNamePaginates(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var afters []interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		afters = append(afters, req.Variables["after"])
+		if req.Variables["after"] == nil {
+			return `{"data":{"orders":{"nodes":[{"legacyResourceId":"2","name":"#10012"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}`
+		}
+		return `{"data":{"orders":{"nodes":[{"legacyResourceId":"1","name":"#1001"}],"pageInfo":{"hasNextPage":false}}}}`
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1}}`))
+
+	order, err := client.Order.GetByName(context.Background(), "#1001", nil)
+	if err != nil {
+		t.Fatalf("Order.GetByName returned error: %v", err)
+	}
+	if order.Id != 1 {
+		t.Errorf("Order.GetByName returned order %d, expected 1", order.Id)
+	}
+
+	expectedAfters := []interface{}{nil, "c1"}
+	if !reflect.DeepEqual(afters, expectedAfters) {
+		t.Errorf("Order.GetByName searched after %v, expected %v", afters, expectedAfters)
+	}
+}
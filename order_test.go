@@ -2,6 +2,7 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -409,6 +410,189 @@ func TestOrderGet(t *testing.T) {
 	orderTests(t, *order)
 }
 
+func TestOrderGetMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(404, `{"errors":"Not Found"}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/3.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":3}}`))
+
+	orders, err := client.Order.GetMany(context.Background(), []uint64{3, 2, 1}, nil)
+
+	expected := []Order{{Id: 3}, {Id: 1}}
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.GetMany returned %+v, expected %+v", orders, expected)
+	}
+
+	batchErr, ok := err.(BatchErrors)
+	if !ok || len(batchErr) != 1 || batchErr[2] == nil {
+		t.Errorf("Order.GetMany err returned %v, expected an error for order 2", err)
+	}
+}
+
+func TestOrderGetWithDuties(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order_with_duties.json")))
+
+	order, err := client.Order.Get(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	shopDuties := decimal.NewFromFloat(105.31)
+	if order.CurrentTotalDutiesSet == nil || !order.CurrentTotalDutiesSet.ShopMoney.Amount.Equal(shopDuties) {
+		t.Errorf("Order.CurrentTotalDutiesSet returned %+v, expected shop money %s", order.CurrentTotalDutiesSet, shopDuties)
+	}
+	if order.OriginalTotalDutiesSet == nil || order.OriginalTotalDutiesSet.PresentmentMoney.CurrencyCode != "USD" {
+		t.Errorf("Order.OriginalTotalDutiesSet returned %+v, expected presentment currency USD", order.OriginalTotalDutiesSet)
+	}
+
+	if len(order.LineItems) != 1 || len(order.LineItems[0].Duties) != 1 {
+		t.Fatalf("Order.LineItems returned %+v, expected a line item with one duty", order.LineItems)
+	}
+
+	duty := order.LineItems[0].Duties[0]
+	if duty.Id != "2" || duty.HarmonizedSystemCode != "520300" || duty.CountryCodeOfOrigin != "CA" ||
+		duty.AdminGraphqlApiId != "gid://shopify/Duty/2" {
+		t.Errorf("LineItem.Duties returned %+v", duty)
+	}
+	if duty.ShopMoney == nil || !duty.ShopMoney.Amount.Equal(decimal.NewFromFloat(164.86)) {
+		t.Errorf("Duty.ShopMoney returned %+v, expected 164.86", duty.ShopMoney)
+	}
+	if len(duty.TaxLines) != 1 || !duty.TaxLines[0].Rate.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("Duty.TaxLines returned %+v, expected one line with rate 0.1", duty.TaxLines)
+	}
+}
+
+func TestOrderGetWithAdditionalFees(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("order_with_additional_fees.json")))
+
+	order, err := client.Order.Get(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	fee := decimal.NewFromFloat(0.27)
+	if order.CurrentTotalAdditionalFeesSet == nil || !order.CurrentTotalAdditionalFeesSet.ShopMoney.Amount.Equal(fee) {
+		t.Errorf("Order.CurrentTotalAdditionalFeesSet returned %+v, expected %s", order.CurrentTotalAdditionalFeesSet, fee)
+	}
+	if order.OriginalTotalAdditionalFeesSet == nil || !order.OriginalTotalAdditionalFeesSet.PresentmentMoney.Amount.Equal(fee) {
+		t.Errorf("Order.OriginalTotalAdditionalFeesSet returned %+v, expected %s", order.OriginalTotalAdditionalFeesSet, fee)
+	}
+
+	if len(order.AdditionalFees) != 1 {
+		t.Fatalf("Order.AdditionalFees returned %+v, expected one fee", order.AdditionalFees)
+	}
+	additionalFee := order.AdditionalFees[0]
+	if additionalFee.Id != 1001 || additionalFee.Name != "Colorado Retail Delivery Fee" ||
+		additionalFee.PriceSet == nil || !additionalFee.PriceSet.ShopMoney.Amount.Equal(fee) {
+		t.Errorf("Order.AdditionalFees returned %+v", additionalFee)
+	}
+}
+
+func TestOrderGetIdentityFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"confirmation_number":"XPAV284CT","po_number":"PO-42","tax_exempt":true,"estimated_taxes":true}}`))
+
+	order, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	expected := &Order{Id: 1, ConfirmationNumber: "XPAV284CT", PoNumber: "PO-42", TaxExempt: true, EstimatedTaxes: true}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Order.Get returned %+v, expected %+v", order, expected)
+	}
+}
+
+func TestOrderGetTaxLines(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"tax_lines":[
+			{"title":"State Tax","price":"1.50","rate":0.06,"channel_liable":true,
+			 "price_set":{"shop_money":{"amount":"1.50","currency_code":"USD"},"presentment_money":{"amount":"2.01","currency_code":"CAD"}}},
+			{"title":"City Tax","price":"0.25","rate":0.01}
+		]}}`))
+
+	order, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	if len(order.TaxLines) != 2 {
+		t.Fatalf("Order.TaxLines returned %+v, expected 2 tax lines", order.TaxLines)
+	}
+
+	marketplace := order.TaxLines[0]
+	if !marketplace.ChannelLiable || marketplace.MerchantLiable() {
+		t.Errorf("TaxLine %+v should be channel liable", marketplace)
+	}
+	if marketplace.PriceSet == nil || marketplace.PriceSet.PresentmentMoney.CurrencyCode != "CAD" ||
+		!marketplace.PriceSet.PresentmentMoney.Amount.Equal(decimal.NewFromFloat(2.01)) {
+		t.Errorf("TaxLine.PriceSet returned %+v, expected 2.01 CAD presentment money", marketplace.PriceSet)
+	}
+
+	if !order.TaxLines[1].MerchantLiable() {
+		t.Errorf("TaxLine %+v should be merchant liable", order.TaxLines[1])
+	}
+}
+
+func TestOrderGetAttributedStaffs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"line_items":[{"id":2,"quantity":3,
+			"attributed_staffs":[{"id":"gid://shopify/StaffMember/902541635","quantity":2},{"id":"gid://shopify/StaffMember/902541636","quantity":1}]}]}}`))
+
+	order, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	expected := []AttributedStaff{
+		{Id: "gid://shopify/StaffMember/902541635", Quantity: 2},
+		{Id: "gid://shopify/StaffMember/902541636", Quantity: 1},
+	}
+	if len(order.LineItems) != 1 || !reflect.DeepEqual(order.LineItems[0].AttributedStaffs, expected) {
+		t.Errorf("LineItem.AttributedStaffs returned %+v, expected %+v", order.LineItems, expected)
+	}
+}
+
+func TestOrderGetCompany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"order":{"id":1,"company":{"id":2,"location_id":3,"contact_id":4}}}`))
+
+	order, err := client.Order.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Order.Get returned error: %v", err)
+	}
+
+	expected := &OrderCompany{Id: 2, LocationId: 3, ContactId: 4}
+	if !reflect.DeepEqual(order.Company, expected) {
+		t.Errorf("Order.Company returned %+v, expected %+v", order.Company, expected)
+	}
+}
+
 func TestOrderGetWithTransactions(t *testing.T) {
 	setup()
 	defer teardown()
@@ -889,6 +1073,49 @@ func TestOrderDelete(t *testing.T) {
 }
 
 // TestLineItemUnmarshalJSON tests unmarsalling a LineItem from json
+func TestOrderPresentmentMoney(t *testing.T) {
+	data := `{
+		"currency": "USD",
+		"presentment_currency": "EUR",
+		"subtotal_price_set": {"shop_money": {"amount": "20.00", "currency_code": "USD"}, "presentment_money": {"amount": "18.00", "currency_code": "EUR"}},
+		"total_discounts_set": {"shop_money": {"amount": "2.00", "currency_code": "USD"}, "presentment_money": {"amount": "1.80", "currency_code": "EUR"}},
+		"line_items": [{
+			"price_set": {"shop_money": {"amount": "10.00", "currency_code": "USD"}, "presentment_money": {"amount": "9.00", "currency_code": "EUR"}}
+		}]
+	}`
+
+	order := Order{}
+	err := json.Unmarshal([]byte(data), &order)
+	if err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		actual   *decimal.Decimal
+		expected *decimal.Decimal
+	}{
+		{"SubtotalPriceSet.In(EUR)", order.SubtotalPriceSet.In(order.PresentmentCurrency), decimalPtr(18)},
+		{"SubtotalPriceSet.In(usd)", order.SubtotalPriceSet.In("usd"), decimalPtr(20)},
+		{"SubtotalPriceSet.In(GBP)", order.SubtotalPriceSet.In("GBP"), nil},
+		{"TotalDiscountsSet.Presentment", order.TotalDiscountsSet.Presentment(), decimalPtr(1.8)},
+		{"TotalDiscountsSet.Shop", order.TotalDiscountsSet.Shop(), decimalPtr(2)},
+		{"LineItems[0].PriceSet.In(EUR)", order.LineItems[0].PriceSet.In("EUR"), decimalPtr(9)},
+		{"TotalTaxSet.In(EUR)", order.TotalTaxSet.In("EUR"), nil},
+	}
+
+	for _, c := range cases {
+		if (c.actual == nil) != (c.expected == nil) || (c.actual != nil && !c.actual.Equal(*c.expected)) {
+			t.Errorf("Order.%s returned %v, expected %v", c.name, c.actual, c.expected)
+		}
+	}
+}
+
+func decimalPtr(f float64) *decimal.Decimal {
+	d := decimal.NewFromFloat(f)
+	return &d
+}
+
 func TestLineItemUnmarshalJSON(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,57 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var defaultPageRetryBackoff = ExponentialBackoff(time.Second, 30*time.Second)
+
+// pageRetry retries failed pages of paginated lists, see WithPaginationRetry
+type pageRetry struct {
+	attempts int
+	backoff  Backoff
+}
+
+// wait returns how long to wait before retrying a page failing with err for
+// the given attempt, or false if the error is not worth retrying, see
+// IsRetryableError
+func (r *pageRetry) wait(attempt int, err error) (time.Duration, bool) {
+	if attempt >= r.attempts || !IsRetryableError(err) {
+		return 0, false
+	}
+
+	wait := r.backoff(attempt)
+	var rateLimitErr RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if retryAfter := time.Duration(rateLimitErr.RetryAfter) * time.Second; retryAfter > wait {
+			wait = retryAfter
+		}
+	}
+	return wait, true
+}
+
+// listPage fetches a page of a paginated list, retrying it with the same
+// options, and so the same cursor, if it fails and page retries are enabled
+func (c *Client) listPage(ctx context.Context, path string, resource, options interface{}) (http.Header, error) {
+	for attempt := 1; ; attempt++ {
+		headers, err := c.createAndDoGetHeaders(ctx, "GET", path, nil, options, resource)
+		if err == nil || c.pageRetry == nil || ctx.Err() != nil {
+			return headers, err
+		}
+
+		wait, ok := c.pageRetry.wait(attempt, err)
+		if !ok {
+			return headers, err
+		}
+
+		c.log.Debugf("retrying page of %s in %s after %v", path, wait.String(), err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+	}
+}
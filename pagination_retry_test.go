@@ -0,0 +1,111 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestPageRetryWait(t *testing.T) {
+	retry := &pageRetry{attempts: 3, backoff: ConstantBackoff(time.Second)}
+
+	cases := []struct {
+		attempt  int
+		err      error
+		expected time.Duration
+		retry    bool
+	}{
+		{1, ResponseError{Status: http.StatusInternalServerError}, time.Second, true},
+		{1, RateLimitError{RetryAfter: 4}, 4 * time.Second, true},
+		{1, fmt.Errorf("list orders: %w", RateLimitError{RetryAfter: 4}), 4 * time.Second, true},
+		{1, &url.Error{Op: "Get", URL: "https://fooshop.myshopify.com", Err: errors.New("connection reset by peer")}, time.Second, true},
+		{1, ErrCircuitOpen, time.Second, true},
+		{1, ResponseError{Status: http.StatusNotFound}, 0, false},
+		{1, errors.New("unknown"), 0, false},
+		{1, context.Canceled, 0, false},
+		{3, ResponseError{Status: http.StatusInternalServerError}, 0, false},
+	}
+
+	for i, c := range cases {
+		wait, ok := retry.wait(c.attempt, c.err)
+		if wait != c.expected || ok != c.retry {
+			t.Errorf("case %d: pageRetry.wait returned %s, %v, expected %s, %v", i, wait, ok, c.expected, c.retry)
+		}
+	}
+}
+
+func TestOrderListAllPageRetry(t *testing.T) {
+	setup()
+	defer teardown()
+	WithPaginationRetry(3, ConstantBackoff(time.Millisecond))(client)
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL,
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"orders": [{"id":1},{"id":2}]}`)
+			resp.Header.Set("Link", `<http://valid.url?page_info=pg2>; rel="next"`)
+			return resp, nil
+		})
+
+	secondPageCalls := 0
+	httpmock.RegisterResponder("GET", listURL+"?page_info=pg2",
+		func(req *http.Request) (*http.Response, error) {
+			secondPageCalls++
+			if secondPageCalls < 3 {
+				return httpmock.NewStringResponse(500, `{"errors": "Internal Server Error"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"orders": [{"id":3}]}`), nil
+		})
+
+	orders, err := client.Order.ListAll(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Order.ListAll returned error: %v", err)
+	}
+
+	expected := []Order{{Id: 1}, {Id: 2}, {Id: 3}}
+	if !reflect.DeepEqual(orders, expected) || secondPageCalls != 3 {
+		t.Errorf("Order.ListAll returned %+v after %d calls of the second page, expected %+v after 3", orders, secondPageCalls, expected)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if info["GET "+listURL] != 1 {
+		t.Errorf("Order.ListAll fetched the first page %d times, expected once", info["GET "+listURL])
+	}
+}
+
+func TestOrderListAllPageRetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+	WithPaginationRetry(2, ConstantBackoff(time.Millisecond))(client)
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, httpmock.NewStringResponder(500, `{"errors": "Internal Server Error"}`))
+
+	_, err := client.Order.ListAll(context.Background(), nil)
+	if respErr, ok := err.(ResponseError); !ok || respErr.Status != 500 {
+		t.Errorf("Order.ListAll returned %v, expected a 500 ResponseError", err)
+	}
+	if calls := httpmock.GetTotalCallCount(); calls != 2 {
+		t.Errorf("Order.ListAll made %d calls, expected 2", calls)
+	}
+}
+
+func TestOrderListAllWithoutPageRetry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix)
+	httpmock.RegisterResponder("GET", listURL, httpmock.NewStringResponder(500, `{"errors": "Internal Server Error"}`))
+
+	_, err := client.Order.ListAll(context.Background(), nil)
+	if err == nil || httpmock.GetTotalCallCount() != 1 {
+		t.Errorf("Order.ListAll returned %v after %d calls, expected an error after 1", err, httpmock.GetTotalCallCount())
+	}
+}
@@ -34,7 +34,7 @@ type PaymentsTransactionsListOptions struct {
 	PayoutStatus PayoutStatus `url:"payout_status,omitempty"`
 	DateMin      *OnlyDate    `url:"date_min,omitempty"`
 	DateMax      *OnlyDate    `url:"date_max,omitempty"`
-	ProcessedAt  *OnlyDate    `json:"processed_at,omitempty"`
+	ProcessedAt  *OnlyDate    `url:"processed_at,omitempty"`
 }
 
 // PaymentsTransactions represents a Shopify Transactions
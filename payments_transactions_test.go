@@ -421,3 +421,22 @@ func TestPaymentsTransactionsGet(t *testing.T) {
 		t.Errorf("PaymentsTransactions.Get returned %+v, expected %+v", paymentsTransactions, expected)
 	}
 }
+
+func TestPaymentsTransactionsListProcessedAt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// OnlyDate is sent quoted, which Shopify accepts
+	params := map[string]string{"processed_at": `"2022-02-03"`}
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/balance/transactions.json", client.pathPrefix),
+		params, httpmock.NewStringResponder(200, `{"transactions": [{"id":1}]}`))
+
+	date := OnlyDate{time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)}
+	transactions, err := client.PaymentsTransactions.List(context.Background(), PaymentsTransactionsListOptions{ProcessedAt: &date})
+	if err != nil {
+		t.Fatalf("PaymentsTransactions.List returned error: %v", err)
+	}
+	if len(transactions) != 1 || transactions[0].Id != 1 {
+		t.Errorf("PaymentsTransactions.List returned %+v", transactions)
+	}
+}
@@ -0,0 +1,181 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// number of orders fetched in one request while reconciling a payout
+const payoutReconciliationOrderBatch = 250
+
+// PayoutReconciliation is the report of a payout: its balance transactions
+// grouped by order with their fees and net amounts
+type PayoutReconciliation struct {
+	Payout Payout
+	// Orders are the orders of the payout ordered by id
+	Orders []PayoutOrderReconciliation
+	// Other are the balance transactions not tied to an order, e.g. reserves
+	// and adjustments, without the payout transaction itself
+	Other []PaymentsTransactions
+
+	Gross decimal.Decimal
+	Fees  decimal.Decimal
+	Net   decimal.Decimal
+	// Discrepancy is the payout amount less the net of its transactions,
+	// zero when the payout reconciles
+	Discrepancy decimal.Decimal
+}
+
+// PayoutOrderReconciliation are the balance transactions of an order in a
+// payout
+type PayoutOrderReconciliation struct {
+	OrderId uint64
+	// Order is nil when the order couldn't be fetched, e.g. it was deleted
+	Order        *Order
+	Transactions []PaymentsTransactions
+
+	Charges     decimal.Decimal
+	Refunds     decimal.Decimal
+	Disputes    decimal.Decimal
+	Adjustments decimal.Decimal
+	Fees        decimal.Decimal
+	Net         decimal.Decimal
+}
+
+// Refund returns the refund of the order a balance transaction was created
+// for, or nil
+func (r *PayoutOrderReconciliation) Refund(transaction PaymentsTransactions) *Refund {
+	if r.Order == nil || transaction.Type != PaymentsTransactionsRefund {
+		return nil
+	}
+	for i := range r.Order.Refunds {
+		if r.Order.Refunds[i].Id == uint64(transaction.SourceId) {
+			return &r.Order.Refunds[i]
+		}
+	}
+	return nil
+}
+
+// Reconciles reports whether the payout amount matches the net of its
+// transactions
+func (r *PayoutReconciliation) Reconciles() bool {
+	return r.Discrepancy.IsZero()
+}
+
+func (r *PayoutOrderReconciliation) add(t PaymentsTransactions, amount, fee, net decimal.Decimal) {
+	r.Transactions = append(r.Transactions, t)
+	switch t.Type {
+	case PaymentsTransactionsCharge:
+		r.Charges = r.Charges.Add(amount)
+	case PaymentsTransactionsRefund:
+		r.Refunds = r.Refunds.Add(amount)
+	case PaymentsTransactionsDispute:
+		r.Disputes = r.Disputes.Add(amount)
+	default:
+		r.Adjustments = r.Adjustments.Add(amount)
+	}
+	r.Fees = r.Fees.Add(fee)
+	r.Net = r.Net.Add(net)
+}
+
+func parsePaymentsAmount(t PaymentsTransactions, field, value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return d, fmt.Errorf("balance transaction %d: invalid %s %q: %v", t.Id, field, value, err)
+	}
+	return d, nil
+}
+
+// Reconcile fetches a payout, its balance transactions and the orders with
+// refunds they belong to and computes the fees and net amounts per order.
+func (s *PayoutsServiceOp) Reconcile(ctx context.Context, payoutId uint64) (*PayoutReconciliation, error) {
+	payout, err := s.Get(ctx, payoutId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.client.PaymentsTransactions.ListAll(ctx, PaymentsTransactionsListOptions{
+		PayoutId: payoutId,
+		Limit:    250,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PayoutReconciliation{Payout: *payout}
+	byOrder := map[uint64]*PayoutOrderReconciliation{}
+	for _, t := range transactions {
+		if t.Type == PaymentsTransactionsPayout {
+			continue
+		}
+
+		amount, err := parsePaymentsAmount(t, "amount", t.Amount)
+		if err != nil {
+			return nil, err
+		}
+		fee, err := parsePaymentsAmount(t, "fee", t.Fee)
+		if err != nil {
+			return nil, err
+		}
+		net, err := parsePaymentsAmount(t, "net", t.Net)
+		if err != nil {
+			return nil, err
+		}
+		report.Gross = report.Gross.Add(amount)
+		report.Fees = report.Fees.Add(fee)
+		report.Net = report.Net.Add(net)
+
+		if t.SourceOrderId == 0 {
+			report.Other = append(report.Other, t)
+			continue
+		}
+		orderId := uint64(t.SourceOrderId)
+		order, ok := byOrder[orderId]
+		if !ok {
+			order = &PayoutOrderReconciliation{OrderId: orderId}
+			byOrder[orderId] = order
+		}
+		order.add(t, amount, fee, net)
+	}
+	report.Discrepancy = payout.Amount.Sub(report.Net)
+
+	ids := make([]uint64, 0, len(byOrder))
+	for id := range byOrder {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for start := 0; start < len(ids); start += payoutReconciliationOrderBatch {
+		end := start + payoutReconciliationOrderBatch
+		if end > len(ids) {
+			end = len(ids)
+		}
+		orders, err := s.client.Order.List(ctx, OrderListOptions{
+			ListOptions: ListOptions{
+				Ids:    ids[start:end],
+				Limit:  payoutReconciliationOrderBatch,
+				Fields: "id,name,currency,total_price,financial_status,created_at,refunds",
+			},
+			Status: OrderStatusAny,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range orders {
+			if r, ok := byOrder[orders[i].Id]; ok {
+				r.Order = &orders[i]
+			}
+		}
+	}
+
+	for _, id := range ids {
+		report.Orders = append(report.Orders, *byOrder[id])
+	}
+	return report, nil
+}
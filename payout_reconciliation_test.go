@@ -0,0 +1,111 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestPayoutsReconcile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts/623721858.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"payout":{"id":623721858,"date":"2024-03-01","currency":"USD","amount":"125.20","status":"paid"}}`))
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/balance/transactions.json", client.pathPrefix),
+		map[string]string{"payout_id": "623721858", "limit": "250"},
+		httpmock.NewStringResponder(200, `{"transactions":[
+			{"id":1,"type":"charge","payout_id":623721858,"currency":"USD","amount":"100.00","fee":"3.20","net":"96.80","source_id":11,"source_type":"charge","source_order_id":1001},
+			{"id":2,"type":"refund","payout_id":623721858,"currency":"USD","amount":"-20.00","fee":"0.00","net":"-20.00","source_id":21,"source_type":"Refund","source_order_id":1001},
+			{"id":3,"type":"charge","payout_id":623721858,"currency":"USD","amount":"50.00","fee":"1.60","net":"48.40","source_id":12,"source_type":"charge","source_order_id":1002},
+			{"id":4,"type":"adjustment","payout_id":623721858,"currency":"USD","amount":"0.00","fee":"0.00","net":"0.00","source_id":31,"source_type":"adjustment"},
+			{"id":5,"type":"payout","payout_id":623721858,"currency":"USD","amount":"-125.20","fee":"0.00","net":"-125.20","source_id":623721858,"source_type":"payout"}
+		]}`))
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix),
+		map[string]string{
+			"ids":    "1001,1002",
+			"limit":  "250",
+			"fields": "id,name,currency,total_price,financial_status,created_at,refunds",
+			"status": "any",
+		},
+		httpmock.NewStringResponder(200, `{"orders":[
+			{"id":1001,"name":"#1001","refunds":[{"id":21,"order_id":1001}]},
+			{"id":1002,"name":"#1002"}
+		]}`))
+
+	report, err := client.Payouts.Reconcile(context.Background(), 623721858)
+	if err != nil {
+		t.Fatalf("Payouts.Reconcile returned error: %v", err)
+	}
+
+	if !report.Reconciles() || !report.Net.Equal(decimal.RequireFromString("125.20")) {
+		t.Errorf("Payouts.Reconcile returned net %s and discrepancy %s", report.Net, report.Discrepancy)
+	}
+	if !report.Gross.Equal(decimal.NewFromInt(130)) || !report.Fees.Equal(decimal.RequireFromString("4.80")) {
+		t.Errorf("Payouts.Reconcile returned gross %s and fees %s", report.Gross, report.Fees)
+	}
+	if len(report.Other) != 1 || report.Other[0].Id != 4 {
+		t.Errorf("Payouts.Reconcile returned other transactions %+v", report.Other)
+	}
+	if len(report.Orders) != 2 {
+		t.Fatalf("Payouts.Reconcile returned %d orders, expected 2", len(report.Orders))
+	}
+
+	first := report.Orders[0]
+	if first.OrderId != 1001 || first.Order == nil || first.Order.Name != "#1001" || len(first.Transactions) != 2 {
+		t.Fatalf("Payouts.Reconcile returned %+v", first)
+	}
+	if !first.Charges.Equal(decimal.NewFromInt(100)) || !first.Refunds.Equal(decimal.NewFromInt(-20)) ||
+		!first.Fees.Equal(decimal.RequireFromString("3.20")) || !first.Net.Equal(decimal.RequireFromString("76.80")) {
+		t.Errorf("Payouts.Reconcile returned %+v for order 1001", first)
+	}
+	if refund := first.Refund(first.Transactions[1]); refund == nil || refund.Id != 21 {
+		t.Errorf("PayoutOrderReconciliation.Refund returned %+v, expected refund 21", refund)
+	}
+	if refund := first.Refund(first.Transactions[0]); refund != nil {
+		t.Errorf("PayoutOrderReconciliation.Refund returned %+v for a charge", refund)
+	}
+	if second := report.Orders[1]; second.OrderId != 1002 || !second.Net.Equal(decimal.RequireFromString("48.40")) {
+		t.Errorf("Payouts.Reconcile returned %+v for order 1002", second)
+	}
+}
+
+func TestPayoutsReconcileDiscrepancy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"payout":{"id":1,"date":"2024-03-01","currency":"USD","amount":"10.00","status":"paid"}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/balance/transactions.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"transactions":[
+			{"id":1,"type":"reserve","amount":"-2.50","fee":"0.00","net":"-2.50"}
+		]}`))
+
+	report, err := client.Payouts.Reconcile(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Payouts.Reconcile returned error: %v", err)
+	}
+	if report.Reconciles() || !report.Discrepancy.Equal(decimal.RequireFromString("12.50")) {
+		t.Errorf("Payouts.Reconcile returned discrepancy %s, expected 12.50", report.Discrepancy)
+	}
+	if len(report.Orders) != 0 {
+		t.Errorf("Payouts.Reconcile returned orders %+v without order transactions", report.Orders)
+	}
+}
+
+func TestPayoutsReconcileInvalidAmount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/payouts/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"payout":{"id":1,"date":"2024-03-01","amount":"10.00"}}`))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shopify_payments/balance/transactions.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"transactions":[{"id":7,"type":"charge","amount":"ten","source_order_id":1}]}`))
+
+	if _, err := client.Payouts.Reconcile(context.Background(), 1); err == nil {
+		t.Error("Payouts.Reconcile accepted an invalid amount")
+	}
+}
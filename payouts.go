@@ -17,6 +17,7 @@ type PayoutsService interface {
 	ListAll(context.Context, interface{}) ([]Payout, error)
 	ListWithPagination(context.Context, interface{}) ([]Payout, *Pagination, error)
 	Get(context.Context, uint64, interface{}) (*Payout, error)
+	Reconcile(context.Context, uint64) (*PayoutReconciliation, error)
 }
 
 // PayoutsServiceOp handles communication with the payout related methods of the
@@ -0,0 +1,150 @@
+package goshopify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RequestPriority tags requests so that background work yields to user facing
+// requests when the shop's rate limit is under pressure, see
+// WithPriorityQueue.
+type RequestPriority int
+
+const (
+	// PriorityInteractive is the default priority, used for user facing
+	// requests.
+	PriorityInteractive RequestPriority = iota
+
+	// PriorityBatch is used for background work such as syncs and backfills.
+	PriorityBatch
+)
+
+const (
+	// REST call limit bucket leak rate per second
+	restLeakRate = 2.0
+
+	priorityPollInterval = 100 * time.Millisecond
+)
+
+type priorityContextKey struct{}
+
+// WithRequestPriority returns a context tagging every request made with it
+// with the given priority.
+func WithRequestPriority(ctx context.Context, priority RequestPriority) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, priority)
+}
+
+// RequestPriorityFromContext returns the priority of the context, defaulting
+// to PriorityInteractive.
+func RequestPriorityFromContext(ctx context.Context) RequestPriority {
+	if p, ok := ctx.Value(priorityContextKey{}).(RequestPriority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// priorityQueue delays requests while the shop is rate limited. Batch requests
+// additionally wait while interactive requests are waiting and while the
+// estimated bucket usage leaves less than reserve calls available.
+type priorityQueue struct {
+	mu           sync.Mutex
+	reserve      int
+	used         float64
+	size         int
+	observedAt   time.Time
+	blockedUntil time.Time
+	interactive  int // number of waiting interactive requests
+
+	// overridden in tests
+	now func() time.Time
+}
+
+func newPriorityQueue(reserve int) *priorityQueue {
+	return &priorityQueue{reserve: reserve, now: time.Now}
+}
+
+// delay returns how long a request with the given priority must wait before
+// being sent, must be called with the lock held.
+func (q *priorityQueue) delay(priority RequestPriority) time.Duration {
+	now := q.now()
+	if now.Before(q.blockedUntil) {
+		return q.blockedUntil.Sub(now)
+	}
+
+	if priority == PriorityInteractive {
+		return 0
+	}
+
+	if q.interactive > 0 {
+		return priorityPollInterval
+	}
+
+	if q.size > 0 {
+		used := q.used - restLeakRate*now.Sub(q.observedAt).Seconds()
+		excess := used - float64(q.size-q.reserve) + 1
+		if excess > 0 {
+			return time.Duration(excess / restLeakRate * float64(time.Second))
+		}
+	}
+
+	return 0
+}
+
+// wait blocks until a request with the priority of ctx may be sent
+func (q *priorityQueue) wait(ctx context.Context) error {
+	priority := RequestPriorityFromContext(ctx)
+
+	q.mu.Lock()
+	registered := false
+	defer func() {
+		if registered {
+			q.interactive--
+		}
+		q.mu.Unlock()
+	}()
+
+	for {
+		d := q.delay(priority)
+		if d <= 0 {
+			return nil
+		}
+
+		if priority == PriorityInteractive && !registered {
+			q.interactive++
+			registered = true
+		}
+
+		if d > priorityPollInterval {
+			d = priorityPollInterval
+		}
+
+		q.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			q.mu.Lock()
+			return ctx.Err()
+		case <-time.After(d):
+		}
+		q.mu.Lock()
+	}
+}
+
+// observeCallLimit records the bucket usage reported by Shopify
+func (q *priorityQueue) observeCallLimit(used, size int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.used = float64(used)
+	q.size = size
+	q.observedAt = q.now()
+}
+
+// block delays all requests for the given duration, e.g. after a 429
+func (q *priorityQueue) block(d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	until := q.now().Add(d)
+	if until.After(q.blockedUntil) {
+		q.blockedUntil = until
+	}
+}
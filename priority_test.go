@@ -0,0 +1,105 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestRequestPriorityFromContext(t *testing.T) {
+	ctx := context.Background()
+	if p := RequestPriorityFromContext(ctx); p != PriorityInteractive {
+		t.Errorf("RequestPriorityFromContext = %d, expected %d", p, PriorityInteractive)
+	}
+
+	ctx = WithRequestPriority(ctx, PriorityBatch)
+	if p := RequestPriorityFromContext(ctx); p != PriorityBatch {
+		t.Errorf("RequestPriorityFromContext = %d, expected %d", p, PriorityBatch)
+	}
+}
+
+func TestPriorityQueueDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := newPriorityQueue(10)
+	q.now = func() time.Time { return now }
+
+	q.observeCallLimit(35, 40)
+	if d := q.delay(PriorityInteractive); d != 0 {
+		t.Errorf("priorityQueue.delay(interactive) = %s, expected 0", d)
+	}
+	// 6 calls above the 30 allowed for batch requests leak in 3 seconds
+	if d := q.delay(PriorityBatch); d != 3*time.Second {
+		t.Errorf("priorityQueue.delay(batch) = %s, expected 3s", d)
+	}
+
+	now = now.Add(3 * time.Second)
+	if d := q.delay(PriorityBatch); d != 0 {
+		t.Errorf("priorityQueue.delay(batch) after leaking = %s, expected 0", d)
+	}
+
+	q.interactive = 1
+	if d := q.delay(PriorityBatch); d != priorityPollInterval {
+		t.Errorf("priorityQueue.delay(batch) with interactive waiting = %s, expected %s", d, priorityPollInterval)
+	}
+	q.interactive = 0
+
+	q.block(2 * time.Second)
+	for _, p := range []RequestPriority{PriorityInteractive, PriorityBatch} {
+		if d := q.delay(p); d != 2*time.Second {
+			t.Errorf("priorityQueue.delay(%d) while blocked = %s, expected 2s", p, d)
+		}
+	}
+}
+
+func TestPriorityQueueWaitCancelled(t *testing.T) {
+	q := newPriorityQueue(0)
+	q.block(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := q.wait(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("priorityQueue.wait err returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if q.interactive != 0 {
+		t.Errorf("priorityQueue.wait left %d interactive waiters registered", q.interactive)
+	}
+}
+
+func TestWithPriorityQueue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithPriorityQueue(10)(client)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusOK, `{"shop":{"id":1}}`)
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "39/40")
+			return resp, nil
+		})
+
+	_, err := client.Shop.Get(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	// the bucket is nearly full, batch requests must yield
+	ctx, cancel := context.WithTimeout(WithRequestPriority(context.Background(), PriorityBatch), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Shop.Get(ctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Shop.Get with batch priority err returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	_, err = client.Shop.Get(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Shop.Get with interactive priority returned error: %v", err)
+	}
+}
@@ -27,6 +27,10 @@ type ProductService interface {
 	Create(context.Context, Product) (*Product, error)
 	Update(context.Context, Product) (*Product, error)
 	Delete(context.Context, uint64) error
+	WaitForUpdate(context.Context, uint64, time.Time, Backoff) (*Product, error)
+	Duplicate(context.Context, uint64, *ProductDuplicateOptions) (*Product, error)
+	SetStatus(context.Context, uint64, ProductStatus) (*Product, error)
+	SchedulePublish(context.Context, uint64, uint64, time.Time) error
 
 	// MetafieldsService used for Product resource to communicate with Metafields resource
 	MetafieldsService
@@ -80,6 +84,10 @@ type Product struct {
 	MetafieldsGlobalDescriptionTag string          `json:"metafields_global_description_tag,omitempty"`
 	Metafields                     []Metafield     `json:"metafields,omitempty"`
 	AdminGraphqlApiId              string          `json:"admin_graphql_api_id,omitempty"`
+
+	// Published is write only, false unpublishes the product from the
+	// Online Store
+	Published *bool `json:"published,omitempty"`
 }
 
 // The options provided by Shopify
@@ -174,6 +182,9 @@ func (s *ProductServiceOp) Count(ctx context.Context, options interface{}) (int,
 
 // Get individual product
 func (s *ProductServiceOp) Get(ctx context.Context, productId uint64, options interface{}) (*Product, error) {
+	if s.client.readsGraphQL(GraphQLReadProducts) {
+		return s.getGraphQL(ctx, productId)
+	}
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, productId)
 	resource := new(ProductResource)
 	err := s.client.Get(ctx, path, resource, options)
@@ -182,6 +193,10 @@ func (s *ProductServiceOp) Get(ctx context.Context, productId uint64, options in
 
 // Create a new product
 func (s *ProductServiceOp) Create(ctx context.Context, product Product) (*Product, error) {
+	if err := s.client.validateCreate("product", product); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", productsBasePath)
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
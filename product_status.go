@@ -0,0 +1,141 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const productDuplicateMutation = `mutation productDuplicate($productId: ID!, $newTitle: String!, $newStatus: ProductStatus, $includeImages: Boolean) {
+  productDuplicate(productId: $productId, newTitle: $newTitle, newStatus: $newStatus, includeImages: $includeImages) {
+    newProduct {
+      legacyResourceId
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const publishablePublishMutation = `mutation publishablePublish($id: ID!, $input: [PublicationInput!]!) {
+  publishablePublish(id: $id, input: $input) {
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// ProductDuplicateOptions are the options of duplicating a product
+type ProductDuplicateOptions struct {
+	// title of the copy, defaults to the title of the product with " (Copy)"
+	NewTitle string
+	// status of the copy, Shopify makes copies drafts by default
+	NewStatus ProductStatus
+	// copy the images of the product as well
+	IncludeImages bool
+}
+
+// Valid reports whether the status is one of the product statuses
+func (s ProductStatus) Valid() bool {
+	switch s {
+	case ProductStatusActive, ProductStatusArchived, ProductStatusDraft:
+		return true
+	}
+	return false
+}
+
+// Duplicate copies a product, with its variants and optionally its images,
+// through the GraphQL productDuplicate mutation and returns the copy.
+func (s *ProductServiceOp) Duplicate(ctx context.Context, productId uint64, options *ProductDuplicateOptions) (*Product, error) {
+	if options == nil {
+		options = &ProductDuplicateOptions{}
+	}
+
+	vars := map[string]interface{}{
+		"productId":     GraphQLId("Product", productId),
+		"newTitle":      options.NewTitle,
+		"includeImages": options.IncludeImages,
+	}
+	if options.NewTitle == "" {
+		original, err := s.Get(ctx, productId, struct {
+			Fields string `url:"fields"`
+		}{"title"})
+		if err != nil {
+			return nil, err
+		}
+		vars["newTitle"] = original.Title + " (Copy)"
+	}
+	if options.NewStatus != "" {
+		if !options.NewStatus.Valid() {
+			return nil, fmt.Errorf("invalid product status %q", options.NewStatus)
+		}
+		vars["newStatus"] = strings.ToUpper(string(options.NewStatus))
+	}
+
+	resp := struct {
+		ProductDuplicate struct {
+			NewProduct *struct {
+				LegacyResourceId string `json:"legacyResourceId"`
+			} `json:"newProduct"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"productDuplicate"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, productDuplicateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.ProductDuplicate.UserErrors) > 0 {
+		return nil, resp.ProductDuplicate.UserErrors
+	}
+	if resp.ProductDuplicate.NewProduct == nil {
+		return nil, fmt.Errorf("productDuplicate returned no product")
+	}
+
+	newId, err := strconv.ParseUint(resp.ProductDuplicate.NewProduct.LegacyResourceId, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, newId, nil)
+}
+
+// SetStatus changes the status of a product, e.g. to archive it or to
+// activate a draft
+func (s *ProductServiceOp) SetStatus(ctx context.Context, productId uint64, status ProductStatus) (*Product, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("invalid product status %q", status)
+	}
+	return s.Update(ctx, Product{Id: productId, Status: status})
+}
+
+// SchedulePublish publishes a product to a publication, e.g. the Online
+// Store, at the given time. A zero time publishes it right away.
+func (s *ProductServiceOp) SchedulePublish(ctx context.Context, productId uint64, publicationId uint64, at time.Time) error {
+	input := map[string]interface{}{"publicationId": GraphQLId("Publication", publicationId)}
+	if !at.IsZero() {
+		input["publishDate"] = at.UTC().Format(time.RFC3339)
+	}
+
+	resp := struct {
+		PublishablePublish struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"publishablePublish"`
+	}{}
+
+	vars := map[string]interface{}{
+		"id":    GraphQLId("Product", productId),
+		"input": []interface{}{input},
+	}
+	err := s.client.GraphQL.Query(ctx, publishablePublishMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.PublishablePublish.UserErrors) > 0 {
+		return resp.PublishablePublish.UserErrors
+	}
+	return nil
+}
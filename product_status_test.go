@@ -0,0 +1,168 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductDuplicate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"productDuplicate":{
+			"newProduct":{"legacyResourceId":"2"},
+			"userErrors":[]
+		}}}`
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"product":{"id":2,"title":"Summer shirt","status":"draft"}}`))
+
+	product, err := client.Product.Duplicate(context.Background(), 1, &ProductDuplicateOptions{
+		NewTitle:      "Summer shirt",
+		NewStatus:     ProductStatusDraft,
+		IncludeImages: true,
+	})
+	if err != nil {
+		t.Fatalf("Product.Duplicate returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"productId":     "gid://shopify/Product/1",
+		"newTitle":      "Summer shirt",
+		"newStatus":     "DRAFT",
+		"includeImages": true,
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Product.Duplicate sent variables %v, expected %v", vars, expectedVars)
+	}
+	if product.Id != 2 || product.Status != ProductStatusDraft {
+		t.Errorf("Product.Duplicate returned %+v", product)
+	}
+}
+
+func TestProductDuplicateDefaultTitle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponderWithQuery("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		map[string]string{"fields": "title"}, httpmock.NewStringResponder(200, `{"product":{"title":"Shirt"}}`))
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"productDuplicate":{
+			"newProduct":null,
+			"userErrors":[{"field":["productId"],"message":"Product does not exist"}]
+		}}}`
+	})
+
+	_, err := client.Product.Duplicate(context.Background(), 1, nil)
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("Product.Duplicate returned %v, expected user errors", err)
+	}
+	if vars["newTitle"] != "Shirt (Copy)" {
+		t.Errorf("Product.Duplicate sent title %v, expected Shirt (Copy)", vars["newTitle"])
+	}
+	if _, ok := vars["newStatus"]; ok {
+		t.Errorf("Product.Duplicate sent a status without one given: %v", vars)
+	}
+}
+
+func TestProductSetStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body []byte
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ = ioutil.ReadAll(req.Body)
+			return httpmock.NewStringResponse(200, `{"product":{"id":1,"status":"archived"}}`), nil
+		})
+
+	product, err := client.Product.SetStatus(context.Background(), 1, ProductStatusArchived)
+	if err != nil {
+		t.Fatalf("Product.SetStatus returned error: %v", err)
+	}
+	if product.Status != ProductStatusArchived {
+		t.Errorf("Product.SetStatus returned %+v", product)
+	}
+	sent := ProductResource{}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Product.Id != 1 || sent.Product.Status != ProductStatusArchived || sent.Product.Title != "" {
+		t.Errorf("Product.SetStatus sent %s", body)
+	}
+
+	if _, err := client.Product.SetStatus(context.Background(), 1, "deleted"); err == nil {
+		t.Error("Product.SetStatus accepted an invalid status")
+	}
+}
+
+func TestProductSchedulePublish(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"publishablePublish":{"userErrors":[]}}}`
+	})
+
+	at := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	err := client.Product.SchedulePublish(context.Background(), 1, 7, at)
+	if err != nil {
+		t.Fatalf("Product.SchedulePublish returned error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"id": "gid://shopify/Product/1",
+		"input": []interface{}{map[string]interface{}{
+			"publicationId": "gid://shopify/Publication/7",
+			"publishDate":   "2024-03-01T14:00:00Z",
+		}},
+	}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("Product.SchedulePublish sent variables %v, expected %v", vars, expected)
+	}
+
+	err = client.Product.SchedulePublish(context.Background(), 1, 7, time.Time{})
+	if err != nil {
+		t.Fatalf("Product.SchedulePublish returned error: %v", err)
+	}
+	if _, ok := vars["input"].([]interface{})[0].(map[string]interface{})["publishDate"]; ok {
+		t.Errorf("Product.SchedulePublish sent a publish date for a zero time: %v", vars)
+	}
+}
+
+func TestProductPublishedJSON(t *testing.T) {
+	published := false
+	data, err := json.Marshal(Product{Id: 1, Published: &published})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":1,"image":{},"published":false}` {
+		t.Errorf("Product marshalled to %s", data)
+	}
+}
+
+func TestProductStatusValid(t *testing.T) {
+	for _, status := range []ProductStatus{ProductStatusActive, ProductStatusArchived, ProductStatusDraft} {
+		if !status.Valid() {
+			t.Errorf("ProductStatus(%q).Valid() = false", status)
+		}
+	}
+	if ProductStatus("ACTIVE").Valid() {
+		t.Error(`ProductStatus("ACTIVE").Valid() = true`)
+	}
+}
@@ -66,6 +66,10 @@ func (s *RedirectServiceOp) Get(ctx context.Context, redirectId uint64, options
 
 // Create a new redirect
 func (s *RedirectServiceOp) Create(ctx context.Context, redirect Redirect) (*Redirect, error) {
+	if err := s.client.validateCreate("redirect", redirect); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", redirectsBasePath)
 	wrappedData := RedirectResource{Redirect: &redirect}
 	resource := new(RedirectResource)
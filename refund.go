@@ -0,0 +1,319 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// RefundService is an interface for interfacing with the refund endpoints
+// of the Shopify API.
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/refund
+type RefundService interface {
+	List(context.Context, uint64, interface{}) ([]Refund, error)
+	Get(context.Context, uint64, uint64, interface{}) (*Refund, error)
+	Calculate(context.Context, uint64, Refund) (*Refund, error)
+	Create(context.Context, uint64, Refund) (*Refund, error)
+	Preview(context.Context, *Order, []RefundLineItem, *RefundPreviewOptions) (*RefundPreview, error)
+}
+
+// RefundServiceOp handles communication with the refund related methods of
+// the Shopify API.
+type RefundServiceOp struct {
+	client *Client
+}
+
+// RefundResource represents the result from the orders/X/refunds/Y.json endpoint
+type RefundResource struct {
+	Refund *Refund `json:"refund"`
+}
+
+// RefundsResource represents the result from the orders/X/refunds.json endpoint
+type RefundsResource struct {
+	Refunds []Refund `json:"refunds"`
+}
+
+// Restock types of refund line items
+const (
+	RefundRestockTypeNoRestock = "no_restock"
+	RefundRestockTypeCancel    = "cancel"
+	RefundRestockTypeReturn    = "return"
+)
+
+// RefundShippingMode selects how much shipping a previewed refund returns
+type RefundShippingMode string
+
+const (
+	// Refund no shipping
+	RefundShippingNone RefundShippingMode = ""
+
+	// Refund all of the shipping not refunded yet
+	RefundShippingFull RefundShippingMode = "full"
+
+	// Refund the share of the remaining shipping the refunded items make up
+	// of the items remaining on the order
+	RefundShippingProportional RefundShippingMode = "proportional"
+)
+
+// RefundPreviewOptions are the options of RefundService.Preview
+type RefundPreviewOptions struct {
+	Shipping RefundShippingMode
+
+	// RestockType and LocationId are used for line items which don't set
+	// their own
+	RestockType string
+	LocationId  uint64
+
+	Note   string
+	Notify bool
+}
+
+// RefundPreview is the outcome of RefundService.Preview: the refund Shopify
+// calculated and a payload creating it.
+type RefundPreview struct {
+	// Refund is ready to be passed to RefundService.Create
+	Refund *Refund
+
+	// Calculation is the refund as returned by the calculate endpoint
+	Calculation *Refund
+
+	Currency string
+	Subtotal decimal.Decimal
+	Tax      decimal.Decimal
+	Shipping decimal.Decimal
+	Total    decimal.Decimal
+}
+
+// List refunds of an order
+func (s *RefundServiceOp) List(ctx context.Context, orderId uint64, options interface{}) ([]Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds.json", ordersBasePath, orderId)
+	resource := new(RefundsResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Refunds, err
+}
+
+// Get individual refund
+func (s *RefundServiceOp) Get(ctx context.Context, orderId uint64, refundId uint64, options interface{}) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds/%d.json", ordersBasePath, orderId, refundId)
+	resource := new(RefundResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Refund, err
+}
+
+// Calculate the line item totals, taxes and suggested transactions of a
+// refund without creating it
+func (s *RefundServiceOp) Calculate(ctx context.Context, orderId uint64, refund Refund) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds/calculate.json", ordersBasePath, orderId)
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
+
+// Create a new refund
+func (s *RefundServiceOp) Create(ctx context.Context, orderId uint64, refund Refund) (*Refund, error) {
+	path := fmt.Sprintf("%s/%d/refunds.json", ordersBasePath, orderId)
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.Post(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
+
+// Preview calculates the refund of the given line items and turns the
+// result into a complete refund payload, including shipping as selected by
+// the options and the transactions Shopify suggests. Nothing is refunded
+// until the payload is passed to Create.
+func (s *RefundServiceOp) Preview(ctx context.Context, order *Order, lineItems []RefundLineItem, options *RefundPreviewOptions) (*RefundPreview, error) {
+	if options == nil {
+		options = &RefundPreviewOptions{}
+	}
+
+	request := Refund{
+		Currency: order.Currency,
+		Note:     options.Note,
+		Notify:   options.Notify,
+	}
+	for _, rli := range lineItems {
+		li, ok := orderLineItem(order, rli.LineItemId)
+		if !ok {
+			return nil, fmt.Errorf("refund preview: line item %d is not part of order %d", rli.LineItemId, order.Id)
+		}
+		if remaining := order.RemainingQuantity(li); rli.Quantity <= 0 || rli.Quantity > remaining {
+			return nil, fmt.Errorf("refund preview: cannot refund %d of the %d remaining units of line item %d", rli.Quantity, remaining, li.Id)
+		}
+		if rli.RestockType == "" {
+			rli.RestockType = options.RestockType
+		}
+		if rli.LocationId == 0 {
+			rli.LocationId = options.LocationId
+		}
+		request.RefundLineItems = append(request.RefundLineItems, RefundLineItem{
+			LineItemId:  rli.LineItemId,
+			Quantity:    rli.Quantity,
+			RestockType: rli.RestockType,
+			LocationId:  rli.LocationId,
+		})
+	}
+
+	switch options.Shipping {
+	case RefundShippingNone:
+	case RefundShippingFull:
+		request.Shipping = &RefundShipping{FullRefund: true}
+	case RefundShippingProportional:
+		amount := proportionalShipping(order, request.RefundLineItems)
+		request.Shipping = &RefundShipping{Amount: &amount}
+	default:
+		return nil, fmt.Errorf("refund preview: unknown shipping mode %q", options.Shipping)
+	}
+
+	calculated, err := s.Calculate(ctx, order.Id, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// rounding can put a proportional amount a cent over what is left
+	if options.Shipping == RefundShippingProportional && calculated.Shipping != nil && calculated.Shipping.MaximumRefundable != nil &&
+		request.Shipping.Amount.GreaterThan(*calculated.Shipping.MaximumRefundable) {
+		capped := *calculated.Shipping.MaximumRefundable
+		request.Shipping = &RefundShipping{Amount: &capped}
+		if calculated, err = s.Calculate(ctx, order.Id, request); err != nil {
+			return nil, err
+		}
+	}
+
+	return newRefundPreview(request, calculated), nil
+}
+
+func newRefundPreview(request Refund, calculated *Refund) *RefundPreview {
+	preview := &RefundPreview{
+		Refund:      &request,
+		Calculation: calculated,
+		Currency:    request.Currency,
+	}
+	if calculated.Currency != "" {
+		preview.Currency = calculated.Currency
+		request.Currency = calculated.Currency
+	}
+
+	for _, rli := range calculated.RefundLineItems {
+		if rli.Subtotal != nil {
+			preview.Subtotal = preview.Subtotal.Add(*rli.Subtotal)
+		}
+		if rli.TotalTax != nil {
+			preview.Tax = preview.Tax.Add(*rli.TotalTax)
+		}
+	}
+
+	if calculated.Shipping != nil {
+		if calculated.Shipping.Amount != nil {
+			preview.Shipping = *calculated.Shipping.Amount
+		}
+		if calculated.Shipping.Tax != nil {
+			preview.Tax = preview.Tax.Add(*calculated.Shipping.Tax)
+		}
+		// pin the calculated amount so the refund doesn't change if more
+		// shipping is refunded in the meantime
+		if request.Shipping != nil {
+			amount := preview.Shipping
+			request.Shipping = &RefundShipping{Amount: &amount}
+		}
+	}
+
+	request.Transactions = nil
+	for _, tx := range calculated.Transactions {
+		if tx.Kind != "suggested_refund" || tx.Amount == nil || tx.Amount.IsZero() {
+			continue
+		}
+		preview.Total = preview.Total.Add(*tx.Amount)
+		request.Transactions = append(request.Transactions, Transaction{
+			ParentId: tx.ParentId,
+			Amount:   tx.Amount,
+			Kind:     "refund",
+			Gateway:  tx.Gateway,
+		})
+	}
+
+	return preview
+}
+
+// String summarizes the refund for a dry run
+func (p *RefundPreview) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "refund of %d line items\n", len(p.Refund.RefundLineItems))
+	fmt.Fprintf(b, "  subtotal  %s %s\n", p.Subtotal.StringFixed(2), p.Currency)
+	fmt.Fprintf(b, "  shipping  %s %s\n", p.Shipping.StringFixed(2), p.Currency)
+	fmt.Fprintf(b, "  tax       %s %s\n", p.Tax.StringFixed(2), p.Currency)
+	fmt.Fprintf(b, "  total     %s %s\n", p.Total.StringFixed(2), p.Currency)
+	for _, tx := range p.Refund.Transactions {
+		parent := ""
+		if tx.ParentId != nil {
+			parent = fmt.Sprintf(" (transaction %d)", *tx.ParentId)
+		}
+		fmt.Fprintf(b, "  refund %s %s to %s%s\n", tx.Amount.StringFixed(2), p.Currency, tx.Gateway, parent)
+	}
+	return b.String()
+}
+
+func orderLineItem(order *Order, lineItemId uint64) (LineItem, bool) {
+	for _, li := range order.LineItems {
+		if li.Id == lineItemId {
+			return li, true
+		}
+	}
+	return LineItem{}, false
+}
+
+// lineItemUnitValue is the price of one unit of a line item after its
+// discounts
+func lineItemUnitValue(li LineItem) decimal.Decimal {
+	if li.Price == nil || li.Quantity == 0 {
+		return decimal.Zero
+	}
+	value := *li.Price
+	if li.TotalDiscount != nil {
+		value = value.Sub(li.TotalDiscount.Div(decimal.NewFromInt(int64(li.Quantity))))
+	}
+	return value
+}
+
+// proportionalShipping returns the share of the shipping not refunded yet
+// that the refunded units make up of the units remaining on the order
+func proportionalShipping(order *Order, lineItems []RefundLineItem) decimal.Decimal {
+	shipping := decimal.Zero
+	for _, sl := range order.ShippingLines {
+		switch {
+		case sl.DiscountedPrice != nil:
+			shipping = shipping.Add(*sl.DiscountedPrice)
+		case sl.Price != nil:
+			shipping = shipping.Add(*sl.Price)
+		}
+	}
+	for _, refund := range order.Refunds {
+		for _, adjustment := range refund.OrderAdjustments {
+			if adjustment.Kind == OrderAdjustmentTypeShippingRefund && adjustment.Amount != nil {
+				shipping = shipping.Sub(adjustment.Amount.Abs())
+			}
+		}
+	}
+	if !shipping.IsPositive() {
+		return decimal.Zero
+	}
+
+	remaining := decimal.Zero
+	for _, li := range order.LineItems {
+		remaining = remaining.Add(lineItemUnitValue(li).Mul(decimal.NewFromInt(int64(order.RemainingQuantity(li)))))
+	}
+	if !remaining.IsPositive() {
+		return decimal.Zero
+	}
+
+	refunded := decimal.Zero
+	for _, rli := range lineItems {
+		li, _ := orderLineItem(order, rli.LineItemId)
+		refunded = refunded.Add(lineItemUnitValue(li).Mul(decimal.NewFromInt(int64(rli.Quantity))))
+	}
+
+	return shipping.Mul(refunded).Div(remaining).Round(2)
+}
@@ -0,0 +1,284 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestRefundList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("refunds.json")))
+
+	refunds, err := client.Refund.List(context.Background(), 450789469, nil)
+	if err != nil {
+		t.Errorf("Refund.List returned error: %v", err)
+	}
+	if len(refunds) != 1 || refunds[0].Id != 509562969 {
+		t.Fatalf("Refund.List returned %+v, expected refund 509562969", refunds)
+	}
+	if refunds[0].RefundLineItems[0].RestockType != RefundRestockTypeReturn {
+		t.Errorf("RefundLineItem.RestockType returned %q, expected return", refunds[0].RefundLineItems[0].RestockType)
+	}
+}
+
+func TestRefundGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds/509562969.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("refund.json")))
+
+	refund, err := client.Refund.Get(context.Background(), 450789469, 509562969, nil)
+	if err != nil {
+		t.Errorf("Refund.Get returned error: %v", err)
+	}
+	if refund.Id != 509562969 || refund.Note != "it broke during shipping" {
+		t.Errorf("Refund.Get returned %+v", refund)
+	}
+	if refund.RefundLineItems[0].LocationId != 487838322 {
+		t.Errorf("RefundLineItem.LocationId returned %d, expected 487838322", refund.RefundLineItems[0].LocationId)
+	}
+}
+
+func TestRefundCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]interface{}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewBytesResponse(201, loadFixture("refund.json")), nil
+		})
+
+	amount := decimal.NewFromFloat(41.94)
+	refund, err := client.Refund.Create(context.Background(), 450789469, Refund{
+		Notify:          true,
+		RefundLineItems: []RefundLineItem{{LineItemId: 703073504, Quantity: 1, RestockType: RefundRestockTypeReturn}},
+		Transactions:    []Transaction{{Kind: "refund", Gateway: "bogus", Amount: &amount}},
+	})
+	if err != nil {
+		t.Errorf("Refund.Create returned error: %v", err)
+	}
+	if refund.Id != 509562969 {
+		t.Errorf("Refund.Create returned %+v", refund)
+	}
+
+	sent := body["refund"].(map[string]interface{})
+	if sent["notify"] != true {
+		t.Errorf("Refund.Create sent %v, expected notify", sent)
+	}
+}
+
+func TestRefundCalculate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds/calculate.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("refund_calculate.json")))
+
+	refund, err := client.Refund.Calculate(context.Background(), 450789469, Refund{
+		Shipping:        &RefundShipping{FullRefund: true},
+		RefundLineItems: []RefundLineItem{{LineItemId: 1, Quantity: 1}},
+	})
+	if err != nil {
+		t.Errorf("Refund.Calculate returned error: %v", err)
+	}
+	if !refund.Shipping.MaximumRefundable.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("RefundShipping.MaximumRefundable returned %s, expected 10", refund.Shipping.MaximumRefundable)
+	}
+	if !refund.Transactions[0].MaximumRefundable.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("Transaction.MaximumRefundable returned %s, expected 60", refund.Transactions[0].MaximumRefundable)
+	}
+}
+
+func refundPreviewOrder() *Order {
+	twenty := decimal.NewFromInt(20)
+	shipping := decimal.NewFromInt(15)
+	return &Order{
+		Id:       450789469,
+		Currency: "USD",
+		LineItems: []LineItem{
+			{Id: 1, Quantity: 2, Price: &twenty},
+			{Id: 2, Quantity: 1, Price: &twenty},
+		},
+		ShippingLines: []ShippingLines{{Price: &shipping}},
+	}
+}
+
+// registerRefundCalculate records the refunds sent to the calculate endpoint
+// and answers with the given fixtures in turn
+func registerRefundCalculate(t *testing.T, sent *[]Refund, fixtures ...string) {
+	t.Helper()
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/450789469/refunds/calculate.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			resource := RefundResource{}
+			if err := json.NewDecoder(req.Body).Decode(&resource); err != nil {
+				return nil, err
+			}
+			*sent = append(*sent, *resource.Refund)
+			fixture := fixtures[len(*sent)-1]
+			return httpmock.NewBytesResponse(200, loadFixture(fixture)), nil
+		})
+}
+
+func TestRefundPreviewProportionalShipping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []Refund
+	registerRefundCalculate(t, &sent, "refund_calculate.json")
+
+	preview, err := client.Refund.Preview(context.Background(), refundPreviewOrder(),
+		[]RefundLineItem{{LineItemId: 1, Quantity: 1}},
+		&RefundPreviewOptions{Shipping: RefundShippingProportional, RestockType: RefundRestockTypeReturn, LocationId: 487838322, Notify: true})
+	if err != nil {
+		t.Fatalf("Refund.Preview returned error: %v", err)
+	}
+
+	// one of three equally priced units, a third of the shipping
+	if len(sent) != 1 {
+		t.Fatalf("Refund.Preview calculated %d times, expected 1", len(sent))
+	}
+	if !sent[0].Shipping.Amount.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("Refund.Preview requested shipping %s, expected 5", sent[0].Shipping.Amount)
+	}
+	if rli := sent[0].RefundLineItems[0]; rli.RestockType != RefundRestockTypeReturn || rli.LocationId != 487838322 {
+		t.Errorf("Refund.Preview requested line item %+v, expected the default restock type and location", rli)
+	}
+
+	for name, c := range map[string]struct{ got, expected decimal.Decimal }{
+		"Subtotal": {preview.Subtotal, decimal.NewFromInt(20)},
+		"Shipping": {preview.Shipping, decimal.NewFromInt(5)},
+		"Tax":      {preview.Tax, decimal.NewFromFloat(2.5)},
+		"Total":    {preview.Total, decimal.NewFromFloat(27.5)},
+	} {
+		if !c.got.Equal(c.expected) {
+			t.Errorf("RefundPreview.%s = %s, expected %s", name, c.got, c.expected)
+		}
+	}
+
+	refund := preview.Refund
+	if !refund.Notify || refund.Currency != "USD" {
+		t.Errorf("RefundPreview.Refund = %+v, expected notify in USD", refund)
+	}
+	if len(refund.Transactions) != 1 {
+		t.Fatalf("RefundPreview.Refund.Transactions = %+v, expected one refund", refund.Transactions)
+	}
+	tx := refund.Transactions[0]
+	if tx.Kind != "refund" || tx.Gateway != "bogus" || *tx.ParentId != 801038806 || !tx.Amount.Equal(decimal.NewFromFloat(27.5)) {
+		t.Errorf("RefundPreview.Refund.Transactions[0] = %+v", tx)
+	}
+
+	summary := preview.String()
+	for _, line := range []string{"total     27.50 USD", "refund 27.50 USD to bogus (transaction 801038806)"} {
+		if !strings.Contains(summary, line) {
+			t.Errorf("RefundPreview.String() = %q, expected it to contain %q", summary, line)
+		}
+	}
+}
+
+func TestRefundPreviewCapsShipping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []Refund
+	registerRefundCalculate(t, &sent, "refund_calculate.json", "refund_calculate.json")
+
+	// two of three units make up 20 of the 30 shipping, but the calculate
+	// fixture only allows refunding 10
+	order := refundPreviewOrder()
+	thirty := decimal.NewFromInt(30)
+	order.ShippingLines[0].Price = &thirty
+
+	_, err := client.Refund.Preview(context.Background(), order,
+		[]RefundLineItem{{LineItemId: 1, Quantity: 2}},
+		&RefundPreviewOptions{Shipping: RefundShippingProportional})
+	if err != nil {
+		t.Fatalf("Refund.Preview returned error: %v", err)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("Refund.Preview calculated %d times, expected 2", len(sent))
+	}
+	if !sent[0].Shipping.Amount.Equal(decimal.NewFromInt(20)) || !sent[1].Shipping.Amount.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Refund.Preview requested shipping %s then %s, expected 20 then 10", sent[0].Shipping.Amount, sent[1].Shipping.Amount)
+	}
+}
+
+func TestRefundPreviewInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	order := refundPreviewOrder()
+	order.Refunds = []Refund{{RefundLineItems: []RefundLineItem{{LineItemId: 2, Quantity: 1}}}}
+
+	cases := []struct {
+		lineItems []RefundLineItem
+		options   *RefundPreviewOptions
+		expected  string
+	}{
+		{[]RefundLineItem{{LineItemId: 3, Quantity: 1}}, nil, "line item 3 is not part of order 450789469"},
+		{[]RefundLineItem{{LineItemId: 1, Quantity: 3}}, nil, "cannot refund 3 of the 2 remaining units of line item 1"},
+		{[]RefundLineItem{{LineItemId: 2, Quantity: 1}}, nil, "cannot refund 1 of the 0 remaining units of line item 2"},
+		{[]RefundLineItem{{LineItemId: 1, Quantity: 1}}, &RefundPreviewOptions{Shipping: "half"}, `unknown shipping mode "half"`},
+	}
+	for _, c := range cases {
+		_, err := client.Refund.Preview(context.Background(), order, c.lineItems, c.options)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("Refund.Preview returned %v, expected %q", err, c.expected)
+		}
+	}
+	if info := httpmock.GetCallCountInfo(); len(info) > 0 {
+		for call, count := range info {
+			if count > 0 {
+				t.Errorf("Refund.Preview called %s", call)
+			}
+		}
+	}
+}
+
+func TestRefundPreviewFullShipping(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []Refund
+	registerRefundCalculate(t, &sent, "refund_calculate.json")
+
+	preview, err := client.Refund.Preview(context.Background(), refundPreviewOrder(),
+		[]RefundLineItem{{LineItemId: 1, Quantity: 1}},
+		&RefundPreviewOptions{Shipping: RefundShippingFull})
+	if err != nil {
+		t.Fatalf("Refund.Preview returned error: %v", err)
+	}
+	if !sent[0].Shipping.FullRefund {
+		t.Errorf("Refund.Preview requested shipping %+v, expected a full refund", sent[0].Shipping)
+	}
+
+	// the payload pins the calculated amount
+	shipping := preview.Refund.Shipping
+	if shipping.FullRefund || !shipping.Amount.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("RefundPreview.Refund.Shipping = %+v, expected an amount of 5", shipping)
+	}
+}
+
+func TestRefundShippingJSON(t *testing.T) {
+	data, err := json.Marshal(Refund{Shipping: &RefundShipping{FullRefund: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"shipping":{"full_refund":true}`) {
+		t.Errorf("Refund marshalled to %s", data)
+	}
+}
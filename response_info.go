@@ -0,0 +1,98 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ResponseInfo is the status code and headers of a response to a request
+type ResponseInfo struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+}
+
+// RequestId returns the X-Request-Id of the response, which Shopify support
+// asks for
+func (r ResponseInfo) RequestId() string {
+	return r.Header.Get("X-Request-Id")
+}
+
+// CallLimit returns the used calls and the size of the REST call limit
+// bucket, zero for responses without X-Shopify-Shop-Api-Call-Limit
+func (r ResponseInfo) CallLimit() (used int, size int) {
+	s := strings.Split(r.Header.Get("X-Shopify-Shop-Api-Call-Limit"), "/")
+	if len(s) != 2 {
+		return 0, 0
+	}
+	used, _ = strconv.Atoi(s[0])
+	size, _ = strconv.Atoi(s[1])
+	return used, size
+}
+
+// DeprecatedReason returns why the request used a deprecated API, empty if
+// it didn't
+func (r ResponseInfo) DeprecatedReason() string {
+	return r.Header.Get("X-Shopify-API-Deprecated-Reason")
+}
+
+// ResponseRecorder records the responses to requests made with its context,
+// see WithResponseRecorder
+type ResponseRecorder struct {
+	mu        sync.Mutex
+	responses []ResponseInfo
+}
+
+// Responses returns the recorded responses, including the ones of retried
+// attempts, in the order they were received
+func (r *ResponseRecorder) Responses() []ResponseInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ResponseInfo(nil), r.responses...)
+}
+
+// Last returns the last recorded response
+func (r *ResponseRecorder) Last() (ResponseInfo, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.responses) == 0 {
+		return ResponseInfo{}, false
+	}
+	return r.responses[len(r.responses)-1], true
+}
+
+func (r *ResponseRecorder) record(req *http.Request, resp *http.Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, ResponseInfo{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	})
+}
+
+type responseRecorderContextKey struct{}
+
+// WithResponseRecorder returns a context recording the status code and
+// headers of the responses to every request made with it, e.g. to log the
+// X-Request-Id or deprecation headers of service calls.
+func WithResponseRecorder(ctx context.Context) (context.Context, *ResponseRecorder) {
+	recorder := &ResponseRecorder{}
+	return context.WithValue(ctx, responseRecorderContextKey{}, recorder), recorder
+}
+
+// recordResponse records the response in the recorder of the request's
+// context, if any
+func recordResponse(req *http.Request, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if recorder, ok := req.Context().Value(responseRecorderContextKey{}).(*ResponseRecorder); ok {
+		recorder.record(req, resp)
+	}
+}
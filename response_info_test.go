@@ -0,0 +1,69 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestResponseRecorder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				resp := httpmock.NewStringResponse(429, `{"errors":"Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."}`)
+				resp.Header.Set("Retry-After", "0")
+				resp.Header.Set("X-Request-Id", "first")
+				return resp, nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"shop":{"id":1}}`)
+			resp.Header.Set("X-Request-Id", "second")
+			resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", "3/40")
+			resp.Header.Set("X-Shopify-API-Deprecated-Reason", "https://shopify.dev/changelog")
+			return resp, nil
+		})
+
+	ctx, recorder := WithResponseRecorder(context.Background())
+	if _, err := client.Shop.Get(ctx, nil); err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	responses := recorder.Responses()
+	if len(responses) != 2 {
+		t.Fatalf("recorded %d responses, expected the retried one too", len(responses))
+	}
+	if responses[0].StatusCode != 429 || responses[0].RequestId() != "first" {
+		t.Errorf("first response is %d %s", responses[0].StatusCode, responses[0].RequestId())
+	}
+
+	last, ok := recorder.Last()
+	if !ok || last.StatusCode != 200 || last.RequestId() != "second" || last.Method != "GET" {
+		t.Errorf("last response is %+v", last)
+	}
+	if used, size := last.CallLimit(); used != 3 || size != 40 {
+		t.Errorf("CallLimit() = %d/%d, expected 3/40", used, size)
+	}
+	if last.DeprecatedReason() != "https://shopify.dev/changelog" {
+		t.Errorf("DeprecatedReason() = %s", last.DeprecatedReason())
+	}
+	if last.URL != fmt.Sprintf("https://fooshop.myshopify.com/%s/shop.json", client.pathPrefix) {
+		t.Errorf("last response URL is %s", last.URL)
+	}
+}
+
+func TestResponseRecorderEmpty(t *testing.T) {
+	_, recorder := WithResponseRecorder(context.Background())
+	if _, ok := recorder.Last(); ok {
+		t.Error("Last() returned a response before any request")
+	}
+	if used, size := (ResponseInfo{Header: http.Header{}}).CallLimit(); used != 0 || size != 0 {
+		t.Errorf("CallLimit() = %d/%d without header", used, size)
+	}
+}
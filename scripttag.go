@@ -85,6 +85,10 @@ func (s *ScriptTagServiceOp) Get(ctx context.Context, tagId uint64, options inte
 
 // Create a new script tag
 func (s *ScriptTagServiceOp) Create(ctx context.Context, tag ScriptTag) (*ScriptTag, error) {
+	if err := s.client.validateCreate("script tag", tag); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", scriptTagsBasePath)
 	wrappedData := ScriptTagResource{ScriptTag: &tag}
 	resource := &ScriptTagResource{}
@@ -0,0 +1,103 @@
+package goshopify
+
+import (
+	"strings"
+	"time"
+)
+
+// Comparison operators of the Shopify search syntax
+const (
+	SearchGreaterThan      = ">"
+	SearchGreaterThanEqual = ">="
+	SearchLessThan         = "<"
+	SearchLessThanEqual    = "<="
+)
+
+// SearchQuery builds a query in the Shopify search syntax, as accepted by
+// the query argument of GraphQL connections. Terms are joined with AND.
+// See https://shopify.dev/docs/api/usage/search-syntax
+type SearchQuery struct {
+	terms []string
+}
+
+// NewSearchQuery returns an empty SearchQuery
+func NewSearchQuery() *SearchQuery {
+	return &SearchQuery{}
+}
+
+// Field adds a field:value term
+func (q *SearchQuery) Field(field, value string) *SearchQuery {
+	return q.Raw(field + ":" + QuoteSearchValue(value))
+}
+
+// Not adds a -field:value term
+func (q *SearchQuery) Not(field, value string) *SearchQuery {
+	return q.Raw("-" + field + ":" + QuoteSearchValue(value))
+}
+
+// Compare adds a field:<op>value term, op being one of the Search*
+// comparison operators.
+func (q *SearchQuery) Compare(field, op, value string) *SearchQuery {
+	return q.Raw(field + ":" + op + QuoteSearchValue(value))
+}
+
+// TimeRange adds terms matching field values between min and max, both
+// inclusive. Zero times leave that side of the range open.
+func (q *SearchQuery) TimeRange(field string, min, max time.Time) *SearchQuery {
+	if !min.IsZero() {
+		q.Raw(field + ":" + SearchGreaterThanEqual + quoteSearchTime(min))
+	}
+	if !max.IsZero() {
+		q.Raw(field + ":" + SearchLessThanEqual + quoteSearchTime(max))
+	}
+	return q
+}
+
+// Or adds a term matching any of the given queries
+func (q *SearchQuery) Or(queries ...*SearchQuery) *SearchQuery {
+	parts := make([]string, 0, len(queries))
+	for _, sub := range queries {
+		if s := sub.String(); s != "" {
+			parts = append(parts, "("+s+")")
+		}
+	}
+	if len(parts) == 0 {
+		return q
+	}
+	return q.Raw("(" + strings.Join(parts, " OR ") + ")")
+}
+
+// Raw adds a term as is, without any escaping
+func (q *SearchQuery) Raw(term string) *SearchQuery {
+	if term != "" {
+		q.terms = append(q.terms, term)
+	}
+	return q
+}
+
+// String returns the query
+func (q *SearchQuery) String() string {
+	if q == nil {
+		return ""
+	}
+	return strings.Join(q.terms, " AND ")
+}
+
+// QuoteSearchValue quotes a search value if it contains whitespace or
+// characters with a meaning in the search syntax.
+func QuoteSearchValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n:\\()\"'*#") {
+		switch strings.ToUpper(value) {
+		case "AND", "OR", "NOT":
+		default:
+			return value
+		}
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(value) + `"`
+}
+
+func quoteSearchTime(t time.Time) string {
+	return "'" + t.UTC().Format(time.RFC3339) + "'"
+}
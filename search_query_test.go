@@ -0,0 +1,55 @@
+package goshopify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuoteSearchValue(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{"paid", "paid"},
+		{"foo@example.com", "foo@example.com"},
+		{"", `""`},
+		{"two words", `"two words"`},
+		{"a:b", `"a:b"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`back\slash`, `"back\\slash"`},
+		{"(x)", `"(x)"`},
+		{"or", `"or"`},
+		{"#1001", `"#1001"`},
+	}
+
+	for _, c := range cases {
+		actual := QuoteSearchValue(c.value)
+		if actual != c.expected {
+			t.Errorf("QuoteSearchValue(%q) returned %s, expected %s", c.value, actual, c.expected)
+		}
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	min := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2024, 2, 1, 0, 0, 0, 0, time.FixedZone("", 3600))
+
+	q := NewSearchQuery().
+		Field("tag", "needs review").
+		Not("tag", "test").
+		Compare("total_price", SearchGreaterThan, "100").
+		TimeRange("created_at", min, max).
+		TimeRange("updated_at", time.Time{}, time.Time{}).
+		Or(NewSearchQuery().Field("financial_status", "paid"), NewSearchQuery(), NewSearchQuery().Field("financial_status", "refunded"))
+
+	expected := `tag:"needs review" AND -tag:test AND total_price:>100 AND ` +
+		`created_at:>='2024-01-01T00:00:00Z' AND created_at:<='2024-01-31T23:00:00Z' AND ` +
+		`((financial_status:paid) OR (financial_status:refunded))`
+	if q.String() != expected {
+		t.Errorf("SearchQuery.String returned %s, expected %s", q.String(), expected)
+	}
+
+	if s := NewSearchQuery().Or().String(); s != "" {
+		t.Errorf("SearchQuery.Or without queries returned %s, expected empty", s)
+	}
+}
@@ -0,0 +1,132 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// number of products or variants assigned to or removed from a selling plan
+// group by one mutation
+const sellingPlanGroupBatchSize = 250
+
+// sellingPlanGroupAssignMutation is the mutation assigning or removing
+// products or variants, by mutation name and name of the ids argument
+const sellingPlanGroupAssignMutation = `mutation %[1]s($id: ID!, $ids: [ID!]!) {
+  %[1]s(id: $id, %[2]s: $ids) {
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// SellingPlanGroupService is an interface for assigning selling plan groups,
+// e.g. subscription plans, to products and variants through the GraphQL API
+// See: https://shopify.dev/docs/api/admin-graphql/latest/mutations/sellingPlanGroupAddProducts
+type SellingPlanGroupService interface {
+	AddProducts(context.Context, uint64, []uint64) error
+	RemoveProducts(context.Context, uint64, []uint64) error
+	AddVariants(context.Context, uint64, []uint64) error
+	RemoveVariants(context.Context, uint64, []uint64) error
+}
+
+// SellingPlanGroupServiceOp handles communication with the selling plan group
+// mutations of the GraphQL API
+type SellingPlanGroupServiceOp struct {
+	client *Client
+}
+
+// AddProducts assigns a selling plan group to products, sending the ids in
+// batches. If any product failed the returned error is a BatchErrors keyed
+// by product id.
+func (s *SellingPlanGroupServiceOp) AddProducts(ctx context.Context, groupId uint64, productIds []uint64) error {
+	return s.assign(ctx, "sellingPlanGroupAddProducts", "productIds", "Product", groupId, productIds)
+}
+
+// RemoveProducts removes a selling plan group from products, see AddProducts
+func (s *SellingPlanGroupServiceOp) RemoveProducts(ctx context.Context, groupId uint64, productIds []uint64) error {
+	return s.assign(ctx, "sellingPlanGroupRemoveProducts", "productIds", "Product", groupId, productIds)
+}
+
+// AddVariants assigns a selling plan group to variants, sending the ids in
+// batches. If any variant failed the returned error is a BatchErrors keyed
+// by variant id.
+func (s *SellingPlanGroupServiceOp) AddVariants(ctx context.Context, groupId uint64, variantIds []uint64) error {
+	return s.assign(ctx, "sellingPlanGroupAddProductVariants", "productVariantIds", "ProductVariant", groupId, variantIds)
+}
+
+// RemoveVariants removes a selling plan group from variants, see AddVariants
+func (s *SellingPlanGroupServiceOp) RemoveVariants(ctx context.Context, groupId uint64, variantIds []uint64) error {
+	return s.assign(ctx, "sellingPlanGroupRemoveProductVariants", "productVariantIds", "ProductVariant", groupId, variantIds)
+}
+
+func (s *SellingPlanGroupServiceOp) assign(ctx context.Context, mutation string, arg string, resource string, groupId uint64, ids []uint64) error {
+	ctx = WithRequestPriority(ctx, PriorityBatch)
+	q := fmt.Sprintf(sellingPlanGroupAssignMutation, mutation, arg)
+
+	errs := BatchErrors{}
+	for start := 0; start < len(ids); start += sellingPlanGroupBatchSize {
+		end := start + sellingPlanGroupBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		gids := make([]string, 0, len(batch))
+		for _, id := range batch {
+			gids = append(gids, GraphQLId(resource, id))
+		}
+		vars := map[string]interface{}{
+			"id":  GraphQLId("SellingPlanGroup", groupId),
+			"ids": gids,
+		}
+
+		resp := map[string]*struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		}{}
+		err := s.client.GraphQL.Query(ctx, q, vars, &resp)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			for _, id := range batch {
+				errs[id] = err
+			}
+			continue
+		}
+		if result := resp[mutation]; result != nil {
+			batchUserErrors(errs, arg, batch, result.UserErrors)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// batchUserErrors adds the user errors of a mutation taking a list of ids to
+// errs. Errors pointing at an id, e.g. field ["productIds", "3"], fail that
+// id, others fail the whole batch.
+func batchUserErrors(errs BatchErrors, arg string, batch []uint64, userErrs GraphQLUserErrors) {
+	for _, userErr := range userErrs {
+		f := userErr.Field
+		if len(f) >= 2 && f[len(f)-2] == arg {
+			if i, err := strconv.Atoi(f[len(f)-1]); err == nil && i >= 0 && i < len(batch) {
+				errs[batch[i]] = append(userErrorsOf(errs[batch[i]]), userErr)
+				continue
+			}
+		}
+		for _, id := range batch {
+			errs[id] = append(userErrorsOf(errs[id]), userErr)
+		}
+	}
+}
+
+// userErrorsOf returns the user errors already recorded for an id
+func userErrorsOf(err error) GraphQLUserErrors {
+	userErrs, _ := err.(GraphQLUserErrors)
+	return userErrs
+}
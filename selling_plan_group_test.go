@@ -0,0 +1,74 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSellingPlanGroupAddProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var batches [][]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		if req.Query != fmt.Sprintf(sellingPlanGroupAssignMutation, "sellingPlanGroupAddProducts", "productIds") {
+			t.Errorf("unexpected graphql query %s", req.Query)
+		}
+		if req.Variables["id"] != "gid://shopify/SellingPlanGroup/5" {
+			t.Errorf("unexpected selling plan group %v", req.Variables["id"])
+		}
+		ids := req.Variables["ids"].([]interface{})
+		batches = append(batches, ids)
+
+		if len(batches) == 2 {
+			return `{"data":{"sellingPlanGroupAddProducts":{"userErrors":[
+				{"field":["productIds","1"],"message":"Product does not exist","code":"NOT_FOUND"}]}}}`
+		}
+		return `{"data":{"sellingPlanGroupAddProducts":{"userErrors":[]}}}`
+	})
+
+	productIds := make([]uint64, 0, 300)
+	for id := uint64(1); id <= 300; id++ {
+		productIds = append(productIds, id)
+	}
+
+	err := client.SellingPlanGroup.AddProducts(context.Background(), 5, productIds)
+	if len(batches) != 2 || len(batches[0]) != sellingPlanGroupBatchSize || len(batches[1]) != 50 {
+		t.Fatalf("SellingPlanGroup.AddProducts sent %d batches", len(batches))
+	}
+	if batches[1][0] != "gid://shopify/Product/251" {
+		t.Errorf("SellingPlanGroup.AddProducts sent %v first in the second batch", batches[1][0])
+	}
+
+	errs, ok := err.(BatchErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("SellingPlanGroup.AddProducts returned %v, expected BatchErrors of product 252", err)
+	}
+	expected := GraphQLUserErrors{{Field: []string{"productIds", "1"}, Message: "Product does not exist", Code: "NOT_FOUND"}}
+	if !reflect.DeepEqual(errs[252], expected) {
+		t.Errorf("SellingPlanGroup.AddProducts returned %v for product 252, expected %v", errs[252], expected)
+	}
+}
+
+func TestSellingPlanGroupRemoveVariants(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		fmt.Sprintf(sellingPlanGroupAssignMutation, "sellingPlanGroupRemoveProductVariants", "productVariantIds"): `{"data":{
+			"sellingPlanGroupRemoveProductVariants":{"userErrors":[{"field":["id"],"message":"Selling plan group does not exist"}]}}}`,
+	}))
+
+	err := client.SellingPlanGroup.RemoveVariants(context.Background(), 5, []uint64{10, 11})
+	errs, ok := err.(BatchErrors)
+	if !ok || len(errs) != 2 || errs[10] == nil || errs[11] == nil {
+		t.Errorf("SellingPlanGroup.RemoveVariants returned %v, expected both variants to fail", err)
+	}
+	expected := []interface{}{"gid://shopify/ProductVariant/10", "gid://shopify/ProductVariant/11"}
+	if len(sent) != 1 || !reflect.DeepEqual(sent[0].Variables["ids"], expected) {
+		t.Errorf("SellingPlanGroup.RemoveVariants sent %+v", sent)
+	}
+}
@@ -13,6 +13,10 @@ const shopResourceName = ""
 // See: https://help.shopify.com/api/reference/shop
 type ShopService interface {
 	Get(ctx context.Context, options interface{}) (*Shop, error)
+	SetMetafields(context.Context, ...Metafield) ([]Metafield, error)
+	ListDomains(context.Context) ([]Domain, error)
+	PrimaryDomain(context.Context) (*Domain, error)
+	ListWebPresences(context.Context) ([]WebPresence, error)
 
 	// MetafieldsService used for Shop resource to communicate with Metafields resource
 	MetafieldsService
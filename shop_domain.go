@@ -0,0 +1,214 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const shopDomainFields = `
+      id
+      host
+      url
+      sslEnabled`
+
+const shopDomainsQuery = `query shopDomains {
+  shop {
+    primaryDomain {` + shopDomainFields + `
+    }
+    domains {` + shopDomainFields + `
+    }
+  }
+}`
+
+const marketWebPresencesQuery = `query marketWebPresences($after: String) {
+  markets(first: 250, after: $after) {
+    nodes {
+      id
+      name
+      handle
+      primary
+      enabled
+      webPresence {
+        id
+        subfolderSuffix
+        defaultLocale
+        alternateLocales
+        domain {` + shopDomainFields + `
+        }
+        rootUrls {
+          locale
+          url
+        }
+      }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+// Domain is a domain of the shop, e.g. the myshopify domain or a custom
+// domain
+type Domain struct {
+	Id         uint64
+	Host       string
+	URL        string
+	SSLEnabled bool
+	Primary    bool
+}
+
+// WebPresence is where the storefront of a market is served: its own domain
+// or a subfolder of the primary domain, e.g. /en-ca, with the root URL of
+// each locale
+type WebPresence struct {
+	Id               uint64
+	MarketId         uint64
+	MarketName       string
+	MarketHandle     string
+	PrimaryMarket    bool
+	Enabled          bool
+	Domain           *Domain
+	SubfolderSuffix  string
+	DefaultLocale    string
+	AlternateLocales []string
+
+	// root URL by locale without trailing slash, e.g.
+	// "fr" => "https://example.ca/fr"
+	RootURLs map[string]string
+}
+
+// RootURL returns the storefront root URL of the web presence for a locale,
+// falling back to the default locale
+func (w WebPresence) RootURL(locale string) string {
+	if root, ok := w.RootURLs[locale]; ok {
+		return root
+	}
+	return w.RootURLs[w.DefaultLocale]
+}
+
+// URL returns the storefront URL of a path for a locale, e.g.
+// URL("fr", "/collections/all") returns
+// "https://example.com/fr-ca/collections/all" for a market in a subfolder
+func (w WebPresence) URL(locale string, path string) string {
+	return w.RootURL(locale) + "/" + strings.TrimPrefix(path, "/")
+}
+
+// ProductURL returns the storefront URL of a product by handle for a locale
+func (w WebPresence) ProductURL(locale string, handle string) string {
+	return w.URL(locale, "products/"+url.PathEscape(handle))
+}
+
+type graphQLDomain struct {
+	Id         string `json:"id"`
+	Host       string `json:"host"`
+	URL        string `json:"url"`
+	SSLEnabled bool   `json:"sslEnabled"`
+}
+
+func (g *graphQLDomain) domain() *Domain {
+	if g == nil {
+		return nil
+	}
+	id, _ := ParseGraphQLId(g.Id)
+	return &Domain{Id: id, Host: g.Host, URL: g.URL, SSLEnabled: g.SSLEnabled}
+}
+
+// ListDomains lists the domains of the shop through the GraphQL API, with the
+// primary domain customers see first
+func (s *ShopServiceOp) ListDomains(ctx context.Context) ([]Domain, error) {
+	resp := struct {
+		Shop struct {
+			PrimaryDomain *graphQLDomain  `json:"primaryDomain"`
+			Domains       []graphQLDomain `json:"domains"`
+		} `json:"shop"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, shopDomainsQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []Domain
+	primary := resp.Shop.PrimaryDomain.domain()
+	if primary != nil {
+		primary.Primary = true
+		domains = append(domains, *primary)
+	}
+	for i := range resp.Shop.Domains {
+		d := resp.Shop.Domains[i].domain()
+		if primary != nil && d.Id == primary.Id {
+			continue
+		}
+		domains = append(domains, *d)
+	}
+	return domains, nil
+}
+
+// PrimaryDomain gets the primary domain of the shop, the one storefront URLs
+// should use instead of the myshopify domain
+func (s *ShopServiceOp) PrimaryDomain(ctx context.Context) (*Domain, error) {
+	domains, err := s.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(domains) == 0 || !domains[0].Primary {
+		return nil, graphQLNotFound()
+	}
+	return &domains[0], nil
+}
+
+// ListWebPresences lists the web presences of the markets of the shop through
+// the GraphQL API. Markets without a web presence of their own are left out.
+func (s *ShopServiceOp) ListWebPresences(ctx context.Context) ([]WebPresence, error) {
+	var presences []WebPresence
+	err := s.client.GraphQLEachNode(ctx, marketWebPresencesQuery, map[string]interface{}{}, "markets", func(node json.RawMessage) error {
+		var m struct {
+			Id          string `json:"id"`
+			Name        string `json:"name"`
+			Handle      string `json:"handle"`
+			Primary     bool   `json:"primary"`
+			Enabled     bool   `json:"enabled"`
+			WebPresence *struct {
+				Id               string         `json:"id"`
+				SubfolderSuffix  string         `json:"subfolderSuffix"`
+				DefaultLocale    string         `json:"defaultLocale"`
+				AlternateLocales []string       `json:"alternateLocales"`
+				Domain           *graphQLDomain `json:"domain"`
+				RootUrls         []struct {
+					Locale string `json:"locale"`
+					URL    string `json:"url"`
+				} `json:"rootUrls"`
+			} `json:"webPresence"`
+		}
+		if err := json.Unmarshal(node, &m); err != nil {
+			return err
+		}
+		if m.WebPresence == nil {
+			return nil
+		}
+
+		marketId, _ := ParseGraphQLId(m.Id)
+		id, _ := ParseGraphQLId(m.WebPresence.Id)
+		presence := WebPresence{
+			Id:               id,
+			MarketId:         marketId,
+			MarketName:       m.Name,
+			MarketHandle:     m.Handle,
+			PrimaryMarket:    m.Primary,
+			Enabled:          m.Enabled,
+			Domain:           m.WebPresence.Domain.domain(),
+			SubfolderSuffix:  m.WebPresence.SubfolderSuffix,
+			DefaultLocale:    m.WebPresence.DefaultLocale,
+			AlternateLocales: m.WebPresence.AlternateLocales,
+			RootURLs:         make(map[string]string, len(m.WebPresence.RootUrls)),
+		}
+		for _, u := range m.WebPresence.RootUrls {
+			presence.RootURLs[u.Locale] = strings.TrimSuffix(u.URL, "/")
+		}
+		presences = append(presences, presence)
+		return nil
+	})
+	return presences, err
+}
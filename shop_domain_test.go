@@ -0,0 +1,110 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestShopListDomains(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		shopDomainsQuery: `{"data":{"shop":{
+			"primaryDomain":{"id":"gid://shopify/Domain/2","host":"example.com","url":"https://example.com","sslEnabled":true},
+			"domains":[
+				{"id":"gid://shopify/Domain/1","host":"fooshop.myshopify.com","url":"https://fooshop.myshopify.com","sslEnabled":true},
+				{"id":"gid://shopify/Domain/2","host":"example.com","url":"https://example.com","sslEnabled":true},
+				{"id":"gid://shopify/Domain/3","host":"example.ca","url":"http://example.ca","sslEnabled":false}
+			]}}}`,
+	}))
+
+	domains, err := client.Shop.ListDomains(context.Background())
+	if err != nil {
+		t.Fatalf("Shop.ListDomains returned error: %v", err)
+	}
+	expected := []Domain{
+		{Id: 2, Host: "example.com", URL: "https://example.com", SSLEnabled: true, Primary: true},
+		{Id: 1, Host: "fooshop.myshopify.com", URL: "https://fooshop.myshopify.com", SSLEnabled: true},
+		{Id: 3, Host: "example.ca", URL: "http://example.ca"},
+	}
+	if !reflect.DeepEqual(domains, expected) {
+		t.Errorf("Shop.ListDomains returned %+v, expected %+v", domains, expected)
+	}
+
+	primary, err := client.Shop.PrimaryDomain(context.Background())
+	if err != nil {
+		t.Fatalf("Shop.PrimaryDomain returned error: %v", err)
+	}
+	if !reflect.DeepEqual(primary, &expected[0]) {
+		t.Errorf("Shop.PrimaryDomain returned %+v, expected %+v", primary, expected[0])
+	}
+}
+
+func TestShopListWebPresences(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		marketWebPresencesQuery: `{"data":{"markets":{"nodes":[
+			{"id":"gid://shopify/Market/1","name":"United States","handle":"us","primary":true,"enabled":true,
+				"webPresence":{"id":"gid://shopify/MarketWebPresence/1","subfolderSuffix":null,"defaultLocale":"en","alternateLocales":[],
+					"domain":{"id":"gid://shopify/Domain/2","host":"example.com","url":"https://example.com","sslEnabled":true},
+					"rootUrls":[{"locale":"en","url":"https://example.com/"}]}},
+			{"id":"gid://shopify/Market/2","name":"Canada","handle":"ca","primary":false,"enabled":true,
+				"webPresence":{"id":"gid://shopify/MarketWebPresence/2","subfolderSuffix":"ca","defaultLocale":"en","alternateLocales":["fr"],
+					"domain":null,
+					"rootUrls":[{"locale":"en","url":"https://example.com/en-ca/"},{"locale":"fr","url":"https://example.com/fr-ca/"}]}},
+			{"id":"gid://shopify/Market/3","name":"Mexico","handle":"mx","primary":false,"enabled":false,"webPresence":null}
+		],"pageInfo":{"hasNextPage":false,"endCursor":null}}}}`,
+	}))
+
+	presences, err := client.Shop.ListWebPresences(context.Background())
+	if err != nil {
+		t.Fatalf("Shop.ListWebPresences returned error: %v", err)
+	}
+	if len(presences) != 2 {
+		t.Fatalf("Shop.ListWebPresences returned %d web presences, expected 2", len(presences))
+	}
+
+	us := presences[0]
+	if !us.PrimaryMarket || us.Domain == nil || us.Domain.Host != "example.com" || us.RootURL("en") != "https://example.com" {
+		t.Errorf("Shop.ListWebPresences returned %+v", us)
+	}
+
+	ca := presences[1]
+	if ca.MarketId != 2 || ca.MarketHandle != "ca" || ca.SubfolderSuffix != "ca" || ca.Domain != nil {
+		t.Errorf("Shop.ListWebPresences returned %+v", ca)
+	}
+	if url := ca.RootURL("fr"); url != "https://example.com/fr-ca" {
+		t.Errorf("WebPresence.RootURL(fr) returned %s", url)
+	}
+	if url := ca.RootURL("de"); url != "https://example.com/en-ca" {
+		t.Errorf("WebPresence.RootURL(de) returned %s, expected the default locale", url)
+	}
+}
+
+func TestWebPresenceURL(t *testing.T) {
+	presence := WebPresence{
+		DefaultLocale: "en",
+		RootURLs:      map[string]string{"en": "https://example.com/en-ca", "fr": "https://example.com/fr-ca"},
+	}
+
+	cases := []struct {
+		actual   string
+		expected string
+	}{
+		{presence.URL("fr", "/collections/all"), "https://example.com/fr-ca/collections/all"},
+		{presence.URL("en", "cart"), "https://example.com/en-ca/cart"},
+		{presence.URL("de", "/"), "https://example.com/en-ca/"},
+		{presence.ProductURL("fr", "t-shirt été"), "https://example.com/fr-ca/products/t-shirt%20%C3%A9t%C3%A9"},
+	}
+	for _, c := range cases {
+		if c.actual != c.expected {
+			t.Errorf("WebPresence URL returned %s, expected %s", c.actual, c.expected)
+		}
+	}
+}
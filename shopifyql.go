@@ -0,0 +1,237 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const shopifyQLQuery = `query shopifyqlQuery($query: String!) {
+  shopifyqlQuery(query: $query) {
+    __typename
+    ... on TableResponse {
+      tableData {
+        columns {
+          name
+          dataType
+          displayName
+        }
+        rowData
+      }
+    }
+    parseErrors {
+      code
+      message
+      range {
+        start {
+          line
+          character
+        }
+      }
+    }
+  }
+}`
+
+// ShopifyQLService is an interface for running ShopifyQL analytics queries
+// through the GraphQL API.
+// See: https://shopify.dev/docs/api/shopifyql
+type ShopifyQLService interface {
+	Query(context.Context, string) (*ShopifyQLTable, error)
+}
+
+// ShopifyQLServiceOp handles communication with the ShopifyQL related
+// methods of the Shopify API.
+type ShopifyQLServiceOp struct {
+	client *Client
+}
+
+// ShopifyQL column data types
+const (
+	ShopifyQLTypeString         = "STRING"
+	ShopifyQLTypeInteger        = "INTEGER"
+	ShopifyQLTypeFloat          = "FLOAT"
+	ShopifyQLTypePercent        = "PERCENT"
+	ShopifyQLTypeMoney          = "MONEY"
+	ShopifyQLTypeBoolean        = "BOOLEAN"
+	ShopifyQLTypeDate           = "DATE"
+	ShopifyQLTypeDayTimestamp   = "DAY_TIMESTAMP"
+	ShopifyQLTypeWeekTimestamp  = "WEEK_TIMESTAMP"
+	ShopifyQLTypeMonthTimestamp = "MONTH_TIMESTAMP"
+	ShopifyQLTypeYearTimestamp  = "YEAR_TIMESTAMP"
+	ShopifyQLTypeHourTimestamp  = "HOUR_TIMESTAMP"
+)
+
+// ShopifyQLColumn is a column of a ShopifyQL result
+type ShopifyQLColumn struct {
+	Name        string `json:"name"`
+	DataType    string `json:"dataType"`
+	DisplayName string `json:"displayName"`
+}
+
+// ShopifyQLTable is the result of a ShopifyQL query. The values of Rows are
+// typed by their column: int64 for integers, float64 for floats and
+// percentages, decimal.Decimal for money, bool for booleans, time.Time for
+// dates and timestamps and string otherwise. Empty values are nil.
+type ShopifyQLTable struct {
+	Columns []ShopifyQLColumn
+	Rows    [][]interface{}
+}
+
+// ShopifyQLParseError is an error in the text of a ShopifyQL query
+type ShopifyQLParseError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Line      int    `json:"-"`
+	Character int    `json:"-"`
+}
+
+// ShopifyQLParseErrors is returned when Shopify can't parse a ShopifyQL query
+type ShopifyQLParseErrors []ShopifyQLParseError
+
+func (e ShopifyQLParseErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, parseErr := range e {
+		if parseErr.Line > 0 {
+			msgs = append(msgs, fmt.Sprintf("%d:%d: %s", parseErr.Line, parseErr.Character, parseErr.Message))
+		} else {
+			msgs = append(msgs, parseErr.Message)
+		}
+	}
+	return "shopifyql: " + strings.Join(msgs, "; ")
+}
+
+// Column returns the index of the named column, or -1
+func (t *ShopifyQLTable) Column(name string) int {
+	for i, c := range t.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Records returns the rows as maps from column names to values
+func (t *ShopifyQLTable) Records() []map[string]interface{} {
+	records := make([]map[string]interface{}, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]interface{}, len(t.Columns))
+		for i, c := range t.Columns {
+			if i < len(row) {
+				record[c.Name] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Query runs a ShopifyQL query, e.g.
+// "FROM sales SHOW total_sales BY month SINCE -3m"
+func (s *ShopifyQLServiceOp) Query(ctx context.Context, query string) (*ShopifyQLTable, error) {
+	resp := struct {
+		ShopifyqlQuery *struct {
+			Typename  string `json:"__typename"`
+			TableData *struct {
+				Columns []ShopifyQLColumn `json:"columns"`
+				RowData [][]*string       `json:"rowData"`
+			} `json:"tableData"`
+			ParseErrors []struct {
+				ShopifyQLParseError
+				Range *struct {
+					Start struct {
+						Line      int `json:"line"`
+						Character int `json:"character"`
+					} `json:"start"`
+				} `json:"range"`
+			} `json:"parseErrors"`
+		} `json:"shopifyqlQuery"`
+	}{}
+
+	vars := map[string]interface{}{"query": query}
+	err := s.client.GraphQL.Query(ctx, shopifyQLQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := resp.ShopifyqlQuery
+	if result == nil {
+		return nil, fmt.Errorf("shopifyql: no result")
+	}
+	if len(result.ParseErrors) > 0 {
+		parseErrs := make(ShopifyQLParseErrors, 0, len(result.ParseErrors))
+		for _, e := range result.ParseErrors {
+			parseErr := e.ShopifyQLParseError
+			if e.Range != nil {
+				parseErr.Line = e.Range.Start.Line
+				parseErr.Character = e.Range.Start.Character
+			}
+			parseErrs = append(parseErrs, parseErr)
+		}
+		return nil, parseErrs
+	}
+	if result.TableData == nil {
+		return nil, fmt.Errorf("shopifyql: unsupported response %s", result.Typename)
+	}
+
+	table := &ShopifyQLTable{
+		Columns: result.TableData.Columns,
+		Rows:    make([][]interface{}, 0, len(result.TableData.RowData)),
+	}
+	for _, raw := range result.TableData.RowData {
+		row := make([]interface{}, len(raw))
+		for i, value := range raw {
+			if i >= len(table.Columns) || value == nil {
+				continue
+			}
+			row[i], err = parseShopifyQLValue(table.Columns[i], *value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+func parseShopifyQLValue(column ShopifyQLColumn, value string) (interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var v interface{}
+	var err error
+	switch column.DataType {
+	case ShopifyQLTypeInteger:
+		v, err = strconv.ParseInt(value, 10, 64)
+	case ShopifyQLTypeFloat, ShopifyQLTypePercent:
+		v, err = strconv.ParseFloat(value, 64)
+	case ShopifyQLTypeMoney:
+		v, err = decimal.NewFromString(value)
+	case ShopifyQLTypeBoolean:
+		v, err = strconv.ParseBool(value)
+	case ShopifyQLTypeDate, ShopifyQLTypeDayTimestamp, ShopifyQLTypeWeekTimestamp,
+		ShopifyQLTypeMonthTimestamp, ShopifyQLTypeYearTimestamp, ShopifyQLTypeHourTimestamp:
+		v, err = parseShopifyQLTime(value)
+	default:
+		return value, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("shopifyql: column %s: invalid %s %q", column.Name, strings.ToLower(column.DataType), value)
+	}
+	return v, nil
+}
+
+// parseShopifyQLTime parses dates and timestamps, which are truncated to the
+// unit of their type
+func parseShopifyQLTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,142 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestShopifyQLQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"shopifyqlQuery":{
+			"__typename":"TableResponse",
+			"tableData":{
+				"columns":[
+					{"name":"month","dataType":"MONTH_TIMESTAMP","displayName":"Month"},
+					{"name":"orders","dataType":"INTEGER","displayName":"Orders"},
+					{"name":"total_sales","dataType":"MONEY","displayName":"Total sales"},
+					{"name":"conversion","dataType":"PERCENT","displayName":"Conversion"},
+					{"name":"channel","dataType":"STRING","displayName":"Channel"}
+				],
+				"rowData":[
+					["2024-01-01","12","1234.50","0.25","Online Store"],
+					["2024-02-01","0","0.00","",null]
+				]
+			},
+			"parseErrors":[]
+		}}}`
+	})
+
+	query := "FROM sales SHOW orders, total_sales BY month, channel SINCE -2m"
+	table, err := client.ShopifyQL.Query(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ShopifyQL.Query returned error: %v", err)
+	}
+	if vars["query"] != query {
+		t.Errorf("ShopifyQL.Query sent variables %v", vars)
+	}
+
+	if len(table.Columns) != 5 || table.Columns[2].DisplayName != "Total sales" {
+		t.Errorf("ShopifyQL.Query returned columns %+v", table.Columns)
+	}
+
+	expected := [][]interface{}{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), int64(12), decimal.RequireFromString("1234.50"), 0.25, "Online Store"},
+		{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), int64(0), decimal.RequireFromString("0.00"), nil, nil},
+	}
+	if !reflect.DeepEqual(table.Rows, expected) {
+		t.Errorf("ShopifyQL.Query returned rows %#v, expected %#v", table.Rows, expected)
+	}
+
+	if i := table.Column("total_sales"); i != 2 {
+		t.Errorf("ShopifyQLTable.Column returned %d, expected 2", i)
+	}
+	if i := table.Column("missing"); i != -1 {
+		t.Errorf("ShopifyQLTable.Column returned %d, expected -1", i)
+	}
+
+	records := table.Records()
+	if len(records) != 2 || records[0]["orders"] != int64(12) || records[1]["channel"] != nil {
+		t.Errorf("ShopifyQLTable.Records returned %v", records)
+	}
+}
+
+func TestShopifyQLQueryParseErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"shopifyqlQuery":{
+			"__typename":"TableResponse",
+			"tableData":null,
+			"parseErrors":[
+				{"code":"SYNTAX_NOT_RECOGNIZED","message":"Syntax not recognized","range":{"start":{"line":1,"character":5}}},
+				{"code":"UNKNOWN","message":"Something else","range":null}
+			]
+		}}}`
+	})
+
+	_, err := client.ShopifyQL.Query(context.Background(), "FROM salez SHOW total_sales")
+	parseErrs, ok := err.(ShopifyQLParseErrors)
+	if !ok {
+		t.Fatalf("ShopifyQL.Query returned %v, expected parse errors", err)
+	}
+	if parseErrs[0].Code != "SYNTAX_NOT_RECOGNIZED" || parseErrs[0].Line != 1 || parseErrs[0].Character != 5 {
+		t.Errorf("ShopifyQL.Query returned %+v", parseErrs[0])
+	}
+
+	expected := "shopifyql: 1:5: Syntax not recognized; Something else"
+	if err.Error() != expected {
+		t.Errorf("ShopifyQLParseErrors.Error returned %q, expected %q", err.Error(), expected)
+	}
+}
+
+func TestShopifyQLQueryInvalidValue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"shopifyqlQuery":{
+			"__typename":"TableResponse",
+			"tableData":{
+				"columns":[{"name":"orders","dataType":"INTEGER","displayName":"Orders"}],
+				"rowData":[["many"]]
+			},
+			"parseErrors":[]
+		}}}`
+	})
+
+	_, err := client.ShopifyQL.Query(context.Background(), "FROM sales SHOW orders")
+	expected := `shopifyql: column orders: invalid integer "many"`
+	if err == nil || err.Error() != expected {
+		t.Errorf("ShopifyQL.Query returned %v, expected %q", err, expected)
+	}
+}
+
+func TestParseShopifyQLTime(t *testing.T) {
+	cases := map[string]time.Time{
+		"2024-03-04T05:06:07Z": time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC),
+		"2024-03-04T05:06:07":  time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC),
+		"2024-03-04":           time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		"2024-03":              time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2024":                 time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for value, expected := range cases {
+		got, err := parseShopifyQLTime(value)
+		if err != nil || !got.Equal(expected) {
+			t.Errorf("parseShopifyQLTime(%q) returned %v, %v, expected %v", value, got, err, expected)
+		}
+	}
+
+	if _, err := parseShopifyQLTime("last week"); err == nil {
+		t.Error("parseShopifyQLTime accepted an invalid time")
+	}
+}
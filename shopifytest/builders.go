@@ -0,0 +1,390 @@
+// Package shopifytest provides builders and a corpus of realistic JSON
+// fixtures of Shopify resources for tests of code using the goshopify
+// package.
+package shopifytest
+
+import (
+	"encoding/json"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/shopspring/decimal"
+)
+
+// CreatedAt is the creation time of built resources, fixed so tests are
+// deterministic
+var CreatedAt = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+// OrderOption changes an order built by NewOrder
+type OrderOption func(*orderBuilder)
+
+type orderBuilder struct {
+	order goshopify.Order
+	rate  decimal.Decimal
+}
+
+// NewOrder returns a paid order of a line item in USD, changed by the
+// options. The prices of the order are computed from its line items in the
+// shop and presentment currencies.
+func NewOrder(opts ...OrderOption) *goshopify.Order {
+	createdAt := CreatedAt
+	b := &orderBuilder{
+		order: goshopify.Order{
+			Id:              450789469,
+			Name:            "#1001",
+			Number:          1,
+			OrderNumber:     1001,
+			Email:           "bob.norman@example.com",
+			CreatedAt:       &createdAt,
+			UpdatedAt:       &createdAt,
+			ProcessedAt:     &createdAt,
+			Currency:        "USD",
+			FinancialStatus: goshopify.OrderFinancialStatusPaid,
+			Gateway:         "bogus",
+			SourceName:      "web",
+			LineItems:       []goshopify.LineItem{*NewLineItem()},
+		},
+		rate: decimal.New(1, 0),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.order.PresentmentCurrency == "" {
+		b.order.PresentmentCurrency = b.order.Currency
+	}
+	b.computePrices()
+	return &b.order
+}
+
+// computePrices sets the prices of the order and its line items from the
+// line item prices and quantities
+func (b *orderBuilder) computePrices() {
+	o := &b.order
+	subtotal := decimal.Zero
+	for i := range o.LineItems {
+		li := &o.LineItems[i]
+		if li.Price == nil {
+			continue
+		}
+		li.PriceSet = b.amountSet(*li.Price)
+		subtotal = subtotal.Add(li.Price.Mul(decimal.New(int64(li.Quantity), 0)))
+	}
+
+	refunded := decimal.Zero
+	for _, refund := range o.Refunds {
+		for _, rli := range refund.RefundLineItems {
+			if rli.Subtotal != nil {
+				refunded = refunded.Add(*rli.Subtotal)
+			}
+		}
+	}
+	current := subtotal.Sub(refunded)
+
+	o.SubtotalPrice, o.SubtotalPriceSet = decimalPtr(subtotal), b.amountSet(subtotal)
+	o.TotalLineItemsPrice, o.TotalLineItemsPriceSet = decimalPtr(subtotal), b.amountSet(subtotal)
+	o.TotalPrice, o.TotalPriceSet = decimalPtr(subtotal), b.amountSet(subtotal)
+	o.CurrentSubtotalPrice, o.CurrentSubtotalPriceSet = decimalPtr(current), b.amountSet(current)
+	o.CurrentTotalPrice, o.CurrentTotalPriceSet = decimalPtr(current), b.amountSet(current)
+}
+
+func (b *orderBuilder) amountSet(amount decimal.Decimal) *goshopify.AmountSet {
+	return &goshopify.AmountSet{
+		ShopMoney:        goshopify.AmountSetEntry{Amount: decimalPtr(amount), CurrencyCode: b.order.Currency},
+		PresentmentMoney: goshopify.AmountSetEntry{Amount: decimalPtr(amount.Mul(b.rate).Round(2)), CurrencyCode: b.order.PresentmentCurrency},
+	}
+}
+
+// OrderId sets the id of the order
+func OrderId(id uint64) OrderOption {
+	return func(b *orderBuilder) { b.order.Id = id }
+}
+
+// OrderName sets the name of the order, e.g. #1002
+func OrderName(name string) OrderOption {
+	return func(b *orderBuilder) { b.order.Name = name }
+}
+
+// OrderEmail sets the email of the order
+func OrderEmail(email string) OrderOption {
+	return func(b *orderBuilder) { b.order.Email = email }
+}
+
+// OrderCurrency sets the shop currency of the order, e.g. EUR
+func OrderCurrency(currency string) OrderOption {
+	return func(b *orderBuilder) { b.order.Currency = currency }
+}
+
+// OrderPresentmentCurrency sets the currency the customer paid in and its
+// exchange rate to the shop currency, e.g. CAD at 1.35, for multi-currency
+// orders
+func OrderPresentmentCurrency(currency string, rate float64) OrderOption {
+	return func(b *orderBuilder) {
+		b.order.PresentmentCurrency = currency
+		b.rate = decimal.NewFromFloat(rate)
+	}
+}
+
+// OrderLineItems replaces the line items of the order
+func OrderLineItems(lineItems ...*goshopify.LineItem) OrderOption {
+	return func(b *orderBuilder) {
+		b.order.LineItems = b.order.LineItems[:0]
+		for _, li := range lineItems {
+			b.order.LineItems = append(b.order.LineItems, *li)
+		}
+	}
+}
+
+// OrderCustomer sets the customer of the order and the order's email
+func OrderCustomer(customer *goshopify.Customer) OrderOption {
+	return func(b *orderBuilder) {
+		b.order.Customer = customer
+		b.order.Email = customer.Email
+	}
+}
+
+// OrderRefund refunds a quantity of the line item with the given id, the
+// order is refunded or partially refunded
+func OrderRefund(lineItemId uint64, quantity int) OrderOption {
+	return func(b *orderBuilder) {
+		var lineItem *goshopify.LineItem
+		for i := range b.order.LineItems {
+			if b.order.LineItems[i].Id == lineItemId {
+				lineItem = &b.order.LineItems[i]
+			}
+		}
+		if lineItem == nil || lineItem.Price == nil {
+			panic("shopifytest: no line item to refund")
+		}
+
+		subtotal := lineItem.Price.Mul(decimal.New(int64(quantity), 0))
+		refundedAt := CreatedAt.Add(24 * time.Hour)
+		b.order.Refunds = append(b.order.Refunds, goshopify.Refund{
+			Id:        uint64(509562969 + len(b.order.Refunds)),
+			OrderId:   b.order.Id,
+			CreatedAt: &refundedAt,
+			Restock:   true,
+			RefundLineItems: []goshopify.RefundLineItem{{
+				Id:          uint64(104689539 + len(b.order.Refunds)),
+				Quantity:    quantity,
+				LineItemId:  lineItemId,
+				Subtotal:    decimalPtr(subtotal),
+				SubTotalSet: b.amountSet(subtotal),
+				RestockType: "return",
+			}},
+			Transactions: []goshopify.Transaction{{
+				Id:        uint64(179259969 + len(b.order.Refunds)),
+				OrderId:   b.order.Id,
+				Amount:    decimalPtr(subtotal),
+				Kind:      "refund",
+				Gateway:   b.order.Gateway,
+				Status:    "success",
+				CreatedAt: &refundedAt,
+				Currency:  b.order.Currency,
+			}},
+		})
+
+		refundedAll := true
+		for _, li := range b.order.LineItems {
+			if refundedQuantity(b.order.Refunds, li.Id) < li.Quantity {
+				refundedAll = false
+			}
+		}
+		if refundedAll {
+			b.order.FinancialStatus = goshopify.OrderFinancialStatusRefunded
+		} else {
+			b.order.FinancialStatus = goshopify.OrderFinancialStatusPartiallyRefunded
+		}
+	}
+}
+
+func refundedQuantity(refunds []goshopify.Refund, lineItemId uint64) int {
+	quantity := 0
+	for _, refund := range refunds {
+		for _, rli := range refund.RefundLineItems {
+			if rli.LineItemId == lineItemId {
+				quantity += rli.Quantity
+			}
+		}
+	}
+	return quantity
+}
+
+// OrderWith changes the order with f, for fields without an option
+func OrderWith(f func(*goshopify.Order)) OrderOption {
+	return func(b *orderBuilder) { f(&b.order) }
+}
+
+// LineItemOption changes a line item built by NewLineItem
+type LineItemOption func(*goshopify.LineItem)
+
+// NewLineItem returns a line item of a shippable product variant priced
+// 199.00, changed by the options
+func NewLineItem(opts ...LineItemOption) *goshopify.LineItem {
+	li := &goshopify.LineItem{
+		Id:                  466157049,
+		ProductId:           632910392,
+		VariantId:           39072856,
+		Title:               "IPod Nano - 8gb",
+		VariantTitle:        "green",
+		Name:                "IPod Nano - 8gb - green",
+		SKU:                 "IPOD2008GREEN",
+		Vendor:              "Apple",
+		Quantity:            1,
+		FulfillableQuantity: 1,
+		Price:               decimalPtr(decimal.RequireFromString("199.00")),
+		Grams:               200,
+		Taxable:             true,
+		RequiresShipping:    true,
+		FulfillmentService:  "manual",
+		ProductExists:       true,
+	}
+	for _, opt := range opts {
+		opt(li)
+	}
+	return li
+}
+
+// LineItemId sets the id of the line item
+func LineItemId(id uint64) LineItemOption {
+	return func(li *goshopify.LineItem) { li.Id = id }
+}
+
+// LineItemTitle sets the title and name of the line item
+func LineItemTitle(title string) LineItemOption {
+	return func(li *goshopify.LineItem) {
+		li.Title = title
+		li.Name = title
+		li.VariantTitle = ""
+	}
+}
+
+// LineItemSKU sets the SKU of the line item
+func LineItemSKU(sku string) LineItemOption {
+	return func(li *goshopify.LineItem) { li.SKU = sku }
+}
+
+// LineItemPrice sets the unit price of the line item, e.g. "19.99"
+func LineItemPrice(price string) LineItemOption {
+	return func(li *goshopify.LineItem) { li.Price = decimalPtr(decimal.RequireFromString(price)) }
+}
+
+// LineItemQuantity sets the quantity of the line item
+func LineItemQuantity(quantity int) LineItemOption {
+	return func(li *goshopify.LineItem) {
+		li.Quantity = quantity
+		li.FulfillableQuantity = quantity
+	}
+}
+
+// LineItemProperty adds a property to the line item, e.g. an engraving. The
+// value is decoded as JSON, e.g. a number or an object, if it is valid JSON.
+func LineItemProperty(name, value string) LineItemOption {
+	return func(li *goshopify.LineItem) {
+		var v interface{} = value
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			v = decoded
+		}
+		li.Properties = append(li.Properties, goshopify.NoteAttribute{Name: name, Value: v})
+	}
+}
+
+// CustomerOption changes a customer built by NewCustomer
+type CustomerOption func(*goshopify.Customer)
+
+// NewCustomer returns an enabled customer with a verified email, changed by
+// the options
+func NewCustomer(opts ...CustomerOption) *goshopify.Customer {
+	createdAt := CreatedAt
+	c := &goshopify.Customer{
+		Id:            207119551,
+		Email:         "bob.norman@example.com",
+		FirstName:     "Bob",
+		LastName:      "Norman",
+		State:         "enabled",
+		VerifiedEmail: true,
+		Phone:         "+16136120707",
+		CreatedAt:     &createdAt,
+		UpdatedAt:     &createdAt,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CustomerId sets the id of the customer
+func CustomerId(id uint64) CustomerOption {
+	return func(c *goshopify.Customer) { c.Id = id }
+}
+
+// CustomerEmail sets the email of the customer, empty for customers known by
+// their phone only
+func CustomerEmail(email string) CustomerOption {
+	return func(c *goshopify.Customer) {
+		c.Email = email
+		c.VerifiedEmail = email != ""
+	}
+}
+
+// CustomerName sets the first and last name of the customer
+func CustomerName(firstName, lastName string) CustomerOption {
+	return func(c *goshopify.Customer) {
+		c.FirstName = firstName
+		c.LastName = lastName
+	}
+}
+
+// ProductOption changes a product built by NewProduct
+type ProductOption func(*goshopify.Product)
+
+// NewProduct returns an active product without variants, changed by the
+// options
+func NewProduct(opts ...ProductOption) *goshopify.Product {
+	createdAt := CreatedAt
+	p := &goshopify.Product{
+		Id:          632910392,
+		Title:       "IPod Nano - 8GB",
+		Handle:      "ipod-nano",
+		Vendor:      "Apple",
+		ProductType: "Cult Products",
+		Status:      goshopify.ProductStatusActive,
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &createdAt,
+		PublishedAt: &createdAt,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ProductId sets the id of the product
+func ProductId(id uint64) ProductOption {
+	return func(p *goshopify.Product) { p.Id = id }
+}
+
+// ProductTitle sets the title of the product
+func ProductTitle(title string) ProductOption {
+	return func(p *goshopify.Product) { p.Title = title }
+}
+
+// ProductVariant adds a variant to the product with the given title, SKU and
+// price, e.g. "19.99"
+func ProductVariant(title, sku, price string) ProductOption {
+	return func(p *goshopify.Product) {
+		position := len(p.Variants) + 1
+		p.Variants = append(p.Variants, goshopify.Variant{
+			Id:        uint64(39072855 + position),
+			ProductId: p.Id,
+			Title:     title,
+			Sku:       sku,
+			Position:  position,
+			Price:     decimalPtr(decimal.RequireFromString(price)),
+		})
+	}
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -0,0 +1,97 @@
+package shopifytest
+
+import (
+	"testing"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+func TestNewOrderDefaults(t *testing.T) {
+	order := NewOrder()
+
+	if order.Id == 0 || len(order.LineItems) != 1 || order.FinancialStatus != goshopify.OrderFinancialStatusPaid {
+		t.Errorf("NewOrder returned %+v", order)
+	}
+	if order.TotalPrice.String() != "199" || order.TotalPriceSet.In("USD").String() != "199" {
+		t.Errorf("NewOrder total price is %s, %+v", order.TotalPrice, order.TotalPriceSet)
+	}
+}
+
+func TestNewOrderMultiCurrency(t *testing.T) {
+	order := NewOrder(
+		OrderPresentmentCurrency("CAD", 1.35),
+		OrderLineItems(
+			NewLineItem(LineItemId(1), LineItemPrice("10.00"), LineItemQuantity(3)),
+			NewLineItem(LineItemId(2), LineItemPrice("5.55")),
+		),
+	)
+
+	if order.SubtotalPrice.String() != "35.55" {
+		t.Errorf("NewOrder subtotal is %s, expected 35.55", order.SubtotalPrice)
+	}
+	if amount := order.TotalPriceSet.In("CAD"); amount == nil || amount.StringFixed(2) != "47.99" {
+		t.Errorf("NewOrder presentment total is %v, expected 47.99", amount)
+	}
+	if amount := order.LineItems[1].PriceSet.In("CAD"); amount == nil || amount.StringFixed(2) != "7.49" {
+		t.Errorf("NewOrder presentment line item price is %v, expected 7.49", amount)
+	}
+}
+
+func TestNewOrderRefund(t *testing.T) {
+	order := NewOrder(
+		OrderLineItems(
+			NewLineItem(LineItemId(1), LineItemPrice("10.00"), LineItemQuantity(2)),
+			NewLineItem(LineItemId(2), LineItemPrice("5.00")),
+		),
+		OrderRefund(1, 1),
+	)
+
+	if order.FinancialStatus != goshopify.OrderFinancialStatusPartiallyRefunded {
+		t.Errorf("NewOrder financial status is %s, expected partially_refunded", order.FinancialStatus)
+	}
+	if order.CurrentTotalPrice.String() != "15" || order.TotalPrice.String() != "25" {
+		t.Errorf("NewOrder current total is %s of %s, expected 15 of 25", order.CurrentTotalPrice, order.TotalPrice)
+	}
+	if len(order.Refunds) != 1 || order.Refunds[0].Transactions[0].Amount.String() != "10" {
+		t.Errorf("NewOrder refunds are %+v", order.Refunds)
+	}
+
+	order = NewOrder(OrderLineItems(NewLineItem(LineItemId(1))), OrderRefund(1, 1))
+	if order.FinancialStatus != goshopify.OrderFinancialStatusRefunded {
+		t.Errorf("NewOrder financial status is %s, expected refunded", order.FinancialStatus)
+	}
+}
+
+func TestLineItemProperty(t *testing.T) {
+	li := NewLineItem(
+		LineItemProperty("Engraving", "Für Zoë"),
+		LineItemProperty("Font size", "14"),
+		LineItemProperty("_bundle_id", ""),
+	)
+
+	expected := []goshopify.NoteAttribute{
+		{Name: "Engraving", Value: "Für Zoë"},
+		{Name: "Font size", Value: float64(14)},
+		{Name: "_bundle_id", Value: ""},
+	}
+	if len(li.Properties) != len(expected) {
+		t.Fatalf("NewLineItem properties are %+v, expected %+v", li.Properties, expected)
+	}
+	for i := range expected {
+		if li.Properties[i] != expected[i] {
+			t.Errorf("NewLineItem property %d is %+v, expected %+v", i, li.Properties[i], expected[i])
+		}
+	}
+}
+
+func TestNewCustomerAndProduct(t *testing.T) {
+	customer := NewCustomer(CustomerEmail(""), CustomerName("Dana", "Lee"))
+	if customer.Email != "" || customer.VerifiedEmail || customer.FirstName != "Dana" {
+		t.Errorf("NewCustomer returned %+v", customer)
+	}
+
+	product := NewProduct(ProductId(7), ProductVariant("S", "TEE-S", "25.00"), ProductVariant("M", "TEE-M", "25.00"))
+	if len(product.Variants) != 2 || product.Variants[1].Position != 2 || product.Variants[0].ProductId != 7 {
+		t.Errorf("NewProduct returned %+v", product)
+	}
+}
@@ -0,0 +1,328 @@
+package shopifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// Fixture names of the corpus
+const (
+	// OrderMinimal is an order with only the fields every order has
+	OrderMinimal = "order/minimal"
+	// OrderMultiCurrency is an order of a shop in USD paid in EUR, with
+	// duties and taxes
+	OrderMultiCurrency = "order/multi_currency"
+	// OrderPartiallyRefunded is an order with a refunded line item and a
+	// refunded shipping line
+	OrderPartiallyRefunded = "order/partially_refunded"
+	// OrderLineItemProperties is an order of line items with edge-case
+	// properties: private ones, empty, numeric and object values, unicode
+	OrderLineItemProperties = "order/line_item_properties"
+	// OrderEdited is an order whose line item quantity was reduced by an
+	// order edit
+	OrderEdited = "order/edited"
+	// ProductVariants is a product with options, variants and images
+	ProductVariants = "product/variants"
+	// CustomerPhoneOnly is a customer without email address
+	CustomerPhoneOnly = "customer/phone_only"
+)
+
+var fixtures = map[string]string{
+	OrderMinimal: `{
+  "id": 450789469,
+  "name": "#1001",
+  "email": "bob.norman@example.com",
+  "created_at": "2024-01-15T05:30:00-05:00",
+  "updated_at": "2024-01-15T05:30:00-05:00",
+  "currency": "USD",
+  "total_price": "199.00",
+  "subtotal_price": "199.00",
+  "financial_status": "paid",
+  "fulfillment_status": null,
+  "line_items": [
+    {
+      "id": 466157049,
+      "variant_id": 39072856,
+      "product_id": 632910392,
+      "title": "IPod Nano - 8gb",
+      "quantity": 1,
+      "price": "199.00",
+      "sku": "IPOD2008GREEN"
+    }
+  ]
+}`,
+
+	OrderMultiCurrency: `{
+  "id": 450789470,
+  "name": "#1002",
+  "email": "anna.schmidt@example.de",
+  "created_at": "2024-02-01T14:12:09+01:00",
+  "updated_at": "2024-02-01T14:12:11+01:00",
+  "currency": "USD",
+  "presentment_currency": "EUR",
+  "total_price": "235.51",
+  "total_price_set": {
+    "shop_money": {"amount": "235.51", "currency_code": "USD"},
+    "presentment_money": {"amount": "217.83", "currency_code": "EUR"}
+  },
+  "subtotal_price": "199.00",
+  "subtotal_price_set": {
+    "shop_money": {"amount": "199.00", "currency_code": "USD"},
+    "presentment_money": {"amount": "184.06", "currency_code": "EUR"}
+  },
+  "total_tax": "36.51",
+  "total_tax_set": {
+    "shop_money": {"amount": "36.51", "currency_code": "USD"},
+    "presentment_money": {"amount": "33.77", "currency_code": "EUR"}
+  },
+  "current_total_duties_set": {
+    "shop_money": {"amount": "12.40", "currency_code": "USD"},
+    "presentment_money": {"amount": "11.47", "currency_code": "EUR"}
+  },
+  "taxes_included": false,
+  "financial_status": "paid",
+  "payment_gateway_names": ["shopify_payments"],
+  "line_items": [
+    {
+      "id": 466157050,
+      "variant_id": 39072856,
+      "product_id": 632910392,
+      "title": "IPod Nano - 8gb",
+      "variant_title": "green",
+      "quantity": 1,
+      "price": "199.00",
+      "price_set": {
+        "shop_money": {"amount": "199.00", "currency_code": "USD"},
+        "presentment_money": {"amount": "184.06", "currency_code": "EUR"}
+      },
+      "sku": "IPOD2008GREEN",
+      "tax_lines": [
+        {
+          "title": "DE MwSt",
+          "price": "36.51",
+          "rate": 0.19,
+          "price_set": {
+            "shop_money": {"amount": "36.51", "currency_code": "USD"},
+            "presentment_money": {"amount": "33.77", "currency_code": "EUR"}
+          }
+        }
+      ],
+      "duties": [
+        {
+          "id": "2",
+          "harmonized_system_code": "520300",
+          "country_code_of_origin": "CN",
+          "shop_money": {"amount": "12.40", "currency_code": "USD"},
+          "presentment_money": {"amount": "11.47", "currency_code": "EUR"}
+        }
+      ]
+    }
+  ],
+  "shipping_address": {
+    "first_name": "Anna",
+    "last_name": "Schmidt",
+    "address1": "Friedrichstraße 43",
+    "city": "Berlin",
+    "zip": "10117",
+    "country": "Germany",
+    "country_code": "DE"
+  }
+}`,
+
+	OrderPartiallyRefunded: `{
+  "id": 450789471,
+  "name": "#1003",
+  "email": "bob.norman@example.com",
+  "created_at": "2024-03-04T09:00:00-05:00",
+  "updated_at": "2024-03-06T16:45:21-05:00",
+  "currency": "USD",
+  "total_price": "408.00",
+  "current_total_price": "209.00",
+  "subtotal_price": "398.00",
+  "current_subtotal_price": "199.00",
+  "financial_status": "partially_refunded",
+  "line_items": [
+    {"id": 466157051, "variant_id": 39072856, "title": "IPod Nano - 8gb", "quantity": 1, "price": "199.00", "current_quantity": 1},
+    {"id": 466157052, "variant_id": 39072857, "title": "IPod Nano - 8gb", "variant_title": "pink", "quantity": 1, "price": "199.00", "current_quantity": 0}
+  ],
+  "shipping_lines": [
+    {"id": 369256396, "title": "Free Shipping", "price": "10.00", "code": "Free Shipping", "source": "shopify"}
+  ],
+  "refunds": [
+    {
+      "id": 509562969,
+      "order_id": 450789471,
+      "created_at": "2024-03-06T16:45:20-05:00",
+      "note": "Wrong color",
+      "restock": true,
+      "refund_line_items": [
+        {
+          "id": 104689539,
+          "quantity": 1,
+          "line_item_id": 466157052,
+          "restock_type": "return",
+          "location_id": 487838322,
+          "subtotal": "199.00",
+          "total_tax": "0.00"
+        }
+      ],
+      "transactions": [
+        {
+          "id": 179259969,
+          "order_id": 450789471,
+          "kind": "refund",
+          "gateway": "bogus",
+          "status": "success",
+          "amount": "209.00",
+          "currency": "USD",
+          "parent_id": 389404469
+        }
+      ],
+      "order_adjustments": [
+        {"id": 1030976842, "order_id": 450789471, "refund_id": 509562969, "amount": "-10.00", "tax_amount": "0.00", "kind": "shipping_refund", "reason": "Shipping refund"}
+      ]
+    }
+  ]
+}`,
+
+	OrderLineItemProperties: `{
+  "id": 450789472,
+  "name": "#1004",
+  "email": "chloe@example.com",
+  "created_at": "2024-04-10T11:20:00-04:00",
+  "currency": "CAD",
+  "total_price": "84.00",
+  "financial_status": "paid",
+  "note_attributes": [
+    {"name": "gift_wrap", "value": "true"},
+    {"name": "delivery_date", "value": "2024-04-14"}
+  ],
+  "line_items": [
+    {
+      "id": 466157053,
+      "title": "Engraved Mug",
+      "quantity": 2,
+      "price": "42.00",
+      "properties": [
+        {"name": "Engraving", "value": "Für Zoë ♥ — 20 ans"},
+        {"name": "_bundle_id", "value": "b-8f2c"},
+        {"name": "Font size", "value": 14},
+        {"name": "Gift message", "value": ""},
+        {"name": "Options", "value": {"color": "navy", "handle": "left"}}
+      ]
+    }
+  ]
+}`,
+
+	OrderEdited: `{
+  "id": 450789473,
+  "name": "#1005",
+  "email": "bob.norman@example.com",
+  "created_at": "2024-05-02T08:00:00-04:00",
+  "updated_at": "2024-05-02T09:30:00-04:00",
+  "currency": "USD",
+  "total_price": "597.00",
+  "current_total_price": "398.00",
+  "financial_status": "partially_paid",
+  "line_items": [
+    {"id": 466157054, "variant_id": 39072856, "title": "IPod Nano - 8gb", "quantity": 3, "current_quantity": 2, "fulfillable_quantity": 2, "price": "199.00"}
+  ]
+}`,
+
+	ProductVariants: `{
+  "id": 632910393,
+  "title": "Classic Tee",
+  "handle": "classic-tee",
+  "vendor": "Acme",
+  "product_type": "Shirts",
+  "status": "active",
+  "tags": "cotton, summer",
+  "created_at": "2024-01-02T10:00:00-05:00",
+  "updated_at": "2024-01-03T10:00:00-05:00",
+  "options": [
+    {"id": 594680422, "product_id": 632910393, "name": "Size", "position": 1, "values": ["S", "M"]},
+    {"id": 594680423, "product_id": 632910393, "name": "Color", "position": 2, "values": ["White"]}
+  ],
+  "variants": [
+    {"id": 39072858, "product_id": 632910393, "title": "S / White", "sku": "TEE-S-WHT", "position": 1, "price": "25.00", "compare_at_price": "30.00", "option1": "S", "option2": "White", "inventory_item_id": 808950810, "inventory_quantity": 10},
+    {"id": 39072859, "product_id": 632910393, "title": "M / White", "sku": "TEE-M-WHT", "position": 2, "price": "25.00", "compare_at_price": null, "option1": "M", "option2": "White", "inventory_item_id": 808950811, "inventory_quantity": 0}
+  ],
+  "images": [
+    {"id": 850703190, "product_id": 632910393, "position": 1, "src": "https://cdn.shopify.com/s/files/1/0000/0001/products/tee.png?v=1704207600", "width": 1200, "height": 1200, "variant_ids": [39072858, 39072859]}
+  ]
+}`,
+
+	CustomerPhoneOnly: `{
+  "id": 207119552,
+  "email": null,
+  "first_name": "Dana",
+  "last_name": "Lee",
+  "phone": "+16135550142",
+  "state": "disabled",
+  "verified_email": false,
+  "orders_count": 1,
+  "total_spent": "42.00",
+  "tags": "",
+  "created_at": "2024-02-20T12:00:00-05:00",
+  "updated_at": "2024-02-20T12:05:00-05:00"
+}`,
+}
+
+// Fixture returns the JSON of a fixture of the corpus, e.g. OrderMultiCurrency.
+// It panics for unknown names.
+func Fixture(name string) []byte {
+	fixture, ok := fixtures[name]
+	if !ok {
+		panic(fmt.Sprintf("shopifytest: unknown fixture %s", name))
+	}
+	return []byte(fixture)
+}
+
+// FixtureNames returns the names of the fixtures of the corpus
+func FixtureNames() []string {
+	names := make([]string, 0, len(fixtures))
+	for name := range fixtures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadFixture decodes a fixture of the corpus into v
+func LoadFixture(name string, v interface{}) error {
+	return json.Unmarshal(Fixture(name), v)
+}
+
+// FixtureResponse wraps a fixture in its resource's root key, e.g.
+// {"order": ...}, as returned by the API, for mock responses
+func FixtureResponse(name string) []byte {
+	resource := strings.SplitN(name, "/", 2)[0]
+	return []byte(fmt.Sprintf(`{"%s":%s}`, resource, Fixture(name)))
+}
+
+// NewWebhookDelivery returns a delivery of a webhook of the topic with the
+// payload, which is encoded as JSON unless it already is a []byte, e.g. a
+// fixture
+func NewWebhookDelivery(topic string, payload interface{}) *goshopify.WebhookDelivery {
+	body, ok := payload.([]byte)
+	if !ok {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			panic(fmt.Sprintf("shopifytest: encoding webhook payload: %v", err))
+		}
+	}
+
+	triggeredAt := CreatedAt
+	return &goshopify.WebhookDelivery{
+		Topic:       topic,
+		ShopDomain:  "fooshop.myshopify.com",
+		WebhookId:   "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+		TriggeredAt: &triggeredAt,
+		Body:        body,
+	}
+}
@@ -0,0 +1,84 @@
+package shopifytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+// TestFixturesDecode keeps the corpus in sync with the models, every field of
+// a fixture must be decoded
+func TestFixturesDecode(t *testing.T) {
+	for _, name := range FixtureNames() {
+		var v interface{}
+		switch strings.SplitN(name, "/", 2)[0] {
+		case "order":
+			v = new(goshopify.Order)
+		case "product":
+			v = new(goshopify.Product)
+		case "customer":
+			v = new(goshopify.Customer)
+		default:
+			t.Fatalf("no model for fixture %s", name)
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(Fixture(name)))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			t.Errorf("decoding fixture %s returned error: %v", name, err)
+		}
+	}
+}
+
+func TestFixtureResponse(t *testing.T) {
+	resource := goshopify.OrderResource{}
+	if err := json.Unmarshal(FixtureResponse(OrderMultiCurrency), &resource); err != nil {
+		t.Fatalf("decoding fixture response returned error: %v", err)
+	}
+
+	order := resource.Order
+	if order == nil || order.PresentmentCurrency != "EUR" || order.TotalPriceSet.In("EUR").String() != "217.83" {
+		t.Errorf("FixtureResponse(%s) decoded to %+v", OrderMultiCurrency, order)
+	}
+}
+
+func TestLoadFixtureLineItemProperties(t *testing.T) {
+	order := goshopify.Order{}
+	if err := LoadFixture(OrderLineItemProperties, &order); err != nil {
+		t.Fatalf("LoadFixture returned error: %v", err)
+	}
+
+	properties := order.LineItems[0].Properties
+	if len(properties) != 5 || properties[0].Value != "Für Zoë ♥ — 20 ans" || properties[2].Value != float64(14) {
+		t.Errorf("LoadFixture decoded properties %+v", properties)
+	}
+}
+
+func TestFixtureUnknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Fixture of an unknown name didn't panic")
+		}
+	}()
+	Fixture("order/unknown")
+}
+
+func TestNewWebhookDelivery(t *testing.T) {
+	delivery := NewWebhookDelivery("orders/create", NewOrder(OrderId(1)))
+
+	order := goshopify.Order{}
+	if err := delivery.Decode(&order); err != nil {
+		t.Fatalf("WebhookDelivery.Decode returned error: %v", err)
+	}
+	if order.Id != 1 || delivery.Topic != "orders/create" {
+		t.Errorf("NewWebhookDelivery returned %+v with order %+v", delivery, order)
+	}
+
+	delivery = NewWebhookDelivery("orders/create", Fixture(OrderMinimal))
+	if !bytes.Equal(delivery.Body, Fixture(OrderMinimal)) {
+		t.Errorf("NewWebhookDelivery of a fixture returned body %s", delivery.Body)
+	}
+}
@@ -0,0 +1,124 @@
+package shopsync
+
+import (
+	"context"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+)
+
+const (
+	ResourceOrders    = "orders"
+	ResourceProducts  = "products"
+	ResourceCustomers = "customers"
+
+	pageLimit = 250
+)
+
+// Source describes how to list a resource incrementally.
+type Source struct {
+	Name string
+
+	// Options returns the list options for the first page of a window
+	// starting at since. A zero since means a full sync.
+	Options func(since time.Time) interface{}
+
+	// List fetches a single page of records.
+	List func(ctx context.Context, options interface{}) ([]Record, *goshopify.Pagination, error)
+}
+
+func listOptions(since time.Time) goshopify.ListOptions {
+	return goshopify.ListOptions{
+		Limit:        pageLimit,
+		UpdatedAtMin: since,
+		Order:        "updated_at asc",
+	}
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// OrderRecord wraps an order, e.g. from an orders/updated webhook, as a Record
+func OrderRecord(o goshopify.Order) Record {
+	return Record{Id: o.Id, UpdatedAt: timeOrZero(o.UpdatedAt), Value: o}
+}
+
+// ProductRecord wraps a product as a Record
+func ProductRecord(p goshopify.Product) Record {
+	return Record{Id: p.Id, UpdatedAt: timeOrZero(p.UpdatedAt), Value: p}
+}
+
+// CustomerRecord wraps a customer as a Record
+func CustomerRecord(c goshopify.Customer) Record {
+	return Record{Id: c.Id, UpdatedAt: timeOrZero(c.UpdatedAt), Value: c}
+}
+
+// OrderSource returns a Source listing orders of any status
+func OrderSource(client *goshopify.Client) Source {
+	return Source{
+		Name: ResourceOrders,
+		Options: func(since time.Time) interface{} {
+			return goshopify.OrderListOptions{
+				ListOptions: listOptions(since),
+				Status:      goshopify.OrderStatusAny,
+			}
+		},
+		List: func(ctx context.Context, options interface{}) ([]Record, *goshopify.Pagination, error) {
+			orders, pagination, err := client.Order.ListWithPagination(ctx, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			records := make([]Record, len(orders))
+			for i, o := range orders {
+				records[i] = OrderRecord(o)
+			}
+			return records, pagination, nil
+		},
+	}
+}
+
+// ProductSource returns a Source listing products
+func ProductSource(client *goshopify.Client) Source {
+	return Source{
+		Name: ResourceProducts,
+		Options: func(since time.Time) interface{} {
+			return listOptions(since)
+		},
+		List: func(ctx context.Context, options interface{}) ([]Record, *goshopify.Pagination, error) {
+			products, pagination, err := client.Product.ListWithPagination(ctx, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			records := make([]Record, len(products))
+			for i, p := range products {
+				records[i] = ProductRecord(p)
+			}
+			return records, pagination, nil
+		},
+	}
+}
+
+// CustomerSource returns a Source listing customers
+func CustomerSource(client *goshopify.Client) Source {
+	return Source{
+		Name: ResourceCustomers,
+		Options: func(since time.Time) interface{} {
+			return listOptions(since)
+		},
+		List: func(ctx context.Context, options interface{}) ([]Record, *goshopify.Pagination, error) {
+			customers, pagination, err := client.Customer.ListWithPagination(ctx, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			records := make([]Record, len(customers))
+			for i, c := range customers {
+				records[i] = CustomerRecord(c)
+			}
+			return records, pagination, nil
+		},
+	}
+}
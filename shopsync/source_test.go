@@ -0,0 +1,70 @@
+package shopsync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/jarcoal/httpmock"
+)
+
+func TestProductSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPage(
+		fmt.Sprintf("https://fooshop.myshopify.com/admin/api/%s/products.json?limit=250&order=updated_at+asc&updated_at_min=2024-01-01T00%%3A00%%3A00Z", testApiVersion),
+		"",
+		`{"products": [{"id":1,"updated_at":"2024-01-02T00:00:00Z"}]}`,
+	)
+
+	src := ProductSource(client)
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	records, _, err := src.List(context.Background(), src.Options(since))
+	if err != nil {
+		t.Fatalf("ProductSource.List returned error: %v", err)
+	}
+
+	if len(records) != 1 || records[0].Id != 1 || records[0].Value.(goshopify.Product).Id != 1 {
+		t.Errorf("ProductSource.List returned %+v, expected product 1", records)
+	}
+}
+
+func TestCustomerSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPage(
+		fmt.Sprintf("https://fooshop.myshopify.com/admin/api/%s/customers.json?limit=250&order=updated_at+asc", testApiVersion),
+		"",
+		`{"customers": [{"id":1,"updated_at":"2024-01-02T00:00:00Z"}]}`,
+	)
+
+	src := CustomerSource(client)
+	records, _, err := src.List(context.Background(), src.Options(time.Time{}))
+	if err != nil {
+		t.Fatalf("CustomerSource.List returned error: %v", err)
+	}
+
+	expected := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if len(records) != 1 || !records[0].UpdatedAt.Equal(expected) {
+		t.Errorf("CustomerSource.List returned %+v, expected customer updated at %v", records, expected)
+	}
+}
+
+func TestSourceListError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/admin/api/%s/orders.json", testApiVersion),
+		httpmock.NewStringResponder(500, ""))
+
+	src := OrderSource(client)
+	records, _, err := src.List(context.Background(), nil)
+	if err == nil || records != nil {
+		t.Errorf("OrderSource.List returned %+v, %v, expected an error", records, err)
+	}
+}
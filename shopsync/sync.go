@@ -0,0 +1,221 @@
+// Package shopsync incrementally synchronizes Shopify resources using
+// updated_at_min windows, webhook deltas and persisted checkpoints.
+package shopsync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Checkpoint records how far a resource has been synced for a shop.
+type Checkpoint struct {
+	Resource string `json:"resource"`
+
+	// UpdatedAt is the high-water mark of the synced records.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Seen holds the updated_at of records processed inside the overlap
+	// window, used to drop duplicates when windows overlap.
+	Seen map[uint64]time.Time `json:"seen,omitempty"`
+}
+
+// CheckpointStore persists checkpoints between runs.
+type CheckpointStore interface {
+	// Load returns the checkpoint for the shop and resource, or nil if there
+	// is none yet.
+	Load(ctx context.Context, shop, resource string) (*Checkpoint, error)
+	Save(ctx context.Context, shop string, checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore is an in-memory CheckpointStore, mostly useful for
+// tests and short lived processes.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewMemoryCheckpointStore returns an empty MemoryCheckpointStore
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]Checkpoint{}}
+}
+
+// Load returns a copy of the stored checkpoint
+func (s *MemoryCheckpointStore) Load(ctx context.Context, shop, resource string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp, ok := s.checkpoints[shop+"/"+resource]
+	if !ok {
+		return nil, nil
+	}
+
+	cp.Seen = copySeen(cp.Seen)
+	return &cp, nil
+}
+
+// Save stores a copy of the checkpoint
+func (s *MemoryCheckpointStore) Save(ctx context.Context, shop string, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint.Seen = copySeen(checkpoint.Seen)
+	s.checkpoints[shop+"/"+checkpoint.Resource] = checkpoint
+	return nil
+}
+
+func copySeen(seen map[uint64]time.Time) map[uint64]time.Time {
+	c := make(map[uint64]time.Time, len(seen))
+	for k, v := range seen {
+		c[k] = v
+	}
+	return c
+}
+
+// Record is a single synced resource along with the fields needed for
+// checkpointing. Value holds the decoded resource, e.g. a goshopify.Order.
+type Record struct {
+	Id        uint64
+	UpdatedAt time.Time
+	Value     interface{}
+}
+
+// Handler processes a single record. Returning an error stops the sync, the
+// checkpoint is only advanced past records that were handled successfully.
+type Handler func(ctx context.Context, record Record) error
+
+// Syncer incrementally syncs resources of a single shop.
+type Syncer struct {
+	Shop  string
+	Store CheckpointStore
+
+	// Overlap rewinds each window start by the given duration to pick up
+	// records whose updated_at was committed late. Records seen inside the
+	// overlap are deduplicated.
+	Overlap time.Duration
+
+	mu sync.Mutex
+}
+
+// Run lists all records of the source updated since the last checkpoint,
+// calls h for every record not processed before and saves the checkpoint
+// after every page.
+func (s *Syncer) Run(ctx context.Context, src Source, h Handler) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp, err := s.load(ctx, src.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	since := cp.UpdatedAt
+	if !since.IsZero() {
+		since = since.Add(-s.Overlap)
+	}
+
+	options := src.Options(since)
+	for {
+		records, pagination, err := src.List(ctx, options)
+		if err != nil {
+			return cp, err
+		}
+
+		for _, r := range records {
+			err = s.handle(ctx, cp, r, h)
+			if err != nil {
+				// keep the records handled before the failing one
+				if saveErr := s.save(ctx, cp); saveErr != nil {
+					return cp, fmt.Errorf("%w, saving the checkpoint failed: %v", err, saveErr)
+				}
+				return cp, err
+			}
+		}
+
+		err = s.save(ctx, cp)
+		if err != nil {
+			return cp, err
+		}
+
+		if pagination == nil || pagination.NextPageOptions == nil {
+			return cp, nil
+		}
+		options = pagination.NextPageOptions
+	}
+}
+
+// Delta processes a record received outside of a Run, typically from a
+// webhook. It is deduplicated against the checkpoint so the next Run does not
+// deliver it again, but it does not advance the high-water mark since older
+// records may still be missing. Like Run it prunes the records of the
+// checkpoint which fell out of the overlap window.
+func (s *Syncer) Delta(ctx context.Context, resource string, r Record, h Handler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp, err := s.load(ctx, resource)
+	if err != nil {
+		return err
+	}
+
+	if s.seen(cp, r) {
+		return nil
+	}
+
+	err = h(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	cp.Seen[r.Id] = r.UpdatedAt
+	return s.save(ctx, cp)
+}
+
+func (s *Syncer) load(ctx context.Context, resource string) (*Checkpoint, error) {
+	cp, err := s.Store.Load(ctx, s.Shop, resource)
+	if err != nil {
+		return nil, err
+	}
+	if cp == nil {
+		cp = &Checkpoint{Resource: resource}
+	}
+	if cp.Seen == nil {
+		cp.Seen = map[uint64]time.Time{}
+	}
+	return cp, nil
+}
+
+func (s *Syncer) seen(cp *Checkpoint, r Record) bool {
+	prev, ok := cp.Seen[r.Id]
+	return ok && !r.UpdatedAt.After(prev)
+}
+
+func (s *Syncer) handle(ctx context.Context, cp *Checkpoint, r Record, h Handler) error {
+	// records already delivered by a Delta are skipped but still advance
+	// the high-water mark
+	if !s.seen(cp, r) {
+		err := h(ctx, r)
+		if err != nil {
+			return err
+		}
+		cp.Seen[r.Id] = r.UpdatedAt
+	}
+
+	if r.UpdatedAt.After(cp.UpdatedAt) {
+		cp.UpdatedAt = r.UpdatedAt
+	}
+	return nil
+}
+
+// save prunes records that fell out of the overlap window and persists the
+// checkpoint.
+func (s *Syncer) save(ctx context.Context, cp *Checkpoint) error {
+	cutoff := cp.UpdatedAt.Add(-s.Overlap)
+	for id, t := range cp.Seen {
+		if t.Before(cutoff) {
+			delete(cp.Seen, id)
+		}
+	}
+	return s.Store.Save(ctx, s.Shop, *cp)
+}
@@ -0,0 +1,249 @@
+package shopsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	goshopify "github.com/bold-commerce/go-shopify/v4"
+	"github.com/jarcoal/httpmock"
+)
+
+const testApiVersion = "9999-99"
+
+var client *goshopify.Client
+
+func setup() {
+	client = goshopify.MustNewClient(goshopify.App{}, "fooshop", "abcd",
+		goshopify.WithVersion(testApiVersion))
+	httpmock.ActivateNonDefault(client.Client)
+}
+
+func teardown() {
+	httpmock.DeactivateAndReset()
+}
+
+func registerPage(url, link, body string) {
+	response := &http.Response{
+		StatusCode: 200,
+		Body:       httpmock.NewRespBodyFromString(body),
+		Header:     http.Header{"Link": {link}},
+	}
+	httpmock.RegisterResponder("GET", url, httpmock.ResponderFromResponse(response))
+}
+
+// staticSource serves the given pages of records in order
+func staticSource(pages ...[]Record) Source {
+	return Source{
+		Name: "things",
+		Options: func(since time.Time) interface{} {
+			return nil
+		},
+		List: func(ctx context.Context, options interface{}) ([]Record, *goshopify.Pagination, error) {
+			i := 0
+			if o, ok := options.(*goshopify.ListOptions); ok {
+				i = o.Page
+			}
+			pagination := new(goshopify.Pagination)
+			if i+1 < len(pages) {
+				pagination.NextPageOptions = &goshopify.ListOptions{Page: i + 1}
+			}
+			return pages[i], pagination, nil
+		},
+	}
+}
+
+func TestSyncerRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerPage(
+		fmt.Sprintf("https://fooshop.myshopify.com/admin/api/%s/orders.json?limit=250&order=updated_at+asc&status=any", testApiVersion),
+		`<http://valid.url?page_info=pg2>; rel="next"`,
+		`{"orders": [{"id":1,"updated_at":"2024-01-01T00:00:00Z"},{"id":2,"updated_at":"2024-01-02T00:00:00Z"}]}`,
+	)
+	registerPage(
+		fmt.Sprintf("https://fooshop.myshopify.com/admin/api/%s/orders.json?page_info=pg2", testApiVersion),
+		"",
+		`{"orders": [{"id":3,"updated_at":"2024-01-03T00:00:00Z"}]}`,
+	)
+
+	store := NewMemoryCheckpointStore()
+	s := &Syncer{Shop: "fooshop", Store: store}
+
+	var ids []uint64
+	cp, err := s.Run(context.Background(), OrderSource(client), func(ctx context.Context, r Record) error {
+		ids = append(ids, r.Value.(goshopify.Order).Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Syncer.Run returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []uint64{1, 2, 3}) {
+		t.Errorf("Syncer.Run handled %v, expected %v", ids, []uint64{1, 2, 3})
+	}
+
+	expected := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	if !cp.UpdatedAt.Equal(expected) {
+		t.Errorf("Syncer.Run checkpoint at %v, expected %v", cp.UpdatedAt, expected)
+	}
+
+	stored, _ := store.Load(context.Background(), "fooshop", ResourceOrders)
+	if stored == nil || !stored.UpdatedAt.Equal(expected) {
+		t.Errorf("Syncer.Run stored checkpoint %+v, expected updated at %v", stored, expected)
+	}
+}
+
+func TestSyncerRunDeduplicatesOverlap(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+
+	store := NewMemoryCheckpointStore()
+	s := &Syncer{Shop: "fooshop", Store: store, Overlap: time.Hour}
+
+	var handled []Record
+	h := func(ctx context.Context, r Record) error {
+		handled = append(handled, r)
+		return nil
+	}
+
+	_, err := s.Run(context.Background(), staticSource([]Record{{Id: 1, UpdatedAt: t1}, {Id: 2, UpdatedAt: t2}}), h)
+	if err != nil {
+		t.Fatalf("Syncer.Run returned error: %v", err)
+	}
+
+	// the second window overlaps the first, only the new update of 1 and the
+	// new record 3 must be delivered
+	t3 := t2.Add(time.Minute)
+	_, err = s.Run(context.Background(), staticSource([]Record{{Id: 2, UpdatedAt: t2}}, []Record{{Id: 1, UpdatedAt: t3}, {Id: 3, UpdatedAt: t3}}), h)
+	if err != nil {
+		t.Fatalf("Syncer.Run returned error: %v", err)
+	}
+
+	expected := []Record{{Id: 1, UpdatedAt: t1}, {Id: 2, UpdatedAt: t2}, {Id: 1, UpdatedAt: t3}, {Id: 3, UpdatedAt: t3}}
+	if !reflect.DeepEqual(handled, expected) {
+		t.Errorf("Syncer.Run handled %+v, expected %+v", handled, expected)
+	}
+}
+
+func TestSyncerRunHandlerError(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryCheckpointStore()
+	s := &Syncer{Shop: "fooshop", Store: store}
+
+	handlerErr := errors.New("handler failed")
+	cp, err := s.Run(context.Background(), staticSource([]Record{{Id: 1, UpdatedAt: t1}, {Id: 2, UpdatedAt: t1.Add(time.Minute)}}), func(ctx context.Context, r Record) error {
+		if r.Id == 2 {
+			return handlerErr
+		}
+		return nil
+	})
+	if err != handlerErr {
+		t.Errorf("Syncer.Run err returned %v, expected %v", err, handlerErr)
+	}
+
+	if !cp.UpdatedAt.Equal(t1) {
+		t.Errorf("Syncer.Run checkpoint at %v, expected %v", cp.UpdatedAt, t1)
+	}
+
+	stored, _ := store.Load(context.Background(), "fooshop", "things")
+	if stored == nil || !stored.UpdatedAt.Equal(t1) {
+		t.Errorf("Syncer.Run stored checkpoint %+v, expected updated at %v", stored, t1)
+	}
+}
+
+func TestSyncerDelta(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryCheckpointStore()
+	s := &Syncer{Shop: "fooshop", Store: store, Overlap: time.Hour}
+
+	count := 0
+	h := func(ctx context.Context, r Record) error {
+		count++
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		err := s.Delta(context.Background(), "things", Record{Id: 1, UpdatedAt: t1}, h)
+		if err != nil {
+			t.Fatalf("Syncer.Delta returned error: %v", err)
+		}
+	}
+
+	_, err := s.Run(context.Background(), staticSource([]Record{{Id: 1, UpdatedAt: t1}}), h)
+	if err != nil {
+		t.Fatalf("Syncer.Run returned error: %v", err)
+	}
+
+	if count != 1 {
+		t.Errorf("Syncer.Delta handled %d records, expected %d", count, 1)
+	}
+
+	cp, _ := store.Load(context.Background(), "fooshop", "things")
+	if cp.UpdatedAt.IsZero() {
+		t.Errorf("Syncer.Run did not advance the checkpoint past a delta")
+	}
+}
+
+// failingStore fails to save checkpoints
+type failingStore struct {
+	*MemoryCheckpointStore
+	err error
+}
+
+func (s failingStore) Save(ctx context.Context, shop string, checkpoint Checkpoint) error {
+	return s.err
+}
+
+func TestSyncerRunHandlerErrorSaveFails(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	saveErr := errors.New("store down")
+	s := &Syncer{Shop: "fooshop", Store: failingStore{NewMemoryCheckpointStore(), saveErr}}
+
+	handlerErr := errors.New("handler failed")
+	_, err := s.Run(context.Background(), staticSource([]Record{{Id: 1, UpdatedAt: t1}}), func(ctx context.Context, r Record) error {
+		return handlerErr
+	})
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("Syncer.Run err returned %v, expected it to wrap %v", err, handlerErr)
+	}
+	if err == nil || !strings.Contains(err.Error(), saveErr.Error()) {
+		t.Errorf("Syncer.Run err returned %v, expected it to mention %v", err, saveErr)
+	}
+}
+
+func TestSyncerDeltaPrunesSeen(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryCheckpointStore()
+	s := &Syncer{Shop: "fooshop", Store: store, Overlap: time.Hour}
+
+	// record 1 is out of the overlap window but wasn't pruned yet
+	err := store.Save(context.Background(), "fooshop", Checkpoint{
+		Resource:  "things",
+		UpdatedAt: t1.Add(2 * time.Hour),
+		Seen:      map[uint64]time.Time{1: t1, 2: t1.Add(2 * time.Hour)},
+	})
+	if err != nil {
+		t.Fatalf("MemoryCheckpointStore.Save returned error: %v", err)
+	}
+
+	h := func(ctx context.Context, r Record) error {
+		return nil
+	}
+	err = s.Delta(context.Background(), "things", Record{Id: 3, UpdatedAt: t1.Add(3 * time.Hour)}, h)
+	if err != nil {
+		t.Fatalf("Syncer.Delta returned error: %v", err)
+	}
+
+	cp, _ := store.Load(context.Background(), "fooshop", "things")
+	expected := map[uint64]time.Time{2: t1.Add(2 * time.Hour), 3: t1.Add(3 * time.Hour)}
+	if !reflect.DeepEqual(cp.Seen, expected) {
+		t.Errorf("Syncer.Delta saved seen records %v, expected %v", cp.Seen, expected)
+	}
+}
@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -21,6 +22,7 @@ type SmartCollectionService interface {
 	Create(context.Context, SmartCollection) (*SmartCollection, error)
 	Update(context.Context, SmartCollection) (*SmartCollection, error)
 	Delete(context.Context, uint64) error
+	Order(context.Context, uint64, SmartCollectionOrderOptions) error
 
 	// MetafieldsService used for SmartCollection resource to communicate with Metafields resource
 	MetafieldsService
@@ -32,10 +34,124 @@ type SmartCollectionServiceOp struct {
 	client *Client
 }
 
+// RuleColumn is the product property a smart collection rule matches on
+type RuleColumn string
+
+const (
+	RuleColumnTitle                 RuleColumn = "title"
+	RuleColumnType                  RuleColumn = "type"
+	RuleColumnVendor                RuleColumn = "vendor"
+	RuleColumnTag                   RuleColumn = "tag"
+	RuleColumnVariantTitle          RuleColumn = "variant_title"
+	RuleColumnVariantPrice          RuleColumn = "variant_price"
+	RuleColumnVariantCompareAtPrice RuleColumn = "variant_compare_at_price"
+	RuleColumnVariantWeight         RuleColumn = "variant_weight"
+	RuleColumnVariantInventory      RuleColumn = "variant_inventory"
+	RuleColumnIsPriceReduced        RuleColumn = "is_price_reduced"
+)
+
+// RuleRelation is how a smart collection rule compares the column with its
+// condition
+type RuleRelation string
+
+const (
+	RuleRelationEquals      RuleRelation = "equals"
+	RuleRelationNotEquals   RuleRelation = "not_equals"
+	RuleRelationGreaterThan RuleRelation = "greater_than"
+	RuleRelationLessThan    RuleRelation = "less_than"
+	RuleRelationStartsWith  RuleRelation = "starts_with"
+	RuleRelationEndsWith    RuleRelation = "ends_with"
+	RuleRelationContains    RuleRelation = "contains"
+	RuleRelationNotContains RuleRelation = "not_contains"
+	RuleRelationIsSet       RuleRelation = "is_set"
+	RuleRelationIsNotSet    RuleRelation = "is_not_set"
+)
+
+// Rule is a condition products must meet to be part of a smart collection,
+// e.g. Rule{RuleColumnVariantPrice, RuleRelationLessThan, "20"}
 type Rule struct {
-	Column    string `json:"column"`
-	Relation  string `json:"relation"`
-	Condition string `json:"condition"`
+	Column    RuleColumn   `json:"column"`
+	Relation  RuleRelation `json:"relation"`
+	Condition string       `json:"condition"`
+}
+
+// relations supported by each column, see
+// https://shopify.dev/docs/api/admin-rest/latest/resources/smartcollection
+var ruleRelations = map[RuleColumn][]RuleRelation{
+	RuleColumnTitle:                 {RuleRelationEquals, RuleRelationNotEquals, RuleRelationStartsWith, RuleRelationEndsWith, RuleRelationContains, RuleRelationNotContains},
+	RuleColumnType:                  {RuleRelationEquals, RuleRelationNotEquals, RuleRelationStartsWith, RuleRelationEndsWith, RuleRelationContains, RuleRelationNotContains},
+	RuleColumnVendor:                {RuleRelationEquals, RuleRelationNotEquals, RuleRelationStartsWith, RuleRelationEndsWith, RuleRelationContains, RuleRelationNotContains},
+	RuleColumnVariantTitle:          {RuleRelationEquals, RuleRelationNotEquals, RuleRelationStartsWith, RuleRelationEndsWith, RuleRelationContains, RuleRelationNotContains},
+	RuleColumnTag:                   {RuleRelationEquals},
+	RuleColumnVariantPrice:          {RuleRelationEquals, RuleRelationNotEquals, RuleRelationGreaterThan, RuleRelationLessThan},
+	RuleColumnVariantCompareAtPrice: {RuleRelationEquals, RuleRelationNotEquals, RuleRelationGreaterThan, RuleRelationLessThan},
+	RuleColumnVariantWeight:         {RuleRelationEquals, RuleRelationNotEquals, RuleRelationGreaterThan, RuleRelationLessThan},
+	RuleColumnVariantInventory:      {RuleRelationEquals, RuleRelationNotEquals, RuleRelationGreaterThan, RuleRelationLessThan},
+	RuleColumnIsPriceReduced:        {RuleRelationIsSet, RuleRelationIsNotSet},
+}
+
+// Validate reports whether the relation is supported by the column and the
+// condition is usable with it. Columns unknown to the library, such as
+// metafield columns, are not checked.
+func (r Rule) Validate() error {
+	relations, ok := ruleRelations[r.Column]
+	if !ok {
+		return nil
+	}
+
+	supported := false
+	for _, relation := range relations {
+		supported = supported || relation == r.Relation
+	}
+	if !supported {
+		return fmt.Errorf("smart collection rule: column %s does not support relation %s", r.Column, r.Relation)
+	}
+
+	switch r.Column {
+	case RuleColumnIsPriceReduced:
+	case RuleColumnVariantPrice, RuleColumnVariantCompareAtPrice, RuleColumnVariantWeight, RuleColumnVariantInventory:
+		if _, err := strconv.ParseFloat(r.Condition, 64); err != nil {
+			return fmt.Errorf("smart collection rule: column %s needs a numeric condition, got %q", r.Column, r.Condition)
+		}
+	default:
+		if r.Condition == "" {
+			return fmt.Errorf("smart collection rule: column %s needs a condition", r.Column)
+		}
+	}
+	return nil
+}
+
+// Validate validates the rules of the collection, see Rule.Validate
+func (c SmartCollection) Validate() error {
+	for _, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Collection sort orders, manual allows ordering the products with Order
+const (
+	CollectionSortOrderAlphaAsc    = "alpha-asc"
+	CollectionSortOrderAlphaDesc   = "alpha-desc"
+	CollectionSortOrderBestSelling = "best-selling"
+	CollectionSortOrderCreated     = "created"
+	CollectionSortOrderCreatedDesc = "created-desc"
+	CollectionSortOrderManual      = "manual"
+	CollectionSortOrderPriceAsc    = "price-asc"
+	CollectionSortOrderPriceDesc   = "price-desc"
+)
+
+// SmartCollectionOrderOptions are the options of ordering the products of a
+// smart collection
+type SmartCollectionOrderOptions struct {
+	// product ids in the order they should appear, products left out follow
+	// them
+	Products []uint64 `url:"products[],omitempty"`
+	// optionally change the sort order of the collection at the same time,
+	// products can only be ordered in manually sorted collections
+	SortOrder string `url:"sort_order,omitempty"`
 }
 
 // SmartCollection represents a Shopify smart collection.
@@ -112,6 +228,12 @@ func (s *SmartCollectionServiceOp) Delete(ctx context.Context, collectionId uint
 	return s.client.Delete(ctx, fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionId))
 }
 
+// Order sets the order of the products in a manually sorted smart collection
+func (s *SmartCollectionServiceOp) Order(ctx context.Context, collectionId uint64, options SmartCollectionOrderOptions) error {
+	path := fmt.Sprintf("%s/%d/order.json", smartCollectionsBasePath, collectionId)
+	return s.client.CreateAndDo(ctx, "PUT", path, nil, options, nil)
+}
+
 // List metafields for a smart collection
 func (s *SmartCollectionServiceOp) ListMetafields(ctx context.Context, smartCollectionId uint64, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceId: smartCollectionId}
@@ -3,6 +3,8 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
@@ -22,8 +24,8 @@ func smartCollectionTests(t *testing.T, collection SmartCollection) {
 		{"Title", "Macbooks", collection.Title},
 		{"BodyHTML", "Macbook Body", collection.BodyHTML},
 		{"SortOrder", "best-selling", collection.SortOrder},
-		{"Column", "title", collection.Rules[0].Column},
-		{"Relation", "contains", collection.Rules[0].Relation},
+		{"Column", RuleColumnTitle, collection.Rules[0].Column},
+		{"Relation", RuleRelationContains, collection.Rules[0].Relation},
 		{"Condition", "mac", collection.Rules[0].Condition},
 		{"Disjunctive", true, collection.Disjunctive},
 	}
@@ -159,6 +161,61 @@ func TestSmartCollectionDelete(t *testing.T) {
 	}
 }
 
+func TestSmartCollectionOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query url.Values
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://fooshop.myshopify.com/%s/smart_collections/1/order.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	err := client.SmartCollection.Order(context.Background(), 1, SmartCollectionOrderOptions{
+		Products:  []uint64{921728736, 632910392},
+		SortOrder: CollectionSortOrderManual,
+	})
+	if err != nil {
+		t.Errorf("SmartCollection.Order returned error: %v", err)
+	}
+
+	expected := url.Values{"products[]": {"921728736", "632910392"}, "sort_order": {"manual"}}
+	if !reflect.DeepEqual(query, expected) {
+		t.Errorf("SmartCollection.Order sent %v, expected %v", query, expected)
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	cases := []struct {
+		rule     Rule
+		expected string
+	}{
+		{Rule{RuleColumnTitle, RuleRelationContains, "mac"}, ""},
+		{Rule{RuleColumnTag, RuleRelationEquals, "sale"}, ""},
+		{Rule{RuleColumnVariantPrice, RuleRelationLessThan, "19.99"}, ""},
+		{Rule{RuleColumnIsPriceReduced, RuleRelationIsSet, ""}, ""},
+		{Rule{"product_metafield_definition", "equals", "anything"}, ""},
+		{Rule{RuleColumnTag, RuleRelationContains, "sale"}, "smart collection rule: column tag does not support relation contains"},
+		{Rule{RuleColumnVariantWeight, RuleRelationGreaterThan, "heavy"}, `smart collection rule: column variant_weight needs a numeric condition, got "heavy"`},
+		{Rule{RuleColumnVendor, RuleRelationEquals, ""}, "smart collection rule: column vendor needs a condition"},
+	}
+	for _, c := range cases {
+		err := c.rule.Validate()
+		if (err == nil && c.expected != "") || (err != nil && err.Error() != c.expected) {
+			t.Errorf("Rule%+v.Validate returned %v, expected %q", c.rule, err, c.expected)
+		}
+	}
+
+	collection := SmartCollection{Disjunctive: true, Rules: []Rule{
+		{RuleColumnTitle, RuleRelationContains, "mac"},
+		{RuleColumnIsPriceReduced, RuleRelationEquals, "true"},
+	}}
+	if err := collection.Validate(); err == nil {
+		t.Error("SmartCollection.Validate accepted an invalid rule")
+	}
+}
+
 func TestSmartCollectionListMetafields(t *testing.T) {
 	setup()
 	defer teardown()
@@ -0,0 +1,121 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+)
+
+const stagedUploadsCreateMutation = `mutation stagedUploadsCreate($input: [StagedUploadInput!]!) {
+	stagedUploadsCreate(input: $input) {
+		stagedTargets {
+			url
+			resourceUrl
+			parameters { name value }
+		}
+		userErrors { field message }
+	}
+}`
+
+type stagedUploadParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// stagedUploadTarget is where a file is uploaded before a mutation uses it
+type stagedUploadTarget struct {
+	Url         string                  `json:"url"`
+	ResourceUrl string                  `json:"resourceUrl"`
+	Parameters  []stagedUploadParameter `json:"parameters"`
+}
+
+func (t *stagedUploadTarget) parameter(name string) string {
+	for _, param := range t.Parameters {
+		if param.Name == name {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// createStagedUpload returns the target of a staged upload of the input, a
+// StagedUploadInput
+func (c *Client) createStagedUpload(ctx context.Context, input map[string]interface{}) (*stagedUploadTarget, error) {
+	resp := struct {
+		StagedUploadsCreate struct {
+			StagedTargets []stagedUploadTarget `json:"stagedTargets"`
+			UserErrors    GraphQLUserErrors    `json:"userErrors"`
+		} `json:"stagedUploadsCreate"`
+	}{}
+	vars := map[string]interface{}{"input": []map[string]interface{}{input}}
+	err := c.GraphQL.Query(ctx, stagedUploadsCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.StagedUploadsCreate.UserErrors) > 0 {
+		return nil, resp.StagedUploadsCreate.UserErrors
+	}
+	if len(resp.StagedUploadsCreate.StagedTargets) == 0 {
+		return nil, fmt.Errorf("no staged upload target returned")
+	}
+	return &resp.StagedUploadsCreate.StagedTargets[0], nil
+}
+
+// postStagedUpload posts the form parameters of the target followed by the
+// file written by write, streaming it rather than holding it in memory.
+func (c *Client) postStagedUpload(ctx context.Context, target *stagedUploadTarget, filename string, write func(io.Writer) error) error {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	form := multipart.NewWriter(pw)
+
+	writeErr := make(chan error, 1)
+	go func() {
+		err := writeStagedUploadForm(form, target.Parameters, filename, write)
+		pw.CloseWithError(err)
+		writeErr <- err
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, target.Url, pr)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		// the request fails when writing the file does, report why
+		pr.CloseWithError(err)
+		if fileErr := <-writeErr; fileErr != nil && fileErr != io.ErrClosedPipe {
+			return fileErr
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return ResponseError{Status: resp.StatusCode, Message: string(body)}
+	}
+	return nil
+}
+
+func writeStagedUploadForm(form *multipart.Writer, params []stagedUploadParameter, filename string, write func(io.Writer) error) error {
+	for _, param := range params {
+		if err := form.WriteField(param.Name, param.Value); err != nil {
+			return err
+		}
+	}
+
+	file, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		return err
+	}
+	return form.Close()
+}
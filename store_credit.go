@@ -0,0 +1,242 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const storeCreditAccountsQuery = `query customerStoreCreditAccounts($id: ID!) {
+  customer(id: $id) {
+    storeCreditAccounts(first: 50) {
+      nodes {
+        id
+        balance {
+          amount
+          currencyCode
+        }
+      }
+    }
+  }
+}`
+
+const storeCreditAccountQuery = `query storeCreditAccount($id: ID!) {
+  storeCreditAccount(id: $id) {
+    id
+    balance {
+      amount
+      currencyCode
+    }
+  }
+}`
+
+const storeCreditTransactionFields = `
+      amount {
+        amount
+        currencyCode
+      }
+      balanceAfterTransaction {
+        amount
+        currencyCode
+      }
+      createdAt
+      account {
+        id
+      }`
+
+const storeCreditAccountCreditMutation = `mutation storeCreditAccountCredit($id: ID!, $creditInput: StoreCreditAccountCreditInput!) {
+  storeCreditAccountCredit(id: $id, creditInput: $creditInput) {
+    storeCreditAccountTransaction {` + storeCreditTransactionFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const storeCreditAccountDebitMutation = `mutation storeCreditAccountDebit($id: ID!, $debitInput: StoreCreditAccountDebitInput!) {
+  storeCreditAccountDebit(id: $id, debitInput: $debitInput) {
+    storeCreditAccountTransaction {` + storeCreditTransactionFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// StoreCreditService is an interface for interfacing with the store credit
+// account GraphQL APIs. A customer has one store credit account per currency.
+// See: https://shopify.dev/docs/apps/build/store-credit
+type StoreCreditService interface {
+	ListAccounts(context.Context, uint64) ([]StoreCreditAccount, error)
+	GetAccount(context.Context, uint64) (*StoreCreditAccount, error)
+	CreditCustomer(context.Context, uint64, StoreCreditAdjustment) (*StoreCreditTransaction, error)
+	Credit(context.Context, uint64, StoreCreditAdjustment) (*StoreCreditTransaction, error)
+	Debit(context.Context, uint64, StoreCreditAdjustment) (*StoreCreditTransaction, error)
+}
+
+// StoreCreditServiceOp handles communication with the store credit related
+// methods of the Shopify API.
+type StoreCreditServiceOp struct {
+	client *Client
+}
+
+// StoreCreditAccount is the store credit balance of a customer in a currency
+type StoreCreditAccount struct {
+	Id       uint64
+	Balance  *decimal.Decimal
+	Currency string
+}
+
+// StoreCreditAdjustment is a credit or debit of a store credit account
+type StoreCreditAdjustment struct {
+	Amount   decimal.Decimal
+	Currency string
+	// optional expiry of credited amounts, ignored by Debit
+	ExpiresAt *time.Time
+}
+
+// StoreCreditTransaction is the result of crediting or debiting a store
+// credit account.
+type StoreCreditTransaction struct {
+	AccountId               uint64
+	Amount                  *decimal.Decimal
+	Currency                string
+	BalanceAfterTransaction *decimal.Decimal
+	CreatedAt               *time.Time
+}
+
+type graphQLStoreCreditAccount struct {
+	Id      string       `json:"id"`
+	Balance graphQLMoney `json:"balance"`
+}
+
+func (a *graphQLStoreCreditAccount) account() *StoreCreditAccount {
+	if a == nil {
+		return nil
+	}
+	id, _ := ParseGraphQLId(a.Id)
+	return &StoreCreditAccount{Id: id, Balance: a.Balance.Amount, Currency: a.Balance.CurrencyCode}
+}
+
+type graphQLStoreCreditTransaction struct {
+	Amount                  graphQLMoney `json:"amount"`
+	BalanceAfterTransaction graphQLMoney `json:"balanceAfterTransaction"`
+	CreatedAt               *time.Time   `json:"createdAt"`
+	Account                 struct {
+		Id string `json:"id"`
+	} `json:"account"`
+}
+
+func (t *graphQLStoreCreditTransaction) transaction() *StoreCreditTransaction {
+	if t == nil {
+		return nil
+	}
+	accountId, _ := ParseGraphQLId(t.Account.Id)
+	return &StoreCreditTransaction{
+		AccountId:               accountId,
+		Amount:                  t.Amount.Amount,
+		Currency:                t.Amount.CurrencyCode,
+		BalanceAfterTransaction: t.BalanceAfterTransaction.Amount,
+		CreatedAt:               t.CreatedAt,
+	}
+}
+
+// ListAccounts lists the store credit accounts of a customer
+func (s *StoreCreditServiceOp) ListAccounts(ctx context.Context, customerId uint64) ([]StoreCreditAccount, error) {
+	resp := struct {
+		Customer *struct {
+			StoreCreditAccounts struct {
+				Nodes []graphQLStoreCreditAccount `json:"nodes"`
+			} `json:"storeCreditAccounts"`
+		} `json:"customer"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("Customer", customerId)}
+	err := s.client.GraphQL.Query(ctx, storeCreditAccountsQuery, vars, &resp)
+	if err != nil || resp.Customer == nil {
+		return nil, err
+	}
+
+	accounts := make([]StoreCreditAccount, 0, len(resp.Customer.StoreCreditAccounts.Nodes))
+	for _, node := range resp.Customer.StoreCreditAccounts.Nodes {
+		accounts = append(accounts, *node.account())
+	}
+	return accounts, nil
+}
+
+// GetAccount gets a store credit account
+func (s *StoreCreditServiceOp) GetAccount(ctx context.Context, accountId uint64) (*StoreCreditAccount, error) {
+	resp := struct {
+		StoreCreditAccount *graphQLStoreCreditAccount `json:"storeCreditAccount"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("StoreCreditAccount", accountId)}
+	err := s.client.GraphQL.Query(ctx, storeCreditAccountQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.StoreCreditAccount.account(), nil
+}
+
+// CreditCustomer credits the store credit account of a customer in the
+// currency of the adjustment, creating the account if the customer has none
+// in that currency.
+func (s *StoreCreditServiceOp) CreditCustomer(ctx context.Context, customerId uint64, adjustment StoreCreditAdjustment) (*StoreCreditTransaction, error) {
+	return s.adjust(ctx, storeCreditAccountCreditMutation, GraphQLId("Customer", customerId), "creditInput", "creditAmount", adjustment)
+}
+
+// Credit adds the adjustment amount to a store credit account
+func (s *StoreCreditServiceOp) Credit(ctx context.Context, accountId uint64, adjustment StoreCreditAdjustment) (*StoreCreditTransaction, error) {
+	return s.adjust(ctx, storeCreditAccountCreditMutation, GraphQLId("StoreCreditAccount", accountId), "creditInput", "creditAmount", adjustment)
+}
+
+// Debit subtracts the adjustment amount from a store credit account
+func (s *StoreCreditServiceOp) Debit(ctx context.Context, accountId uint64, adjustment StoreCreditAdjustment) (*StoreCreditTransaction, error) {
+	adjustment.ExpiresAt = nil
+	return s.adjust(ctx, storeCreditAccountDebitMutation, GraphQLId("StoreCreditAccount", accountId), "debitInput", "debitAmount", adjustment)
+}
+
+func (s *StoreCreditServiceOp) adjust(ctx context.Context, mutation, id, inputKey, amountKey string, adjustment StoreCreditAdjustment) (*StoreCreditTransaction, error) {
+	input := map[string]interface{}{
+		amountKey: map[string]interface{}{
+			"amount":       adjustment.Amount.String(),
+			"currencyCode": adjustment.Currency,
+		},
+	}
+	if adjustment.ExpiresAt != nil {
+		input["expiresAt"] = adjustment.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	// both mutations share the payload shape, keyed by the mutation name
+	resp := map[string]*struct {
+		Transaction *graphQLStoreCreditTransaction `json:"storeCreditAccountTransaction"`
+		UserErrors  GraphQLUserErrors              `json:"userErrors"`
+	}{}
+
+	vars := map[string]interface{}{"id": id, inputKey: input}
+	err := s.client.GraphQL.Query(ctx, mutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payload := range resp {
+		if payload == nil {
+			continue
+		}
+		if len(payload.UserErrors) > 0 {
+			return nil, payload.UserErrors
+		}
+		if payload.Transaction == nil {
+			break
+		}
+		return payload.Transaction.transaction(), nil
+	}
+	return nil, fmt.Errorf("no store credit transaction returned")
+}
@@ -0,0 +1,171 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestStoreCreditListAccounts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"customer":{"storeCreditAccounts":{"nodes":[
+			{"id":"gid://shopify/StoreCreditAccount/1","balance":{"amount":"10.0","currencyCode":"USD"}},
+			{"id":"gid://shopify/StoreCreditAccount/2","balance":{"amount":"5.5","currencyCode":"CAD"}}
+		]}}}}`
+	})
+
+	accounts, err := client.StoreCredit.ListAccounts(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("StoreCredit.ListAccounts returned error: %v", err)
+	}
+
+	if vars["id"] != "gid://shopify/Customer/7" {
+		t.Errorf("StoreCredit.ListAccounts sent %+v", vars)
+	}
+
+	ten := decimal.NewFromFloat(10)
+	fiveFifty := decimal.NewFromFloat(5.5)
+	if len(accounts) != 2 || accounts[0].Id != 1 || accounts[0].Currency != "USD" || !accounts[0].Balance.Equal(ten) ||
+		accounts[1].Id != 2 || accounts[1].Currency != "CAD" || !accounts[1].Balance.Equal(fiveFifty) {
+		t.Errorf("StoreCredit.ListAccounts returned %+v", accounts)
+	}
+}
+
+func TestStoreCreditGetAccount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"storeCreditAccount":{"id":"gid://shopify/StoreCreditAccount/1","balance":{"amount":"10.0","currencyCode":"USD"}}}}`
+	})
+
+	account, err := client.StoreCredit.GetAccount(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("StoreCredit.GetAccount returned error: %v", err)
+	}
+
+	if vars["id"] != "gid://shopify/StoreCreditAccount/1" || account.Id != 1 || account.Currency != "USD" {
+		t.Errorf("StoreCredit.GetAccount sent %+v, returned %+v", vars, account)
+	}
+}
+
+func TestStoreCreditCreditCustomer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"storeCreditAccountCredit":{"storeCreditAccountTransaction":{
+			"amount":{"amount":"10.0","currencyCode":"USD"},
+			"balanceAfterTransaction":{"amount":"25.0","currencyCode":"USD"},
+			"createdAt":"2024-01-01T00:00:00Z",
+			"account":{"id":"gid://shopify/StoreCreditAccount/1"}
+		},"userErrors":[]}}}`
+	})
+
+	expiresAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	transaction, err := client.StoreCredit.CreditCustomer(context.Background(), 7, StoreCreditAdjustment{
+		Amount:    decimal.NewFromFloat(10),
+		Currency:  "USD",
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("StoreCredit.CreditCustomer returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id": "gid://shopify/Customer/7",
+		"creditInput": map[string]interface{}{
+			"creditAmount": map[string]interface{}{"amount": "10", "currencyCode": "USD"},
+			"expiresAt":    "2025-01-01T00:00:00Z",
+		},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("StoreCredit.CreditCustomer sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	balance := decimal.NewFromFloat(25)
+	if transaction.AccountId != 1 || transaction.Currency != "USD" || !transaction.BalanceAfterTransaction.Equal(balance) {
+		t.Errorf("StoreCredit.CreditCustomer returned %+v", transaction)
+	}
+}
+
+func TestStoreCreditDebit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"storeCreditAccountDebit":{"storeCreditAccountTransaction":{
+			"amount":{"amount":"-5.0","currencyCode":"USD"},
+			"balanceAfterTransaction":{"amount":"5.0","currencyCode":"USD"},
+			"account":{"id":"gid://shopify/StoreCreditAccount/1"}
+		},"userErrors":[]}}}`
+	})
+
+	expiresAt := time.Now()
+	transaction, err := client.StoreCredit.Debit(context.Background(), 1, StoreCreditAdjustment{
+		Amount:    decimal.NewFromFloat(5),
+		Currency:  "USD",
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("StoreCredit.Debit returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"id": "gid://shopify/StoreCreditAccount/1",
+		"debitInput": map[string]interface{}{
+			"debitAmount": map[string]interface{}{"amount": "5", "currencyCode": "USD"},
+		},
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("StoreCredit.Debit sent %+v, expected %+v", vars, expectedVars)
+	}
+
+	amount := decimal.NewFromFloat(-5)
+	if transaction.AccountId != 1 || !transaction.Amount.Equal(amount) {
+		t.Errorf("StoreCredit.Debit returned %+v", transaction)
+	}
+}
+
+func TestStoreCreditDebitUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"storeCreditAccountDebit":{"storeCreditAccountTransaction":null,
+			"userErrors":[{"field":["debitInput","debitAmount","amount"],"message":"Insufficient funds","code":"INSUFFICIENT_FUNDS"}]}}}`
+	})
+
+	_, err := client.StoreCredit.Debit(context.Background(), 1, StoreCreditAdjustment{Amount: decimal.NewFromFloat(500), Currency: "USD"})
+	if _, ok := err.(GraphQLUserErrors); !ok || err.Error() != "debitInput.debitAmount.amount: Insufficient funds" {
+		t.Errorf("StoreCredit.Debit err returned %v", err)
+	}
+}
+
+func TestStoreCreditDebitNoTransaction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"storeCreditAccountDebit":{"storeCreditAccountTransaction":null,"userErrors":[]}}}`
+	})
+
+	transaction, err := client.StoreCredit.Debit(context.Background(), 1, StoreCreditAdjustment{Amount: decimal.NewFromFloat(5), Currency: "USD"})
+	if transaction != nil || err == nil {
+		t.Errorf("StoreCredit.Debit returned %+v, %v, expected an error", transaction, err)
+	}
+}
@@ -0,0 +1,353 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+const subscriptionBillingCycleFields = `
+      cycleIndex
+      cycleStartAt
+      cycleEndAt
+      billingAttemptExpectedDate
+      skipped
+      edited
+      status`
+
+const subscriptionBillingCyclesQuery = `query subscriptionBillingCycles($contractId: ID!, $first: Int!, $after: String, $dateRange: SubscriptionBillingCyclesDateRangeSelector!) {
+  subscriptionBillingCycles(contractId: $contractId, first: $first, after: $after, billingCyclesDateRangeSelector: $dateRange) {
+    nodes {` + subscriptionBillingCycleFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const subscriptionBillingCycleQuery = `query subscriptionBillingCycle($input: SubscriptionBillingCycleInput!) {
+  subscriptionBillingCycle(billingCycleInput: $input) {` + subscriptionBillingCycleFields + `
+  }
+}`
+
+const subscriptionBillingCycleSkipMutation = `mutation subscriptionBillingCycleSkip($input: SubscriptionBillingCycleInput!) {
+  subscriptionBillingCycleSkip(billingCycleInput: $input) {
+    billingCycle {` + subscriptionBillingCycleFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const subscriptionBillingCycleUnskipMutation = `mutation subscriptionBillingCycleUnskip($input: SubscriptionBillingCycleInput!) {
+  subscriptionBillingCycleUnskip(billingCycleInput: $input) {
+    billingCycle {` + subscriptionBillingCycleFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const subscriptionBillingCycleScheduleEditMutation = `mutation subscriptionBillingCycleScheduleEdit($input: SubscriptionBillingCycleInput!, $edit: SubscriptionBillingCycleScheduleEditInput!) {
+  subscriptionBillingCycleScheduleEdit(billingCycleInput: $input, input: $edit) {
+    billingCycle {` + subscriptionBillingCycleFields + `
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const subscriptionBillingCycleContractEditMutation = `mutation subscriptionBillingCycleContractEdit($input: SubscriptionBillingCycleInput!) {
+  subscriptionBillingCycleContractEdit(billingCycleInput: $input) {
+    draft {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+const subscriptionBillingCycleContractDraftCommitMutation = `mutation subscriptionBillingCycleContractDraftCommit($draftId: ID!) {
+  subscriptionBillingCycleContractDraftCommit(draftId: $draftId) {
+    contract {
+      id
+    }
+    userErrors {
+      field
+      message
+      code
+    }
+  }
+}`
+
+// SubscriptionContractService is an interface for the billing cycles of
+// subscription contracts through the GraphQL API.
+// See: https://shopify.dev/docs/apps/build/purchase-options/subscriptions/contracts/billing-cycles
+type SubscriptionContractService interface {
+	ListBillingCycles(context.Context, uint64, *SubscriptionBillingCyclesOptions) ([]SubscriptionBillingCycle, *GraphQLPageInfo, error)
+	GetBillingCycle(context.Context, uint64, int) (*SubscriptionBillingCycle, error)
+	SkipBillingCycle(context.Context, uint64, int) (*SubscriptionBillingCycle, error)
+	UnskipBillingCycle(context.Context, uint64, int) (*SubscriptionBillingCycle, error)
+	RescheduleBillingCycle(context.Context, uint64, int, time.Time, SubscriptionScheduleEditReason) (*SubscriptionBillingCycle, error)
+	EditBillingCycleContract(context.Context, uint64, int) (string, error)
+	CommitBillingCycleContractDraft(context.Context, string) error
+}
+
+// SubscriptionContractServiceOp handles communication with the subscription
+// contracts through the GraphQL API
+type SubscriptionContractServiceOp struct {
+	client *Client
+}
+
+// SubscriptionBillingCycleStatus tells whether a billing cycle was billed
+type SubscriptionBillingCycleStatus string
+
+const (
+	SubscriptionBillingCycleBilled   SubscriptionBillingCycleStatus = "billed"
+	SubscriptionBillingCycleUnbilled SubscriptionBillingCycleStatus = "unbilled"
+)
+
+// SubscriptionScheduleEditReason is who asked to reschedule a billing cycle
+type SubscriptionScheduleEditReason string
+
+const (
+	SubscriptionScheduleEditBuyerInitiated    SubscriptionScheduleEditReason = "BUYER_INITIATED"
+	SubscriptionScheduleEditMerchantInitiated SubscriptionScheduleEditReason = "MERCHANT_INITIATED"
+	SubscriptionScheduleEditDevInitiated      SubscriptionScheduleEditReason = "DEV_INITIATED"
+)
+
+// SubscriptionBillingCycle is a billing cycle of a subscription contract, by
+// index starting at 1
+type SubscriptionBillingCycle struct {
+	ContractId          uint64
+	Index               int
+	StartAt             *time.Time
+	EndAt               *time.Time
+	ExpectedBillingDate *time.Time
+	Skipped             bool
+	Edited              bool
+	Status              SubscriptionBillingCycleStatus
+}
+
+// SubscriptionBillingCyclesOptions are the options of listing the billing
+// cycles of a contract
+type SubscriptionBillingCyclesOptions struct {
+	// number of cycles, defaults to 50, at most 250
+	First int
+	// cursor of the page to list, see GraphQLPageInfo.EndCursor
+	After string
+	// cycles overlapping the date range, which defaults to the year from now
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type graphQLSubscriptionBillingCycle struct {
+	CycleIndex                 int        `json:"cycleIndex"`
+	CycleStartAt               *time.Time `json:"cycleStartAt"`
+	CycleEndAt                 *time.Time `json:"cycleEndAt"`
+	BillingAttemptExpectedDate *time.Time `json:"billingAttemptExpectedDate"`
+	Skipped                    bool       `json:"skipped"`
+	Edited                     bool       `json:"edited"`
+	Status                     string     `json:"status"`
+}
+
+func (g graphQLSubscriptionBillingCycle) billingCycle(contractId uint64) SubscriptionBillingCycle {
+	return SubscriptionBillingCycle{
+		ContractId:          contractId,
+		Index:               g.CycleIndex,
+		StartAt:             g.CycleStartAt,
+		EndAt:               g.CycleEndAt,
+		ExpectedBillingDate: g.BillingAttemptExpectedDate,
+		Skipped:             g.Skipped,
+		Edited:              g.Edited,
+		Status:              SubscriptionBillingCycleStatus(strings.ToLower(g.Status)),
+	}
+}
+
+// billingCycleInput is the SubscriptionBillingCycleInput selecting a cycle of
+// a contract by index
+func billingCycleInput(contractId uint64, index int) map[string]interface{} {
+	return map[string]interface{}{
+		"contractId": GraphQLId("SubscriptionContract", contractId),
+		"selector":   map[string]interface{}{"index": index},
+	}
+}
+
+// ListBillingCycles lists the billing cycles of a contract in a date range
+func (s *SubscriptionContractServiceOp) ListBillingCycles(ctx context.Context, contractId uint64, options *SubscriptionBillingCyclesOptions) ([]SubscriptionBillingCycle, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &SubscriptionBillingCyclesOptions{}
+	}
+
+	start, end := options.StartDate, options.EndDate
+	if start.IsZero() {
+		start = time.Now()
+	}
+	if end.IsZero() {
+		end = start.AddDate(1, 0, 0)
+	}
+	vars := map[string]interface{}{
+		"contractId": GraphQLId("SubscriptionContract", contractId),
+		"first":      50,
+		"dateRange": map[string]interface{}{
+			"startDate": start.UTC().Format(time.RFC3339),
+			"endDate":   end.UTC().Format(time.RFC3339),
+		},
+	}
+	if options.First > 0 {
+		vars["first"] = options.First
+	}
+	if options.After != "" {
+		vars["after"] = options.After
+	}
+
+	resp := struct {
+		SubscriptionBillingCycles struct {
+			Nodes    []graphQLSubscriptionBillingCycle `json:"nodes"`
+			PageInfo GraphQLPageInfo                   `json:"pageInfo"`
+		} `json:"subscriptionBillingCycles"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, subscriptionBillingCyclesQuery, vars, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cycles := make([]SubscriptionBillingCycle, 0, len(resp.SubscriptionBillingCycles.Nodes))
+	for _, c := range resp.SubscriptionBillingCycles.Nodes {
+		cycles = append(cycles, c.billingCycle(contractId))
+	}
+	return cycles, &resp.SubscriptionBillingCycles.PageInfo, nil
+}
+
+// GetBillingCycle gets a billing cycle of a contract by index
+func (s *SubscriptionContractServiceOp) GetBillingCycle(ctx context.Context, contractId uint64, index int) (*SubscriptionBillingCycle, error) {
+	resp := struct {
+		SubscriptionBillingCycle *graphQLSubscriptionBillingCycle `json:"subscriptionBillingCycle"`
+	}{}
+	vars := map[string]interface{}{"input": billingCycleInput(contractId, index)}
+	err := s.client.GraphQL.Query(ctx, subscriptionBillingCycleQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.SubscriptionBillingCycle == nil {
+		return nil, graphQLNotFound()
+	}
+	cycle := resp.SubscriptionBillingCycle.billingCycle(contractId)
+	return &cycle, nil
+}
+
+// SkipBillingCycle skips a billing cycle of a contract, e.g. to pause a
+// subscription for a cycle on the customer's request
+func (s *SubscriptionContractServiceOp) SkipBillingCycle(ctx context.Context, contractId uint64, index int) (*SubscriptionBillingCycle, error) {
+	vars := map[string]interface{}{"input": billingCycleInput(contractId, index)}
+	return s.editBillingCycle(ctx, "subscriptionBillingCycleSkip", subscriptionBillingCycleSkipMutation, contractId, vars)
+}
+
+// UnskipBillingCycle unskips a skipped billing cycle of a contract
+func (s *SubscriptionContractServiceOp) UnskipBillingCycle(ctx context.Context, contractId uint64, index int) (*SubscriptionBillingCycle, error) {
+	vars := map[string]interface{}{"input": billingCycleInput(contractId, index)}
+	return s.editBillingCycle(ctx, "subscriptionBillingCycleUnskip", subscriptionBillingCycleUnskipMutation, contractId, vars)
+}
+
+// RescheduleBillingCycle moves the billing date of a billing cycle of a
+// contract
+func (s *SubscriptionContractServiceOp) RescheduleBillingCycle(ctx context.Context, contractId uint64, index int, billingDate time.Time, reason SubscriptionScheduleEditReason) (*SubscriptionBillingCycle, error) {
+	if billingDate.IsZero() {
+		return nil, errors.New("reschedule billing cycle: billing date is required")
+	}
+	if reason == "" {
+		reason = SubscriptionScheduleEditMerchantInitiated
+	}
+	vars := map[string]interface{}{
+		"input": billingCycleInput(contractId, index),
+		"edit": map[string]interface{}{
+			"billingDate": billingDate.UTC().Format(time.RFC3339),
+			"reason":      string(reason),
+		},
+	}
+	return s.editBillingCycle(ctx, "subscriptionBillingCycleScheduleEdit", subscriptionBillingCycleScheduleEditMutation, contractId, vars)
+}
+
+// editBillingCycle runs a billing cycle mutation returning the edited cycle
+func (s *SubscriptionContractServiceOp) editBillingCycle(ctx context.Context, mutation string, query string, contractId uint64, vars map[string]interface{}) (*SubscriptionBillingCycle, error) {
+	resp := map[string]*struct {
+		BillingCycle *graphQLSubscriptionBillingCycle `json:"billingCycle"`
+		UserErrors   GraphQLUserErrors                `json:"userErrors"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, query, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	result := resp[mutation]
+	if result == nil {
+		return nil, errors.New(mutation + " returned no result")
+	}
+	if len(result.UserErrors) > 0 {
+		return nil, result.UserErrors
+	}
+	if result.BillingCycle == nil {
+		return nil, errors.New(mutation + " returned no billing cycle")
+	}
+	cycle := result.BillingCycle.billingCycle(contractId)
+	return &cycle, nil
+}
+
+// EditBillingCycleContract creates a draft of the contract that only applies
+// to one billing cycle and returns the draft id. Edit the draft with the
+// subscriptionDraft mutations, then commit it with
+// CommitBillingCycleContractDraft.
+func (s *SubscriptionContractServiceOp) EditBillingCycleContract(ctx context.Context, contractId uint64, index int) (string, error) {
+	resp := struct {
+		SubscriptionBillingCycleContractEdit struct {
+			Draft *struct {
+				Id string `json:"id"`
+			} `json:"draft"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"subscriptionBillingCycleContractEdit"`
+	}{}
+	vars := map[string]interface{}{"input": billingCycleInput(contractId, index)}
+	err := s.client.GraphQL.Query(ctx, subscriptionBillingCycleContractEditMutation, vars, &resp)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.SubscriptionBillingCycleContractEdit.UserErrors) > 0 {
+		return "", resp.SubscriptionBillingCycleContractEdit.UserErrors
+	}
+	if resp.SubscriptionBillingCycleContractEdit.Draft == nil {
+		return "", errors.New("subscriptionBillingCycleContractEdit returned no draft")
+	}
+	return resp.SubscriptionBillingCycleContractEdit.Draft.Id, nil
+}
+
+// CommitBillingCycleContractDraft commits a draft created by
+// EditBillingCycleContract, applying it to its billing cycle
+func (s *SubscriptionContractServiceOp) CommitBillingCycleContractDraft(ctx context.Context, draftId string) error {
+	resp := struct {
+		SubscriptionBillingCycleContractDraftCommit struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"subscriptionBillingCycleContractDraftCommit"`
+	}{}
+	vars := map[string]interface{}{"draftId": draftId}
+	err := s.client.GraphQL.Query(ctx, subscriptionBillingCycleContractDraftCommitMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.SubscriptionBillingCycleContractDraftCommit.UserErrors) > 0 {
+		return resp.SubscriptionBillingCycleContractDraftCommit.UserErrors
+	}
+	return nil
+}
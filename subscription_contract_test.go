@@ -0,0 +1,153 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const subscriptionBillingCycleJSON = `{"cycleIndex":3,"cycleStartAt":"2024-03-01T00:00:00Z","cycleEndAt":"2024-03-31T00:00:00Z",
+	"billingAttemptExpectedDate":"2024-03-01T00:00:00Z","skipped":%s,"edited":false,"status":"UNBILLED"}`
+
+func TestSubscriptionContractListBillingCycles(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		subscriptionBillingCyclesQuery: `{"data":{"subscriptionBillingCycles":{"nodes":[
+			{"cycleIndex":1,"cycleStartAt":"2024-01-01T00:00:00Z","cycleEndAt":"2024-01-31T00:00:00Z",
+				"billingAttemptExpectedDate":"2024-01-01T00:00:00Z","skipped":false,"edited":false,"status":"BILLED"},
+			{"cycleIndex":2,"cycleStartAt":"2024-02-01T00:00:00Z","cycleEndAt":"2024-02-29T00:00:00Z",
+				"billingAttemptExpectedDate":"2024-02-01T00:00:00Z","skipped":true,"edited":false,"status":"UNBILLED"}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"c2"}}}}`,
+	}))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cycles, pageInfo, err := client.SubscriptionContract.ListBillingCycles(context.Background(), 7, &SubscriptionBillingCyclesOptions{StartDate: start})
+	if err != nil {
+		t.Fatalf("SubscriptionContract.ListBillingCycles returned error: %v", err)
+	}
+	if len(cycles) != 2 || cycles[0].Status != SubscriptionBillingCycleBilled || !cycles[1].Skipped || cycles[1].ContractId != 7 || cycles[1].Index != 2 {
+		t.Errorf("SubscriptionContract.ListBillingCycles returned %+v", cycles)
+	}
+	if !pageInfo.HasNextPage || pageInfo.EndCursor != "c2" {
+		t.Errorf("SubscriptionContract.ListBillingCycles returned page info %+v", pageInfo)
+	}
+
+	expected := map[string]interface{}{
+		"contractId": "gid://shopify/SubscriptionContract/7",
+		"first":      float64(50),
+		"dateRange":  map[string]interface{}{"startDate": "2024-01-01T00:00:00Z", "endDate": "2025-01-01T00:00:00Z"},
+	}
+	if !reflect.DeepEqual(sent[0].Variables, expected) {
+		t.Errorf("SubscriptionContract.ListBillingCycles sent %v, expected %v", sent[0].Variables, expected)
+	}
+}
+
+func TestSubscriptionContractSkipBillingCycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		subscriptionBillingCycleSkipMutation: `{"data":{"subscriptionBillingCycleSkip":{"billingCycle":` +
+			fmt.Sprintf(subscriptionBillingCycleJSON, "true") + `,"userErrors":[]}}}`,
+		subscriptionBillingCycleUnskipMutation: `{"data":{"subscriptionBillingCycleUnskip":{"billingCycle":` +
+			fmt.Sprintf(subscriptionBillingCycleJSON, "false") + `,"userErrors":[]}}}`,
+	}))
+
+	cycle, err := client.SubscriptionContract.SkipBillingCycle(context.Background(), 7, 3)
+	if err != nil {
+		t.Fatalf("SubscriptionContract.SkipBillingCycle returned error: %v", err)
+	}
+	if !cycle.Skipped || cycle.Index != 3 || cycle.Status != SubscriptionBillingCycleUnbilled {
+		t.Errorf("SubscriptionContract.SkipBillingCycle returned %+v", cycle)
+	}
+	expected := map[string]interface{}{"input": map[string]interface{}{
+		"contractId": "gid://shopify/SubscriptionContract/7",
+		"selector":   map[string]interface{}{"index": float64(3)},
+	}}
+	if !reflect.DeepEqual(sent[0].Variables, expected) {
+		t.Errorf("SubscriptionContract.SkipBillingCycle sent %v, expected %v", sent[0].Variables, expected)
+	}
+
+	cycle, err = client.SubscriptionContract.UnskipBillingCycle(context.Background(), 7, 3)
+	if err != nil || cycle.Skipped {
+		t.Errorf("SubscriptionContract.UnskipBillingCycle returned %+v, %v", cycle, err)
+	}
+}
+
+func TestSubscriptionContractRescheduleBillingCycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		subscriptionBillingCycleScheduleEditMutation: `{"data":{"subscriptionBillingCycleScheduleEdit":{"billingCycle":null,
+			"userErrors":[{"field":["input","billingDate"],"message":"Billing date cannot be in the past","code":"BILLING_DATE_IN_THE_PAST"}]}}}`,
+	}))
+
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	_, err := client.SubscriptionContract.RescheduleBillingCycle(context.Background(), 7, 3, date, SubscriptionScheduleEditBuyerInitiated)
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("SubscriptionContract.RescheduleBillingCycle returned %v, expected GraphQLUserErrors", err)
+	}
+	edit := map[string]interface{}{"billingDate": "2024-03-10T00:00:00Z", "reason": "BUYER_INITIATED"}
+	if !reflect.DeepEqual(sent[0].Variables["edit"], edit) {
+		t.Errorf("SubscriptionContract.RescheduleBillingCycle sent %v, expected %v", sent[0].Variables["edit"], edit)
+	}
+
+	if _, err := client.SubscriptionContract.RescheduleBillingCycle(context.Background(), 7, 3, time.Time{}, ""); err == nil {
+		t.Error("SubscriptionContract.RescheduleBillingCycle without a date returned no error")
+	}
+}
+
+func TestSubscriptionContractEditBillingCycleContract(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		subscriptionBillingCycleContractEditMutation: `{"data":{"subscriptionBillingCycleContractEdit":{
+			"draft":{"id":"gid://shopify/SubscriptionDraft/11"},"userErrors":[]}}}`,
+		subscriptionBillingCycleContractDraftCommitMutation: `{"data":{"subscriptionBillingCycleContractDraftCommit":{
+			"contract":{"id":"gid://shopify/SubscriptionBillingCycleEditedContract/7"},"userErrors":[]}}}`,
+	}))
+
+	draftId, err := client.SubscriptionContract.EditBillingCycleContract(context.Background(), 7, 3)
+	if err != nil {
+		t.Fatalf("SubscriptionContract.EditBillingCycleContract returned error: %v", err)
+	}
+	if draftId != "gid://shopify/SubscriptionDraft/11" {
+		t.Errorf("SubscriptionContract.EditBillingCycleContract returned draft %s", draftId)
+	}
+
+	if err := client.SubscriptionContract.CommitBillingCycleContractDraft(context.Background(), draftId); err != nil {
+		t.Fatalf("SubscriptionContract.CommitBillingCycleContractDraft returned error: %v", err)
+	}
+	if len(sent) != 2 || sent[1].Variables["draftId"] != draftId {
+		t.Errorf("SubscriptionContract.CommitBillingCycleContractDraft sent %+v", sent)
+	}
+}
+
+func TestSubscriptionContractGetBillingCycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		subscriptionBillingCycleQuery: `{"data":{"subscriptionBillingCycle":` + fmt.Sprintf(subscriptionBillingCycleJSON, "false") + `}}`,
+	}))
+
+	cycle, err := client.SubscriptionContract.GetBillingCycle(context.Background(), 7, 3)
+	if err != nil {
+		t.Fatalf("SubscriptionContract.GetBillingCycle returned error: %v", err)
+	}
+	expectedDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if cycle.Index != 3 || cycle.ExpectedBillingDate == nil || !cycle.ExpectedBillingDate.Equal(expectedDate) {
+		t.Errorf("SubscriptionContract.GetBillingCycle returned %+v", cycle)
+	}
+}
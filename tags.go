@@ -0,0 +1,169 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// number of tag mutations sent in one GraphQL request by AddMany and
+// RemoveMany
+const tagsBatchSize = 25
+
+// TagsService is an interface for changing the tags of any taggable
+// resource, e.g. orders, draft orders, customers, products and articles,
+// through the GraphQL tagsAdd and tagsRemove mutations. Unlike updates of
+// the resources these don't send the whole resource, so they don't race
+// with other writers.
+type TagsService interface {
+	Add(context.Context, string, ...string) error
+	Remove(context.Context, string, ...string) error
+	AddMany(context.Context, []string, ...string) error
+	RemoveMany(context.Context, []string, ...string) error
+}
+
+// TagsServiceOp handles communication with the tag mutations of the Shopify
+// GraphQL API
+type TagsServiceOp struct {
+	client *Client
+}
+
+// TagsErrors holds the errors of AddMany and RemoveMany, keyed by GraphQL id
+type TagsErrors map[string]error
+
+func (e TagsErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", id, e[id]))
+	}
+	return fmt.Sprintf("%d of the batch failed: %s", len(e), strings.Join(msgs, ", "))
+}
+
+// Add adds tags to a resource by its GraphQL id, e.g. gid://shopify/Order/1
+func (s *TagsServiceOp) Add(ctx context.Context, id string, tags ...string) error {
+	return s.single(ctx, "tagsAdd", id, tags)
+}
+
+// Remove removes tags from a resource by its GraphQL id
+func (s *TagsServiceOp) Remove(ctx context.Context, id string, tags ...string) error {
+	return s.single(ctx, "tagsRemove", id, tags)
+}
+
+// AddMany adds tags to many resources, sending the mutations in batches. If
+// any resource failed the returned error is a TagsErrors.
+func (s *TagsServiceOp) AddMany(ctx context.Context, ids []string, tags ...string) error {
+	return s.many(ctx, "tagsAdd", ids, tags)
+}
+
+// RemoveMany removes tags from many resources, see AddMany
+func (s *TagsServiceOp) RemoveMany(ctx context.Context, ids []string, tags ...string) error {
+	return s.many(ctx, "tagsRemove", ids, tags)
+}
+
+func (s *TagsServiceOp) single(ctx context.Context, mutation string, id string, tags []string) error {
+	err := s.many(ctx, mutation, []string{id}, tags)
+	if errs, ok := err.(TagsErrors); ok {
+		return errs[id]
+	}
+	return err
+}
+
+func (s *TagsServiceOp) many(ctx context.Context, mutation string, ids []string, tags []string) error {
+	tags = addTags(nil, tags)
+	if len(tags) == 0 {
+		return fmt.Errorf("%s: no tags given", mutation)
+	}
+
+	errs := TagsErrors{}
+	for start := 0; start < len(ids); start += tagsBatchSize {
+		end := start + tagsBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		results, err := s.batch(ctx, mutation, batch, tags)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			for _, id := range batch {
+				errs[id] = err
+			}
+			continue
+		}
+		for i, id := range batch {
+			if userErrs := results[fmt.Sprintf("t%d", i)].UserErrors; len(userErrs) > 0 {
+				errs[id] = userErrs
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// batch sends the mutations of ids as aliases t0, t1, ... of one request
+func (s *TagsServiceOp) batch(ctx context.Context, mutation string, ids []string, tags []string) (map[string]struct {
+	UserErrors GraphQLUserErrors `json:"userErrors"`
+}, error) {
+	params := []string{"$tags: [String!]!"}
+	fields := make([]string, 0, len(ids))
+	vars := map[string]interface{}{"tags": tags}
+	for i, id := range ids {
+		params = append(params, fmt.Sprintf("$id%d: ID!", i))
+		fields = append(fields, fmt.Sprintf("  t%d: %s(id: $id%d, tags: $tags) {\n    userErrors {\n      field\n      message\n    }\n  }", i, mutation, i))
+		vars[fmt.Sprintf("id%d", i)] = id
+	}
+	q := fmt.Sprintf("mutation %s(%s) {\n%s\n}", mutation, strings.Join(params, ", "), strings.Join(fields, "\n"))
+
+	resp := map[string]struct {
+		UserErrors GraphQLUserErrors `json:"userErrors"`
+	}{}
+	err := s.client.GraphQL.Query(ctx, q, vars, &resp)
+	return resp, err
+}
+
+// AddTags adds tags to orders without updating the whole orders, sending the
+// tagsAdd mutations in batches. If any order failed the returned error is a
+// BatchErrors.
+func (s *OrderServiceOp) AddTags(ctx context.Context, orderIds []uint64, tags ...string) error {
+	return orderTagsErrors(orderIds, s.client.Tags.AddMany(ctx, orderGraphQLIds(orderIds), tags...))
+}
+
+// RemoveTags removes tags from orders, see AddTags
+func (s *OrderServiceOp) RemoveTags(ctx context.Context, orderIds []uint64, tags ...string) error {
+	return orderTagsErrors(orderIds, s.client.Tags.RemoveMany(ctx, orderGraphQLIds(orderIds), tags...))
+}
+
+func orderGraphQLIds(orderIds []uint64) []string {
+	ids := make([]string, 0, len(orderIds))
+	for _, id := range orderIds {
+		ids = append(ids, GraphQLId("Order", id))
+	}
+	return ids
+}
+
+func orderTagsErrors(orderIds []uint64, err error) error {
+	tagsErrs, ok := err.(TagsErrors)
+	if !ok {
+		return err
+	}
+
+	errs := BatchErrors{}
+	for _, id := range orderIds {
+		if err, ok := tagsErrs[GraphQLId("Order", id)]; ok {
+			errs[id] = err
+		}
+	}
+	return errs
+}
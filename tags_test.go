@@ -0,0 +1,123 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestTagsAdd(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query, vars = req.Query, req.Variables
+		return `{"data":{"t0":{"userErrors":[]}}}`
+	})
+
+	err := client.Tags.Add(context.Background(), "gid://shopify/Customer/1", "vip", " ", "VIP", "wholesale")
+	if err != nil {
+		t.Fatalf("Tags.Add returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{
+		"tags": []interface{}{"vip", "wholesale"},
+		"id0":  "gid://shopify/Customer/1",
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Tags.Add sent variables %v, expected %v", vars, expectedVars)
+	}
+	if !strings.Contains(query, "t0: tagsAdd(id: $id0, tags: $tags)") {
+		t.Errorf("Tags.Add sent query %s", query)
+	}
+}
+
+func TestTagsRemoveUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var query string
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query = req.Query
+		return `{"data":{"t0":{"userErrors":[{"field":["id"],"message":"Product does not exist"}]}}}`
+	})
+
+	err := client.Tags.Remove(context.Background(), "gid://shopify/Product/1", "sale")
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("Tags.Remove returned %v, expected user errors", err)
+	}
+	if !strings.Contains(query, "t0: tagsRemove(id: $id0, tags: $tags)") {
+		t.Errorf("Tags.Remove sent query %s", query)
+	}
+
+	if err := client.Tags.Remove(context.Background(), "gid://shopify/Product/1", ""); err == nil {
+		t.Error("Tags.Remove accepted no tags")
+	}
+}
+
+func TestOrderAddTagsBatches(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var batches []map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		batches = append(batches, req.Variables)
+
+		// order 27 is the third alias of the second batch
+		results := map[string]interface{}{}
+		for i := 0; i < len(req.Variables)-1; i++ {
+			userErrors := []interface{}{}
+			if req.Variables[fmt.Sprintf("id%d", i)] == "gid://shopify/Order/27" {
+				userErrors = append(userErrors, map[string]interface{}{"field": []string{"id"}, "message": "Order does not exist"})
+			}
+			results[fmt.Sprintf("t%d", i)] = map[string]interface{}{"userErrors": userErrors}
+		}
+		resp, _ := json.Marshal(map[string]interface{}{"data": results})
+		return string(resp)
+	})
+
+	ids := make([]uint64, 0, 30)
+	for id := uint64(1); id <= 30; id++ {
+		ids = append(ids, id)
+	}
+
+	err := client.Order.AddTags(context.Background(), ids, "reviewed")
+	errs, ok := err.(BatchErrors)
+	if !ok {
+		t.Fatalf("Order.AddTags returned %v, expected BatchErrors", err)
+	}
+	if len(errs) != 1 || errs[27] == nil {
+		t.Errorf("Order.AddTags returned errors %v, expected only order 27", errs)
+	}
+
+	if len(batches) != 2 {
+		t.Fatalf("Order.AddTags sent %d requests, expected 2 batches", len(batches))
+	}
+	if len(batches[0]) != 26 || len(batches[1]) != 6 {
+		t.Errorf("Order.AddTags sent batches of %d and %d variables", len(batches[0]), len(batches[1]))
+	}
+	if batches[1]["id0"] != "gid://shopify/Order/26" {
+		t.Errorf("Order.AddTags started the second batch with %v", batches[1]["id0"])
+	}
+}
+
+func TestOrderRemoveTagsRequestError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/graphql.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"errors":[{"message":"Invalid global id"}]}`))
+
+	err := client.Order.RemoveTags(context.Background(), []uint64{1, 2}, "reviewed")
+	errs, ok := err.(BatchErrors)
+	if !ok || len(errs) != 2 || errs[1] == nil || errs[2] == nil {
+		t.Errorf("Order.RemoveTags returned %v, expected errors for both orders", err)
+	}
+}
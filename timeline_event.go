@@ -0,0 +1,205 @@
+package goshopify
+
+import (
+	"context"
+	"time"
+)
+
+const timelineEventsQuery = `query timelineEvents($id: ID!, $first: Int!, $after: String, $query: String) {
+  node(id: $id) {
+    ... on HasEvents {
+      events(first: $first, after: $after, query: $query, sortKey: CREATED_AT, reverse: true) {
+        nodes {
+          __typename
+          id
+          createdAt
+          message
+          appTitle
+          attributeToApp
+          attributeToUser
+          criticalAlert
+          ... on BasicEvent {
+            action
+            subjectType
+          }
+          ... on CommentEvent {
+            rawMessage
+            edited
+            author {
+              name
+            }
+            attachments {
+              id
+              name
+              url
+              fileExtension
+              size
+              image {
+                url
+              }
+            }
+          }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}`
+
+// TimelineEventsOptions are the options of listing the timeline events of
+// an order or a customer
+type TimelineEventsOptions struct {
+	// number of events, defaults to 50, at most 250
+	First int
+	// cursor of the page to list, see GraphQLPageInfo.EndCursor
+	After string
+	// search query of the events, e.g. "created_at:>2024-01-01"
+	Query string
+}
+
+// TimelineEvent is an event of the timeline of an order or a customer, newest
+// first. Comments staff left on the timeline have Comment set.
+type TimelineEvent struct {
+	Id              uint64
+	CreatedAt       *time.Time
+	Message         string
+	AppTitle        string
+	AttributeToApp  bool
+	AttributeToUser bool
+	CriticalAlert   bool
+	Action          string
+	SubjectType     string
+
+	Comment     bool
+	RawMessage  string
+	Edited      bool
+	Author      string
+	Attachments []TimelineAttachment
+}
+
+// TimelineAttachment is a file attached to a timeline comment
+type TimelineAttachment struct {
+	Id            string
+	Name          string
+	URL           string
+	FileExtension string
+	Size          int
+	ImageURL      string
+}
+
+type graphQLTimelineEvent struct {
+	Typename        string     `json:"__typename"`
+	Id              string     `json:"id"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	Message         string     `json:"message"`
+	AppTitle        string     `json:"appTitle"`
+	AttributeToApp  bool       `json:"attributeToApp"`
+	AttributeToUser bool       `json:"attributeToUser"`
+	CriticalAlert   bool       `json:"criticalAlert"`
+	Action          string     `json:"action"`
+	SubjectType     string     `json:"subjectType"`
+	RawMessage      string     `json:"rawMessage"`
+	Edited          bool       `json:"edited"`
+	Author          *struct {
+		Name string `json:"name"`
+	} `json:"author"`
+	Attachments []struct {
+		Id            string `json:"id"`
+		Name          string `json:"name"`
+		URL           string `json:"url"`
+		FileExtension string `json:"fileExtension"`
+		Size          int    `json:"size"`
+		Image         *struct {
+			URL string `json:"url"`
+		} `json:"image"`
+	} `json:"attachments"`
+}
+
+func (g graphQLTimelineEvent) event() TimelineEvent {
+	event := TimelineEvent{
+		CreatedAt:       g.CreatedAt,
+		Message:         g.Message,
+		AppTitle:        g.AppTitle,
+		AttributeToApp:  g.AttributeToApp,
+		AttributeToUser: g.AttributeToUser,
+		CriticalAlert:   g.CriticalAlert,
+		Action:          g.Action,
+		SubjectType:     g.SubjectType,
+		Comment:         g.Typename == "CommentEvent",
+		RawMessage:      g.RawMessage,
+		Edited:          g.Edited,
+	}
+	event.Id, _ = ParseGraphQLId(g.Id)
+	if g.Author != nil {
+		event.Author = g.Author.Name
+	}
+	for _, a := range g.Attachments {
+		attachment := TimelineAttachment{
+			Id:            a.Id,
+			Name:          a.Name,
+			URL:           a.URL,
+			FileExtension: a.FileExtension,
+			Size:          a.Size,
+		}
+		if a.Image != nil {
+			attachment.ImageURL = a.Image.URL
+		}
+		event.Attachments = append(event.Attachments, attachment)
+	}
+	return event
+}
+
+// ListTimelineEvents lists the timeline events of an order, including staff
+// comments with their attachments
+func (s *OrderServiceOp) ListTimelineEvents(ctx context.Context, orderId uint64, options *TimelineEventsOptions) ([]TimelineEvent, *GraphQLPageInfo, error) {
+	return listTimelineEvents(ctx, s.client, GraphQLId("Order", orderId), options)
+}
+
+// ListTimelineEvents lists the timeline events of a customer, including
+// staff comments with their attachments
+func (s *CustomerServiceOp) ListTimelineEvents(ctx context.Context, customerId uint64, options *TimelineEventsOptions) ([]TimelineEvent, *GraphQLPageInfo, error) {
+	return listTimelineEvents(ctx, s.client, GraphQLId("Customer", customerId), options)
+}
+
+func listTimelineEvents(ctx context.Context, client *Client, id string, options *TimelineEventsOptions) ([]TimelineEvent, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &TimelineEventsOptions{}
+	}
+
+	vars := map[string]interface{}{"id": id, "first": 50}
+	if options.First > 0 {
+		vars["first"] = options.First
+	}
+	if options.After != "" {
+		vars["after"] = options.After
+	}
+	if options.Query != "" {
+		vars["query"] = options.Query
+	}
+
+	resp := struct {
+		Node *struct {
+			Events struct {
+				Nodes    []graphQLTimelineEvent `json:"nodes"`
+				PageInfo GraphQLPageInfo        `json:"pageInfo"`
+			} `json:"events"`
+		} `json:"node"`
+	}{}
+
+	err := client.GraphQL.Query(ctx, timelineEventsQuery, vars, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.Node == nil {
+		return nil, nil, graphQLNotFound()
+	}
+
+	events := make([]TimelineEvent, 0, len(resp.Node.Events.Nodes))
+	for _, node := range resp.Node.Events.Nodes {
+		events = append(events, node.event())
+	}
+	return events, &resp.Node.Events.PageInfo, nil
+}
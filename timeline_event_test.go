@@ -0,0 +1,85 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestOrderListTimelineEvents(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"node":{"events":{
+			"nodes":[
+				{"__typename":"CommentEvent","id":"gid://shopify/CommentEvent/2","createdAt":"2024-03-02T10:00:00Z",
+				 "message":"<p>Refund approved</p>","rawMessage":"Refund approved","edited":true,"attributeToUser":true,
+				 "author":{"name":"Jane Doe"},
+				 "attachments":[{"id":"gid://shopify/CommentEventAttachment/3","name":"receipt.png","url":"https://cdn.shopify.com/receipt.png",
+				   "fileExtension":"png","size":2048,"image":{"url":"https://cdn.shopify.com/receipt.png"}}]},
+				{"__typename":"BasicEvent","id":"gid://shopify/BasicEvent/1","createdAt":"2024-03-01T10:00:00Z",
+				 "message":"Order was placed","action":"create","subjectType":"ORDER","appTitle":"Online Store","attributeToApp":true}
+			],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}}`
+	})
+
+	events, pageInfo, err := client.Order.ListTimelineEvents(context.Background(), 450789469, &TimelineEventsOptions{First: 2, Query: "comments:true"})
+	if err != nil {
+		t.Fatalf("Order.ListTimelineEvents returned error: %v", err)
+	}
+
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Order/450789469", "first": float64(2), "query": "comments:true"}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Order.ListTimelineEvents sent variables %v, expected %v", vars, expectedVars)
+	}
+	if !pageInfo.HasNextPage || pageInfo.EndCursor != "abc" {
+		t.Errorf("Order.ListTimelineEvents returned page info %+v", pageInfo)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Order.ListTimelineEvents returned %d events, expected 2", len(events))
+	}
+
+	comment := events[0]
+	if !comment.Comment || comment.Id != 2 || comment.RawMessage != "Refund approved" || !comment.Edited || comment.Author != "Jane Doe" {
+		t.Errorf("Order.ListTimelineEvents returned comment %+v", comment)
+	}
+	expectedAttachments := []TimelineAttachment{{
+		Id:            "gid://shopify/CommentEventAttachment/3",
+		Name:          "receipt.png",
+		URL:           "https://cdn.shopify.com/receipt.png",
+		FileExtension: "png",
+		Size:          2048,
+		ImageURL:      "https://cdn.shopify.com/receipt.png",
+	}}
+	if !reflect.DeepEqual(comment.Attachments, expectedAttachments) {
+		t.Errorf("Order.ListTimelineEvents returned attachments %+v, expected %+v", comment.Attachments, expectedAttachments)
+	}
+
+	basic := events[1]
+	if basic.Comment || basic.Id != 1 || basic.Action != "create" || basic.SubjectType != "ORDER" || !basic.AttributeToApp || basic.AppTitle != "Online Store" {
+		t.Errorf("Order.ListTimelineEvents returned event %+v", basic)
+	}
+}
+
+func TestCustomerListTimelineEventsNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"node":null}}`
+	})
+
+	_, _, err := client.Customer.ListTimelineEvents(context.Background(), 1, nil)
+	if !isNotFound(err) {
+		t.Errorf("Customer.ListTimelineEvents returned %v, expected not found", err)
+	}
+	expectedVars := map[string]interface{}{"id": "gid://shopify/Customer/1", "first": float64(50)}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("Customer.ListTimelineEvents sent variables %v, expected %v", vars, expectedVars)
+	}
+}
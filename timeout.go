@@ -0,0 +1,46 @@
+package goshopify
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// withRequestTimeout returns the request with the client's default timeout,
+// see WithRequestTimeout, unless its context already has a deadline. The
+// timeout covers the whole call including retries.
+func (c *Client) withRequestTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	if c.requestTimeout <= 0 {
+		return req, func() {}
+	}
+	if _, ok := req.Context().Deadline(); ok {
+		return req, func() {}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.requestTimeout)
+	return req.WithContext(ctx), cancel
+}
+
+// timeoutTransport returns a copy of the transport with the given connect
+// and read timeouts, or false if it isn't an *http.Transport
+func timeoutTransport(rt http.RoundTripper, connect, read time.Duration) (*http.Transport, bool) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	base, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, false
+	}
+
+	t := base.Clone()
+	if connect > 0 {
+		dialer := &net.Dialer{Timeout: connect, KeepAlive: 30 * time.Second}
+		t.DialContext = dialer.DialContext
+		t.TLSHandshakeTimeout = connect
+	}
+	if read > 0 {
+		t.ResponseHeaderTimeout = read
+	}
+	return t, true
+}
@@ -0,0 +1,114 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// newSlowServer returns a server answering shop.json after delay
+func newSlowServer(delay time.Duration) (*httptest.Server, *url.URL) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"shop":{"id":1}}`))
+	}))
+	u, _ := url.Parse(server.URL)
+	return server, u
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	server, u := newSlowServer(time.Second)
+	defer server.Close()
+
+	c := MustNewClient(app, "fooshop", "abcd", WithBaseURL(u), WithRequestTimeout(20*time.Millisecond))
+	_, err := c.Shop.Get(context.Background(), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shop.Get returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWithRequestTimeoutCallerDeadline(t *testing.T) {
+	server, u := newSlowServer(50 * time.Millisecond)
+	defer server.Close()
+
+	c := MustNewClient(app, "fooshop", "abcd", WithBaseURL(u), WithRequestTimeout(10*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the caller's deadline takes precedence over the default
+	if _, err := c.Shop.Get(ctx, nil); err != nil {
+		t.Errorf("Shop.Get with a deadline returned error: %v", err)
+	}
+}
+
+func TestWithRequestTimeoutRateLimitWait(t *testing.T) {
+	c := MustNewClient(app, "fooshop", "abcd", WithVersion(testApiVersion), WithRetry(3), WithRequestTimeout(50*time.Millisecond))
+	httpmock.ActivateNonDefault(c.Client)
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/api/"+testApiVersion+"/shop.json",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client. Reduce request rates to resume uninterrupted service."}`)
+			resp.Header.Set("Retry-After", "10")
+			return resp, nil
+		})
+
+	start := time.Now()
+	_, err := c.Shop.Get(context.Background(), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shop.Get returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Shop.Get waited %s for the rate limit past its timeout", elapsed)
+	}
+}
+
+func TestWithTimeouts(t *testing.T) {
+	httpClient := &http.Client{}
+	c := MustNewClient(app, "fooshop", "abcd", WithHTTPClient(httpClient), WithTimeouts(time.Second, 2*time.Second))
+
+	transport, ok := c.Client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("WithTimeouts set transport %T", c.Client.Transport)
+	}
+	if transport.TLSHandshakeTimeout != time.Second || transport.ResponseHeaderTimeout != 2*time.Second || transport.DialContext == nil {
+		t.Errorf("WithTimeouts set handshake timeout %s and response header timeout %s", transport.TLSHandshakeTimeout, transport.ResponseHeaderTimeout)
+	}
+	if httpClient.Transport != nil || c.Client == httpClient {
+		t.Error("WithTimeouts changed the http client passed to WithHTTPClient")
+	}
+	if http.DefaultTransport.(*http.Transport).ResponseHeaderTimeout != 0 {
+		t.Error("WithTimeouts changed the default transport")
+	}
+
+	// other transports are left alone
+	roundTripper := httpmock.NewMockTransport()
+	c = MustNewClient(app, "fooshop", "abcd", WithHTTPClient(&http.Client{Transport: roundTripper}), WithTimeouts(time.Second, time.Second))
+	if c.Client.Transport != roundTripper {
+		t.Errorf("WithTimeouts replaced transport %T", c.Client.Transport)
+	}
+}
+
+func TestWithTimeoutsRead(t *testing.T) {
+	server, u := newSlowServer(time.Second)
+	defer server.Close()
+
+	c := MustNewClient(app, "fooshop", "abcd", WithBaseURL(u), WithTimeouts(0, 20*time.Millisecond))
+	start := time.Now()
+	if _, err := c.Shop.Get(context.Background(), nil); err == nil {
+		t.Error("Shop.Get of a stalled response returned no error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Shop.Get waited %s for a stalled response", elapsed)
+	}
+}
@@ -0,0 +1,58 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenRefresher returns a fresh access token for the shop, e.g. from a
+// secret store, after Shopify rejected the current one, see
+// WithTokenRefresher
+type TokenRefresher func(ctx context.Context, shop string) (string, error)
+
+// Token returns the access token of the client
+func (c *Client) Token() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
+
+// SetToken swaps the access token of a live client, requests built afterwards
+// use the new token
+func (c *Client) SetToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.token = token
+}
+
+// refreshToken sets a fresh token on a request rejected with a 401 and
+// reports whether it should be retried. Concurrent requests rejected with the
+// same token refresh it only once.
+func (c *Client) refreshToken(req *http.Request) bool {
+	used := req.Header.Get("X-Shopify-Access-Token")
+	if used == "" {
+		return false
+	}
+
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token != used {
+		// rotated since the request was built
+		req.Header.Set("X-Shopify-Access-Token", c.token)
+		return c.token != ""
+	}
+
+	token, err := c.tokenRefresher(req.Context(), c.shop)
+	if err != nil {
+		c.log.Errorf("refreshing access token of %s: %s", c.shop, err)
+		return false
+	}
+	if token == "" || token == used {
+		return false
+	}
+
+	c.token = token
+	req.Header.Set("X-Shopify-Access-Token", token)
+	return true
+}
@@ -0,0 +1,144 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// registerTokenResponder answers with a 401 unless the request has the
+// access token
+func registerTokenResponder(token string) {
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Shopify-Access-Token") != token {
+				return httpmock.NewStringResponse(401, `{"errors":"[API] Invalid API key or access token (unrecognized login or wrong password)"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+}
+
+func TestSetToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTokenResponder("rotated")
+
+	client.SetToken("rotated")
+	if client.Token() != "rotated" {
+		t.Errorf("Token() = %s, expected rotated", client.Token())
+	}
+
+	req, _ := client.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Errorf("Do() returned error: %v", err)
+	}
+}
+
+func TestTokenRefresher(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTokenResponder("fresh")
+
+	refreshes := 0
+	invalidTokens := 0
+	WithTokenRefresher(func(ctx context.Context, shop string) (string, error) {
+		refreshes++
+		if shop != "fooshop.myshopify.com" {
+			t.Errorf("token refresher called for %s", shop)
+		}
+		return "fresh", nil
+	})(client)
+	WithInvalidTokenCallback(func(ctx context.Context, shop, token string, err error) {
+		invalidTokens++
+	})(client)
+
+	req, _ := client.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do() returned error: %v", err)
+	}
+	if refreshes != 1 || invalidTokens != 0 || client.Token() != "fresh" {
+		t.Errorf("Do() refreshed %d times, invalid token callback called %d times, token %s", refreshes, invalidTokens, client.Token())
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("Do() sent %d requests, expected a retry", n)
+	}
+}
+
+func TestTokenRefresherRejected(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTokenResponder("never")
+
+	refreshes := 0
+	invalidTokens := 0
+	WithTokenRefresher(func(ctx context.Context, shop string) (string, error) {
+		refreshes++
+		return "fresh", nil
+	})(client)
+	WithInvalidTokenCallback(func(ctx context.Context, shop, token string, err error) {
+		invalidTokens++
+	})(client)
+
+	req, _ := client.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); !IsInvalidTokenError(err) {
+		t.Errorf("Do() returned %v, expected an invalid token error", err)
+	}
+	if refreshes != 1 || invalidTokens != 1 {
+		t.Errorf("Do() refreshed %d times, invalid token callback called %d times", refreshes, invalidTokens)
+	}
+	if n := httpmock.GetTotalCallCount(); n != 2 {
+		t.Errorf("Do() sent %d requests, expected a single retry", n)
+	}
+}
+
+func TestTokenRefresherError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTokenResponder("fresh")
+
+	WithTokenRefresher(func(ctx context.Context, shop string) (string, error) {
+		return "", errors.New("vault is sealed")
+	})(client)
+
+	req, _ := client.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	if err := client.Do(req, nil); !IsInvalidTokenError(err) {
+		t.Errorf("Do() returned %v, expected an invalid token error", err)
+	}
+	if client.Token() != "abcd" {
+		t.Errorf("Token() = %s, expected the token to be kept", client.Token())
+	}
+}
+
+func TestTokenRefresherStaleRequests(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerTokenResponder("fresh")
+
+	refreshes := 0
+	WithTokenRefresher(func(ctx context.Context, shop string) (string, error) {
+		refreshes++
+		return "fresh", nil
+	})(client)
+
+	// requests built with the old token don't refresh it again
+	reqs := make([]*http.Request, 5)
+	for i := range reqs {
+		reqs[i], _ = client.NewRequest(context.Background(), "GET", "foo/1", nil, nil)
+	}
+	for _, req := range reqs {
+		if err := client.Do(req, nil); err != nil {
+			t.Errorf("Do() returned error: %v", err)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("token refreshed %d times, expected once", refreshes)
+	}
+}
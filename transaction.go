@@ -55,6 +55,10 @@ func (s *TransactionServiceOp) Get(ctx context.Context, orderId uint64, transact
 
 // Create a new transaction
 func (s *TransactionServiceOp) Create(ctx context.Context, orderId uint64, transaction Transaction) (*Transaction, error) {
+	if err := s.client.validateCreate("transaction", transaction); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s/%d/transactions.json", ordersBasePath, orderId)
 	wrappedData := TransactionResource{Transaction: &transaction}
 	resource := new(TransactionResource)
@@ -2,7 +2,9 @@ package goshopify
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -196,3 +198,71 @@ func TestTransactionCreate(t *testing.T) {
 	}
 	TransactionTests(t, *result)
 }
+
+func TestTransactionGetReceiptAndSettlement(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/orders/1/transactions/2.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"transaction":{"id":2,
+			"receipt":{"testcase":true,"authorization":"123456","charges":{"data":[{"id":"ch_1"}]}},
+			"total_unsettled_set":{"presentment_money":{"amount":"0.00","currency":"CAD"},"shop_money":{"amount":"0.00","currency":"USD"}},
+			"payments_refund_attributes":{"status":"success","acquirer_reference_number":"74537604221431003881462"},
+			"currency_exchange_adjustment":{"id":7,"adjustment":"-0.01","original_amount":"1.50","final_amount":"1.49","currency":"CAD"}}}`))
+
+	transaction, err := client.Transaction.Get(context.Background(), 1, 2, nil)
+	if err != nil {
+		t.Fatalf("Transaction.Get returned error: %v", err)
+	}
+
+	if transaction.Receipt.Get("authorization") != "123456" {
+		t.Errorf("Transaction.Receipt.Get returned %q, expected 123456", transaction.Receipt.Get("authorization"))
+	}
+
+	receipt := struct {
+		Testcase bool `json:"testcase"`
+		Charges  struct {
+			Data []struct {
+				Id string `json:"id"`
+			} `json:"data"`
+		} `json:"charges"`
+	}{}
+	err = transaction.Receipt.Decode(&receipt)
+	if err != nil || !receipt.Testcase || len(receipt.Charges.Data) != 1 || receipt.Charges.Data[0].Id != "ch_1" {
+		t.Errorf("Transaction.Receipt.Decode returned %+v, %v", receipt, err)
+	}
+
+	if transaction.TotalUnsettledSet == nil || transaction.TotalUnsettledSet.ShopMoney.CurrencyCode != "USD" ||
+		!transaction.TotalUnsettledSet.PresentmentMoney.Amount.IsZero() {
+		t.Errorf("Transaction.TotalUnsettledSet returned %+v", transaction.TotalUnsettledSet)
+	}
+
+	expectedRefund := &PaymentsRefundAttributes{Status: "success", AcquirerReferenceNumber: "74537604221431003881462"}
+	if !reflect.DeepEqual(transaction.PaymentsRefundAttributes, expectedRefund) {
+		t.Errorf("Transaction.PaymentsRefundAttributes returned %+v, expected %+v", transaction.PaymentsRefundAttributes, expectedRefund)
+	}
+
+	adjustment := transaction.CurrencyExchangeAdjustment
+	if adjustment == nil || adjustment.Id != 7 || adjustment.Currency != "CAD" || !adjustment.Adjustment.Equal(decimal.NewFromFloat(-0.01)) {
+		t.Errorf("Transaction.CurrencyExchangeAdjustment returned %+v", adjustment)
+	}
+}
+
+func TestTransactionReceiptEmpty(t *testing.T) {
+	var receipt TransactionReceipt
+
+	fields, err := receipt.Fields()
+	if err != nil || len(fields) != 0 {
+		t.Errorf("TransactionReceipt.Fields returned %v, %v, expected an empty map", fields, err)
+	}
+
+	data, err := json.Marshal(Transaction{Id: 1})
+	if err != nil || string(data) != `{"id":1}` {
+		t.Errorf("json.Marshal(Transaction) returned %s, %v", data, err)
+	}
+
+	data, err = json.Marshal(Transaction{Receipt: TransactionReceipt(`{"a":1}`)})
+	if err != nil || string(data) != `{"receipt":{"a":1}}` {
+		t.Errorf("json.Marshal(Transaction) returned %s, %v", data, err)
+	}
+}
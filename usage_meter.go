@@ -0,0 +1,310 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const appUsagePricingQuery = `query appUsagePricing($id: ID!) {
+  node(id: $id) {
+    ... on AppSubscriptionLineItem {
+      plan {
+        pricingDetails {
+          ... on AppUsagePricing {
+            cappedAmount {
+              amount
+              currencyCode
+            }
+            balanceUsed {
+              amount
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const appUsageRecordCreateMutation = `mutation appUsageRecordCreate($subscriptionLineItemId: ID!, $price: MoneyInput!, $description: String!, $idempotencyKey: String) {
+  appUsageRecordCreate(subscriptionLineItemId: $subscriptionLineItemId, price: $price, description: $description, idempotencyKey: $idempotencyKey) {
+    appUsageRecord {
+      id
+      description
+      createdAt
+      price {
+        amount
+      }
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+// UsageCapReachedError is returned when a usage charge would exceed the
+// capped amount of the subscription
+type UsageCapReachedError struct {
+	CappedAmount decimal.Decimal
+	BalanceUsed  decimal.Decimal
+	Price        decimal.Decimal
+}
+
+func (e UsageCapReachedError) Error() string {
+	return fmt.Sprintf("usage charge of %s exceeds the remaining %s of the capped amount %s",
+		e.Price, e.CappedAmount.Sub(e.BalanceUsed), e.CappedAmount)
+}
+
+// UsageRecord is a usage charge made through a UsageMeter
+type UsageRecord struct {
+	Description    string
+	Price          decimal.Decimal
+	IdempotencyKey string
+}
+
+type usageBilling interface {
+	balance(ctx context.Context) (capped, used decimal.Decimal, err error)
+	charge(ctx context.Context, record UsageRecord) (*UsageCharge, error)
+}
+
+// UsageMeter makes usage charges against the capped amount of a
+// subscription. It tracks the balance used and refuses charges exceeding the
+// capped amount with a UsageCapReachedError before calling Shopify. Charges
+// are made one at a time, a UsageMeter is safe for concurrent use.
+type UsageMeter struct {
+	billing usageBilling
+
+	mu     sync.Mutex
+	loaded bool
+	capped decimal.Decimal
+	used   decimal.Decimal
+}
+
+// NewUsageMeter returns a UsageMeter for the usage charges of a recurring
+// application charge
+func NewUsageMeter(client *Client, recurringChargeId uint64) *UsageMeter {
+	return &UsageMeter{billing: restUsageBilling{client: client, chargeId: recurringChargeId}}
+}
+
+// NewAppSubscriptionUsageMeter returns a UsageMeter for the usage records of
+// an app subscription line item with usage pricing, made with the GraphQL
+// appUsageRecordCreate mutation
+func NewAppSubscriptionUsageMeter(client *Client, subscriptionLineItemId string) *UsageMeter {
+	return &UsageMeter{billing: &graphQLUsageBilling{client: client, lineItemId: subscriptionLineItemId}}
+}
+
+// Refresh reloads the capped amount and balance used from Shopify, e.g.
+// after the merchant approved a new capped amount
+func (m *UsageMeter) Refresh(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.refresh(ctx)
+}
+
+func (m *UsageMeter) refresh(ctx context.Context) error {
+	capped, used, err := m.billing.balance(ctx)
+	if err != nil {
+		return err
+	}
+	m.capped, m.used, m.loaded = capped, used, true
+	return nil
+}
+
+// Remaining returns the amount left to charge before the cap is reached
+func (m *UsageMeter) Remaining(ctx context.Context) (decimal.Decimal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.loaded {
+		if err := m.refresh(ctx); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	return m.capped.Sub(m.used), nil
+}
+
+// Charge makes a usage charge unless it would exceed the capped amount, in
+// which case it returns a UsageCapReachedError. Shopify refusing the charge
+// for exceeding the cap returns a UsageCapReachedError as well.
+func (m *UsageMeter) Charge(ctx context.Context, record UsageRecord) (*UsageCharge, error) {
+	if !record.Price.IsPositive() {
+		return nil, fmt.Errorf("usage charge price must be positive, got %s", record.Price)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.loaded {
+		if err := m.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if m.used.Add(record.Price).GreaterThan(m.capped) {
+		return nil, m.capReached(record.Price)
+	}
+
+	charge, err := m.billing.charge(ctx, record)
+	if isUsageCapError(err) {
+		// the local balance was stale, e.g. charges were made elsewhere
+		if refreshErr := m.refresh(ctx); refreshErr != nil {
+			m.loaded = false
+		}
+		return nil, m.capReached(record.Price)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if charge.BalanceUsed != nil {
+		m.used = *charge.BalanceUsed
+	} else {
+		m.used = m.used.Add(record.Price)
+	}
+	return charge, nil
+}
+
+func (m *UsageMeter) capReached(price decimal.Decimal) UsageCapReachedError {
+	return UsageCapReachedError{CappedAmount: m.capped, BalanceUsed: m.used, Price: price}
+}
+
+// isUsageCapError reports whether Shopify refused a usage charge for
+// exceeding the capped amount
+func isUsageCapError(err error) bool {
+	var msgs []string
+	switch e := err.(type) {
+	case ResponseError:
+		if e.Status != 422 {
+			return false
+		}
+		msgs = append([]string{e.Message}, e.Errors...)
+	case GraphQLUserErrors:
+		for _, userErr := range e {
+			msgs = append(msgs, userErr.Message)
+		}
+	default:
+		return false
+	}
+
+	for _, msg := range msgs {
+		msg = strings.ToLower(msg)
+		if strings.Contains(msg, "capped amount") || strings.Contains(msg, "exceeds balance") ||
+			strings.Contains(msg, "balance remaining") {
+			return true
+		}
+	}
+	return false
+}
+
+type restUsageBilling struct {
+	client   *Client
+	chargeId uint64
+}
+
+func (b restUsageBilling) balance(ctx context.Context) (decimal.Decimal, decimal.Decimal, error) {
+	charge, err := b.client.RecurringApplicationCharge.Get(ctx, b.chargeId, nil)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	if charge.CappedAmount == nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("recurring application charge %d has no capped amount", b.chargeId)
+	}
+
+	used := decimal.Zero
+	if charge.BalanceUsed != nil {
+		used = *charge.BalanceUsed
+	}
+	return *charge.CappedAmount, used, nil
+}
+
+func (b restUsageBilling) charge(ctx context.Context, record UsageRecord) (*UsageCharge, error) {
+	return b.client.UsageCharge.Create(ctx, b.chargeId, UsageCharge{
+		Description: record.Description,
+		Price:       &record.Price,
+	})
+}
+
+type graphQLUsageBilling struct {
+	client     *Client
+	lineItemId string
+	// currency of the capped amount, usage records are charged in it
+	currency string
+}
+
+func (b *graphQLUsageBilling) balance(ctx context.Context) (decimal.Decimal, decimal.Decimal, error) {
+	resp := struct {
+		Node *struct {
+			Plan struct {
+				PricingDetails struct {
+					CappedAmount *graphQLMoney `json:"cappedAmount"`
+					BalanceUsed  *graphQLMoney `json:"balanceUsed"`
+				} `json:"pricingDetails"`
+			} `json:"plan"`
+		} `json:"node"`
+	}{}
+
+	err := b.client.GraphQL.Query(ctx, appUsagePricingQuery, map[string]interface{}{"id": b.lineItemId}, &resp)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	if resp.Node == nil {
+		return decimal.Zero, decimal.Zero, graphQLNotFound()
+	}
+	pricing := resp.Node.Plan.PricingDetails
+	if pricing.CappedAmount == nil || pricing.CappedAmount.Amount == nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("app subscription line item %s has no usage pricing", b.lineItemId)
+	}
+	b.currency = pricing.CappedAmount.CurrencyCode
+
+	used := decimal.Zero
+	if pricing.BalanceUsed != nil && pricing.BalanceUsed.Amount != nil {
+		used = *pricing.BalanceUsed.Amount
+	}
+	return *pricing.CappedAmount.Amount, used, nil
+}
+
+func (b *graphQLUsageBilling) charge(ctx context.Context, record UsageRecord) (*UsageCharge, error) {
+	vars := map[string]interface{}{
+		"subscriptionLineItemId": b.lineItemId,
+		"description":            record.Description,
+		"price":                  map[string]interface{}{"amount": record.Price.String(), "currencyCode": b.currency},
+	}
+	if record.IdempotencyKey != "" {
+		vars["idempotencyKey"] = record.IdempotencyKey
+	}
+
+	resp := struct {
+		AppUsageRecordCreate struct {
+			AppUsageRecord *struct {
+				Id          string        `json:"id"`
+				Description string        `json:"description"`
+				CreatedAt   *time.Time    `json:"createdAt"`
+				Price       *graphQLMoney `json:"price"`
+			} `json:"appUsageRecord"`
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"appUsageRecordCreate"`
+	}{}
+
+	err := b.client.GraphQL.Query(ctx, appUsageRecordCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.AppUsageRecordCreate.UserErrors) > 0 {
+		return nil, resp.AppUsageRecordCreate.UserErrors
+	}
+
+	created := resp.AppUsageRecordCreate.AppUsageRecord
+	if created == nil {
+		return nil, fmt.Errorf("appUsageRecordCreate returned no usage record")
+	}
+	charge := &UsageCharge{Description: created.Description, CreatedAt: created.CreatedAt, Price: &record.Price}
+	charge.Id, _ = ParseGraphQLId(created.Id)
+	if created.Price != nil && created.Price.Amount != nil {
+		charge.Price = created.Price.Amount
+	}
+	return charge, nil
+}
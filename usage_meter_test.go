@@ -0,0 +1,144 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/shopspring/decimal"
+)
+
+func registerRecurringChargeBalance(capped, used string) {
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/recurring_application_charges/455696195.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, fmt.Sprintf(`{"recurring_application_charge":{"id":455696195,"status":"active","capped_amount":"%s","balance_used":"%s"}}`, capped, used)))
+}
+
+func TestUsageMeterCharge(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerRecurringChargeBalance("100.00", "90.00")
+	var sent UsageChargeResource
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/recurring_application_charges/455696195/usage_charges.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Errorf("invalid usage charge body: %v", err)
+			}
+			return httpmock.NewStringResponse(200, `{"usage_charge":{"id":1,"description":"100 emails","price":"6.00","balance_used":"96.00","balance_remaining":"4.00"}}`), nil
+		})
+
+	meter := NewUsageMeter(client, 455696195)
+	remaining, err := meter.Remaining(context.Background())
+	if err != nil || !remaining.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("UsageMeter.Remaining returned %s, %v, expected 10", remaining, err)
+	}
+
+	charge, err := meter.Charge(context.Background(), UsageRecord{Description: "100 emails", Price: decimal.NewFromInt(6)})
+	if err != nil {
+		t.Fatalf("UsageMeter.Charge returned error: %v", err)
+	}
+	if charge.Id != 1 || sent.Charge.Description != "100 emails" || !sent.Charge.Price.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("UsageMeter.Charge sent %+v and returned %+v", sent.Charge, charge)
+	}
+
+	_, err = meter.Charge(context.Background(), UsageRecord{Description: "100 emails", Price: decimal.NewFromInt(6)})
+	capErr, ok := err.(UsageCapReachedError)
+	if !ok {
+		t.Fatalf("UsageMeter.Charge returned %v, expected UsageCapReachedError", err)
+	}
+	expected := UsageCapReachedError{CappedAmount: decimal.NewFromInt(100), BalanceUsed: decimal.NewFromInt(96), Price: decimal.NewFromInt(6)}
+	if !capErr.CappedAmount.Equal(expected.CappedAmount) || !capErr.BalanceUsed.Equal(expected.BalanceUsed) || !capErr.Price.Equal(expected.Price) {
+		t.Errorf("UsageMeter.Charge returned %+v, expected %+v", capErr, expected)
+	}
+
+	info := httpmock.GetCallCountInfo()
+	if calls := info[fmt.Sprintf("POST https://fooshop.myshopify.com/%s/recurring_application_charges/455696195/usage_charges.json", client.pathPrefix)]; calls != 1 {
+		t.Errorf("UsageMeter.Charge created %d usage charges, expected the second refused locally", calls)
+	}
+	if calls := info[fmt.Sprintf("GET https://fooshop.myshopify.com/%s/recurring_application_charges/455696195.json", client.pathPrefix)]; calls != 1 {
+		t.Errorf("UsageMeter fetched the balance %d times, expected once", calls)
+	}
+
+	if _, err := meter.Charge(context.Background(), UsageRecord{Price: decimal.Zero}); err == nil {
+		t.Error("UsageMeter.Charge accepted a zero price")
+	}
+}
+
+func TestUsageMeterChargeShopifyCapError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerRecurringChargeBalance("100.00", "50.00")
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://fooshop.myshopify.com/%s/recurring_application_charges/455696195/usage_charges.json", client.pathPrefix),
+		httpmock.NewStringResponder(422, `{"errors":{"base":["Total price exceeds balance remaining"]}}`))
+
+	meter := NewUsageMeter(client, 455696195)
+	_, err := meter.Charge(context.Background(), UsageRecord{Description: "sms", Price: decimal.NewFromInt(20)})
+	if _, ok := err.(UsageCapReachedError); !ok {
+		t.Errorf("UsageMeter.Charge returned %v, expected UsageCapReachedError", err)
+	}
+	if calls := httpmock.GetCallCountInfo()[fmt.Sprintf("GET https://fooshop.myshopify.com/%s/recurring_application_charges/455696195.json", client.pathPrefix)]; calls != 2 {
+		t.Errorf("UsageMeter fetched the balance %d times, expected a refresh after the cap error", calls)
+	}
+}
+
+func TestAppSubscriptionUsageMeter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var queries []string
+	var vars []map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		queries = append(queries, req.Query)
+		vars = append(vars, req.Variables)
+
+		if req.Query == appUsagePricingQuery {
+			return `{"data":{"node":{"plan":{"pricingDetails":{
+				"cappedAmount":{"amount":"50.0","currencyCode":"CAD"},
+				"balanceUsed":{"amount":"0.0","currencyCode":"CAD"}}}}}}`
+		}
+		return `{"data":{"appUsageRecordCreate":{
+			"appUsageRecord":{"id":"gid://shopify/AppUsageRecord/7","description":"1 report","price":{"amount":"12.5"}},
+			"userErrors":[]}}}`
+	})
+
+	lineItemId := "gid://shopify/AppSubscriptionLineItem/4?v=1&index=1"
+	meter := NewAppSubscriptionUsageMeter(client, lineItemId)
+	charge, err := meter.Charge(context.Background(), UsageRecord{Description: "1 report", Price: decimal.RequireFromString("12.5"), IdempotencyKey: "report-1"})
+	if err != nil {
+		t.Fatalf("UsageMeter.Charge returned error: %v", err)
+	}
+	if charge.Id != 7 || !charge.Price.Equal(decimal.RequireFromString("12.5")) {
+		t.Errorf("UsageMeter.Charge returned %+v", charge)
+	}
+
+	if len(vars) != 2 || vars[0]["id"] != lineItemId {
+		t.Fatalf("UsageMeter sent %v", vars)
+	}
+	expected := map[string]interface{}{
+		"subscriptionLineItemId": lineItemId,
+		"description":            "1 report",
+		"price":                  map[string]interface{}{"amount": "12.5", "currencyCode": "CAD"},
+		"idempotencyKey":         "report-1",
+	}
+	if !reflect.DeepEqual(vars[1], expected) {
+		t.Errorf("UsageMeter.Charge sent variables %v, expected %v", vars[1], expected)
+	}
+
+	remaining, _ := meter.Remaining(context.Background())
+	if !remaining.Equal(decimal.RequireFromString("37.5")) {
+		t.Errorf("UsageMeter.Remaining returned %s, expected 37.5", remaining)
+	}
+	if _, err := meter.Charge(context.Background(), UsageRecord{Description: "4 reports", Price: decimal.NewFromInt(50)}); err == nil {
+		t.Error("UsageMeter.Charge exceeded the capped amount")
+	}
+	if len(queries) != 2 {
+		t.Errorf("UsageMeter sent %d queries, expected 2", len(queries))
+	}
+}
@@ -0,0 +1,310 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const usersBasePath = "users"
+
+const staffMemberFields = `
+  id
+  firstName
+  lastName
+  name
+  email
+  phone
+  locale
+  accountType
+  active
+  isShopOwner`
+
+const staffMembersQuery = `query staffMembers($first: Int!, $after: String) {
+  staffMembers(first: $first, after: $after) {
+    nodes {` + staffMemberFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+const staffMemberQuery = `query staffMember($id: ID!) {
+  staffMember(id: $id) {` + staffMemberFields + `
+  }
+}`
+
+const accountOwnerQuery = `query accountOwner {
+  shop {
+    accountOwner {` + staffMemberFields + `
+    }
+  }
+}`
+
+const staffMemberNamesQuery = `query staffMemberNames($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    ... on StaffMember {
+      id
+      name
+    }
+  }
+}`
+
+// UserService is an interface for interfacing with the user endpoints of the
+// Shopify API and the staff members of the GraphQL API.
+// See: https://shopify.dev/docs/api/admin-rest/latest/resources/user
+type UserService interface {
+	List(context.Context, interface{}) ([]User, error)
+	Get(context.Context, uint64, interface{}) (*User, error)
+	Current(context.Context) (*User, error)
+	ListStaffMembers(context.Context, *StaffMemberListOptions) ([]StaffMember, *GraphQLPageInfo, error)
+	GetStaffMember(context.Context, uint64) (*StaffMember, error)
+	AccountOwner(context.Context) (*StaffMember, error)
+	Names(context.Context, []uint64) (map[uint64]string, error)
+}
+
+// UserServiceOp handles communication with the user related methods of the
+// Shopify API.
+type UserServiceOp struct {
+	client *Client
+}
+
+// User represents a Shopify staff account, as returned by the REST API. The
+// users endpoints are only available to Shopify Plus shops.
+type User struct {
+	Id                   uint64   `json:"id,omitempty"`
+	FirstName            string   `json:"first_name,omitempty"`
+	LastName             string   `json:"last_name,omitempty"`
+	Email                string   `json:"email,omitempty"`
+	Phone                string   `json:"phone,omitempty"`
+	URL                  string   `json:"url,omitempty"`
+	Im                   string   `json:"im,omitempty"`
+	ScreenName           string   `json:"screen_name,omitempty"`
+	Bio                  string   `json:"bio,omitempty"`
+	AccountOwner         bool     `json:"account_owner,omitempty"`
+	ReceiveAnnouncements int      `json:"receive_announcements,omitempty"`
+	Locale               string   `json:"locale,omitempty"`
+	UserType             string   `json:"user_type,omitempty"`
+	TfaEnabled           bool     `json:"tfa_enabled?,omitempty"`
+	Permissions          []string `json:"permissions,omitempty"`
+	AdminGraphqlApiId    string   `json:"admin_graphql_api_id,omitempty"`
+}
+
+// Name returns the full name of the user
+func (u User) Name() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// UserResource represents the result from the users/X.json endpoint
+type UserResource struct {
+	User *User `json:"user"`
+}
+
+// UsersResource represents the result from the users.json endpoint
+type UsersResource struct {
+	Users []User `json:"users"`
+}
+
+// StaffMember represents a staff member of the GraphQL API, which unlike the
+// users endpoints is available to all shops with the read_users scope.
+type StaffMember struct {
+	Id          uint64 `json:"id,omitempty"`
+	FirstName   string `json:"first_name,omitempty"`
+	LastName    string `json:"last_name,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	AccountType string `json:"account_type,omitempty"`
+	Active      bool   `json:"active,omitempty"`
+	IsShopOwner bool   `json:"is_shop_owner,omitempty"`
+}
+
+// StaffMemberListOptions are the options of listing staff members
+type StaffMemberListOptions struct {
+	// number of staff members per page, defaults to 50
+	First int
+	After string
+}
+
+type graphQLStaffMember struct {
+	Id          string `json:"id"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Locale      string `json:"locale"`
+	AccountType string `json:"accountType"`
+	Active      bool   `json:"active"`
+	IsShopOwner bool   `json:"isShopOwner"`
+}
+
+func (m *graphQLStaffMember) staffMember() *StaffMember {
+	if m == nil {
+		return nil
+	}
+
+	id, _ := ParseGraphQLId(m.Id)
+	return &StaffMember{
+		Id:          id,
+		FirstName:   m.FirstName,
+		LastName:    m.LastName,
+		Name:        m.Name,
+		Email:       m.Email,
+		Phone:       m.Phone,
+		Locale:      m.Locale,
+		AccountType: strings.ToLower(m.AccountType),
+		Active:      m.Active,
+		IsShopOwner: m.IsShopOwner,
+	}
+}
+
+// List users
+func (s *UserServiceOp) List(ctx context.Context, options interface{}) ([]User, error) {
+	path := fmt.Sprintf("%s.json", usersBasePath)
+	resource := new(UsersResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.Users, err
+}
+
+// Get individual user
+func (s *UserServiceOp) Get(ctx context.Context, userId uint64, options interface{}) (*User, error) {
+	path := fmt.Sprintf("%s/%d.json", usersBasePath, userId)
+	resource := new(UserResource)
+	err := s.client.Get(ctx, path, resource, options)
+	return resource.User, err
+}
+
+// Current returns the user the access token was issued for, which requires an
+// online access token
+func (s *UserServiceOp) Current(ctx context.Context) (*User, error) {
+	path := fmt.Sprintf("%s/current.json", usersBasePath)
+	resource := new(UserResource)
+	err := s.client.Get(ctx, path, resource, nil)
+	return resource.User, err
+}
+
+// ListStaffMembers lists a page of the shop's staff members through the
+// GraphQL API
+func (s *UserServiceOp) ListStaffMembers(ctx context.Context, options *StaffMemberListOptions) ([]StaffMember, *GraphQLPageInfo, error) {
+	if options == nil {
+		options = &StaffMemberListOptions{}
+	}
+
+	vars := map[string]interface{}{"first": options.First}
+	if options.First <= 0 {
+		vars["first"] = 50
+	}
+	if options.After != "" {
+		vars["after"] = options.After
+	}
+
+	resp := struct {
+		StaffMembers struct {
+			Nodes    []graphQLStaffMember `json:"nodes"`
+			PageInfo GraphQLPageInfo      `json:"pageInfo"`
+		} `json:"staffMembers"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, staffMembersQuery, vars, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := make([]StaffMember, 0, len(resp.StaffMembers.Nodes))
+	for i := range resp.StaffMembers.Nodes {
+		members = append(members, *resp.StaffMembers.Nodes[i].staffMember())
+	}
+	return members, &resp.StaffMembers.PageInfo, nil
+}
+
+// GetStaffMember returns a staff member through the GraphQL API, the id is
+// the same as the user_id of orders and users
+func (s *UserServiceOp) GetStaffMember(ctx context.Context, userId uint64) (*StaffMember, error) {
+	resp := struct {
+		StaffMember *graphQLStaffMember `json:"staffMember"`
+	}{}
+
+	vars := map[string]interface{}{"id": GraphQLId("StaffMember", userId)}
+	err := s.client.GraphQL.Query(ctx, staffMemberQuery, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StaffMember == nil {
+		return nil, graphQLNotFound()
+	}
+	return resp.StaffMember.staffMember(), nil
+}
+
+// AccountOwner returns the staff member owning the shop
+func (s *UserServiceOp) AccountOwner(ctx context.Context) (*StaffMember, error) {
+	resp := struct {
+		Shop struct {
+			AccountOwner *graphQLStaffMember `json:"accountOwner"`
+		} `json:"shop"`
+	}{}
+
+	err := s.client.GraphQL.Query(ctx, accountOwnerQuery, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Shop.AccountOwner == nil {
+		return nil, graphQLNotFound()
+	}
+	return resp.Shop.AccountOwner.staffMember(), nil
+}
+
+// Names resolves user ids, such as the user_id of orders and refunds, to the
+// names of the staff members in a single GraphQL query. Ids of deleted staff
+// members are missing from the result.
+func (s *UserServiceOp) Names(ctx context.Context, userIds []uint64) (map[uint64]string, error) {
+	names := make(map[uint64]string, len(userIds))
+
+	ids := make([]string, 0, len(userIds))
+	seen := map[uint64]bool{}
+	for _, id := range userIds {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, GraphQLId("StaffMember", id))
+	}
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	// the nodes field takes at most 250 ids
+	for start := 0; start < len(ids); start += 250 {
+		end := start + 250
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		resp := struct {
+			Nodes []*struct {
+				Id   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"nodes"`
+		}{}
+		vars := map[string]interface{}{"ids": ids[start:end]}
+		err := s.client.GraphQL.Query(ctx, staffMemberNamesQuery, vars, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range resp.Nodes {
+			if n == nil || n.Id == "" {
+				continue
+			}
+			id, err := ParseGraphQLId(n.Id)
+			if err != nil {
+				return nil, err
+			}
+			names[id] = n.Name
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,213 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestUserList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/users.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("users.json")))
+
+	users, err := client.User.List(context.Background(), nil)
+	if err != nil {
+		t.Errorf("User.List returned error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("User.List returned %d users, expected 2", len(users))
+	}
+
+	owner := users[0]
+	if owner.Id != 548380009 || !owner.AccountOwner || !owner.TfaEnabled || owner.Locale != "en" {
+		t.Errorf("User.List returned %+v", owner)
+	}
+	if owner.Name() != "John Smith" {
+		t.Errorf("User.Name returned %q, expected John Smith", owner.Name())
+	}
+	if !reflect.DeepEqual(owner.Permissions, []string{"applications", "orders", "products"}) {
+		t.Errorf("User.Permissions returned %v", owner.Permissions)
+	}
+	if users[1].AccountOwner || users[1].UserType != "restricted" {
+		t.Errorf("User.List returned %+v", users[1])
+	}
+}
+
+func TestUserGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/users/548380009.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("user.json")))
+
+	user, err := client.User.Get(context.Background(), 548380009, nil)
+	if err != nil {
+		t.Errorf("User.Get returned error: %v", err)
+	}
+	if user.Id != 548380009 || user.Email != "j.smith@example.com" {
+		t.Errorf("User.Get returned %+v", user)
+	}
+}
+
+func TestUserCurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/users/current.json", client.pathPrefix),
+		httpmock.NewBytesResponder(200, loadFixture("user.json")))
+
+	user, err := client.User.Current(context.Background())
+	if err != nil {
+		t.Errorf("User.Current returned error: %v", err)
+	}
+	if user.Id != 548380009 {
+		t.Errorf("User.Current returned %+v", user)
+	}
+}
+
+func TestUserListStaffMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"staffMembers":{
+			"nodes":[
+				{"id":"gid://shopify/StaffMember/548380009","firstName":"John","lastName":"Smith","name":"John Smith",
+				 "email":"j.smith@example.com","locale":"en","accountType":"REGULAR","active":true,"isShopOwner":true},
+				{"id":"gid://shopify/StaffMember/930143300","firstName":"Steve","lastName":"Jobs","name":"Steve Jobs",
+				 "email":"steve@example.com","locale":"fr","accountType":"COLLABORATOR","active":false,"isShopOwner":false}
+			],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}
+		}}}`
+	})
+
+	members, pageInfo, err := client.User.ListStaffMembers(context.Background(), &StaffMemberListOptions{After: "xyz"})
+	if err != nil {
+		t.Fatalf("User.ListStaffMembers returned error: %v", err)
+	}
+	if vars["first"] != float64(50) || vars["after"] != "xyz" {
+		t.Errorf("User.ListStaffMembers sent variables %v", vars)
+	}
+
+	expected := []StaffMember{
+		{Id: 548380009, FirstName: "John", LastName: "Smith", Name: "John Smith", Email: "j.smith@example.com",
+			Locale: "en", AccountType: "regular", Active: true, IsShopOwner: true},
+		{Id: 930143300, FirstName: "Steve", LastName: "Jobs", Name: "Steve Jobs", Email: "steve@example.com",
+			Locale: "fr", AccountType: "collaborator"},
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("User.ListStaffMembers returned %+v, expected %+v", members, expected)
+	}
+	if !pageInfo.HasNextPage || pageInfo.EndCursor != "abc" {
+		t.Errorf("User.ListStaffMembers returned page info %+v", pageInfo)
+	}
+}
+
+func TestUserGetStaffMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"staffMember":{
+			"id":"gid://shopify/StaffMember/548380009","name":"John Smith","locale":"en","isShopOwner":true
+		}}}`
+	})
+
+	member, err := client.User.GetStaffMember(context.Background(), 548380009)
+	if err != nil {
+		t.Fatalf("User.GetStaffMember returned error: %v", err)
+	}
+	if vars["id"] != "gid://shopify/StaffMember/548380009" {
+		t.Errorf("User.GetStaffMember sent variables %v", vars)
+	}
+	if member.Id != 548380009 || member.Name != "John Smith" || !member.IsShopOwner {
+		t.Errorf("User.GetStaffMember returned %+v", member)
+	}
+}
+
+func TestUserGetStaffMemberNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"staffMember":null}}`
+	})
+
+	_, err := client.User.GetStaffMember(context.Background(), 1)
+	if rerr, ok := err.(ResponseError); !ok || rerr.Status != 404 {
+		t.Errorf("User.GetStaffMember returned %v, expected a 404", err)
+	}
+}
+
+func TestUserAccountOwner(t *testing.T) {
+	setup()
+	defer teardown()
+
+	registerGraphQL(t, nil, func(graphQLRequest) string {
+		return `{"data":{"shop":{"accountOwner":{
+			"id":"gid://shopify/StaffMember/548380009","name":"John Smith","email":"j.smith@example.com","isShopOwner":true
+		}}}}`
+	})
+
+	owner, err := client.User.AccountOwner(context.Background())
+	if err != nil {
+		t.Fatalf("User.AccountOwner returned error: %v", err)
+	}
+	if owner.Id != 548380009 || !owner.IsShopOwner {
+		t.Errorf("User.AccountOwner returned %+v", owner)
+	}
+}
+
+func TestUserNames(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"nodes":[
+			{"id":"gid://shopify/StaffMember/548380009","name":"John Smith"},
+			null,
+			{"id":"gid://shopify/StaffMember/930143300","name":"Steve Jobs"}
+		]}}`
+	})
+
+	names, err := client.User.Names(context.Background(), []uint64{548380009, 0, 1, 930143300, 548380009})
+	if err != nil {
+		t.Fatalf("User.Names returned error: %v", err)
+	}
+
+	expectedIds := []interface{}{
+		"gid://shopify/StaffMember/548380009",
+		"gid://shopify/StaffMember/1",
+		"gid://shopify/StaffMember/930143300",
+	}
+	if !reflect.DeepEqual(vars["ids"], expectedIds) {
+		t.Errorf("User.Names sent ids %v, expected %v", vars["ids"], expectedIds)
+	}
+
+	expected := map[uint64]string{548380009: "John Smith", 930143300: "Steve Jobs"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("User.Names returned %v, expected %v", names, expected)
+	}
+}
+
+func TestUserNamesEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+
+	names, err := client.User.Names(context.Background(), []uint64{0})
+	if err != nil || len(names) != 0 {
+		t.Errorf("User.Names returned %v, %v, expected no names without a request", names, err)
+	}
+}
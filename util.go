@@ -3,6 +3,7 @@ package goshopify
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -25,6 +26,17 @@ func ShopShortName(name string) string {
 	return strings.Replace(ShopFullName(name), ".myshopify.com", "", -1)
 }
 
+var shopNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*\.myshopify\.com$`)
+
+// ValidateShopName returns an error if the name isn't a shop's myshopify
+// domain, e.g. "theshop.myshopify.com", or simply "theshop"
+func ValidateShopName(name string) error {
+	if !shopNameRegex.MatchString(ShopFullName(name)) {
+		return fmt.Errorf("invalid shop name %q", name)
+	}
+	return nil
+}
+
 // Return the Shop's base url.
 func ShopBaseUrl(name string) string {
 	name = ShopFullName(name)
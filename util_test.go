@@ -156,3 +156,27 @@ func TestOnlyDateEncode(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateShopName(t *testing.T) {
+	cases := []struct {
+		in    string
+		valid bool
+	}{
+		{"myshop", true},
+		{"my-shop-2", true},
+		{" myshop.myshopify.com ", true},
+		{"my shop", false},
+		{"my_shop", false},
+		{"-myshop", false},
+		{"myshop.example.com", false},
+		{"evil.com/myshop", false},
+		{"myshop.myshopify.com.evil.com", false},
+	}
+
+	for _, c := range cases {
+		err := ValidateShopName(c.in)
+		if (err == nil) != c.valid {
+			t.Errorf("ValidateShopName(%s): expected valid %v, actual error %v", c.in, c.valid, err)
+		}
+	}
+}
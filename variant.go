@@ -3,6 +3,7 @@ package goshopify
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -23,6 +24,8 @@ type VariantService interface {
 	Create(context.Context, uint64, Variant) (*Variant, error)
 	Update(context.Context, Variant) (*Variant, error)
 	Delete(context.Context, uint64, uint64) error
+	GetBySKU(context.Context, []string) (map[string][]Variant, error)
+	GetByBarcode(context.Context, []string) (map[string][]Variant, error)
 
 	// MetafieldsService used for Variant resource to communicate with Metafields resource
 	MetafieldsService
@@ -34,17 +37,18 @@ type VariantServiceOp struct {
 	client *Client
 }
 
-type variantInventoryPolicy string
+// VariantInventoryPolicy tells whether a variant can be ordered when out of stock
+type VariantInventoryPolicy string
 
 // https://shopify.dev/docs/api/admin-rest/2023-07/resources/product-variant#resource-object
 const (
 	// Customers are not allowed to place orders for the product variant if it's out
 	// of stock. This is the default value.
-	VariantInventoryPolicyDeny variantInventoryPolicy = "deny"
+	VariantInventoryPolicyDeny VariantInventoryPolicy = "deny"
 
 	// Customers are allowed to place orders for the product variant if it's out of
 	// stock.
-	VariantInventoryPolicyContinue variantInventoryPolicy = "continue"
+	VariantInventoryPolicyContinue VariantInventoryPolicy = "continue"
 )
 
 // Variant represents a Shopify variant
@@ -55,7 +59,7 @@ type Variant struct {
 	Sku                  string                 `json:"sku,omitempty"`
 	Position             int                    `json:"position,omitempty"`
 	Grams                int                    `json:"grams,omitempty"`
-	InventoryPolicy      variantInventoryPolicy `json:"inventory_policy,omitempty"`
+	InventoryPolicy      VariantInventoryPolicy `json:"inventory_policy,omitempty"`
 	Price                *decimal.Decimal       `json:"price,omitempty"`
 	CompareAtPrice       *decimal.Decimal       `json:"compare_at_price,omitempty"`
 	FulfillmentService   string                 `json:"fulfillment_service,omitempty"`
@@ -77,14 +81,28 @@ type Variant struct {
 	RequireShipping      bool                   `json:"requires_shipping"`
 	AdminGraphqlApiId    string                 `json:"admin_graphql_api_id,omitempty"`
 	Metafields           []Metafield            `json:"metafields,omitempty"`
-	PresentmentPrices    []presentmentPrices    `json:"presentment_prices,omitempty"`
+
+	PresentmentPrices []VariantPresentmentPrice `json:"presentment_prices,omitempty"`
 }
 
-type presentmentPrices struct {
+// VariantPresentmentPrice is the price of a variant in a presentment currency
+type VariantPresentmentPrice struct {
 	Price          *AmountSetEntry `json:"price,omitempty"`
 	CompareAtPrice *AmountSetEntry `json:"compare_at_price,omitempty"`
 }
 
+// PriceIn returns the presentment price of the variant in currency, or nil if
+// the variant has none. Presentment prices are only returned when requested
+// with the presentment_currencies option.
+func (v Variant) PriceIn(currency string) *VariantPresentmentPrice {
+	for i, price := range v.PresentmentPrices {
+		if price.Price != nil && strings.EqualFold(price.Price.CurrencyCode, currency) {
+			return &v.PresentmentPrices[i]
+		}
+	}
+	return nil
+}
+
 // VariantResource represents the result from the variants/X.json endpoint
 type VariantResource struct {
 	Variant *Variant `json:"variant"`
@@ -0,0 +1,102 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+const variantLookupQuery = `query variantLookup($query: String!, $first: Int!, $after: String) {
+  productVariants(query: $query, first: $first, after: $after) {
+    nodes {
+      legacyResourceId
+      title
+      sku
+      position
+      price
+      compareAtPrice
+      barcode
+      taxable
+      inventoryQuantity
+      inventoryPolicy
+      createdAt
+      updatedAt
+      selectedOptions { value }
+      inventoryItem { legacyResourceId }
+      image { id }
+      product { legacyResourceId }
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+// number of values searched for in one productVariants query, more make the
+// search query slow and long
+const variantLookupBatchSize = 50
+
+// GetBySKU resolves SKUs to the variants having them, searching in batches
+// through the GraphQL productVariants query instead of listing the catalog.
+// SKUs are not unique in Shopify, so each SKU maps to all of its variants;
+// SKUs without variants are missing from the result.
+func (s *VariantServiceOp) GetBySKU(ctx context.Context, skus []string) (map[string][]Variant, error) {
+	return s.lookup(ctx, "sku", skus, func(v Variant) string { return v.Sku })
+}
+
+// GetByBarcode resolves barcodes, e.g. UPCs or ISBNs, to the variants
+// having them, see GetBySKU
+func (s *VariantServiceOp) GetByBarcode(ctx context.Context, barcodes []string) (map[string][]Variant, error) {
+	return s.lookup(ctx, "barcode", barcodes, func(v Variant) string { return v.Barcode })
+}
+
+func (s *VariantServiceOp) lookup(ctx context.Context, field string, values []string, key func(Variant) string) (map[string][]Variant, error) {
+	wanted := map[string]bool{}
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || wanted[value] {
+			continue
+		}
+		wanted[value] = true
+		unique = append(unique, value)
+	}
+
+	found := make(map[string][]Variant, len(unique))
+	for start := 0; start < len(unique); start += variantLookupBatchSize {
+		end := start + variantLookupBatchSize
+		if end > len(unique) {
+			end = len(unique)
+		}
+
+		terms := make([]string, 0, end-start)
+		for _, value := range unique[start:end] {
+			terms = append(terms, field+":"+QuoteSearchValue(value))
+		}
+
+		vars := map[string]interface{}{
+			"query": strings.Join(terms, " OR "),
+			"first": 250,
+		}
+		err := s.client.GraphQLEachNode(ctx, variantLookupQuery, vars, "productVariants", func(node json.RawMessage) error {
+			n := struct {
+				graphQLProductVariant
+				Product *graphQLLegacyResource `json:"product"`
+			}{}
+			if err := json.Unmarshal(node, &n); err != nil {
+				return err
+			}
+
+			// the search also matches prefixes and tokens, keep exact matches
+			variant := n.variant(n.Product.id())
+			if k := key(variant); wanted[k] {
+				found[k] = append(found[k], variant)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return found, nil
+}
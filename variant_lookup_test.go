@@ -0,0 +1,121 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestVariantGetBySKU(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLPages(map[string]string{
+		"": `{"data":{"productVariants":{
+			"nodes":[
+				{"legacyResourceId":"1","sku":"SHIRT-S","price":"10.00","barcode":"111",
+				 "selectedOptions":[{"value":"S"}],"inventoryItem":{"legacyResourceId":"11"},"product":{"legacyResourceId":"100"}},
+				{"legacyResourceId":"2","sku":"SHIRT-S-RED","product":{"legacyResourceId":"100"}}
+			],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`,
+		"abc": `{"data":{"productVariants":{
+			"nodes":[
+				{"legacyResourceId":"3","sku":"SHIRT-S","product":{"legacyResourceId":"200"}},
+				{"legacyResourceId":"4","sku":"MUG \"XL\"","product":{"legacyResourceId":"300"}}
+			],
+			"pageInfo":{"hasNextPage":false,"endCursor":"def"}}}}`,
+	}))
+
+	variants, err := client.Variant.GetBySKU(context.Background(), []string{"SHIRT-S", "MUG \"XL\"", "SHIRT-S", "", "MISSING"})
+	if err != nil {
+		t.Fatalf("Variant.GetBySKU returned error: %v", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatalf("Variant.GetBySKU sent %d queries, expected 2 pages", len(sent))
+	}
+	expectedQuery := `sku:SHIRT-S OR sku:"MUG \"XL\"" OR sku:MISSING`
+	if sent[0].Variables["query"] != expectedQuery || sent[0].Variables["first"] != float64(250) {
+		t.Errorf("Variant.GetBySKU sent variables %v, expected query %s", sent[0].Variables, expectedQuery)
+	}
+
+	if len(variants) != 2 {
+		t.Errorf("Variant.GetBySKU returned %d SKUs, expected 2: %+v", len(variants), variants)
+	}
+	shirts := variants["SHIRT-S"]
+	if len(shirts) != 2 || shirts[0].Id != 1 || shirts[1].Id != 3 {
+		t.Fatalf("Variant.GetBySKU returned %+v for SHIRT-S, expected variants 1 and 3", shirts)
+	}
+	price := decimal.NewFromInt(10)
+	if shirts[0].ProductId != 100 || shirts[0].InventoryItemId != 11 || !shirts[0].Price.Equal(price) || shirts[0].Option1 != "S" {
+		t.Errorf("Variant.GetBySKU returned %+v", shirts[0])
+	}
+	if shirts[1].ProductId != 200 {
+		t.Errorf("Variant.GetBySKU returned product %d, expected 200", shirts[1].ProductId)
+	}
+	if mugs := variants[`MUG "XL"`]; len(mugs) != 1 || mugs[0].Id != 4 {
+		t.Errorf("Variant.GetBySKU returned %+v for the quoted SKU", mugs)
+	}
+}
+
+func TestVariantGetByBarcodeBatches(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var queries []string
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		query := req.Variables["query"].(string)
+		queries = append(queries, query)
+
+		// answer with the first barcode of the batch
+		barcode := strings.TrimPrefix(strings.SplitN(query, " OR ", 2)[0], "barcode:")
+		return fmt.Sprintf(`{"data":{"productVariants":{
+			"nodes":[{"legacyResourceId":"%d","barcode":"%s","product":{"legacyResourceId":"1"}}],
+			"pageInfo":{"hasNextPage":false}}}}`, len(queries), barcode)
+	})
+
+	barcodes := make([]string, 0, 120)
+	for i := 0; i < 120; i++ {
+		barcodes = append(barcodes, fmt.Sprintf("%012d", i))
+	}
+
+	variants, err := client.Variant.GetByBarcode(context.Background(), barcodes)
+	if err != nil {
+		t.Fatalf("Variant.GetByBarcode returned error: %v", err)
+	}
+
+	if len(queries) != 3 {
+		t.Fatalf("Variant.GetByBarcode sent %d queries, expected 3 batches", len(queries))
+	}
+	for i, expected := range []int{50, 50, 20} {
+		if terms := strings.Count(queries[i], "barcode:"); terms != expected {
+			t.Errorf("batch %d searched %d barcodes, expected %d", i, terms, expected)
+		}
+	}
+
+	expected := map[string][]uint64{"000000000000": {1}, "000000000050": {2}, "000000000100": {3}}
+	got := map[string][]uint64{}
+	for barcode, vs := range variants {
+		for _, v := range vs {
+			got[barcode] = append(got[barcode], v.Id)
+		}
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Variant.GetByBarcode returned %v, expected %v", got, expected)
+	}
+}
+
+func TestVariantGetBySKUEmpty(t *testing.T) {
+	setup()
+	defer teardown()
+
+	variants, err := client.Variant.GetBySKU(context.Background(), []string{""})
+	if err != nil || len(variants) != 0 {
+		t.Errorf("Variant.GetBySKU returned %v, %v, expected nothing without a request", variants, err)
+	}
+}
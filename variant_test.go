@@ -455,3 +455,28 @@ func variantTestsWithTaxCode(t *testing.T, variant Variant) {
 		t.Errorf("Variant.TaxCode returned %+v, expected %+v", variant.TaxCode, expectedTacCode)
 	}
 }
+
+func TestVariantPriceIn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/variants/1.json", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"variant":{"id":1,"price":"10.00","presentment_prices":[
+			{"price":{"amount":"10.00","currency_code":"USD"},"compare_at_price":null},
+			{"price":{"amount":"9.00","currency_code":"EUR"},"compare_at_price":{"amount":"12.00","currency_code":"EUR"}}
+		]}}`))
+
+	variant, err := client.Variant.Get(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("Variant.Get returned error: %v", err)
+	}
+
+	price := variant.PriceIn("eur")
+	if price == nil || !price.Price.Amount.Equal(decimal.NewFromFloat(9)) || !price.CompareAtPrice.Amount.Equal(decimal.NewFromFloat(12)) {
+		t.Errorf("Variant.PriceIn returned %+v, expected 9.00 EUR compared at 12.00", price)
+	}
+
+	if price := variant.PriceIn("GBP"); price != nil {
+		t.Errorf("Variant.PriceIn returned %+v, expected nil", price)
+	}
+}
@@ -0,0 +1,130 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// WaitGetFunc fetches the current state of a resource for WaitFor, e.g.
+// a closure around ProductService.Get.
+type WaitGetFunc func(ctx context.Context) (interface{}, error)
+
+// WaitPredicate reports whether a resource returned by a WaitGetFunc has
+// reached the awaited state.
+type WaitPredicate func(resource interface{}) bool
+
+// Backoff returns the delay before the given poll attempt, starting at 1
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff waits the same delay between all attempts
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff doubles the delay after every attempt, starting at
+// initial and capped at max.
+func ExponentialBackoff(initial, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		return delay
+	}
+}
+
+var defaultWaitBackoff = ExponentialBackoff(250*time.Millisecond, 5*time.Second)
+
+// WaitFor polls get until predicate returns true for the returned resource,
+// or ctx is done, and returns the last resource. It papers over Shopify's
+// eventual consistency, e.g. reading a product back right after updating it.
+// Not found errors are polled again, as a freshly created resource may not be
+// readable yet, other errors are returned immediately. A nil backoff waits
+// 250ms doubling up to 5s between attempts.
+func WaitFor(ctx context.Context, get WaitGetFunc, predicate WaitPredicate, backoff Backoff) (interface{}, error) {
+	if backoff == nil {
+		backoff = defaultWaitBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		resource, err := get(ctx)
+		if err == nil && predicate(resource) {
+			return resource, nil
+		}
+		if err != nil && !isNotFound(err) {
+			return resource, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return resource, ctx.Err()
+		case <-time.After(backoff(attempt)):
+		}
+	}
+}
+
+func isNotFound(err error) bool {
+	respErr, ok := err.(ResponseError)
+	return ok && respErr.Status == http.StatusNotFound
+}
+
+// UpdatedAfter is a WaitPredicate for resources with an UpdatedAt field,
+// e.g. *Product or *Order, reporting whether they were updated after t,
+// typically the UpdatedAt of the resource before the write.
+func UpdatedAfter(t time.Time) WaitPredicate {
+	return func(resource interface{}) bool {
+		updatedAt := resourceUpdatedAt(resource)
+		return updatedAt != nil && updatedAt.After(t)
+	}
+}
+
+// UpdatedSince is like UpdatedAfter but also accepts an update in the same
+// second as t, as Shopify only keeps updated_at to the second. Two writes in
+// the same second can't be told apart, so prefer UpdatedAfter with the
+// previous UpdatedAt when the resource was read before the write.
+func UpdatedSince(t time.Time) WaitPredicate {
+	t = t.Truncate(time.Second)
+	return func(resource interface{}) bool {
+		updatedAt := resourceUpdatedAt(resource)
+		return updatedAt != nil && !updatedAt.Before(t)
+	}
+}
+
+// resourceUpdatedAt returns the UpdatedAt field of the resource, nil if it
+// has none
+func resourceUpdatedAt(resource interface{}) *time.Time {
+	v := reflect.ValueOf(resource)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := v.FieldByName("UpdatedAt")
+	if !field.IsValid() {
+		return nil
+	}
+	updatedAt, _ := field.Interface().(*time.Time)
+	return updatedAt
+}
+
+// WaitForUpdate polls the product until its UpdatedAt is after the given
+// time, see UpdatedAfter and WaitFor.
+func (s *ProductServiceOp) WaitForUpdate(ctx context.Context, productId uint64, after time.Time, backoff Backoff) (*Product, error) {
+	resource, err := WaitFor(ctx, func(ctx context.Context) (interface{}, error) {
+		return s.Get(ctx, productId, nil)
+	}, UpdatedAfter(after), backoff)
+	product, _ := resource.(*Product)
+	return product, err
+}
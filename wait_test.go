@@ -0,0 +1,151 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second)
+
+	cases := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{100, 5 * time.Second},
+	}
+	for _, c := range cases {
+		if actual := backoff(c.attempt); actual != c.expected {
+			t.Errorf("backoff(%d) returned %s, expected %s", c.attempt, actual, c.expected)
+		}
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	calls := 0
+	get := func(ctx context.Context) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, ResponseError{Status: http.StatusNotFound}
+		}
+		return calls, nil
+	}
+	done := func(resource interface{}) bool {
+		return resource.(int) >= 3
+	}
+
+	resource, err := WaitFor(context.Background(), get, done, ConstantBackoff(time.Millisecond))
+	if err != nil {
+		t.Fatalf("WaitFor returned error: %v", err)
+	}
+	if resource != 3 || calls != 3 {
+		t.Errorf("WaitFor returned %v after %d calls, expected 3 after 3", resource, calls)
+	}
+}
+
+func TestWaitForError(t *testing.T) {
+	expectedErr := ResponseError{Status: http.StatusInternalServerError}
+	calls := 0
+	get := func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, expectedErr
+	}
+
+	_, err := WaitFor(context.Background(), get, func(interface{}) bool { return true }, ConstantBackoff(time.Millisecond))
+	if respErr, ok := err.(ResponseError); !ok || respErr.Status != expectedErr.Status || calls != 1 {
+		t.Errorf("WaitFor returned %v after %d calls, expected %v after 1", err, calls, expectedErr)
+	}
+}
+
+func TestWaitForContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	get := func(ctx context.Context) (interface{}, error) {
+		return "pending", nil
+	}
+
+	resource, err := WaitFor(ctx, get, func(interface{}) bool { return false }, ConstantBackoff(time.Millisecond))
+	if err != context.DeadlineExceeded || resource != "pending" {
+		t.Errorf("WaitFor returned %v, %v, expected pending, %v", resource, err, context.DeadlineExceeded)
+	}
+}
+
+func TestUpdatedAfter(t *testing.T) {
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := before.Add(time.Minute)
+
+	cases := []struct {
+		resource interface{}
+		expected bool
+	}{
+		{&Product{UpdatedAt: &after}, true},
+		{Order{UpdatedAt: &after}, true},
+		// the previous updated_at isn't an update
+		{&Product{UpdatedAt: &before}, false},
+		{&Product{}, false},
+		{(*Product)(nil), false},
+		{nil, false},
+		{"not a resource", false},
+	}
+	for i, c := range cases {
+		if actual := UpdatedAfter(before)(c.resource); actual != c.expected {
+			t.Errorf("case %d: UpdatedAfter returned %v, expected %v", i, actual, c.expected)
+		}
+	}
+}
+
+func TestUpdatedSince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC)
+	before := time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC)
+	sameSecond := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		resource interface{}
+		expected bool
+	}{
+		// updated_at is truncated to the second
+		{&Product{UpdatedAt: &sameSecond}, true},
+		{&Product{UpdatedAt: &before}, false},
+		{&Product{}, false},
+		{nil, false},
+	}
+	for i, c := range cases {
+		if actual := UpdatedSince(since)(c.resource); actual != c.expected {
+			t.Errorf("case %d: UpdatedSince returned %v, expected %v", i, actual, c.expected)
+		}
+	}
+}
+
+func TestProductWaitForUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://fooshop.myshopify.com/%s/products/1.json", client.pathPrefix),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 2 {
+				return httpmock.NewStringResponse(200, `{"product":{"id":1,"updated_at":"2024-01-01T00:00:00Z"}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"product":{"id":1,"updated_at":"2024-01-01T00:01:00Z"}}`), nil
+		})
+
+	previous := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	product, err := client.Product.WaitForUpdate(context.Background(), 1, previous, ConstantBackoff(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Product.WaitForUpdate returned error: %v", err)
+	}
+	if calls != 2 || !product.UpdatedAt.After(previous) {
+		t.Errorf("Product.WaitForUpdate returned %v after %d calls", product.UpdatedAt, calls)
+	}
+}
@@ -18,6 +18,10 @@ type WebhookService interface {
 	Create(context.Context, Webhook) (*Webhook, error)
 	Update(context.Context, Webhook) (*Webhook, error)
 	Delete(context.Context, uint64) error
+	ListSubscriptions(context.Context, ...string) ([]WebhookSubscription, error)
+	CreateSubscription(context.Context, WebhookSubscription) (*WebhookSubscription, error)
+	UpdateSubscription(context.Context, WebhookSubscription) (*WebhookSubscription, error)
+	DeleteSubscription(context.Context, uint64) error
 }
 
 // WebhookServiceOp handles communication with the webhook-related methods of
@@ -80,6 +84,10 @@ func (s *WebhookServiceOp) Get(ctx context.Context, webhookdId uint64, options i
 
 // Create a new webhook
 func (s *WebhookServiceOp) Create(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	if err := s.client.validateCreate("webhook", webhook); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", webhooksBasePath)
 	wrappedData := WebhookResource{Webhook: &webhook}
 	resource := new(WebhookResource)
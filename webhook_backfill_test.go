@@ -0,0 +1,87 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestBackfillWebhooks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	listURL := fmt.Sprintf("https://fooshop.myshopify.com/%s/orders.json", client.pathPrefix)
+	pages := []struct {
+		query string
+		link  string
+		body  string
+	}{
+		{
+			"?limit=250&status=any&updated_at_max=2024-01-02T00%3A00%3A00Z&updated_at_min=2024-01-01T00%3A00%3A00Z",
+			`<http://valid.url?page_info=pg2>; rel="next"`,
+			`{"orders": [{"id":1,"custom":"kept"}]}`,
+		},
+		{"?page_info=pg2", "", `{"orders": [{"id":2}]}`},
+	}
+	for _, p := range pages {
+		response := &http.Response{
+			StatusCode: 200,
+			Body:       httpmock.NewRespBodyFromString(p.body),
+			Header:     http.Header{"Link": {p.link}},
+		}
+		httpmock.RegisterResponder("GET", listURL+p.query, httpmock.ResponderFromResponse(response))
+	}
+
+	var bodies []string
+	mux := NewWebhookMux(app)
+	mux.HandleFunc("orders/updated", func(ctx context.Context, d *WebhookDelivery) error {
+		if !d.Replayed || d.ShopDomain != "fooshop.myshopify.com" || d.ApiVersion != testApiVersion {
+			t.Errorf("BackfillWebhooks delivered %+v", d)
+		}
+		bodies = append(bodies, string(d.Body))
+		return nil
+	})
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	count, err := client.BackfillWebhooks(context.Background(), "orders/updated", since, since.Add(24*time.Hour), mux)
+	if err != nil {
+		t.Fatalf("Client.BackfillWebhooks returned error: %v", err)
+	}
+
+	expected := []string{`{"id":1,"custom":"kept"}`, `{"id":2}`}
+	if count != 2 || !reflect.DeepEqual(bodies, expected) {
+		t.Errorf("Client.BackfillWebhooks delivered %d %v, expected %v", count, bodies, expected)
+	}
+}
+
+func TestBackfillWebhooksCreatedWindow(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET",
+		fmt.Sprintf("https://fooshop.myshopify.com/%s/products.json?created_at_min=2024-01-01T00%%3A00%%3A00Z&limit=250", client.pathPrefix),
+		httpmock.NewStringResponder(200, `{"products": [{"id":1}]}`))
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	count, err := client.BackfillWebhooks(context.Background(), "products/create", since, time.Time{},
+		WebhookHandlerFunc(func(ctx context.Context, d *WebhookDelivery) error { return nil }))
+	if err != nil || count != 1 {
+		t.Errorf("Client.BackfillWebhooks returned %d, %v, expected 1 delivery", count, err)
+	}
+}
+
+func TestBackfillWebhooksUnsupportedTopic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.BackfillWebhooks(context.Background(), "app/uninstalled", time.Time{}, time.Time{}, NewWebhookMux(app))
+	expected := "webhook backfill is not supported for topic app/uninstalled"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Client.BackfillWebhooks err returned %v, expected %s", err, expected)
+	}
+}
@@ -0,0 +1,95 @@
+package goshopify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CloudEvents attributes of converted webhooks
+const (
+	CloudEventSpecVersion = "1.0"
+	CloudEventTypePrefix  = "com.shopify.webhook."
+)
+
+// CloudEvent is a webhook delivery wrapped in a CloudEvents 1.0 envelope,
+// which marshals to the JSON event format (structured content mode).
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	Id              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      []byte          `json:"data_base64,omitempty"` // payloads which aren't JSON
+
+	// Extension attributes
+	ShopifyApiVersion string `json:"shopifyapiversion,omitempty"`
+	ShopifyReplayed   bool   `json:"shopifyreplayed,omitempty"`
+}
+
+// CloudEvent converts the delivery into a CloudEvent. The type is derived
+// from the topic, e.g. com.shopify.webhook.orders.updated, the source from
+// the shop domain and the subject from the id of the resource in the payload.
+// The id is the webhook id, replayed deliveries which have none get one
+// derived from their content so that consumers can still deduplicate them.
+// A body which isn't JSON is kept as is in DataBase64.
+func (d *WebhookDelivery) CloudEvent() *CloudEvent {
+	event := &CloudEvent{
+		SpecVersion:       CloudEventSpecVersion,
+		Id:                d.WebhookId,
+		Source:            "https://" + d.ShopDomain,
+		Type:              CloudEventTypePrefix + strings.Replace(d.Topic, "/", ".", -1),
+		Subject:           webhookResourceId(d.Body),
+		Time:              d.TriggeredAt,
+		ShopifyApiVersion: d.ApiVersion,
+		ShopifyReplayed:   d.Replayed,
+	}
+
+	if json.Valid(d.Body) {
+		event.DataContentType = "application/json"
+		event.Data = json.RawMessage(d.Body)
+	} else if len(d.Body) > 0 {
+		event.DataContentType = "application/octet-stream"
+		event.DataBase64 = d.Body
+	}
+
+	if event.Id == "" {
+		sum := sha256.Sum256([]byte(d.Topic + "\n" + d.ShopDomain + "\n" + string(d.Body)))
+		event.Id = hex.EncodeToString(sum[:])
+	}
+
+	return event
+}
+
+// webhookResourceId returns the id of the resource of a webhook payload, if
+// any
+func webhookResourceId(body []byte) string {
+	payload := struct {
+		Id json.Number `json:"id"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	if _, err := strconv.ParseUint(payload.Id.String(), 10, 64); err != nil {
+		return ""
+	}
+	return payload.Id.String()
+}
+
+// CloudEventPublisher publishes a CloudEvent to an event bus
+type CloudEventPublisher func(ctx context.Context, event *CloudEvent) error
+
+// CloudEventHandler returns a WebhookHandler converting deliveries into
+// CloudEvents and passing them to publish, e.g. to register on a WebhookMux.
+func CloudEventHandler(publish CloudEventPublisher) WebhookHandler {
+	return WebhookHandlerFunc(func(ctx context.Context, delivery *WebhookDelivery) error {
+		return publish(ctx, delivery.CloudEvent())
+	})
+}
@@ -0,0 +1,91 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliveryCloudEvent(t *testing.T) {
+	triggeredAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	delivery := &WebhookDelivery{
+		Topic:       "orders/updated",
+		ShopDomain:  "fooshop.myshopify.com",
+		ApiVersion:  "2024-01",
+		WebhookId:   "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+		TriggeredAt: &triggeredAt,
+		Body:        []byte(`{"id":450789469,"name":"#1001"}`),
+	}
+
+	data, err := json.Marshal(delivery.CloudEvent())
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	expected := `{"specversion":"1.0","id":"b54557e4-bdd9-4b37-8a5f-bf7d70bcd043","source":"https://fooshop.myshopify.com",` +
+		`"type":"com.shopify.webhook.orders.updated","subject":"450789469","time":"2024-01-01T10:00:00Z",` +
+		`"datacontenttype":"application/json","data":{"id":450789469,"name":"#1001"},"shopifyapiversion":"2024-01"}`
+	if string(data) != expected {
+		t.Errorf("CloudEvent marshalled to %s, expected %s", data, expected)
+	}
+}
+
+func TestWebhookDeliveryCloudEventNotJSON(t *testing.T) {
+	delivery := &WebhookDelivery{
+		Topic:      "orders/updated",
+		ShopDomain: "fooshop.myshopify.com",
+		WebhookId:  "1",
+		Body:       []byte(`{"id":1,`),
+	}
+
+	data, err := json.Marshal(delivery.CloudEvent())
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	expected := `{"specversion":"1.0","id":"1","source":"https://fooshop.myshopify.com","type":"com.shopify.webhook.orders.updated",` +
+		`"datacontenttype":"application/octet-stream","data_base64":"eyJpZCI6MSw="}`
+	if string(data) != expected {
+		t.Errorf("CloudEvent marshalled to %s, expected %s", data, expected)
+	}
+}
+
+func TestWebhookDeliveryCloudEventReplayed(t *testing.T) {
+	delivery := &WebhookDelivery{
+		Topic:      "app/uninstalled",
+		ShopDomain: "fooshop.myshopify.com",
+		Body:       []byte(`{"id":"not numeric"}`),
+		Replayed:   true,
+	}
+
+	event := delivery.CloudEvent()
+	if event.Subject != "" || !event.ShopifyReplayed || event.Type != "com.shopify.webhook.app.uninstalled" {
+		t.Errorf("WebhookDelivery.CloudEvent returned %+v", event)
+	}
+	if len(event.Id) != 64 || event.Id != delivery.CloudEvent().Id {
+		t.Errorf("WebhookDelivery.CloudEvent returned unstable id %s", event.Id)
+	}
+
+	delivery.Body = []byte(`{"id":"other"}`)
+	if delivery.CloudEvent().Id == event.Id {
+		t.Errorf("WebhookDelivery.CloudEvent returned the same id for different payloads")
+	}
+}
+
+func TestCloudEventHandler(t *testing.T) {
+	var published *CloudEvent
+	mux := NewWebhookMux(app)
+	mux.Handle("orders/create", CloudEventHandler(func(ctx context.Context, event *CloudEvent) error {
+		published = event
+		return nil
+	}))
+
+	err := mux.HandleWebhook(context.Background(), &WebhookDelivery{Topic: "orders/create", ShopDomain: "fooshop.myshopify.com", Body: []byte(`{"id":1}`)})
+	if err != nil {
+		t.Fatalf("CloudEventHandler returned error: %v", err)
+	}
+	if published == nil || published.Type != "com.shopify.webhook.orders.create" || published.Subject != "1" {
+		t.Errorf("CloudEventHandler published %+v", published)
+	}
+}
@@ -0,0 +1,165 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Headers Shopify sets on every webhook delivery
+const (
+	WebhookTopicHeader       = "X-Shopify-Topic"
+	WebhookShopDomainHeader  = "X-Shopify-Shop-Domain"
+	WebhookApiVersionHeader  = "X-Shopify-API-Version"
+	WebhookIdHeader          = "X-Shopify-Webhook-Id"
+	WebhookTriggeredAtHeader = "X-Shopify-Triggered-At"
+)
+
+// WebhookDelivery is a single webhook payload along with its metadata, as
+// received over HTTP or replayed by a backfill.
+type WebhookDelivery struct {
+	Topic       string
+	ShopDomain  string
+	ApiVersion  string
+	WebhookId   string
+	TriggeredAt *time.Time
+	Body        []byte
+
+	// Replayed is set for deliveries produced by BackfillWebhooks rather
+	// than sent by Shopify.
+	Replayed bool
+}
+
+// Decode unmarshals the delivery body into v
+func (d *WebhookDelivery) Decode(v interface{}) error {
+	return json.Unmarshal(d.Body, v)
+}
+
+// WebhookHandler handles a single webhook delivery
+type WebhookHandler interface {
+	HandleWebhook(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+// WebhookHandlerFunc is an adapter to use ordinary functions as WebhookHandler
+type WebhookHandlerFunc func(ctx context.Context, delivery *WebhookDelivery) error
+
+// HandleWebhook calls f(ctx, delivery)
+func (f WebhookHandlerFunc) HandleWebhook(ctx context.Context, delivery *WebhookDelivery) error {
+	return f(ctx, delivery)
+}
+
+// ParseWebhookRequest verifies the HMAC of a webhook http request and returns
+// the delivery it carries.
+func (app App) ParseWebhookRequest(httpRequest *http.Request) (*WebhookDelivery, error) {
+	_, err := app.VerifyWebhookRequestVerbose(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(httpRequest.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWebhookDelivery(httpRequest.Header, body), nil
+}
+
+// newWebhookDelivery builds a delivery from the Shopify webhook headers,
+// whether sent as http headers or as queue message attributes.
+func newWebhookDelivery(header http.Header, body []byte) *WebhookDelivery {
+	delivery := &WebhookDelivery{
+		Topic:      header.Get(WebhookTopicHeader),
+		ShopDomain: header.Get(WebhookShopDomainHeader),
+		ApiVersion: header.Get(WebhookApiVersionHeader),
+		WebhookId:  header.Get(WebhookIdHeader),
+		Body:       body,
+	}
+
+	if triggeredAt, err := time.Parse(time.RFC3339, header.Get(WebhookTriggeredAtHeader)); err == nil {
+		delivery.TriggeredAt = &triggeredAt
+	}
+
+	return delivery
+}
+
+// WebhookMux dispatches webhook deliveries to handlers registered by topic.
+// It can be mounted as an http.Handler for live webhooks and also be passed
+// anywhere a WebhookHandler is expected, e.g. BackfillWebhooks.
+type WebhookMux struct {
+	app App
+
+	// Logger logs the handler errors, which aren't sent back in responses.
+	// Errors go to os.Stderr when it is nil.
+	Logger LeveledLoggerInterface
+
+	mu       sync.RWMutex
+	handlers map[string]WebhookHandler
+}
+
+// NewWebhookMux returns a WebhookMux verifying http requests with the app's
+// ApiSecret.
+func NewWebhookMux(app App) *WebhookMux {
+	return &WebhookMux{
+		app:      app,
+		handlers: map[string]WebhookHandler{},
+	}
+}
+
+// Handle registers the handler for the given topic, e.g. "orders/updated"
+func (m *WebhookMux) Handle(topic string, handler WebhookHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[topic] = handler
+}
+
+// HandleFunc registers the handler function for the given topic
+func (m *WebhookMux) HandleFunc(topic string, handler func(context.Context, *WebhookDelivery) error) {
+	m.Handle(topic, WebhookHandlerFunc(handler))
+}
+
+// HandleWebhook dispatches the delivery to the handler registered for its
+// topic. Deliveries for topics without a handler are ignored.
+func (m *WebhookMux) HandleWebhook(ctx context.Context, delivery *WebhookDelivery) error {
+	m.mu.RLock()
+	handler, ok := m.handlers[delivery.Topic]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	return handler.HandleWebhook(ctx, delivery)
+}
+
+// ServeHTTP verifies and dispatches a webhook http request. Requests failing
+// verification get a 401, handler errors a 500 so that Shopify retries the
+// delivery. Handler errors are logged rather than sent to Shopify, where they
+// would show in the delivery logs.
+func (m *WebhookMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	delivery, err := m.app.ParseWebhookRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid Signature", http.StatusUnauthorized)
+		return
+	}
+
+	err = m.HandleWebhook(r.Context(), delivery)
+	if err != nil {
+		webhookLogger(m.Logger).Errorf("%s webhook %s of %s failed: %v", delivery.Topic, delivery.WebhookId, delivery.ShopDomain, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// webhookLogger returns the logger, or one logging errors to os.Stderr when
+// it is nil
+func webhookLogger(logger LeveledLoggerInterface) LeveledLoggerInterface {
+	if logger == nil {
+		return &LeveledLogger{Level: LevelError}
+	}
+	return logger
+}
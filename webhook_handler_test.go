@@ -0,0 +1,121 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testWebhookBody = `"my secret message"`
+	testWebhookHMAC = "hMTq0K2x7oyOjoBwGYeTj5oxfnaVYXzbanUG9aajpKI="
+)
+
+func newTestWebhookRequest(hmac string) *http.Request {
+	req := httptest.NewRequest("POST", "/webhooks", bytes.NewBufferString(testWebhookBody))
+	req.Header.Set(shopifyChecksumHeader, hmac)
+	req.Header.Set(WebhookTopicHeader, "orders/updated")
+	req.Header.Set(WebhookShopDomainHeader, "fooshop.myshopify.com")
+	req.Header.Set(WebhookApiVersionHeader, "2024-01")
+	req.Header.Set(WebhookIdHeader, "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043")
+	req.Header.Set(WebhookTriggeredAtHeader, "2024-01-01T10:00:00Z")
+	return req
+}
+
+func TestParseWebhookRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	delivery, err := app.ParseWebhookRequest(newTestWebhookRequest(testWebhookHMAC))
+	if err != nil {
+		t.Fatalf("App.ParseWebhookRequest returned error: %v", err)
+	}
+
+	triggeredAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	if delivery.Topic != "orders/updated" ||
+		delivery.ShopDomain != "fooshop.myshopify.com" ||
+		delivery.ApiVersion != "2024-01" ||
+		delivery.WebhookId != "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043" ||
+		delivery.TriggeredAt == nil || !delivery.TriggeredAt.Equal(triggeredAt) ||
+		string(delivery.Body) != testWebhookBody {
+		t.Errorf("App.ParseWebhookRequest returned %+v", delivery)
+	}
+
+	var message string
+	err = delivery.Decode(&message)
+	if err != nil || message != "my secret message" {
+		t.Errorf("WebhookDelivery.Decode returned %q, %v, expected %q", message, err, "my secret message")
+	}
+}
+
+func TestParseWebhookRequestInvalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := app.ParseWebhookRequest(newTestWebhookRequest(""))
+	if err == nil {
+		t.Errorf("App.ParseWebhookRequest expected an error for a missing hmac")
+	}
+}
+
+func TestWebhookMuxServeHTTP(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handlerErr := errors.New("boom")
+	cases := []struct {
+		hmac     string
+		err      error
+		expected int
+	}{
+		{testWebhookHMAC, nil, http.StatusOK},
+		{testWebhookHMAC, handlerErr, http.StatusInternalServerError},
+		{"wronghash", nil, http.StatusUnauthorized},
+	}
+
+	for _, c := range cases {
+		var received *WebhookDelivery
+		logs := &bytes.Buffer{}
+		mux := NewWebhookMux(app)
+		mux.Logger = &LeveledLogger{Level: LevelError, stderrOverride: logs}
+		mux.HandleFunc("orders/updated", func(ctx context.Context, d *WebhookDelivery) error {
+			received = d
+			return c.err
+		})
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestWebhookRequest(c.hmac))
+
+		if rec.Code != c.expected {
+			t.Errorf("WebhookMux.ServeHTTP responded %d, expected %d", rec.Code, c.expected)
+		}
+		if strings.Contains(rec.Body.String(), "boom") {
+			t.Errorf("WebhookMux.ServeHTTP responded with the handler error %q", rec.Body)
+		}
+		if c.err != nil && !strings.Contains(logs.String(), "boom") {
+			t.Errorf("WebhookMux.ServeHTTP logged %q, expected the handler error", logs)
+		}
+
+		if c.expected != http.StatusUnauthorized && received == nil {
+			t.Errorf("WebhookMux.ServeHTTP did not dispatch the delivery")
+		}
+	}
+}
+
+func TestWebhookMuxHandleWebhookUnknownTopic(t *testing.T) {
+	mux := NewWebhookMux(app)
+	mux.HandleFunc("orders/updated", func(ctx context.Context, d *WebhookDelivery) error {
+		t.Errorf("WebhookMux dispatched a delivery for topic %s", d.Topic)
+		return nil
+	})
+
+	err := mux.HandleWebhook(context.Background(), &WebhookDelivery{Topic: "products/update"})
+	if err != nil {
+		t.Errorf("WebhookMux.HandleWebhook returned error: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package goshopify
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// UnknownWebhookPayloadError is returned decoding a delivery of a topic or API
+// version without registered payload struct
+type UnknownWebhookPayloadError struct {
+	Topic      string
+	ApiVersion string
+}
+
+func (e UnknownWebhookPayloadError) Error() string {
+	return fmt.Sprintf("no webhook payload registered for topic %s with api version %s", e.Topic, e.ApiVersion)
+}
+
+// WebhookApiVersionError is returned decoding a delivery sent with another API
+// version than the one the payloads are pinned to, e.g. after a subscription
+// was moved to a new version
+type WebhookApiVersionError struct {
+	ApiVersion string
+	Expected   string
+}
+
+func (e WebhookApiVersionError) Error() string {
+	return fmt.Sprintf("webhook sent with api version %s, expected %s", e.ApiVersion, e.Expected)
+}
+
+type webhookPayloadVersion struct {
+	since      string
+	newPayload func() interface{}
+}
+
+// WebhookPayloads maps webhook topics and API versions to the structs their
+// payloads are decoded into, so payload changes between versions don't go
+// unnoticed. Payload structs are registered for the API versions since the
+// given one up to the next registered version.
+type WebhookPayloads struct {
+	apiVersion string
+
+	mu       sync.RWMutex
+	payloads map[string][]webhookPayloadVersion
+}
+
+// NewWebhookPayloads returns payloads pinned to the given API version, the
+// version of the app's webhook subscriptions. An empty version accepts
+// deliveries of any version.
+func NewWebhookPayloads(apiVersion string) *WebhookPayloads {
+	return &WebhookPayloads{
+		apiVersion: apiVersion,
+		payloads:   map[string][]webhookPayloadVersion{},
+	}
+}
+
+// Register registers the payload struct of a topic for the API versions since
+// the given one, e.g. "2024-07". An empty version registers it for all
+// versions before the other registered ones. newPayload returns a pointer to
+// decode into.
+func (p *WebhookPayloads) Register(topic string, sinceApiVersion string, newPayload func() interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	versions := p.payloads[topic]
+	for i, v := range versions {
+		if v.since == sinceApiVersion {
+			versions[i].newPayload = newPayload
+			return
+		}
+	}
+	versions = append(versions, webhookPayloadVersion{since: sinceApiVersion, newPayload: newPayload})
+	// versions sort as strings, "unstable" after the dated ones
+	sort.Slice(versions, func(i, j int) bool { return versions[i].since < versions[j].since })
+	p.payloads[topic] = versions
+}
+
+// Decode decodes the payload of a delivery into the struct registered for its
+// topic and API version and returns it. It returns a WebhookApiVersionError
+// for deliveries of another version than the pinned one and an
+// UnknownWebhookPayloadError when no struct is registered.
+func (p *WebhookPayloads) Decode(delivery *WebhookDelivery) (interface{}, error) {
+	if p.apiVersion != "" && delivery.ApiVersion != p.apiVersion {
+		return nil, WebhookApiVersionError{ApiVersion: delivery.ApiVersion, Expected: p.apiVersion}
+	}
+
+	p.mu.RLock()
+	versions := p.payloads[delivery.Topic]
+	var newPayload func() interface{}
+	for _, v := range versions {
+		if v.since <= delivery.ApiVersion || v.since == "" {
+			newPayload = v.newPayload
+		}
+	}
+	p.mu.RUnlock()
+
+	if newPayload == nil {
+		return nil, UnknownWebhookPayloadError{Topic: delivery.Topic, ApiVersion: delivery.ApiVersion}
+	}
+
+	payload := newPayload()
+	if err := delivery.Decode(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// OrderEditPayload is the payload of orders/edited deliveries, which wraps
+// the edit rather than sending the order
+type OrderEditPayload struct {
+	OrderEdit OrderEditEvent `json:"order_edit"`
+}
+
+// OrderEditEvent is an edit of an order, with the quantities it added and
+// removed by line item
+type OrderEditEvent struct {
+	Id             uint64     `json:"id"`
+	AppId          uint64     `json:"app_id"`
+	CreatedAt      *time.Time `json:"created_at"`
+	NotifyCustomer bool       `json:"notify_customer"`
+	OrderId        uint64     `json:"order_id"`
+	StaffNote      string     `json:"staff_note"`
+	UserId         uint64     `json:"user_id"`
+	LineItems      struct {
+		Additions []OrderEditLineItemDelta `json:"additions"`
+		Removals  []OrderEditLineItemDelta `json:"removals"`
+	} `json:"line_items"`
+}
+
+// OrderEditLineItemDelta is the quantity of a line item added or removed by
+// an order edit
+type OrderEditLineItemDelta struct {
+	Id    uint64 `json:"id"`
+	Delta int    `json:"delta"`
+}
+
+// DefaultWebhookPayloads has the payload structs of the topics of common
+// resources, which are supersets tolerating the fields of all API versions.
+// Register replaces them or adds other topics.
+var DefaultWebhookPayloads = NewWebhookPayloads("")
+
+func init() {
+	topics := map[string]func() interface{}{
+		"orders":           func() interface{} { return new(Order) },
+		"products":         func() interface{} { return new(Product) },
+		"customers":        func() interface{} { return new(Customer) },
+		"draft_orders":     func() interface{} { return new(DraftOrder) },
+		"fulfillments":     func() interface{} { return new(Fulfillment) },
+		"collections":      func() interface{} { return new(Collection) },
+		"inventory_items":  func() interface{} { return new(InventoryItem) },
+		"inventory_levels": func() interface{} { return new(InventoryLevel) },
+		"locations":        func() interface{} { return new(Location) },
+		"themes":           func() interface{} { return new(Theme) },
+	}
+	events := map[string][]string{
+		"orders":           {"create", "updated", "paid", "cancelled", "fulfilled", "partially_fulfilled", "delete"},
+		"products":         {"create", "update", "delete"},
+		"customers":        {"create", "update", "delete", "enable", "disable"},
+		"draft_orders":     {"create", "update", "delete"},
+		"fulfillments":     {"create", "update"},
+		"collections":      {"create", "update", "delete"},
+		"inventory_items":  {"create", "update", "delete"},
+		"inventory_levels": {"connect", "update", "disconnect"},
+		"locations":        {"create", "update", "delete"},
+		"themes":           {"create", "update", "publish", "delete"},
+	}
+	for resource, newPayload := range topics {
+		for _, event := range events[resource] {
+			DefaultWebhookPayloads.Register(resource+"/"+event, "", newPayload)
+		}
+	}
+	DefaultWebhookPayloads.Register("orders/edited", "", func() interface{} { return new(OrderEditPayload) })
+	DefaultWebhookPayloads.Register("refunds/create", "", func() interface{} { return new(Refund) })
+	DefaultWebhookPayloads.Register("app/uninstalled", "", func() interface{} { return new(Shop) })
+	DefaultWebhookPayloads.Register("shop/update", "", func() interface{} { return new(Shop) })
+}
+
+// DecodePayload decodes the payload of the delivery into the struct
+// registered in DefaultWebhookPayloads for its topic, e.g. *Order for
+// orders/updated
+func (d *WebhookDelivery) DecodePayload() (interface{}, error) {
+	return DefaultWebhookPayloads.Decode(d)
+}
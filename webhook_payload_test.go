@@ -0,0 +1,105 @@
+package goshopify
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testOrderV2 struct {
+	Id   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestWebhookPayloadsDecode(t *testing.T) {
+	payloads := NewWebhookPayloads("")
+	payloads.Register("orders/updated", "", func() interface{} { return new(Order) })
+	payloads.Register("orders/updated", "2024-10", func() interface{} { return new(testOrderV2) })
+
+	cases := []struct {
+		apiVersion string
+		expected   interface{}
+	}{
+		{"2024-07", &Order{Id: 1, Name: "#1001"}},
+		{"2024-10", &testOrderV2{Id: 1, Name: "#1001"}},
+		{"2025-01", &testOrderV2{Id: 1, Name: "#1001"}},
+		{"unstable", &testOrderV2{Id: 1, Name: "#1001"}},
+	}
+	for _, c := range cases {
+		delivery := &WebhookDelivery{
+			Topic:      "orders/updated",
+			ApiVersion: c.apiVersion,
+			Body:       []byte(`{"id":1,"name":"#1001","new_field":true}`),
+		}
+		payload, err := payloads.Decode(delivery)
+		if err != nil {
+			t.Fatalf("WebhookPayloads.Decode(%s) returned error: %v", c.apiVersion, err)
+		}
+		if !reflect.DeepEqual(payload, c.expected) {
+			t.Errorf("WebhookPayloads.Decode(%s) returned %#v, expected %#v", c.apiVersion, payload, c.expected)
+		}
+	}
+}
+
+func TestWebhookPayloadsDecodeErrors(t *testing.T) {
+	payloads := NewWebhookPayloads("2024-07")
+	payloads.Register("orders/updated", "2024-07", func() interface{} { return new(Order) })
+
+	_, err := payloads.Decode(&WebhookDelivery{Topic: "orders/updated", ApiVersion: "2024-10", Body: []byte(`{}`)})
+	expectedVersionErr := WebhookApiVersionError{ApiVersion: "2024-10", Expected: "2024-07"}
+	if !reflect.DeepEqual(err, expectedVersionErr) {
+		t.Errorf("WebhookPayloads.Decode returned %#v, expected %#v", err, expectedVersionErr)
+	}
+
+	_, err = payloads.Decode(&WebhookDelivery{Topic: "carts/update", ApiVersion: "2024-07", Body: []byte(`{}`)})
+	expectedUnknownErr := UnknownWebhookPayloadError{Topic: "carts/update", ApiVersion: "2024-07"}
+	if !reflect.DeepEqual(err, expectedUnknownErr) {
+		t.Errorf("WebhookPayloads.Decode returned %#v, expected %#v", err, expectedUnknownErr)
+	}
+
+	// registered since a later version only
+	unpinned := NewWebhookPayloads("")
+	unpinned.Register("orders/updated", "2024-10", func() interface{} { return new(Order) })
+	_, err = unpinned.Decode(&WebhookDelivery{Topic: "orders/updated", ApiVersion: "2024-07", Body: []byte(`{}`)})
+	expectedUnknownErr = UnknownWebhookPayloadError{Topic: "orders/updated", ApiVersion: "2024-07"}
+	if !reflect.DeepEqual(err, expectedUnknownErr) {
+		t.Errorf("WebhookPayloads.Decode returned %#v, expected %#v", err, expectedUnknownErr)
+	}
+}
+
+func TestWebhookDeliveryDecodePayload(t *testing.T) {
+	delivery := &WebhookDelivery{
+		Topic:      "products/update",
+		ApiVersion: "2024-07",
+		Body:       []byte(`{"id":1,"title":"Shirt"}`),
+	}
+	payload, err := delivery.DecodePayload()
+	if err != nil {
+		t.Fatalf("WebhookDelivery.DecodePayload returned error: %v", err)
+	}
+	product, ok := payload.(*Product)
+	if !ok || product.Id != 1 || product.Title != "Shirt" {
+		t.Errorf("WebhookDelivery.DecodePayload returned %#v", payload)
+	}
+}
+
+func TestWebhookDeliveryDecodePayloadOrderEdit(t *testing.T) {
+	delivery := &WebhookDelivery{
+		Topic:      "orders/edited",
+		ApiVersion: "2024-07",
+		Body: []byte(`{"order_edit":{"id":78912328,"app_id":null,"created_at":"2024-03-01T10:00:00-05:00",
+			"notify_customer":false,"order_id":450789469,"staff_note":"","user_id":null,
+			"line_items":{"additions":[{"id":466157049,"delta":1}],"removals":[{"id":518995019,"delta":2}]}}}`),
+	}
+	payload, err := delivery.DecodePayload()
+	if err != nil {
+		t.Fatalf("WebhookDelivery.DecodePayload returned error: %v", err)
+	}
+	edit, ok := payload.(*OrderEditPayload)
+	if !ok || edit.OrderEdit.Id != 78912328 || edit.OrderEdit.OrderId != 450789469 {
+		t.Fatalf("WebhookDelivery.DecodePayload returned %#v", payload)
+	}
+	expected := []OrderEditLineItemDelta{{Id: 518995019, Delta: 2}}
+	if len(edit.OrderEdit.LineItems.Additions) != 1 || !reflect.DeepEqual(edit.OrderEdit.LineItems.Removals, expected) {
+		t.Errorf("WebhookDelivery.DecodePayload returned line items %+v", edit.OrderEdit.LineItems)
+	}
+}
@@ -0,0 +1,131 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// eventBridgeSourcePrefix is the source of the events of Shopify's
+// EventBridge partner event sources
+const eventBridgeSourcePrefix = "aws.partner/shopify.com"
+
+// ParseEventBridgeWebhook parses a webhook delivered through Amazon
+// EventBridge, e.g. the body of an SQS message routed there by an EventBridge
+// rule. Events not coming from a Shopify partner event source are rejected.
+// EventBridge deliveries carry no verifiable HMAC, they are authenticated by
+// AWS instead.
+func ParseEventBridgeWebhook(body []byte) (*WebhookDelivery, error) {
+	event := struct {
+		Source string `json:"source"`
+		Detail struct {
+			Payload  json.RawMessage   `json:"payload"`
+			Metadata map[string]string `json:"metadata"`
+		} `json:"detail"`
+	}{}
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("invalid EventBridge event: %w", err)
+	}
+
+	if !strings.HasPrefix(event.Source, eventBridgeSourcePrefix) {
+		return nil, fmt.Errorf("EventBridge event source %q is not Shopify", event.Source)
+	}
+
+	return newQueueWebhookDelivery(event.Detail.Metadata, event.Detail.Payload)
+}
+
+// ParsePubSubWebhook parses a webhook delivered through Google Cloud Pub/Sub
+// from the message data and attributes, as returned by the Pub/Sub client
+// libraries. Pub/Sub deliveries carry no verifiable HMAC, they are
+// authenticated by Google instead.
+func ParsePubSubWebhook(data []byte, attributes map[string]string) (*WebhookDelivery, error) {
+	return newQueueWebhookDelivery(attributes, data)
+}
+
+func newQueueWebhookDelivery(attributes map[string]string, body []byte) (*WebhookDelivery, error) {
+	header := make(http.Header, len(attributes))
+	for name, value := range attributes {
+		header.Set(name, value)
+	}
+
+	delivery := newWebhookDelivery(header, body)
+	if delivery.Topic == "" {
+		return nil, errors.New("webhook message has no topic")
+	}
+	return delivery, nil
+}
+
+// HandleEventBridgeMessage parses an EventBridge webhook, e.g. from the body
+// of an SQS message, and dispatches it, see ParseEventBridgeWebhook. Messages
+// should only be deleted from the queue when no error is returned.
+func (m *WebhookMux) HandleEventBridgeMessage(ctx context.Context, body []byte) error {
+	delivery, err := ParseEventBridgeWebhook(body)
+	if err != nil {
+		return err
+	}
+	return m.HandleWebhook(ctx, delivery)
+}
+
+// HandlePubSubMessage parses a Pub/Sub webhook and dispatches it, see
+// ParsePubSubWebhook.
+func (m *WebhookMux) HandlePubSubMessage(ctx context.Context, data []byte, attributes map[string]string) error {
+	delivery, err := ParsePubSubWebhook(data, attributes)
+	if err != nil {
+		return err
+	}
+	return m.HandleWebhook(ctx, delivery)
+}
+
+// pubSubPushRequest is the body Pub/Sub push subscriptions post
+type pubSubPushRequest struct {
+	Message struct {
+		Data       string            `json:"data"`
+		Attributes map[string]string `json:"attributes"`
+		MessageId  string            `json:"messageId"`
+	} `json:"message"`
+	Subscription string `json:"subscription"`
+}
+
+// PubSubPushHandler returns an http.Handler for Pub/Sub push subscriptions
+// dispatching the webhooks they deliver to handler, e.g. a WebhookMux.
+// Malformed messages are acknowledged with a 400 as redelivering them won't
+// help, handler errors get a 500 so that Pub/Sub redelivers the message.
+// Handler errors are logged to logger, or os.Stderr when it is nil, rather
+// than sent in the response. Authenticating the push requests, e.g. with an
+// OIDC token, is left to middleware.
+func PubSubPushHandler(handler WebhookHandler, logger LeveledLoggerInterface) http.Handler {
+	logger = webhookLogger(logger)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		push := pubSubPushRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			http.Error(w, "Invalid Pub/Sub message", http.StatusBadRequest)
+			return
+		}
+
+		data, err := base64.StdEncoding.DecodeString(push.Message.Data)
+		if err != nil {
+			http.Error(w, "Invalid Pub/Sub message data", http.StatusBadRequest)
+			return
+		}
+
+		delivery, err := ParsePubSubWebhook(data, push.Message.Attributes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = handler.HandleWebhook(r.Context(), delivery)
+		if err != nil {
+			logger.Errorf("%s webhook of Pub/Sub message %s failed: %v", delivery.Topic, push.Message.MessageId, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,160 @@
+package goshopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testEventBridgeEvent = `{
+	"version": "0",
+	"id": "1f8ba8c7-6ee0-0d5b-3b1a-6b2d9a5c3f41",
+	"detail-type": "shopifyWebhook",
+	"source": "aws.partner/shopify.com/1234/fooshop",
+	"account": "123456789012",
+	"time": "2024-01-01T10:00:00Z",
+	"region": "us-east-1",
+	"resources": [],
+	"detail": {
+		"payload": {"id": 450789469, "name": "#1001"},
+		"metadata": {
+			"Content-Type": "application/json",
+			"X-Shopify-Topic": "orders/updated",
+			"X-Shopify-Shop-Domain": "fooshop.myshopify.com",
+			"X-Shopify-API-Version": "2024-01",
+			"X-Shopify-Webhook-Id": "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+			"X-Shopify-Triggered-At": "2024-01-01T10:00:00Z"
+		}
+	}
+}`
+
+func TestParseEventBridgeWebhook(t *testing.T) {
+	delivery, err := ParseEventBridgeWebhook([]byte(testEventBridgeEvent))
+	if err != nil {
+		t.Fatalf("ParseEventBridgeWebhook returned error: %v", err)
+	}
+
+	if delivery.Topic != "orders/updated" || delivery.ShopDomain != "fooshop.myshopify.com" ||
+		delivery.ApiVersion != "2024-01" || delivery.WebhookId != "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043" {
+		t.Errorf("ParseEventBridgeWebhook returned %+v", delivery)
+	}
+	if delivery.TriggeredAt == nil || delivery.TriggeredAt.Hour() != 10 {
+		t.Errorf("ParseEventBridgeWebhook returned triggered at %v", delivery.TriggeredAt)
+	}
+
+	order := Order{}
+	if err := delivery.Decode(&order); err != nil || order.Id != 450789469 || order.Name != "#1001" {
+		t.Errorf("WebhookDelivery.Decode returned %+v, %v", order, err)
+	}
+}
+
+func TestParseEventBridgeWebhookInvalid(t *testing.T) {
+	cases := []string{
+		`not json`,
+		`{"source": "aws.events", "detail": {"metadata": {"X-Shopify-Topic": "orders/updated"}}}`,
+		`{"source": "aws.partner/shopify.com/1234/fooshop", "detail": {"payload": {}}}`,
+	}
+	for _, c := range cases {
+		if _, err := ParseEventBridgeWebhook([]byte(c)); err == nil {
+			t.Errorf("ParseEventBridgeWebhook(%s) returned no error", c)
+		}
+	}
+}
+
+func TestParsePubSubWebhook(t *testing.T) {
+	attributes := map[string]string{
+		"X-Shopify-Topic":       "orders/create",
+		"x-shopify-shop-domain": "fooshop.myshopify.com",
+	}
+
+	delivery, err := ParsePubSubWebhook([]byte(`{"id":1}`), attributes)
+	if err != nil {
+		t.Fatalf("ParsePubSubWebhook returned error: %v", err)
+	}
+	if delivery.Topic != "orders/create" || delivery.ShopDomain != "fooshop.myshopify.com" || string(delivery.Body) != `{"id":1}` {
+		t.Errorf("ParsePubSubWebhook returned %+v", delivery)
+	}
+}
+
+func TestWebhookMuxHandleEventBridgeMessage(t *testing.T) {
+	var received *WebhookDelivery
+	mux := NewWebhookMux(app)
+	mux.HandleFunc("orders/updated", func(ctx context.Context, d *WebhookDelivery) error {
+		received = d
+		return nil
+	})
+
+	err := mux.HandleEventBridgeMessage(context.Background(), []byte(testEventBridgeEvent))
+	if err != nil || received == nil {
+		t.Errorf("WebhookMux.HandleEventBridgeMessage returned %v, dispatched %v", err, received)
+	}
+
+	err = mux.HandleEventBridgeMessage(context.Background(), []byte(`{}`))
+	if err == nil {
+		t.Errorf("WebhookMux.HandleEventBridgeMessage returned no error for an invalid message")
+	}
+}
+
+func TestWebhookMuxHandlePubSubMessage(t *testing.T) {
+	var received *WebhookDelivery
+	mux := NewWebhookMux(app)
+	mux.HandleFunc("orders/create", func(ctx context.Context, d *WebhookDelivery) error {
+		received = d
+		return nil
+	})
+
+	err := mux.HandlePubSubMessage(context.Background(), []byte(`{"id":1}`), map[string]string{WebhookTopicHeader: "orders/create"})
+	if err != nil || received == nil {
+		t.Errorf("WebhookMux.HandlePubSubMessage returned %v, dispatched %v", err, received)
+	}
+}
+
+func TestPubSubPushHandler(t *testing.T) {
+	handlerErr := errors.New("boom")
+	data := base64.StdEncoding.EncodeToString([]byte(`{"id":1}`))
+	validBody := `{"message":{"data":"` + data + `","attributes":{"X-Shopify-Topic":"orders/create"},"messageId":"1"},"subscription":"projects/foo/subscriptions/bar"}`
+
+	cases := []struct {
+		body     string
+		err      error
+		expected int
+	}{
+		{validBody, nil, http.StatusOK},
+		{validBody, handlerErr, http.StatusInternalServerError},
+		{`not json`, nil, http.StatusBadRequest},
+		{`{"message":{"data":"!!","attributes":{"X-Shopify-Topic":"orders/create"}}}`, nil, http.StatusBadRequest},
+		{`{"message":{"data":"` + data + `"}}`, nil, http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		var received *WebhookDelivery
+		mux := NewWebhookMux(app)
+		mux.HandleFunc("orders/create", func(ctx context.Context, d *WebhookDelivery) error {
+			received = d
+			return c.err
+		})
+
+		rec := httptest.NewRecorder()
+		logs := &bytes.Buffer{}
+		handler := PubSubPushHandler(mux, &LeveledLogger{Level: LevelError, stderrOverride: logs})
+		handler.ServeHTTP(rec, httptest.NewRequest("POST", "/pubsub", bytes.NewBufferString(c.body)))
+
+		if rec.Code != c.expected {
+			t.Errorf("PubSubPushHandler responded %d for %s, expected %d", rec.Code, c.body, c.expected)
+		}
+		if strings.Contains(rec.Body.String(), "boom") {
+			t.Errorf("PubSubPushHandler responded with the handler error %q", rec.Body)
+		}
+		if c.err != nil && !strings.Contains(logs.String(), "boom") {
+			t.Errorf("PubSubPushHandler logged %q, expected the handler error", logs)
+		}
+		if c.expected != http.StatusBadRequest && (received == nil || string(received.Body) != `{"id":1}`) {
+			t.Errorf("PubSubPushHandler dispatched %+v", received)
+		}
+	}
+}
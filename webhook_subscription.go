@@ -0,0 +1,249 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const webhookSubscriptionFields = `
+      id
+      topic
+      format
+      includeFields
+      metafieldNamespaces
+      filter
+      createdAt
+      updatedAt
+      apiVersion {
+        handle
+      }
+      endpoint {
+        __typename
+        ... on WebhookHttpEndpoint {
+          callbackUrl
+        }
+      }`
+
+const webhookSubscriptionCreateMutation = `mutation webhookSubscriptionCreate($topic: WebhookSubscriptionTopic!, $webhookSubscription: WebhookSubscriptionInput!) {
+  webhookSubscriptionCreate(topic: $topic, webhookSubscription: $webhookSubscription) {
+    webhookSubscription {` + webhookSubscriptionFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const webhookSubscriptionUpdateMutation = `mutation webhookSubscriptionUpdate($id: ID!, $webhookSubscription: WebhookSubscriptionInput!) {
+  webhookSubscriptionUpdate(id: $id, webhookSubscription: $webhookSubscription) {
+    webhookSubscription {` + webhookSubscriptionFields + `
+    }
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const webhookSubscriptionDeleteMutation = `mutation webhookSubscriptionDelete($id: ID!) {
+  webhookSubscriptionDelete(id: $id) {
+    deletedWebhookSubscriptionId
+    userErrors {
+      field
+      message
+    }
+  }
+}`
+
+const webhookSubscriptionsQuery = `query webhookSubscriptions($topics: [WebhookSubscriptionTopic!], $after: String) {
+  webhookSubscriptions(first: 100, topics: $topics, after: $after) {
+    nodes {` + webhookSubscriptionFields + `
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+// WebhookSubscription is a webhook subscription managed through the GraphQL
+// API. Unlike REST webhooks, IncludeFields and MetafieldNamespaces shrink the
+// payloads of every topic and Filter only delivers matching events, e.g.
+// "total_price:>=100".
+type WebhookSubscription struct {
+	Id                  uint64
+	Topic               string
+	CallbackUrl         string
+	Format              string
+	IncludeFields       []string
+	MetafieldNamespaces []string
+	Filter              string
+	ApiVersion          string
+	CreatedAt           *time.Time
+	UpdatedAt           *time.Time
+}
+
+// WebhookSubscriptionTopic returns the GraphQL topic of a REST webhook topic,
+// e.g. ORDERS_CREATE for orders/create. GraphQL topics are returned as is.
+func WebhookSubscriptionTopic(topic string) string {
+	return strings.ToUpper(strings.NewReplacer("/", "_", ".", "_").Replace(topic))
+}
+
+type graphQLWebhookSubscription struct {
+	Id                  string     `json:"id"`
+	Topic               string     `json:"topic"`
+	Format              string     `json:"format"`
+	IncludeFields       []string   `json:"includeFields"`
+	MetafieldNamespaces []string   `json:"metafieldNamespaces"`
+	Filter              string     `json:"filter"`
+	CreatedAt           *time.Time `json:"createdAt"`
+	UpdatedAt           *time.Time `json:"updatedAt"`
+	ApiVersion          struct {
+		Handle string `json:"handle"`
+	} `json:"apiVersion"`
+	Endpoint struct {
+		CallbackUrl string `json:"callbackUrl"`
+	} `json:"endpoint"`
+}
+
+func (g graphQLWebhookSubscription) subscription() (WebhookSubscription, error) {
+	id, err := ParseGraphQLId(g.Id)
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	return WebhookSubscription{
+		Id:                  id,
+		Topic:               g.Topic,
+		CallbackUrl:         g.Endpoint.CallbackUrl,
+		Format:              strings.ToLower(g.Format),
+		IncludeFields:       g.IncludeFields,
+		MetafieldNamespaces: g.MetafieldNamespaces,
+		Filter:              g.Filter,
+		ApiVersion:          g.ApiVersion.Handle,
+		CreatedAt:           g.CreatedAt,
+		UpdatedAt:           g.UpdatedAt,
+	}, nil
+}
+
+func (w WebhookSubscription) input() map[string]interface{} {
+	input := map[string]interface{}{
+		"callbackUrl":         w.CallbackUrl,
+		"includeFields":       w.IncludeFields,
+		"metafieldNamespaces": w.MetafieldNamespaces,
+	}
+	if w.Format != "" {
+		input["format"] = strings.ToUpper(w.Format)
+	}
+	if w.Filter != "" {
+		input["filter"] = w.Filter
+	}
+	return input
+}
+
+type webhookSubscriptionPayload struct {
+	WebhookSubscription *graphQLWebhookSubscription `json:"webhookSubscription"`
+	UserErrors          GraphQLUserErrors           `json:"userErrors"`
+}
+
+func (p webhookSubscriptionPayload) subscription() (*WebhookSubscription, error) {
+	if len(p.UserErrors) > 0 {
+		return nil, p.UserErrors
+	}
+	if p.WebhookSubscription == nil {
+		return nil, errors.New("no webhook subscription returned")
+	}
+	subscription, err := p.WebhookSubscription.subscription()
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// CreateSubscription creates a webhook subscription through the GraphQL
+// webhookSubscriptionCreate mutation. The topic can be a REST topic, e.g.
+// orders/create, or a GraphQL one, e.g. ORDERS_CREATE.
+func (s *WebhookServiceOp) CreateSubscription(ctx context.Context, subscription WebhookSubscription) (*WebhookSubscription, error) {
+	if subscription.Topic == "" || subscription.CallbackUrl == "" {
+		return nil, errors.New("webhook subscription: topic and callback url are required")
+	}
+
+	resp := struct {
+		WebhookSubscriptionCreate webhookSubscriptionPayload `json:"webhookSubscriptionCreate"`
+	}{}
+	vars := map[string]interface{}{
+		"topic":               WebhookSubscriptionTopic(subscription.Topic),
+		"webhookSubscription": subscription.input(),
+	}
+	err := s.client.GraphQL.Query(ctx, webhookSubscriptionCreateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.WebhookSubscriptionCreate.subscription()
+}
+
+// UpdateSubscription updates the callback url, format and filters of a
+// webhook subscription, its topic can't be changed
+func (s *WebhookServiceOp) UpdateSubscription(ctx context.Context, subscription WebhookSubscription) (*WebhookSubscription, error) {
+	resp := struct {
+		WebhookSubscriptionUpdate webhookSubscriptionPayload `json:"webhookSubscriptionUpdate"`
+	}{}
+	vars := map[string]interface{}{
+		"id":                  GraphQLId("WebhookSubscription", subscription.Id),
+		"webhookSubscription": subscription.input(),
+	}
+	err := s.client.GraphQL.Query(ctx, webhookSubscriptionUpdateMutation, vars, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.WebhookSubscriptionUpdate.subscription()
+}
+
+// DeleteSubscription deletes a webhook subscription
+func (s *WebhookServiceOp) DeleteSubscription(ctx context.Context, id uint64) error {
+	resp := struct {
+		WebhookSubscriptionDelete struct {
+			UserErrors GraphQLUserErrors `json:"userErrors"`
+		} `json:"webhookSubscriptionDelete"`
+	}{}
+	vars := map[string]interface{}{"id": GraphQLId("WebhookSubscription", id)}
+	err := s.client.GraphQL.Query(ctx, webhookSubscriptionDeleteMutation, vars, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.WebhookSubscriptionDelete.UserErrors) > 0 {
+		return resp.WebhookSubscriptionDelete.UserErrors
+	}
+	return nil
+}
+
+// ListSubscriptions lists the webhook subscriptions of the app, of the given
+// topics if any
+func (s *WebhookServiceOp) ListSubscriptions(ctx context.Context, topics ...string) ([]WebhookSubscription, error) {
+	vars := map[string]interface{}{}
+	if len(topics) > 0 {
+		graphQLTopics := make([]string, 0, len(topics))
+		for _, topic := range topics {
+			graphQLTopics = append(graphQLTopics, WebhookSubscriptionTopic(topic))
+		}
+		vars["topics"] = graphQLTopics
+	}
+
+	var subscriptions []WebhookSubscription
+	err := s.client.GraphQLEachNode(ctx, webhookSubscriptionsQuery, vars, "webhookSubscriptions", func(node json.RawMessage) error {
+		var g graphQLWebhookSubscription
+		if err := json.Unmarshal(node, &g); err != nil {
+			return err
+		}
+		subscription, err := g.subscription()
+		if err != nil {
+			return err
+		}
+		subscriptions = append(subscriptions, subscription)
+		return nil
+	})
+	return subscriptions, err
+}
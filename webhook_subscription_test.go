@@ -0,0 +1,125 @@
+package goshopify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+const webhookSubscriptionNode = `{
+	"id":"gid://shopify/WebhookSubscription/4759306",
+	"topic":"ORDERS_CREATE",
+	"format":"JSON",
+	"includeFields":["id","total_price"],
+	"metafieldNamespaces":["erp"],
+	"filter":"total_price:>=100",
+	"createdAt":"2024-01-02T03:04:05Z",
+	"updatedAt":"2024-01-02T03:04:05Z",
+	"apiVersion":{"handle":"2024-01"},
+	"endpoint":{"__typename":"WebhookHttpEndpoint","callbackUrl":"https://example.com/webhooks"}
+}`
+
+func TestWebhookSubscriptionTopic(t *testing.T) {
+	cases := map[string]string{
+		"orders/create":               "ORDERS_CREATE",
+		"orders/partially_fulfilled":  "ORDERS_PARTIALLY_FULFILLED",
+		"app_subscriptions/update":    "APP_SUBSCRIPTIONS_UPDATE",
+		"ORDERS_CREATE":               "ORDERS_CREATE",
+		"bulk_operations/finish":      "BULK_OPERATIONS_FINISH",
+		"customers.marketing_consent": "CUSTOMERS_MARKETING_CONSENT",
+	}
+	for in, expected := range cases {
+		if actual := WebhookSubscriptionTopic(in); actual != expected {
+			t.Errorf("WebhookSubscriptionTopic(%s) = %s, expected %s", in, actual, expected)
+		}
+	}
+}
+
+func TestWebhookCreateSubscription(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		webhookSubscriptionCreateMutation: `{"data":{"webhookSubscriptionCreate":{"webhookSubscription":` + webhookSubscriptionNode + `,"userErrors":[]}}}`,
+	}))
+
+	subscription, err := client.Webhook.CreateSubscription(context.Background(), WebhookSubscription{
+		Topic:               "orders/create",
+		CallbackUrl:         "https://example.com/webhooks",
+		Format:              "json",
+		IncludeFields:       []string{"id", "total_price"},
+		MetafieldNamespaces: []string{"erp"},
+		Filter:              "total_price:>=100",
+	})
+	if err != nil {
+		t.Fatalf("Webhook.CreateSubscription returned error: %v", err)
+	}
+	if subscription.Id != 4759306 || subscription.Topic != "ORDERS_CREATE" || subscription.CallbackUrl != "https://example.com/webhooks" ||
+		subscription.Format != "json" || subscription.ApiVersion != "2024-01" || !reflect.DeepEqual(subscription.IncludeFields, []string{"id", "total_price"}) {
+		t.Errorf("Webhook.CreateSubscription returned %+v", subscription)
+	}
+
+	expected := map[string]interface{}{
+		"topic": "ORDERS_CREATE",
+		"webhookSubscription": map[string]interface{}{
+			"callbackUrl":         "https://example.com/webhooks",
+			"format":              "JSON",
+			"includeFields":       []interface{}{"id", "total_price"},
+			"metafieldNamespaces": []interface{}{"erp"},
+			"filter":              "total_price:>=100",
+		},
+	}
+	if !reflect.DeepEqual(sent[0].Variables, expected) {
+		t.Errorf("Webhook.CreateSubscription sent variables %v, expected %v", sent[0].Variables, expected)
+	}
+
+	if _, err := client.Webhook.CreateSubscription(context.Background(), WebhookSubscription{Topic: "orders/create"}); err == nil {
+		t.Error("Webhook.CreateSubscription accepted a subscription without callback url")
+	}
+}
+
+func TestWebhookUpdateDeleteSubscription(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []graphQLRequest
+	registerGraphQL(t, &sent, graphQLQueryResponses(t, map[string]string{
+		webhookSubscriptionUpdateMutation: `{"data":{"webhookSubscriptionUpdate":{"webhookSubscription":null,"userErrors":[{"field":["webhookSubscription","filter"],"message":"Filter is invalid"}]}}}`,
+		webhookSubscriptionDeleteMutation: `{"data":{"webhookSubscriptionDelete":{"deletedWebhookSubscriptionId":"gid://shopify/WebhookSubscription/4759306","userErrors":[]}}}`,
+	}))
+
+	_, err := client.Webhook.UpdateSubscription(context.Background(), WebhookSubscription{Id: 4759306, CallbackUrl: "https://example.com/webhooks", Filter: "total_price:"})
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("Webhook.UpdateSubscription returned %v, expected user errors", err)
+	}
+	if sent[0].Variables["id"] != "gid://shopify/WebhookSubscription/4759306" {
+		t.Errorf("Webhook.UpdateSubscription sent variables %v", sent[0].Variables)
+	}
+
+	if err := client.Webhook.DeleteSubscription(context.Background(), 4759306); err != nil {
+		t.Errorf("Webhook.DeleteSubscription returned error: %v", err)
+	}
+}
+
+func TestWebhookListSubscriptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var vars map[string]interface{}
+	registerGraphQL(t, nil, func(req graphQLRequest) string {
+		vars = req.Variables
+		return `{"data":{"webhookSubscriptions":{"nodes":[` + webhookSubscriptionNode + `],"pageInfo":{"hasNextPage":false}}}}`
+	})
+
+	subscriptions, err := client.Webhook.ListSubscriptions(context.Background(), "orders/create")
+	if err != nil {
+		t.Fatalf("Webhook.ListSubscriptions returned error: %v", err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].Filter != "total_price:>=100" {
+		t.Errorf("Webhook.ListSubscriptions returned %+v", subscriptions)
+	}
+	if !reflect.DeepEqual(vars["topics"], []interface{}{"ORDERS_CREATE"}) {
+		t.Errorf("Webhook.ListSubscriptions sent variables %v", vars)
+	}
+}